@@ -0,0 +1,149 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Capacity commitment plans.
+const (
+	// CommitmentPlanFlex commitments have a committed period of 1 minute.
+	CommitmentPlanFlex = "FLEX"
+	// CommitmentPlanTrial commitments have a committed period of 182 days.
+	CommitmentPlanTrial = "TRIAL"
+	// CommitmentPlanMonthly commitments have a committed period of 30 days.
+	CommitmentPlanMonthly = "MONTHLY"
+	// CommitmentPlanAnnual commitments have a committed period of 365 days.
+	CommitmentPlanAnnual = "ANNUAL"
+)
+
+// Capacity commitment states.
+const (
+	// CommitmentStatePending means the slots of the commitment are being
+	// provisioned.
+	CommitmentStatePending = "PENDING"
+	// CommitmentStateActive means the slots of the commitment are available
+	// to the reservations of its admin project.
+	CommitmentStateActive = "ACTIVE"
+	// CommitmentStateFailed means the slots of the commitment could not be
+	// provisioned.
+	CommitmentStateFailed = "FAILED"
+)
+
+// CapacityCommitmentParameters defines parameters for a desired BigQuery
+// capacity commitment
+// https://cloud.google.com/bigquery/docs/reference/reservations/rest/v1/projects.locations.capacityCommitments
+// The ID of the commitment (ie the `capacityCommitmentId` parameter of the
+// Create call) is determined by the value of the `crossplane.io/external-name`
+// annotation. Unless overridden by the user, this annotation is automatically
+// populated with the value of the `metadata.name` attribute.
+// NOTE: A capacity commitment can not be deleted before its committed period
+// ends. Deleting the managed resource is retried until it does.
+type CapacityCommitmentParameters struct {
+	// Location: The location of the capacity commitment, e.g. US or
+	// europe-west3.
+	// +immutable
+	Location string `json:"location"`
+
+	// SlotCount: Number of slots in this commitment. The slot count of a
+	// commitment can not be changed once it is created; commitments must be
+	// split or merged instead.
+	// +immutable
+	// +kubebuilder:validation:Minimum=1
+	SlotCount int64 `json:"slotCount"`
+
+	// Plan: The commitment plan. The plan of a commitment can only be
+	// changed to a plan with a longer committed period, which restarts the
+	// committed period. TRIAL commitments can not be created.
+	// +kubebuilder:validation:Enum=FLEX;MONTHLY;ANNUAL
+	Plan string `json:"plan"`
+
+	// RenewalPlan: The plan this capacity commitment is converted to after
+	// its committed period ends. Only applicable for ANNUAL and TRIAL
+	// commitments.
+	// +optional
+	// +kubebuilder:validation:Enum=FLEX;MONTHLY;ANNUAL
+	RenewalPlan *string `json:"renewalPlan,omitempty"`
+}
+
+// CapacityCommitmentObservation is used to show the observed state of the
+// CapacityCommitment resource on GCP.
+type CapacityCommitmentObservation struct {
+	// CommitmentEndTime: Output only. The end of the current committed
+	// period. It is applicable only for ACTIVE capacity commitments.
+	CommitmentEndTime string `json:"commitmentEndTime,omitempty"`
+
+	// CommitmentStartTime: Output only. The start of the current committed
+	// period. It is applicable only for ACTIVE capacity commitments.
+	CommitmentStartTime string `json:"commitmentStartTime,omitempty"`
+
+	// FailureReason: Output only. For FAILED commitments, the reason of the
+	// failure.
+	FailureReason string `json:"failureReason,omitempty"`
+
+	// Name: Output only. The resource name of the capacity commitment in
+	// the format `projects/*/locations/*/capacityCommitments/*`.
+	Name string `json:"name,omitempty"`
+
+	// State: Output only. State of the commitment, i.e. PENDING, ACTIVE or
+	// FAILED.
+	State string `json:"state,omitempty"`
+}
+
+// CapacityCommitmentSpec defines the desired state of a CapacityCommitment.
+type CapacityCommitmentSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       CapacityCommitmentParameters `json:"forProvider"`
+}
+
+// CapacityCommitmentStatus represents the observed state of a
+// CapacityCommitment.
+type CapacityCommitmentStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          CapacityCommitmentObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CapacityCommitment is a managed resource that represents a Google BigQuery
+// capacity commitment, i.e. slots purchased for a committed period.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="SLOTS",type="integer",JSONPath=".spec.forProvider.slotCount"
+// +kubebuilder:printcolumn:name="PLAN",type="string",JSONPath=".spec.forProvider.plan"
+// +kubebuilder:printcolumn:name="END",type="string",JSONPath=".status.atProvider.commitmentEndTime"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type CapacityCommitment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CapacityCommitmentSpec   `json:"spec"`
+	Status CapacityCommitmentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CapacityCommitmentList contains a list of CapacityCommitment types
+type CapacityCommitmentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CapacityCommitment `json:"items"`
+}
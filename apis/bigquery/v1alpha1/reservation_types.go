@@ -0,0 +1,103 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// ReservationParameters defines parameters for a desired BigQuery reservation
+// https://cloud.google.com/bigquery/docs/reference/reservations/rest/v1/projects.locations.reservations
+// The name of the reservation (ie the `reservationId` parameter of the Create
+// call) is determined by the value of the `crossplane.io/external-name`
+// annotation. Unless overridden by the user, this annotation is automatically
+// populated with the value of the `metadata.name` attribute.
+// NOTE: Editions and autoscaling can not be configured by the API version
+// this provider uses.
+type ReservationParameters struct {
+	// Location: The location of the reservation, e.g. US or europe-west3.
+	// +immutable
+	Location string `json:"location"`
+
+	// SlotCapacity: Minimum slots available to this reservation. The
+	// reservation can not be created or updated if the slot capacity of
+	// the reservation and its siblings would exceed the slots committed to
+	// the admin project.
+	// +kubebuilder:validation:Minimum=0
+	SlotCapacity int64 `json:"slotCapacity"`
+
+	// IgnoreIdleSlots: If false, any query using this reservation will use
+	// idle slots from other reservations within the same admin project. If
+	// true, a query using this reservation will execute with the slot
+	// capacity of the reservation at most.
+	// +optional
+	IgnoreIdleSlots *bool `json:"ignoreIdleSlots,omitempty"`
+}
+
+// ReservationObservation is used to show the observed state of the
+// Reservation resource on GCP.
+type ReservationObservation struct {
+	// CreationTime: Output only. Creation time of the reservation.
+	CreationTime string `json:"creationTime,omitempty"`
+
+	// Name: Output only. The resource name of the reservation in the format
+	// `projects/*/locations/*/reservations/*`.
+	Name string `json:"name,omitempty"`
+
+	// UpdateTime: Output only. Last update time of the reservation.
+	UpdateTime string `json:"updateTime,omitempty"`
+}
+
+// ReservationSpec defines the desired state of a Reservation.
+type ReservationSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ReservationParameters `json:"forProvider"`
+}
+
+// ReservationStatus represents the observed state of a Reservation.
+type ReservationStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ReservationObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// Reservation is a managed resource that represents a Google BigQuery
+// reservation, which guarantees slots to the projects assigned to it.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="SLOTS",type="integer",JSONPath=".spec.forProvider.slotCapacity"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Reservation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ReservationSpec   `json:"spec"`
+	Status ReservationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ReservationList contains a list of Reservation types
+type ReservationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Reservation `json:"items"`
+}
@@ -0,0 +1,410 @@
+// +build !ignore_autogenerated
+
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityCommitment) DeepCopyInto(out *CapacityCommitment) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityCommitment.
+func (in *CapacityCommitment) DeepCopy() *CapacityCommitment {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityCommitment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CapacityCommitment) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityCommitmentList) DeepCopyInto(out *CapacityCommitmentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CapacityCommitment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityCommitmentList.
+func (in *CapacityCommitmentList) DeepCopy() *CapacityCommitmentList {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityCommitmentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CapacityCommitmentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityCommitmentObservation) DeepCopyInto(out *CapacityCommitmentObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityCommitmentObservation.
+func (in *CapacityCommitmentObservation) DeepCopy() *CapacityCommitmentObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityCommitmentObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityCommitmentParameters) DeepCopyInto(out *CapacityCommitmentParameters) {
+	*out = *in
+	if in.RenewalPlan != nil {
+		in, out := &in.RenewalPlan, &out.RenewalPlan
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityCommitmentParameters.
+func (in *CapacityCommitmentParameters) DeepCopy() *CapacityCommitmentParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityCommitmentParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityCommitmentSpec) DeepCopyInto(out *CapacityCommitmentSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityCommitmentSpec.
+func (in *CapacityCommitmentSpec) DeepCopy() *CapacityCommitmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityCommitmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityCommitmentStatus) DeepCopyInto(out *CapacityCommitmentStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityCommitmentStatus.
+func (in *CapacityCommitmentStatus) DeepCopy() *CapacityCommitmentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityCommitmentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Reservation) DeepCopyInto(out *Reservation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Reservation.
+func (in *Reservation) DeepCopy() *Reservation {
+	if in == nil {
+		return nil
+	}
+	out := new(Reservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Reservation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservationList) DeepCopyInto(out *ReservationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Reservation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservationList.
+func (in *ReservationList) DeepCopy() *ReservationList {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReservationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservationObservation) DeepCopyInto(out *ReservationObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservationObservation.
+func (in *ReservationObservation) DeepCopy() *ReservationObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservationObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservationParameters) DeepCopyInto(out *ReservationParameters) {
+	*out = *in
+	if in.IgnoreIdleSlots != nil {
+		in, out := &in.IgnoreIdleSlots, &out.IgnoreIdleSlots
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservationParameters.
+func (in *ReservationParameters) DeepCopy() *ReservationParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservationParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservationSpec) DeepCopyInto(out *ReservationSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservationSpec.
+func (in *ReservationSpec) DeepCopy() *ReservationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReservationStatus) DeepCopyInto(out *ReservationStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReservationStatus.
+func (in *ReservationStatus) DeepCopy() *ReservationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReservationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TablePolicyMember) DeepCopyInto(out *TablePolicyMember) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TablePolicyMember.
+func (in *TablePolicyMember) DeepCopy() *TablePolicyMember {
+	if in == nil {
+		return nil
+	}
+	out := new(TablePolicyMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TablePolicyMember) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TablePolicyMemberList) DeepCopyInto(out *TablePolicyMemberList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TablePolicyMember, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TablePolicyMemberList.
+func (in *TablePolicyMemberList) DeepCopy() *TablePolicyMemberList {
+	if in == nil {
+		return nil
+	}
+	out := new(TablePolicyMemberList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TablePolicyMemberList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TablePolicyMemberParameters) DeepCopyInto(out *TablePolicyMemberParameters) {
+	*out = *in
+	if in.Member != nil {
+		in, out := &in.Member, &out.Member
+		*out = new(string)
+		**out = **in
+	}
+	if in.ServiceAccountMemberRef != nil {
+		in, out := &in.ServiceAccountMemberRef, &out.ServiceAccountMemberRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.ServiceAccountMemberSelector != nil {
+		in, out := &in.ServiceAccountMemberSelector, &out.ServiceAccountMemberSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Condition != nil {
+		in, out := &in.Condition, &out.Condition
+		*out = new(v1alpha1.Expr)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TablePolicyMemberParameters.
+func (in *TablePolicyMemberParameters) DeepCopy() *TablePolicyMemberParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(TablePolicyMemberParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TablePolicyMemberSpec) DeepCopyInto(out *TablePolicyMemberSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TablePolicyMemberSpec.
+func (in *TablePolicyMemberSpec) DeepCopy() *TablePolicyMemberSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TablePolicyMemberSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TablePolicyMemberStatus) DeepCopyInto(out *TablePolicyMemberStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TablePolicyMemberStatus.
+func (in *TablePolicyMemberStatus) DeepCopy() *TablePolicyMemberStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TablePolicyMemberStatus)
+	in.DeepCopyInto(out)
+	return out
+}
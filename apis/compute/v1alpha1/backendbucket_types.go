@@ -0,0 +1,178 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// BackendBucketParameters define the desired state of a Google Compute
+// Engine backend bucket, which serves a Cloud Storage bucket through an HTTP(S)
+// load balancer:
+// https://cloud.google.com/compute/docs/reference/rest/v1/backendBuckets
+type BackendBucketParameters struct {
+	// BucketName: The name of the Cloud Storage bucket.
+	// +optional
+	BucketName *string `json:"bucketName,omitempty"`
+
+	// BucketNameRef references a Bucket to retrieve its name.
+	// +optional
+	BucketNameRef *xpv1.Reference `json:"bucketNameRef,omitempty"`
+
+	// BucketNameSelector selects a reference to a Bucket to retrieve its
+	// name.
+	// +optional
+	BucketNameSelector *xpv1.Selector `json:"bucketNameSelector,omitempty"`
+
+	// Description: An optional textual description of the resource.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// EnableCDN: If true, enable Cloud CDN for this backend bucket.
+	// +optional
+	EnableCDN *bool `json:"enableCdn,omitempty"`
+
+	// CDNPolicy: Cloud CDN configuration for this backend bucket.
+	// +optional
+	CDNPolicy *BackendBucketCDNPolicy `json:"cdnPolicy,omitempty"`
+
+	// SignedURLKeys: The keys used to sign Cloud CDN URLs for this backend
+	// bucket. Keys are identified by their name; GCP never reveals the value
+	// of a key once it has been added, so a key is rotated by replacing it
+	// with a key of a different name.
+	// +optional
+	SignedURLKeys []SignedURLKey `json:"signedUrlKeys,omitempty"`
+}
+
+// BackendBucketCDNPolicy is the Cloud CDN configuration of a backend bucket.
+type BackendBucketCDNPolicy struct {
+	// CacheMode: Specifies the cache setting for all responses from this
+	// backend.
+	// +optional
+	// +kubebuilder:validation:Enum=USE_ORIGIN_HEADERS;FORCE_CACHE_ALL;CACHE_ALL_STATIC
+	CacheMode *string `json:"cacheMode,omitempty"`
+
+	// ClientTTL: Specifies a separate client (e.g. browser client) maximum
+	// TTL in seconds.
+	// +optional
+	ClientTTL *int64 `json:"clientTtl,omitempty"`
+
+	// DefaultTTL: Specifies the default TTL in seconds for cached content
+	// served by this origin for responses that do not have an existing valid
+	// TTL.
+	// +optional
+	DefaultTTL *int64 `json:"defaultTtl,omitempty"`
+
+	// MaxTTL: Specifies the maximum allowed TTL in seconds for cached
+	// content served by this origin.
+	// +optional
+	MaxTTL *int64 `json:"maxTtl,omitempty"`
+
+	// NegativeCaching: Negative caching allows per-status code TTLs to be
+	// set, in order to apply fine-grained caching for common errors or
+	// redirects.
+	// +optional
+	NegativeCaching *bool `json:"negativeCaching,omitempty"`
+
+	// RequestCoalescing: If true then Cloud CDN will combine multiple
+	// concurrent cache fill requests into a small number of requests to the
+	// origin.
+	// +optional
+	RequestCoalescing *bool `json:"requestCoalescing,omitempty"`
+
+	// ServeWhileStale: Serve existing content from the cache (if available)
+	// when revalidating content with the origin, or when an error is
+	// encountered when refreshing the cache.
+	// +optional
+	ServeWhileStale *int64 `json:"serveWhileStale,omitempty"`
+
+	// SignedURLCacheMaxAgeSec: Maximum number of seconds the response to a
+	// signed URL request will be considered fresh.
+	// +optional
+	SignedURLCacheMaxAgeSec *int64 `json:"signedUrlCacheMaxAgeSec,omitempty"`
+}
+
+// A SignedURLKey is a key used to sign Cloud CDN URLs.
+type SignedURLKey struct {
+	// KeyName: Name of the key. The name must be 1-63 characters long, and
+	// comply with RFC1035.
+	// +kubebuilder:validation:Pattern=`^[a-z]([-a-z0-9]*[a-z0-9])?$`
+	KeyName string `json:"keyName"`
+
+	// KeyValueSecretRef references the secret key that contains the 128-bit
+	// key value used for signing the URL, encoded as RFC 4648 Section 5
+	// base64url.
+	KeyValueSecretRef xpv1.SecretKeySelector `json:"keyValueSecretRef"`
+}
+
+// A BackendBucketObservation represents the observed state of a Google
+// Compute Engine backend bucket.
+type BackendBucketObservation struct {
+	// CreationTimestamp: Creation timestamp in RFC3339 text format.
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+
+	// ID: The unique identifier for the resource.
+	ID uint64 `json:"id,omitempty"`
+
+	// SelfLink: Server-defined URL for the resource.
+	SelfLink string `json:"selfLink,omitempty"`
+
+	// SignedURLKeyNames: The names of the keys that are currently active for
+	// signing Cloud CDN URLs.
+	SignedURLKeyNames []string `json:"signedUrlKeyNames,omitempty"`
+}
+
+// A BackendBucketSpec defines the desired state of a BackendBucket.
+type BackendBucketSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       BackendBucketParameters `json:"forProvider"`
+}
+
+// A BackendBucketStatus represents the observed state of a BackendBucket.
+type BackendBucketStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          BackendBucketObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A BackendBucket is a managed resource that represents a Google Compute
+// Engine backend bucket.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="BUCKET",type="string",JSONPath=".spec.forProvider.bucketName"
+// +kubebuilder:printcolumn:name="CDN",type="boolean",JSONPath=".spec.forProvider.enableCdn"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type BackendBucket struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackendBucketSpec   `json:"spec"`
+	Status BackendBucketStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BackendBucketList contains a list of BackendBucket.
+type BackendBucketList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BackendBucket `json:"items"`
+}
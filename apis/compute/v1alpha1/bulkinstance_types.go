@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// BulkInstanceParameters define the desired state of a set of Google Compute
+// Engine instances that are created from an instance template in a single
+// bulk insert request:
+// https://cloud.google.com/compute/docs/reference/rest/v1/instances/bulkInsert
+type BulkInstanceParameters struct {
+	// Zone: Name of the zone the instances are created in.
+	// +immutable
+	Zone string `json:"zone"`
+
+	// SourceInstanceTemplate: The full or partial URL of the instance
+	// template the instances are created from, e.g.
+	// global/instanceTemplates/my-template.
+	// +immutable
+	SourceInstanceTemplate string `json:"sourceInstanceTemplate"`
+
+	// NamePattern: The string pattern used for the names of the instances.
+	// The pattern must contain one continuous sequence of placeholder hash
+	// characters (#), each corresponding to one digit of the generated
+	// instance names. For example a pattern of inst-#### generates instance
+	// names such as inst-0001, inst-0002, etc.
+	// +immutable
+	// +kubebuilder:validation:Pattern=`^[a-z]([-a-z0-9#]*[a-z0-9#])?$`
+	NamePattern string `json:"namePattern"`
+
+	// Count: The desired number of instances. Instances are created or
+	// deleted to converge on this number.
+	// +kubebuilder:validation:Minimum=0
+	Count int64 `json:"count"`
+
+	// MinCount: The minimum number of instances a bulk insert request must
+	// be able to create. If fewer could be created, none are. Defaults to
+	// the number of instances requested.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MinCount *int64 `json:"minCount,omitempty"`
+}
+
+// A BulkInstanceObservation represents the observed state of a set of Google
+// Compute Engine instances.
+type BulkInstanceObservation struct {
+	// Instances: The names of the instances whose names match the name
+	// pattern.
+	Instances []string `json:"instances,omitempty"`
+
+	// CreatedCount: The number of instances that currently exist.
+	CreatedCount int64 `json:"createdCount"`
+
+	// DesiredCount: The number of instances that are desired.
+	DesiredCount int64 `json:"desiredCount"`
+
+	// Operation: The name of the pending bulk insert operation, if any.
+	Operation string `json:"operation,omitempty"`
+}
+
+// A BulkInstanceSpec defines the desired state of a BulkInstance.
+type BulkInstanceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       BulkInstanceParameters `json:"forProvider"`
+}
+
+// A BulkInstanceStatus represents the observed state of a BulkInstance.
+type BulkInstanceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          BulkInstanceObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A BulkInstance is a managed resource that represents a set of Google
+// Compute Engine instances created from the same instance template.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="CREATED",type="integer",JSONPath=".status.atProvider.createdCount"
+// +kubebuilder:printcolumn:name="DESIRED",type="integer",JSONPath=".spec.forProvider.count"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type BulkInstance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BulkInstanceSpec   `json:"spec"`
+	Status BulkInstanceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BulkInstanceList contains a list of BulkInstance.
+type BulkInstanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BulkInstance `json:"items"`
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// DiskResourcePolicyBindingParameters define the desired resource policies of
+// a Google Compute Engine persistent disk.
+type DiskResourcePolicyBindingParameters struct {
+	// Disk: Name of the persistent disk the resource policies are attached
+	// to.
+	// +immutable
+	Disk string `json:"disk"`
+
+	// Zone: Name of the zone of the persistent disk.
+	// +immutable
+	Zone string `json:"zone"`
+
+	// ResourcePolicies: URLs of the resource policies to attach to the
+	// disk. Resource policies attached to the disk that are not listed here
+	// are removed from it.
+	// +optional
+	ResourcePolicies []string `json:"resourcePolicies,omitempty"`
+
+	// ResourcePolicyRefs references ResourcePolicies to retrieve their URLs.
+	// +optional
+	ResourcePolicyRefs []xpv1.Reference `json:"resourcePolicyRefs,omitempty"`
+
+	// ResourcePolicySelector selects references to ResourcePolicies to
+	// retrieve their URLs.
+	// +optional
+	ResourcePolicySelector *xpv1.Selector `json:"resourcePolicySelector,omitempty"`
+}
+
+// A DiskResourcePolicyBindingObservation represents the observed state of the
+// resource policies of a Google Compute Engine persistent disk.
+type DiskResourcePolicyBindingObservation struct {
+	// ResourcePolicies: URLs of the resource policies attached to the disk.
+	ResourcePolicies []string `json:"resourcePolicies,omitempty"`
+}
+
+// A DiskResourcePolicyBindingSpec defines the desired state of a
+// DiskResourcePolicyBinding.
+type DiskResourcePolicyBindingSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       DiskResourcePolicyBindingParameters `json:"forProvider"`
+}
+
+// A DiskResourcePolicyBindingStatus represents the observed state of a
+// DiskResourcePolicyBinding.
+type DiskResourcePolicyBindingStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          DiskResourcePolicyBindingObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A DiskResourcePolicyBinding is a managed resource that represents the
+// resource policies attached to a Google Compute Engine persistent disk. It is
+// authoritative for the resource policies of the disk; the disk itself is
+// never created or deleted.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="DISK",type="string",JSONPath=".spec.forProvider.disk"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type DiskResourcePolicyBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DiskResourcePolicyBindingSpec   `json:"spec"`
+	Status DiskResourcePolicyBindingStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DiskResourcePolicyBindingList contains a list of DiskResourcePolicyBinding.
+type DiskResourcePolicyBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DiskResourcePolicyBinding `json:"items"`
+}
@@ -26,10 +26,8 @@ import (
 // Firewall rule. Most fields map directly to a Firewall:
 // https://cloud.google.com/compute/docs/reference/rest/v1/firewalls/
 type FirewallParameters struct {
-	// Description: An optional description of this resource. Provide this
-	// field when you create the resource.
+	// Description: An optional description of this resource.
 	// +optional
-	// +immutable
 	Description *string `json:"description,omitempty"`
 
 	// Network: URL of the network resource for this firewall rule. If not
@@ -0,0 +1,165 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// ForwardingRuleParameters define the desired state of a Google Compute Engine
+// regional forwarding rule, i.e. the frontend of a regional load balancer.
+// Only the target and whether the rule is accessible from other regions can
+// be updated once created. Most fields map directly to a ForwardingRule:
+// https://cloud.google.com/compute/docs/reference/rest/v1/forwardingRules
+type ForwardingRuleParameters struct {
+	// Region: Name of the region the forwarding rule resides in.
+	// +immutable
+	Region string `json:"region"`
+
+	// LoadBalancingScheme: The kind of load balancer the forwarding rule is
+	// used with. It must match the scheme of its backend service.
+	// +immutable
+	// +kubebuilder:validation:Enum=EXTERNAL;INTERNAL;INTERNAL_MANAGED
+	LoadBalancingScheme string `json:"loadBalancingScheme"`
+
+	// IPAddress: The IP address the forwarding rule serves. An ephemeral
+	// address is assigned if omitted.
+	// +optional
+	// +immutable
+	IPAddress *string `json:"ipAddress,omitempty"`
+
+	// IPProtocol: The IP protocol the forwarding rule applies to. It must
+	// match the protocol of an INTERNAL backend service.
+	// +optional
+	// +immutable
+	// +kubebuilder:validation:Enum=TCP;UDP;ESP;AH;SCTP;ICMP
+	IPProtocol *string `json:"ipProtocol,omitempty"`
+
+	// AllPorts: Whether packets addressed to any port are forwarded to the
+	// backend service. Exclusive with ports and portRange.
+	// +optional
+	// +immutable
+	AllPorts *bool `json:"allPorts,omitempty"`
+
+	// Ports: Up to five ports whose packets are forwarded to the backend
+	// service. Exclusive with allPorts and portRange.
+	// +optional
+	// +immutable
+	// +kubebuilder:validation:MaxItems=5
+	Ports []string `json:"ports,omitempty"`
+
+	// PortRange: The range of ports whose packets are forwarded to the
+	// target, e.g. 80-8080. Exclusive with allPorts and ports.
+	// +optional
+	// +immutable
+	PortRange *string `json:"portRange,omitempty"`
+
+	// BackendService: The full or partial URL of the regional backend
+	// service the forwarding rule forwards to, e.g.
+	// regions/us-central1/backendServices/my-service. Exclusive with
+	// target.
+	// +optional
+	// +immutable
+	BackendService *string `json:"backendService,omitempty"`
+
+	// Target: The full or partial URL of the target resource the forwarding
+	// rule forwards to, e.g. a target instance or target pool. Exclusive
+	// with backendService.
+	// +optional
+	Target *string `json:"target,omitempty"`
+
+	// Network: The full or partial URL of the network of an INTERNAL
+	// forwarding rule, e.g. global/networks/my-network.
+	// +optional
+	// +immutable
+	Network *string `json:"network,omitempty"`
+
+	// Subnetwork: The full or partial URL of the subnetwork the IP address
+	// of an INTERNAL forwarding rule is taken from.
+	// +optional
+	// +immutable
+	Subnetwork *string `json:"subnetwork,omitempty"`
+
+	// AllowGlobalAccess: Whether clients in other regions can reach an
+	// INTERNAL forwarding rule.
+	// +optional
+	AllowGlobalAccess *bool `json:"allowGlobalAccess,omitempty"`
+
+	// Description: An optional description of this resource.
+	// +optional
+	// +immutable
+	Description *string `json:"description,omitempty"`
+}
+
+// A ForwardingRuleObservation represents the observed state of a Google
+// Compute Engine forwarding rule.
+type ForwardingRuleObservation struct {
+	// CreationTimestamp: Creation timestamp in RFC3339 text format.
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+
+	// ID: The unique identifier for the resource. This identifier is
+	// defined by the server.
+	ID uint64 `json:"id,omitempty"`
+
+	// SelfLink: Server-defined fully-qualified URL for this resource.
+	SelfLink string `json:"selfLink,omitempty"`
+
+	// ServiceName: The internal DNS name of an INTERNAL forwarding rule
+	// that has a service label.
+	ServiceName string `json:"serviceName,omitempty"`
+}
+
+// A ForwardingRuleSpec defines the desired state of a ForwardingRule.
+type ForwardingRuleSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ForwardingRuleParameters `json:"forProvider"`
+}
+
+// A ForwardingRuleStatus represents the observed state of a ForwardingRule.
+type ForwardingRuleStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ForwardingRuleObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ForwardingRule is a managed resource that represents a Google Compute
+// Engine regional forwarding rule.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="ADDRESS",type="string",JSONPath=".spec.forProvider.ipAddress"
+// +kubebuilder:printcolumn:name="REGION",type="string",JSONPath=".spec.forProvider.region"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type ForwardingRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ForwardingRuleSpec   `json:"spec"`
+	Status ForwardingRuleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ForwardingRuleList contains a list of ForwardingRule.
+type ForwardingRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ForwardingRule `json:"items"`
+}
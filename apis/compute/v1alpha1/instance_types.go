@@ -0,0 +1,270 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// InstanceParameters define the desired state of a Google Compute Engine
+// virtual machine instance. Most fields map directly to an Instance:
+// https://cloud.google.com/compute/docs/reference/rest/v1/instances
+type InstanceParameters struct {
+	// Zone: Name of the zone the instance resides in.
+	// +immutable
+	Zone string `json:"zone"`
+
+	// MachineType: The full or partial URL of the machine type of the
+	// instance, e.g. zones/us-central1-a/machineTypes/e2-medium.
+	// +immutable
+	MachineType string `json:"machineType"`
+
+	// Disks: The disks attached to the instance. Exactly one of them must
+	// be the boot disk. Only whether they are deleted along with the
+	// instance can be updated.
+	Disks []AttachedDisk `json:"disks"`
+
+	// NetworkInterfaces: The network interfaces of the instance.
+	// +immutable
+	NetworkInterfaces []NetworkInterface `json:"networkInterfaces"`
+
+	// Scheduling: How the instance is scheduled, restarted and maintained.
+	// +optional
+	Scheduling *Scheduling `json:"scheduling,omitempty"`
+
+	// GuestAccelerators: The accelerators, e.g. GPUs, attached to the
+	// instance. The instance has none unless they are listed. Instances
+	// with accelerators must terminate on host maintenance, and their
+	// accelerators can only be changed while they are stopped.
+	// +optional
+	GuestAccelerators []AcceleratorConfig `json:"guestAccelerators,omitempty"`
+
+	// ShieldedInstanceConfig: The Shielded VM options of the instance. Its
+	// source image must support Shielded VM features.
+	// +optional
+	ShieldedInstanceConfig *ShieldedInstanceConfig `json:"shieldedInstanceConfig,omitempty"`
+
+	// ConfidentialInstanceConfig: The Confidential VM options of the
+	// instance.
+	// +optional
+	// +immutable
+	ConfidentialInstanceConfig *ConfidentialInstanceConfig `json:"confidentialInstanceConfig,omitempty"`
+
+	// AllowStopForUpdate: Whether the instance may be stopped to apply
+	// changes that GCP only accepts for stopped instances, e.g. changing
+	// whether it is preemptible, its accelerators or its Secure Boot and
+	// vTPM options. It is started again once the changes are applied. Such
+	// changes fail if this is false.
+	// +optional
+	AllowStopForUpdate *bool `json:"allowStopForUpdate,omitempty"`
+
+	// Description: An optional description of this resource.
+	// +optional
+	// +immutable
+	Description *string `json:"description,omitempty"`
+}
+
+// An AttachedDisk is a disk attached to an instance.
+type AttachedDisk struct {
+	// Boot: Whether the disk is the boot disk of the instance.
+	// +optional
+	// +immutable
+	Boot *bool `json:"boot,omitempty"`
+
+	// DeviceName: The name of the disk as seen by the guest operating
+	// system. Defaults to the name of the disk.
+	// +optional
+	// +immutable
+	DeviceName *string `json:"deviceName,omitempty"`
+
+	// AutoDelete: Whether the disk is deleted along with the instance
+	// rather than kept. GCP keeps disks unless told otherwise.
+	// +optional
+	AutoDelete *bool `json:"autoDelete,omitempty"`
+
+	// Source: The full or partial URL of an existing disk to attach, e.g.
+	// zones/us-central1-a/disks/my-disk. Exclusive with initializeParams.
+	// +optional
+	// +immutable
+	Source *string `json:"source,omitempty"`
+
+	// InitializeParams: The parameters of a new disk that is created
+	// along with the instance. Exclusive with source.
+	// +optional
+	// +immutable
+	InitializeParams *AttachedDiskInitializeParams `json:"initializeParams,omitempty"`
+}
+
+// AttachedDiskInitializeParams are the parameters of a disk that is created
+// along with an instance.
+type AttachedDiskInitializeParams struct {
+	// SourceImage: The full or partial URL of the image the disk is
+	// created from, e.g. projects/debian-cloud/global/images/family/debian-11.
+	// +optional
+	SourceImage *string `json:"sourceImage,omitempty"`
+
+	// DiskSizeGb: The size of the disk in GB. Defaults to the size of the
+	// source image.
+	// +optional
+	DiskSizeGb *int64 `json:"diskSizeGb,omitempty"`
+
+	// DiskType: The full or partial URL of the type of the disk, e.g.
+	// zones/us-central1-a/diskTypes/pd-ssd.
+	// +optional
+	DiskType *string `json:"diskType,omitempty"`
+}
+
+// A NetworkInterface connects an instance to a network.
+type NetworkInterface struct {
+	// Network: The full or partial URL of the network, e.g.
+	// global/networks/default. Defaults to the network of the
+	// subnetwork.
+	// +optional
+	Network *string `json:"network,omitempty"`
+
+	// Subnetwork: The full or partial URL of the subnetwork, e.g.
+	// regions/us-central1/subnetworks/default.
+	// +optional
+	Subnetwork *string `json:"subnetwork,omitempty"`
+
+	// NetworkIP: The internal IP address of the interface. An ephemeral
+	// address is assigned if omitted.
+	// +optional
+	NetworkIP *string `json:"networkIP,omitempty"`
+}
+
+// An AcceleratorConfig attaches accelerators of one type to an instance.
+type AcceleratorConfig struct {
+	// AcceleratorType: The name or the full or partial URL of the type of
+	// the accelerators, e.g. nvidia-tesla-t4 or
+	// zones/us-central1-a/acceleratorTypes/nvidia-tesla-t4. It must be
+	// available in the zone of the instance.
+	AcceleratorType string `json:"acceleratorType"`
+
+	// AcceleratorCount: The number of accelerators of the type.
+	// +kubebuilder:validation:Minimum=1
+	AcceleratorCount int64 `json:"acceleratorCount"`
+}
+
+// ShieldedInstanceConfig configures the Shielded VM options of an instance.
+type ShieldedInstanceConfig struct {
+	// EnableSecureBoot: Whether the instance only boots software signed
+	// by trusted keys. Disabled by default. Changing it requires the
+	// instance to be stopped.
+	// +optional
+	EnableSecureBoot *bool `json:"enableSecureBoot,omitempty"`
+
+	// EnableVtpm: Whether the instance has a virtual Trusted Platform
+	// Module. Enabled by default. Changing it requires the instance to be
+	// stopped.
+	// +optional
+	EnableVtpm *bool `json:"enableVtpm,omitempty"`
+
+	// EnableIntegrityMonitoring: Whether the boot integrity of the
+	// instance is monitored. It requires the vTPM. Enabled by default.
+	// +optional
+	EnableIntegrityMonitoring *bool `json:"enableIntegrityMonitoring,omitempty"`
+}
+
+// ConfidentialInstanceConfig configures the Confidential VM options of an
+// instance.
+type ConfidentialInstanceConfig struct {
+	// EnableConfidentialCompute: Whether the memory of the instance is
+	// encrypted with AMD SEV. It requires an N2D or C2D machine type and
+	// the instance to terminate on host maintenance.
+	// +immutable
+	EnableConfidentialCompute bool `json:"enableConfidentialCompute"`
+}
+
+// Scheduling configures how an instance is scheduled.
+type Scheduling struct {
+	// Preemptible: Whether the instance is preemptible, i.e. may be
+	// stopped by GCP at any time. Preemptible instances can not restart
+	// automatically or migrate on host maintenance. Changing it requires
+	// the instance to be stopped.
+	// +optional
+	Preemptible *bool `json:"preemptible,omitempty"`
+
+	// AutomaticRestart: Whether the instance is restarted if it is
+	// terminated by GCP, e.g. due to a hardware failure.
+	// +optional
+	AutomaticRestart *bool `json:"automaticRestart,omitempty"`
+
+	// OnHostMaintenance: Whether the instance is migrated or terminated
+	// during host maintenance.
+	// +optional
+	// +kubebuilder:validation:Enum=MIGRATE;TERMINATE
+	OnHostMaintenance *string `json:"onHostMaintenance,omitempty"`
+}
+
+// An InstanceObservation represents the observed state of a Google Compute
+// Engine instance.
+type InstanceObservation struct {
+	// CreationTimestamp: Creation timestamp in RFC3339 text format.
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+
+	// ID: The unique identifier for the resource. This identifier is
+	// defined by the server.
+	ID uint64 `json:"id,omitempty"`
+
+	// SelfLink: Server-defined fully-qualified URL for this resource.
+	SelfLink string `json:"selfLink,omitempty"`
+
+	// Status: The status of the instance, e.g. RUNNING or TERMINATED.
+	Status string `json:"status,omitempty"`
+}
+
+// An InstanceSpec defines the desired state of an Instance.
+type InstanceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       InstanceParameters `json:"forProvider"`
+}
+
+// An InstanceStatus represents the observed state of an Instance.
+type InstanceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          InstanceObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An Instance is a managed resource that represents a Google Compute Engine
+// virtual machine instance.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.status"
+// +kubebuilder:printcolumn:name="ZONE",type="string",JSONPath=".spec.forProvider.zone"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Instance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   InstanceSpec   `json:"spec"`
+	Status InstanceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// InstanceList contains a list of Instance.
+type InstanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Instance `json:"items"`
+}
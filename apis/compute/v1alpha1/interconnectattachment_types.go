@@ -0,0 +1,181 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Known InterconnectAttachment connection secret keys.
+const (
+	ConnectionSecretKeyCloudRouterIPAddress    = "cloudRouterIpAddress"
+	ConnectionSecretKeyCustomerRouterIPAddress = "customerRouterIpAddress"
+)
+
+// InterconnectAttachmentParameters define the desired state of a Google
+// Compute Engine interconnect attachment, i.e. a VLAN attachment connecting a
+// Cloud Interconnect to a Cloud Router. Only the bandwidth and whether the
+// attachment is enabled can be updated once created. Most fields map directly
+// to an InterconnectAttachment:
+// https://cloud.google.com/compute/docs/reference/rest/v1/interconnectAttachments
+type InterconnectAttachmentParameters struct {
+	// Region: Name of the region the interconnect attachment and its Cloud
+	// Router reside in.
+	// +immutable
+	Region string `json:"region"`
+
+	// Router: The full or partial URL of the Cloud Router the interconnect
+	// attachment is bound to, e.g. regions/us-central1/routers/my-router.
+	// The router must be in the same region as the attachment.
+	// +immutable
+	Router string `json:"router"`
+
+	// Interconnect: The full or partial URL of the dedicated interconnect
+	// the attachment's traffic traverses, e.g.
+	// global/interconnects/my-interconnect. Required for DEDICATED
+	// attachments; PARTNER attachments are provisioned by the partner.
+	// +optional
+	// +immutable
+	Interconnect *string `json:"interconnect,omitempty"`
+
+	// Type: The type of interconnect attachment.
+	// +optional
+	// +immutable
+	// +kubebuilder:validation:Enum=DEDICATED;PARTNER;PARTNER_PROVIDER
+	Type *string `json:"type,omitempty"`
+
+	// VlanTag8021q: The IEEE 802.1Q VLAN tag of the attachment. Only
+	// applicable to DEDICATED attachments; chosen by GCP if omitted.
+	// +optional
+	// +immutable
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=4094
+	VlanTag8021q *int64 `json:"vlanTag8021q,omitempty"`
+
+	// Bandwidth: Provisioned bandwidth capacity of the attachment. For
+	// PARTNER attachments it is set by the partner.
+	// +optional
+	// +kubebuilder:validation:Enum=BPS_50M;BPS_100M;BPS_200M;BPS_300M;BPS_400M;BPS_500M;BPS_1G;BPS_2G;BPS_5G;BPS_10G;BPS_20G;BPS_50G
+	Bandwidth *string `json:"bandwidth,omitempty"`
+
+	// AdminEnabled: Whether the attachment carries traffic. A disabled
+	// attachment drops all traffic and does not establish a BGP session.
+	// +optional
+	AdminEnabled *bool `json:"adminEnabled,omitempty"`
+
+	// EdgeAvailabilityDomain: The availability domain of a PARTNER
+	// attachment. Attachments meant to be redundant must be in different
+	// domains.
+	// +optional
+	// +immutable
+	// +kubebuilder:validation:Enum=AVAILABILITY_DOMAIN_1;AVAILABILITY_DOMAIN_2;AVAILABILITY_DOMAIN_ANY
+	EdgeAvailabilityDomain *string `json:"edgeAvailabilityDomain,omitempty"`
+
+	// CandidateSubnets: Up to 16 /29 link-local CIDR ranges within
+	// 169.254.0.0/16 to allocate the Cloud Router and customer router IP
+	// addresses from.
+	// +optional
+	// +immutable
+	CandidateSubnets []string `json:"candidateSubnets,omitempty"`
+
+	// Description: An optional description of this resource.
+	// +optional
+	// +immutable
+	Description *string `json:"description,omitempty"`
+}
+
+// An InterconnectAttachmentObservation represents the observed state of a
+// Google Compute Engine interconnect attachment.
+type InterconnectAttachmentObservation struct {
+	// CloudRouterIPAddress: IPv4 address and prefix length of the Cloud
+	// Router interface of the attachment.
+	CloudRouterIPAddress string `json:"cloudRouterIpAddress,omitempty"`
+
+	// CustomerRouterIPAddress: IPv4 address and prefix length of the
+	// customer router interface of the attachment.
+	CustomerRouterIPAddress string `json:"customerRouterIpAddress,omitempty"`
+
+	// CreationTimestamp: Creation timestamp in RFC3339 text format.
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+
+	// GoogleReferenceID: Google reference ID to provide to Google support
+	// when troubleshooting the attachment.
+	GoogleReferenceID string `json:"googleReferenceId,omitempty"`
+
+	// ID: The unique identifier for the resource. This identifier is
+	// defined by the server.
+	ID uint64 `json:"id,omitempty"`
+
+	// OperationalStatus: Whether the attachment is capable of carrying
+	// traffic, i.e. OS_ACTIVE or OS_UNPROVISIONED.
+	OperationalStatus string `json:"operationalStatus,omitempty"`
+
+	// PairingKey: The opaque key a PARTNER attachment is provisioned with
+	// by the partner.
+	PairingKey string `json:"pairingKey,omitempty"`
+
+	// SelfLink: Server-defined fully-qualified URL for this resource.
+	SelfLink string `json:"selfLink,omitempty"`
+
+	// State: The provisioning state of the attachment, e.g. ACTIVE or
+	// PENDING_PARTNER.
+	State string `json:"state,omitempty"`
+}
+
+// An InterconnectAttachmentSpec defines the desired state of an
+// InterconnectAttachment.
+type InterconnectAttachmentSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       InterconnectAttachmentParameters `json:"forProvider"`
+}
+
+// An InterconnectAttachmentStatus represents the observed state of an
+// InterconnectAttachment.
+type InterconnectAttachmentStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          InterconnectAttachmentObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An InterconnectAttachment is a managed resource that represents a Google
+// Compute Engine interconnect attachment, i.e. a VLAN attachment of a Cloud
+// Interconnect.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="REGION",type="string",JSONPath=".spec.forProvider.region"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type InterconnectAttachment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   InterconnectAttachmentSpec   `json:"spec"`
+	Status InterconnectAttachmentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// InterconnectAttachmentList contains a list of InterconnectAttachment.
+type InterconnectAttachmentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []InterconnectAttachment `json:"items"`
+}
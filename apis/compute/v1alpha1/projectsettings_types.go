@@ -0,0 +1,119 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// ProjectSettingsParameters define the desired Compute Engine settings of the
+// project a ProviderConfig points to.
+type ProjectSettingsParameters struct {
+	// DefaultNetworkTier: The network tier used by default for resources
+	// created in the project, e.g. external IP addresses and forwarding
+	// rules, when they do not specify one.
+	// +optional
+	// +kubebuilder:validation:Enum=PREMIUM;STANDARD
+	DefaultNetworkTier *string `json:"defaultNetworkTier,omitempty"`
+
+	// UsageExportBucket: The name of an existing bucket in Cloud Storage
+	// where the usage report object is stored. Usage export is disabled
+	// when it is unset.
+	// +optional
+	UsageExportBucket *string `json:"usageExportBucket,omitempty"`
+
+	// UsageExportBucketRef references a Bucket to retrieve its name.
+	// +optional
+	UsageExportBucketRef *xpv1.Reference `json:"usageExportBucketRef,omitempty"`
+
+	// UsageExportBucketSelector selects a reference to a Bucket to retrieve
+	// its name.
+	// +optional
+	UsageExportBucketSelector *xpv1.Selector `json:"usageExportBucketSelector,omitempty"`
+
+	// UsageExportReportNamePrefix: An optional prefix for the name of the
+	// usage report object stored in the usage export bucket. If omitted,
+	// GCP uses the prefix `usage_gce`.
+	// +optional
+	UsageExportReportNamePrefix *string `json:"usageExportReportNamePrefix,omitempty"`
+}
+
+// ProjectSettingsObservation is used to show the observed state of the
+// Compute Engine settings of a project.
+type ProjectSettingsObservation struct {
+	// Name: The project ID.
+	Name string `json:"name,omitempty"`
+
+	// DefaultNetworkTier: The network tier used by default in the project.
+	// It is empty until it was set for the first time, in which case GCP
+	// uses PREMIUM.
+	DefaultNetworkTier string `json:"defaultNetworkTier,omitempty"`
+
+	// DefaultServiceAccount: The default service account used by VMs
+	// running in this project.
+	DefaultServiceAccount string `json:"defaultServiceAccount,omitempty"`
+
+	// UsageExportBucket: The name of the bucket usage reports are exported
+	// to, if usage export is enabled.
+	UsageExportBucket string `json:"usageExportBucket,omitempty"`
+
+	// UsageExportReportNamePrefix: The prefix of the exported usage report
+	// objects.
+	UsageExportReportNamePrefix string `json:"usageExportReportNamePrefix,omitempty"`
+}
+
+// A ProjectSettingsSpec defines the desired state of a ProjectSettings.
+type ProjectSettingsSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ProjectSettingsParameters `json:"forProvider"`
+}
+
+// A ProjectSettingsStatus represents the observed state of a
+// ProjectSettings.
+type ProjectSettingsStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ProjectSettingsObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ProjectSettings is a managed resource that represents the Compute Engine
+// settings of the project a ProviderConfig points to. The project itself is
+// never created or deleted.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="NETWORK_TIER",type="string",JSONPath=".status.atProvider.defaultNetworkTier"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=projectsettings,scope=Cluster,categories={crossplane,managed,gcp}
+type ProjectSettings struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProjectSettingsSpec   `json:"spec"`
+	Status ProjectSettingsStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ProjectSettingsList contains a list of ProjectSettings.
+type ProjectSettingsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ProjectSettings `json:"items"`
+}
@@ -18,16 +18,30 @@ package v1alpha1
 
 import (
 	"context"
+	"strings"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 
 	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/crossplane/provider-gcp/apis/compute/v1beta1"
+	storagev1alpha3 "github.com/crossplane/provider-gcp/apis/storage/v1alpha3"
 )
 
+// ResourcePolicyURL extracts the partially qualified URL of a ResourcePolicy.
+func ResourcePolicyURL() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		rp, ok := mg.(*ResourcePolicy)
+		if !ok {
+			return ""
+		}
+		return strings.TrimPrefix(rp.Status.AtProvider.SelfLink, v1beta1.ComputeURIPrefix)
+	}
+}
+
 // ResolveReferences of this Firewall
 func (mg *Firewall) ResolveReferences(ctx context.Context, c client.Reader) error {
 	r := reference.NewAPIResolver(c, mg)
@@ -48,3 +62,87 @@ func (mg *Firewall) ResolveReferences(ctx context.Context, c client.Reader) erro
 
 	return nil
 }
+
+// ResolveReferences of this ProjectSettings
+func (mg *ProjectSettings) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.usageExportBucket
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.UsageExportBucket),
+		Reference:    mg.Spec.ForProvider.UsageExportBucketRef,
+		Selector:     mg.Spec.ForProvider.UsageExportBucketSelector,
+		To:           reference.To{Managed: &storagev1alpha3.Bucket{}, List: &storagev1alpha3.BucketList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.usageExportBucket")
+	}
+	mg.Spec.ForProvider.UsageExportBucket = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.UsageExportBucketRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this DiskResourcePolicyBinding
+func (mg *DiskResourcePolicyBinding) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.resourcePolicies
+	mrsp, err := r.ResolveMultiple(ctx, reference.MultiResolutionRequest{
+		CurrentValues: mg.Spec.ForProvider.ResourcePolicies,
+		References:    mg.Spec.ForProvider.ResourcePolicyRefs,
+		Selector:      mg.Spec.ForProvider.ResourcePolicySelector,
+		To:            reference.To{Managed: &ResourcePolicy{}, List: &ResourcePolicyList{}},
+		Extract:       ResourcePolicyURL(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.resourcePolicies")
+	}
+	mg.Spec.ForProvider.ResourcePolicies = mrsp.ResolvedValues
+	mg.Spec.ForProvider.ResourcePolicyRefs = mrsp.ResolvedReferences
+
+	return nil
+}
+
+// ResolveReferences of this BackendBucket
+func (mg *BackendBucket) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.bucketName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.BucketName),
+		Reference:    mg.Spec.ForProvider.BucketNameRef,
+		Selector:     mg.Spec.ForProvider.BucketNameSelector,
+		To:           reference.To{Managed: &storagev1alpha3.Bucket{}, List: &storagev1alpha3.BucketList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.bucketName")
+	}
+	mg.Spec.ForProvider.BucketName = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.BucketNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this RouterPeer
+func (mg *RouterPeer) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.interfaceName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.InterfaceName),
+		Reference:    mg.Spec.ForProvider.InterfaceNameRef,
+		Selector:     mg.Spec.ForProvider.InterfaceNameSelector,
+		To:           reference.To{Managed: &RouterInterface{}, List: &RouterInterfaceList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.interfaceName")
+	}
+	mg.Spec.ForProvider.InterfaceName = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.InterfaceNameRef = rsp.ResolvedReference
+
+	return nil
+}
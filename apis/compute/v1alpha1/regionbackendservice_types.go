@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// RegionBackendServiceParameters define the desired state of a Google Compute
+// Engine regional backend service, e.g. the backend service of an internal
+// TCP/UDP load balancer. Most fields map directly to a BackendService:
+// https://cloud.google.com/compute/docs/reference/rest/v1/regionBackendServices
+type RegionBackendServiceParameters struct {
+	// Region: Name of the region the backend service resides in.
+	// +immutable
+	Region string `json:"region"`
+
+	// LoadBalancingScheme: The kind of load balancer the backend service
+	// is used with. INTERNAL backend services back internal TCP/UDP load
+	// balancers.
+	// +immutable
+	// +kubebuilder:validation:Enum=EXTERNAL;INTERNAL;INTERNAL_MANAGED
+	LoadBalancingScheme string `json:"loadBalancingScheme"`
+
+	// Protocol: The protocol the backend service uses to talk to its
+	// backends. INTERNAL backend services use TCP or UDP.
+	// +optional
+	// +kubebuilder:validation:Enum=HTTP;HTTPS;HTTP2;TCP;SSL;UDP;GRPC
+	Protocol *string `json:"protocol,omitempty"`
+
+	// Network: The full or partial URL of the network the backend service
+	// belongs to, e.g. global/networks/my-network. Only INTERNAL backend
+	// services belong to a network.
+	// +optional
+	// +immutable
+	Network *string `json:"network,omitempty"`
+
+	// HealthChecks: The full or partial URLs of the regional health checks
+	// of the backends, e.g. regions/us-central1/healthChecks/my-check.
+	// INTERNAL backend services require exactly one.
+	// +optional
+	HealthChecks []string `json:"healthChecks,omitempty"`
+
+	// Backends: The backends serving the backend service.
+	// +optional
+	Backends []Backend `json:"backends,omitempty"`
+
+	// ConnectionDraining: How long connections to a removed or unhealthy
+	// backend are kept.
+	// +optional
+	ConnectionDraining *ConnectionDraining `json:"connectionDraining,omitempty"`
+
+	// SessionAffinity: The type of session affinity of the backend service.
+	// +optional
+	// +kubebuilder:validation:Enum=NONE;CLIENT_IP;CLIENT_IP_PROTO;CLIENT_IP_PORT_PROTO;GENERATED_COOKIE
+	SessionAffinity *string `json:"sessionAffinity,omitempty"`
+
+	// TimeoutSec: How many seconds to wait for a backend before considering
+	// a request failed.
+	// +optional
+	TimeoutSec *int64 `json:"timeoutSec,omitempty"`
+
+	// Description: An optional description of this resource.
+	// +optional
+	Description *string `json:"description,omitempty"`
+}
+
+// A Backend of a backend service.
+type Backend struct {
+	// Group: The full or partial URL of the instance group or network
+	// endpoint group of the backend, e.g.
+	// zones/us-central1-a/instanceGroups/my-group.
+	Group string `json:"group"`
+
+	// BalancingMode: How the load is balanced across the backend. Backends
+	// of INTERNAL backend services use CONNECTION.
+	// +optional
+	// +kubebuilder:validation:Enum=UTILIZATION;RATE;CONNECTION
+	BalancingMode *string `json:"balancingMode,omitempty"`
+
+	// Failover: Whether the backend is a failover backend, which only
+	// receives traffic when the primary backends are unhealthy.
+	// +optional
+	Failover *bool `json:"failover,omitempty"`
+
+	// Description: An optional description of the backend.
+	// +optional
+	Description *string `json:"description,omitempty"`
+}
+
+// ConnectionDraining configures how connections to backends are drained.
+type ConnectionDraining struct {
+	// DrainingTimeoutSec: How many seconds existing connections to a
+	// removed or unhealthy backend are kept. 0 disables draining.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=3600
+	DrainingTimeoutSec int64 `json:"drainingTimeoutSec"`
+}
+
+// A RegionBackendServiceObservation represents the observed state of a Google
+// Compute Engine regional backend service.
+type RegionBackendServiceObservation struct {
+	// CreationTimestamp: Creation timestamp in RFC3339 text format.
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+
+	// Fingerprint: The fingerprint of the backend service, which changes
+	// whenever it is updated.
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// ID: The unique identifier for the resource. This identifier is
+	// defined by the server.
+	ID uint64 `json:"id,omitempty"`
+
+	// SelfLink: Server-defined fully-qualified URL for this resource.
+	SelfLink string `json:"selfLink,omitempty"`
+}
+
+// A RegionBackendServiceSpec defines the desired state of a
+// RegionBackendService.
+type RegionBackendServiceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RegionBackendServiceParameters `json:"forProvider"`
+}
+
+// A RegionBackendServiceStatus represents the observed state of a
+// RegionBackendService.
+type RegionBackendServiceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          RegionBackendServiceObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RegionBackendService is a managed resource that represents a Google
+// Compute Engine regional backend service.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="SCHEME",type="string",JSONPath=".spec.forProvider.loadBalancingScheme"
+// +kubebuilder:printcolumn:name="REGION",type="string",JSONPath=".spec.forProvider.region"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type RegionBackendService struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RegionBackendServiceSpec   `json:"spec"`
+	Status RegionBackendServiceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RegionBackendServiceList contains a list of RegionBackendService.
+type RegionBackendServiceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RegionBackendService `json:"items"`
+}
@@ -45,6 +45,123 @@ var (
 	FirewallGroupVersionKind = SchemeGroupVersion.WithKind(FirewallKind)
 )
 
+// ProjectSettings type metadata.
+var (
+	ProjectSettingsKind             = reflect.TypeOf(ProjectSettings{}).Name()
+	ProjectSettingsGroupKind        = schema.GroupKind{Group: Group, Kind: ProjectSettingsKind}.String()
+	ProjectSettingsKindAPIVersion   = ProjectSettingsKind + "." + SchemeGroupVersion.String()
+	ProjectSettingsGroupVersionKind = SchemeGroupVersion.WithKind(ProjectSettingsKind)
+)
+
+// ResourcePolicy type metadata.
+var (
+	ResourcePolicyKind             = reflect.TypeOf(ResourcePolicy{}).Name()
+	ResourcePolicyGroupKind        = schema.GroupKind{Group: Group, Kind: ResourcePolicyKind}.String()
+	ResourcePolicyKindAPIVersion   = ResourcePolicyKind + "." + SchemeGroupVersion.String()
+	ResourcePolicyGroupVersionKind = SchemeGroupVersion.WithKind(ResourcePolicyKind)
+)
+
+// DiskResourcePolicyBinding type metadata.
+var (
+	DiskResourcePolicyBindingKind             = reflect.TypeOf(DiskResourcePolicyBinding{}).Name()
+	DiskResourcePolicyBindingGroupKind        = schema.GroupKind{Group: Group, Kind: DiskResourcePolicyBindingKind}.String()
+	DiskResourcePolicyBindingKindAPIVersion   = DiskResourcePolicyBindingKind + "." + SchemeGroupVersion.String()
+	DiskResourcePolicyBindingGroupVersionKind = SchemeGroupVersion.WithKind(DiskResourcePolicyBindingKind)
+)
+
+// BulkInstance type metadata.
+var (
+	BulkInstanceKind             = reflect.TypeOf(BulkInstance{}).Name()
+	BulkInstanceGroupKind        = schema.GroupKind{Group: Group, Kind: BulkInstanceKind}.String()
+	BulkInstanceKindAPIVersion   = BulkInstanceKind + "." + SchemeGroupVersion.String()
+	BulkInstanceGroupVersionKind = SchemeGroupVersion.WithKind(BulkInstanceKind)
+)
+
+// BackendBucket type metadata.
+var (
+	BackendBucketKind             = reflect.TypeOf(BackendBucket{}).Name()
+	BackendBucketGroupKind        = schema.GroupKind{Group: Group, Kind: BackendBucketKind}.String()
+	BackendBucketKindAPIVersion   = BackendBucketKind + "." + SchemeGroupVersion.String()
+	BackendBucketGroupVersionKind = SchemeGroupVersion.WithKind(BackendBucketKind)
+)
+
+// RouterInterface type metadata.
+var (
+	RouterInterfaceKind             = reflect.TypeOf(RouterInterface{}).Name()
+	RouterInterfaceGroupKind        = schema.GroupKind{Group: Group, Kind: RouterInterfaceKind}.String()
+	RouterInterfaceKindAPIVersion   = RouterInterfaceKind + "." + SchemeGroupVersion.String()
+	RouterInterfaceGroupVersionKind = SchemeGroupVersion.WithKind(RouterInterfaceKind)
+)
+
+// RouterPeer type metadata.
+var (
+	RouterPeerKind             = reflect.TypeOf(RouterPeer{}).Name()
+	RouterPeerGroupKind        = schema.GroupKind{Group: Group, Kind: RouterPeerKind}.String()
+	RouterPeerKindAPIVersion   = RouterPeerKind + "." + SchemeGroupVersion.String()
+	RouterPeerGroupVersionKind = SchemeGroupVersion.WithKind(RouterPeerKind)
+)
+
+// TargetInstance type metadata.
+var (
+	TargetInstanceKind             = reflect.TypeOf(TargetInstance{}).Name()
+	TargetInstanceGroupKind        = schema.GroupKind{Group: Group, Kind: TargetInstanceKind}.String()
+	TargetInstanceKindAPIVersion   = TargetInstanceKind + "." + SchemeGroupVersion.String()
+	TargetInstanceGroupVersionKind = SchemeGroupVersion.WithKind(TargetInstanceKind)
+)
+
+// InterconnectAttachment type metadata.
+var (
+	InterconnectAttachmentKind             = reflect.TypeOf(InterconnectAttachment{}).Name()
+	InterconnectAttachmentGroupKind        = schema.GroupKind{Group: Group, Kind: InterconnectAttachmentKind}.String()
+	InterconnectAttachmentKindAPIVersion   = InterconnectAttachmentKind + "." + SchemeGroupVersion.String()
+	InterconnectAttachmentGroupVersionKind = SchemeGroupVersion.WithKind(InterconnectAttachmentKind)
+)
+
+// RegionBackendService type metadata.
+var (
+	RegionBackendServiceKind             = reflect.TypeOf(RegionBackendService{}).Name()
+	RegionBackendServiceGroupKind        = schema.GroupKind{Group: Group, Kind: RegionBackendServiceKind}.String()
+	RegionBackendServiceKindAPIVersion   = RegionBackendServiceKind + "." + SchemeGroupVersion.String()
+	RegionBackendServiceGroupVersionKind = SchemeGroupVersion.WithKind(RegionBackendServiceKind)
+)
+
+// ForwardingRule type metadata.
+var (
+	ForwardingRuleKind             = reflect.TypeOf(ForwardingRule{}).Name()
+	ForwardingRuleGroupKind        = schema.GroupKind{Group: Group, Kind: ForwardingRuleKind}.String()
+	ForwardingRuleKindAPIVersion   = ForwardingRuleKind + "." + SchemeGroupVersion.String()
+	ForwardingRuleGroupVersionKind = SchemeGroupVersion.WithKind(ForwardingRuleKind)
+)
+
+// Instance type metadata.
+var (
+	InstanceKind             = reflect.TypeOf(Instance{}).Name()
+	InstanceGroupKind        = schema.GroupKind{Group: Group, Kind: InstanceKind}.String()
+	InstanceKindAPIVersion   = InstanceKind + "." + SchemeGroupVersion.String()
+	InstanceGroupVersionKind = SchemeGroupVersion.WithKind(InstanceKind)
+)
+
+// SSLCertificate type metadata.
+var (
+	SSLCertificateKind             = reflect.TypeOf(SSLCertificate{}).Name()
+	SSLCertificateGroupKind        = schema.GroupKind{Group: Group, Kind: SSLCertificateKind}.String()
+	SSLCertificateKindAPIVersion   = SSLCertificateKind + "." + SchemeGroupVersion.String()
+	SSLCertificateGroupVersionKind = SchemeGroupVersion.WithKind(SSLCertificateKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&Firewall{}, &FirewallList{})
+	SchemeBuilder.Register(&ProjectSettings{}, &ProjectSettingsList{})
+	SchemeBuilder.Register(&ResourcePolicy{}, &ResourcePolicyList{})
+	SchemeBuilder.Register(&DiskResourcePolicyBinding{}, &DiskResourcePolicyBindingList{})
+	SchemeBuilder.Register(&BulkInstance{}, &BulkInstanceList{})
+	SchemeBuilder.Register(&BackendBucket{}, &BackendBucketList{})
+	SchemeBuilder.Register(&RouterInterface{}, &RouterInterfaceList{})
+	SchemeBuilder.Register(&RouterPeer{}, &RouterPeerList{})
+	SchemeBuilder.Register(&TargetInstance{}, &TargetInstanceList{})
+	SchemeBuilder.Register(&InterconnectAttachment{}, &InterconnectAttachmentList{})
+	SchemeBuilder.Register(&RegionBackendService{}, &RegionBackendServiceList{})
+	SchemeBuilder.Register(&ForwardingRule{}, &ForwardingRuleList{})
+	SchemeBuilder.Register(&Instance{}, &InstanceList{})
+	SchemeBuilder.Register(&SSLCertificate{}, &SSLCertificateList{})
 }
@@ -0,0 +1,219 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// ResourcePolicy states.
+const (
+	// ResourcePolicyStatusCreating means the resource policy is being created.
+	ResourcePolicyStatusCreating = "CREATING"
+	// ResourcePolicyStatusDeleting means the resource policy is being deleted.
+	ResourcePolicyStatusDeleting = "DELETING"
+	// ResourcePolicyStatusReady means the resource policy is ready for use.
+	ResourcePolicyStatusReady = "READY"
+)
+
+// ResourcePolicyParameters define the desired state of a Google Compute Engine
+// resource policy. Resource policies can not be updated once created. Most
+// fields map directly to a ResourcePolicy:
+// https://cloud.google.com/compute/docs/reference/rest/v1/resourcePolicies
+type ResourcePolicyParameters struct {
+	// Region: Name of the region of the resource policy.
+	// +immutable
+	Region string `json:"region"`
+
+	// Description: An optional description of this resource.
+	// +optional
+	// +immutable
+	Description *string `json:"description,omitempty"`
+
+	// SnapshotSchedulePolicy: Resource policy for persistent disks for
+	// creating snapshots.
+	// +immutable
+	SnapshotSchedulePolicy *ResourcePolicySnapshotSchedulePolicy `json:"snapshotSchedulePolicy"`
+}
+
+// ResourcePolicySnapshotSchedulePolicy specifies when snapshots of the disks
+// a resource policy is attached to are created, and how long they are kept.
+type ResourcePolicySnapshotSchedulePolicy struct {
+	// RetentionPolicy: Retention policy applied to snapshots created by
+	// this resource policy.
+	// +optional
+	RetentionPolicy *ResourcePolicySnapshotSchedulePolicyRetentionPolicy `json:"retentionPolicy,omitempty"`
+
+	// Schedule: A schedule that is applied to disks covered by this policy.
+	Schedule ResourcePolicySnapshotSchedulePolicySchedule `json:"schedule"`
+
+	// SnapshotProperties: Properties with which snapshots are created such
+	// as labels and storage locations.
+	// +optional
+	SnapshotProperties *ResourcePolicySnapshotSchedulePolicySnapshotProperties `json:"snapshotProperties,omitempty"`
+}
+
+// ResourcePolicySnapshotSchedulePolicyRetentionPolicy is the policy for
+// retention of scheduled snapshots.
+type ResourcePolicySnapshotSchedulePolicyRetentionPolicy struct {
+	// MaxRetentionDays: Maximum age of the snapshot that is allowed to be
+	// kept.
+	MaxRetentionDays int64 `json:"maxRetentionDays"`
+
+	// OnSourceDiskDelete: Specifies the behavior to apply to scheduled
+	// snapshots when the source disk is deleted.
+	// +optional
+	// +kubebuilder:validation:Enum=APPLY_RETENTION_POLICY;KEEP_AUTO_SNAPSHOTS
+	OnSourceDiskDelete *string `json:"onSourceDiskDelete,omitempty"`
+}
+
+// ResourcePolicySnapshotSchedulePolicySchedule is a schedule for disks where
+// the scheduled operations are performed. Exactly one of the daily, hourly or
+// weekly schedules must be set.
+type ResourcePolicySnapshotSchedulePolicySchedule struct {
+	// DailySchedule: Creates a snapshot every given number of days.
+	// +optional
+	DailySchedule *ResourcePolicyDailyCycle `json:"dailySchedule,omitempty"`
+
+	// HourlySchedule: Creates a snapshot every given number of hours.
+	// +optional
+	HourlySchedule *ResourcePolicyHourlyCycle `json:"hourlySchedule,omitempty"`
+
+	// WeeklySchedule: Creates a snapshot on the given days of the week.
+	// +optional
+	WeeklySchedule *ResourcePolicyWeeklyCycle `json:"weeklySchedule,omitempty"`
+}
+
+// ResourcePolicyDailyCycle is a time window specified for daily operations.
+type ResourcePolicyDailyCycle struct {
+	// DaysInCycle: Defines a schedule with units measured in days. The
+	// value determines how many days pass between the start of each cycle.
+	DaysInCycle int64 `json:"daysInCycle"`
+
+	// StartTime: Start time of the window. This must be in UTC format that
+	// resolves to one of 00:00, 04:00, 08:00, 12:00, 16:00, or 20:00. For
+	// example, both 13:00-5 and 08:00 are valid.
+	StartTime string `json:"startTime"`
+}
+
+// ResourcePolicyHourlyCycle is a time window specified for hourly operations.
+type ResourcePolicyHourlyCycle struct {
+	// HoursInCycle: Defines a schedule with units measured in hours. The
+	// value determines how many hours pass between the start of each cycle.
+	HoursInCycle int64 `json:"hoursInCycle"`
+
+	// StartTime: Time within the window to start the operations. It must be
+	// in format "HH:MM", where HH : [00-23] and MM : [00-00] GMT.
+	StartTime string `json:"startTime"`
+}
+
+// ResourcePolicyWeeklyCycle is a time window specified for weekly operations.
+type ResourcePolicyWeeklyCycle struct {
+	// DayOfWeeks: Up to 7 intervals/windows, one for each day of the week.
+	// +kubebuilder:validation:MaxItems=7
+	DayOfWeeks []ResourcePolicyWeeklyCycleDayOfWeek `json:"dayOfWeeks"`
+}
+
+// ResourcePolicyWeeklyCycleDayOfWeek is the time window of a single day of the
+// week.
+type ResourcePolicyWeeklyCycleDayOfWeek struct {
+	// Day: Defines a schedule that runs on specific days of the week.
+	// +kubebuilder:validation:Enum=MONDAY;TUESDAY;WEDNESDAY;THURSDAY;FRIDAY;SATURDAY;SUNDAY
+	Day string `json:"day"`
+
+	// StartTime: Time within the window to start the operations. It must be
+	// in format "HH:MM", where HH : [00-23] and MM : [00-00] GMT.
+	StartTime string `json:"startTime"`
+}
+
+// ResourcePolicySnapshotSchedulePolicySnapshotProperties are the properties of
+// scheduled snapshots created by a resource policy.
+type ResourcePolicySnapshotSchedulePolicySnapshotProperties struct {
+	// ChainName: Chain name that the snapshot is created in.
+	// +optional
+	ChainName *string `json:"chainName,omitempty"`
+
+	// GuestFlush: Indication to perform a 'guest aware' snapshot.
+	// +optional
+	GuestFlush *bool `json:"guestFlush,omitempty"`
+
+	// Labels: Labels to apply to scheduled snapshots.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// StorageLocations: Cloud Storage bucket storage location of the auto
+	// snapshot (regional or multi-regional).
+	// +optional
+	StorageLocations []string `json:"storageLocations,omitempty"`
+}
+
+// A ResourcePolicyObservation represents the observed state of a Google
+// Compute Engine resource policy.
+type ResourcePolicyObservation struct {
+	// CreationTimestamp: Creation timestamp in RFC3339 text format.
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+
+	// ID: The unique identifier for the resource. This identifier is
+	// defined by the server.
+	ID uint64 `json:"id,omitempty"`
+
+	// SelfLink: Server-defined fully-qualified URL for this resource.
+	SelfLink string `json:"selfLink,omitempty"`
+
+	// Status: The status of resource policy creation.
+	Status string `json:"status,omitempty"`
+}
+
+// A ResourcePolicySpec defines the desired state of a ResourcePolicy.
+type ResourcePolicySpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ResourcePolicyParameters `json:"forProvider"`
+}
+
+// A ResourcePolicyStatus represents the observed state of a ResourcePolicy.
+type ResourcePolicyStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ResourcePolicyObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ResourcePolicy is a managed resource that represents a Google Compute
+// Engine resource policy, e.g. a snapshot schedule for persistent disks.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.status"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type ResourcePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ResourcePolicySpec   `json:"spec"`
+	Status ResourcePolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ResourcePolicyList contains a list of ResourcePolicy.
+type ResourcePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ResourcePolicy `json:"items"`
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// RouterInterfaceParameters define the desired state of an interface of a
+// Google Compute Engine Cloud Router. Interfaces are part of the router's
+// configuration, which must already exist:
+// https://cloud.google.com/compute/docs/reference/rest/v1/routers
+type RouterInterfaceParameters struct {
+	// Region: Name of the region the Cloud Router resides in.
+	// +immutable
+	Region string `json:"region"`
+
+	// Router: Name of the Cloud Router the interface belongs to.
+	// +immutable
+	Router string `json:"router"`
+
+	// IPRange: IP address and range of the interface. The IP range must be
+	// in the RFC3927 link-local IP address space, e.g. 169.254.0.1/30.
+	// +optional
+	IPRange *string `json:"ipRange,omitempty"`
+
+	// LinkedVPNTunnel: URI of the linked VPN tunnel, which must be in the
+	// same region as the router. Each interface can have one linked
+	// resource, which can be a VPN tunnel or an interconnect attachment.
+	// +optional
+	LinkedVPNTunnel *string `json:"linkedVpnTunnel,omitempty"`
+
+	// LinkedInterconnectAttachment: URI of the linked interconnect
+	// attachment, which must be in the same region as the router.
+	// +optional
+	LinkedInterconnectAttachment *string `json:"linkedInterconnectAttachment,omitempty"`
+}
+
+// A RouterInterfaceObservation represents the observed state of an interface
+// of a Google Compute Engine Cloud Router.
+type RouterInterfaceObservation struct {
+	// ManagementType: The resource that configures and manages this
+	// interface, i.e. MANAGED_BY_USER or MANAGED_BY_ATTACHMENT.
+	ManagementType string `json:"managementType,omitempty"`
+}
+
+// A RouterInterfaceSpec defines the desired state of a RouterInterface.
+type RouterInterfaceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RouterInterfaceParameters `json:"forProvider"`
+}
+
+// A RouterInterfaceStatus represents the observed state of a RouterInterface.
+type RouterInterfaceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          RouterInterfaceObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RouterInterface is a managed resource that represents an interface of a
+// Google Compute Engine Cloud Router.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="ROUTER",type="string",JSONPath=".spec.forProvider.router"
+// +kubebuilder:printcolumn:name="IP-RANGE",type="string",JSONPath=".spec.forProvider.ipRange"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type RouterInterface struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RouterInterfaceSpec   `json:"spec"`
+	Status RouterInterfaceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RouterInterfaceList contains a list of RouterInterface.
+type RouterInterfaceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RouterInterface `json:"items"`
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// RouterPeerParameters define the desired state of a BGP peer of a Google
+// Compute Engine Cloud Router. BGP peers are part of the router's
+// configuration, which must already exist:
+// https://cloud.google.com/compute/docs/reference/rest/v1/routers
+type RouterPeerParameters struct {
+	// Region: Name of the region the Cloud Router resides in.
+	// +immutable
+	Region string `json:"region"`
+
+	// Router: Name of the Cloud Router the BGP peer belongs to.
+	// +immutable
+	Router string `json:"router"`
+
+	// InterfaceName: Name of the interface the BGP peer is associated with.
+	// +optional
+	InterfaceName *string `json:"interfaceName,omitempty"`
+
+	// InterfaceNameRef references a RouterInterface to retrieve its name.
+	// +optional
+	InterfaceNameRef *xpv1.Reference `json:"interfaceNameRef,omitempty"`
+
+	// InterfaceNameSelector selects a reference to a RouterInterface to
+	// retrieve its name.
+	// +optional
+	InterfaceNameSelector *xpv1.Selector `json:"interfaceNameSelector,omitempty"`
+
+	// IPAddress: IP address of the interface inside Google Cloud Platform.
+	// Only IPv4 is supported.
+	// +optional
+	IPAddress *string `json:"ipAddress,omitempty"`
+
+	// PeerIPAddress: IP address of the BGP interface outside Google Cloud
+	// Platform. Only IPv4 is supported.
+	// +optional
+	PeerIPAddress *string `json:"peerIpAddress,omitempty"`
+
+	// PeerASN: Peer BGP Autonomous System Number (ASN). Each BGP interface
+	// may use a different value.
+	PeerASN int64 `json:"peerAsn"`
+
+	// AdvertisedRoutePriority: The priority of routes advertised to this BGP
+	// peer. Where there is more than one matching route of maximum length,
+	// the routes with the lowest priority value win.
+	// +optional
+	AdvertisedRoutePriority *int64 `json:"advertisedRoutePriority,omitempty"`
+
+	// AdvertiseMode: User-specified flag to indicate which mode to use for
+	// advertisement.
+	// +optional
+	// +kubebuilder:validation:Enum=CUSTOM;DEFAULT
+	AdvertiseMode *string `json:"advertiseMode,omitempty"`
+
+	// AdvertisedGroups: User-specified list of prefix groups to advertise in
+	// custom mode, which can take one of the following options: ALL_SUBNETS,
+	// which advertises all available subnets, including peer VPC subnets.
+	// This field can only be populated if advertiseMode is CUSTOM.
+	// +optional
+	AdvertisedGroups []string `json:"advertisedGroups,omitempty"`
+
+	// AdvertisedIPRanges: User-specified list of individual IP ranges to
+	// advertise in custom mode. This field can only be populated if
+	// advertiseMode is CUSTOM.
+	// +optional
+	AdvertisedIPRanges []RouterAdvertisedIPRange `json:"advertisedIpRanges,omitempty"`
+}
+
+// A RouterAdvertisedIPRange is an IP range that is advertised to a BGP peer.
+type RouterAdvertisedIPRange struct {
+	// Range: The IP range to advertise. The value must be a CIDR-formatted
+	// string.
+	Range string `json:"range"`
+
+	// Description: User-specified description for the IP range.
+	// +optional
+	Description *string `json:"description,omitempty"`
+}
+
+// A RouterPeerObservation represents the observed state of a BGP peer of a
+// Google Compute Engine Cloud Router.
+type RouterPeerObservation struct {
+	// ManagementType: The resource that configures and manages this BGP
+	// peer, i.e. MANAGED_BY_USER or MANAGED_BY_ATTACHMENT.
+	ManagementType string `json:"managementType,omitempty"`
+}
+
+// A RouterPeerSpec defines the desired state of a RouterPeer.
+type RouterPeerSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       RouterPeerParameters `json:"forProvider"`
+}
+
+// A RouterPeerStatus represents the observed state of a RouterPeer.
+type RouterPeerStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          RouterPeerObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RouterPeer is a managed resource that represents a BGP peer of a Google
+// Compute Engine Cloud Router.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="ROUTER",type="string",JSONPath=".spec.forProvider.router"
+// +kubebuilder:printcolumn:name="PEER-ASN",type="integer",JSONPath=".spec.forProvider.peerAsn"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type RouterPeer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RouterPeerSpec   `json:"spec"`
+	Status RouterPeerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RouterPeerList contains a list of RouterPeer.
+type RouterPeerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RouterPeer `json:"items"`
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// SSLCertificateParameters define the desired state of a Google-managed
+// Google Compute Engine SSL certificate, which target HTTPS and SSL proxies
+// present to their clients. Most fields map directly to an SslCertificate:
+// https://cloud.google.com/compute/docs/reference/rest/v1/sslCertificates
+type SSLCertificateParameters struct {
+	// Managed: The configuration of the Google-managed certificate.
+	// +immutable
+	Managed ManagedSSLCertificate `json:"managed"`
+
+	// Description: An optional description of this resource.
+	// +optional
+	// +immutable
+	Description *string `json:"description,omitempty"`
+}
+
+// ManagedSSLCertificate configures a certificate that Google provisions and
+// renews.
+type ManagedSSLCertificate struct {
+	// Domains: The domains the certificate is provisioned for. Domains can
+	// not be changed once the certificate exists; annotate the
+	// SSLCertificate with gcp.crossplane.io/recreate-on-immutable: "true" to
+	// have it recreated when they are.
+	// +immutable
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=100
+	Domains []string `json:"domains"`
+}
+
+// An SSLCertificateObservation represents the observed state of a Google
+// Compute Engine SSL certificate.
+type SSLCertificateObservation struct {
+	// CreationTimestamp: Creation timestamp in RFC3339 text format.
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+
+	// ExpireTime: Expire time of the certificate in RFC3339 text format.
+	ExpireTime string `json:"expireTime,omitempty"`
+
+	// ID: The unique identifier for the resource. This identifier is
+	// defined by the server.
+	ID uint64 `json:"id,omitempty"`
+
+	// SelfLink: Server-defined fully-qualified URL for this resource.
+	SelfLink string `json:"selfLink,omitempty"`
+
+	// ManagedStatus: The provisioning status of the certificate, e.g.
+	// PROVISIONING or ACTIVE.
+	ManagedStatus string `json:"managedStatus,omitempty"`
+
+	// DomainStatus: The provisioning status of each domain of the
+	// certificate.
+	DomainStatus map[string]string `json:"domainStatus,omitempty"`
+
+	// SubjectAlternativeNames: The domains the certificate was issued for.
+	SubjectAlternativeNames []string `json:"subjectAlternativeNames,omitempty"`
+}
+
+// An SSLCertificateSpec defines the desired state of an SSLCertificate.
+type SSLCertificateSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SSLCertificateParameters `json:"forProvider"`
+}
+
+// An SSLCertificateStatus represents the observed state of an SSLCertificate.
+type SSLCertificateStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          SSLCertificateObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An SSLCertificate is a managed resource that represents a Google-managed
+// Google Compute Engine SSL certificate.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.managedStatus"
+// +kubebuilder:printcolumn:name="EXPIRES",type="string",JSONPath=".status.atProvider.expireTime"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type SSLCertificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SSLCertificateSpec   `json:"spec"`
+	Status SSLCertificateStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SSLCertificateList contains a list of SSLCertificate.
+type SSLCertificateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SSLCertificate `json:"items"`
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TargetInstanceParameters define the desired state of a Google Compute
+// Engine target instance, i.e. a single instance that forwarding rules may
+// forward traffic to. Target instances can not be updated once created. Most
+// fields map directly to a TargetInstance:
+// https://cloud.google.com/compute/docs/reference/rest/v1/targetInstances
+type TargetInstanceParameters struct {
+	// Zone: Name of the zone of the target instance and of the instance it
+	// forwards traffic to.
+	// +immutable
+	Zone string `json:"zone"`
+
+	// Instance: The full or partial URL of the instance that handles
+	// traffic forwarded to the target instance, e.g.
+	// zones/us-central1-a/instances/my-instance, or its name.
+	// +immutable
+	Instance string `json:"instance"`
+
+	// NatPolicy: NAT option controlling how IPs are NAT'ed to the
+	// instance. Currently only NO_NAT (default value) is supported.
+	// +optional
+	// +immutable
+	// +kubebuilder:validation:Enum=NO_NAT
+	NatPolicy *string `json:"natPolicy,omitempty"`
+
+	// Description: An optional description of this resource.
+	// +optional
+	// +immutable
+	Description *string `json:"description,omitempty"`
+}
+
+// A TargetInstanceObservation represents the observed state of a Google
+// Compute Engine target instance.
+type TargetInstanceObservation struct {
+	// CreationTimestamp: Creation timestamp in RFC3339 text format.
+	CreationTimestamp string `json:"creationTimestamp,omitempty"`
+
+	// ID: The unique identifier for the resource. This identifier is
+	// defined by the server.
+	ID uint64 `json:"id,omitempty"`
+
+	// SelfLink: Server-defined fully-qualified URL for this resource.
+	SelfLink string `json:"selfLink,omitempty"`
+}
+
+// A TargetInstanceSpec defines the desired state of a TargetInstance.
+type TargetInstanceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TargetInstanceParameters `json:"forProvider"`
+}
+
+// A TargetInstanceStatus represents the observed state of a TargetInstance.
+type TargetInstanceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          TargetInstanceObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A TargetInstance is a managed resource that represents a Google Compute
+// Engine target instance, which forwarding rules use for protocol forwarding
+// to a single instance.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="ZONE",type="string",JSONPath=".spec.forProvider.zone"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type TargetInstance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TargetInstanceSpec   `json:"spec"`
+	Status TargetInstanceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TargetInstanceList contains a list of TargetInstance.
+type TargetInstanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TargetInstance `json:"items"`
+}
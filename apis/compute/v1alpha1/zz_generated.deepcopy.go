@@ -25,6 +25,645 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AcceleratorConfig) DeepCopyInto(out *AcceleratorConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AcceleratorConfig.
+func (in *AcceleratorConfig) DeepCopy() *AcceleratorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AcceleratorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AttachedDisk) DeepCopyInto(out *AttachedDisk) {
+	*out = *in
+	if in.Boot != nil {
+		in, out := &in.Boot, &out.Boot
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DeviceName != nil {
+		in, out := &in.DeviceName, &out.DeviceName
+		*out = new(string)
+		**out = **in
+	}
+	if in.AutoDelete != nil {
+		in, out := &in.AutoDelete, &out.AutoDelete
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(string)
+		**out = **in
+	}
+	if in.InitializeParams != nil {
+		in, out := &in.InitializeParams, &out.InitializeParams
+		*out = new(AttachedDiskInitializeParams)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttachedDisk.
+func (in *AttachedDisk) DeepCopy() *AttachedDisk {
+	if in == nil {
+		return nil
+	}
+	out := new(AttachedDisk)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AttachedDiskInitializeParams) DeepCopyInto(out *AttachedDiskInitializeParams) {
+	*out = *in
+	if in.SourceImage != nil {
+		in, out := &in.SourceImage, &out.SourceImage
+		*out = new(string)
+		**out = **in
+	}
+	if in.DiskSizeGb != nil {
+		in, out := &in.DiskSizeGb, &out.DiskSizeGb
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DiskType != nil {
+		in, out := &in.DiskType, &out.DiskType
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttachedDiskInitializeParams.
+func (in *AttachedDiskInitializeParams) DeepCopy() *AttachedDiskInitializeParams {
+	if in == nil {
+		return nil
+	}
+	out := new(AttachedDiskInitializeParams)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Backend) DeepCopyInto(out *Backend) {
+	*out = *in
+	if in.BalancingMode != nil {
+		in, out := &in.BalancingMode, &out.BalancingMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Backend.
+func (in *Backend) DeepCopy() *Backend {
+	if in == nil {
+		return nil
+	}
+	out := new(Backend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendBucket) DeepCopyInto(out *BackendBucket) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendBucket.
+func (in *BackendBucket) DeepCopy() *BackendBucket {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendBucket)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackendBucket) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendBucketCDNPolicy) DeepCopyInto(out *BackendBucketCDNPolicy) {
+	*out = *in
+	if in.CacheMode != nil {
+		in, out := &in.CacheMode, &out.CacheMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.ClientTTL != nil {
+		in, out := &in.ClientTTL, &out.ClientTTL
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DefaultTTL != nil {
+		in, out := &in.DefaultTTL, &out.DefaultTTL
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxTTL != nil {
+		in, out := &in.MaxTTL, &out.MaxTTL
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NegativeCaching != nil {
+		in, out := &in.NegativeCaching, &out.NegativeCaching
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RequestCoalescing != nil {
+		in, out := &in.RequestCoalescing, &out.RequestCoalescing
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ServeWhileStale != nil {
+		in, out := &in.ServeWhileStale, &out.ServeWhileStale
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SignedURLCacheMaxAgeSec != nil {
+		in, out := &in.SignedURLCacheMaxAgeSec, &out.SignedURLCacheMaxAgeSec
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendBucketCDNPolicy.
+func (in *BackendBucketCDNPolicy) DeepCopy() *BackendBucketCDNPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendBucketCDNPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendBucketList) DeepCopyInto(out *BackendBucketList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BackendBucket, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendBucketList.
+func (in *BackendBucketList) DeepCopy() *BackendBucketList {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendBucketList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackendBucketList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendBucketObservation) DeepCopyInto(out *BackendBucketObservation) {
+	*out = *in
+	if in.SignedURLKeyNames != nil {
+		in, out := &in.SignedURLKeyNames, &out.SignedURLKeyNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendBucketObservation.
+func (in *BackendBucketObservation) DeepCopy() *BackendBucketObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendBucketObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendBucketParameters) DeepCopyInto(out *BackendBucketParameters) {
+	*out = *in
+	if in.BucketName != nil {
+		in, out := &in.BucketName, &out.BucketName
+		*out = new(string)
+		**out = **in
+	}
+	if in.BucketNameRef != nil {
+		in, out := &in.BucketNameRef, &out.BucketNameRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.BucketNameSelector != nil {
+		in, out := &in.BucketNameSelector, &out.BucketNameSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.EnableCDN != nil {
+		in, out := &in.EnableCDN, &out.EnableCDN
+		*out = new(bool)
+		**out = **in
+	}
+	if in.CDNPolicy != nil {
+		in, out := &in.CDNPolicy, &out.CDNPolicy
+		*out = new(BackendBucketCDNPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SignedURLKeys != nil {
+		in, out := &in.SignedURLKeys, &out.SignedURLKeys
+		*out = make([]SignedURLKey, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendBucketParameters.
+func (in *BackendBucketParameters) DeepCopy() *BackendBucketParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendBucketParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendBucketSpec) DeepCopyInto(out *BackendBucketSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendBucketSpec.
+func (in *BackendBucketSpec) DeepCopy() *BackendBucketSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendBucketSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackendBucketStatus) DeepCopyInto(out *BackendBucketStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendBucketStatus.
+func (in *BackendBucketStatus) DeepCopy() *BackendBucketStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackendBucketStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BulkInstance) DeepCopyInto(out *BulkInstance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkInstance.
+func (in *BulkInstance) DeepCopy() *BulkInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(BulkInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BulkInstance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BulkInstanceList) DeepCopyInto(out *BulkInstanceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BulkInstance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkInstanceList.
+func (in *BulkInstanceList) DeepCopy() *BulkInstanceList {
+	if in == nil {
+		return nil
+	}
+	out := new(BulkInstanceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BulkInstanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BulkInstanceObservation) DeepCopyInto(out *BulkInstanceObservation) {
+	*out = *in
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkInstanceObservation.
+func (in *BulkInstanceObservation) DeepCopy() *BulkInstanceObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(BulkInstanceObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BulkInstanceParameters) DeepCopyInto(out *BulkInstanceParameters) {
+	*out = *in
+	if in.MinCount != nil {
+		in, out := &in.MinCount, &out.MinCount
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkInstanceParameters.
+func (in *BulkInstanceParameters) DeepCopy() *BulkInstanceParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(BulkInstanceParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BulkInstanceSpec) DeepCopyInto(out *BulkInstanceSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkInstanceSpec.
+func (in *BulkInstanceSpec) DeepCopy() *BulkInstanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BulkInstanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BulkInstanceStatus) DeepCopyInto(out *BulkInstanceStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BulkInstanceStatus.
+func (in *BulkInstanceStatus) DeepCopy() *BulkInstanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BulkInstanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfidentialInstanceConfig) DeepCopyInto(out *ConfidentialInstanceConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfidentialInstanceConfig.
+func (in *ConfidentialInstanceConfig) DeepCopy() *ConfidentialInstanceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfidentialInstanceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionDraining) DeepCopyInto(out *ConnectionDraining) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionDraining.
+func (in *ConnectionDraining) DeepCopy() *ConnectionDraining {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionDraining)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskResourcePolicyBinding) DeepCopyInto(out *DiskResourcePolicyBinding) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskResourcePolicyBinding.
+func (in *DiskResourcePolicyBinding) DeepCopy() *DiskResourcePolicyBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskResourcePolicyBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DiskResourcePolicyBinding) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskResourcePolicyBindingList) DeepCopyInto(out *DiskResourcePolicyBindingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DiskResourcePolicyBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskResourcePolicyBindingList.
+func (in *DiskResourcePolicyBindingList) DeepCopy() *DiskResourcePolicyBindingList {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskResourcePolicyBindingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DiskResourcePolicyBindingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskResourcePolicyBindingObservation) DeepCopyInto(out *DiskResourcePolicyBindingObservation) {
+	*out = *in
+	if in.ResourcePolicies != nil {
+		in, out := &in.ResourcePolicies, &out.ResourcePolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskResourcePolicyBindingObservation.
+func (in *DiskResourcePolicyBindingObservation) DeepCopy() *DiskResourcePolicyBindingObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskResourcePolicyBindingObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskResourcePolicyBindingParameters) DeepCopyInto(out *DiskResourcePolicyBindingParameters) {
+	*out = *in
+	if in.ResourcePolicies != nil {
+		in, out := &in.ResourcePolicies, &out.ResourcePolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourcePolicyRefs != nil {
+		in, out := &in.ResourcePolicyRefs, &out.ResourcePolicyRefs
+		*out = make([]v1.Reference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourcePolicySelector != nil {
+		in, out := &in.ResourcePolicySelector, &out.ResourcePolicySelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskResourcePolicyBindingParameters.
+func (in *DiskResourcePolicyBindingParameters) DeepCopy() *DiskResourcePolicyBindingParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskResourcePolicyBindingParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskResourcePolicyBindingSpec) DeepCopyInto(out *DiskResourcePolicyBindingSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskResourcePolicyBindingSpec.
+func (in *DiskResourcePolicyBindingSpec) DeepCopy() *DiskResourcePolicyBindingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskResourcePolicyBindingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskResourcePolicyBindingStatus) DeepCopyInto(out *DiskResourcePolicyBindingStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskResourcePolicyBindingStatus.
+func (in *DiskResourcePolicyBindingStatus) DeepCopy() *DiskResourcePolicyBindingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskResourcePolicyBindingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Firewall) DeepCopyInto(out *Firewall) {
 	*out = *in
@@ -34,18 +673,1798 @@ func (in *Firewall) DeepCopyInto(out *Firewall) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Firewall.
-func (in *Firewall) DeepCopy() *Firewall {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Firewall.
+func (in *Firewall) DeepCopy() *Firewall {
+	if in == nil {
+		return nil
+	}
+	out := new(Firewall)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Firewall) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallAllowed) DeepCopyInto(out *FirewallAllowed) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallAllowed.
+func (in *FirewallAllowed) DeepCopy() *FirewallAllowed {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallAllowed)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallDenied) DeepCopyInto(out *FirewallDenied) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallDenied.
+func (in *FirewallDenied) DeepCopy() *FirewallDenied {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallDenied)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallList) DeepCopyInto(out *FirewallList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Firewall, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallList.
+func (in *FirewallList) DeepCopy() *FirewallList {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FirewallList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallLogConfig) DeepCopyInto(out *FirewallLogConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallLogConfig.
+func (in *FirewallLogConfig) DeepCopy() *FirewallLogConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallLogConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallObservation) DeepCopyInto(out *FirewallObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallObservation.
+func (in *FirewallObservation) DeepCopy() *FirewallObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallParameters) DeepCopyInto(out *FirewallParameters) {
+	*out = *in
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(string)
+		**out = **in
+	}
+	if in.NetworkRef != nil {
+		in, out := &in.NetworkRef, &out.NetworkRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.NetworkSelector != nil {
+		in, out := &in.NetworkSelector, &out.NetworkSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SourceRanges != nil {
+		in, out := &in.SourceRanges, &out.SourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DestinationRanges != nil {
+		in, out := &in.DestinationRanges, &out.DestinationRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SourceTags != nil {
+		in, out := &in.SourceTags, &out.SourceTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TargetTags != nil {
+		in, out := &in.TargetTags, &out.TargetTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SourceServiceAccounts != nil {
+		in, out := &in.SourceServiceAccounts, &out.SourceServiceAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TargetServiceAccounts != nil {
+		in, out := &in.TargetServiceAccounts, &out.TargetServiceAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Allowed != nil {
+		in, out := &in.Allowed, &out.Allowed
+		*out = make([]*FirewallAllowed, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(FirewallAllowed)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Denied != nil {
+		in, out := &in.Denied, &out.Denied
+		*out = make([]*FirewallDenied, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(FirewallDenied)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Direction != nil {
+		in, out := &in.Direction, &out.Direction
+		*out = new(string)
+		**out = **in
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LogConfig != nil {
+		in, out := &in.LogConfig, &out.LogConfig
+		*out = new(FirewallLogConfig)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallParameters.
+func (in *FirewallParameters) DeepCopy() *FirewallParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallSpec) DeepCopyInto(out *FirewallSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallSpec.
+func (in *FirewallSpec) DeepCopy() *FirewallSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallStatus) DeepCopyInto(out *FirewallStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallStatus.
+func (in *FirewallStatus) DeepCopy() *FirewallStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardingRule) DeepCopyInto(out *ForwardingRule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardingRule.
+func (in *ForwardingRule) DeepCopy() *ForwardingRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardingRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ForwardingRule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardingRuleList) DeepCopyInto(out *ForwardingRuleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ForwardingRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardingRuleList.
+func (in *ForwardingRuleList) DeepCopy() *ForwardingRuleList {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardingRuleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ForwardingRuleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardingRuleObservation) DeepCopyInto(out *ForwardingRuleObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardingRuleObservation.
+func (in *ForwardingRuleObservation) DeepCopy() *ForwardingRuleObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardingRuleObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardingRuleParameters) DeepCopyInto(out *ForwardingRuleParameters) {
+	*out = *in
+	if in.IPAddress != nil {
+		in, out := &in.IPAddress, &out.IPAddress
+		*out = new(string)
+		**out = **in
+	}
+	if in.IPProtocol != nil {
+		in, out := &in.IPProtocol, &out.IPProtocol
+		*out = new(string)
+		**out = **in
+	}
+	if in.AllPorts != nil {
+		in, out := &in.AllPorts, &out.AllPorts
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PortRange != nil {
+		in, out := &in.PortRange, &out.PortRange
+		*out = new(string)
+		**out = **in
+	}
+	if in.BackendService != nil {
+		in, out := &in.BackendService, &out.BackendService
+		*out = new(string)
+		**out = **in
+	}
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(string)
+		**out = **in
+	}
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(string)
+		**out = **in
+	}
+	if in.Subnetwork != nil {
+		in, out := &in.Subnetwork, &out.Subnetwork
+		*out = new(string)
+		**out = **in
+	}
+	if in.AllowGlobalAccess != nil {
+		in, out := &in.AllowGlobalAccess, &out.AllowGlobalAccess
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardingRuleParameters.
+func (in *ForwardingRuleParameters) DeepCopy() *ForwardingRuleParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardingRuleParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardingRuleSpec) DeepCopyInto(out *ForwardingRuleSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardingRuleSpec.
+func (in *ForwardingRuleSpec) DeepCopy() *ForwardingRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardingRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardingRuleStatus) DeepCopyInto(out *ForwardingRuleStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardingRuleStatus.
+func (in *ForwardingRuleStatus) DeepCopy() *ForwardingRuleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardingRuleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Instance) DeepCopyInto(out *Instance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Instance.
+func (in *Instance) DeepCopy() *Instance {
+	if in == nil {
+		return nil
+	}
+	out := new(Instance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Instance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceList) DeepCopyInto(out *InstanceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Instance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceList.
+func (in *InstanceList) DeepCopy() *InstanceList {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InstanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceObservation) DeepCopyInto(out *InstanceObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceObservation.
+func (in *InstanceObservation) DeepCopy() *InstanceObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceParameters) DeepCopyInto(out *InstanceParameters) {
+	*out = *in
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = make([]AttachedDisk, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NetworkInterfaces != nil {
+		in, out := &in.NetworkInterfaces, &out.NetworkInterfaces
+		*out = make([]NetworkInterface, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = new(Scheduling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GuestAccelerators != nil {
+		in, out := &in.GuestAccelerators, &out.GuestAccelerators
+		*out = make([]AcceleratorConfig, len(*in))
+		copy(*out, *in)
+	}
+	if in.ShieldedInstanceConfig != nil {
+		in, out := &in.ShieldedInstanceConfig, &out.ShieldedInstanceConfig
+		*out = new(ShieldedInstanceConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfidentialInstanceConfig != nil {
+		in, out := &in.ConfidentialInstanceConfig, &out.ConfidentialInstanceConfig
+		*out = new(ConfidentialInstanceConfig)
+		**out = **in
+	}
+	if in.AllowStopForUpdate != nil {
+		in, out := &in.AllowStopForUpdate, &out.AllowStopForUpdate
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceParameters.
+func (in *InstanceParameters) DeepCopy() *InstanceParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceSpec) DeepCopyInto(out *InstanceSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceSpec.
+func (in *InstanceSpec) DeepCopy() *InstanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceStatus) DeepCopyInto(out *InstanceStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceStatus.
+func (in *InstanceStatus) DeepCopy() *InstanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterconnectAttachment) DeepCopyInto(out *InterconnectAttachment) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterconnectAttachment.
+func (in *InterconnectAttachment) DeepCopy() *InterconnectAttachment {
+	if in == nil {
+		return nil
+	}
+	out := new(InterconnectAttachment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InterconnectAttachment) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterconnectAttachmentList) DeepCopyInto(out *InterconnectAttachmentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]InterconnectAttachment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterconnectAttachmentList.
+func (in *InterconnectAttachmentList) DeepCopy() *InterconnectAttachmentList {
+	if in == nil {
+		return nil
+	}
+	out := new(InterconnectAttachmentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InterconnectAttachmentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterconnectAttachmentObservation) DeepCopyInto(out *InterconnectAttachmentObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterconnectAttachmentObservation.
+func (in *InterconnectAttachmentObservation) DeepCopy() *InterconnectAttachmentObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(InterconnectAttachmentObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterconnectAttachmentParameters) DeepCopyInto(out *InterconnectAttachmentParameters) {
+	*out = *in
+	if in.Interconnect != nil {
+		in, out := &in.Interconnect, &out.Interconnect
+		*out = new(string)
+		**out = **in
+	}
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+	if in.VlanTag8021q != nil {
+		in, out := &in.VlanTag8021q, &out.VlanTag8021q
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Bandwidth != nil {
+		in, out := &in.Bandwidth, &out.Bandwidth
+		*out = new(string)
+		**out = **in
+	}
+	if in.AdminEnabled != nil {
+		in, out := &in.AdminEnabled, &out.AdminEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EdgeAvailabilityDomain != nil {
+		in, out := &in.EdgeAvailabilityDomain, &out.EdgeAvailabilityDomain
+		*out = new(string)
+		**out = **in
+	}
+	if in.CandidateSubnets != nil {
+		in, out := &in.CandidateSubnets, &out.CandidateSubnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterconnectAttachmentParameters.
+func (in *InterconnectAttachmentParameters) DeepCopy() *InterconnectAttachmentParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(InterconnectAttachmentParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterconnectAttachmentSpec) DeepCopyInto(out *InterconnectAttachmentSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterconnectAttachmentSpec.
+func (in *InterconnectAttachmentSpec) DeepCopy() *InterconnectAttachmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InterconnectAttachmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InterconnectAttachmentStatus) DeepCopyInto(out *InterconnectAttachmentStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InterconnectAttachmentStatus.
+func (in *InterconnectAttachmentStatus) DeepCopy() *InterconnectAttachmentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InterconnectAttachmentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedSSLCertificate) DeepCopyInto(out *ManagedSSLCertificate) {
+	*out = *in
+	if in.Domains != nil {
+		in, out := &in.Domains, &out.Domains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedSSLCertificate.
+func (in *ManagedSSLCertificate) DeepCopy() *ManagedSSLCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedSSLCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkInterface) DeepCopyInto(out *NetworkInterface) {
+	*out = *in
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(string)
+		**out = **in
+	}
+	if in.Subnetwork != nil {
+		in, out := &in.Subnetwork, &out.Subnetwork
+		*out = new(string)
+		**out = **in
+	}
+	if in.NetworkIP != nil {
+		in, out := &in.NetworkIP, &out.NetworkIP
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInterface.
+func (in *NetworkInterface) DeepCopy() *NetworkInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectSettings) DeepCopyInto(out *ProjectSettings) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectSettings.
+func (in *ProjectSettings) DeepCopy() *ProjectSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProjectSettings) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectSettingsList) DeepCopyInto(out *ProjectSettingsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ProjectSettings, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectSettingsList.
+func (in *ProjectSettingsList) DeepCopy() *ProjectSettingsList {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectSettingsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ProjectSettingsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectSettingsObservation) DeepCopyInto(out *ProjectSettingsObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectSettingsObservation.
+func (in *ProjectSettingsObservation) DeepCopy() *ProjectSettingsObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectSettingsObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectSettingsParameters) DeepCopyInto(out *ProjectSettingsParameters) {
+	*out = *in
+	if in.DefaultNetworkTier != nil {
+		in, out := &in.DefaultNetworkTier, &out.DefaultNetworkTier
+		*out = new(string)
+		**out = **in
+	}
+	if in.UsageExportBucket != nil {
+		in, out := &in.UsageExportBucket, &out.UsageExportBucket
+		*out = new(string)
+		**out = **in
+	}
+	if in.UsageExportBucketRef != nil {
+		in, out := &in.UsageExportBucketRef, &out.UsageExportBucketRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.UsageExportBucketSelector != nil {
+		in, out := &in.UsageExportBucketSelector, &out.UsageExportBucketSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UsageExportReportNamePrefix != nil {
+		in, out := &in.UsageExportReportNamePrefix, &out.UsageExportReportNamePrefix
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectSettingsParameters.
+func (in *ProjectSettingsParameters) DeepCopy() *ProjectSettingsParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectSettingsParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectSettingsSpec) DeepCopyInto(out *ProjectSettingsSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectSettingsSpec.
+func (in *ProjectSettingsSpec) DeepCopy() *ProjectSettingsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectSettingsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectSettingsStatus) DeepCopyInto(out *ProjectSettingsStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectSettingsStatus.
+func (in *ProjectSettingsStatus) DeepCopy() *ProjectSettingsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectSettingsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegionBackendService) DeepCopyInto(out *RegionBackendService) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegionBackendService.
+func (in *RegionBackendService) DeepCopy() *RegionBackendService {
+	if in == nil {
+		return nil
+	}
+	out := new(RegionBackendService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RegionBackendService) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegionBackendServiceList) DeepCopyInto(out *RegionBackendServiceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RegionBackendService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegionBackendServiceList.
+func (in *RegionBackendServiceList) DeepCopy() *RegionBackendServiceList {
+	if in == nil {
+		return nil
+	}
+	out := new(RegionBackendServiceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RegionBackendServiceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegionBackendServiceObservation) DeepCopyInto(out *RegionBackendServiceObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegionBackendServiceObservation.
+func (in *RegionBackendServiceObservation) DeepCopy() *RegionBackendServiceObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RegionBackendServiceObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegionBackendServiceParameters) DeepCopyInto(out *RegionBackendServiceParameters) {
+	*out = *in
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(string)
+		**out = **in
+	}
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(string)
+		**out = **in
+	}
+	if in.HealthChecks != nil {
+		in, out := &in.HealthChecks, &out.HealthChecks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Backends != nil {
+		in, out := &in.Backends, &out.Backends
+		*out = make([]Backend, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConnectionDraining != nil {
+		in, out := &in.ConnectionDraining, &out.ConnectionDraining
+		*out = new(ConnectionDraining)
+		**out = **in
+	}
+	if in.SessionAffinity != nil {
+		in, out := &in.SessionAffinity, &out.SessionAffinity
+		*out = new(string)
+		**out = **in
+	}
+	if in.TimeoutSec != nil {
+		in, out := &in.TimeoutSec, &out.TimeoutSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegionBackendServiceParameters.
+func (in *RegionBackendServiceParameters) DeepCopy() *RegionBackendServiceParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RegionBackendServiceParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegionBackendServiceSpec) DeepCopyInto(out *RegionBackendServiceSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegionBackendServiceSpec.
+func (in *RegionBackendServiceSpec) DeepCopy() *RegionBackendServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RegionBackendServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegionBackendServiceStatus) DeepCopyInto(out *RegionBackendServiceStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegionBackendServiceStatus.
+func (in *RegionBackendServiceStatus) DeepCopy() *RegionBackendServiceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RegionBackendServiceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePolicy) DeepCopyInto(out *ResourcePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePolicy.
+func (in *ResourcePolicy) DeepCopy() *ResourcePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResourcePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePolicyDailyCycle) DeepCopyInto(out *ResourcePolicyDailyCycle) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePolicyDailyCycle.
+func (in *ResourcePolicyDailyCycle) DeepCopy() *ResourcePolicyDailyCycle {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePolicyDailyCycle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePolicyHourlyCycle) DeepCopyInto(out *ResourcePolicyHourlyCycle) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePolicyHourlyCycle.
+func (in *ResourcePolicyHourlyCycle) DeepCopy() *ResourcePolicyHourlyCycle {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePolicyHourlyCycle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePolicyList) DeepCopyInto(out *ResourcePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ResourcePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePolicyList.
+func (in *ResourcePolicyList) DeepCopy() *ResourcePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResourcePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePolicyObservation) DeepCopyInto(out *ResourcePolicyObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePolicyObservation.
+func (in *ResourcePolicyObservation) DeepCopy() *ResourcePolicyObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePolicyObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePolicyParameters) DeepCopyInto(out *ResourcePolicyParameters) {
+	*out = *in
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.SnapshotSchedulePolicy != nil {
+		in, out := &in.SnapshotSchedulePolicy, &out.SnapshotSchedulePolicy
+		*out = new(ResourcePolicySnapshotSchedulePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePolicyParameters.
+func (in *ResourcePolicyParameters) DeepCopy() *ResourcePolicyParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePolicyParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePolicySnapshotSchedulePolicy) DeepCopyInto(out *ResourcePolicySnapshotSchedulePolicy) {
+	*out = *in
+	if in.RetentionPolicy != nil {
+		in, out := &in.RetentionPolicy, &out.RetentionPolicy
+		*out = new(ResourcePolicySnapshotSchedulePolicyRetentionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Schedule.DeepCopyInto(&out.Schedule)
+	if in.SnapshotProperties != nil {
+		in, out := &in.SnapshotProperties, &out.SnapshotProperties
+		*out = new(ResourcePolicySnapshotSchedulePolicySnapshotProperties)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePolicySnapshotSchedulePolicy.
+func (in *ResourcePolicySnapshotSchedulePolicy) DeepCopy() *ResourcePolicySnapshotSchedulePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePolicySnapshotSchedulePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePolicySnapshotSchedulePolicyRetentionPolicy) DeepCopyInto(out *ResourcePolicySnapshotSchedulePolicyRetentionPolicy) {
+	*out = *in
+	if in.OnSourceDiskDelete != nil {
+		in, out := &in.OnSourceDiskDelete, &out.OnSourceDiskDelete
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePolicySnapshotSchedulePolicyRetentionPolicy.
+func (in *ResourcePolicySnapshotSchedulePolicyRetentionPolicy) DeepCopy() *ResourcePolicySnapshotSchedulePolicyRetentionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePolicySnapshotSchedulePolicyRetentionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePolicySnapshotSchedulePolicySchedule) DeepCopyInto(out *ResourcePolicySnapshotSchedulePolicySchedule) {
+	*out = *in
+	if in.DailySchedule != nil {
+		in, out := &in.DailySchedule, &out.DailySchedule
+		*out = new(ResourcePolicyDailyCycle)
+		**out = **in
+	}
+	if in.HourlySchedule != nil {
+		in, out := &in.HourlySchedule, &out.HourlySchedule
+		*out = new(ResourcePolicyHourlyCycle)
+		**out = **in
+	}
+	if in.WeeklySchedule != nil {
+		in, out := &in.WeeklySchedule, &out.WeeklySchedule
+		*out = new(ResourcePolicyWeeklyCycle)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePolicySnapshotSchedulePolicySchedule.
+func (in *ResourcePolicySnapshotSchedulePolicySchedule) DeepCopy() *ResourcePolicySnapshotSchedulePolicySchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePolicySnapshotSchedulePolicySchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePolicySnapshotSchedulePolicySnapshotProperties) DeepCopyInto(out *ResourcePolicySnapshotSchedulePolicySnapshotProperties) {
+	*out = *in
+	if in.ChainName != nil {
+		in, out := &in.ChainName, &out.ChainName
+		*out = new(string)
+		**out = **in
+	}
+	if in.GuestFlush != nil {
+		in, out := &in.GuestFlush, &out.GuestFlush
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.StorageLocations != nil {
+		in, out := &in.StorageLocations, &out.StorageLocations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePolicySnapshotSchedulePolicySnapshotProperties.
+func (in *ResourcePolicySnapshotSchedulePolicySnapshotProperties) DeepCopy() *ResourcePolicySnapshotSchedulePolicySnapshotProperties {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePolicySnapshotSchedulePolicySnapshotProperties)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePolicySpec) DeepCopyInto(out *ResourcePolicySpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePolicySpec.
+func (in *ResourcePolicySpec) DeepCopy() *ResourcePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePolicyStatus) DeepCopyInto(out *ResourcePolicyStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePolicyStatus.
+func (in *ResourcePolicyStatus) DeepCopy() *ResourcePolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePolicyWeeklyCycle) DeepCopyInto(out *ResourcePolicyWeeklyCycle) {
+	*out = *in
+	if in.DayOfWeeks != nil {
+		in, out := &in.DayOfWeeks, &out.DayOfWeeks
+		*out = make([]ResourcePolicyWeeklyCycleDayOfWeek, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePolicyWeeklyCycle.
+func (in *ResourcePolicyWeeklyCycle) DeepCopy() *ResourcePolicyWeeklyCycle {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePolicyWeeklyCycle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePolicyWeeklyCycleDayOfWeek) DeepCopyInto(out *ResourcePolicyWeeklyCycleDayOfWeek) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePolicyWeeklyCycleDayOfWeek.
+func (in *ResourcePolicyWeeklyCycleDayOfWeek) DeepCopy() *ResourcePolicyWeeklyCycleDayOfWeek {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePolicyWeeklyCycleDayOfWeek)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouterAdvertisedIPRange) DeepCopyInto(out *RouterAdvertisedIPRange) {
+	*out = *in
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterAdvertisedIPRange.
+func (in *RouterAdvertisedIPRange) DeepCopy() *RouterAdvertisedIPRange {
+	if in == nil {
+		return nil
+	}
+	out := new(RouterAdvertisedIPRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouterInterface) DeepCopyInto(out *RouterInterface) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterInterface.
+func (in *RouterInterface) DeepCopy() *RouterInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(RouterInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RouterInterface) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouterInterfaceList) DeepCopyInto(out *RouterInterfaceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RouterInterface, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterInterfaceList.
+func (in *RouterInterfaceList) DeepCopy() *RouterInterfaceList {
+	if in == nil {
+		return nil
+	}
+	out := new(RouterInterfaceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RouterInterfaceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouterInterfaceObservation) DeepCopyInto(out *RouterInterfaceObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterInterfaceObservation.
+func (in *RouterInterfaceObservation) DeepCopy() *RouterInterfaceObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RouterInterfaceObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouterInterfaceParameters) DeepCopyInto(out *RouterInterfaceParameters) {
+	*out = *in
+	if in.IPRange != nil {
+		in, out := &in.IPRange, &out.IPRange
+		*out = new(string)
+		**out = **in
+	}
+	if in.LinkedVPNTunnel != nil {
+		in, out := &in.LinkedVPNTunnel, &out.LinkedVPNTunnel
+		*out = new(string)
+		**out = **in
+	}
+	if in.LinkedInterconnectAttachment != nil {
+		in, out := &in.LinkedInterconnectAttachment, &out.LinkedInterconnectAttachment
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterInterfaceParameters.
+func (in *RouterInterfaceParameters) DeepCopy() *RouterInterfaceParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RouterInterfaceParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouterInterfaceSpec) DeepCopyInto(out *RouterInterfaceSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterInterfaceSpec.
+func (in *RouterInterfaceSpec) DeepCopy() *RouterInterfaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RouterInterfaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouterInterfaceStatus) DeepCopyInto(out *RouterInterfaceStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterInterfaceStatus.
+func (in *RouterInterfaceStatus) DeepCopy() *RouterInterfaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RouterInterfaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouterPeer) DeepCopyInto(out *RouterPeer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterPeer.
+func (in *RouterPeer) DeepCopy() *RouterPeer {
+	if in == nil {
+		return nil
+	}
+	out := new(RouterPeer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RouterPeer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouterPeerList) DeepCopyInto(out *RouterPeerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RouterPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterPeerList.
+func (in *RouterPeerList) DeepCopy() *RouterPeerList {
+	if in == nil {
+		return nil
+	}
+	out := new(RouterPeerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RouterPeerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouterPeerObservation) DeepCopyInto(out *RouterPeerObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterPeerObservation.
+func (in *RouterPeerObservation) DeepCopy() *RouterPeerObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RouterPeerObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouterPeerParameters) DeepCopyInto(out *RouterPeerParameters) {
+	*out = *in
+	if in.InterfaceName != nil {
+		in, out := &in.InterfaceName, &out.InterfaceName
+		*out = new(string)
+		**out = **in
+	}
+	if in.InterfaceNameRef != nil {
+		in, out := &in.InterfaceNameRef, &out.InterfaceNameRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.InterfaceNameSelector != nil {
+		in, out := &in.InterfaceNameSelector, &out.InterfaceNameSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IPAddress != nil {
+		in, out := &in.IPAddress, &out.IPAddress
+		*out = new(string)
+		**out = **in
+	}
+	if in.PeerIPAddress != nil {
+		in, out := &in.PeerIPAddress, &out.PeerIPAddress
+		*out = new(string)
+		**out = **in
+	}
+	if in.AdvertisedRoutePriority != nil {
+		in, out := &in.AdvertisedRoutePriority, &out.AdvertisedRoutePriority
+		*out = new(int64)
+		**out = **in
+	}
+	if in.AdvertiseMode != nil {
+		in, out := &in.AdvertiseMode, &out.AdvertiseMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.AdvertisedGroups != nil {
+		in, out := &in.AdvertisedGroups, &out.AdvertisedGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdvertisedIPRanges != nil {
+		in, out := &in.AdvertisedIPRanges, &out.AdvertisedIPRanges
+		*out = make([]RouterAdvertisedIPRange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterPeerParameters.
+func (in *RouterPeerParameters) DeepCopy() *RouterPeerParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RouterPeerParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouterPeerSpec) DeepCopyInto(out *RouterPeerSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterPeerSpec.
+func (in *RouterPeerSpec) DeepCopy() *RouterPeerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RouterPeerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouterPeerStatus) DeepCopyInto(out *RouterPeerStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterPeerStatus.
+func (in *RouterPeerStatus) DeepCopy() *RouterPeerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RouterPeerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSLCertificate) DeepCopyInto(out *SSLCertificate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSLCertificate.
+func (in *SSLCertificate) DeepCopy() *SSLCertificate {
 	if in == nil {
 		return nil
 	}
-	out := new(Firewall)
+	out := new(SSLCertificate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Firewall) DeepCopyObject() runtime.Object {
+func (in *SSLCertificate) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -53,244 +2472,324 @@ func (in *Firewall) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FirewallAllowed) DeepCopyInto(out *FirewallAllowed) {
+func (in *SSLCertificateList) DeepCopyInto(out *SSLCertificateList) {
 	*out = *in
-	if in.Ports != nil {
-		in, out := &in.Ports, &out.Ports
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SSLCertificate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallAllowed.
-func (in *FirewallAllowed) DeepCopy() *FirewallAllowed {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSLCertificateList.
+func (in *SSLCertificateList) DeepCopy() *SSLCertificateList {
 	if in == nil {
 		return nil
 	}
-	out := new(FirewallAllowed)
+	out := new(SSLCertificateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SSLCertificateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FirewallDenied) DeepCopyInto(out *FirewallDenied) {
+func (in *SSLCertificateObservation) DeepCopyInto(out *SSLCertificateObservation) {
 	*out = *in
-	if in.Ports != nil {
-		in, out := &in.Ports, &out.Ports
+	if in.DomainStatus != nil {
+		in, out := &in.DomainStatus, &out.DomainStatus
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SubjectAlternativeNames != nil {
+		in, out := &in.SubjectAlternativeNames, &out.SubjectAlternativeNames
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallDenied.
-func (in *FirewallDenied) DeepCopy() *FirewallDenied {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSLCertificateObservation.
+func (in *SSLCertificateObservation) DeepCopy() *SSLCertificateObservation {
 	if in == nil {
 		return nil
 	}
-	out := new(FirewallDenied)
+	out := new(SSLCertificateObservation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FirewallList) DeepCopyInto(out *FirewallList) {
+func (in *SSLCertificateParameters) DeepCopyInto(out *SSLCertificateParameters) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Firewall, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.Managed.DeepCopyInto(&out.Managed)
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallList.
-func (in *FirewallList) DeepCopy() *FirewallList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSLCertificateParameters.
+func (in *SSLCertificateParameters) DeepCopy() *SSLCertificateParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(FirewallList)
+	out := new(SSLCertificateParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *FirewallList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FirewallLogConfig) DeepCopyInto(out *FirewallLogConfig) {
+func (in *SSLCertificateSpec) DeepCopyInto(out *SSLCertificateSpec) {
 	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallLogConfig.
-func (in *FirewallLogConfig) DeepCopy() *FirewallLogConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSLCertificateSpec.
+func (in *SSLCertificateSpec) DeepCopy() *SSLCertificateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FirewallLogConfig)
+	out := new(SSLCertificateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FirewallObservation) DeepCopyInto(out *FirewallObservation) {
+func (in *SSLCertificateStatus) DeepCopyInto(out *SSLCertificateStatus) {
 	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallObservation.
-func (in *FirewallObservation) DeepCopy() *FirewallObservation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSLCertificateStatus.
+func (in *SSLCertificateStatus) DeepCopy() *SSLCertificateStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(FirewallObservation)
+	out := new(SSLCertificateStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FirewallParameters) DeepCopyInto(out *FirewallParameters) {
+func (in *Scheduling) DeepCopyInto(out *Scheduling) {
 	*out = *in
-	if in.Description != nil {
-		in, out := &in.Description, &out.Description
-		*out = new(string)
+	if in.Preemptible != nil {
+		in, out := &in.Preemptible, &out.Preemptible
+		*out = new(bool)
 		**out = **in
 	}
-	if in.Network != nil {
-		in, out := &in.Network, &out.Network
-		*out = new(string)
+	if in.AutomaticRestart != nil {
+		in, out := &in.AutomaticRestart, &out.AutomaticRestart
+		*out = new(bool)
 		**out = **in
 	}
-	if in.NetworkRef != nil {
-		in, out := &in.NetworkRef, &out.NetworkRef
-		*out = new(v1.Reference)
+	if in.OnHostMaintenance != nil {
+		in, out := &in.OnHostMaintenance, &out.OnHostMaintenance
+		*out = new(string)
 		**out = **in
 	}
-	if in.NetworkSelector != nil {
-		in, out := &in.NetworkSelector, &out.NetworkSelector
-		*out = new(v1.Selector)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Scheduling.
+func (in *Scheduling) DeepCopy() *Scheduling {
+	if in == nil {
+		return nil
 	}
-	if in.Priority != nil {
-		in, out := &in.Priority, &out.Priority
-		*out = new(int64)
+	out := new(Scheduling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ShieldedInstanceConfig) DeepCopyInto(out *ShieldedInstanceConfig) {
+	*out = *in
+	if in.EnableSecureBoot != nil {
+		in, out := &in.EnableSecureBoot, &out.EnableSecureBoot
+		*out = new(bool)
 		**out = **in
 	}
-	if in.SourceRanges != nil {
-		in, out := &in.SourceRanges, &out.SourceRanges
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.EnableVtpm != nil {
+		in, out := &in.EnableVtpm, &out.EnableVtpm
+		*out = new(bool)
+		**out = **in
 	}
-	if in.DestinationRanges != nil {
-		in, out := &in.DestinationRanges, &out.DestinationRanges
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.EnableIntegrityMonitoring != nil {
+		in, out := &in.EnableIntegrityMonitoring, &out.EnableIntegrityMonitoring
+		*out = new(bool)
+		**out = **in
 	}
-	if in.SourceTags != nil {
-		in, out := &in.SourceTags, &out.SourceTags
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShieldedInstanceConfig.
+func (in *ShieldedInstanceConfig) DeepCopy() *ShieldedInstanceConfig {
+	if in == nil {
+		return nil
 	}
-	if in.TargetTags != nil {
-		in, out := &in.TargetTags, &out.TargetTags
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	out := new(ShieldedInstanceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SignedURLKey) DeepCopyInto(out *SignedURLKey) {
+	*out = *in
+	out.KeyValueSecretRef = in.KeyValueSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SignedURLKey.
+func (in *SignedURLKey) DeepCopy() *SignedURLKey {
+	if in == nil {
+		return nil
 	}
-	if in.SourceServiceAccounts != nil {
-		in, out := &in.SourceServiceAccounts, &out.SourceServiceAccounts
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	out := new(SignedURLKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetInstance) DeepCopyInto(out *TargetInstance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetInstance.
+func (in *TargetInstance) DeepCopy() *TargetInstance {
+	if in == nil {
+		return nil
 	}
-	if in.TargetServiceAccounts != nil {
-		in, out := &in.TargetServiceAccounts, &out.TargetServiceAccounts
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	out := new(TargetInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TargetInstance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	if in.Allowed != nil {
-		in, out := &in.Allowed, &out.Allowed
-		*out = make([]*FirewallAllowed, len(*in))
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetInstanceList) DeepCopyInto(out *TargetInstanceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TargetInstance, len(*in))
 		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(FirewallAllowed)
-				(*in).DeepCopyInto(*out)
-			}
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Denied != nil {
-		in, out := &in.Denied, &out.Denied
-		*out = make([]*FirewallDenied, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(FirewallDenied)
-				(*in).DeepCopyInto(*out)
-			}
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetInstanceList.
+func (in *TargetInstanceList) DeepCopy() *TargetInstanceList {
+	if in == nil {
+		return nil
 	}
-	if in.Direction != nil {
-		in, out := &in.Direction, &out.Direction
-		*out = new(string)
-		**out = **in
+	out := new(TargetInstanceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TargetInstanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	if in.Disabled != nil {
-		in, out := &in.Disabled, &out.Disabled
-		*out = new(bool)
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetInstanceObservation) DeepCopyInto(out *TargetInstanceObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetInstanceObservation.
+func (in *TargetInstanceObservation) DeepCopy() *TargetInstanceObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetInstanceObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetInstanceParameters) DeepCopyInto(out *TargetInstanceParameters) {
+	*out = *in
+	if in.NatPolicy != nil {
+		in, out := &in.NatPolicy, &out.NatPolicy
+		*out = new(string)
 		**out = **in
 	}
-	if in.LogConfig != nil {
-		in, out := &in.LogConfig, &out.LogConfig
-		*out = new(FirewallLogConfig)
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallParameters.
-func (in *FirewallParameters) DeepCopy() *FirewallParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetInstanceParameters.
+func (in *TargetInstanceParameters) DeepCopy() *TargetInstanceParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(FirewallParameters)
+	out := new(TargetInstanceParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FirewallSpec) DeepCopyInto(out *FirewallSpec) {
+func (in *TargetInstanceSpec) DeepCopyInto(out *TargetInstanceSpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallSpec.
-func (in *FirewallSpec) DeepCopy() *FirewallSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetInstanceSpec.
+func (in *TargetInstanceSpec) DeepCopy() *TargetInstanceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FirewallSpec)
+	out := new(TargetInstanceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FirewallStatus) DeepCopyInto(out *FirewallStatus) {
+func (in *TargetInstanceStatus) DeepCopyInto(out *TargetInstanceStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 	out.AtProvider = in.AtProvider
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallStatus.
-func (in *FirewallStatus) DeepCopy() *FirewallStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetInstanceStatus.
+func (in *TargetInstanceStatus) DeepCopy() *TargetInstanceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(FirewallStatus)
+	out := new(TargetInstanceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
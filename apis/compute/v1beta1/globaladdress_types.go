@@ -84,6 +84,19 @@ type GlobalAddressParameters struct {
 	// +immutable
 	NetworkSelector *xpv1.Selector `json:"networkSelector,omitempty"`
 
+	// NetworkTier: This signifies the networking tier used for configuring
+	// this address and can only take the following values: PREMIUM or
+	// STANDARD. Global forwarding rules can only be Premium Tier. If this
+	// field is not specified, it is assumed to be PREMIUM.
+	//
+	// Possible values:
+	//   "PREMIUM"
+	//   "STANDARD"
+	// +optional
+	// +immutable
+	// +kubebuilder:validation:Enum=PREMIUM;STANDARD
+	NetworkTier *string `json:"networkTier,omitempty"`
+
 	// PrefixLength: The prefix length if the resource represents an IP
 	// range.
 	// +optional
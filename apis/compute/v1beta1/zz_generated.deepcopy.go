@@ -142,6 +142,11 @@ func (in *GlobalAddressParameters) DeepCopyInto(out *GlobalAddressParameters) {
 		*out = new(v1.Selector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NetworkTier != nil {
+		in, out := &in.NetworkTier, &out.NetworkTier
+		*out = new(string)
+		**out = **in
+	}
 	if in.PrefixLength != nil {
 		in, out := &in.PrefixLength, &out.PrefixLength
 		*out = new(int64)
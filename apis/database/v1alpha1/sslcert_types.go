@@ -0,0 +1,119 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Connection secret keys of an SSLCert.
+const (
+	SSLCertSecretClientCertificateKey   = "clientCertificate"
+	SSLCertSecretClientPrivateKeyKey    = "clientPrivateKey"
+	SSLCertSecretServerCACertificateKey = "serverCACertificate"
+)
+
+// SSLCertParameters defines parameters for a desired CloudSQL client
+// certificate:
+// https://cloud.google.com/sql/docs/mysql/admin-api/rest/v1beta4/sslCerts
+// The SHA1 fingerprint of the certificate is stored as the value of the
+// `crossplane.io/external-name` annotation once it is created.
+type SSLCertParameters struct {
+	// Instance: The name of the CloudSQLInstance the client certificate is
+	// issued for.
+	// +optional
+	// +immutable
+	Instance *string `json:"instance,omitempty"`
+
+	// InstanceRef references a CloudSQLInstance and retrieves its name.
+	// +optional
+	// +immutable
+	InstanceRef *xpv1.Reference `json:"instanceRef,omitempty"`
+
+	// InstanceSelector selects a reference to a CloudSQLInstance.
+	// +optional
+	InstanceSelector *xpv1.Selector `json:"instanceSelector,omitempty"`
+
+	// CommonName: User supplied name. Must be a distinct name from the
+	// other certificates for this instance.
+	// +immutable
+	CommonName string `json:"commonName"`
+}
+
+// SSLCertObservation is used to show the observed state of the SSLCert
+// resource on GCP. All fields in this structure should only be populated from
+// GCP responses; any changes made to the k8s resource outside of the crossplane
+// gcp controller will be ignored and overwritten.
+type SSLCertObservation struct {
+	// CertSerialNumber: Serial number, as extracted from the certificate.
+	CertSerialNumber string `json:"certSerialNumber,omitempty"`
+
+	// CreateTime: The time when the certificate was created in RFC 3339
+	// format.
+	CreateTime string `json:"createTime,omitempty"`
+
+	// ExpirationTime: The time when the certificate expires in RFC 3339
+	// format.
+	ExpirationTime string `json:"expirationTime,omitempty"`
+
+	// SelfLink: The URI of this resource.
+	SelfLink string `json:"selfLink,omitempty"`
+
+	// Sha1Fingerprint: Sha1 Fingerprint.
+	Sha1Fingerprint string `json:"sha1Fingerprint,omitempty"`
+}
+
+// SSLCertSpec defines the desired state of an SSLCert.
+type SSLCertSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SSLCertParameters `json:"forProvider"`
+}
+
+// SSLCertStatus represents the observed state of an SSLCert.
+type SSLCertStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          SSLCertObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SSLCert is a managed resource that represents a client certificate of a
+// Google CloudSQL instance. Its certificate and private key are written to
+// its connection secret when it is created.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXPIRATION",type="string",JSONPath=".status.atProvider.expirationTime"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type SSLCert struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SSLCertSpec   `json:"spec"`
+	Status SSLCertStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SSLCertList contains a list of SSLCert types
+type SSLCertList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SSLCert `json:"items"`
+}
@@ -217,7 +217,7 @@ type Settings struct {
 	UserLabels map[string]string `json:"userLabels,omitempty"`
 
 	// DatabaseFlags is the array of database flags passed to the instance at
-	// startup.
+	// startup. Note that changing some flags restarts the instance.
 	// +optional
 	DatabaseFlags []*DatabaseFlags `json:"databaseFlags,omitempty"`
 
@@ -265,6 +265,28 @@ type Settings struct {
 	// instances.
 	// +optional
 	StorageAutoResizeLimit *int64 `json:"storageAutoResizeLimit,omitempty"`
+
+	// InsightsConfig: The Query Insights configuration of the instance.
+	// +optional
+	InsightsConfig *InsightsConfig `json:"insightsConfig,omitempty"`
+}
+
+// InsightsConfig is the Query Insights configuration of an instance.
+type InsightsConfig struct {
+	// QueryInsightsEnabled: Whether Query Insights is enabled.
+	// +optional
+	QueryInsightsEnabled *bool `json:"queryInsightsEnabled,omitempty"`
+
+	// QueryStringLength: The maximum length of the query strings that are
+	// stored, in bytes. Longer query strings are truncated. Default value
+	// is 1024 bytes. Range: 256-4500 bytes.
+	// +optional
+	QueryStringLength *int64 `json:"queryStringLength,omitempty"`
+
+	// RecordApplicationTags: Whether Query Insights records the
+	// application tags of queries.
+	// +optional
+	RecordApplicationTags *bool `json:"recordApplicationTags,omitempty"`
 }
 
 // LocationPreference is preferred location. This specifies where a Cloud
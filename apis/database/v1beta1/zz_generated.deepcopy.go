@@ -431,6 +431,36 @@ func (in *IPMapping) DeepCopy() *IPMapping {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InsightsConfig) DeepCopyInto(out *InsightsConfig) {
+	*out = *in
+	if in.QueryInsightsEnabled != nil {
+		in, out := &in.QueryInsightsEnabled, &out.QueryInsightsEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.QueryStringLength != nil {
+		in, out := &in.QueryStringLength, &out.QueryStringLength
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RecordApplicationTags != nil {
+		in, out := &in.RecordApplicationTags, &out.RecordApplicationTags
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InsightsConfig.
+func (in *InsightsConfig) DeepCopy() *InsightsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(InsightsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocationPreference) DeepCopyInto(out *LocationPreference) {
 	*out = *in
@@ -597,6 +627,11 @@ func (in *Settings) DeepCopyInto(out *Settings) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.InsightsConfig != nil {
+		in, out := &in.InsightsConfig, &out.InsightsConfig
+		*out = new(InsightsConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Settings.
@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// PolicyParameters define the desired state of a Cloud DNS Policy
+// https://cloud.google.com/dns/docs/reference/v1/policies
+type PolicyParameters struct {
+	// Description: A mutable string of at most 1024 characters associated
+	// with this resource for the user's convenience. Has no effect on the
+	// policy's function.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// EnableInboundForwarding: Allows networks bound to this policy to
+	// receive DNS queries sent by VMs or applications over VPN connections.
+	// When enabled, a virtual IP address is allocated from each of the
+	// subnetworks that are bound to this policy.
+	// +optional
+	EnableInboundForwarding *bool `json:"enableInboundForwarding,omitempty"`
+
+	// EnableLogging: Controls whether logging is enabled for the networks
+	// bound to this policy. Defaults to no logging if not set.
+	// +optional
+	EnableLogging *bool `json:"enableLogging,omitempty"`
+
+	// AlternativeNameServerConfig: Sets an alternative name server for the
+	// associated networks. When specified, all DNS queries are forwarded to
+	// a name server that you choose. Names such as .internal are not
+	// available when an alternative name server is specified.
+	// +optional
+	AlternativeNameServerConfig *PolicyAlternativeNameServerConfig `json:"alternativeNameServerConfig,omitempty"`
+
+	// Networks: The partially qualified URLs of the VPC networks to which
+	// this policy is applied, e.g. projects/my-project/global/networks/my-network.
+	// +optional
+	Networks []string `json:"networks,omitempty"`
+
+	// NetworkRefs references Networks and retrieves their URLs.
+	// +optional
+	NetworkRefs []xpv1.Reference `json:"networkRefs,omitempty"`
+
+	// NetworkSelector selects references to Networks.
+	// +optional
+	NetworkSelector *xpv1.Selector `json:"networkSelector,omitempty"`
+}
+
+// PolicyAlternativeNameServerConfig sets the name servers DNS queries are
+// forwarded to.
+type PolicyAlternativeNameServerConfig struct {
+	// TargetNameServers: The name servers DNS queries are forwarded to.
+	TargetNameServers []PolicyTargetNameServer `json:"targetNameServers"`
+}
+
+// PolicyTargetNameServer is a name server DNS queries are forwarded to.
+type PolicyTargetNameServer struct {
+	// IPv4Address: IPv4 address to forward to.
+	IPv4Address string `json:"ipv4Address"`
+
+	// ForwardingPath: Forwarding path for this TargetNameServer. If unset
+	// or set to default, Cloud DNS makes forwarding decisions based on
+	// address ranges; that is, RFC1918 addresses go to the VPC network,
+	// non-RFC1918 addresses go to the internet. When set to private, Cloud
+	// DNS always sends queries through the VPC network for this target.
+	// +optional
+	// +kubebuilder:validation:Enum=default;private
+	ForwardingPath *string `json:"forwardingPath,omitempty"`
+}
+
+// PolicyObservation is used to show the observed state of the Policy
+type PolicyObservation struct {
+	// ID: Unique identifier for the resource; defined by the server.
+	ID uint64 `json:"id,omitempty"`
+}
+
+// PolicySpec defines the desired state of a Policy.
+type PolicySpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       PolicyParameters `json:"forProvider"`
+}
+
+// PolicyStatus represents the observed state of a Policy.
+type PolicyStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          PolicyObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// Policy is a managed resource that represents a Policy in Cloud DNS, a
+// collection of DNS rules applied to one or more VPC networks.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="INBOUND",type="boolean",JSONPath=".spec.forProvider.enableInboundForwarding"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Policy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PolicySpec   `json:"spec"`
+	Status PolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PolicyList contains a list of Policy
+type PolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Policy `json:"items"`
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1beta1"
+)
+
+// ResolveReferences of this Policy
+func (mg *Policy) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.networks
+	mrsp, err := r.ResolveMultiple(ctx, reference.MultiResolutionRequest{
+		CurrentValues: mg.Spec.ForProvider.Networks,
+		References:    mg.Spec.ForProvider.NetworkRefs,
+		Selector:      mg.Spec.ForProvider.NetworkSelector,
+		To:            reference.To{Managed: &v1beta1.Network{}, List: &v1beta1.NetworkList{}},
+		Extract:       v1beta1.NetworkURL(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.networks")
+	}
+	mg.Spec.ForProvider.Networks = mrsp.ResolvedValues
+	mg.Spec.ForProvider.NetworkRefs = mrsp.ResolvedReferences
+
+	return nil
+}
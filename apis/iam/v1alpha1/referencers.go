@@ -130,3 +130,24 @@ func (in *ServiceAccountPolicy) ResolveReferences(ctx context.Context, c client.
 
 	return nil
 }
+
+// ResolveReferences of this WorkloadIdentityPoolProvider
+func (in *WorkloadIdentityPoolProvider) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, in)
+
+	// Resolve spec.forProvider.workloadIdentityPool
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(in.Spec.ForProvider.WorkloadIdentityPool),
+		Reference:    in.Spec.ForProvider.WorkloadIdentityPoolRef,
+		Selector:     in.Spec.ForProvider.WorkloadIdentityPoolSelector,
+		To:           reference.To{Managed: &WorkloadIdentityPool{}, List: &WorkloadIdentityPoolList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.workloadIdentityPool")
+	}
+	in.Spec.ForProvider.WorkloadIdentityPool = reference.ToPtrValue(rsp.ResolvedValue)
+	in.Spec.ForProvider.WorkloadIdentityPoolRef = rsp.ResolvedReference
+
+	return nil
+}
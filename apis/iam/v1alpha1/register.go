@@ -61,8 +61,26 @@ var (
 	ServiceAccountPolicyGroupVersionKind = SchemeGroupVersion.WithKind(ServiceAccountPolicyKind)
 )
 
+// WorkloadIdentityPool type metadata.
+var (
+	WorkloadIdentityPoolKind             = reflect.TypeOf(WorkloadIdentityPool{}).Name()
+	WorkloadIdentityPoolGroupKind        = schema.GroupKind{Group: Group, Kind: WorkloadIdentityPoolKind}.String()
+	WorkloadIdentityPoolKindAPIVersion   = WorkloadIdentityPoolKind + "." + SchemeGroupVersion.String()
+	WorkloadIdentityPoolGroupVersionKind = SchemeGroupVersion.WithKind(WorkloadIdentityPoolKind)
+)
+
+// WorkloadIdentityPoolProvider type metadata.
+var (
+	WorkloadIdentityPoolProviderKind             = reflect.TypeOf(WorkloadIdentityPoolProvider{}).Name()
+	WorkloadIdentityPoolProviderGroupKind        = schema.GroupKind{Group: Group, Kind: WorkloadIdentityPoolProviderKind}.String()
+	WorkloadIdentityPoolProviderKindAPIVersion   = WorkloadIdentityPoolProviderKind + "." + SchemeGroupVersion.String()
+	WorkloadIdentityPoolProviderGroupVersionKind = SchemeGroupVersion.WithKind(WorkloadIdentityPoolProviderKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&ServiceAccount{}, &ServiceAccountList{},
 		&ServiceAccountKey{}, &ServiceAccountKeyList{},
-		&ServiceAccountPolicy{}, &ServiceAccountPolicyList{})
+		&ServiceAccountPolicy{}, &ServiceAccountPolicyList{},
+		&WorkloadIdentityPool{}, &WorkloadIdentityPoolList{},
+		&WorkloadIdentityPoolProvider{}, &WorkloadIdentityPoolProviderList{})
 }
@@ -38,6 +38,12 @@ type ServiceAccountParameters struct {
 	// service account. Must be less than or equal to 256 characters.
 	// +optional
 	Description *string `json:"description,omitempty"`
+
+	// Disabled specifies whether the service account is disabled. A service
+	// account that is disabled or enabled out of band is changed back to
+	// the desired state. The state is not managed if omitted.
+	// +optional
+	Disabled *bool `json:"disabled,omitempty"`
 }
 
 // ServiceAccountObservation is used to show the observed state of the
@@ -76,6 +82,12 @@ type ServiceAccountObservation struct {
 type ServiceAccountSpec struct {
 	xpv1.ResourceSpec `json:",inline"`
 	ForProvider       ServiceAccountParameters `json:"forProvider"`
+
+	// ConnectionDetailsMapping maps the connection detail keys published for
+	// this ServiceAccount, i.e. `email`, to the keys they should be written
+	// to in the connection secret.
+	// +optional
+	ConnectionDetailsMapping map[string]string `json:"connectionDetailsMapping,omitempty"`
 }
 
 // ServiceAccountStatus represents the observed state of a
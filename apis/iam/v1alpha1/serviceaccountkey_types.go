@@ -105,6 +105,13 @@ type ServiceAccountKeyObservation struct {
 type ServiceAccountKeySpec struct {
 	xpv1.ResourceSpec `json:",inline"`
 	ForProvider       ServiceAccountKeyParameters `json:"forProvider"`
+
+	// ConnectionDetailsMapping renames the keys of the connection details
+	// published by this resource. Each entry maps a default connection detail
+	// key, e.g. `privateKey`, to the key it is published as instead. Connection
+	// details without an entry keep their default key.
+	// +optional
+	ConnectionDetailsMapping map[string]string `json:"connectionDetailsMapping,omitempty"`
 }
 
 // ServiceAccountKeyStatus represents the observed state of a ServiceAccountKey.
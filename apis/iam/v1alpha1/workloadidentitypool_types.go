@@ -0,0 +1,112 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Workload identity pool and provider states.
+const (
+	// WorkloadIdentityStateActive means the pool or provider is active and
+	// can be used to exchange tokens.
+	WorkloadIdentityStateActive = "ACTIVE"
+	// WorkloadIdentityStateDeleted means the pool or provider was soft
+	// deleted. It can be undeleted for 30 days, after which it is purged.
+	WorkloadIdentityStateDeleted = "DELETED"
+)
+
+// WorkloadIdentityPoolParameters defines parameters for a desired IAM
+// WorkloadIdentityPool
+// https://cloud.google.com/iam/docs/reference/rest/v1/projects.locations.workloadIdentityPools
+// The ID of the pool (ie the `workloadIdentityPoolId` parameter of the Create
+// call) is determined by the value of the `crossplane.io/external-name`
+// annotation. Unless overridden by the user, this annotation is automatically
+// populated with the value of the `metadata.name` attribute.
+type WorkloadIdentityPoolParameters struct {
+	// DisplayName is an optional user-specified name for the pool. Must be
+	// less than or equal to 32 characters.
+	// +optional
+	// +kubebuilder:validation:MaxLength=32
+	DisplayName *string `json:"displayName,omitempty"`
+
+	// Description is an optional user-specified description of the pool.
+	// Must be less than or equal to 256 characters.
+	// +optional
+	// +kubebuilder:validation:MaxLength=256
+	Description *string `json:"description,omitempty"`
+
+	// Disabled specifies whether the pool is disabled. Credentials of a
+	// disabled pool cannot be exchanged for Google Cloud access tokens, but
+	// existing tokens remain valid until they expire.
+	// +optional
+	Disabled *bool `json:"disabled,omitempty"`
+}
+
+// WorkloadIdentityPoolObservation is used to show the observed state of the
+// WorkloadIdentityPool resource on GCP.
+type WorkloadIdentityPoolObservation struct {
+	// Name is the relative resource name of the pool in the following format:
+	// projects/{PROJECT_NUMBER}/locations/global/workloadIdentityPools/{external-name}.
+	Name string `json:"name,omitempty"`
+
+	// State is the state of the pool, i.e. ACTIVE or DELETED.
+	State string `json:"state,omitempty"`
+}
+
+// WorkloadIdentityPoolSpec defines the desired state of a
+// WorkloadIdentityPool.
+type WorkloadIdentityPoolSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       WorkloadIdentityPoolParameters `json:"forProvider,omitempty"`
+}
+
+// WorkloadIdentityPoolStatus represents the observed state of a
+// WorkloadIdentityPool.
+type WorkloadIdentityPoolStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          WorkloadIdentityPoolObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WorkloadIdentityPool is a managed resource that represents a Google IAM
+// Workload Identity Pool.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type WorkloadIdentityPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WorkloadIdentityPoolSpec   `json:"spec"`
+	Status WorkloadIdentityPoolStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WorkloadIdentityPoolList contains a list of WorkloadIdentityPool types
+type WorkloadIdentityPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkloadIdentityPool `json:"items"`
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// WorkloadIdentityPoolProviderParameters defines parameters for a desired IAM
+// WorkloadIdentityPoolProvider
+// https://cloud.google.com/iam/docs/reference/rest/v1/projects.locations.workloadIdentityPools.providers
+// The ID of the provider (ie the `workloadIdentityPoolProviderId` parameter
+// of the Create call) is determined by the value of the
+// `crossplane.io/external-name` annotation. Unless overridden by the user,
+// this annotation is automatically populated with the value of the
+// `metadata.name` attribute.
+// Exactly one of OIDC or AWS must be specified.
+type WorkloadIdentityPoolProviderParameters struct {
+	// WorkloadIdentityPool is the ID of the pool the provider belongs to.
+	// +optional
+	// +immutable
+	WorkloadIdentityPool *string `json:"workloadIdentityPool,omitempty"`
+
+	// WorkloadIdentityPoolRef references a WorkloadIdentityPool and
+	// retrieves its ID.
+	// +optional
+	// +immutable
+	WorkloadIdentityPoolRef *xpv1.Reference `json:"workloadIdentityPoolRef,omitempty"`
+
+	// WorkloadIdentityPoolSelector selects a reference to a
+	// WorkloadIdentityPool.
+	// +optional
+	WorkloadIdentityPoolSelector *xpv1.Selector `json:"workloadIdentityPoolSelector,omitempty"`
+
+	// DisplayName is an optional user-specified name for the provider. Must
+	// be less than or equal to 32 characters.
+	// +optional
+	// +kubebuilder:validation:MaxLength=32
+	DisplayName *string `json:"displayName,omitempty"`
+
+	// Description is an optional user-specified description of the
+	// provider. Must be less than or equal to 256 characters.
+	// +optional
+	// +kubebuilder:validation:MaxLength=256
+	Description *string `json:"description,omitempty"`
+
+	// Disabled specifies whether the provider is disabled. Credentials of a
+	// disabled provider cannot be exchanged for Google Cloud access tokens.
+	// +optional
+	Disabled *bool `json:"disabled,omitempty"`
+
+	// AttributeMapping maps attributes from the authentication credentials
+	// issued by the external identity provider to Google Cloud attributes,
+	// e.g. `google.subject: assertion.sub`. The `google.subject` attribute
+	// must be mapped for OIDC providers. AWS providers use a default mapping
+	// if omitted.
+	// +optional
+	AttributeMapping map[string]string `json:"attributeMapping,omitempty"`
+
+	// AttributeCondition is a Common Expression Language expression that
+	// must evaluate to true for a credential to be accepted, e.g.
+	// `'admins' in google.groups`.
+	// +optional
+	AttributeCondition *string `json:"attributeCondition,omitempty"`
+
+	// OIDC configures the provider to accept credentials of an OpenID
+	// Connect identity provider.
+	// +optional
+	OIDC *WorkloadIdentityPoolProviderOIDC `json:"oidc,omitempty"`
+
+	// AWS configures the provider to accept credentials of an Amazon Web
+	// Services account.
+	// +optional
+	AWS *WorkloadIdentityPoolProviderAWS `json:"aws,omitempty"`
+}
+
+// WorkloadIdentityPoolProviderOIDC represents an OpenID Connect identity
+// provider.
+type WorkloadIdentityPoolProviderOIDC struct {
+	// IssuerURI is the OIDC issuer URL. Must be an HTTPS endpoint.
+	IssuerURI string `json:"issuerUri"`
+
+	// AllowedAudiences are the values accepted for the `aud` field of the
+	// OIDC token. If omitted, the audience must be the full resource name of
+	// the provider, with or without the HTTPS prefix.
+	// +optional
+	AllowedAudiences []string `json:"allowedAudiences,omitempty"`
+}
+
+// WorkloadIdentityPoolProviderAWS represents an Amazon Web Services identity
+// provider.
+type WorkloadIdentityPoolProviderAWS struct {
+	// AccountID is the ID of the AWS account.
+	AccountID string `json:"accountId"`
+}
+
+// WorkloadIdentityPoolProviderObservation is used to show the observed state
+// of the WorkloadIdentityPoolProvider resource on GCP.
+type WorkloadIdentityPoolProviderObservation struct {
+	// Name is the relative resource name of the provider in the following
+	// format:
+	// projects/{PROJECT_NUMBER}/locations/global/workloadIdentityPools/{POOL_ID}/providers/{external-name}.
+	Name string `json:"name,omitempty"`
+
+	// State is the state of the provider, i.e. ACTIVE or DELETED.
+	State string `json:"state,omitempty"`
+}
+
+// WorkloadIdentityPoolProviderSpec defines the desired state of a
+// WorkloadIdentityPoolProvider.
+type WorkloadIdentityPoolProviderSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       WorkloadIdentityPoolProviderParameters `json:"forProvider"`
+}
+
+// WorkloadIdentityPoolProviderStatus represents the observed state of a
+// WorkloadIdentityPoolProvider.
+type WorkloadIdentityPoolProviderStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          WorkloadIdentityPoolProviderObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WorkloadIdentityPoolProvider is a managed resource that represents a Google
+// IAM Workload Identity Pool Provider.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="POOL",type="string",JSONPath=".spec.forProvider.workloadIdentityPool"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type WorkloadIdentityPoolProvider struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WorkloadIdentityPoolProviderSpec   `json:"spec"`
+	Status WorkloadIdentityPoolProviderStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WorkloadIdentityPoolProviderList contains a list of
+// WorkloadIdentityPoolProvider types
+type WorkloadIdentityPoolProviderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkloadIdentityPoolProvider `json:"items"`
+}
@@ -310,6 +310,13 @@ func (in *ServiceAccountKeySpec) DeepCopyInto(out *ServiceAccountKeySpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	if in.ConnectionDetailsMapping != nil {
+		in, out := &in.ConnectionDetailsMapping, &out.ConnectionDetailsMapping
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountKeySpec.
@@ -399,6 +406,11 @@ func (in *ServiceAccountParameters) DeepCopyInto(out *ServiceAccountParameters)
 		*out = new(string)
 		**out = **in
 	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountParameters.
@@ -555,6 +567,13 @@ func (in *ServiceAccountSpec) DeepCopyInto(out *ServiceAccountSpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	if in.ConnectionDetailsMapping != nil {
+		in, out := &in.ConnectionDetailsMapping, &out.ConnectionDetailsMapping
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountSpec.
@@ -583,3 +602,351 @@ func (in *ServiceAccountStatus) DeepCopy() *ServiceAccountStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentityPool) DeepCopyInto(out *WorkloadIdentityPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentityPool.
+func (in *WorkloadIdentityPool) DeepCopy() *WorkloadIdentityPool {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentityPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkloadIdentityPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentityPoolList) DeepCopyInto(out *WorkloadIdentityPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkloadIdentityPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentityPoolList.
+func (in *WorkloadIdentityPoolList) DeepCopy() *WorkloadIdentityPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentityPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkloadIdentityPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentityPoolObservation) DeepCopyInto(out *WorkloadIdentityPoolObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentityPoolObservation.
+func (in *WorkloadIdentityPoolObservation) DeepCopy() *WorkloadIdentityPoolObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentityPoolObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentityPoolParameters) DeepCopyInto(out *WorkloadIdentityPoolParameters) {
+	*out = *in
+	if in.DisplayName != nil {
+		in, out := &in.DisplayName, &out.DisplayName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentityPoolParameters.
+func (in *WorkloadIdentityPoolParameters) DeepCopy() *WorkloadIdentityPoolParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentityPoolParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentityPoolProvider) DeepCopyInto(out *WorkloadIdentityPoolProvider) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentityPoolProvider.
+func (in *WorkloadIdentityPoolProvider) DeepCopy() *WorkloadIdentityPoolProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentityPoolProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkloadIdentityPoolProvider) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentityPoolProviderAWS) DeepCopyInto(out *WorkloadIdentityPoolProviderAWS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentityPoolProviderAWS.
+func (in *WorkloadIdentityPoolProviderAWS) DeepCopy() *WorkloadIdentityPoolProviderAWS {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentityPoolProviderAWS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentityPoolProviderList) DeepCopyInto(out *WorkloadIdentityPoolProviderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkloadIdentityPoolProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentityPoolProviderList.
+func (in *WorkloadIdentityPoolProviderList) DeepCopy() *WorkloadIdentityPoolProviderList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentityPoolProviderList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkloadIdentityPoolProviderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentityPoolProviderOIDC) DeepCopyInto(out *WorkloadIdentityPoolProviderOIDC) {
+	*out = *in
+	if in.AllowedAudiences != nil {
+		in, out := &in.AllowedAudiences, &out.AllowedAudiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentityPoolProviderOIDC.
+func (in *WorkloadIdentityPoolProviderOIDC) DeepCopy() *WorkloadIdentityPoolProviderOIDC {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentityPoolProviderOIDC)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentityPoolProviderObservation) DeepCopyInto(out *WorkloadIdentityPoolProviderObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentityPoolProviderObservation.
+func (in *WorkloadIdentityPoolProviderObservation) DeepCopy() *WorkloadIdentityPoolProviderObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentityPoolProviderObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentityPoolProviderParameters) DeepCopyInto(out *WorkloadIdentityPoolProviderParameters) {
+	*out = *in
+	if in.WorkloadIdentityPool != nil {
+		in, out := &in.WorkloadIdentityPool, &out.WorkloadIdentityPool
+		*out = new(string)
+		**out = **in
+	}
+	if in.WorkloadIdentityPoolRef != nil {
+		in, out := &in.WorkloadIdentityPoolRef, &out.WorkloadIdentityPoolRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.WorkloadIdentityPoolSelector != nil {
+		in, out := &in.WorkloadIdentityPoolSelector, &out.WorkloadIdentityPoolSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DisplayName != nil {
+		in, out := &in.DisplayName, &out.DisplayName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AttributeMapping != nil {
+		in, out := &in.AttributeMapping, &out.AttributeMapping
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AttributeCondition != nil {
+		in, out := &in.AttributeCondition, &out.AttributeCondition
+		*out = new(string)
+		**out = **in
+	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(WorkloadIdentityPoolProviderOIDC)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(WorkloadIdentityPoolProviderAWS)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentityPoolProviderParameters.
+func (in *WorkloadIdentityPoolProviderParameters) DeepCopy() *WorkloadIdentityPoolProviderParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentityPoolProviderParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentityPoolProviderSpec) DeepCopyInto(out *WorkloadIdentityPoolProviderSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentityPoolProviderSpec.
+func (in *WorkloadIdentityPoolProviderSpec) DeepCopy() *WorkloadIdentityPoolProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentityPoolProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentityPoolProviderStatus) DeepCopyInto(out *WorkloadIdentityPoolProviderStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentityPoolProviderStatus.
+func (in *WorkloadIdentityPoolProviderStatus) DeepCopy() *WorkloadIdentityPoolProviderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentityPoolProviderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentityPoolSpec) DeepCopyInto(out *WorkloadIdentityPoolSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentityPoolSpec.
+func (in *WorkloadIdentityPoolSpec) DeepCopy() *WorkloadIdentityPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentityPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentityPoolStatus) DeepCopyInto(out *WorkloadIdentityPoolStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentityPoolStatus.
+func (in *WorkloadIdentityPoolStatus) DeepCopy() *WorkloadIdentityPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentityPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
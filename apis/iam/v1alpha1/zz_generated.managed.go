@@ -187,3 +187,115 @@ func (mg *ServiceAccountPolicy) SetProviderReference(r *xpv1.Reference) {
 func (mg *ServiceAccountPolicy) SetWriteConnectionSecretToReference(r *xpv1.SecretReference) {
 	mg.Spec.WriteConnectionSecretToReference = r
 }
+
+// GetCondition of this WorkloadIdentityPool.
+func (mg *WorkloadIdentityPool) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// GetDeletionPolicy of this WorkloadIdentityPool.
+func (mg *WorkloadIdentityPool) GetDeletionPolicy() xpv1.DeletionPolicy {
+	return mg.Spec.DeletionPolicy
+}
+
+// GetProviderConfigReference of this WorkloadIdentityPool.
+func (mg *WorkloadIdentityPool) GetProviderConfigReference() *xpv1.Reference {
+	return mg.Spec.ProviderConfigReference
+}
+
+/*
+GetProviderReference of this WorkloadIdentityPool.
+Deprecated: Use GetProviderConfigReference.
+*/
+func (mg *WorkloadIdentityPool) GetProviderReference() *xpv1.Reference {
+	return mg.Spec.ProviderReference
+}
+
+// GetWriteConnectionSecretToReference of this WorkloadIdentityPool.
+func (mg *WorkloadIdentityPool) GetWriteConnectionSecretToReference() *xpv1.SecretReference {
+	return mg.Spec.WriteConnectionSecretToReference
+}
+
+// SetConditions of this WorkloadIdentityPool.
+func (mg *WorkloadIdentityPool) SetConditions(c ...xpv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+// SetDeletionPolicy of this WorkloadIdentityPool.
+func (mg *WorkloadIdentityPool) SetDeletionPolicy(r xpv1.DeletionPolicy) {
+	mg.Spec.DeletionPolicy = r
+}
+
+// SetProviderConfigReference of this WorkloadIdentityPool.
+func (mg *WorkloadIdentityPool) SetProviderConfigReference(r *xpv1.Reference) {
+	mg.Spec.ProviderConfigReference = r
+}
+
+/*
+SetProviderReference of this WorkloadIdentityPool.
+Deprecated: Use SetProviderConfigReference.
+*/
+func (mg *WorkloadIdentityPool) SetProviderReference(r *xpv1.Reference) {
+	mg.Spec.ProviderReference = r
+}
+
+// SetWriteConnectionSecretToReference of this WorkloadIdentityPool.
+func (mg *WorkloadIdentityPool) SetWriteConnectionSecretToReference(r *xpv1.SecretReference) {
+	mg.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetCondition of this WorkloadIdentityPoolProvider.
+func (mg *WorkloadIdentityPoolProvider) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// GetDeletionPolicy of this WorkloadIdentityPoolProvider.
+func (mg *WorkloadIdentityPoolProvider) GetDeletionPolicy() xpv1.DeletionPolicy {
+	return mg.Spec.DeletionPolicy
+}
+
+// GetProviderConfigReference of this WorkloadIdentityPoolProvider.
+func (mg *WorkloadIdentityPoolProvider) GetProviderConfigReference() *xpv1.Reference {
+	return mg.Spec.ProviderConfigReference
+}
+
+/*
+GetProviderReference of this WorkloadIdentityPoolProvider.
+Deprecated: Use GetProviderConfigReference.
+*/
+func (mg *WorkloadIdentityPoolProvider) GetProviderReference() *xpv1.Reference {
+	return mg.Spec.ProviderReference
+}
+
+// GetWriteConnectionSecretToReference of this WorkloadIdentityPoolProvider.
+func (mg *WorkloadIdentityPoolProvider) GetWriteConnectionSecretToReference() *xpv1.SecretReference {
+	return mg.Spec.WriteConnectionSecretToReference
+}
+
+// SetConditions of this WorkloadIdentityPoolProvider.
+func (mg *WorkloadIdentityPoolProvider) SetConditions(c ...xpv1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+// SetDeletionPolicy of this WorkloadIdentityPoolProvider.
+func (mg *WorkloadIdentityPoolProvider) SetDeletionPolicy(r xpv1.DeletionPolicy) {
+	mg.Spec.DeletionPolicy = r
+}
+
+// SetProviderConfigReference of this WorkloadIdentityPoolProvider.
+func (mg *WorkloadIdentityPoolProvider) SetProviderConfigReference(r *xpv1.Reference) {
+	mg.Spec.ProviderConfigReference = r
+}
+
+/*
+SetProviderReference of this WorkloadIdentityPoolProvider.
+Deprecated: Use SetProviderConfigReference.
+*/
+func (mg *WorkloadIdentityPoolProvider) SetProviderReference(r *xpv1.Reference) {
+	mg.Spec.ProviderReference = r
+}
+
+// SetWriteConnectionSecretToReference of this WorkloadIdentityPoolProvider.
+func (mg *WorkloadIdentityPoolProvider) SetWriteConnectionSecretToReference(r *xpv1.SecretReference) {
+	mg.Spec.WriteConnectionSecretToReference = r
+}
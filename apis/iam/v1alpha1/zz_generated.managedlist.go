@@ -46,3 +46,21 @@ func (l *ServiceAccountPolicyList) GetItems() []resource.Managed {
 	}
 	return items
 }
+
+// GetItems of this WorkloadIdentityPoolList.
+func (l *WorkloadIdentityPoolList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
+// GetItems of this WorkloadIdentityPoolProviderList.
+func (l *WorkloadIdentityPoolProviderList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
@@ -149,11 +149,11 @@ type CryptoKeyObservation struct {
 	// Keys with purpose
 	// ENCRYPT_DECRYPT may have a
 	// primary. For other keys, this field will be omitted.
-	Primary *CryptoKeyVersion `json:"primary,omitempty"`
+	Primary *CryptoKeyVersionObservation `json:"primary,omitempty"`
 }
 
-// A CryptoKeyVersion represents an individual cryptographic key, and the
-// associated key material.
+// A CryptoKeyVersionObservation represents an individual cryptographic key,
+// and the associated key material.
 //
 // An ENABLED version can be used for cryptographic operations.
 //
@@ -164,7 +164,7 @@ type CryptoKeyObservation struct {
 // encrypt, decrypt, or sign data when an authorized user or application
 // invokes
 // Cloud KMS.
-type CryptoKeyVersion struct {
+type CryptoKeyVersionObservation struct {
 	// Algorithm: Output only. The CryptoKeyVersionAlgorithm that
 	// this
 	// CryptoKeyVersion supports.
@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// CryptoKeyVersionParameters defines parameters for a desired KMS
+// CryptoKeyVersion
+// https://cloud.google.com/kms/docs/reference/rest/v1/projects.locations.keyRings.cryptoKeys.cryptoKeyVersions
+type CryptoKeyVersionParameters struct {
+	// CryptoKey: The RRN of the CryptoKey to which this CryptoKeyVersion
+	// belongs.
+	// +optional
+	// +immutable
+	CryptoKey *string `json:"cryptoKey,omitempty"`
+
+	// CryptoKeyRef references a CryptoKey and retrieves its URI
+	// +optional
+	// +immutable
+	CryptoKeyRef *xpv1.Reference `json:"cryptoKeyRef,omitempty"`
+
+	// CryptoKeySelector selects a reference to a CryptoKey
+	// +optional
+	CryptoKeySelector *xpv1.Selector `json:"cryptoKeySelector,omitempty"`
+
+	// State: The desired state of the CryptoKeyVersion. A version that is
+	// DESTROY_SCHEDULED is destroyed once its destroy time is reached, and
+	// is restored to the DISABLED state when any other state is desired
+	// before then. Defaults to ENABLED.
+	//
+	// Possible values:
+	//   "ENABLED" - This version may be used for cryptographic operations.
+	//   "DISABLED" - This version may not be used, but the key material is
+	// still available, and the version can be placed back into the ENABLED
+	// state.
+	//   "DESTROY_SCHEDULED" - This version is scheduled for destruction,
+	// and will be destroyed soon.
+	// +optional
+	// +kubebuilder:validation:Enum=ENABLED;DISABLED;DESTROY_SCHEDULED
+	State *string `json:"state,omitempty"`
+}
+
+// CryptoKeyVersionSpec defines the desired state of a CryptoKeyVersion.
+type CryptoKeyVersionSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       CryptoKeyVersionParameters `json:"forProvider"`
+}
+
+// CryptoKeyVersionStatus represents the observed state of a
+// CryptoKeyVersion.
+type CryptoKeyVersionStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          CryptoKeyVersionObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CryptoKeyVersion is a managed resource that represents a Google KMS Crypto
+// Key Version.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.state"
+// +kubebuilder:printcolumn:name="DESTROY-TIME",type="string",JSONPath=".status.atProvider.destroyTime"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type CryptoKeyVersion struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CryptoKeyVersionSpec   `json:"spec"`
+	Status CryptoKeyVersionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CryptoKeyVersionList contains a list of CryptoKeyVersion types
+type CryptoKeyVersionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CryptoKeyVersion `json:"items"`
+}
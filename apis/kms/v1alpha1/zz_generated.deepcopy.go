@@ -89,7 +89,7 @@ func (in *CryptoKeyObservation) DeepCopyInto(out *CryptoKeyObservation) {
 	*out = *in
 	if in.Primary != nil {
 		in, out := &in.Primary, &out.Primary
-		*out = new(CryptoKeyVersion)
+		*out = new(CryptoKeyVersionObservation)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -315,6 +315,65 @@ func (in *CryptoKeyStatus) DeepCopy() *CryptoKeyStatus {
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CryptoKeyVersion) DeepCopyInto(out *CryptoKeyVersion) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CryptoKeyVersion.
+func (in *CryptoKeyVersion) DeepCopy() *CryptoKeyVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(CryptoKeyVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CryptoKeyVersion) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CryptoKeyVersionList) DeepCopyInto(out *CryptoKeyVersionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CryptoKeyVersion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CryptoKeyVersionList.
+func (in *CryptoKeyVersionList) DeepCopy() *CryptoKeyVersionList {
+	if in == nil {
+		return nil
+	}
+	out := new(CryptoKeyVersionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CryptoKeyVersionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CryptoKeyVersionObservation) DeepCopyInto(out *CryptoKeyVersionObservation) {
 	*out = *in
 	if in.Attestation != nil {
 		in, out := &in.Attestation, &out.Attestation
@@ -328,12 +387,81 @@ func (in *CryptoKeyVersion) DeepCopyInto(out *CryptoKeyVersion) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CryptoKeyVersion.
-func (in *CryptoKeyVersion) DeepCopy() *CryptoKeyVersion {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CryptoKeyVersionObservation.
+func (in *CryptoKeyVersionObservation) DeepCopy() *CryptoKeyVersionObservation {
 	if in == nil {
 		return nil
 	}
-	out := new(CryptoKeyVersion)
+	out := new(CryptoKeyVersionObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CryptoKeyVersionParameters) DeepCopyInto(out *CryptoKeyVersionParameters) {
+	*out = *in
+	if in.CryptoKey != nil {
+		in, out := &in.CryptoKey, &out.CryptoKey
+		*out = new(string)
+		**out = **in
+	}
+	if in.CryptoKeyRef != nil {
+		in, out := &in.CryptoKeyRef, &out.CryptoKeyRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.CryptoKeySelector != nil {
+		in, out := &in.CryptoKeySelector, &out.CryptoKeySelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.State != nil {
+		in, out := &in.State, &out.State
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CryptoKeyVersionParameters.
+func (in *CryptoKeyVersionParameters) DeepCopy() *CryptoKeyVersionParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(CryptoKeyVersionParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CryptoKeyVersionSpec) DeepCopyInto(out *CryptoKeyVersionSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CryptoKeyVersionSpec.
+func (in *CryptoKeyVersionSpec) DeepCopy() *CryptoKeyVersionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CryptoKeyVersionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CryptoKeyVersionStatus) DeepCopyInto(out *CryptoKeyVersionStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CryptoKeyVersionStatus.
+func (in *CryptoKeyVersionStatus) DeepCopy() *CryptoKeyVersionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CryptoKeyVersionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
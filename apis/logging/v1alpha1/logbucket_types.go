@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// LogBucket lifecycle states.
+const (
+	// LogBucketStateActive means the bucket is active and can store logs.
+	LogBucketStateActive = "ACTIVE"
+	// LogBucketStateDeleteRequested means the bucket has been marked for
+	// deletion and will be purged once its grace period passes.
+	LogBucketStateDeleteRequested = "DELETE_REQUESTED"
+)
+
+// LogBucketParameters defines parameters for a desired Cloud Logging bucket
+// https://cloud.google.com/logging/docs/reference/v2/rest/v2/projects.locations.buckets
+// The name of the bucket (ie the `bucketId` parameter of the Create call) is
+// determined by the value of the `crossplane.io/external-name` annotation.
+// Unless overridden by the user, this annotation is automatically populated
+// with the value of the `metadata.name` attribute.
+// NOTE: Customer managed encryption keys can only be configured for all
+// buckets of a project or organization, not per bucket, by the API version
+// this provider uses.
+type LogBucketParameters struct {
+	// Location: The location of the bucket, e.g. global or us-east1.
+	// +immutable
+	Location string `json:"location"`
+
+	// Description: Describes this bucket.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// RetentionDays: Logs will be retained by default for this amount of
+	// time, after which they will automatically be deleted. The minimum
+	// retention period is 1 day. If this value is set to zero at bucket
+	// creation time, the default time of 30 days will be used. The
+	// retention period of a locked bucket can not be decreased.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=3650
+	RetentionDays *int64 `json:"retentionDays,omitempty"`
+
+	// Locked: Whether the bucket has been locked. The retention period on
+	// a locked bucket may not be decreased. Locked buckets may only be
+	// deleted if they are empty. A bucket can not be unlocked once it is
+	// locked.
+	// +optional
+	Locked *bool `json:"locked,omitempty"`
+}
+
+// LogBucketObservation is used to show the observed state of the LogBucket
+// resource on GCP. All fields in this structure should only be populated from
+// GCP responses; any changes made to the k8s resource outside of the crossplane
+// gcp controller will be ignored and overwritten.
+type LogBucketObservation struct {
+	// CreateTime: Output only. The creation timestamp of the bucket.
+	CreateTime string `json:"createTime,omitempty"`
+
+	// LifecycleState: Output only. The bucket lifecycle state.
+	LifecycleState string `json:"lifecycleState,omitempty"`
+
+	// Name: Output only. The resource name of the bucket in the format
+	// `projects/*/locations/*/buckets/*`.
+	Name string `json:"name,omitempty"`
+
+	// UpdateTime: Output only. The last update timestamp of the bucket.
+	UpdateTime string `json:"updateTime,omitempty"`
+}
+
+// LogBucketSpec defines the desired state of a LogBucket.
+type LogBucketSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       LogBucketParameters `json:"forProvider"`
+}
+
+// LogBucketStatus represents the observed state of a LogBucket.
+type LogBucketStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          LogBucketObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LogBucket is a managed resource that represents a Google Cloud Logging
+// bucket, which stores the logs routed to it.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="RETENTION",type="integer",JSONPath=".spec.forProvider.retentionDays"
+// +kubebuilder:printcolumn:name="LOCKED",type="boolean",JSONPath=".spec.forProvider.locked"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type LogBucket struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LogBucketSpec   `json:"spec"`
+	Status LogBucketStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LogBucketList contains a list of LogBucket types
+type LogBucketList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LogBucket `json:"items"`
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// LogViewParameters defines parameters for a desired Cloud Logging view
+// https://cloud.google.com/logging/docs/reference/v2/rest/v2/projects.locations.buckets.views
+// The name of the view (ie the `viewId` parameter of the Create call) is
+// determined by the value of the `crossplane.io/external-name` annotation.
+// Unless overridden by the user, this annotation is automatically populated
+// with the value of the `metadata.name` attribute.
+type LogViewParameters struct {
+	// Bucket: The RRN of the LogBucket to which this LogView belongs, in
+	// the format `projects/*/locations/*/buckets/*`.
+	// +optional
+	// +immutable
+	Bucket *string `json:"bucket,omitempty"`
+
+	// BucketRef references a LogBucket and retrieves its RRN
+	// +optional
+	// +immutable
+	BucketRef *xpv1.Reference `json:"bucketRef,omitempty"`
+
+	// BucketSelector selects a reference to a LogBucket
+	// +optional
+	BucketSelector *xpv1.Selector `json:"bucketSelector,omitempty"`
+
+	// Description: Describes this view.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// Filter: Filter that restricts which log entries in a bucket are
+	// visible in this view. Filters are restricted to be a logical AND of
+	// ==/!= of any of the following: originating project/folder/
+	// organization/billing account, resource type and log id. For example:
+	// SOURCE("projects/myproject") AND resource.type = "gce_instance" AND
+	// LOG_ID("stdout")
+	// +optional
+	Filter *string `json:"filter,omitempty"`
+}
+
+// LogViewObservation is used to show the observed state of the LogView
+// resource on GCP. All fields in this structure should only be populated from
+// GCP responses; any changes made to the k8s resource outside of the crossplane
+// gcp controller will be ignored and overwritten.
+type LogViewObservation struct {
+	// CreateTime: Output only. The creation timestamp of the view.
+	CreateTime string `json:"createTime,omitempty"`
+
+	// Name: Output only. The resource name of the view in the format
+	// `projects/*/locations/*/buckets/*/views/*`.
+	Name string `json:"name,omitempty"`
+
+	// UpdateTime: Output only. The last update timestamp of the view.
+	UpdateTime string `json:"updateTime,omitempty"`
+}
+
+// LogViewSpec defines the desired state of a LogView.
+type LogViewSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       LogViewParameters `json:"forProvider"`
+}
+
+// LogViewStatus represents the observed state of a LogView.
+type LogViewStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          LogViewObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LogView is a managed resource that represents a Google Cloud Logging view,
+// which grants scoped access to the logs of a LogBucket.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type LogView struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   LogViewSpec   `json:"spec"`
+	Status LogViewStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// LogViewList contains a list of LogView types
+type LogViewList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LogView `json:"items"`
+}
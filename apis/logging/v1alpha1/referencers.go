@@ -0,0 +1,59 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// LogBucketRRN extracts the relative resource name of a LogBucket.
+func LogBucketRRN() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		b, ok := mg.(*LogBucket)
+		if !ok {
+			return ""
+		}
+		return b.Status.AtProvider.Name
+	}
+}
+
+// ResolveReferences of this LogView
+func (in *LogView) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, in)
+
+	// Resolve spec.forProvider.bucket
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(in.Spec.ForProvider.Bucket),
+		Reference:    in.Spec.ForProvider.BucketRef,
+		Selector:     in.Spec.ForProvider.BucketSelector,
+		To:           reference.To{Managed: &LogBucket{}, List: &LogBucketList{}},
+		Extract:      LogBucketRRN(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.bucket")
+	}
+	in.Spec.ForProvider.Bucket = reference.ToPtrValue(rsp.ResolvedValue)
+	in.Spec.ForProvider.BucketRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// SubscriptionParameters defines parameters for a desired PubSub Subscription.
+type SubscriptionParameters struct {
+	// Topic is the name of the topic the subscription receives messages
+	// from, either the name of a topic in the same project or
+	// `projects/{project}/topics/{topic}`.
+	// +optional
+	// +immutable
+	// +crossplane:generate:reference:type=Topic
+	Topic *string `json:"topic,omitempty"`
+
+	// TopicRef allows you to specify custom resource name of the Topic to
+	// fill Topic field.
+	// +optional
+	TopicRef *xpv1.Reference `json:"topicRef,omitempty"`
+
+	// TopicSelector allows you to use selector constraints to select a
+	// Topic.
+	// +optional
+	TopicSelector *xpv1.Selector `json:"topicSelector,omitempty"`
+
+	// Filter is an expression in the Pub/Sub filter language on the
+	// attributes of messages, e.g. `attributes.region = "eu"`. Only
+	// messages that match it are delivered. It can not be changed once the
+	// subscription was created.
+	// +optional
+	// +immutable
+	Filter *string `json:"filter,omitempty"`
+
+	// AckDeadlineSeconds is how many seconds a subscriber has to
+	// acknowledge a message before it is redelivered.
+	// +optional
+	// +kubebuilder:validation:Minimum=10
+	// +kubebuilder:validation:Maximum=600
+	AckDeadlineSeconds *int64 `json:"ackDeadlineSeconds,omitempty"`
+
+	// MessageRetentionDuration is how long unacknowledged messages are
+	// retained, e.g. `604800s`.
+	// +optional
+	MessageRetentionDuration *string `json:"messageRetentionDuration,omitempty"`
+
+	// RetainAckedMessages indicates whether acknowledged messages are
+	// retained for the message retention duration too.
+	// +optional
+	RetainAckedMessages *bool `json:"retainAckedMessages,omitempty"`
+
+	// EnableMessageOrdering indicates whether messages published with the
+	// same ordering key are delivered in order. It can not be changed once
+	// the subscription was created.
+	// +optional
+	// +immutable
+	EnableMessageOrdering *bool `json:"enableMessageOrdering,omitempty"`
+
+	// Labels are used as additional metadata on Subscription.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// SubscriptionSpec defines the desired state of a
+// Subscription.
+type SubscriptionSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SubscriptionParameters `json:"forProvider"`
+}
+
+// SubscriptionStatus represents the observed state of a
+// Subscription.
+type SubscriptionStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+
+// Subscription is a managed resource that represents a Google PubSub
+// Subscription.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TOPIC",type="string",JSONPath=".spec.forProvider.topic"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Subscription struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SubscriptionSpec   `json:"spec"`
+	Status SubscriptionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SubscriptionList contains a list of Subscription types
+type SubscriptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Subscription `json:"items"`
+}
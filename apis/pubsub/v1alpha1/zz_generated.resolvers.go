@@ -26,6 +26,32 @@ import (
 	client "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// ResolveReferences of this Subscription.
+func (mg *Subscription) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	var rsp reference.ResolutionResponse
+	var err error
+
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(mg.Spec.ForProvider.Topic),
+		Extract:      reference.ExternalName(),
+		Reference:    mg.Spec.ForProvider.TopicRef,
+		Selector:     mg.Spec.ForProvider.TopicSelector,
+		To: reference.To{
+			List:    &TopicList{},
+			Managed: &Topic{},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "mg.Spec.ForProvider.Topic")
+	}
+	mg.Spec.ForProvider.Topic = reference.ToPtrValue(rsp.ResolvedValue)
+	mg.Spec.ForProvider.TopicRef = rsp.ResolvedReference
+
+	return nil
+}
+
 // ResolveReferences of this Topic.
 func (mg *Topic) ResolveReferences(ctx context.Context, c client.Reader) error {
 	r := reference.NewAPIResolver(c, mg)
@@ -0,0 +1,191 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// ServiceParameters define the desired state of a Cloud Run service. Most
+// fields map directly to a Service:
+// https://cloud.google.com/run/docs/reference/rest/v1/namespaces.services
+type ServiceParameters struct {
+	// Location: The region the service runs in, e.g. us-central1.
+	// +immutable
+	Location string `json:"location"`
+
+	// Template: The template of the revisions of the service. A new
+	// revision is created whenever it changes.
+	Template RevisionTemplate `json:"template"`
+
+	// Traffic: How traffic is split across the revisions of the service.
+	// The percentages must sum to 100 unless all targets are the latest
+	// revision. All traffic goes to the latest ready revision if omitted.
+	// +optional
+	Traffic []TrafficTarget `json:"traffic,omitempty"`
+}
+
+// A RevisionTemplate describes the revisions of a service.
+type RevisionTemplate struct {
+	// Name: The name of the revision created from the template. It must be
+	// prefixed with the name of the service, e.g. my-service-v2, and
+	// changed along with the template. Traffic targets refer to revisions
+	// by name. A name is generated if omitted.
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// ServiceAccountName: The email address of the service account the
+	// revision runs as.
+	// +optional
+	ServiceAccountName *string `json:"serviceAccountName,omitempty"`
+
+	// Containers: The container the revision runs.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=1
+	Containers []Container `json:"containers"`
+}
+
+// A Container of a revision.
+type Container struct {
+	// Image: The URL of the container image, e.g.
+	// us-docker.pkg.dev/cloudrun/container/hello.
+	Image string `json:"image"`
+
+	// Args: The arguments of the entrypoint of the image.
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Env: The environment variables of the container.
+	// +optional
+	Env []EnvVar `json:"env,omitempty"`
+}
+
+// An EnvVar is an environment variable of a container.
+type EnvVar struct {
+	// Name of the environment variable.
+	Name string `json:"name"`
+
+	// Value of the environment variable.
+	// +optional
+	Value *string `json:"value,omitempty"`
+}
+
+// A TrafficTarget routes a share of the traffic of a service to a revision.
+type TrafficTarget struct {
+	// RevisionName: The name of the revision the traffic is routed to.
+	// Exclusive with latestRevision.
+	// +optional
+	RevisionName *string `json:"revisionName,omitempty"`
+
+	// LatestRevision: Whether the traffic is routed to the latest ready
+	// revision. Exclusive with revisionName.
+	// +optional
+	LatestRevision *bool `json:"latestRevision,omitempty"`
+
+	// Percent: The percentage of the traffic routed to the target.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percent *int64 `json:"percent,omitempty"`
+
+	// Tag: A tag that makes the target addressable at a URL of its own,
+	// e.g. https://canary---my-service-abc123-uc.a.run.app, whether or not
+	// it receives any of the traffic of the service.
+	// +optional
+	Tag *string `json:"tag,omitempty"`
+}
+
+// A TrafficTargetStatus represents the observed state of a traffic target.
+type TrafficTargetStatus struct {
+	// RevisionName: The name of the revision the traffic is routed to.
+	RevisionName string `json:"revisionName,omitempty"`
+
+	// LatestRevision: Whether the traffic is routed to the latest ready
+	// revision.
+	LatestRevision bool `json:"latestRevision,omitempty"`
+
+	// Percent: The percentage of the traffic routed to the target.
+	Percent int64 `json:"percent,omitempty"`
+
+	// Tag: The tag of the target.
+	Tag string `json:"tag,omitempty"`
+
+	// URL: The URL the target is addressable at, if it is tagged.
+	URL string `json:"url,omitempty"`
+}
+
+// A ServiceObservation represents the observed state of a Cloud Run service.
+type ServiceObservation struct {
+	// URL: The URL the service is addressable at.
+	URL string `json:"url,omitempty"`
+
+	// LatestCreatedRevisionName: The name of the revision that was created
+	// last.
+	LatestCreatedRevisionName string `json:"latestCreatedRevisionName,omitempty"`
+
+	// LatestReadyRevisionName: The name of the latest revision that is
+	// ready to serve traffic.
+	LatestReadyRevisionName string `json:"latestReadyRevisionName,omitempty"`
+
+	// ObservedGeneration: The generation of the service that was last
+	// rolled out.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Traffic: How traffic is currently split across the revisions of the
+	// service, including the URLs of tagged targets.
+	Traffic []TrafficTargetStatus `json:"traffic,omitempty"`
+}
+
+// A ServiceSpec defines the desired state of a Service.
+type ServiceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ServiceParameters `json:"forProvider"`
+}
+
+// A ServiceStatus represents the observed state of a Service.
+type ServiceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ServiceObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Service is a managed resource that represents a Cloud Run service.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="URL",type="string",JSONPath=".status.atProvider.url"
+// +kubebuilder:printcolumn:name="REVISION",type="string",JSONPath=".status.atProvider.latestReadyRevisionName"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Service struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ServiceSpec   `json:"spec"`
+	Status ServiceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ServiceList contains a list of Service.
+type ServiceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Service `json:"items"`
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// SecretParameters define the desired state of a Google Secret Manager
+// Secret, i.e. the container of secret versions. Most fields map directly to a
+// Secret: https://cloud.google.com/secret-manager/docs/reference/rest/v1/projects.secrets
+type SecretParameters struct {
+	// Replication: The locations the payload of the secret is replicated
+	// to. It is replicated automatically if omitted.
+	// +optional
+	// +immutable
+	Replication *Replication `json:"replication,omitempty"`
+
+	// Labels: The labels of the secret.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Topics: The names or full names of up to ten Pub/Sub topics that are
+	// notified of changes to the secret, e.g. my-topic or
+	// projects/my-project/topics/my-topic. Names are qualified with the
+	// project of the secret. The Secret Manager service agent must be
+	// allowed to publish to them.
+	// +optional
+	// +kubebuilder:validation:MaxItems=10
+	Topics []string `json:"topics,omitempty"`
+
+	// TopicRefs are references to the Pub/Sub Topics used to set Topics.
+	// +optional
+	TopicRefs []xpv1.Reference `json:"topicRefs,omitempty"`
+
+	// TopicSelector selects references to the Pub/Sub Topics used to set
+	// Topics.
+	// +optional
+	TopicSelector *xpv1.Selector `json:"topicSelector,omitempty"`
+
+	// Rotation: When GCP notifies the topics of the secret that it is to be
+	// rotated. It requires at least one topic.
+	// +optional
+	Rotation *Rotation `json:"rotation,omitempty"`
+}
+
+// Replication configures where the payload of a secret is stored.
+type Replication struct {
+	// UserManaged: The locations the payload is replicated to.
+	// +optional
+	UserManaged *UserManagedReplication `json:"userManaged,omitempty"`
+}
+
+// UserManagedReplication replicates the payload of a secret to the supplied
+// locations.
+type UserManagedReplication struct {
+	// Locations: The names of the locations, e.g. us-east1.
+	// +kubebuilder:validation:MinItems=1
+	Locations []string `json:"locations"`
+}
+
+// Rotation configures when a secret is to be rotated.
+type Rotation struct {
+	// NextRotationTime: When the secret is to be rotated next, in RFC3339
+	// format, e.g. 2022-01-01T00:00:00Z. It must be at least five minutes
+	// in the future when set. GCP advances it by the rotation period
+	// after each rotation, so a time that has passed is not reconciled.
+	// +optional
+	NextRotationTime *string `json:"nextRotationTime,omitempty"`
+
+	// RotationPeriod: How often the secret is rotated after the next
+	// rotation, in seconds with an s suffix, e.g. 2592000s. It must be at
+	// least an hour and requires nextRotationTime.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]{1,9})?s$`
+	RotationPeriod *string `json:"rotationPeriod,omitempty"`
+}
+
+// A SecretObservation represents the observed state of a Google Secret
+// Manager Secret.
+type SecretObservation struct {
+	// CreateTime: When the secret was created.
+	CreateTime string `json:"createTime,omitempty"`
+
+	// Name: The resource name of the secret, in the format
+	// projects/*/secrets/*.
+	Name string `json:"name,omitempty"`
+
+	// NextRotationTime: When the secret is to be rotated next.
+	NextRotationTime string `json:"nextRotationTime,omitempty"`
+}
+
+// A SecretSpec defines the desired state of a Secret.
+type SecretSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SecretParameters `json:"forProvider"`
+}
+
+// A SecretStatus represents the observed state of a Secret.
+type SecretStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          SecretObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Secret is a managed resource that represents a Google Secret Manager
+// Secret.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="NEXT-ROTATION",type="string",JSONPath=".status.atProvider.nextRotationTime"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Secret struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SecretSpec   `json:"spec"`
+	Status SecretStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SecretList contains a list of Secret.
+type SecretList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Secret `json:"items"`
+}
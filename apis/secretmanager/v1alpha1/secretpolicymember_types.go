@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	iamv1alpha1 "github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
+)
+
+// SecretPolicyMemberParameters defines parameters for a desired Secret Manager
+// SecretPolicyMember
+type SecretPolicyMemberParameters struct {
+	// Secret: The RRN of the Secret to which this SecretPolicyMember
+	// belongs, in the format `projects/*/secrets/*`.
+	// NOTE: Secrets can only be specified by their RRN, which a Secret
+	// reports as status.atProvider.name.
+	// +immutable
+	// +kubebuilder:validation:Pattern=`^projects/[^/]+/secrets/[^/]+$`
+	Secret string `json:"secret"`
+
+	// Role: Role that is assigned to `member`.
+	// For example, `roles/secretmanager.secretAccessor`.
+	// +immutable
+	Role string `json:"role"`
+
+	// Member: Specifies the identity requesting access for a Cloud
+	// Platform resource, e.g. `serviceAccount:{emailid}`, `user:{emailid}`
+	// or `group:{emailid}`.
+	// +optional
+	// +immutable
+	// +kubebuilder:validation:Pattern=`^(allUsers|allAuthenticatedUsers|(user|serviceAccount|group|domain|projectOwner|projectEditor|projectViewer|deleted:user|deleted:serviceAccount|deleted:group):.+|principal(Set)?://.+)$`
+	Member *string `json:"member,omitempty"`
+
+	// ServiceAccountMemberRef is reference to ServiceAccount used to set
+	// the Member.
+	// +optional
+	// +immutable
+	ServiceAccountMemberRef *xpv1.Reference `json:"serviceAccountMemberRef,omitempty"`
+
+	// ServiceAccountMemberSelector selects reference to ServiceAccount used
+	// to set the Member.
+	// +optional
+	// +immutable
+	ServiceAccountMemberSelector *xpv1.Selector `json:"serviceAccountMemberSelector,omitempty"`
+
+	// Condition: The condition that is associated with the binding of the
+	// role to the member, e.g. to grant access only until a given time.
+	// GCP treats bindings of the same role but a different condition as
+	// distinct bindings.
+	// +optional
+	// +immutable
+	Condition *iamv1alpha1.Expr `json:"condition,omitempty"`
+}
+
+// SecretPolicyMemberSpec defines the desired state of a
+// SecretPolicyMember.
+type SecretPolicyMemberSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SecretPolicyMemberParameters `json:"forProvider"`
+}
+
+// SecretPolicyMemberStatus represents the observed state of a
+// SecretPolicyMember.
+type SecretPolicyMemberStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+
+// SecretPolicyMember is a managed resource that represents membership of a
+// Google Secret Manager Secret IAM Policy.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type SecretPolicyMember struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SecretPolicyMemberSpec   `json:"spec"`
+	Status SecretPolicyMemberStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SecretPolicyMemberList contains a list of SecretPolicyMember types
+type SecretPolicyMemberList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SecretPolicyMember `json:"items"`
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// EndpointParameters defines parameters for a desired Service Directory
+// Endpoint
+// https://cloud.google.com/service-directory/docs/reference/rest/v1beta1/projects.locations.namespaces.services.endpoints
+type EndpointParameters struct {
+	// Service: The RRN of the Service to which this Endpoint belongs, in
+	// the format `projects/*/locations/*/namespaces/*/services/*`. The
+	// Service RRN identifies the Namespace of the Endpoint as well.
+	// +optional
+	// +immutable
+	Service *string `json:"service,omitempty"`
+
+	// ServiceRef references a Service and retrieves its RRN
+	// +optional
+	// +immutable
+	ServiceRef *xpv1.Reference `json:"serviceRef,omitempty"`
+
+	// ServiceSelector selects a reference to a Service
+	// +optional
+	ServiceSelector *xpv1.Selector `json:"serviceSelector,omitempty"`
+
+	// Address: An IPv4 or IPv6 address. Service Directory rejects bad
+	// addresses like: * `8.8.8` * `8.8.8.8:53` * `test:bad:address` *
+	// `[::1]` * `[::1]:8080` Limited to 45 characters.
+	// +optional
+	Address *string `json:"address,omitempty"`
+
+	// Port: Service Directory rejects values outside of `[0, 65535]`.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	Port *int64 `json:"port,omitempty"`
+
+	// Network: The Google Compute Engine network (VPC) of the endpoint in
+	// the format `projects/<project number>/locations/global/networks/*`.
+	// The project must be specified by project number (project id is
+	// rejected).
+	// +optional
+	// +immutable
+	Network *string `json:"network,omitempty"`
+
+	// Metadata: Metadata for the endpoint. This data can be consumed by
+	// service clients. The entire metadata dictionary may contain up to
+	// 512 characters, spread across all key-value pairs.
+	// +optional
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// EndpointObservation is used to show the observed state of the
+// Endpoint resource on GCP. All fields in this structure should only
+// be populated from GCP responses; any changes made to the k8s resource outside
+// of the crossplane gcp controller will be ignored and overwritten.
+type EndpointObservation struct {
+	// CreateTime: Output only. The timestamp when the endpoint was
+	// created.
+	CreateTime string `json:"createTime,omitempty"`
+
+	// Name: Output only. The resource name for the endpoint in the
+	// format `projects/*/locations/*/namespaces/*/services/*/endpoints/*`.
+	Name string `json:"name,omitempty"`
+
+	// UpdateTime: Output only. The timestamp when the endpoint was last
+	// updated.
+	UpdateTime string `json:"updateTime,omitempty"`
+}
+
+// EndpointSpec defines the desired state of an Endpoint.
+type EndpointSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       EndpointParameters `json:"forProvider"`
+}
+
+// EndpointStatus represents the observed state of an Endpoint.
+type EndpointStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          EndpointObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// Endpoint is a managed resource that represents a Google Service Directory
+// Endpoint.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="ADDRESS",type="string",JSONPath=".spec.forProvider.address"
+// +kubebuilder:printcolumn:name="PORT",type="integer",JSONPath=".spec.forProvider.port"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Endpoint struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EndpointSpec   `json:"spec"`
+	Status EndpointStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EndpointList contains a list of Endpoint types
+type EndpointList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Endpoint `json:"items"`
+}
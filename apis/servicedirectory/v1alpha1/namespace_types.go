@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// NamespaceParameters defines parameters for a desired Service Directory
+// Namespace
+// https://cloud.google.com/service-directory/docs/reference/rest/v1beta1/projects.locations.namespaces
+// The name of the namespace (ie the `namespaceId` parameter of the Create
+// call) is determined by the value of the `crossplane.io/external-name`
+// annotation. Unless overridden by the user, this annotation is automatically
+// populated with the value of the `metadata.name` attribute.
+type NamespaceParameters struct {
+	// Location: The region in which the Namespace is created, e.g.
+	// us-east1.
+	// +immutable
+	Location string `json:"location"`
+
+	// Labels: Resource labels associated with this namespace. No more
+	// than 64 user labels can be associated with a given resource. Label
+	// keys and values can be no longer than 63 characters.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// NamespaceObservation is used to show the observed state of the
+// Namespace resource on GCP. All fields in this structure should only
+// be populated from GCP responses; any changes made to the k8s resource outside
+// of the crossplane gcp controller will be ignored and overwritten.
+type NamespaceObservation struct {
+	// CreateTime: Output only. The timestamp when the namespace was
+	// created.
+	CreateTime string `json:"createTime,omitempty"`
+
+	// Name: Output only. The resource name for the namespace in the
+	// format `projects/*/locations/*/namespaces/*`.
+	Name string `json:"name,omitempty"`
+
+	// UpdateTime: Output only. The timestamp when the namespace was last
+	// updated.
+	UpdateTime string `json:"updateTime,omitempty"`
+}
+
+// NamespaceSpec defines the desired state of a Namespace.
+type NamespaceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       NamespaceParameters `json:"forProvider"`
+}
+
+// NamespaceStatus represents the observed state of a Namespace.
+type NamespaceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          NamespaceObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// Namespace is a managed resource that represents a Google Service Directory
+// Namespace.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="LOCATION",type="string",JSONPath=".spec.forProvider.location"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Namespace struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NamespaceSpec   `json:"spec"`
+	Status NamespaceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NamespaceList contains a list of Namespace types
+type NamespaceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Namespace `json:"items"`
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// NamespaceRRN extracts the relative resource name of a Namespace.
+func NamespaceRRN() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		n, ok := mg.(*Namespace)
+		if !ok {
+			return ""
+		}
+		return n.Status.AtProvider.Name
+	}
+}
+
+// ServiceRRN extracts the relative resource name of a Service.
+func ServiceRRN() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		s, ok := mg.(*Service)
+		if !ok {
+			return ""
+		}
+		return s.Status.AtProvider.Name
+	}
+}
+
+// ResolveReferences of this Service
+func (in *Service) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, in)
+
+	// Resolve spec.forProvider.namespace
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(in.Spec.ForProvider.Namespace),
+		Reference:    in.Spec.ForProvider.NamespaceRef,
+		Selector:     in.Spec.ForProvider.NamespaceSelector,
+		To:           reference.To{Managed: &Namespace{}, List: &NamespaceList{}},
+		Extract:      NamespaceRRN(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.namespace")
+	}
+	in.Spec.ForProvider.Namespace = reference.ToPtrValue(rsp.ResolvedValue)
+	in.Spec.ForProvider.NamespaceRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this Endpoint
+func (in *Endpoint) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, in)
+
+	// Resolve spec.forProvider.service
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(in.Spec.ForProvider.Service),
+		Reference:    in.Spec.ForProvider.ServiceRef,
+		Selector:     in.Spec.ForProvider.ServiceSelector,
+		To:           reference.To{Managed: &Service{}, List: &ServiceList{}},
+		Extract:      ServiceRRN(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.service")
+	}
+	in.Spec.ForProvider.Service = reference.ToPtrValue(rsp.ResolvedValue)
+	in.Spec.ForProvider.ServiceRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// ServiceParameters defines parameters for a desired Service Directory
+// Service
+// https://cloud.google.com/service-directory/docs/reference/rest/v1beta1/projects.locations.namespaces.services
+type ServiceParameters struct {
+	// Namespace: The RRN of the Namespace to which this Service belongs,
+	// in the format `projects/*/locations/*/namespaces/*`.
+	// +optional
+	// +immutable
+	Namespace *string `json:"namespace,omitempty"`
+
+	// NamespaceRef references a Namespace and retrieves its RRN
+	// +optional
+	// +immutable
+	NamespaceRef *xpv1.Reference `json:"namespaceRef,omitempty"`
+
+	// NamespaceSelector selects a reference to a Namespace
+	// +optional
+	NamespaceSelector *xpv1.Selector `json:"namespaceSelector,omitempty"`
+
+	// Metadata: Metadata annotations for the service. This data can be
+	// consumed by service clients. The entire metadata dictionary may
+	// contain up to 2000 characters, spread across all key-value pairs.
+	// +optional
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// ServiceObservation is used to show the observed state of the
+// Service resource on GCP. All fields in this structure should only
+// be populated from GCP responses; any changes made to the k8s resource outside
+// of the crossplane gcp controller will be ignored and overwritten.
+type ServiceObservation struct {
+	// CreateTime: Output only. The timestamp when the service was created.
+	CreateTime string `json:"createTime,omitempty"`
+
+	// Name: Output only. The resource name for the service in the
+	// format `projects/*/locations/*/namespaces/*/services/*`.
+	Name string `json:"name,omitempty"`
+
+	// UpdateTime: Output only. The timestamp when the service was last
+	// updated.
+	UpdateTime string `json:"updateTime,omitempty"`
+}
+
+// ServiceSpec defines the desired state of a Service.
+type ServiceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ServiceParameters `json:"forProvider"`
+}
+
+// ServiceStatus represents the observed state of a Service.
+type ServiceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ServiceObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// Service is a managed resource that represents a Google Service Directory
+// Service.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type Service struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ServiceSpec   `json:"spec"`
+	Status ServiceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ServiceList contains a list of Service types
+type ServiceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Service `json:"items"`
+}
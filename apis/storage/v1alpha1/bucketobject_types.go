@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// BucketObjectParameters define the desired state of an object of a Google
+// Cloud Storage bucket. Most fields map directly to an Object:
+// https://cloud.google.com/storage/docs/json_api/v1/objects
+type BucketObjectParameters struct {
+	// Bucket: The name of the bucket the object is stored in.
+	// +optional
+	// +immutable
+	Bucket *string `json:"bucket,omitempty"`
+
+	// BucketRef references a Bucket to retrieve its name.
+	// +optional
+	// +immutable
+	BucketRef *xpv1.Reference `json:"bucketRef,omitempty"`
+
+	// BucketSelector selects a reference to a Bucket to retrieve its name.
+	// +optional
+	BucketSelector *xpv1.Selector `json:"bucketSelector,omitempty"`
+
+	// Content: The content of the object. The object is uploaded again
+	// when its content changes.
+	Content string `json:"content"`
+
+	// ContentType: The Content-Type of the object. GCP guesses it from the
+	// content if omitted.
+	// +optional
+	ContentType *string `json:"contentType,omitempty"`
+
+	// CacheControl: The Cache-Control directive of the object, e.g.
+	// no-store.
+	// +optional
+	CacheControl *string `json:"cacheControl,omitempty"`
+
+	// Metadata: User-provided metadata of the object.
+	// +optional
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// A BucketObjectObservation represents the observed state of an object of a
+// Google Cloud Storage bucket.
+type BucketObjectObservation struct {
+	// Generation: The content generation of the object, which changes
+	// whenever its content is uploaded again.
+	Generation int64 `json:"generation,omitempty"`
+
+	// MD5Hash: The base64-encoded MD5 hash of the content of the object.
+	MD5Hash string `json:"md5Hash,omitempty"`
+
+	// MediaLink: The link to download the content of the object.
+	MediaLink string `json:"mediaLink,omitempty"`
+
+	// SelfLink: The link to the object.
+	SelfLink string `json:"selfLink,omitempty"`
+
+	// Size: The length of the content of the object in bytes.
+	Size uint64 `json:"size,omitempty"`
+}
+
+// A BucketObjectSpec defines the desired state of a BucketObject.
+type BucketObjectSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       BucketObjectParameters `json:"forProvider"`
+}
+
+// A BucketObjectStatus represents the observed state of a BucketObject.
+type BucketObjectStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          BucketObjectObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A BucketObject is a managed resource that represents an object of a Google
+// Cloud Storage bucket. Its external name is the name of the object.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="BUCKET",type="string",JSONPath=".spec.forProvider.bucket"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type BucketObject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BucketObjectSpec   `json:"spec"`
+	Status BucketObjectStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BucketObjectList contains a list of BucketObject.
+type BucketObjectList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BucketObject `json:"items"`
+}
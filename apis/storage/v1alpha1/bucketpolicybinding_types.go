@@ -0,0 +1,112 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	iamv1alpha1 "github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
+)
+
+// BucketPolicyBindingParameters defines parameters for a desired binding of a
+// role to a set of members in a Bucket IAM Policy.
+type BucketPolicyBindingParameters struct {
+	// Bucket: The RRN of the Bucket to which this BucketPolicyBinding belongs.
+	// +optional
+	// +immutable
+	Bucket *string `json:"bucket,omitempty"`
+
+	// BucketRef references a Bucket and retrieves its URI
+	// +optional
+	// +immutable
+	BucketRef *xpv1.Reference `json:"bucketRef,omitempty"`
+
+	// BucketSelector selects a reference to a Bucket
+	// +optional
+	BucketSelector *xpv1.Selector `json:"bucketSelector,omitempty"`
+
+	// Role: Role that is assigned to `members`.
+	// For example, `roles/viewer`, `roles/editor`, or `roles/owner`.
+	// +immutable
+	Role string `json:"role"`
+
+	// Condition: The condition that is associated with this binding.
+	// Together with the role it identifies the binding in the policy, so
+	// bindings of the same role with a different condition are not
+	// touched.
+	// +optional
+	// +immutable
+	Condition *iamv1alpha1.Expr `json:"condition,omitempty"`
+
+	// Members: Specifies the identities requesting access for a Cloud
+	// Platform resource. The binding is reconciled to contain exactly
+	// these members. See BucketPolicyMember for the accepted formats.
+	// +optional
+	Members []string `json:"members,omitempty"`
+
+	// ServiceAccountMemberRefs are references to ServiceAccounts used to set
+	// the Members.
+	// +optional
+	ServiceAccountMemberRefs []xpv1.Reference `json:"serviceAccountMemberRefs,omitempty"`
+
+	// ServiceAccountMemberSelector selects references to ServiceAccounts used
+	// to set the Members.
+	// +optional
+	ServiceAccountMemberSelector *xpv1.Selector `json:"serviceAccountMemberSelector,omitempty"`
+}
+
+// BucketPolicyBindingSpec defines the desired state of a
+// BucketPolicyBinding.
+type BucketPolicyBindingSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       BucketPolicyBindingParameters `json:"forProvider"`
+}
+
+// BucketPolicyBindingStatus represents the observed state of a
+// BucketPolicyBinding.
+type BucketPolicyBindingStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+
+// BucketPolicyBinding is a managed resource that represents a single role
+// binding, with one or more members, of a Google Cloud Storage Bucket IAM
+// Policy.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="ROLE",type="string",JSONPath=".spec.forProvider.role"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,gcp}
+type BucketPolicyBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BucketPolicyBindingSpec   `json:"spec"`
+	Status BucketPolicyBindingStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BucketPolicyBindingList contains a list of BucketPolicyBinding types
+type BucketPolicyBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BucketPolicyBinding `json:"items"`
+}
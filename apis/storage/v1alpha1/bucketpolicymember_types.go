@@ -20,6 +20,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	iamv1alpha1 "github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
 )
 
 // BucketPolicyMemberParameters defines parameters for a desired KMS BucketPolicyMember
@@ -29,6 +31,13 @@ type BucketPolicyMemberParameters struct {
 	// +immutable
 	Bucket *string `json:"bucket,omitempty"`
 
+	// Project: The project that requests to manage the binding are billed
+	// to, and whose quota they count against, e.g. the project of the
+	// bucket. Defaults to the quota project of the ProviderConfig, if any,
+	// or the project of its credentials.
+	// +optional
+	Project *string `json:"project,omitempty"`
+
 	// BucketRef references a Bucket and retrieves its URI
 	// +optional
 	// +immutable
@@ -43,6 +52,13 @@ type BucketPolicyMemberParameters struct {
 	// +immutable
 	Role string `json:"role"`
 
+	// Condition: The condition that is associated with the binding of the
+	// role to the member. A conditional and an unconditional binding of the
+	// same role are distinct bindings.
+	// +optional
+	// +immutable
+	Condition *iamv1alpha1.Expr `json:"condition,omitempty"`
+
 	// Member: Specifies the identity requesting access for a Cloud
 	// Platform resource.
 	// `member` can have the following values:
@@ -108,10 +124,41 @@ type BucketPolicyMemberParameters struct {
 	// `example.com`.
 	//
 	//
+	// * `principal://{identifier}` and `principalSet://{identifier}`: A
+	//    workload or workforce identity federation principal or set of
+	//    principals.
+	//
+	//
 	// +optional
 	// +immutable
+	// +kubebuilder:validation:Pattern=`^(allUsers|allAuthenticatedUsers|(user|serviceAccount|group|domain|projectOwner|projectEditor|projectViewer|deleted:user|deleted:serviceAccount|deleted:group):.+|principal(Set)?://.+)$`
 	Member *string `json:"member,omitempty"`
 
+	// Members: Further identities the role is bound to along with the
+	// member, in any of the formats accepted for the member. Other members
+	// of the binding, e.g. those bound by other BucketPolicyMembers, are left
+	// untouched, and only these members are unbound once the
+	// BucketPolicyMember is deleted.
+	// +optional
+	// +immutable
+	Members []string `json:"members,omitempty"`
+
+	// PolicyVersion: The version of the IAM policy of the bucket that is
+	// requested and set, which defaults to 3. Conditional bindings require
+	// version 3; version 1 may be requested for buckets whose policies do
+	// not behave as expected once version 3 is requested.
+	// +optional
+	// +kubebuilder:validation:Enum=1;3
+	PolicyVersion *int64 `json:"policyVersion,omitempty"`
+
+	// PruneDeletedMembers: Whether members of the binding of the role that
+	// are in the deleted: form, e.g. those left behind by a service account
+	// that was deleted and recreated, are removed from the binding along
+	// with binding the member. Members bound by this BucketPolicyMember are
+	// never removed. Defaults to false.
+	// +optional
+	PruneDeletedMembers *bool `json:"pruneDeletedMembers,omitempty"`
+
 	// ServiceAccountMemberRef is reference to ServiceAccount used to set
 	// the Member.
 	// +optional
@@ -125,6 +172,21 @@ type BucketPolicyMemberParameters struct {
 	ServiceAccountMemberSelector *xpv1.Selector `json:"serviceAccountMemberSelector,omitempty"`
 }
 
+// BucketPolicyMemberObservation represents the observed state of the binding
+// of a BucketPolicyMember. It is reported for auditing only; it does not
+// affect how the binding is reconciled.
+type BucketPolicyMemberObservation struct {
+	// BindingMembers: All members the IAM policy of the bucket binds the role
+	// to, with the condition of the BucketPolicyMember, including members
+	// bound by others.
+	// +optional
+	BindingMembers []string `json:"bindingMembers,omitempty"`
+
+	// Etag: The etag of the IAM policy of the bucket as last observed.
+	// +optional
+	Etag string `json:"etag,omitempty"`
+}
+
 // BucketPolicyMemberSpec defines the desired state of a
 // BucketPolicyMember.
 type BucketPolicyMemberSpec struct {
@@ -136,12 +198,14 @@ type BucketPolicyMemberSpec struct {
 // BucketPolicyMember.
 type BucketPolicyMemberStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          BucketPolicyMemberObservation `json:"atProvider,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 
 // BucketPolicyMember is a managed resource that represents membership of a
-// Google Cloud Storage Bucket IAM Policy.
+// Google Cloud Storage Bucket IAM Policy. Unless it binds more than one
+// member, its external name identifies the binding as {bucket}/{role}/{member}.
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
@@ -99,3 +99,59 @@ func (in *BucketPolicyMember) ResolveReferences(ctx context.Context, c client.Re
 
 	return nil
 }
+
+// ResolveReferences of this BucketPolicyBinding
+func (in *BucketPolicyBinding) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, in)
+
+	// Resolve spec.forProvider.bucket
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(in.Spec.ForProvider.Bucket),
+		Reference:    in.Spec.ForProvider.BucketRef,
+		Selector:     in.Spec.ForProvider.BucketSelector,
+		To:           reference.To{Managed: &v1alpha3.Bucket{}, List: &v1alpha3.BucketList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.bucket")
+	}
+	in.Spec.ForProvider.Bucket = reference.ToPtrValue(rsp.ResolvedValue)
+	in.Spec.ForProvider.BucketRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.members
+	mrsp, err := r.ResolveMultiple(ctx, reference.MultiResolutionRequest{
+		CurrentValues: in.Spec.ForProvider.Members,
+		References:    in.Spec.ForProvider.ServiceAccountMemberRefs,
+		Selector:      in.Spec.ForProvider.ServiceAccountMemberSelector,
+		To:            reference.To{Managed: &iamv1alpha1.ServiceAccount{}, List: &iamv1alpha1.ServiceAccountList{}},
+		Extract:       iamv1alpha1.ServiceAccountMemberName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.members")
+	}
+	in.Spec.ForProvider.Members = mrsp.ResolvedValues
+	in.Spec.ForProvider.ServiceAccountMemberRefs = mrsp.ResolvedReferences
+
+	return nil
+}
+
+// ResolveReferences of this BucketObject
+func (in *BucketObject) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, in)
+
+	// Resolve spec.forProvider.bucket
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: reference.FromPtrValue(in.Spec.ForProvider.Bucket),
+		Reference:    in.Spec.ForProvider.BucketRef,
+		Selector:     in.Spec.ForProvider.BucketSelector,
+		To:           reference.To{Managed: &v1alpha3.Bucket{}, List: &v1alpha3.BucketList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.bucket")
+	}
+	in.Spec.ForProvider.Bucket = reference.ToPtrValue(rsp.ResolvedValue)
+	in.Spec.ForProvider.BucketRef = rsp.ResolvedReference
+
+	return nil
+}
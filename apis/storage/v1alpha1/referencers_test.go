@@ -0,0 +1,181 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/storage/v1alpha3"
+)
+
+const (
+	nameTestBucket     = "test-bucket"
+	externalTestBucket = "test-bucket-0a1b2c"
+	nameMissingBucket  = "missing-bucket"
+	labelTest          = "test-key"
+	valueTest          = "test-value"
+	testMember         = "user:jane@example.com"
+)
+
+func TestBucketPolicyMember_ResolveReferences(t *testing.T) {
+	testBucketName := externalTestBucket
+	testMemberName := testMember
+	testClient, err := getFakeClient()
+	if err != nil {
+		t.Fatalf("Failed to initialize fake client: %s", err)
+	}
+
+	type want struct {
+		bucket    *string
+		bucketRef *xpv1.Reference
+		err       error
+	}
+
+	testCases := map[string]struct {
+		member *BucketPolicyMember
+		want   want
+	}{
+		"NoOpBucketReference": {
+			member: &BucketPolicyMember{
+				Spec: BucketPolicyMemberSpec{
+					ForProvider: BucketPolicyMemberParameters{
+						Bucket: &testBucketName,
+						Member: &testMemberName,
+					},
+				},
+			},
+			want: want{
+				bucket: &testBucketName,
+			},
+		},
+		"ResolveBucketByName": {
+			member: &BucketPolicyMember{
+				Spec: BucketPolicyMemberSpec{
+					ForProvider: BucketPolicyMemberParameters{
+						BucketRef: &xpv1.Reference{Name: nameTestBucket},
+						Member:    &testMemberName,
+					},
+				},
+			},
+			want: want{
+				bucket:    &testBucketName,
+				bucketRef: &xpv1.Reference{Name: nameTestBucket},
+			},
+		},
+		"ResolveBucketBySelector": {
+			member: &BucketPolicyMember{
+				Spec: BucketPolicyMemberSpec{
+					ForProvider: BucketPolicyMemberParameters{
+						BucketSelector: &xpv1.Selector{
+							MatchLabels: map[string]string{
+								labelTest: valueTest,
+							},
+						},
+						Member: &testMemberName,
+					},
+				},
+			},
+			want: want{
+				bucket:    &testBucketName,
+				bucketRef: &xpv1.Reference{Name: nameTestBucket},
+			},
+		},
+		"ReferencedBucketNotFound": {
+			member: &BucketPolicyMember{
+				Spec: BucketPolicyMemberSpec{
+					ForProvider: BucketPolicyMemberParameters{
+						BucketRef: &xpv1.Reference{Name: nameMissingBucket},
+						Member:    &testMemberName,
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(kerrors.NewNotFound(schema.GroupResource{
+					Group:    v1alpha3.Group,
+					Resource: "buckets",
+				}, nameMissingBucket), "cannot get referenced resource"), "spec.forProvider.bucket"),
+			},
+		},
+		"SelectorMatchesNoBucket": {
+			member: &BucketPolicyMember{
+				Spec: BucketPolicyMemberSpec{
+					ForProvider: BucketPolicyMemberParameters{
+						BucketSelector: &xpv1.Selector{
+							MatchLabels: map[string]string{
+								labelTest: "other-value",
+							},
+						},
+						Member: &testMemberName,
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errors.New("no resources matched selector"), "spec.forProvider.bucket"),
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.member.ResolveReferences(context.Background(), testClient)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("ResolveReferences(...): -want error, +got error:\n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.bucket, tc.member.Spec.ForProvider.Bucket); diff != "" {
+				t.Errorf("ResolveReferences(...): -want bucket, +got bucket:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.bucketRef, tc.member.Spec.ForProvider.BucketRef); diff != "" {
+				t.Errorf("ResolveReferences(...): -want bucketRef, +got bucketRef:\n%s", diff)
+			}
+		})
+	}
+}
+
+func getFakeClient() (client.Client, error) {
+	testBucket := &v1alpha3.Bucket{
+		ObjectMeta: v1.ObjectMeta{
+			Name: nameTestBucket,
+			Labels: map[string]string{
+				labelTest: valueTest,
+			},
+		},
+	}
+	meta.SetExternalName(testBucket, externalTestBucket)
+
+	scheme, err := v1alpha3.SchemeBuilder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(testBucket).Build(), nil
+}
@@ -53,6 +53,23 @@ var (
 	BucketPolicyMemberGroupVersionKind = SchemeGroupVersion.WithKind(BucketPolicyMemberKind)
 )
 
+// BucketPolicyBinding type metadata.
+var (
+	BucketPolicyBindingKind             = reflect.TypeOf(BucketPolicyBinding{}).Name()
+	BucketPolicyBindingGroupKind        = schema.GroupKind{Group: Group, Kind: BucketPolicyBindingKind}.String()
+	BucketPolicyBindingKindAPIVersion   = BucketPolicyBindingKind + "." + SchemeGroupVersion.String()
+	BucketPolicyBindingGroupVersionKind = SchemeGroupVersion.WithKind(BucketPolicyBindingKind)
+)
+
+// BucketObject type metadata.
+var (
+	BucketObjectKind             = reflect.TypeOf(BucketObject{}).Name()
+	BucketObjectGroupKind        = schema.GroupKind{Group: Group, Kind: BucketObjectKind}.String()
+	BucketObjectKindAPIVersion   = BucketObjectKind + "." + SchemeGroupVersion.String()
+	BucketObjectGroupVersionKind = SchemeGroupVersion.WithKind(BucketObjectKind)
+)
+
 func init() {
-	SchemeBuilder.Register(&BucketPolicy{}, &BucketPolicyList{}, &BucketPolicyMember{}, &BucketPolicyMemberList{})
+	SchemeBuilder.Register(&BucketPolicy{}, &BucketPolicyList{}, &BucketPolicyMember{}, &BucketPolicyMemberList{}, &BucketPolicyBinding{}, &BucketPolicyBindingList{})
+	SchemeBuilder.Register(&BucketObject{}, &BucketObjectList{})
 }
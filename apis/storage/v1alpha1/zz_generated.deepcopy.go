@@ -22,9 +22,165 @@ package v1alpha1
 
 import (
 	"github.com/crossplane/crossplane-runtime/apis/common/v1"
+	iamv1alpha1 "github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketObject) DeepCopyInto(out *BucketObject) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketObject.
+func (in *BucketObject) DeepCopy() *BucketObject {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BucketObject) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketObjectList) DeepCopyInto(out *BucketObjectList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BucketObject, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketObjectList.
+func (in *BucketObjectList) DeepCopy() *BucketObjectList {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketObjectList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BucketObjectList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketObjectObservation) DeepCopyInto(out *BucketObjectObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketObjectObservation.
+func (in *BucketObjectObservation) DeepCopy() *BucketObjectObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketObjectObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketObjectParameters) DeepCopyInto(out *BucketObjectParameters) {
+	*out = *in
+	if in.Bucket != nil {
+		in, out := &in.Bucket, &out.Bucket
+		*out = new(string)
+		**out = **in
+	}
+	if in.BucketRef != nil {
+		in, out := &in.BucketRef, &out.BucketRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.BucketSelector != nil {
+		in, out := &in.BucketSelector, &out.BucketSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContentType != nil {
+		in, out := &in.ContentType, &out.ContentType
+		*out = new(string)
+		**out = **in
+	}
+	if in.CacheControl != nil {
+		in, out := &in.CacheControl, &out.CacheControl
+		*out = new(string)
+		**out = **in
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketObjectParameters.
+func (in *BucketObjectParameters) DeepCopy() *BucketObjectParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketObjectParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketObjectSpec) DeepCopyInto(out *BucketObjectSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketObjectSpec.
+func (in *BucketObjectSpec) DeepCopy() *BucketObjectSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketObjectSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketObjectStatus) DeepCopyInto(out *BucketObjectStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketObjectStatus.
+func (in *BucketObjectStatus) DeepCopy() *BucketObjectStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketObjectStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BucketPolicy) DeepCopyInto(out *BucketPolicy) {
 	*out = *in
@@ -52,6 +208,148 @@ func (in *BucketPolicy) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketPolicyBinding) DeepCopyInto(out *BucketPolicyBinding) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketPolicyBinding.
+func (in *BucketPolicyBinding) DeepCopy() *BucketPolicyBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketPolicyBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BucketPolicyBinding) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketPolicyBindingList) DeepCopyInto(out *BucketPolicyBindingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BucketPolicyBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketPolicyBindingList.
+func (in *BucketPolicyBindingList) DeepCopy() *BucketPolicyBindingList {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketPolicyBindingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BucketPolicyBindingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketPolicyBindingParameters) DeepCopyInto(out *BucketPolicyBindingParameters) {
+	*out = *in
+	if in.Bucket != nil {
+		in, out := &in.Bucket, &out.Bucket
+		*out = new(string)
+		**out = **in
+	}
+	if in.BucketRef != nil {
+		in, out := &in.BucketRef, &out.BucketRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.BucketSelector != nil {
+		in, out := &in.BucketSelector, &out.BucketSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Condition != nil {
+		in, out := &in.Condition, &out.Condition
+		*out = new(iamv1alpha1.Expr)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceAccountMemberRefs != nil {
+		in, out := &in.ServiceAccountMemberRefs, &out.ServiceAccountMemberRefs
+		*out = make([]v1.Reference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceAccountMemberSelector != nil {
+		in, out := &in.ServiceAccountMemberSelector, &out.ServiceAccountMemberSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketPolicyBindingParameters.
+func (in *BucketPolicyBindingParameters) DeepCopy() *BucketPolicyBindingParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketPolicyBindingParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketPolicyBindingSpec) DeepCopyInto(out *BucketPolicyBindingSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketPolicyBindingSpec.
+func (in *BucketPolicyBindingSpec) DeepCopy() *BucketPolicyBindingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketPolicyBindingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketPolicyBindingStatus) DeepCopyInto(out *BucketPolicyBindingStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketPolicyBindingStatus.
+func (in *BucketPolicyBindingStatus) DeepCopy() *BucketPolicyBindingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketPolicyBindingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BucketPolicyList) DeepCopyInto(out *BucketPolicyList) {
 	*out = *in
@@ -143,6 +441,26 @@ func (in *BucketPolicyMemberList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketPolicyMemberObservation) DeepCopyInto(out *BucketPolicyMemberObservation) {
+	*out = *in
+	if in.BindingMembers != nil {
+		in, out := &in.BindingMembers, &out.BindingMembers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketPolicyMemberObservation.
+func (in *BucketPolicyMemberObservation) DeepCopy() *BucketPolicyMemberObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketPolicyMemberObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BucketPolicyMemberParameters) DeepCopyInto(out *BucketPolicyMemberParameters) {
 	*out = *in
@@ -151,6 +469,11 @@ func (in *BucketPolicyMemberParameters) DeepCopyInto(out *BucketPolicyMemberPara
 		*out = new(string)
 		**out = **in
 	}
+	if in.Project != nil {
+		in, out := &in.Project, &out.Project
+		*out = new(string)
+		**out = **in
+	}
 	if in.BucketRef != nil {
 		in, out := &in.BucketRef, &out.BucketRef
 		*out = new(v1.Reference)
@@ -161,11 +484,31 @@ func (in *BucketPolicyMemberParameters) DeepCopyInto(out *BucketPolicyMemberPara
 		*out = new(v1.Selector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Condition != nil {
+		in, out := &in.Condition, &out.Condition
+		*out = new(iamv1alpha1.Expr)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Member != nil {
 		in, out := &in.Member, &out.Member
 		*out = new(string)
 		**out = **in
 	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PolicyVersion != nil {
+		in, out := &in.PolicyVersion, &out.PolicyVersion
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PruneDeletedMembers != nil {
+		in, out := &in.PruneDeletedMembers, &out.PruneDeletedMembers
+		*out = new(bool)
+		**out = **in
+	}
 	if in.ServiceAccountMemberRef != nil {
 		in, out := &in.ServiceAccountMemberRef, &out.ServiceAccountMemberRef
 		*out = new(v1.Reference)
@@ -209,6 +552,7 @@ func (in *BucketPolicyMemberSpec) DeepCopy() *BucketPolicyMemberSpec {
 func (in *BucketPolicyMemberStatus) DeepCopyInto(out *BucketPolicyMemberStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketPolicyMemberStatus.
@@ -137,7 +137,9 @@ func CopyToACLRules(r []ACLRule) []storage.ACLRule {
 // LifecycleAction is a lifecycle configuration action.
 type LifecycleAction struct {
 	// StorageClass is the storage class to set on matching objects if the Action
-	// is "SetStorageClass".
+	// is "SetStorageClass". Objects may only be transitioned to a colder
+	// storage class, i.e. "NEARLINE", "COLDLINE" or "ARCHIVE", than the
+	// classes they are matched by.
 	StorageClass string `json:"storageClass,omitempty"`
 
 	// Type is the type of action to take on matching objects.
@@ -185,7 +187,7 @@ type LifecycleCondition struct {
 	// class.
 	//
 	// Values include "MULTI_REGIONAL", "REGIONAL", "NEARLINE", "COLDLINE",
-	// "STANDARD", and "DURABLE_REDUCED_AVAILABILITY".
+	// "ARCHIVE", "STANDARD", and "DURABLE_REDUCED_AVAILABILITY".
 	MatchesStorageClasses []string `json:"matchesStorageClasses,omitempty"`
 
 	// NumNewerVersions is the condition matching objects with a number of newer versions.
@@ -574,6 +576,7 @@ type BucketUpdatableAttrs struct {
 	DefaultEventBasedHold bool `json:"defaultEventBasedHold,omitempty"`
 
 	// The encryption configuration used by default for newly inserted objects.
+	// Newly inserted objects use Google-managed encryption if omitted.
 	Encryption *BucketEncryption `json:"encryption,omitempty"`
 
 	// Labels are the bucket's labels.
@@ -590,6 +593,9 @@ type BucketUpdatableAttrs struct {
 	// It is always empty for BucketAttrs returned from the service.
 	// See https://cloud.google.com/storage/docs/json_api/v1/buckets/insert
 	// for valid values.
+	// NOTE: Because it is never returned by the service, a change to it is not
+	// detected as drift. It is applied whenever the bucket is created or
+	// updated. It cannot be used when BucketPolicyOnly is enabled.
 	PredefinedACL string `json:"predefinedAcl,omitempty"`
 
 	// If not empty, applies a predefined set of default object access controls.
@@ -597,6 +603,9 @@ type BucketUpdatableAttrs struct {
 	// It is always empty for BucketAttrs returned from the service.
 	// See https://cloud.google.com/storage/docs/json_api/v1/buckets/insert
 	// for valid values.
+	// NOTE: Because it is never returned by the service, a change to it is not
+	// detected as drift. It is applied whenever the bucket is created or
+	// updated. It cannot be used when BucketPolicyOnly is enabled.
 	PredefinedDefaultObjectACL string `json:"predefinedCefaultObjectAcl,omitempty"`
 
 	// RequesterPays reports whether the bucket is a Requester Pays bucket.
@@ -769,6 +778,11 @@ type BucketOutputAttrs struct {
 	// most customers. It might be changed in backwards-incompatible ways and is not
 	// subject to any SLA or deprecation policy.
 	RetentionPolicy *RetentionPolicyStatus `json:"retentionPolicy,omitempty"`
+
+	// RequesterPays reports whether the bucket was a Requester Pays bucket
+	// when it was last observed. Requests for a Requester Pays bucket are
+	// billed to the project of its ProviderConfig.
+	RequesterPays bool `json:"requesterPays,omitempty"`
 }
 
 // NewBucketOutputAttrs creates new instance of BucketOutputAttrs from storage.BucketAttrs
@@ -779,6 +793,7 @@ func NewBucketOutputAttrs(attrs *storage.BucketAttrs) BucketOutputAttrs {
 	ao := BucketOutputAttrs{
 		BucketPolicyOnly: NewBucketPolicyOnly(attrs.BucketPolicyOnly),
 		RetentionPolicy:  NewRetentionPolicyStatus(attrs.RetentionPolicy),
+		RequesterPays:    attrs.RequesterPays,
 	}
 	if !attrs.Created.IsZero() {
 		ao.Created = &metav1.Time{Time: attrs.Created}
@@ -791,6 +806,29 @@ func NewBucketOutputAttrs(attrs *storage.BucketAttrs) BucketOutputAttrs {
 // https://cloud.google.com/storage/docs/json_api/v1/buckets#resource
 type BucketParameters struct {
 	BucketSpecAttrs `json:",inline"`
+
+	// TagValues are the Resource Manager tag values bound to the bucket,
+	// e.g. tagValues/281484271763108, which IAM conditions can refer to. If
+	// set, tag values bound to the bucket outside of this list are unbound.
+	// +optional
+	TagValues []string `json:"tagValues,omitempty"`
+
+	// ReleaseEventBasedHolds releases the event-based holds of the objects
+	// of the bucket, e.g. of those created while defaultEventBasedHold was
+	// enabled, so that their retention periods start. Holds are only
+	// released while defaultEventBasedHold is disabled. Every object of the
+	// bucket matching the release is listed whenever the bucket is observed.
+	// +optional
+	ReleaseEventBasedHolds *EventBasedHoldRelease `json:"releaseEventBasedHolds,omitempty"`
+}
+
+// An EventBasedHoldRelease selects the objects of a bucket whose event-based
+// holds are released.
+type EventBasedHoldRelease struct {
+	// Prefix restricts the release to the objects whose names begin with it.
+	// The holds of all objects of the bucket are released if it is omitted.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
 }
 
 // A BucketSpec defines the desired state of a Bucket.
@@ -799,11 +837,40 @@ type BucketSpec struct {
 	BucketParameters  `json:",inline"`
 }
 
+// A BucketIAMMember is a member bound to a role by the IAM policy of a bucket.
+type BucketIAMMember struct {
+	// Role the member is bound to.
+	Role string `json:"role"`
+
+	// Member bound to the role.
+	Member string `json:"member"`
+
+	// ConditionTitle is the title of the condition of the binding, if any.
+	ConditionTitle string `json:"conditionTitle,omitempty"`
+
+	// ManagedBy is the kind and name of the managed resource that binds
+	// the member to the role, e.g. BucketPolicyMember/example. It is empty
+	// for members that were bound outside of this provider.
+	ManagedBy string `json:"managedBy,omitempty"`
+}
+
 // A BucketStatus represents the observed state of a Bucket.
 type BucketStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
 
 	BucketOutputAttrs `json:"attributes,omitempty"`
+
+	// IAMMembers are the members bound to roles by the IAM policy of the
+	// bucket.
+	IAMMembers []BucketIAMMember `json:"iamMembers,omitempty"`
+
+	// TagValues are the Resource Manager tag values bound to the bucket. They
+	// are only observed if tag values are desired.
+	TagValues []string `json:"tagValues,omitempty"`
+
+	// ReleasedEventBasedHolds is the number of objects whose event-based
+	// holds were released when the bucket was last updated.
+	ReleasedEventBasedHolds int64 `json:"releasedEventBasedHolds,omitempty"`
 }
 
 // +kubebuilder:object:root=true
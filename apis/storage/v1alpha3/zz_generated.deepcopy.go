@@ -86,6 +86,21 @@ func (in *BucketEncryption) DeepCopy() *BucketEncryption {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BucketIAMMember) DeepCopyInto(out *BucketIAMMember) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketIAMMember.
+func (in *BucketIAMMember) DeepCopy() *BucketIAMMember {
+	if in == nil {
+		return nil
+	}
+	out := new(BucketIAMMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BucketList) DeepCopyInto(out *BucketList) {
 	*out = *in
@@ -166,6 +181,16 @@ func (in *BucketOutputAttrs) DeepCopy() *BucketOutputAttrs {
 func (in *BucketParameters) DeepCopyInto(out *BucketParameters) {
 	*out = *in
 	in.BucketSpecAttrs.DeepCopyInto(&out.BucketSpecAttrs)
+	if in.TagValues != nil {
+		in, out := &in.TagValues, &out.TagValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReleaseEventBasedHolds != nil {
+		in, out := &in.ReleaseEventBasedHolds, &out.ReleaseEventBasedHolds
+		*out = new(EventBasedHoldRelease)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketParameters.
@@ -246,6 +271,16 @@ func (in *BucketStatus) DeepCopyInto(out *BucketStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 	in.BucketOutputAttrs.DeepCopyInto(&out.BucketOutputAttrs)
+	if in.IAMMembers != nil {
+		in, out := &in.IAMMembers, &out.IAMMembers
+		*out = make([]BucketIAMMember, len(*in))
+		copy(*out, *in)
+	}
+	if in.TagValues != nil {
+		in, out := &in.TagValues, &out.TagValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BucketStatus.
@@ -359,6 +394,21 @@ func (in *CORS) DeepCopy() *CORS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventBasedHoldRelease) DeepCopyInto(out *EventBasedHoldRelease) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventBasedHoldRelease.
+func (in *EventBasedHoldRelease) DeepCopy() *EventBasedHoldRelease {
+	if in == nil {
+		return nil
+	}
+	out := new(EventBasedHoldRelease)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Lifecycle) DeepCopyInto(out *Lifecycle) {
 	*out = *in
@@ -29,6 +29,119 @@ type ProviderConfigSpec struct {
 
 	// ProjectID is the project name (not numerical ID) of this GCP ProviderConfig.
 	ProjectID string `json:"projectID"`
+
+	// QuotaProject is an optional project that is billed for, and whose
+	// quota is used by, the requests the provider makes to manage the IAM
+	// policies of storage buckets, e.g. of requester pays buckets. It is sent
+	// as the x-goog-user-project header.
+	// +optional
+	QuotaProject string `json:"quotaProject,omitempty"`
+
+	// CertificateAuthority is an optional PEM encoded bundle of certificate
+	// authorities that are trusted in addition to the system ones when
+	// connecting to the GCP API, e.g. the CA of a TLS intercepting proxy.
+	// +optional
+	CertificateAuthority *ProviderCertificateAuthority `json:"certificateAuthority,omitempty"`
+
+	// ClientCertificate is an optional PEM encoded client certificate and
+	// its private key that are presented when connecting to the GCP API,
+	// e.g. to a proxy that requires mutual TLS.
+	// +optional
+	ClientCertificate *ProviderClientCertificate `json:"clientCertificate,omitempty"`
+
+	// ProxyURL is an optional URL of the HTTP proxy that requests to the GCP
+	// API are sent through, e.g. http://proxy.example.com:3128. Proxies
+	// configured by the HTTPS_PROXY environment variable are used otherwise.
+	// +optional
+	ProxyURL string `json:"proxyURL,omitempty"`
+
+	// Impersonation configures an optional service account the credentials
+	// impersonate, so that all requests to the GCP API are made as that
+	// service account rather than as the credentials themselves.
+	// +optional
+	Impersonation *ProviderImpersonation `json:"impersonation,omitempty"`
+
+	// Endpoints override the endpoints used to connect to GCP services, e.g.
+	// to use regional endpoints for data residency or latency. Endpoints are
+	// only used by the controllers of regional managed resources.
+	// +optional
+	Endpoints []ProviderEndpoint `json:"endpoints,omitempty"`
+
+	// ExternalNames configure the naming convention that the external names
+	// the provider generates for managed resources follow.
+	// +optional
+	ExternalNames *ProviderExternalNames `json:"externalNames,omitempty"`
+}
+
+// ProviderExternalNames configure a naming convention of the GCP resources the
+// provider creates. The convention only applies to the external names the
+// provider generates from the names of managed resources that do not set
+// their own external name. Names that follow the convention must be valid
+// names of any GCP resource, i.e. start with a lower case letter, consist of
+// at most 63 lower case letters, digits and dashes, and not end with a dash.
+type ProviderExternalNames struct {
+	// Prefix prepended to generated external names, e.g. acme-.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[a-z][-a-z0-9]*$`
+	Prefix string `json:"prefix,omitempty"`
+
+	// Suffix appended to generated external names, e.g. -prod.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[-a-z0-9]*[a-z0-9]$`
+	Suffix string `json:"suffix,omitempty"`
+}
+
+// A ProviderEndpoint configures the endpoint used to connect to a GCP service.
+type ProviderEndpoint struct {
+	// Service the endpoint is used for, i.e. storage, sqladmin, redis, run
+	// or cloudresourcemanager.
+	Service string `json:"service"`
+
+	// URL of the endpoint. Any {region} in the URL is replaced with the
+	// lower case region or location of the managed resource, e.g.
+	// https://storage.{region}.rep.googleapis.com/storage/v1/.
+	URL string `json:"url"`
+
+	// Regions the endpoint is used for. An endpoint that lists the region of
+	// a managed resource takes precedence over one that omits its regions,
+	// which is used for all regions.
+	// +optional
+	Regions []string `json:"regions,omitempty"`
+}
+
+// ProviderCertificateAuthority is a bundle of trusted certificate authorities.
+type ProviderCertificateAuthority struct {
+	// Source of the certificate authority bundle.
+	// +kubebuilder:validation:Enum=Secret;Environment;Filesystem
+	Source xpv1.CredentialsSource `json:"source"`
+
+	xpv1.CommonCredentialSelectors `json:",inline"`
+}
+
+// ProviderClientCertificate is a PEM encoded client certificate followed by its
+// private key.
+type ProviderClientCertificate struct {
+	// Source of the client certificate and its private key.
+	// +kubebuilder:validation:Enum=Secret;Environment;Filesystem
+	Source xpv1.CredentialsSource `json:"source"`
+
+	xpv1.CommonCredentialSelectors `json:",inline"`
+}
+
+// ProviderImpersonation configures the impersonation of a service account.
+type ProviderImpersonation struct {
+	// ServiceAccount to impersonate, e.g.
+	// crossplane@my-project.iam.gserviceaccount.com. The credentials must be
+	// granted roles/iam.serviceAccountTokenCreator on it, or on the first of
+	// its delegates.
+	ServiceAccount string `json:"serviceAccount"`
+
+	// Delegates is an optional chain of service accounts through which the
+	// service account is impersonated, in order. Each of them must be
+	// granted roles/iam.serviceAccountTokenCreator on the next one, and the
+	// last one on the service account.
+	// +optional
+	Delegates []string `json:"delegates,omitempty"`
 }
 
 // ProviderCredentials required to authenticate.
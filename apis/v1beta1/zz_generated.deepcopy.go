@@ -24,6 +24,38 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderCertificateAuthority) DeepCopyInto(out *ProviderCertificateAuthority) {
+	*out = *in
+	in.CommonCredentialSelectors.DeepCopyInto(&out.CommonCredentialSelectors)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderCertificateAuthority.
+func (in *ProviderCertificateAuthority) DeepCopy() *ProviderCertificateAuthority {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderCertificateAuthority)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderClientCertificate) DeepCopyInto(out *ProviderClientCertificate) {
+	*out = *in
+	in.CommonCredentialSelectors.DeepCopyInto(&out.CommonCredentialSelectors)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderClientCertificate.
+func (in *ProviderClientCertificate) DeepCopy() *ProviderClientCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderClientCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderConfig) DeepCopyInto(out *ProviderConfig) {
 	*out = *in
@@ -87,6 +119,33 @@ func (in *ProviderConfigList) DeepCopyObject() runtime.Object {
 func (in *ProviderConfigSpec) DeepCopyInto(out *ProviderConfigSpec) {
 	*out = *in
 	in.Credentials.DeepCopyInto(&out.Credentials)
+	if in.CertificateAuthority != nil {
+		in, out := &in.CertificateAuthority, &out.CertificateAuthority
+		*out = new(ProviderCertificateAuthority)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(ProviderClientCertificate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Impersonation != nil {
+		in, out := &in.Impersonation, &out.Impersonation
+		*out = new(ProviderImpersonation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]ProviderEndpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExternalNames != nil {
+		in, out := &in.ExternalNames, &out.ExternalNames
+		*out = new(ProviderExternalNames)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigSpec.
@@ -188,3 +247,58 @@ func (in *ProviderCredentials) DeepCopy() *ProviderCredentials {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderEndpoint) DeepCopyInto(out *ProviderEndpoint) {
+	*out = *in
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderEndpoint.
+func (in *ProviderEndpoint) DeepCopy() *ProviderEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderExternalNames) DeepCopyInto(out *ProviderExternalNames) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderExternalNames.
+func (in *ProviderExternalNames) DeepCopy() *ProviderExternalNames {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderExternalNames)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderImpersonation) DeepCopyInto(out *ProviderImpersonation) {
+	*out = *in
+	if in.Delegates != nil {
+		in, out := &in.Delegates, &out.Delegates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderImpersonation.
+func (in *ProviderImpersonation) DeepCopy() *ProviderImpersonation {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderImpersonation)
+	in.DeepCopyInto(out)
+	return out
+}
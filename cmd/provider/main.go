@@ -28,7 +28,11 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 
 	"github.com/crossplane/provider-gcp/apis"
+	storagev1alpha1 "github.com/crossplane/provider-gcp/apis/storage/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/bucketpolicy"
 	"github.com/crossplane/provider-gcp/pkg/controller"
+	"github.com/crossplane/provider-gcp/pkg/controller/storage"
 )
 
 func main() {
@@ -37,7 +41,18 @@ func main() {
 		debug          = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
 		syncInterval   = app.Flag("sync", "Sync interval controls how often all resources will be double checked for drift.").Short('s').Default("1h").Duration()
 		pollInterval   = app.Flag("poll", "Poll interval controls how often an individual resource should be checked for drift.").Default("1m").Duration()
-		leaderElection = app.Flag("leader-election", "Use leader election for the conroller manager.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
+		leaderElection = app.Flag("leader-election", "Use leader election for the conroller manager. Should be enabled whenever more than one replica of the provider runs, so that only one of them reconciles resources at a time. Changes to IAM policies made concurrently by several replicas are retried, but contend for the same policies.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
+
+		reconcileTimeout  = app.Flag("reconcile-timeout", "Reconcile timeout controls how long a single reconcile of an individual resource, including its calls to the GCP API, may take. Cluster, NodePool and CloudSQLInstance resources default to 5m.").Default(controller.DefaultReconcileTimeout.String()).Duration()
+		reconcileTimeouts = app.Flag("reconcile-timeout-for", "Overrides the reconcile timeout of a kind of resource, e.g. Cluster.container.gcp.crossplane.io=10m. May be repeated.").PlaceHolder("KIND=DURATION").StringMap()
+		apiRateLimits     = app.Flag("api-rate-limit-for", "Limits the rate at which a kind of resource calls the GCP API to a number of calls per second, optionally followed by the number of calls that may burst, e.g. BucketPolicyMember.storage.gcp.crossplane.io=10/20. Only BucketPolicyMember resources support a limit, which defaults to none. May be repeated.").PlaceHolder("KIND=QPS[/BURST]").StringMap()
+		policyCacheTTL    = app.Flag("bucket-policy-cache-ttl", "Controls how long the IAM policy of a bucket is shared by the BucketPolicyMember resources of the bucket once read, rather than read by each of them. 0 only shares concurrent reads.").Default(bucketpolicy.DefaultPolicyCacheTTL.String()).Duration()
+		policyBatchWindow = app.Flag("bucket-policy-batch-window", "Controls how long changes of the BucketPolicyMember resources of a bucket to its IAM policy are collected once one of them changes it, so that the policy is read and set once for all of them. 0 disables batching.").Default(bucketpolicy.DefaultBatchWindow.String()).Duration()
+		apiCallTimeout    = app.Flag("api-call-timeout", "Controls how long a single call of a BucketPolicyMember resource to the GCP API may take before it is abandoned and retried. 0 disables the timeout; calls remain bounded by the reconcile timeout.").Default(gcp.DefaultCallTimeout.String()).Duration()
+		errorBackoffCap   = app.Flag("bucket-policy-error-backoff-cap", "Controls how long a BucketPolicyMember resource whose observations keep failing with the same error, e.g. because reading the IAM policy of its bucket is forbidden, waits at most before calling the GCP API again. The wait starts at the poll interval and doubles with each failure. 0 disables the backoff.").Default(bucketpolicy.DefaultErrorBackoffCap.String()).Duration()
+		readOnly          = app.Flag("read-only", "Run in read-only mode, in which BucketPolicyMember, BucketPolicyBinding and BucketPolicy resources observe the IAM policies of their buckets and report the changes they would make to them, as in dry-run mode, but never change them.").Bool()
+		allowedDomains    = app.Flag("allowed-member-domain", "Restricts the user:, group:, serviceAccount: and domain: members BucketPolicyMember resources may bind to those of a domain or of its subdomains, e.g. example.com. Binding a member of another domain is refused. Members of any domain may be bound unless one is allowed. May be repeated.").PlaceHolder("DOMAIN").Strings()
+		readyTimeouts     = app.Flag("ready-timeout-for", "Overrides how long after creation a Cluster or CloudSQLInstance resource may remain unavailable before it is marked as degraded, e.g. Cluster.container.gcp.crossplane.io=1h. Both default to 30m; 0 disables the timeout. May be repeated.").PlaceHolder("KIND=DURATION").StringMap()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -62,7 +77,23 @@ func main() {
 	})
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 
+	timeouts, err := controller.NewTimeouts(*reconcileTimeout, *reconcileTimeouts)
+	kingpin.FatalIfError(err, "Cannot parse reconcile timeouts")
+	timeouts, err = timeouts.WithReadyTimeouts(*readyTimeouts)
+	kingpin.FatalIfError(err, "Cannot parse ready timeouts")
+	api, err := controller.NewAPIRateLimits(*apiRateLimits)
+	kingpin.FatalIfError(err, "Cannot parse GCP API rate limits")
+
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add GCP APIs to scheme")
-	kingpin.FatalIfError(controller.Setup(mgr, log, ratelimiter.NewDefaultProviderRateLimiter(ratelimiter.DefaultProviderRPS), *pollInterval), "Cannot setup GCP controllers")
+	bpm := storage.BucketPolicyMemberOptions{
+		APIRateLimiter:  api.For(storagev1alpha1.BucketPolicyMemberGroupKind),
+		PolicyTTL:       *policyCacheTTL,
+		BatchWindow:     *policyBatchWindow,
+		CallTimeout:     *apiCallTimeout,
+		ErrorBackoffCap: *errorBackoffCap,
+		ReadOnly:        *readOnly,
+		AllowedDomains:  *allowedDomains,
+	}
+	kingpin.FatalIfError(controller.Setup(mgr, log, ratelimiter.NewDefaultProviderRateLimiter(ratelimiter.DefaultProviderRPS), *pollInterval, timeouts, bpm), "Cannot setup GCP controllers")
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
 }
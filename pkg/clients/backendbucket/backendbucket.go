@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backendbucket
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/mitchellh/copystructure"
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const errCheckUpToDate = "unable to determine if external resource is up to date"
+
+// GenerateBackendBucket takes a *BackendBucketParameters and returns
+// *compute.BackendBucket. It assigns only the fields that are writable, i.e.
+// not labelled as [Output Only] in Google's reference. Signed URL keys are
+// not part of a backend bucket and must be added and deleted separately.
+func GenerateBackendBucket(name string, in v1alpha1.BackendBucketParameters, bb *compute.BackendBucket) {
+	bb.Name = name
+	bb.BucketName = gcp.StringValue(in.BucketName)
+	bb.Description = gcp.StringValue(in.Description)
+	bb.EnableCdn = gcp.BoolValue(in.EnableCDN)
+	bb.ForceSendFields = []string{"EnableCdn"}
+
+	if in.CDNPolicy != nil {
+		if bb.CdnPolicy == nil {
+			bb.CdnPolicy = &compute.BackendBucketCdnPolicy{}
+		}
+		bb.CdnPolicy.CacheMode = gcp.StringValue(in.CDNPolicy.CacheMode)
+		bb.CdnPolicy.ClientTtl = gcp.Int64Value(in.CDNPolicy.ClientTTL)
+		bb.CdnPolicy.DefaultTtl = gcp.Int64Value(in.CDNPolicy.DefaultTTL)
+		bb.CdnPolicy.MaxTtl = gcp.Int64Value(in.CDNPolicy.MaxTTL)
+		bb.CdnPolicy.NegativeCaching = gcp.BoolValue(in.CDNPolicy.NegativeCaching)
+		bb.CdnPolicy.RequestCoalescing = gcp.BoolValue(in.CDNPolicy.RequestCoalescing)
+		bb.CdnPolicy.ServeWhileStale = gcp.Int64Value(in.CDNPolicy.ServeWhileStale)
+		bb.CdnPolicy.SignedUrlCacheMaxAgeSec = gcp.Int64Value(in.CDNPolicy.SignedURLCacheMaxAgeSec)
+	}
+}
+
+// GenerateObservation takes a compute.BackendBucket and returns
+// *BackendBucketObservation.
+func GenerateObservation(in compute.BackendBucket) v1alpha1.BackendBucketObservation {
+	o := v1alpha1.BackendBucketObservation{
+		CreationTimestamp: in.CreationTimestamp,
+		ID:                in.Id,
+		SelfLink:          in.SelfLink,
+	}
+	if in.CdnPolicy != nil {
+		o.SignedURLKeyNames = in.CdnPolicy.SignedUrlKeyNames
+	}
+	return o
+}
+
+// LateInitializeSpec fills unassigned fields with the values in
+// compute.BackendBucket object.
+func LateInitializeSpec(spec *v1alpha1.BackendBucketParameters, in compute.BackendBucket) {
+	spec.Description = gcp.LateInitializeString(spec.Description, in.Description)
+	spec.EnableCDN = gcp.LateInitializeBool(spec.EnableCDN, in.EnableCdn)
+
+	// GCP defaults the CDN policy when CDN is enabled.
+	if in.CdnPolicy == nil {
+		return
+	}
+	if spec.CDNPolicy == nil {
+		spec.CDNPolicy = &v1alpha1.BackendBucketCDNPolicy{}
+	}
+	spec.CDNPolicy.CacheMode = gcp.LateInitializeString(spec.CDNPolicy.CacheMode, in.CdnPolicy.CacheMode)
+	spec.CDNPolicy.ClientTTL = gcp.LateInitializeInt64(spec.CDNPolicy.ClientTTL, in.CdnPolicy.ClientTtl)
+	spec.CDNPolicy.DefaultTTL = gcp.LateInitializeInt64(spec.CDNPolicy.DefaultTTL, in.CdnPolicy.DefaultTtl)
+	spec.CDNPolicy.MaxTTL = gcp.LateInitializeInt64(spec.CDNPolicy.MaxTTL, in.CdnPolicy.MaxTtl)
+	spec.CDNPolicy.NegativeCaching = gcp.LateInitializeBool(spec.CDNPolicy.NegativeCaching, in.CdnPolicy.NegativeCaching)
+	spec.CDNPolicy.RequestCoalescing = gcp.LateInitializeBool(spec.CDNPolicy.RequestCoalescing, in.CdnPolicy.RequestCoalescing)
+	spec.CDNPolicy.ServeWhileStale = gcp.LateInitializeInt64(spec.CDNPolicy.ServeWhileStale, in.CdnPolicy.ServeWhileStale)
+	spec.CDNPolicy.SignedURLCacheMaxAgeSec = gcp.LateInitializeInt64(spec.CDNPolicy.SignedURLCacheMaxAgeSec, in.CdnPolicy.SignedUrlCacheMaxAgeSec)
+}
+
+// IsUpToDate checks whether current state is up-to-date compared to the given
+// set of parameters. Signed URL keys are not considered; see
+// DiffSignedURLKeys.
+func IsUpToDate(name string, in *v1alpha1.BackendBucketParameters, observed *compute.BackendBucket) (bool, error) {
+	generated, err := copystructure.Copy(observed)
+	if err != nil {
+		return true, errors.Wrap(err, errCheckUpToDate)
+	}
+	desired, ok := generated.(*compute.BackendBucket)
+	if !ok {
+		return true, errors.New(errCheckUpToDate)
+	}
+	GenerateBackendBucket(name, *in, desired)
+	return cmp.Equal(desired, observed, cmpopts.EquateEmpty(),
+		cmpopts.IgnoreFields(compute.BackendBucket{}, "ForceSendFields")), nil
+}
+
+// DiffSignedURLKeys returns the desired signed URL keys that must be added
+// to, and the names of the keys that must be deleted from, the observed
+// backend bucket. Keys are identified by name.
+func DiffSignedURLKeys(in []v1alpha1.SignedURLKey, observed *compute.BackendBucket) (add []v1alpha1.SignedURLKey, remove []string) {
+	active := map[string]bool{}
+	if observed.CdnPolicy != nil {
+		for _, n := range observed.CdnPolicy.SignedUrlKeyNames {
+			active[n] = true
+		}
+	}
+	desired := make(map[string]bool, len(in))
+	for _, k := range in {
+		desired[k.KeyName] = true
+		if !active[k.KeyName] {
+			add = append(add, k)
+		}
+	}
+	if observed.CdnPolicy != nil {
+		for _, n := range observed.CdnPolicy.SignedUrlKeyNames {
+			if !desired[n] {
+				remove = append(remove, n)
+			}
+		}
+	}
+	return add, remove
+}
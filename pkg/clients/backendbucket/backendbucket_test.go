@@ -0,0 +1,142 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backendbucket
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testName   = "static-assets"
+	testBucket = "my-bucket"
+)
+
+func key(name string) v1alpha1.SignedURLKey {
+	return v1alpha1.SignedURLKey{KeyName: name}
+}
+
+func TestDiffSignedURLKeys(t *testing.T) {
+	type want struct {
+		add    []v1alpha1.SignedURLKey
+		remove []string
+	}
+	cases := map[string]struct {
+		in       []v1alpha1.SignedURLKey
+		observed *compute.BackendBucket
+		want     want
+	}{
+		"NoCDNPolicy": {
+			in:       []v1alpha1.SignedURLKey{key("key-1")},
+			observed: &compute.BackendBucket{},
+			want:     want{add: []v1alpha1.SignedURLKey{key("key-1")}},
+		},
+		"InSync": {
+			in:       []v1alpha1.SignedURLKey{key("key-2"), key("key-1")},
+			observed: &compute.BackendBucket{CdnPolicy: &compute.BackendBucketCdnPolicy{SignedUrlKeyNames: []string{"key-1", "key-2"}}},
+		},
+		"AddKey": {
+			in:       []v1alpha1.SignedURLKey{key("key-1"), key("key-2")},
+			observed: &compute.BackendBucket{CdnPolicy: &compute.BackendBucketCdnPolicy{SignedUrlKeyNames: []string{"key-1"}}},
+			want:     want{add: []v1alpha1.SignedURLKey{key("key-2")}},
+		},
+		"RemoveKey": {
+			in:       []v1alpha1.SignedURLKey{key("key-2")},
+			observed: &compute.BackendBucket{CdnPolicy: &compute.BackendBucketCdnPolicy{SignedUrlKeyNames: []string{"key-1", "key-2"}}},
+			want:     want{remove: []string{"key-1"}},
+		},
+		"RotateKey": {
+			in:       []v1alpha1.SignedURLKey{key("key-2")},
+			observed: &compute.BackendBucket{CdnPolicy: &compute.BackendBucketCdnPolicy{SignedUrlKeyNames: []string{"key-1"}}},
+			want:     want{add: []v1alpha1.SignedURLKey{key("key-2")}, remove: []string{"key-1"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			add, remove := DiffSignedURLKeys(tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want.add, add); diff != "" {
+				t.Errorf("DiffSignedURLKeys(...): -want add, +got add:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.remove, remove); diff != "" {
+				t.Errorf("DiffSignedURLKeys(...): -want remove, +got remove:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		in       *v1alpha1.BackendBucketParameters
+		observed *compute.BackendBucket
+		want     bool
+	}{
+		"UpToDate": {
+			in: &v1alpha1.BackendBucketParameters{
+				BucketName: gcp.StringPtr(testBucket),
+				EnableCDN:  gcp.BoolPtr(true),
+				CDNPolicy:  &v1alpha1.BackendBucketCDNPolicy{DefaultTTL: gcp.Int64Ptr(3600)},
+			},
+			observed: &compute.BackendBucket{
+				Name:       testName,
+				BucketName: testBucket,
+				EnableCdn:  true,
+				CdnPolicy:  &compute.BackendBucketCdnPolicy{DefaultTtl: 3600, SignedUrlKeyNames: []string{"key-1"}},
+			},
+			want: true,
+		},
+		"CDNDisabled": {
+			in: &v1alpha1.BackendBucketParameters{
+				BucketName: gcp.StringPtr(testBucket),
+				EnableCDN:  gcp.BoolPtr(false),
+			},
+			observed: &compute.BackendBucket{Name: testName, BucketName: testBucket, EnableCdn: true},
+			want:     false,
+		},
+		"CachePolicyChanged": {
+			in: &v1alpha1.BackendBucketParameters{
+				BucketName: gcp.StringPtr(testBucket),
+				EnableCDN:  gcp.BoolPtr(true),
+				CDNPolicy:  &v1alpha1.BackendBucketCDNPolicy{CacheMode: gcp.StringPtr("FORCE_CACHE_ALL")},
+			},
+			observed: &compute.BackendBucket{
+				Name:       testName,
+				BucketName: testBucket,
+				EnableCdn:  true,
+				CdnPolicy:  &compute.BackendBucketCdnPolicy{CacheMode: "CACHE_ALL_STATIC"},
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := IsUpToDate(testName, tc.in, tc.observed)
+			if err != nil {
+				t.Errorf("IsUpToDate(...): unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
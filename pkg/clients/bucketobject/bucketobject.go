@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucketobject
+
+import (
+	"crypto/md5" // nolint:gosec
+	"encoding/base64"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/api/storage/v1"
+
+	"github.com/crossplane/provider-gcp/apis/storage/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// Client should be satisfied to conduct BucketObject operations.
+type Client interface {
+	Get(bucket string, object string) *storage.ObjectsGetCall
+	Insert(bucket string, object *storage.Object) *storage.ObjectsInsertCall
+	Patch(bucket string, object string, o *storage.Object) *storage.ObjectsPatchCall
+	Delete(bucket string, object string) *storage.ObjectsDeleteCall
+}
+
+// GenerateObject takes a BucketObjectParameters and returns the
+// *storage.Object whose content is uploaded with it.
+func GenerateObject(name string, in v1alpha1.BucketObjectParameters) *storage.Object {
+	return &storage.Object{
+		Name:         name,
+		ContentType:  gcp.StringValue(in.ContentType),
+		CacheControl: gcp.StringValue(in.CacheControl),
+		Metadata:     in.Metadata,
+	}
+}
+
+// GeneratePatch returns the *storage.Object that updates the metadata of the
+// supplied object to the desired metadata. Metadata keys that are no longer
+// desired are removed.
+func GeneratePatch(in v1alpha1.BucketObjectParameters, observed storage.Object) *storage.Object {
+	o := &storage.Object{
+		ContentType:  gcp.StringValue(in.ContentType),
+		CacheControl: gcp.StringValue(in.CacheControl),
+		Metadata:     in.Metadata,
+	}
+	for _, k := range gcp.RemovedLabels(in.Metadata, observed.Metadata) {
+		o.NullFields = append(o.NullFields, "Metadata."+k)
+	}
+	return o
+}
+
+// GenerateObservation takes a storage.Object and returns
+// *BucketObjectObservation.
+func GenerateObservation(in storage.Object) v1alpha1.BucketObjectObservation {
+	return v1alpha1.BucketObjectObservation{
+		Generation: in.Generation,
+		MD5Hash:    in.Md5Hash,
+		MediaLink:  in.MediaLink,
+		SelfLink:   in.SelfLink,
+		Size:       in.Size,
+	}
+}
+
+// LateInitializeSpec fills unassigned fields with the values in
+// storage.Object object.
+func LateInitializeSpec(spec *v1alpha1.BucketObjectParameters, in storage.Object) {
+	spec.ContentType = gcp.LateInitializeString(spec.ContentType, in.ContentType)
+	spec.CacheControl = gcp.LateInitializeString(spec.CacheControl, in.CacheControl)
+}
+
+// MD5Hash returns the base64-encoded MD5 hash of the supplied content, as
+// GCP reports it for objects.
+func MD5Hash(content string) string {
+	// GCP reports MD5 hashes; they are not used for security.
+	h := md5.Sum([]byte(content)) // nolint:gosec
+	return base64.StdEncoding.EncodeToString(h[:])
+}
+
+// IsContentUpToDate returns true if the observed object has the desired
+// content.
+func IsContentUpToDate(in v1alpha1.BucketObjectParameters, observed storage.Object) bool {
+	return MD5Hash(in.Content) == observed.Md5Hash
+}
+
+// IsMetadataUpToDate returns true if the observed object has the desired
+// metadata.
+func IsMetadataUpToDate(in v1alpha1.BucketObjectParameters, observed storage.Object) bool {
+	return (in.ContentType == nil || *in.ContentType == observed.ContentType) &&
+		(in.CacheControl == nil || *in.CacheControl == observed.CacheControl) &&
+		cmp.Equal(in.Metadata, observed.Metadata, cmpopts.EquateEmpty())
+}
+
+// IsUpToDate returns true if the observed object has the desired content and
+// metadata.
+func IsUpToDate(in v1alpha1.BucketObjectParameters, observed storage.Object) bool {
+	return IsContentUpToDate(in, observed) && IsMetadataUpToDate(in, observed)
+}
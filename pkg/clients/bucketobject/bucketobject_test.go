@@ -0,0 +1,234 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucketobject
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/storage/v1"
+
+	"github.com/crossplane/provider-gcp/apis/storage/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testName    = "config/app.json"
+	testContent = `{"greeting": "hello"}`
+
+	// testMD5Hash is the base64-encoded MD5 hash of testContent.
+	testMD5Hash = "eFfJAVVf+lZnL3hbaJOz9A=="
+)
+
+func params(m ...func(*v1alpha1.BucketObjectParameters)) *v1alpha1.BucketObjectParameters {
+	o := &v1alpha1.BucketObjectParameters{
+		Bucket:       gcp.StringPtr("my-bucket"),
+		Content:      testContent,
+		ContentType:  gcp.StringPtr("application/json"),
+		CacheControl: gcp.StringPtr("no-store"),
+		Metadata:     map[string]string{"owner": "crossplane"},
+	}
+	for _, f := range m {
+		f(o)
+	}
+	return o
+}
+
+func object(m ...func(*storage.Object)) *storage.Object {
+	o := &storage.Object{
+		Name:         testName,
+		Bucket:       "my-bucket",
+		ContentType:  "application/json",
+		CacheControl: "no-store",
+		Metadata:     map[string]string{"owner": "crossplane"},
+		Md5Hash:      testMD5Hash,
+	}
+	for _, f := range m {
+		f(o)
+	}
+	return o
+}
+
+func TestMD5Hash(t *testing.T) {
+	if diff := cmp.Diff(testMD5Hash, MD5Hash(testContent)); diff != "" {
+		t.Errorf("MD5Hash(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestGenerateObject(t *testing.T) {
+	cases := map[string]struct {
+		in   v1alpha1.BucketObjectParameters
+		want *storage.Object
+	}{
+		"Full": {
+			in: *params(),
+			want: &storage.Object{
+				Name:         testName,
+				ContentType:  "application/json",
+				CacheControl: "no-store",
+				Metadata:     map[string]string{"owner": "crossplane"},
+			},
+		},
+		"ContentOnly": {
+			in:   v1alpha1.BucketObjectParameters{Content: testContent},
+			want: &storage.Object{Name: testName},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateObject(testName, tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateObject(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGeneratePatch(t *testing.T) {
+	cases := map[string]struct {
+		in       v1alpha1.BucketObjectParameters
+		observed storage.Object
+		want     *storage.Object
+	}{
+		"MetadataChanged": {
+			in:       *params(func(p *v1alpha1.BucketObjectParameters) { p.Metadata = map[string]string{"owner": "platform"} }),
+			observed: *object(),
+			want: &storage.Object{
+				ContentType:  "application/json",
+				CacheControl: "no-store",
+				Metadata:     map[string]string{"owner": "platform"},
+			},
+		},
+		"MetadataRemoved": {
+			in: *params(func(p *v1alpha1.BucketObjectParameters) { p.Metadata = map[string]string{"team": "a"} }),
+			observed: *object(func(o *storage.Object) {
+				o.Metadata = map[string]string{"owner": "crossplane", "env": "dev", "team": "a"}
+			}),
+			want: &storage.Object{
+				ContentType:  "application/json",
+				CacheControl: "no-store",
+				Metadata:     map[string]string{"team": "a"},
+				NullFields:   []string{"Metadata.env", "Metadata.owner"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GeneratePatch(tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GeneratePatch(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateObservation(t *testing.T) {
+	in := *object(func(o *storage.Object) {
+		o.Generation = 1633000000000000
+		o.MediaLink = "https://storage.googleapis.com/download/storage/v1/b/my-bucket/o/config%2Fapp.json?alt=media"
+		o.SelfLink = "https://www.googleapis.com/storage/v1/b/my-bucket/o/config%2Fapp.json"
+		o.Size = 21
+	})
+	want := v1alpha1.BucketObjectObservation{
+		Generation: 1633000000000000,
+		MD5Hash:    testMD5Hash,
+		MediaLink:  "https://storage.googleapis.com/download/storage/v1/b/my-bucket/o/config%2Fapp.json?alt=media",
+		SelfLink:   "https://www.googleapis.com/storage/v1/b/my-bucket/o/config%2Fapp.json",
+		Size:       21,
+	}
+	if diff := cmp.Diff(want, GenerateObservation(in)); diff != "" {
+		t.Errorf("GenerateObservation(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	cases := map[string]struct {
+		spec     *v1alpha1.BucketObjectParameters
+		observed storage.Object
+		want     *v1alpha1.BucketObjectParameters
+	}{
+		"AllFilled": {
+			spec:     params(),
+			observed: *object(func(o *storage.Object) { o.ContentType = "text/plain" }),
+			want:     params(),
+		},
+		"ContentTypeGuessed": {
+			spec: params(func(p *v1alpha1.BucketObjectParameters) {
+				p.ContentType = nil
+				p.CacheControl = nil
+			}),
+			observed: *object(func(o *storage.Object) { o.CacheControl = "" }),
+			want:     params(func(p *v1alpha1.BucketObjectParameters) { p.CacheControl = nil }),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.spec, tc.observed)
+			if diff := cmp.Diff(tc.want, tc.spec); diff != "" {
+				t.Errorf("LateInitializeSpec(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	type want struct {
+		content  bool
+		upToDate bool
+	}
+	cases := map[string]struct {
+		in       v1alpha1.BucketObjectParameters
+		observed storage.Object
+		want     want
+	}{
+		"UpToDate": {
+			in:       *params(),
+			observed: *object(),
+			want:     want{content: true, upToDate: true},
+		},
+		"ContentChanged": {
+			in:       *params(func(p *v1alpha1.BucketObjectParameters) { p.Content = `{"greeting": "hi"}` }),
+			observed: *object(),
+			want:     want{content: false, upToDate: false},
+		},
+		"CacheControlChanged": {
+			in:       *params(func(p *v1alpha1.BucketObjectParameters) { p.CacheControl = gcp.StringPtr("max-age=60") }),
+			observed: *object(),
+			want:     want{content: true, upToDate: false},
+		},
+		"MetadataRemoved": {
+			in:       *params(func(p *v1alpha1.BucketObjectParameters) { p.Metadata = nil }),
+			observed: *object(),
+			want:     want{content: true, upToDate: false},
+		},
+		"NoMetadata": {
+			in:       *params(func(p *v1alpha1.BucketObjectParameters) { p.Metadata = nil }),
+			observed: *object(func(o *storage.Object) { o.Metadata = nil }),
+			want:     want{content: true, upToDate: true},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want.content, IsContentUpToDate(tc.in, tc.observed)); diff != "" {
+				t.Errorf("IsContentUpToDate(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.upToDate, IsUpToDate(tc.in, tc.observed)); diff != "" {
+				t.Errorf("IsUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
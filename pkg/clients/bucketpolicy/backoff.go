@@ -0,0 +1,146 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucketpolicy
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// DefaultErrorBackoffCap is the longest an ErrorBackoff waits before calling
+// again once calls failed repeatedly. It is zero, i.e. failing calls are not
+// backed off, unless a cap is configured.
+const DefaultErrorBackoffCap time.Duration = 0
+
+// An ErrorBackoff spaces out the calls of a resource, e.g. the observations of
+// a resource binding roles in the IAM policy of a bucket, that keep failing
+// with the same class of error, e.g. because the provider is forbidden to read
+// the policy, so that they do not exhaust the GCP API quota. A nil ErrorBackoff
+// never waits.
+type ErrorBackoff struct {
+	base time.Duration
+	cap  time.Duration
+	now  func() time.Time
+
+	mu       sync.Mutex
+	failures map[string]*failure
+}
+
+// A failure of the calls of a version of a resource.
+type failure struct {
+	version string
+	class   string
+	err     error
+	count   int
+	until   time.Time
+}
+
+// An ErrorBackoffOption configures an ErrorBackoff.
+type ErrorBackoffOption func(*ErrorBackoff)
+
+// WithClock configures the clock an ErrorBackoff tells the time by, e.g. a
+// fake one.
+func WithClock(now func() time.Time) ErrorBackoffOption {
+	return func(b *ErrorBackoff) { b.now = now }
+}
+
+// NewErrorBackoff returns an ErrorBackoff that waits for the supplied base
+// duration before calling again once a call failed, doubling the wait each
+// time a call fails with the same class of error up to the supplied cap.
+func NewErrorBackoff(base, cap time.Duration, o ...ErrorBackoffOption) *ErrorBackoff {
+	b := &ErrorBackoff{base: base, cap: cap, now: time.Now, failures: map[string]*failure{}}
+	for _, fn := range o {
+		fn(b)
+	}
+	return b
+}
+
+// Do calls the supplied function for the supplied version, e.g. the
+// generation, of the resource identified by the supplied key, unless its
+// previous call failed and the ErrorBackoff is still waiting to call it again,
+// in which case the error of its previous call is returned. A call that
+// succeeds resets the wait, as does one of another version of the resource,
+// or one that fails with a different class of error, e.g. a different HTTP
+// status code. Only the failures of the latest version of a resource are kept.
+func (b *ErrorBackoff) Do(key, version string, call func() error) error {
+	if b == nil {
+		return call()
+	}
+
+	b.mu.Lock()
+	f, ok := b.failures[key]
+	if ok && f.version == version && b.now().Before(f.until) {
+		b.mu.Unlock()
+		return f.err
+	}
+	b.mu.Unlock()
+
+	err := call()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		delete(b.failures, key)
+		return nil
+	}
+	class := errorClass(err)
+	f, ok = b.failures[key]
+	if !ok || f.version != version || f.class != class {
+		f = &failure{version: version, class: class}
+		b.failures[key] = f
+	}
+	f.err = err
+	f.count++
+	f.until = b.now().Add(b.wait(f.count))
+	return err
+}
+
+// Forget the failures of the resource identified by the supplied key, e.g.
+// once it is deleted, so that its next call is made right away.
+func (b *ErrorBackoff) Forget(key string) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	delete(b.failures, key)
+	b.mu.Unlock()
+}
+
+// wait returns how long to wait once a call failed the supplied number of
+// times in a row.
+func (b *ErrorBackoff) wait(failures int) time.Duration {
+	w := b.base
+	for i := 1; i < failures && w < b.cap; i++ {
+		w *= 2
+	}
+	if w > b.cap {
+		w = b.cap
+	}
+	return w
+}
+
+// errorClass returns the class of the supplied error, i.e. its HTTP status
+// code if it is a response from the GCP API, or its message otherwise.
+func errorClass(err error) string {
+	if code := gcp.ErrorCode(err); code != 0 {
+		return strconv.Itoa(code)
+	}
+	return err.Error()
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucketpolicy
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/googleapi"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+func TestErrorBackoff(t *testing.T) {
+	errForbidden := errors.Wrap(&googleapi.Error{Code: http.StatusForbidden}, "cannot get policy")
+	errNotFound := errors.Wrap(&googleapi.Error{Code: http.StatusNotFound}, "cannot get policy")
+	now := time.Now()
+
+	// A step advances the clock to the supplied offset from now, and calls
+	// the backoff with a function that returns the supplied error.
+	type step struct {
+		at      time.Duration
+		version string
+		err     error
+		called  bool
+	}
+	cases := map[string]struct {
+		reason string
+		steps  []step
+	}{
+		"Grows": {
+			reason: "The wait should double each time a call fails with the same class of error, up to the cap.",
+			steps: []step{
+				{at: 0, err: errForbidden, called: true},
+				{at: 59 * time.Second, err: errForbidden},
+				{at: time.Minute, err: errForbidden, called: true},
+				{at: 2*time.Minute + 59*time.Second, err: errForbidden},
+				{at: 3 * time.Minute, err: errForbidden, called: true},
+				{at: 6*time.Minute + 59*time.Second, err: errForbidden},
+				{at: 7 * time.Minute, err: errForbidden, called: true},
+				// The wait is capped at five minutes rather than doubled
+				// to eight.
+				{at: 12 * time.Minute, err: errForbidden, called: true},
+				{at: 17 * time.Minute, err: errForbidden, called: true},
+			},
+		},
+		"ResetBySuccess": {
+			reason: "A call that succeeds should reset the wait.",
+			steps: []step{
+				{at: 0, err: errForbidden, called: true},
+				{at: time.Minute, err: errForbidden, called: true},
+				{at: 3 * time.Minute, called: true},
+				{at: 3 * time.Minute, err: errForbidden, called: true},
+				{at: 3*time.Minute + 59*time.Second, err: errForbidden},
+				{at: 4 * time.Minute, err: errForbidden, called: true},
+			},
+		},
+		"ResetByOtherClass": {
+			reason: "A call that fails with another class of error should reset the wait.",
+			steps: []step{
+				{at: 0, err: errForbidden, called: true},
+				{at: time.Minute, err: errForbidden, called: true},
+				{at: 3 * time.Minute, err: errNotFound, called: true},
+				{at: 3*time.Minute + 59*time.Second, err: errNotFound},
+				{at: 4 * time.Minute, err: errNotFound, called: true},
+			},
+		},
+		"ResetByOtherVersion": {
+			reason: "A call of another version of the resource should reset the wait.",
+			steps: []step{
+				{at: 0, version: "1", err: errForbidden, called: true},
+				{at: time.Minute, version: "1", err: errForbidden, called: true},
+				{at: time.Minute + 59*time.Second, version: "2", err: errForbidden, called: true},
+				{at: 2*time.Minute + 58*time.Second, version: "2", err: errForbidden},
+				{at: 2*time.Minute + 59*time.Second, version: "2", err: errForbidden, called: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			b := NewErrorBackoff(time.Minute, 5*time.Minute)
+			var last error
+			for i, s := range tc.steps {
+				b.now = func() time.Time { return now.Add(s.at) }
+				called := false
+				err := b.Do(testBucket, s.version, func() error {
+					called = true
+					return s.err
+				})
+				if called != s.called {
+					t.Errorf("\n%s\nDo(...): step %d: want called %t, got %t", tc.reason, i, s.called, called)
+				}
+				want := s.err
+				if !s.called {
+					want = last
+				}
+				if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+					t.Errorf("\n%s\nDo(...): step %d: -want error, +got error:\n%s", tc.reason, i, diff)
+				}
+				last = err
+			}
+		})
+	}
+}
+
+func TestErrorBackoffKeys(t *testing.T) {
+	b := NewErrorBackoff(time.Minute, 5*time.Minute)
+	errBoom := errors.New("boom")
+	_ = b.Do(testBucket, "1", func() error { return errBoom })
+
+	called := false
+	if err := b.Do("other-bucket", "1", func() error { called = true; return nil }); err != nil || !called {
+		t.Errorf("Do(...): want the calls of another key to be made, got called %t, error %v", called, err)
+	}
+}
+
+func TestErrorBackoffForget(t *testing.T) {
+	b := NewErrorBackoff(time.Minute, 5*time.Minute)
+	errBoom := errors.New("boom")
+	_ = b.Do(testBucket, "1", func() error { return errBoom })
+	b.Forget(testBucket)
+
+	called := false
+	if err := b.Do(testBucket, "1", func() error { called = true; return nil }); err != nil || !called {
+		t.Errorf("Do(...): want the calls of a forgotten key to be made, got called %t, error %v", called, err)
+	}
+	if len(b.failures) != 0 {
+		t.Errorf("Forget(...): want no failures kept, got %d", len(b.failures))
+	}
+}
+
+func TestErrorBackoffNil(t *testing.T) {
+	var b *ErrorBackoff
+	errBoom := errors.New("boom")
+	calls := 0
+	for i := 0; i < 2; i++ {
+		_ = b.Do(testBucket, "1", func() error { calls++; return errBoom })
+	}
+	if calls != 2 {
+		t.Errorf("Do(...): want a nil ErrorBackoff to always call, got %d calls", calls)
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucketpolicy
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/api/storage/v1"
+)
+
+// DefaultBatchWindow is how long a Batcher waits for further changes to the
+// IAM policy of a bucket once a change to it was submitted.
+const DefaultBatchWindow = 100 * time.Millisecond
+
+// DefaultBatchTimeout is how long a Batcher may take to apply a batch of
+// changes once its window closed.
+const DefaultBatchTimeout = time.Minute
+
+// A Change to an IAM policy. It returns true if it changed the policy.
+type Change func(*storage.Policy) (bool, error)
+
+// An ApplyFn reads an IAM policy, applies the supplied change to it and sets
+// it unless the change left it unchanged. The change may be applied more than
+// once, e.g. to a policy that is read again after it changed concurrently. It
+// returns true if the policy was set.
+type ApplyFn func(ctx context.Context, change Change) (bool, error)
+
+// A Batcher coalesces the changes the resources binding roles in the IAM
+// policies of buckets submit to the policy of the same bucket within a short
+// window, so that the policy is read and set once for all of them rather than
+// once for each. A nil Batcher applies each change on its own.
+type Batcher struct {
+	window  time.Duration
+	timeout time.Duration
+
+	mu      sync.Mutex
+	batches map[string]*batch
+}
+
+// A batch of changes to the IAM policy of a bucket, which is applied once its
+// window closed.
+type batch struct {
+	changes []*batchedChange
+	done    chan struct{}
+}
+
+// A batchedChange is a change in a batch, and the result of applying it.
+type batchedChange struct {
+	change  Change
+	changed bool
+	err     error
+}
+
+// NewBatcher returns a Batcher that waits for the supplied duration for
+// further changes once a change to the IAM policy of a bucket was submitted.
+func NewBatcher(window time.Duration) *Batcher {
+	return &Batcher{window: window, timeout: DefaultBatchTimeout, batches: map[string]*batch{}}
+}
+
+// Apply the supplied change to the IAM policy identified by the supplied key,
+// e.g. of a bucket, along with the other changes submitted to it within the
+// window of the Batcher. The batch is applied using the apply function of its
+// first change once the window closed, so changes that share a key must share
+// how they are applied. It is applied with a context of its own, which is not
+// done before the batch timed out, so that no change depends on the context of
+// another. A change that returns an error fails on its own, without being
+// applied, while an error applying the batch fails all of its changes. It
+// returns true if the change changed the policy and the policy was set.
+func (b *Batcher) Apply(ctx context.Context, key string, change Change, apply ApplyFn) (bool, error) {
+	if b == nil {
+		return apply(ctx, change)
+	}
+
+	c := &batchedChange{change: change}
+	b.mu.Lock()
+	bt, ok := b.batches[key]
+	if !ok {
+		bt = &batch{done: make(chan struct{})}
+		b.batches[key] = bt
+	}
+	bt.changes = append(bt.changes, c)
+	b.mu.Unlock()
+
+	if !ok {
+		go b.run(key, bt, apply)
+	}
+
+	// NOTE: A change is applied along with its batch even if its context is
+	// done while it waits for the batch to be applied.
+	select {
+	case <-bt.done:
+		return c.changed, c.err
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
+
+// run waits for the window of the Batcher to close, and applies the supplied
+// batch.
+func (b *Batcher) run(key string, bt *batch, apply ApplyFn) {
+	defer close(bt.done)
+
+	time.Sleep(b.window)
+
+	// Changes submitted from now on are applied by the next batch.
+	b.mu.Lock()
+	delete(b.batches, key)
+	changes := bt.changes
+	b.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	set, err := apply(ctx, func(p *storage.Policy) (bool, error) {
+		changed := false
+		for _, c := range changes {
+			// A change that fails is not applied, so each change is
+			// applied to a copy of the policy first.
+			cp := copyPolicy(p)
+			c.changed, c.err = c.change(cp)
+			if c.err != nil || !c.changed {
+				continue
+			}
+			*p = *cp
+			changed = true
+		}
+		return changed, nil
+	})
+	for _, c := range changes {
+		if c.err != nil {
+			continue
+		}
+		c.changed, c.err = c.changed && set, err
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucketpolicy
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/api/storage/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/storage/v1alpha1"
+)
+
+// policyStore applies changes to a single policy, counting how often it is
+// set.
+type policyStore struct {
+	mu     sync.Mutex
+	policy *storage.Policy
+	sets   int
+}
+
+func (s *policyStore) apply(_ context.Context, change Change) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := copyPolicy(s.policy)
+	changed, err := change(p)
+	if err != nil || !changed {
+		return false, err
+	}
+	s.policy = p
+	s.sets++
+	return true, nil
+}
+
+func bindMember(member string) Change {
+	return func(p *storage.Policy) (bool, error) {
+		return BindRoleToMember(v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &member}, p), nil
+	}
+}
+
+func TestBatcherCoalesces(t *testing.T) {
+	b := NewBatcher(50 * time.Millisecond)
+	s := &policyStore{policy: &storage.Policy{}}
+
+	members := []string{"user:a@example.com", "user:b@example.com", "user:c@example.com"}
+	changed := make([]bool, len(members))
+	errs := make([]error, len(members))
+	wg := &sync.WaitGroup{}
+	for i, m := range members {
+		wg.Add(1)
+		go func(i int, m string) {
+			defer wg.Done()
+			changed[i], errs[i] = b.Apply(context.Background(), testBucket, bindMember(m), s.apply)
+		}(i, m)
+	}
+	wg.Wait()
+
+	if s.sets != 1 {
+		t.Errorf("Apply(...): want the policy to be set once, got %d", s.sets)
+	}
+	for i := range members {
+		if errs[i] != nil {
+			t.Errorf("Apply(...): %s", errs[i])
+		}
+		if !changed[i] {
+			t.Errorf("Apply(...): want change %d to be reported as applied", i)
+		}
+	}
+	want := []*storage.PolicyBindings{{Role: testRole, Members: members}}
+	if diff := cmp.Diff(want, s.policy.Bindings, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("Apply(...): -want bindings, +got:\n%s", diff)
+	}
+	if len(b.batches) != 0 {
+		t.Errorf("Apply(...): want applied batches to be removed, got %d", len(b.batches))
+	}
+}
+
+func TestBatcherFailedChange(t *testing.T) {
+	b := NewBatcher(50 * time.Millisecond)
+	s := &policyStore{policy: &storage.Policy{}}
+	errBoom := errors.New("boom")
+
+	var failedErr, boundErr error
+	var bound bool
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, failedErr = b.Apply(context.Background(), testBucket, func(p *storage.Policy) (bool, error) {
+			p.Bindings = append(p.Bindings, &storage.PolicyBindings{Role: "roles/storage.admin", Members: []string{testMember}})
+			return true, errBoom
+		}, s.apply)
+	}()
+	go func() {
+		defer wg.Done()
+		bound, boundErr = b.Apply(context.Background(), testBucket, bindMember(testMember), s.apply)
+	}()
+	wg.Wait()
+
+	if diff := cmp.Diff(errBoom, failedErr, test.EquateErrors()); diff != "" {
+		t.Errorf("Apply(...): -want error, +got error:\n%s", diff)
+	}
+	if boundErr != nil || !bound {
+		t.Errorf("Apply(...): want a change to be applied although another change of its batch failed, got %t, %v", bound, boundErr)
+	}
+	want := []*storage.PolicyBindings{{Role: testRole, Members: []string{testMember}}}
+	if diff := cmp.Diff(want, s.policy.Bindings); diff != "" {
+		t.Errorf("Apply(...): -want bindings, +got:\n%s", diff)
+	}
+}
+
+func TestBatcherFailedApply(t *testing.T) {
+	b := NewBatcher(time.Millisecond)
+	errBoom := errors.New("boom")
+	changed, err := b.Apply(context.Background(), testBucket, bindMember(testMember), func(_ context.Context, _ Change) (bool, error) {
+		return false, errBoom
+	})
+	if diff := cmp.Diff(errBoom, err, test.EquateErrors()); diff != "" {
+		t.Errorf("Apply(...): -want error, +got error:\n%s", diff)
+	}
+	if changed {
+		t.Errorf("Apply(...): want a change that failed to be applied to be reported as not applied")
+	}
+}
+
+func TestBatcherCancelledChange(t *testing.T) {
+	b := NewBatcher(50 * time.Millisecond)
+	s := &policyStore{policy: &storage.Policy{}}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var cancelledErr, boundErr error
+	var bound bool
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, cancelledErr = b.Apply(ctx, testBucket, bindMember("user:a@example.com"), s.apply)
+	}()
+	go func() {
+		defer wg.Done()
+		// The second change joins the batch of the first.
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+		bound, boundErr = b.Apply(context.Background(), testBucket, bindMember(testMember), s.apply)
+	}()
+	wg.Wait()
+
+	if diff := cmp.Diff(context.Canceled, cancelledErr, test.EquateErrors()); diff != "" {
+		t.Errorf("Apply(...): -want error, +got error:\n%s", diff)
+	}
+	if boundErr != nil || !bound {
+		t.Errorf("Apply(...): want a change to be applied although the context of the first change of its batch is done, got %t, %v", bound, boundErr)
+	}
+	if s.sets != 1 {
+		t.Errorf("Apply(...): want the policy to be set once, got %d", s.sets)
+	}
+}
+
+func TestBatcherTimeout(t *testing.T) {
+	b := NewBatcher(time.Millisecond)
+	b.timeout = time.Millisecond
+	_, err := b.Apply(context.Background(), testBucket, bindMember(testMember), func(ctx context.Context, _ Change) (bool, error) {
+		<-ctx.Done()
+		return false, ctx.Err()
+	})
+	if diff := cmp.Diff(context.DeadlineExceeded, err, test.EquateErrors()); diff != "" {
+		t.Errorf("Apply(...): -want error, +got error:\n%s", diff)
+	}
+}
+
+func TestBatcherNil(t *testing.T) {
+	var b *Batcher
+	s := &policyStore{policy: &storage.Policy{}}
+	if _, err := b.Apply(context.Background(), testBucket, bindMember(testMember), s.apply); err != nil {
+		t.Fatalf("Apply(...): %s", err)
+	}
+	if s.sets != 1 {
+		t.Errorf("Apply(...): want the policy to be set once, got %d", s.sets)
+	}
+}
@@ -17,19 +17,59 @@ limitations under the License.
 package bucketpolicy
 
 import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/mitchellh/copystructure"
+	"google.golang.org/api/iam/v1"
 	"google.golang.org/api/storage/v1"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 
 	iamv1alpha1 "github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
 	"github.com/crossplane/provider-gcp/apis/storage/v1alpha1"
+	"github.com/crossplane/provider-gcp/apis/storage/v1alpha3"
 	gcp "github.com/crossplane/provider-gcp/pkg/clients"
 )
 
-const errCheckUpToDate = "unable to determine if external resource is up to date"
+const (
+	errCheckUpToDate     = "unable to determine if external resource is up to date"
+	errFmtInvalidMember  = "invalid member %q: must be allUsers, allAuthenticatedUsers, a principal:// or principalSet:// identifier, or an email address or domain prefixed with its identity type, e.g. user:jane@example.com, serviceAccount:, group: or domain:example.com"
+	errFmtInvalidRole    = "invalid role %q: must be a predefined role, e.g. roles/storage.objectViewer, or a custom role, e.g. projects/my-project/roles/myRole or organizations/123/roles/myRole"
+	errSerializePolicy   = "cannot serialize IAM policy"
+	errFmtPolicyTooLarge = "PolicyTooLarge: IAM policy of %d bytes exceeds the limit of %d bytes, consider binding groups rather than individual members"
+	errFmtPolicyVersion  = "policy version %d does not support conditional bindings: set the policy version to 3, or remove the condition"
+	errFmtUnbindsMember  = "refusing to unbind %s, which the provider authenticates as, from %s: bind it in the desired policy, or use a BucketPolicyMember instead"
+)
+
+// MaxPolicySize is the maximum size in bytes of a serialized IAM policy that
+// GCP accepts.
+const MaxPolicySize = 250 * 1024
+
+// memberFormat matches the identities accepted as members of a Bucket IAM
+// policy binding. Users, service accounts and groups are identified by their
+// email address, and domains by their domain name.
+var memberFormat = regexp.MustCompile(`^(allUsers|allAuthenticatedUsers|` +
+	`(user|serviceAccount|group):[^@:\s]+@[^@\s]+\.[^@\s]+|` +
+	`deleted:(user|serviceAccount|group):[^@:\s]+@[^@\s]+\.[^@\s?]+(\?uid=\d+)?|` +
+	`domain:[^@:\s]+\.[^@\s]+|` +
+	`(projectOwner|projectEditor|projectViewer):.+|` +
+	`principal(Set)?://.+)$`)
+
+// roleFormat matches the predefined and custom roles that Bucket IAM policy
+// bindings may bind members to.
+var roleFormat = regexp.MustCompile(`^((projects|organizations)/[^/]+/)?roles/[a-zA-Z0-9_.]+$`)
+
+// expiryFormat matches a clause of a condition expression that only grants
+// access until a timestamp, e.g. request.time < timestamp("2020-10-01T00:00:00Z").
+var expiryFormat = regexp.MustCompile(`^request\.time\s*<=?\s*timestamp\(\s*["']([^"']+)["']\s*\)$`)
 
 // Client should be satisfied to conduct Bucket Policy operations.
 type Client interface {
@@ -76,60 +116,958 @@ func IsUpToDate(in *v1alpha1.BucketPolicyParameters, observed *storage.Policy) (
 func ArePoliciesSame(p1, p2 *storage.Policy) bool {
 	return cmp.Equal(p1, p2, cmpopts.EquateEmpty(),
 		cmpopts.IgnoreFields(storage.Policy{}, "Version"),
-		cmpopts.SortSlices(func(i, j *storage.PolicyBindings) bool { return i.Role > j.Role }),
+		cmpopts.SortSlices(func(i, j *storage.PolicyBindings) bool { return bindingKey(i) > bindingKey(j) }),
 		cmpopts.SortSlices(func(i, j string) bool { return i > j }))
 }
 
+// ValidateMembers returns an error if any of the supplied members is not a
+// well formed identity, e.g. a bare email address without its identity type.
+func ValidateMembers(members ...string) error {
+	for _, m := range members {
+		if !memberFormat.MatchString(m) {
+			return errors.Errorf(errFmtInvalidMember, m)
+		}
+	}
+	return nil
+}
+
+// PublicMembers returns those of the supplied members that make a bucket
+// public once bound, i.e. allUsers and allAuthenticatedUsers.
+func PublicMembers(members ...string) []string {
+	var public []string
+	for _, m := range members {
+		if m == "allUsers" || m == "allAuthenticatedUsers" {
+			public = append(public, m)
+		}
+	}
+	return public
+}
+
+// DisallowedMembers returns those of the supplied members whose domain is
+// neither one of the supplied allowed domains nor a subdomain of one of them.
+// Only user:, group:, serviceAccount: and domain: members have a domain; other
+// members, e.g. allUsers, are never disallowed. No member is disallowed unless
+// a domain is allowed.
+func DisallowedMembers(allowed []string, members ...string) []string {
+	if len(allowed) == 0 {
+		return nil
+	}
+	var disallowed []string
+	for _, m := range members {
+		if domain, ok := memberDomain(m); ok && !isAllowedDomain(domain, allowed) {
+			disallowed = append(disallowed, m)
+		}
+	}
+	return disallowed
+}
+
+// memberDomain returns the domain of the supplied member, e.g. example.com for
+// user:jane@example.com, and true if members of its type have a domain.
+func memberDomain(member string) (string, bool) {
+	parts := strings.SplitN(member, ":", 2)
+	if len(parts) != 2 {
+		return "", false
+	}
+	switch parts[0] {
+	case "user", "group", "serviceAccount":
+		return parts[1][strings.LastIndex(parts[1], "@")+1:], true
+	case "domain":
+		return parts[1], true
+	}
+	return "", false
+}
+
+func isAllowedDomain(domain string, allowed []string) bool {
+	domain = strings.ToLower(domain)
+	for _, a := range allowed {
+		a = strings.ToLower(strings.TrimPrefix(a, "."))
+		if domain == a || strings.HasSuffix(domain, "."+a) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateRole returns an error if the supplied role is neither a predefined
+// nor a custom role, e.g. a bare role name without the roles/ prefix.
+func ValidateRole(role string) error {
+	if !roleFormat.MatchString(role) {
+		return errors.Errorf(errFmtInvalidRole, role)
+	}
+	return nil
+}
+
+// MemberPolicyVersion returns the version of the IAM policy of the bucket that
+// is requested and set for the supplied parameters.
+func MemberPolicyVersion(in v1alpha1.BucketPolicyMemberParameters) int64 {
+	if in.PolicyVersion == nil {
+		return iamv1alpha1.PolicyVersion
+	}
+	return *in.PolicyVersion
+}
+
+// ValidatePolicyVersion returns an error if the supplied parameters bind their
+// role with a condition in a policy version that does not support conditional
+// bindings.
+func ValidatePolicyVersion(in v1alpha1.BucketPolicyMemberParameters) error {
+	if v := MemberPolicyVersion(in); !isUnconditional(in.Condition) && v < iamv1alpha1.PolicyVersion {
+		return errors.Errorf(errFmtPolicyVersion, v)
+	}
+	return nil
+}
+
+// IsCustomRole returns true if the supplied role is a custom role of a project
+// or an organization, e.g. projects/my-project/roles/myRole, rather than a
+// predefined role.
+func IsCustomRole(role string) bool {
+	return strings.HasPrefix(role, "projects/") || strings.HasPrefix(role, "organizations/")
+}
+
+// CustomRoleExists returns true unless the supplied role is a custom role that
+// does not exist, or was deleted, according to the supplied IAM service.
+// Predefined roles are assumed to exist.
+func CustomRoleExists(ctx context.Context, s *iam.Service, role string) (bool, error) {
+	var r *iam.Role
+	var err error
+	switch {
+	case strings.HasPrefix(role, "projects/"):
+		r, err = s.Projects.Roles.Get(role).Context(ctx).Do()
+	case strings.HasPrefix(role, "organizations/"):
+		r, err = s.Organizations.Roles.Get(role).Context(ctx).Do()
+	default:
+		return true, nil
+	}
+	if gcp.IsErrorNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return !r.Deleted, nil
+}
+
+// ValidatePolicySize returns an error if the supplied policy is larger than
+// GCP accepts once serialized, i.e. setting it would fail.
+func ValidatePolicySize(sp *storage.Policy) error {
+	b, err := json.Marshal(sp)
+	if err != nil {
+		return errors.Wrap(err, errSerializePolicy)
+	}
+	if len(b) > MaxPolicySize {
+		return errors.Errorf(errFmtPolicyTooLarge, len(b), MaxPolicySize)
+	}
+	return nil
+}
+
+// ValidateRetainsMember returns an error if setting the supplied desired
+// policy would unbind the supplied member from any role the supplied observed
+// policy binds it to, e.g. the member the provider authenticates as, which
+// may then be unable to set the policy again. No member is always retained.
+func ValidateRetainsMember(desired, observed *storage.Policy, member string) error {
+	if member == "" {
+		return nil
+	}
+	keep := boundMembers(desired)
+	var unbound []string
+	for k, bm := range boundMembers(observed) {
+		if _, ok := keep[k]; !ok && bm.member == member {
+			unbound = append(unbound, describeBoundMember(bm))
+		}
+	}
+	if len(unbound) == 0 {
+		return nil
+	}
+	sort.Strings(unbound)
+	return errors.Errorf(errFmtUnbindsMember, member, strings.Join(unbound, ", "))
+}
+
+// DiffMembers returns human readable descriptions of the roles setting the
+// supplied desired policy would bind members to and unbind members from,
+// compared to the supplied observed policy.
+func DiffMembers(desired, observed *storage.Policy) (bound, unbound []string) {
+	want, got := boundMembers(desired), boundMembers(observed)
+	for k, bm := range want {
+		if _, ok := got[k]; !ok {
+			bound = append(bound, describeBoundMember(bm))
+		}
+	}
+	for k, bm := range got {
+		if _, ok := want[k]; !ok {
+			unbound = append(unbound, describeBoundMember(bm))
+		}
+	}
+	sort.Strings(bound)
+	sort.Strings(unbound)
+	return bound, unbound
+}
+
+// RetainMember removes all members but the supplied one from the bindings of
+// the supplied policy, and the bindings that do not bind it from the policy.
+func RetainMember(sp *storage.Policy, member string) {
+	bindings := make([]*storage.PolicyBindings, 0, len(sp.Bindings))
+	for _, b := range sp.Bindings {
+		for _, m := range b.Members {
+			if m == member {
+				b.Members = []string{member}
+				bindings = append(bindings, b)
+				break
+			}
+		}
+	}
+	sp.Bindings = bindings
+}
+
+// A boundMember is a member bound to a role by a binding of a policy.
+type boundMember struct {
+	binding *storage.PolicyBindings
+	member  string
+}
+
+// boundMembers returns the members the supplied policy binds to roles, keyed
+// by their binding and member.
+func boundMembers(sp *storage.Policy) map[string]boundMember {
+	bm := map[string]boundMember{}
+	for _, b := range sp.Bindings {
+		for _, m := range b.Members {
+			bm[memberKey(b, m)] = boundMember{binding: b, member: m}
+		}
+	}
+	return bm
+}
+
+func describeBoundMember(bm boundMember) string {
+	d := "role " + bm.binding.Role + " of member " + bm.member
+	if bm.binding.Condition != nil {
+		d += " with condition " + strconv.Quote(bm.binding.Condition.Title)
+	}
+	return d
+}
+
+// bindingKey returns a key that identifies the supplied binding within a
+// policy, i.e. its role and condition.
+func bindingKey(b *storage.PolicyBindings) string {
+	if isUnconditionalBinding(b) {
+		return b.Role
+	}
+	return b.Role + "/" + b.Condition.Title + "/" + b.Condition.Expression
+}
+
 // IsEmpty returns if Policy is empty
 func IsEmpty(in *storage.Policy) bool {
 	return in.Bindings == nil
 }
 
-// BindRoleToMember updates *storage.Policy instance with BucketPolicyMemberParameters.
+// BoundMembers returns the members the supplied BucketPolicyMemberParameters
+// bind their role to, i.e. its member, if any, followed by its other members.
+func BoundMembers(in v1alpha1.BucketPolicyMemberParameters) []string {
+	members := make([]string, 0, len(in.Members)+1)
+	if in.Member != nil {
+		members = append(members, *in.Member)
+	}
+	for _, m := range in.Members {
+		if !containsMember(members, m) {
+			members = append(members, m)
+		}
+	}
+	return members
+}
+
+// BindRoleToMember updates *storage.Policy instance with BucketPolicyMemberParameters
+// so that the binding of the given role and condition contains all of its
+// members. Other members of the binding are left untouched. The policy is
+// normalized along the way, see NormalizeBindings.
 // returns true if policy changed
 func BindRoleToMember(in v1alpha1.BucketPolicyMemberParameters, sp *storage.Policy) bool {
-	sp.Version = iamv1alpha1.PolicyVersion
+	sp.Version = MemberPolicyVersion(in)
+	members := BoundMembers(in)
+	normalized := NormalizeBindings(in, sp)
 	for _, b := range sp.Bindings {
-		if b.Role == in.Role {
-			for _, m := range b.Members {
-				if m == gcp.StringValue(in.Member) {
-					// role already bound to member, no change
-					return false
+		if isBinding(b, in.Role, in.Condition) {
+			bound := newMemberSet(b.Members...)
+			changed := normalized
+			for _, m := range members {
+				if !bound.has(m) {
+					// role already exist, add member
+					b.Members = append(b.Members, m)
+					bound.add(m)
+					changed = true
 				}
 			}
-			// role already exist, add member
-			b.Members = append(b.Members, gcp.StringValue(in.Member))
-			return true
+			return changed
 		}
 	}
-	// role does not exist, add binding with role and member
+	if len(members) == 0 {
+		// nothing to bind
+		return normalized
+	}
+	// role does not exist, add binding with role, condition and members
 	sp.Bindings = append(sp.Bindings, &storage.PolicyBindings{
-		Role:    in.Role,
-		Members: []string{gcp.StringValue(in.Member)},
+		Role:      in.Role,
+		Condition: generateCondition(in.Condition),
+		Members:   members,
 	})
 	return true
 }
 
+// HasBinding returns true if the supplied *storage.Policy binds the role of
+// the supplied BucketPolicyMemberParameters, with their condition, to all of
+// their members. Unlike BindRoleToMember it only reads the policy, and it
+// considers every binding of the role and condition, e.g. duplicates added by
+// another client.
+func HasBinding(in v1alpha1.BucketPolicyMemberParameters, sp *storage.Policy) bool {
+	members := BoundMembers(in)
+	if len(members) == 0 || sp == nil {
+		return false
+	}
+	bound := memberSet{}
+	for _, b := range sp.Bindings {
+		if isBinding(b, in.Role, in.Condition) {
+			bound.add(b.Members...)
+		}
+	}
+	for _, m := range members {
+		if !bound.has(m) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasAnyMember returns true if the supplied *storage.Policy binds the role of
+// the supplied BucketPolicyMemberParameters, with their condition, to any of
+// their members.
+func HasAnyMember(in v1alpha1.BucketPolicyMemberParameters, sp *storage.Policy) bool {
+	observed := ObservedMembers(in, sp)
+	for _, m := range BoundMembers(in) {
+		if containsMember(observed, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// ObservedMembers returns all members the supplied *storage.Policy binds the
+// role of the supplied BucketPolicyMemberParameters to, with their condition,
+// e.g. including members bound by other clients or by duplicate bindings. The
+// members are sorted, and each is returned once.
+func ObservedMembers(in v1alpha1.BucketPolicyMemberParameters, sp *storage.Policy) []string {
+	if sp == nil {
+		return nil
+	}
+	seen := memberSet{}
+	var members []string
+	for _, b := range sp.Bindings {
+		if !isBinding(b, in.Role, in.Condition) {
+			continue
+		}
+		for _, m := range b.Members {
+			if !seen.has(m) {
+				seen.add(m)
+				members = append(members, m)
+			}
+		}
+	}
+	sort.Strings(members)
+	return members
+}
+
+// NormalizeBindings merges the bindings of the role and condition of the
+// supplied BucketPolicyMemberParameters in the supplied *storage.Policy into
+// the first of them, lists each of its members once, and removes the bindings
+// that have no members, e.g. duplicates added by concurrent replicas of the
+// provider, which GCP may reject when the policy is set.
+// returns true if policy changed
+func NormalizeBindings(in v1alpha1.BucketPolicyMemberParameters, sp *storage.Policy) bool {
+	var target *storage.PolicyBindings
+	var members []string
+	seen := memberSet{}
+	changed := false
+	bindings := make([]*storage.PolicyBindings, 0, len(sp.Bindings))
+	for _, b := range sp.Bindings {
+		if !isBinding(b, in.Role, in.Condition) {
+			bindings = append(bindings, b)
+			continue
+		}
+		if target == nil {
+			target = b
+			bindings = append(bindings, b)
+		} else {
+			changed = true
+		}
+		for _, m := range b.Members {
+			if seen.has(m) {
+				changed = true
+				continue
+			}
+			seen.add(m)
+			members = append(members, m)
+		}
+	}
+	if target != nil {
+		target.Members = members
+	}
+	kept := bindings[:0]
+	for _, b := range bindings {
+		if len(b.Members) == 0 {
+			changed = true
+			continue
+		}
+		kept = append(kept, b)
+	}
+	if changed {
+		sp.Bindings = kept
+	}
+	return changed
+}
+
+// HasDuplicateBindings returns true if NormalizeBindings would merge the
+// bindings of the role and condition of the supplied
+// BucketPolicyMemberParameters in the supplied *storage.Policy, or list any of
+// their members once rather than repeatedly. Unlike NormalizeBindings it only
+// reads the policy.
+func HasDuplicateBindings(in v1alpha1.BucketPolicyMemberParameters, sp *storage.Policy) bool {
+	if sp == nil {
+		return false
+	}
+	var seen memberSet
+	for _, b := range sp.Bindings {
+		if !isBinding(b, in.Role, in.Condition) {
+			continue
+		}
+		if seen != nil {
+			return true
+		}
+		seen = make(memberSet, len(b.Members))
+		for _, m := range b.Members {
+			if seen.has(m) {
+				return true
+			}
+			seen.add(m)
+		}
+	}
+	return false
+}
+
+// ImportedCondition returns the condition of the binding of the role of the
+// supplied BucketPolicyMemberParameters to their members in the supplied
+// *storage.Policy, e.g. of an imported binding whose resource does not specify
+// its condition. It returns nil unless the role is bound to all of their
+// members by exactly one conditional binding, and not unconditionally.
+func ImportedCondition(in v1alpha1.BucketPolicyMemberParameters, sp *storage.Policy) *iamv1alpha1.Expr {
+	members := BoundMembers(in)
+	unconditional := in
+	unconditional.Condition = nil
+	if len(members) == 0 || sp == nil || HasBinding(unconditional, sp) {
+		return nil
+	}
+	var found *storage.Expr
+	for _, b := range sp.Bindings {
+		if b.Role != in.Role || isUnconditionalBinding(b) || !containsMembers(b.Members, members) {
+			continue
+		}
+		if found != nil {
+			return nil
+		}
+		found = b.Condition
+	}
+	if found == nil {
+		return nil
+	}
+	return &iamv1alpha1.Expr{
+		Description: gcp.LateInitializeString(nil, found.Description),
+		Expression:  found.Expression,
+		Location:    gcp.LateInitializeString(nil, found.Location),
+		Title:       gcp.LateInitializeString(nil, found.Title),
+	}
+}
+
+// DescribeMemberBinding returns a human readable description of the binding
+// of the role to the members of the supplied BucketPolicyMemberParameters.
+func DescribeMemberBinding(in v1alpha1.BucketPolicyMemberParameters) string {
+	members := BoundMembers(in)
+	noun := " of member "
+	if len(members) > 1 {
+		noun = " of members "
+	}
+	d := "role " + in.Role + noun + strings.Join(members, ", ")
+	if !isUnconditional(in.Condition) {
+		d += " with condition " + strconv.Quote(gcp.StringValue(in.Condition.Title))
+	}
+	return d
+}
+
+// A memberBinding identifies the binding of the members of a
+// BucketPolicyMember in the IAM policy of its bucket.
+type memberBinding struct {
+	Bucket              string   `json:"bucket"`
+	Role                string   `json:"role"`
+	Members             []string `json:"members"`
+	ConditionTitle      string   `json:"conditionTitle,omitempty"`
+	ConditionExpression string   `json:"conditionExpression,omitempty"`
+	PolicyVersion       int64    `json:"policyVersion"`
+}
+
+// MemberBinding returns the serialized identity of the binding of the supplied
+// parameters. It changes with the bucket, role, members, condition or policy
+// version of the binding, but not with the order of its members.
+func MemberBinding(in v1alpha1.BucketPolicyMemberParameters) string {
+	b := memberBinding{
+		Bucket:        gcp.StringValue(in.Bucket),
+		Role:          in.Role,
+		Members:       BoundMembers(in),
+		PolicyVersion: MemberPolicyVersion(in),
+	}
+	sort.Strings(b.Members)
+	if !isUnconditional(in.Condition) {
+		b.ConditionTitle = gcp.StringValue(in.Condition.Title)
+		b.ConditionExpression = in.Condition.Expression
+	}
+	// Marshalling a struct of strings never fails.
+	out, _ := json.Marshal(b)
+	return string(out)
+}
+
+// RemovedMembers returns the members of the supplied last applied binding, see
+// MemberBinding, that the supplied BucketPolicyMemberParameters no longer bind,
+// e.g. once they were removed from its members. None are returned unless the
+// binding was last applied to the same bucket, role and condition.
+func RemovedMembers(lastApplied string, in v1alpha1.BucketPolicyMemberParameters) []string {
+	applied := memberBinding{}
+	if lastApplied == "" || json.Unmarshal([]byte(lastApplied), &applied) != nil {
+		return nil
+	}
+	cond := memberBinding{}
+	if !isUnconditional(in.Condition) {
+		cond.ConditionTitle = gcp.StringValue(in.Condition.Title)
+		cond.ConditionExpression = in.Condition.Expression
+	}
+	if applied.Bucket != gcp.StringValue(in.Bucket) || applied.Role != in.Role ||
+		applied.ConditionTitle != cond.ConditionTitle || applied.ConditionExpression != cond.ConditionExpression {
+		return nil
+	}
+	bound := newMemberSet(BoundMembers(in)...)
+	var removed []string
+	for _, m := range applied.Members {
+		if !bound.has(m) {
+			removed = append(removed, m)
+		}
+	}
+	return removed
+}
+
+// MemberExternalName returns the external name of the binding of the supplied
+// role to the supplied member in the IAM policy of the supplied bucket, i.e.
+// {bucket}/{role}/{member}.
+func MemberExternalName(bucket, role, member string) string {
+	return bucket + "/" + role + "/" + member
+}
+
+// ParseMemberExternalName returns the bucket, role and member of the supplied
+// external name of a binding, and whether it is one at all. Both the role,
+// e.g. projects/my-project/roles/myRole, and the member may contain slashes,
+// but bucket names never do.
+func ParseMemberExternalName(name string) (bucket, role, member string, ok bool) {
+	parts := strings.SplitN(name, "/", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return "", "", "", false
+	}
+	bucket, rest := parts[0], parts[1]
+	segments := 2
+	if !strings.HasPrefix(rest, "roles/") {
+		segments = 4
+	}
+	parts = strings.SplitN(rest, "/", segments+1)
+	if len(parts) != segments+1 || parts[segments] == "" {
+		return "", "", "", false
+	}
+	role = strings.Join(parts[:segments], "/")
+	if !roleFormat.MatchString(role) {
+		return "", "", "", false
+	}
+	return bucket, role, parts[segments], true
+}
+
 // UnbindRoleFromMember generates *storage.Policy instance from BucketPolicyMemberParameters.
+// Only its members are removed from the binding of the given role and
+// condition; other members of the binding are left untouched. Duplicate
+// bindings of the role and condition are normalized first, so that the members
+// are removed from all of them, and a binding left without members is removed.
 // returns true if bound (i.e. policy changed)
 func UnbindRoleFromMember(in v1alpha1.BucketPolicyMemberParameters, sp *storage.Policy) bool {
+	changed := NormalizeBindings(in, sp)
+	unbound := newMemberSet(BoundMembers(in)...)
+	for i, b := range sp.Bindings {
+		if !isBinding(b, in.Role, in.Condition) {
+			continue
+		}
+		kept := make([]string, 0, len(b.Members))
+		for _, m := range b.Members {
+			if !unbound.has(m) {
+				kept = append(kept, m)
+			}
+		}
+		if len(kept) == len(b.Members) {
+			return changed
+		}
+		if len(kept) == 0 {
+			// remove binding located at index i
+			sp.Bindings = append(sp.Bindings[:i], sp.Bindings[i+1:]...)
+			return true
+		}
+		b.Members = kept
+		return true
+	}
+	return changed
+}
+
+// PruneDeletedMembers removes the members in the deleted: form, e.g.
+// deleted:serviceAccount:sa@example.com?uid=123, from the binding of the role
+// and condition of the supplied BucketPolicyMemberParameters, if these prune
+// deleted members. Members the parameters bind are never removed.
+// returns true if policy changed
+func PruneDeletedMembers(in v1alpha1.BucketPolicyMemberParameters, sp *storage.Policy) bool {
+	if !gcp.BoolValue(in.PruneDeletedMembers) {
+		return false
+	}
+	members := BoundMembers(in)
+	changed := false
 	for _, b := range sp.Bindings {
-		if b.Role == in.Role {
-			ix := -1
-			for i, m := range b.Members {
-				if m == gcp.StringValue(in.Member) {
-					// found member binding in role
-					ix = i
-					break
-				}
+		if !isBinding(b, in.Role, in.Condition) {
+			continue
+		}
+		kept := make([]string, 0, len(b.Members))
+		for _, m := range b.Members {
+			if isPrunable(members, m) {
+				continue
 			}
-			if ix >= 0 {
-				// remove member located at index ix
-				b.Members = append(b.Members[:ix], b.Members[ix+1:]...)
+			kept = append(kept, m)
+		}
+		if len(kept) != len(b.Members) {
+			b.Members = kept
+			changed = true
+		}
+	}
+	return changed
+}
+
+// HasDeletedMembers returns true if PruneDeletedMembers would remove members
+// from the supplied *storage.Policy. Unlike PruneDeletedMembers it only reads
+// the policy.
+func HasDeletedMembers(in v1alpha1.BucketPolicyMemberParameters, sp *storage.Policy) bool {
+	if !gcp.BoolValue(in.PruneDeletedMembers) {
+		return false
+	}
+	members := BoundMembers(in)
+	for _, b := range sp.Bindings {
+		if !isBinding(b, in.Role, in.Condition) {
+			continue
+		}
+		for _, m := range b.Members {
+			if isPrunable(members, m) {
 				return true
 			}
-			return false
 		}
 	}
 	return false
 }
+
+// isPrunable returns true if the supplied member of a binding is in the
+// deleted: form and is not one of the supplied bound members.
+func isPrunable(bound []string, member string) bool {
+	return strings.HasPrefix(member, "deleted:") && !containsMember(bound, member)
+}
+
+// A memberSet is a set of members, which is cheaper to look members up in than
+// the members of a binding of a large policy.
+type memberSet map[string]struct{}
+
+func newMemberSet(members ...string) memberSet {
+	s := make(memberSet, len(members))
+	s.add(members...)
+	return s
+}
+
+func (s memberSet) add(members ...string) {
+	for _, m := range members {
+		s[m] = struct{}{}
+	}
+}
+
+func (s memberSet) has(member string) bool {
+	_, ok := s[member]
+	return ok
+}
+
+func containsMember(members []string, member string) bool {
+	for _, m := range members {
+		if m == member {
+			return true
+		}
+	}
+	return false
+}
+
+func containsMembers(members, want []string) bool {
+	for _, m := range want {
+		if !containsMember(members, m) {
+			return false
+		}
+	}
+	return true
+}
+
+// BindRoleToMembers updates *storage.Policy instance with
+// BucketPolicyBindingParameters so that the binding of the given role and
+// condition contains exactly the desired members. Duplicate bindings of the
+// role and condition are normalized first, and members are compared as
+// memberIdentity does. Bindings of other roles or conditions are left
+// untouched.
+// returns true if policy changed
+func BindRoleToMembers(in v1alpha1.BucketPolicyBindingParameters, sp *storage.Policy) bool {
+	sp.Version = iamv1alpha1.PolicyVersion
+	changed := NormalizeBindings(v1alpha1.BucketPolicyMemberParameters{Role: in.Role, Condition: in.Condition}, sp)
+	for _, b := range sp.Bindings {
+		if !isBinding(b, in.Role, in.Condition) {
+			continue
+		}
+		members, same := bindMembers(b.Members, in.Members)
+		if same {
+			// binding already has the desired members, no change
+			return changed
+		}
+		b.Members = members
+		return true
+	}
+	if len(in.Members) == 0 {
+		// nothing to bind
+		return changed
+	}
+	// binding does not exist, add binding with role, condition and members
+	b := &storage.PolicyBindings{
+		Role:    in.Role,
+		Members: make([]string, len(in.Members)),
+	}
+	copy(b.Members, in.Members)
+	b.Condition = generateCondition(in.Condition)
+	sp.Bindings = append(sp.Bindings, b)
+	return true
+}
+
+// bindMembers returns the desired members of a binding whose members are the
+// supplied bound ones, and whether they are the same. A desired member that is
+// bound keeps the form it is bound in.
+func bindMembers(bound, desired []string) ([]string, bool) {
+	forms := make(map[string]string, len(bound))
+	for _, m := range bound {
+		forms[memberIdentity(m)] = m
+	}
+	members := make([]string, 0, len(desired))
+	seen := make(memberSet, len(desired))
+	for _, m := range desired {
+		id := memberIdentity(m)
+		if seen.has(id) {
+			continue
+		}
+		seen.add(id)
+		if f, ok := forms[id]; ok {
+			m = f
+		}
+		members = append(members, m)
+	}
+	same := len(members) == len(bound)
+	for _, m := range bound {
+		same = same && seen.has(memberIdentity(m))
+	}
+	return members, same
+}
+
+// UnbindRoleFromMembers removes the desired members from the binding of the
+// given role and condition in *storage.Policy instance. Duplicate bindings of
+// the role and condition are normalized first, so that the members are
+// removed from all of them, and members are compared as memberIdentity does.
+// The binding itself is removed once it has no members left.
+// returns true if policy changed
+func UnbindRoleFromMembers(in v1alpha1.BucketPolicyBindingParameters, sp *storage.Policy) bool {
+	changed := NormalizeBindings(v1alpha1.BucketPolicyMemberParameters{Role: in.Role, Condition: in.Condition}, sp)
+	remove := make(memberSet, len(in.Members))
+	for _, m := range in.Members {
+		remove.add(memberIdentity(m))
+	}
+	for i, b := range sp.Bindings {
+		if !isBinding(b, in.Role, in.Condition) {
+			continue
+		}
+		members := make([]string, 0, len(b.Members))
+		for _, m := range b.Members {
+			if !remove.has(memberIdentity(m)) {
+				members = append(members, m)
+			}
+		}
+		if len(members) == len(b.Members) {
+			return changed
+		}
+		if len(members) == 0 {
+			// remove binding located at index i
+			sp.Bindings = append(sp.Bindings[:i], sp.Bindings[i+1:]...)
+			return true
+		}
+		b.Members = members
+		return true
+	}
+	return changed
+}
+
+// memberIdentity returns the principal the supplied member of a binding
+// names, by which members of a BucketPolicyBinding are compared. GCP does not
+// distinguish the case of email addresses, and lists a member whose principal
+// was deleted in the deleted: form, e.g.
+// deleted:user:jane@example.com?uid=123, until it is removed.
+func memberIdentity(member string) string {
+	m := strings.ToLower(member)
+	if !strings.HasPrefix(m, "deleted:") {
+		return m
+	}
+	m = strings.TrimPrefix(m, "deleted:")
+	if i := strings.LastIndex(m, "?uid="); i >= 0 {
+		m = m[:i]
+	}
+	return m
+}
+
+func generateCondition(in *iamv1alpha1.Expr) *storage.Expr {
+	if isUnconditional(in) {
+		return nil
+	}
+	return &storage.Expr{
+		Description: gcp.StringValue(in.Description),
+		Expression:  in.Expression,
+		Location:    gcp.StringValue(in.Location),
+		Title:       gcp.StringValue(in.Title),
+	}
+}
+
+// ConditionExpiry returns the time after which the supplied condition no
+// longer grants access, and whether it stops doing so at all. Only conditions
+// whose expression requires request.time to be before a timestamp, possibly
+// along with other clauses joined by &&, are known to expire.
+func ConditionExpiry(in *iamv1alpha1.Expr) (time.Time, bool) {
+	if in == nil || strings.Contains(in.Expression, "||") {
+		return time.Time{}, false
+	}
+	var expiry time.Time
+	for _, c := range strings.Split(in.Expression, "&&") {
+		c = strings.TrimSpace(c)
+		for strings.HasPrefix(c, "(") && strings.HasSuffix(c, ")") {
+			c = strings.TrimSpace(c[1 : len(c)-1])
+		}
+		m := expiryFormat.FindStringSubmatch(c)
+		if m == nil {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, m[1])
+		if err != nil {
+			continue
+		}
+		if expiry.IsZero() || t.Before(expiry) {
+			expiry = t
+		}
+	}
+	return expiry, !expiry.IsZero()
+}
+
+// isBinding returns true if the supplied binding is the one identified by the
+// supplied role and condition. GCP treats bindings of the same role but a
+// different condition title or expression as distinct, so a nil condition
+// only matches the unconditional binding of the role.
+func isBinding(b *storage.PolicyBindings, role string, condition *iamv1alpha1.Expr) bool {
+	if b.Role != role {
+		return false
+	}
+	if isUnconditional(condition) || isUnconditionalBinding(b) {
+		return isUnconditional(condition) && isUnconditionalBinding(b)
+	}
+	return condition.Expression == b.Condition.Expression &&
+		gcp.StringValue(condition.Title) == b.Condition.Title
+}
+
+// isUnconditional returns true if the supplied condition of a binding does not
+// condition it. A condition without an expression, e.g. the empty condition of
+// a resource created before conditions were supported, is no condition at all.
+func isUnconditional(c *iamv1alpha1.Expr) bool {
+	return c == nil || c.Expression == ""
+}
+
+// isUnconditionalBinding returns true if the supplied binding of a policy is
+// not conditioned on an expression.
+func isUnconditionalBinding(b *storage.PolicyBindings) bool {
+	return b.Condition == nil || b.Condition.Expression == ""
+}
+
+// MemberOwners maps members bound to roles by the IAM policy of a bucket to
+// the managed resources that bind them.
+type MemberOwners map[string]string
+
+// NewMemberOwners returns the owners of the members that the supplied managed
+// resources bind to roles in the IAM policy of the named bucket.
+func NewMemberOwners(bucket string, policies []v1alpha1.BucketPolicy, bindings []v1alpha1.BucketPolicyBinding, members []v1alpha1.BucketPolicyMember) MemberOwners {
+	o := MemberOwners{}
+	for _, p := range policies {
+		if gcp.StringValue(p.Spec.ForProvider.Bucket) != bucket {
+			continue
+		}
+		for _, b := range p.Spec.ForProvider.Policy.Bindings {
+			for _, m := range b.Members {
+				o.add(b.Role, b.Condition, m, v1alpha1.BucketPolicyKind+"/"+p.GetName())
+			}
+		}
+	}
+	for _, b := range bindings {
+		if gcp.StringValue(b.Spec.ForProvider.Bucket) != bucket {
+			continue
+		}
+		for _, m := range b.Spec.ForProvider.Members {
+			o.add(b.Spec.ForProvider.Role, b.Spec.ForProvider.Condition, m, v1alpha1.BucketPolicyBindingKind+"/"+b.GetName())
+		}
+	}
+	for _, m := range members {
+		if gcp.StringValue(m.Spec.ForProvider.Bucket) != bucket {
+			continue
+		}
+		for _, bm := range BoundMembers(m.Spec.ForProvider) {
+			o.add(m.Spec.ForProvider.Role, m.Spec.ForProvider.Condition, bm, v1alpha1.BucketPolicyMemberKind+"/"+m.GetName())
+		}
+	}
+	return o
+}
+
+func (o MemberOwners) add(role string, condition *iamv1alpha1.Expr, member, owner string) {
+	var c *storage.Expr
+	if !isUnconditional(condition) {
+		c = &storage.Expr{Title: gcp.StringValue(condition.Title), Expression: condition.Expression}
+	}
+	o[memberKey(&storage.PolicyBindings{Role: role, Condition: c}, member)] = owner
+}
+
+func memberKey(b *storage.PolicyBindings, member string) string {
+	return bindingKey(b) + "/" + member
+}
+
+// GenerateIAMMembers returns the members bound to roles by the supplied IAM
+// policy of a bucket, along with the managed resources that bind them.
+func GenerateIAMMembers(sp *storage.Policy, o MemberOwners) []v1alpha3.BucketIAMMember {
+	var members []v1alpha3.BucketIAMMember
+	for _, b := range sp.Bindings {
+		for _, m := range b.Members {
+			im := v1alpha3.BucketIAMMember{
+				Role:      b.Role,
+				Member:    m,
+				ManagedBy: o[memberKey(b, m)],
+			}
+			if b.Condition != nil {
+				im.ConditionTitle = b.Condition.Title
+			}
+			members = append(members, im)
+		}
+	}
+	return members
+}
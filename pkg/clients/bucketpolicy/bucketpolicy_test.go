@@ -1,13 +1,28 @@
 package bucketpolicy
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/iam/v1"
+	"google.golang.org/api/option"
 	"google.golang.org/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	iamv1alpha1 "github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
 	"github.com/crossplane/provider-gcp/apis/storage/v1alpha1"
+	"github.com/crossplane/provider-gcp/apis/storage/v1alpha3"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
 )
 
 var (
@@ -16,6 +31,10 @@ var (
 )
 
 func TestBindRoleToMember(t *testing.T) {
+	condition := &iamv1alpha1.Expr{
+		Title:      gcp.StringPtr("expirable access"),
+		Expression: "request.time < timestamp(\"2020-10-01T00:00:00.000Z\")",
+	}
 	type args struct {
 		in v1alpha1.BucketPolicyMemberParameters
 		ck *storage.Policy
@@ -51,6 +70,30 @@ func TestBindRoleToMember(t *testing.T) {
 				},
 			},
 		},
+		"ExplicitPolicyVersion": {
+			args: args{
+				in: v1alpha1.BucketPolicyMemberParameters{
+					Role:          testRole,
+					Member:        &testMember,
+					PolicyVersion: gcp.Int64Ptr(1),
+				},
+				ck: &storage.Policy{},
+			},
+			want: want{
+				changed: true,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{
+								testMember,
+							},
+							Role: testRole,
+						},
+					},
+					Version: 1,
+				},
+			},
+		},
 		"RoleAlreadyBoundToMember": {
 			args: args{
 				in: v1alpha1.BucketPolicyMemberParameters{
@@ -84,6 +127,52 @@ func TestBindRoleToMember(t *testing.T) {
 				},
 			},
 		},
+		"DuplicatesNormalized": {
+			args: args{
+				in: v1alpha1.BucketPolicyMemberParameters{
+					Role:   testRole,
+					Member: &testMember,
+				},
+				ck: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{
+								testMember,
+								"some-other-member",
+								testMember,
+							},
+							Role: testRole,
+						},
+						{
+							Members: []string{},
+							Role:    "roles/storage.objectViewer",
+						},
+						{
+							Members: []string{
+								"some-other-member",
+							},
+							Role: testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+			want: want{
+				changed: true,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{
+								testMember,
+								"some-other-member",
+							},
+							Role: testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
 		"RoleAlreadyThereMemberAdded": {
 			args: args{
 				in: v1alpha1.BucketPolicyMemberParameters{
@@ -161,59 +250,59 @@ func TestBindRoleToMember(t *testing.T) {
 				},
 			},
 		},
-	}
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			changed := BindRoleToMember(tc.args.in, tc.args.ck)
-			if diff := cmp.Diff(tc.want.changed, changed); diff != "" {
-				t.Errorf("BindRoleToMember(...): -want changed, +got changed: %s", diff)
-			}
-			if diff := cmp.Diff(tc.want.out, tc.args.ck); diff != "" {
-				t.Errorf("BindRoleToMember(...): -want policy, +got policy: %s", diff)
-			}
-		})
-	}
-}
-
-func TestUnbindRoleFromMember(t *testing.T) {
-	type args struct {
-		in v1alpha1.BucketPolicyMemberParameters
-		ck *storage.Policy
-	}
-	type want struct {
-		out     *storage.Policy
-		changed bool
-	}
-	cases := map[string]struct {
-		args
-		want
-	}{
-		"EmptyPolicy": {
+		"ConditionalBindingForSameRoleUnchanged": {
 			args: args{
 				in: v1alpha1.BucketPolicyMemberParameters{
 					Role:   testRole,
 					Member: &testMember,
 				},
-				ck: &storage.Policy{},
+				ck: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Condition: &storage.Expr{
+								Title:      "expirable access",
+								Expression: "request.time < timestamp(\"2020-10-01T00:00:00.000Z\")",
+							},
+							Members: []string{testMember},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
 			},
 			want: want{
-				changed: false,
-				out:     &storage.Policy{},
+				changed: true,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Condition: &storage.Expr{
+								Title:      "expirable access",
+								Expression: "request.time < timestamp(\"2020-10-01T00:00:00.000Z\")",
+							},
+							Members: []string{testMember},
+							Role:    testRole,
+						},
+						{
+							Members: []string{testMember},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
 			},
 		},
-		"RoleBoundToSingleMember": {
+		"AddConditionalBinding": {
 			args: args{
 				in: v1alpha1.BucketPolicyMemberParameters{
-					Role:   testRole,
-					Member: &testMember,
+					Role:      testRole,
+					Condition: condition,
+					Member:    &testMember,
 				},
 				ck: &storage.Policy{
 					Bindings: []*storage.PolicyBindings{
 						{
-							Members: []string{
-								testMember,
-							},
-							Role: testRole,
+							Members: []string{testMember},
+							Role:    testRole,
 						},
 					},
 					Version: iamv1alpha1.PolicyVersion,
@@ -224,7 +313,15 @@ func TestUnbindRoleFromMember(t *testing.T) {
 				out: &storage.Policy{
 					Bindings: []*storage.PolicyBindings{
 						{
-							Members: []string{},
+							Members: []string{testMember},
+							Role:    testRole,
+						},
+						{
+							Condition: &storage.Expr{
+								Title:      "expirable access",
+								Expression: "request.time < timestamp(\"2020-10-01T00:00:00.000Z\")",
+							},
+							Members: []string{testMember},
 							Role:    testRole,
 						},
 					},
@@ -232,19 +329,19 @@ func TestUnbindRoleFromMember(t *testing.T) {
 				},
 			},
 		},
-		"RoleBoundToMultipleMembers": {
+		"MembersPartiallyBound": {
 			args: args{
 				in: v1alpha1.BucketPolicyMemberParameters{
-					Role:   testRole,
-					Member: &testMember,
+					Role:    testRole,
+					Member:  &testMember,
+					Members: []string{"group:bound@example.com", "group:unbound@example.com"},
 				},
 				ck: &storage.Policy{
 					Bindings: []*storage.PolicyBindings{
 						{
 							Members: []string{
-								"some-other-member",
-								testMember,
-								"yet-another-member",
+								"user:external@example.com",
+								"group:bound@example.com",
 							},
 							Role: testRole,
 						},
@@ -258,8 +355,10 @@ func TestUnbindRoleFromMember(t *testing.T) {
 					Bindings: []*storage.PolicyBindings{
 						{
 							Members: []string{
-								"some-other-member",
-								"yet-another-member",
+								"user:external@example.com",
+								"group:bound@example.com",
+								testMember,
+								"group:unbound@example.com",
 							},
 							Role: testRole,
 						},
@@ -268,18 +367,19 @@ func TestUnbindRoleFromMember(t *testing.T) {
 				},
 			},
 		},
-		"RoleBoundToMultipleMembersButNotOurMember": {
+		"MembersAlreadyBound": {
 			args: args{
 				in: v1alpha1.BucketPolicyMemberParameters{
-					Role:   testRole,
-					Member: &testMember,
+					Role:    testRole,
+					Members: []string{"group:bound@example.com", testMember},
 				},
 				ck: &storage.Policy{
 					Bindings: []*storage.PolicyBindings{
 						{
 							Members: []string{
-								"some-other-member",
-								"yet-another-member",
+								testMember,
+								"user:external@example.com",
+								"group:bound@example.com",
 							},
 							Role: testRole,
 						},
@@ -293,8 +393,9 @@ func TestUnbindRoleFromMember(t *testing.T) {
 					Bindings: []*storage.PolicyBindings{
 						{
 							Members: []string{
-								"some-other-member",
-								"yet-another-member",
+								testMember,
+								"user:external@example.com",
+								"group:bound@example.com",
 							},
 							Role: testRole,
 						},
@@ -303,19 +404,51 @@ func TestUnbindRoleFromMember(t *testing.T) {
 				},
 			},
 		},
-		"MemberHasARoleBoundButNotOurRole": {
+		"RoleNotThereMembersAdded": {
 			args: args{
 				in: v1alpha1.BucketPolicyMemberParameters{
-					Role:   testRole,
-					Member: &testMember,
+					Role:    testRole,
+					Member:  &testMember,
+					Members: []string{testMember, "group:other@example.com"},
 				},
-				ck: &storage.Policy{
+				ck: &storage.Policy{},
+			},
+			want: want{
+				changed: true,
+				out: &storage.Policy{
 					Bindings: []*storage.PolicyBindings{
 						{
 							Members: []string{
 								testMember,
+								"group:other@example.com",
 							},
-							Role: "some-other-role",
+							Role: testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+		"ConditionalBindingAlreadyBoundToMember": {
+			args: args{
+				in: v1alpha1.BucketPolicyMemberParameters{
+					Role:      testRole,
+					Condition: condition,
+					Member:    &testMember,
+				},
+				ck: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{"some-other-member"},
+							Role:    testRole,
+						},
+						{
+							Condition: &storage.Expr{
+								Title:      "expirable access",
+								Expression: "request.time < timestamp(\"2020-10-01T00:00:00.000Z\")",
+							},
+							Members: []string{testMember},
+							Role:    testRole,
 						},
 					},
 					Version: iamv1alpha1.PolicyVersion,
@@ -326,10 +459,16 @@ func TestUnbindRoleFromMember(t *testing.T) {
 				out: &storage.Policy{
 					Bindings: []*storage.PolicyBindings{
 						{
-							Members: []string{
-								testMember,
+							Members: []string{"some-other-member"},
+							Role:    testRole,
+						},
+						{
+							Condition: &storage.Expr{
+								Title:      "expirable access",
+								Expression: "request.time < timestamp(\"2020-10-01T00:00:00.000Z\")",
 							},
-							Role: "some-other-role",
+							Members: []string{testMember},
+							Role:    testRole,
 						},
 					},
 					Version: iamv1alpha1.PolicyVersion,
@@ -339,13 +478,2230 @@ func TestUnbindRoleFromMember(t *testing.T) {
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			changed := UnbindRoleFromMember(tc.args.in, tc.args.ck)
+			changed := BindRoleToMember(tc.args.in, tc.args.ck)
 			if diff := cmp.Diff(tc.want.changed, changed); diff != "" {
-				t.Errorf("UnbindRoleFromMember(...): -want changed, +got changed: %s", diff)
+				t.Errorf("BindRoleToMember(...): -want changed, +got changed: %s", diff)
 			}
 			if diff := cmp.Diff(tc.want.out, tc.args.ck); diff != "" {
-				t.Errorf("UnbindRoleFromMember(...): -want policy, +got policy: %s", diff)
+				t.Errorf("BindRoleToMember(...): -want policy, +got policy: %s", diff)
+			}
+		})
+	}
+}
+
+func TestHasBinding(t *testing.T) {
+	condition := &iamv1alpha1.Expr{
+		Title:      gcp.StringPtr("expirable access"),
+		Expression: "request.time < timestamp(\"2020-10-01T00:00:00.000Z\")",
+	}
+	other := "group:a@example.com"
+	cases := map[string]struct {
+		in   v1alpha1.BucketPolicyMemberParameters
+		sp   *storage.Policy
+		want bool
+	}{
+		"Bound": {
+			in:   v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &testMember},
+			sp:   &storage.Policy{Bindings: []*storage.PolicyBindings{{Role: testRole, Members: []string{other, testMember}}}},
+			want: true,
+		},
+		"BoundAmongOtherRoles": {
+			in: v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &testMember},
+			sp: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: "roles/storage.objectViewer", Members: []string{other}},
+				{Role: testRole, Members: []string{testMember}},
+				{Role: "roles/storage.legacyBucketReader", Members: []string{testMember}},
+			}},
+			want: true,
+		},
+		"BoundToOtherRole": {
+			in: v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &testMember},
+			sp: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: "roles/storage.objectViewer", Members: []string{testMember}},
+				{Role: testRole, Members: []string{other}},
+			}},
+			want: false,
+		},
+		"EmptyPolicy": {
+			in:   v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &testMember},
+			sp:   &storage.Policy{},
+			want: false,
+		},
+		"RemovedOutOfBand": {
+			in:   v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &testMember},
+			sp:   &storage.Policy{Bindings: []*storage.PolicyBindings{{Role: testRole, Members: []string{other}}}},
+			want: false,
+		},
+		"SomeMembersBound": {
+			in:   v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &testMember, Members: []string{other}},
+			sp:   &storage.Policy{Bindings: []*storage.PolicyBindings{{Role: testRole, Members: []string{testMember}}}},
+			want: false,
+		},
+		"MembersOfDuplicateBindings": {
+			in: v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &testMember, Members: []string{other}},
+			sp: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: testRole, Members: []string{testMember}},
+				{Role: testRole, Members: []string{other}},
+			}},
+			want: true,
+		},
+		"BoundWithOtherCondition": {
+			in: v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &testMember},
+			sp: &storage.Policy{Bindings: []*storage.PolicyBindings{{
+				Role:      testRole,
+				Members:   []string{testMember},
+				Condition: &storage.Expr{Title: gcp.StringValue(condition.Title), Expression: condition.Expression},
+			}}},
+			want: false,
+		},
+		"BoundWithCondition": {
+			in: v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &testMember, Condition: condition},
+			sp: &storage.Policy{Bindings: []*storage.PolicyBindings{{
+				Role:      testRole,
+				Members:   []string{testMember},
+				Condition: &storage.Expr{Title: gcp.StringValue(condition.Title), Expression: condition.Expression},
+			}}},
+			want: true,
+		},
+		"NoMembers": {
+			in:   v1alpha1.BucketPolicyMemberParameters{Role: testRole},
+			sp:   &storage.Policy{Bindings: []*storage.PolicyBindings{{Role: testRole, Members: []string{testMember}}}},
+			want: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			before := copyPolicy(tc.sp)
+			if diff := cmp.Diff(tc.want, HasBinding(tc.in, tc.sp)); diff != "" {
+				t.Errorf("HasBinding(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(before, tc.sp); diff != "" {
+				t.Errorf("HasBinding(...): policy was modified: -want, +got:\n%s", diff)
 			}
 		})
 	}
 }
+
+func TestUnbindRoleFromMember(t *testing.T) {
+	condition := &iamv1alpha1.Expr{
+		Title:      gcp.StringPtr("expirable access"),
+		Expression: "request.time < timestamp(\"2020-10-01T00:00:00.000Z\")",
+	}
+	type args struct {
+		in v1alpha1.BucketPolicyMemberParameters
+		ck *storage.Policy
+	}
+	type want struct {
+		out     *storage.Policy
+		changed bool
+	}
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"EmptyPolicy": {
+			args: args{
+				in: v1alpha1.BucketPolicyMemberParameters{
+					Role:   testRole,
+					Member: &testMember,
+				},
+				ck: &storage.Policy{},
+			},
+			want: want{
+				changed: false,
+				out:     &storage.Policy{},
+			},
+		},
+		"RoleBoundToSingleMember": {
+			args: args{
+				in: v1alpha1.BucketPolicyMemberParameters{
+					Role:   testRole,
+					Member: &testMember,
+				},
+				ck: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{
+								testMember,
+							},
+							Role: testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+			want: want{
+				changed: true,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{},
+					Version:  iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+		"DuplicateBindings": {
+			args: args{
+				in: v1alpha1.BucketPolicyMemberParameters{
+					Role:   testRole,
+					Member: &testMember,
+				},
+				ck: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{testMember},
+							Role:    testRole,
+						},
+						{
+							Members: []string{"some-other-member", testMember},
+							Role:    testRole,
+						},
+						{
+							Members: []string{testMember},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+			want: want{
+				changed: true,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{"some-other-member"},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+		"DuplicateBindingsWithoutOurMember": {
+			args: args{
+				in: v1alpha1.BucketPolicyMemberParameters{
+					Role:   testRole,
+					Member: &testMember,
+				},
+				ck: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{"some-other-member"},
+							Role:    testRole,
+						},
+						{
+							Members: []string{"yet-another-member"},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+			want: want{
+				changed: true,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{"some-other-member", "yet-another-member"},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+		"RoleBoundToMultipleMembers": {
+			args: args{
+				in: v1alpha1.BucketPolicyMemberParameters{
+					Role:   testRole,
+					Member: &testMember,
+				},
+				ck: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{
+								"some-other-member",
+								testMember,
+								"yet-another-member",
+							},
+							Role: testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+			want: want{
+				changed: true,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{
+								"some-other-member",
+								"yet-another-member",
+							},
+							Role: testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+		"RoleBoundToMultipleMembersButNotOurMember": {
+			args: args{
+				in: v1alpha1.BucketPolicyMemberParameters{
+					Role:   testRole,
+					Member: &testMember,
+				},
+				ck: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{
+								"some-other-member",
+								"yet-another-member",
+							},
+							Role: testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+			want: want{
+				changed: false,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{
+								"some-other-member",
+								"yet-another-member",
+							},
+							Role: testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+		"MembersPartiallyBound": {
+			args: args{
+				in: v1alpha1.BucketPolicyMemberParameters{
+					Role:    testRole,
+					Member:  &testMember,
+					Members: []string{"group:bound@example.com", "group:unbound@example.com"},
+				},
+				ck: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{
+								"user:external@example.com",
+								"group:bound@example.com",
+								testMember,
+							},
+							Role: testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+			want: want{
+				changed: true,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{
+								"user:external@example.com",
+							},
+							Role: testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+		"MemberHasARoleBoundButNotOurRole": {
+			args: args{
+				in: v1alpha1.BucketPolicyMemberParameters{
+					Role:   testRole,
+					Member: &testMember,
+				},
+				ck: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{
+								testMember,
+							},
+							Role: "some-other-role",
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+			want: want{
+				changed: false,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{
+								testMember,
+							},
+							Role: "some-other-role",
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+		"ConditionalBindingForSameRoleUnchanged": {
+			args: args{
+				in: v1alpha1.BucketPolicyMemberParameters{
+					Role:   testRole,
+					Member: &testMember,
+				},
+				ck: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Condition: &storage.Expr{
+								Title:      "expirable access",
+								Expression: "request.time < timestamp(\"2020-10-01T00:00:00.000Z\")",
+							},
+							Members: []string{testMember},
+							Role:    testRole,
+						},
+						{
+							Members: []string{
+								"some-other-member",
+								testMember,
+							},
+							Role: testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+			want: want{
+				changed: true,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Condition: &storage.Expr{
+								Title:      "expirable access",
+								Expression: "request.time < timestamp(\"2020-10-01T00:00:00.000Z\")",
+							},
+							Members: []string{testMember},
+							Role:    testRole,
+						},
+						{
+							Members: []string{
+								"some-other-member",
+							},
+							Role: testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+		"RemoveConditionalBinding": {
+			args: args{
+				in: v1alpha1.BucketPolicyMemberParameters{
+					Role:      testRole,
+					Condition: condition,
+					Member:    &testMember,
+				},
+				ck: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{testMember},
+							Role:    testRole,
+						},
+						{
+							Condition: &storage.Expr{
+								Title:      "expirable access",
+								Expression: "request.time < timestamp(\"2020-10-01T00:00:00.000Z\")",
+							},
+							Members: []string{testMember},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+			want: want{
+				changed: true,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{testMember},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			changed := UnbindRoleFromMember(tc.args.in, tc.args.ck)
+			if diff := cmp.Diff(tc.want.changed, changed); diff != "" {
+				t.Errorf("UnbindRoleFromMember(...): -want changed, +got changed: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.out, tc.args.ck); diff != "" {
+				t.Errorf("UnbindRoleFromMember(...): -want policy, +got policy: %s", diff)
+			}
+		})
+	}
+}
+
+func TestUnconditionalMember(t *testing.T) {
+	conditional := func() *storage.Policy {
+		return &storage.Policy{
+			Bindings: []*storage.PolicyBindings{{
+				Role:      testRole,
+				Members:   []string{testMember},
+				Condition: &storage.Expr{Title: "expirable access", Expression: "request.time < timestamp(\"2020-10-01T00:00:00.000Z\")"},
+			}},
+			Version: iamv1alpha1.PolicyVersion,
+		}
+	}
+	type want struct {
+		bound   bool
+		bind    *storage.Policy
+		unbind  *storage.Policy
+		unbound bool
+	}
+	cases := map[string]struct {
+		reason string
+		in     v1alpha1.BucketPolicyMemberParameters
+		policy *storage.Policy
+		want   want
+	}{
+		"NoCondition": {
+			reason: "A resource created before conditions were supported should neither match nor remove a conditional binding of its role and member.",
+			in:     v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &testMember},
+			policy: conditional(),
+			want: want{
+				bind: &storage.Policy{
+					Bindings: append(conditional().Bindings, &storage.PolicyBindings{Role: testRole, Members: []string{testMember}}),
+					Version:  iamv1alpha1.PolicyVersion,
+				},
+				unbind: conditional(),
+			},
+		},
+		"EmptyCondition": {
+			reason: "A condition without an expression should be treated as no condition rather than as a conditional binding.",
+			in:     v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &testMember, Condition: &iamv1alpha1.Expr{}, PolicyVersion: gcp.Int64Ptr(1)},
+			policy: conditional(),
+			want: want{
+				bind: &storage.Policy{
+					Bindings: append(conditional().Bindings, &storage.PolicyBindings{Role: testRole, Members: []string{testMember}}),
+					Version:  1,
+				},
+				unbind: conditional(),
+			},
+		},
+		"EmptyBindingCondition": {
+			reason: "A binding of a policy whose condition has no expression should be matched by a resource without a condition.",
+			in:     v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &testMember},
+			policy: &storage.Policy{
+				Bindings: []*storage.PolicyBindings{{Role: testRole, Members: []string{testMember}, Condition: &storage.Expr{}}},
+				Version:  iamv1alpha1.PolicyVersion,
+			},
+			want: want{
+				bound: true,
+				bind: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{{Role: testRole, Members: []string{testMember}, Condition: &storage.Expr{}}},
+					Version:  iamv1alpha1.PolicyVersion,
+				},
+				unbind: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{},
+					Version:  iamv1alpha1.PolicyVersion,
+				},
+				unbound: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := HasBinding(tc.in, tc.policy); got != tc.want.bound {
+				t.Errorf("\n%s\nHasBinding(...): want %t, got %t", tc.reason, tc.want.bound, got)
+			}
+			bind := copyPolicy(tc.policy)
+			BindRoleToMember(tc.in, bind)
+			if diff := cmp.Diff(tc.want.bind, bind); diff != "" {
+				t.Errorf("\n%s\nBindRoleToMember(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			unbind := copyPolicy(tc.policy)
+			if got := UnbindRoleFromMember(tc.in, unbind); got != tc.want.unbound {
+				t.Errorf("\n%s\nUnbindRoleFromMember(...): want changed %t, got %t", tc.reason, tc.want.unbound, got)
+			}
+			if diff := cmp.Diff(tc.want.unbind, unbind); diff != "" {
+				t.Errorf("\n%s\nUnbindRoleFromMember(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if err := ValidatePolicyVersion(tc.in); err != nil {
+				t.Errorf("\n%s\nValidatePolicyVersion(...): %s", tc.reason, err)
+			}
+		})
+	}
+}
+
+func TestImportedCondition(t *testing.T) {
+	condition := &storage.Expr{Title: "expirable access", Expression: "request.time < timestamp(\"2020-10-01T00:00:00.000Z\")"}
+	in := v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &testMember}
+	cases := map[string]struct {
+		reason string
+		in     v1alpha1.BucketPolicyMemberParameters
+		sp     *storage.Policy
+		want   *iamv1alpha1.Expr
+	}{
+		"Conditional": {
+			reason: "The condition of the single conditional binding of the member should be returned.",
+			in:     in,
+			sp:     &storage.Policy{Bindings: []*storage.PolicyBindings{{Role: testRole, Members: []string{testMember}, Condition: condition}}},
+			want:   &iamv1alpha1.Expr{Title: gcp.StringPtr(condition.Title), Expression: condition.Expression},
+		},
+		"Unconditional": {
+			reason: "No condition should be returned if the member is bound unconditionally.",
+			in:     in,
+			sp: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: testRole, Members: []string{testMember}, Condition: condition},
+				{Role: testRole, Members: []string{testMember}},
+			}},
+		},
+		"OtherMember": {
+			reason: "No condition should be returned if the conditional binding does not bind the member.",
+			in:     in,
+			sp:     &storage.Policy{Bindings: []*storage.PolicyBindings{{Role: testRole, Members: []string{"user:jane@example.com"}, Condition: condition}}},
+		},
+		"Ambiguous": {
+			reason: "No condition should be returned if several conditional bindings bind the member.",
+			in:     in,
+			sp: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: testRole, Members: []string{testMember}, Condition: condition},
+				{Role: testRole, Members: []string{testMember}, Condition: &storage.Expr{Expression: "true"}},
+			}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ImportedCondition(tc.in, tc.sp)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nImportedCondition(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPruneDeletedMembers(t *testing.T) {
+	deleted := "deleted:serviceAccount:sa@example.com?uid=123"
+	other := "deleted:user:jane@example.com?uid=456"
+	cases := map[string]struct {
+		in      v1alpha1.BucketPolicyMemberParameters
+		sp      *storage.Policy
+		want    *storage.Policy
+		changed bool
+	}{
+		"Disabled": {
+			in:   v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &testMember},
+			sp:   &storage.Policy{Bindings: []*storage.PolicyBindings{{Role: testRole, Members: []string{deleted, testMember}}}},
+			want: &storage.Policy{Bindings: []*storage.PolicyBindings{{Role: testRole, Members: []string{deleted, testMember}}}},
+		},
+		"Enabled": {
+			in:      v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &testMember, PruneDeletedMembers: gcp.BoolPtr(true)},
+			sp:      &storage.Policy{Bindings: []*storage.PolicyBindings{{Role: testRole, Members: []string{deleted, testMember, other}}}},
+			want:    &storage.Policy{Bindings: []*storage.PolicyBindings{{Role: testRole, Members: []string{testMember}}}},
+			changed: true,
+		},
+		"OtherRole": {
+			in: v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &testMember, PruneDeletedMembers: gcp.BoolPtr(true)},
+			sp: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: testRole, Members: []string{testMember}},
+				{Role: "roles/storage.objectViewer", Members: []string{deleted}},
+			}},
+			want: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: testRole, Members: []string{testMember}},
+				{Role: "roles/storage.objectViewer", Members: []string{deleted}},
+			}},
+		},
+		"BoundDeletedMember": {
+			in:   v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &deleted, PruneDeletedMembers: gcp.BoolPtr(true)},
+			sp:   &storage.Policy{Bindings: []*storage.PolicyBindings{{Role: testRole, Members: []string{deleted}}}},
+			want: &storage.Policy{Bindings: []*storage.PolicyBindings{{Role: testRole, Members: []string{deleted}}}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.changed, HasDeletedMembers(tc.in, tc.sp)); diff != "" {
+				t.Errorf("HasDeletedMembers(...): -want, +got: %s", diff)
+			}
+			changed := PruneDeletedMembers(tc.in, tc.sp)
+			if diff := cmp.Diff(tc.changed, changed); diff != "" {
+				t.Errorf("PruneDeletedMembers(...): -want changed, +got changed: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want, tc.sp); diff != "" {
+				t.Errorf("PruneDeletedMembers(...): -want policy, +got policy: %s", diff)
+			}
+		})
+	}
+}
+
+func TestNormalizeBindings(t *testing.T) {
+	other := "user:jane@example.com"
+	condition := &iamv1alpha1.Expr{
+		Title:      gcp.StringPtr("expirable access"),
+		Expression: "request.time < timestamp(\"2020-10-01T00:00:00.000Z\")",
+	}
+	cases := map[string]struct {
+		in         v1alpha1.BucketPolicyMemberParameters
+		sp         *storage.Policy
+		want       *storage.Policy
+		duplicates bool
+		changed    bool
+	}{
+		"Normalized": {
+			in:   v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &testMember},
+			sp:   &storage.Policy{Bindings: []*storage.PolicyBindings{{Role: testRole, Members: []string{testMember, other}}}},
+			want: &storage.Policy{Bindings: []*storage.PolicyBindings{{Role: testRole, Members: []string{testMember, other}}}},
+		},
+		"DuplicateMembers": {
+			in:         v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &testMember},
+			sp:         &storage.Policy{Bindings: []*storage.PolicyBindings{{Role: testRole, Members: []string{testMember, other, testMember}}}},
+			want:       &storage.Policy{Bindings: []*storage.PolicyBindings{{Role: testRole, Members: []string{testMember, other}}}},
+			duplicates: true,
+			changed:    true,
+		},
+		"DuplicateBindings": {
+			in: v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &testMember},
+			sp: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: testRole, Members: []string{testMember}},
+				{Role: "roles/storage.objectViewer", Members: []string{testMember}},
+				{Role: testRole, Members: []string{other, testMember}},
+			}},
+			want: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: testRole, Members: []string{testMember, other}},
+				{Role: "roles/storage.objectViewer", Members: []string{testMember}},
+			}},
+			duplicates: true,
+			changed:    true,
+		},
+		"EmptyBinding": {
+			in: v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &testMember},
+			sp: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: testRole, Members: []string{testMember}},
+				{Role: "roles/storage.objectViewer", Members: []string{}},
+			}},
+			want: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: testRole, Members: []string{testMember}},
+			}},
+			changed: true,
+		},
+		"OtherCondition": {
+			in: v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &testMember},
+			sp: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: testRole, Members: []string{testMember}},
+				{Role: testRole, Members: []string{testMember}, Condition: &storage.Expr{Title: *condition.Title, Expression: condition.Expression}},
+			}},
+			want: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: testRole, Members: []string{testMember}},
+				{Role: testRole, Members: []string{testMember}, Condition: &storage.Expr{Title: *condition.Title, Expression: condition.Expression}},
+			}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.duplicates, HasDuplicateBindings(tc.in, tc.sp)); diff != "" {
+				t.Errorf("HasDuplicateBindings(...): -want, +got: %s", diff)
+			}
+			changed := NormalizeBindings(tc.in, tc.sp)
+			if diff := cmp.Diff(tc.changed, changed); diff != "" {
+				t.Errorf("NormalizeBindings(...): -want changed, +got changed: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want, tc.sp); diff != "" {
+				t.Errorf("NormalizeBindings(...): -want policy, +got policy: %s", diff)
+			}
+		})
+	}
+}
+
+func TestObservedMembers(t *testing.T) {
+	other := "user:jane@example.com"
+	cases := map[string]struct {
+		in   v1alpha1.BucketPolicyMemberParameters
+		sp   *storage.Policy
+		want []string
+	}{
+		"NoPolicy": {
+			in: v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &testMember},
+		},
+		"NotBound": {
+			in: v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &testMember},
+			sp: &storage.Policy{Bindings: []*storage.PolicyBindings{{Role: "roles/storage.objectViewer", Members: []string{testMember}}}},
+		},
+		"BoundByOthers": {
+			in: v1alpha1.BucketPolicyMemberParameters{Role: testRole, Member: &testMember},
+			sp: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: testRole, Members: []string{testMember, other}},
+				{Role: testRole, Members: []string{other}, Condition: &storage.Expr{Title: "expirable access", Expression: "true"}},
+				{Role: testRole, Members: []string{"group:team@example.com", testMember}},
+			}},
+			want: []string{"group:team@example.com", testMember, other},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, ObservedMembers(tc.in, tc.sp)); diff != "" {
+				t.Errorf("ObservedMembers(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestHasAnyMember(t *testing.T) {
+	other := "user:jane@example.com"
+	cases := map[string]struct {
+		in   v1alpha1.BucketPolicyMemberParameters
+		sp   *storage.Policy
+		want bool
+	}{
+		"NoMembers": {
+			in: v1alpha1.BucketPolicyMemberParameters{Role: testRole},
+			sp: &storage.Policy{Bindings: []*storage.PolicyBindings{{Role: testRole, Members: []string{testMember}}}},
+		},
+		"NotBound": {
+			in: v1alpha1.BucketPolicyMemberParameters{Role: testRole, Members: []string{testMember, other}},
+			sp: &storage.Policy{Bindings: []*storage.PolicyBindings{{Role: "roles/storage.objectViewer", Members: []string{testMember}}}},
+		},
+		"SomeBound": {
+			in:   v1alpha1.BucketPolicyMemberParameters{Role: testRole, Members: []string{testMember, other}},
+			sp:   &storage.Policy{Bindings: []*storage.PolicyBindings{{Role: testRole, Members: []string{other}}}},
+			want: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, HasAnyMember(tc.in, tc.sp)); diff != "" {
+				t.Errorf("HasAnyMember(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBindRoleToMembers(t *testing.T) {
+	condition := &iamv1alpha1.Expr{
+		Title:      gcp.StringPtr("expirable access"),
+		Expression: "request.time < timestamp(\"2020-10-01T00:00:00.000Z\")",
+	}
+	type args struct {
+		in v1alpha1.BucketPolicyBindingParameters
+		ck *storage.Policy
+	}
+	type want struct {
+		out     *storage.Policy
+		changed bool
+	}
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"DuplicateBindings": {
+			args: args{
+				in: v1alpha1.BucketPolicyBindingParameters{
+					Role:    testRole,
+					Members: []string{testMember, "some-other-member"},
+				},
+				ck: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{testMember},
+							Role:    testRole,
+						},
+						{
+							Members: []string{"some-other-member", "removed-member"},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+			want: want{
+				changed: true,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{testMember, "some-other-member"},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+		"MembersDifferInCase": {
+			args: args{
+				in: v1alpha1.BucketPolicyBindingParameters{
+					Role:    testRole,
+					Members: []string{"user:Jane@Example.com"},
+				},
+				ck: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{"user:jane@example.com"},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+			want: want{
+				changed: false,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{"user:jane@example.com"},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+		"DeletedMemberKept": {
+			args: args{
+				in: v1alpha1.BucketPolicyBindingParameters{
+					Role:    testRole,
+					Members: []string{"user:jane@example.com", testMember},
+				},
+				ck: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{"deleted:user:jane@example.com?uid=123"},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+			want: want{
+				changed: true,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{"deleted:user:jane@example.com?uid=123", testMember},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+		"EmptyPolicy": {
+			args: args{
+				in: v1alpha1.BucketPolicyBindingParameters{
+					Role:    testRole,
+					Members: []string{testMember, "some-other-member"},
+				},
+				ck: &storage.Policy{},
+			},
+			want: want{
+				changed: true,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{testMember, "some-other-member"},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+		"BindingUpToDate": {
+			args: args{
+				in: v1alpha1.BucketPolicyBindingParameters{
+					Role:    testRole,
+					Members: []string{testMember, "some-other-member"},
+				},
+				ck: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{"some-other-member", testMember},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+			want: want{
+				changed: false,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{"some-other-member", testMember},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+		"AddAndRemoveMembers": {
+			args: args{
+				in: v1alpha1.BucketPolicyBindingParameters{
+					Role:    testRole,
+					Members: []string{testMember},
+				},
+				ck: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{"some-other-member"},
+							Role:    testRole,
+						},
+						{
+							Members: []string{"some-other-member"},
+							Role:    "some-other-role",
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+			want: want{
+				changed: true,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{testMember},
+							Role:    testRole,
+						},
+						{
+							Members: []string{"some-other-member"},
+							Role:    "some-other-role",
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+		"SameRoleDifferentCondition": {
+			args: args{
+				in: v1alpha1.BucketPolicyBindingParameters{
+					Role:      testRole,
+					Condition: condition,
+					Members:   []string{testMember},
+				},
+				ck: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{"some-other-member"},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+			want: want{
+				changed: true,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{"some-other-member"},
+							Role:    testRole,
+						},
+						{
+							Condition: &storage.Expr{
+								Title:      gcp.StringValue(condition.Title),
+								Expression: condition.Expression,
+							},
+							Members: []string{testMember},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			changed := BindRoleToMembers(tc.args.in, tc.args.ck)
+			if diff := cmp.Diff(tc.want.changed, changed); diff != "" {
+				t.Errorf("BindRoleToMembers(...): -want changed, +got changed: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.out, tc.args.ck); diff != "" {
+				t.Errorf("BindRoleToMembers(...): -want policy, +got policy: %s", diff)
+			}
+		})
+	}
+}
+
+func TestUnbindRoleFromMembers(t *testing.T) {
+	type args struct {
+		in v1alpha1.BucketPolicyBindingParameters
+		ck *storage.Policy
+	}
+	type want struct {
+		out     *storage.Policy
+		changed bool
+	}
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"DuplicateBindings": {
+			args: args{
+				in: v1alpha1.BucketPolicyBindingParameters{
+					Role:    testRole,
+					Members: []string{testMember},
+				},
+				ck: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{testMember, "some-other-member"},
+							Role:    testRole,
+						},
+						{
+							Members: []string{testMember},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+			want: want{
+				changed: true,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{"some-other-member"},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+		"DeletedAndDifferentCaseMembers": {
+			args: args{
+				in: v1alpha1.BucketPolicyBindingParameters{
+					Role:    testRole,
+					Members: []string{"user:Jane@Example.com", "serviceAccount:sa@example.com"},
+				},
+				ck: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{"user:jane@example.com", "deleted:serviceAccount:sa@example.com?uid=123", "some-other-member"},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+			want: want{
+				changed: true,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{"some-other-member"},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+		"EmptyPolicy": {
+			args: args{
+				in: v1alpha1.BucketPolicyBindingParameters{
+					Role:    testRole,
+					Members: []string{testMember},
+				},
+				ck: &storage.Policy{},
+			},
+			want: want{
+				changed: false,
+				out:     &storage.Policy{},
+			},
+		},
+		"RemoveWholeBinding": {
+			args: args{
+				in: v1alpha1.BucketPolicyBindingParameters{
+					Role:    testRole,
+					Members: []string{testMember, "some-other-member"},
+				},
+				ck: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{testMember, "some-other-member"},
+							Role:    testRole,
+						},
+						{
+							Members: []string{testMember},
+							Role:    "some-other-role",
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+			want: want{
+				changed: true,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{testMember},
+							Role:    "some-other-role",
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+		"KeepOtherMembers": {
+			args: args{
+				in: v1alpha1.BucketPolicyBindingParameters{
+					Role:    testRole,
+					Members: []string{testMember},
+				},
+				ck: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{"some-other-member", testMember},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+			want: want{
+				changed: true,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Members: []string{"some-other-member"},
+							Role:    testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+		"ConditionalBindingNotTouched": {
+			args: args{
+				in: v1alpha1.BucketPolicyBindingParameters{
+					Role:    testRole,
+					Members: []string{testMember},
+				},
+				ck: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Condition: &storage.Expr{Expression: "true"},
+							Members:   []string{testMember},
+							Role:      testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+			want: want{
+				changed: false,
+				out: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Condition: &storage.Expr{Expression: "true"},
+							Members:   []string{testMember},
+							Role:      testRole,
+						},
+					},
+					Version: iamv1alpha1.PolicyVersion,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			changed := UnbindRoleFromMembers(tc.args.in, tc.args.ck)
+			if diff := cmp.Diff(tc.want.changed, changed); diff != "" {
+				t.Errorf("UnbindRoleFromMembers(...): -want changed, +got changed: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.out, tc.args.ck); diff != "" {
+				t.Errorf("UnbindRoleFromMembers(...): -want policy, +got policy: %s", diff)
+			}
+		})
+	}
+}
+
+func TestBoundMembers(t *testing.T) {
+	cases := map[string]struct {
+		in   v1alpha1.BucketPolicyMemberParameters
+		want []string
+	}{
+		"Member": {
+			in:   v1alpha1.BucketPolicyMemberParameters{Member: &testMember},
+			want: []string{testMember},
+		},
+		"MemberAndMembers": {
+			in: v1alpha1.BucketPolicyMemberParameters{
+				Member:  &testMember,
+				Members: []string{"group:a@example.com", testMember, "group:b@example.com", "group:a@example.com"},
+			},
+			want: []string{testMember, "group:a@example.com", "group:b@example.com"},
+		},
+		"MembersOnly": {
+			in:   v1alpha1.BucketPolicyMemberParameters{Members: []string{"group:a@example.com"}},
+			want: []string{"group:a@example.com"},
+		},
+		"None": {
+			in:   v1alpha1.BucketPolicyMemberParameters{},
+			want: []string{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, BoundMembers(tc.in)); diff != "" {
+				t.Errorf("BoundMembers(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateMembers(t *testing.T) {
+	cases := map[string]struct {
+		members []string
+		valid   bool
+	}{
+		"WellFormed": {
+			members: []string{
+				testMember,
+				"user:jane@example.com",
+				"group:admins@example.com",
+				"domain:example.com",
+				"allUsers",
+				"allAuthenticatedUsers",
+				"projectViewer:my-project",
+				"deleted:user:jane@example.com?uid=123456789012345678901",
+				"principal://iam.googleapis.com/projects/1/locations/global/workloadIdentityPools/pool/subject/sub",
+				"principalSet://iam.googleapis.com/projects/1/locations/global/workloadIdentityPools/pool/*",
+			},
+			valid: true,
+		},
+		"BareEmail": {
+			members: []string{testMember, "jane@example.com"},
+			valid:   false,
+		},
+		"UnknownIdentityType": {
+			members: []string{"robot:jane@example.com"},
+			valid:   false,
+		},
+		"MissingIdentity": {
+			members: []string{"user:"},
+			valid:   false,
+		},
+		"UserWithoutEmail": {
+			members: []string{"user:foo"},
+			valid:   false,
+		},
+		"MalformedServiceAccount": {
+			members: []string{"serviceAccount:perfect-test-sa@"},
+			valid:   false,
+		},
+		"GroupWithoutDomain": {
+			members: []string{"group:admins@example"},
+			valid:   false,
+		},
+		"DomainWithoutTLD": {
+			members: []string{"domain:example"},
+			valid:   false,
+		},
+		"DomainWithEmail": {
+			members: []string{"domain:jane@example.com"},
+			valid:   false,
+		},
+		"EmailWithWhitespace": {
+			members: []string{"user:jane @example.com"},
+			valid:   false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateMembers(tc.members...)
+			if diff := cmp.Diff(tc.valid, err == nil); diff != "" {
+				t.Errorf("ValidateMembers(...): -want valid, +got valid: %s\n%v", diff, err)
+			}
+		})
+	}
+}
+
+func TestPublicMembers(t *testing.T) {
+	members := []string{"allUsers", testMember, "allAuthenticatedUsers", "domain:example.com"}
+	want := []string{"allUsers", "allAuthenticatedUsers"}
+	if diff := cmp.Diff(want, PublicMembers(members...)); diff != "" {
+		t.Errorf("PublicMembers(...): -want, +got:\n%s", diff)
+	}
+	if got := PublicMembers(testMember); got != nil {
+		t.Errorf("PublicMembers(...): want no public members, got %v", got)
+	}
+}
+
+func TestDisallowedMembers(t *testing.T) {
+	allowed := []string{"example.com", ".my-project.iam.gserviceaccount.com"}
+	cases := map[string]struct {
+		allowed []string
+		members []string
+		want    []string
+	}{
+		"NoAllowedDomains": {
+			members: []string{"user:attacker@evil.com"},
+		},
+		"Allowed": {
+			allowed: allowed,
+			members: []string{"user:jane@example.com", "group:eng@corp.example.com", "domain:EXAMPLE.com", "serviceAccount:crossplane@my-project.iam.gserviceaccount.com"},
+		},
+		"Disallowed": {
+			allowed: allowed,
+			members: []string{"user:attacker@evil.com", "user:jane@example.com", "domain:notexample.com", "serviceAccount:sa@other-project.iam.gserviceaccount.com"},
+			want:    []string{"user:attacker@evil.com", "domain:notexample.com", "serviceAccount:sa@other-project.iam.gserviceaccount.com"},
+		},
+		"SpecialMembers": {
+			allowed: allowed,
+			members: []string{"allUsers", "allAuthenticatedUsers", "projectOwner:my-project", "deleted:user:attacker@evil.com?uid=123"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, DisallowedMembers(tc.allowed, tc.members...)); diff != "" {
+				t.Errorf("DisallowedMembers(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateRole(t *testing.T) {
+	cases := map[string]struct {
+		role  string
+		valid bool
+	}{
+		"Predefined": {
+			role:  testRole,
+			valid: true,
+		},
+		"ProjectCustom": {
+			role:  "projects/my-project/roles/bucketReader_v2",
+			valid: true,
+		},
+		"OrganizationCustom": {
+			role:  "organizations/123456789/roles/bucketReader",
+			valid: true,
+		},
+		"MissingPrefix": {
+			role:  "storage.objectAdmin",
+			valid: false,
+		},
+		"Empty": {
+			role:  "",
+			valid: false,
+		},
+		"MissingRoleName": {
+			role:  "roles/",
+			valid: false,
+		},
+		"MissingProject": {
+			role:  "projects//roles/bucketReader",
+			valid: false,
+		},
+		"UnknownParent": {
+			role:  "folders/123/roles/bucketReader",
+			valid: false,
+		},
+		"Whitespace": {
+			role:  "roles/storage.object Admin",
+			valid: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateRole(tc.role)
+			if diff := cmp.Diff(tc.valid, err == nil); diff != "" {
+				t.Errorf("ValidateRole(...): -want valid, +got valid: %s\n%v", diff, err)
+			}
+		})
+	}
+}
+
+func TestValidatePolicyVersion(t *testing.T) {
+	condition := &iamv1alpha1.Expr{Expression: "request.time < timestamp(\"2020-10-01T00:00:00.000Z\")"}
+	cases := map[string]struct {
+		in      v1alpha1.BucketPolicyMemberParameters
+		version int64
+		valid   bool
+	}{
+		"Default": {
+			in:      v1alpha1.BucketPolicyMemberParameters{Condition: condition},
+			version: iamv1alpha1.PolicyVersion,
+			valid:   true,
+		},
+		"ExplicitV1": {
+			in:      v1alpha1.BucketPolicyMemberParameters{PolicyVersion: gcp.Int64Ptr(1)},
+			version: 1,
+			valid:   true,
+		},
+		"ConditionRequiresV3": {
+			in:      v1alpha1.BucketPolicyMemberParameters{Condition: condition, PolicyVersion: gcp.Int64Ptr(1)},
+			version: 1,
+			valid:   false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.version, MemberPolicyVersion(tc.in)); diff != "" {
+				t.Errorf("MemberPolicyVersion(...): -want, +got:\n%s", diff)
+			}
+			err := ValidatePolicyVersion(tc.in)
+			if diff := cmp.Diff(tc.valid, err == nil); diff != "" {
+				t.Errorf("ValidatePolicyVersion(...): -want valid, +got valid: %s\n%v", diff, err)
+			}
+		})
+	}
+}
+
+func TestMemberBinding(t *testing.T) {
+	in := v1alpha1.BucketPolicyMemberParameters{
+		Bucket:  gcp.StringPtr("some-bucket"),
+		Role:    testRole,
+		Member:  &testMember,
+		Members: []string{"user:jane@example.com", "group:team@example.com"},
+	}
+	cases := map[string]struct {
+		reason string
+		change func(*v1alpha1.BucketPolicyMemberParameters)
+		same   bool
+	}{
+		"MembersReordered": {
+			reason: "Reordering the members should not change the binding.",
+			change: func(p *v1alpha1.BucketPolicyMemberParameters) {
+				p.Members = []string{"group:team@example.com", "user:jane@example.com"}
+			},
+			same: true,
+		},
+		"RoleChanged": {
+			reason: "Changing the role should change the binding.",
+			change: func(p *v1alpha1.BucketPolicyMemberParameters) { p.Role = "roles/storage.objectViewer" },
+		},
+		"MemberAdded": {
+			reason: "Adding a member should change the binding.",
+			change: func(p *v1alpha1.BucketPolicyMemberParameters) { p.Members = append(p.Members, "domain:example.com") },
+		},
+		"ConditionAdded": {
+			reason: "Adding a condition should change the binding.",
+			change: func(p *v1alpha1.BucketPolicyMemberParameters) {
+				p.Condition = &iamv1alpha1.Expr{Expression: "request.time < timestamp(\"2020-10-01T00:00:00.000Z\")"}
+			},
+		},
+		"PolicyVersionChanged": {
+			reason: "Changing the policy version should change the binding.",
+			change: func(p *v1alpha1.BucketPolicyMemberParameters) { p.PolicyVersion = gcp.Int64Ptr(1) },
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			changed := *in.DeepCopy()
+			tc.change(&changed)
+			if diff := cmp.Diff(tc.same, MemberBinding(in) == MemberBinding(changed)); diff != "" {
+				t.Errorf("\n%s\nMemberBinding(...): -want same, +got same:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRemovedMembers(t *testing.T) {
+	jane := "user:jane@example.com"
+	applied := v1alpha1.BucketPolicyMemberParameters{
+		Bucket:  gcp.StringPtr("some-bucket"),
+		Role:    testRole,
+		Member:  &testMember,
+		Members: []string{jane},
+	}
+	cases := map[string]struct {
+		reason      string
+		lastApplied string
+		change      func(*v1alpha1.BucketPolicyMemberParameters)
+		want        []string
+	}{
+		"NeverApplied": {
+			reason: "No members should be removed from a binding that was never applied.",
+			change: func(p *v1alpha1.BucketPolicyMemberParameters) { p.Members = nil },
+		},
+		"Unchanged": {
+			reason:      "No members should be removed from an unchanged binding.",
+			lastApplied: MemberBinding(applied),
+			change:      func(p *v1alpha1.BucketPolicyMemberParameters) {},
+		},
+		"MemberRemoved": {
+			reason:      "A member removed from the members should be removed from the binding.",
+			lastApplied: MemberBinding(applied),
+			change:      func(p *v1alpha1.BucketPolicyMemberParameters) { p.Members = nil },
+			want:        []string{jane},
+		},
+		"MemberMoved": {
+			reason:      "A member moved from the member to the members should not be removed from the binding.",
+			lastApplied: MemberBinding(applied),
+			change: func(p *v1alpha1.BucketPolicyMemberParameters) {
+				p.Member = nil
+				p.Members = []string{jane, testMember}
+			},
+		},
+		"RoleChanged": {
+			reason:      "No members should be removed from a binding of another role.",
+			lastApplied: MemberBinding(applied),
+			change: func(p *v1alpha1.BucketPolicyMemberParameters) {
+				p.Role = "roles/storage.objectViewer"
+				p.Members = nil
+			},
+		},
+		"Malformed": {
+			reason:      "No members should be removed from a binding whose last applied binding is malformed.",
+			lastApplied: "{",
+			change:      func(p *v1alpha1.BucketPolicyMemberParameters) { p.Members = nil },
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			in := *applied.DeepCopy()
+			tc.change(&in)
+			if diff := cmp.Diff(tc.want, RemovedMembers(tc.lastApplied, in)); diff != "" {
+				t.Errorf("\n%s\nRemovedMembers(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidateRetainsMember(t *testing.T) {
+	caller := "serviceAccount:crossplane@my-project.iam.gserviceaccount.com"
+	observed := &storage.Policy{Bindings: []*storage.PolicyBindings{
+		{Role: "roles/storage.admin", Members: []string{caller, testMember}},
+		{Role: testRole, Members: []string{testMember}},
+	}}
+	cases := map[string]struct {
+		desired *storage.Policy
+		member  string
+		want    error
+	}{
+		"Retained": {
+			desired: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: "roles/storage.admin", Members: []string{caller}},
+			}},
+			member: caller,
+		},
+		"OtherMemberUnbound": {
+			desired: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: "roles/storage.admin", Members: []string{caller}},
+				{Role: "roles/storage.objectViewer", Members: []string{testMember}},
+			}},
+			member: caller,
+		},
+		"NoMember": {
+			desired: &storage.Policy{},
+		},
+		"Unbound": {
+			desired: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: testRole, Members: []string{testMember}},
+			}},
+			member: caller,
+			want:   errors.Errorf(errFmtUnbindsMember, caller, "role roles/storage.admin of member "+caller),
+		},
+		"UnboundByCondition": {
+			desired: &storage.Policy{Bindings: []*storage.PolicyBindings{
+				{Role: "roles/storage.admin", Members: []string{caller}, Condition: &storage.Expr{Title: "weekdays", Expression: "true"}},
+			}},
+			member: caller,
+			want:   errors.Errorf(errFmtUnbindsMember, caller, "role roles/storage.admin of member "+caller),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateRetainsMember(tc.desired, observed, tc.member)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateRetainsMember(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiffMembers(t *testing.T) {
+	observed := &storage.Policy{Bindings: []*storage.PolicyBindings{
+		{Role: testRole, Members: []string{testMember, "user:jane@example.com"}},
+	}}
+	desired := &storage.Policy{Bindings: []*storage.PolicyBindings{
+		{Role: testRole, Members: []string{testMember}},
+		{Role: "roles/storage.objectViewer", Members: []string{"user:jane@example.com"}, Condition: &storage.Expr{Title: "weekdays", Expression: "true"}},
+	}}
+	wantBound := []string{`role roles/storage.objectViewer of member user:jane@example.com with condition "weekdays"`}
+	wantUnbound := []string{"role " + testRole + " of member user:jane@example.com"}
+
+	bound, unbound := DiffMembers(desired, observed)
+	if diff := cmp.Diff(wantBound, bound); diff != "" {
+		t.Errorf("DiffMembers(...): -want bound, +got bound:\n%s", diff)
+	}
+	if diff := cmp.Diff(wantUnbound, unbound); diff != "" {
+		t.Errorf("DiffMembers(...): -want unbound, +got unbound:\n%s", diff)
+	}
+	bound, unbound = DiffMembers(observed, observed)
+	if len(bound)+len(unbound) != 0 {
+		t.Errorf("DiffMembers(...): want no difference, got bound %v and unbound %v", bound, unbound)
+	}
+}
+
+func TestRetainMember(t *testing.T) {
+	caller := "serviceAccount:crossplane@my-project.iam.gserviceaccount.com"
+	sp := &storage.Policy{Etag: "BwWKmjvelug=", Bindings: []*storage.PolicyBindings{
+		{Role: "roles/storage.admin", Members: []string{testMember, caller}},
+		{Role: testRole, Members: []string{testMember}},
+	}}
+	want := &storage.Policy{Etag: "BwWKmjvelug=", Bindings: []*storage.PolicyBindings{
+		{Role: "roles/storage.admin", Members: []string{caller}},
+	}}
+	RetainMember(sp, caller)
+	if diff := cmp.Diff(want, sp); diff != "" {
+		t.Errorf("RetainMember(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestParseMemberExternalName(t *testing.T) {
+	type want struct {
+		bucket string
+		role   string
+		member string
+		ok     bool
+	}
+	cases := map[string]struct {
+		name string
+		want want
+	}{
+		"Predefined": {
+			name: MemberExternalName(testBucket, testRole, testMember),
+			want: want{bucket: testBucket, role: testRole, member: testMember, ok: true},
+		},
+		"ProjectCustom": {
+			name: MemberExternalName(testBucket, "projects/my-project/roles/bucketReader", testMember),
+			want: want{bucket: testBucket, role: "projects/my-project/roles/bucketReader", member: testMember, ok: true},
+		},
+		"OrganizationCustom": {
+			name: MemberExternalName(testBucket, "organizations/123456789/roles/bucketReader", testMember),
+			want: want{bucket: testBucket, role: "organizations/123456789/roles/bucketReader", member: testMember, ok: true},
+		},
+		"MemberWithSlashes": {
+			name: MemberExternalName(testBucket, testRole, "principal://iam.googleapis.com/locations/global/workforcePools/pool/subject/jane"),
+			want: want{bucket: testBucket, role: testRole, member: "principal://iam.googleapis.com/locations/global/workforcePools/pool/subject/jane", ok: true},
+		},
+		"MetadataName": {
+			name: "test-bucket-policy-member",
+		},
+		"MissingMember": {
+			name: testBucket + "/" + testRole,
+		},
+		"MissingBucket": {
+			name: MemberExternalName("", testRole, testMember),
+		},
+		"InvalidRole": {
+			name: MemberExternalName(testBucket, "folders/123/roles/bucketReader", testMember),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			bucket, role, member, ok := ParseMemberExternalName(tc.name)
+			if diff := cmp.Diff(tc.want, want{bucket: bucket, role: role, member: member, ok: ok}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("ParseMemberExternalName(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCustomRoleExists(t *testing.T) {
+	type want struct {
+		exists bool
+		calls  int
+		err    bool
+	}
+	cases := map[string]struct {
+		role  string
+		roles map[string]*iam.Role
+		want  want
+	}{
+		"PredefinedRole": {
+			role: testRole,
+			want: want{exists: true},
+		},
+		"ExistingProjectRole": {
+			role:  "projects/my-project/roles/myRole",
+			roles: map[string]*iam.Role{"projects/my-project/roles/myRole": {Name: "projects/my-project/roles/myRole"}},
+			want:  want{exists: true, calls: 1},
+		},
+		"ExistingOrganizationRole": {
+			role:  "organizations/123/roles/myRole",
+			roles: map[string]*iam.Role{"organizations/123/roles/myRole": {Name: "organizations/123/roles/myRole"}},
+			want:  want{exists: true, calls: 1},
+		},
+		"MissingRole": {
+			role: "projects/my-project/roles/myRole",
+			want: want{calls: 1},
+		},
+		"DeletedRole": {
+			role:  "projects/my-project/roles/myRole",
+			roles: map[string]*iam.Role{"projects/my-project/roles/myRole": {Name: "projects/my-project/roles/myRole", Deleted: true}},
+			want:  want{calls: 1},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				w.Header().Set("Content-Type", "application/json")
+				role, ok := tc.roles[strings.TrimPrefix(r.URL.Path, "/v1/")]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{"error": map[string]interface{}{"code": http.StatusNotFound, "message": "role not found"}})
+					return
+				}
+				_ = json.NewEncoder(w).Encode(role)
+			}))
+			defer server.Close()
+			s, err := iam.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			if err != nil {
+				t.Fatalf("NewService(...): %s", err)
+			}
+			exists, err := CustomRoleExists(context.Background(), s, tc.role)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("CustomRoleExists(...): -want error, +got error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.exists, exists); diff != "" {
+				t.Errorf("CustomRoleExists(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("CustomRoleExists(...): -want calls to the GCP API, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidatePolicySize(t *testing.T) {
+	members := func(n int) []string {
+		m := make([]string, n)
+		for i := range m {
+			m[i] = fmt.Sprintf("serviceAccount:sa-%d@wesaas-playground.iam.gserviceaccount.com", i)
+		}
+		return m
+	}
+	cases := map[string]struct {
+		policy *storage.Policy
+		valid  bool
+	}{
+		"Empty": {
+			policy: &storage.Policy{},
+			valid:  true,
+		},
+		"WithinLimit": {
+			policy: &storage.Policy{
+				Bindings: []*storage.PolicyBindings{{Role: testRole, Members: members(100)}},
+			},
+			valid: true,
+		},
+		"TooLarge": {
+			policy: &storage.Policy{
+				Bindings: []*storage.PolicyBindings{{Role: testRole, Members: members(5000)}},
+			},
+			valid: false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidatePolicySize(tc.policy)
+			if diff := cmp.Diff(tc.valid, err == nil); diff != "" {
+				t.Errorf("ValidatePolicySize(...): -want valid, +got valid: %s\n%v", diff, err)
+			}
+		})
+	}
+}
+
+func TestGenerateIAMMembers(t *testing.T) {
+	bucket := "cool-bucket"
+	otherBucket := "other-bucket"
+	external := "user:external@example.com"
+	title := "expires"
+	expression := "request.time < timestamp(\"2030-01-01T00:00:00Z\")"
+
+	type args struct {
+		policies []v1alpha1.BucketPolicy
+		bindings []v1alpha1.BucketPolicyBinding
+		members  []v1alpha1.BucketPolicyMember
+		sp       *storage.Policy
+	}
+	cases := map[string]struct {
+		args
+		want []v1alpha3.BucketIAMMember
+	}{
+		"EmptyPolicy": {
+			args: args{
+				sp: &storage.Policy{},
+			},
+		},
+		"OwnedAndExternalMembers": {
+			args: args{
+				policies: []v1alpha1.BucketPolicy{{
+					ObjectMeta: metav1.ObjectMeta{Name: "policy"},
+					Spec: v1alpha1.BucketPolicySpec{ForProvider: v1alpha1.BucketPolicyParameters{
+						Bucket: &bucket,
+						Policy: iamv1alpha1.Policy{Bindings: []*iamv1alpha1.Binding{{
+							Role:    "roles/storage.admin",
+							Members: []string{testMember},
+						}}},
+					}},
+				}},
+				bindings: []v1alpha1.BucketPolicyBinding{{
+					ObjectMeta: metav1.ObjectMeta{Name: "binding"},
+					Spec: v1alpha1.BucketPolicyBindingSpec{ForProvider: v1alpha1.BucketPolicyBindingParameters{
+						Bucket:    &bucket,
+						Role:      testRole,
+						Members:   []string{testMember},
+						Condition: &iamv1alpha1.Expr{Title: &title, Expression: expression},
+					}},
+				}},
+				members: []v1alpha1.BucketPolicyMember{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "member"},
+						Spec: v1alpha1.BucketPolicyMemberSpec{ForProvider: v1alpha1.BucketPolicyMemberParameters{
+							Bucket: &bucket,
+							Role:   testRole,
+							Member: &testMember,
+						}},
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "other"},
+						Spec: v1alpha1.BucketPolicyMemberSpec{ForProvider: v1alpha1.BucketPolicyMemberParameters{
+							Bucket: &otherBucket,
+							Role:   testRole,
+							Member: &external,
+						}},
+					},
+				},
+				sp: &storage.Policy{
+					Bindings: []*storage.PolicyBindings{
+						{
+							Role:    "roles/storage.admin",
+							Members: []string{testMember},
+						},
+						{
+							Role:    testRole,
+							Members: []string{testMember, external},
+						},
+						{
+							Role:      testRole,
+							Members:   []string{testMember},
+							Condition: &storage.Expr{Title: title, Expression: expression},
+						},
+					},
+				},
+			},
+			want: []v1alpha3.BucketIAMMember{
+				{Role: "roles/storage.admin", Member: testMember, ManagedBy: v1alpha1.BucketPolicyKind + "/policy"},
+				{Role: testRole, Member: testMember, ManagedBy: v1alpha1.BucketPolicyMemberKind + "/member"},
+				{Role: testRole, Member: external},
+				{Role: testRole, Member: testMember, ConditionTitle: title, ManagedBy: v1alpha1.BucketPolicyBindingKind + "/binding"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o := NewMemberOwners(bucket, tc.args.policies, tc.args.bindings, tc.args.members)
+			if diff := cmp.Diff(tc.want, GenerateIAMMembers(tc.args.sp, o)); diff != "" {
+				t.Errorf("GenerateIAMMembers(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewMemberOwners(t *testing.T) {
+	bucket := "cool-bucket"
+	jane := "user:jane@example.com"
+	key := func(m string) string { return memberKey(&storage.PolicyBindings{Role: testRole}, m) }
+	cases := map[string]struct {
+		reason  string
+		members []v1alpha1.BucketPolicyMember
+		want    MemberOwners
+	}{
+		"Member": {
+			reason: "The member of a BucketPolicyMember should be owned by it.",
+			members: []v1alpha1.BucketPolicyMember{{
+				ObjectMeta: metav1.ObjectMeta{Name: "member"},
+				Spec: v1alpha1.BucketPolicyMemberSpec{ForProvider: v1alpha1.BucketPolicyMemberParameters{
+					Bucket: &bucket, Role: testRole, Member: &testMember,
+				}},
+			}},
+			want: MemberOwners{key(testMember): v1alpha1.BucketPolicyMemberKind + "/member"},
+		},
+		"Members": {
+			reason: "Each member of a BucketPolicyMember without a single member should be owned by it, and no empty member should be.",
+			members: []v1alpha1.BucketPolicyMember{{
+				ObjectMeta: metav1.ObjectMeta{Name: "members"},
+				Spec: v1alpha1.BucketPolicyMemberSpec{ForProvider: v1alpha1.BucketPolicyMemberParameters{
+					Bucket: &bucket, Role: testRole, Members: []string{testMember, jane},
+				}},
+			}},
+			want: MemberOwners{
+				key(testMember): v1alpha1.BucketPolicyMemberKind + "/members",
+				key(jane):       v1alpha1.BucketPolicyMemberKind + "/members",
+			},
+		},
+		"MemberAndMembers": {
+			reason: "Both the member and the other members of a BucketPolicyMember should be owned by it.",
+			members: []v1alpha1.BucketPolicyMember{{
+				ObjectMeta: metav1.ObjectMeta{Name: "both"},
+				Spec: v1alpha1.BucketPolicyMemberSpec{ForProvider: v1alpha1.BucketPolicyMemberParameters{
+					Bucket: &bucket, Role: testRole, Member: &testMember, Members: []string{jane},
+				}},
+			}},
+			want: MemberOwners{
+				key(testMember): v1alpha1.BucketPolicyMemberKind + "/both",
+				key(jane):       v1alpha1.BucketPolicyMemberKind + "/both",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, NewMemberOwners(bucket, nil, nil, tc.members)); diff != "" {
+				t.Errorf("\n%s\nNewMemberOwners(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestConditionExpiry(t *testing.T) {
+	expiry := time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC)
+	type want struct {
+		expiry  time.Time
+		expires bool
+	}
+	cases := map[string]struct {
+		in   *iamv1alpha1.Expr
+		want want
+	}{
+		"NoCondition": {
+			in: nil,
+		},
+		"Expiry": {
+			in:   &iamv1alpha1.Expr{Expression: `request.time < timestamp("2020-10-01T00:00:00.000Z")`},
+			want: want{expiry: expiry, expires: true},
+		},
+		"InclusiveExpiry": {
+			in:   &iamv1alpha1.Expr{Expression: `request.time <= timestamp('2020-10-01T00:00:00Z')`},
+			want: want{expiry: expiry, expires: true},
+		},
+		"ExpiryAndOtherClause": {
+			in:   &iamv1alpha1.Expr{Expression: `resource.name.startsWith("projects/_/buckets/my-bucket/objects/logs/") && (request.time < timestamp("2020-10-01T00:00:00Z"))`},
+			want: want{expiry: expiry, expires: true},
+		},
+		"EarliestExpiry": {
+			in:   &iamv1alpha1.Expr{Expression: `request.time < timestamp("2021-10-01T00:00:00Z") && request.time < timestamp("2020-10-01T00:00:00Z")`},
+			want: want{expiry: expiry, expires: true},
+		},
+		"Alternative": {
+			in: &iamv1alpha1.Expr{Expression: `request.time < timestamp("2020-10-01T00:00:00Z") || resource.type == "storage.googleapis.com/Bucket"`},
+		},
+		"StartTime": {
+			in: &iamv1alpha1.Expr{Expression: `request.time > timestamp("2020-10-01T00:00:00Z")`},
+		},
+		"InvalidTimestamp": {
+			in: &iamv1alpha1.Expr{Expression: `request.time < timestamp("tomorrow")`},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			expiry, expires := ConditionExpiry(tc.in)
+			if diff := cmp.Diff(tc.want, want{expiry: expiry, expires: expires}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("ConditionExpiry(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+// The scan functions are the implementations of BindRoleToMember,
+// UnbindRoleFromMember and HasBinding that scanned the members of a binding
+// for each member of the parameters. Their results must not change.
+
+func scanBindRoleToMember(in v1alpha1.BucketPolicyMemberParameters, sp *storage.Policy) bool {
+	sp.Version = MemberPolicyVersion(in)
+	members := BoundMembers(in)
+	for _, b := range sp.Bindings {
+		if isBinding(b, in.Role, in.Condition) {
+			changed := false
+			for _, m := range members {
+				if !containsMember(b.Members, m) {
+					b.Members = append(b.Members, m)
+					changed = true
+				}
+			}
+			return changed
+		}
+	}
+	if len(members) == 0 {
+		return false
+	}
+	sp.Bindings = append(sp.Bindings, &storage.PolicyBindings{
+		Role:      in.Role,
+		Condition: generateCondition(in.Condition),
+		Members:   members,
+	})
+	return true
+}
+
+func scanUnbindRoleFromMember(in v1alpha1.BucketPolicyMemberParameters, sp *storage.Policy) bool {
+	members := BoundMembers(in)
+	for _, b := range sp.Bindings {
+		if isBinding(b, in.Role, in.Condition) {
+			kept := make([]string, 0, len(b.Members))
+			for _, m := range b.Members {
+				if !containsMember(members, m) {
+					kept = append(kept, m)
+				}
+			}
+			if len(kept) == len(b.Members) {
+				return false
+			}
+			b.Members = kept
+			return true
+		}
+	}
+	return false
+}
+
+func scanHasBinding(in v1alpha1.BucketPolicyMemberParameters, sp *storage.Policy) bool {
+	members := BoundMembers(in)
+	if len(members) == 0 || sp == nil {
+		return false
+	}
+	for _, m := range members {
+		bound := false
+		for _, b := range sp.Bindings {
+			if isBinding(b, in.Role, in.Condition) && containsMember(b.Members, m) {
+				bound = true
+				break
+			}
+		}
+		if !bound {
+			return false
+		}
+	}
+	return true
+}
+
+// largePolicy returns a policy of the supplied number of bindings, each of
+// its own role and the supplied number of members.
+func largePolicy(bindings, members int) *storage.Policy {
+	sp := &storage.Policy{Version: iamv1alpha1.PolicyVersion}
+	for i := 0; i < bindings; i++ {
+		b := &storage.PolicyBindings{Role: fmt.Sprintf("roles/custom.role%d", i)}
+		for j := 0; j < members; j++ {
+			b.Members = append(b.Members, fmt.Sprintf("user:member%d@example.com", j))
+		}
+		sp.Bindings = append(sp.Bindings, b)
+	}
+	return sp
+}
+
+// largeParameters returns parameters that bind the role of the last binding of
+// a largePolicy to the supplied number of its members, starting from the
+// supplied offset. Members beyond the members of the binding are not bound.
+func largeParameters(bindings, offset, members int) v1alpha1.BucketPolicyMemberParameters {
+	in := v1alpha1.BucketPolicyMemberParameters{Role: fmt.Sprintf("roles/custom.role%d", bindings-1)}
+	for j := offset; j < offset+members; j++ {
+		in.Members = append(in.Members, fmt.Sprintf("user:member%d@example.com", j))
+	}
+	return in
+}
+
+func TestMemberSetEquivalence(t *testing.T) {
+	const bindings, members = 50, 40
+	cases := map[string]v1alpha1.BucketPolicyMemberParameters{
+		"AllBound":     largeParameters(bindings, 0, 10),
+		"SomeBound":    largeParameters(bindings, members-5, 10),
+		"NoneBound":    largeParameters(bindings, members, 10),
+		"SingleMember": {Role: "roles/custom.role7", Member: gcp.StringPtr("user:member3@example.com")},
+		"OtherRole":    {Role: testRole, Member: &testMember},
+		"Condition": {
+			Role:      "roles/custom.role0",
+			Member:    gcp.StringPtr("user:member0@example.com"),
+			Condition: &iamv1alpha1.Expr{Title: gcp.StringPtr("expirable access"), Expression: "request.time < timestamp(\"2020-10-01T00:00:00.000Z\")"},
+		},
+	}
+	for name, in := range cases {
+		t.Run(name, func(t *testing.T) {
+			sp := largePolicy(bindings, members)
+			sp.Bindings[1].Members = append(sp.Bindings[1].Members, sp.Bindings[1].Members...)
+
+			if want, got := scanHasBinding(in, sp), HasBinding(in, sp); want != got {
+				t.Errorf("HasBinding(...): want %t, got %t", want, got)
+			}
+
+			want, got := copyPolicy(sp), copyPolicy(sp)
+			if wantChanged, gotChanged := scanBindRoleToMember(in, want), BindRoleToMember(in, got); wantChanged != gotChanged {
+				t.Errorf("BindRoleToMember(...): want changed %t, got %t", wantChanged, gotChanged)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("BindRoleToMember(...): -want, +got:\n%s", diff)
+			}
+
+			want, got = copyPolicy(sp), copyPolicy(sp)
+			if wantChanged, gotChanged := scanUnbindRoleFromMember(in, want), UnbindRoleFromMember(in, got); wantChanged != gotChanged {
+				t.Errorf("UnbindRoleFromMember(...): want changed %t, got %t", wantChanged, gotChanged)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("UnbindRoleFromMember(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+// The benchmarks observe a binding of many members in a policy of 500
+// bindings, as each reconcile of a BucketPolicyMember that is up to date does.
+
+const (
+	benchBindings = 500
+	benchMembers  = 1000
+	benchBound    = 100
+)
+
+func BenchmarkHasBinding(b *testing.B) {
+	sp, in := largePolicy(benchBindings, benchMembers), largeParameters(benchBindings, benchMembers-benchBound, benchBound)
+	b.Run("MemberSet", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			HasBinding(in, sp)
+		}
+	})
+	b.Run("Scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			scanHasBinding(in, sp)
+		}
+	})
+}
+
+func BenchmarkBindRoleToMember(b *testing.B) {
+	sp, in := largePolicy(benchBindings, benchMembers), largeParameters(benchBindings, benchMembers-benchBound, benchBound)
+	b.Run("MemberSet", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			BindRoleToMember(in, sp)
+		}
+	})
+	b.Run("Scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			scanBindRoleToMember(in, sp)
+		}
+	})
+}
+
+func BenchmarkUnbindRoleFromMember(b *testing.B) {
+	sp, in := largePolicy(benchBindings, benchMembers), largeParameters(benchBindings, benchMembers, benchBound)
+	b.Run("MemberSet", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			UnbindRoleFromMember(in, sp)
+		}
+	})
+	b.Run("Scan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			scanUnbindRoleFromMember(in, sp)
+		}
+	})
+}
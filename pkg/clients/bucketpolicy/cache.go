@@ -0,0 +1,145 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucketpolicy
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/api/storage/v1"
+)
+
+// DefaultPolicyCacheTTL is how long a PolicyCache serves the IAM policy of a
+// bucket it read before reading it again.
+const DefaultPolicyCacheTTL = 5 * time.Second
+
+// A PolicyCache shares the IAM policies of buckets between the reconciles of
+// the resources binding roles in them, so that these read the policy of a
+// bucket once in a while rather than once each. Policies are cached by a key
+// that identifies the bucket along with anything else the policy read depends
+// on, e.g. the credentials and project it is read with. A nil PolicyCache
+// caches nothing.
+type PolicyCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu       sync.Mutex
+	policies map[string]*cachedPolicy
+}
+
+// A cachedPolicy is the IAM policy of a bucket, which is being read until done
+// is closed.
+type cachedPolicy struct {
+	done    chan struct{}
+	policy  *storage.Policy
+	err     error
+	expires time.Time
+}
+
+// NewPolicyCache returns a PolicyCache that serves the IAM policy of a bucket
+// for the supplied duration after it was read. Concurrent reads of the policy
+// of a bucket are always shared, even if the duration is not positive.
+func NewPolicyCache(ttl time.Duration) *PolicyCache {
+	return &PolicyCache{ttl: ttl, now: time.Now, policies: map[string]*cachedPolicy{}}
+}
+
+// Get returns the IAM policy cached with the supplied key. The policy is read
+// using the supplied function unless it is cached, or already being read.
+// Errors reading the policy are not cached. The returned policy is a copy that
+// may be modified by the caller.
+func (c *PolicyCache) Get(ctx context.Context, key string, read func() (*storage.Policy, error)) (*storage.Policy, error) {
+	if c == nil {
+		return read()
+	}
+
+	c.mu.Lock()
+	p, ok := c.policies[key]
+	if ok && isDone(p) && !c.now().Before(p.expires) {
+		ok = false
+	}
+	if !ok {
+		p = &cachedPolicy{done: make(chan struct{})}
+		c.policies[key] = p
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		p.policy, p.err = read()
+		c.mu.Lock()
+		p.expires = c.now().Add(c.ttl)
+		if p.err != nil && c.policies[key] == p {
+			delete(c.policies, key)
+		}
+		c.mu.Unlock()
+		close(p.done)
+	}
+
+	select {
+	case <-p.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return copyPolicy(p.policy), nil
+}
+
+// Invalidate the IAM policy cached with the supplied key, if any, in order for
+// it to be read again. Reads of the policy that are in progress are not shared
+// with later calls to Get.
+func (c *PolicyCache) Invalidate(key string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	delete(c.policies, key)
+	c.mu.Unlock()
+}
+
+func isDone(p *cachedPolicy) bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// copyPolicy returns a copy of the supplied policy whose bindings may be
+// modified without modifying the supplied policy.
+func copyPolicy(in *storage.Policy) *storage.Policy {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	if in.Bindings == nil {
+		return &out
+	}
+	out.Bindings = make([]*storage.PolicyBindings, len(in.Bindings))
+	for i, b := range in.Bindings {
+		cb := *b
+		cb.Members = append([]string(nil), b.Members...)
+		if b.Condition != nil {
+			cond := *b.Condition
+			cb.Condition = &cond
+		}
+		out.Bindings[i] = &cb
+	}
+	return &out
+}
@@ -0,0 +1,180 @@
+package bucketpolicy
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/storage/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+const testBucket = "test-bucket"
+
+func testPolicy() *storage.Policy {
+	return &storage.Policy{
+		Etag: "BwWWja0YfJA=",
+		Bindings: []*storage.PolicyBindings{
+			{Role: testRole, Members: []string{testMember}},
+		},
+	}
+}
+
+func TestPolicyCacheConcurrentGet(t *testing.T) {
+	c := NewPolicyCache(time.Minute)
+
+	var reads int32
+	release := make(chan struct{})
+	read := func() (*storage.Policy, error) {
+		atomic.AddInt32(&reads, 1)
+		<-release
+		return testPolicy(), nil
+	}
+
+	const n = 10
+	got := make([]*storage.Policy, n)
+	errs := make([]error, n)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			got[i], errs[i] = c.Get(context.Background(), testBucket, read)
+		}(i)
+	}
+	close(release)
+	wg.Wait()
+
+	if r := atomic.LoadInt32(&reads); r != 1 {
+		t.Errorf("Get(...): want 1 read, got %d", r)
+	}
+	for i := 0; i < n; i++ {
+		if errs[i] != nil {
+			t.Errorf("Get(...): %s", errs[i])
+		}
+		if diff := cmp.Diff(testPolicy(), got[i]); diff != "" {
+			t.Errorf("Get(...): -want, +got:\n%s", diff)
+		}
+	}
+
+	// Every caller may modify the policy it got.
+	got[0].Bindings[0].Members = append(got[0].Bindings[0].Members, "user:jane@example.com")
+	if diff := cmp.Diff(testPolicy(), got[1]); diff != "" {
+		t.Errorf("Get(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestPolicyCacheGet(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := time.Now()
+
+	type want struct {
+		policy *storage.Policy
+		err    error
+		reads  int
+	}
+	cases := map[string]struct {
+		reason string
+		ttl    time.Duration
+		setup  func(c *PolicyCache)
+		read   func() (*storage.Policy, error)
+		want   want
+	}{
+		"Cached": {
+			reason: "A policy that was read within the TTL should not be read again.",
+			ttl:    time.Minute,
+			setup: func(c *PolicyCache) {
+				c.now = func() time.Time { return now.Add(59 * time.Second) }
+			},
+			want: want{policy: testPolicy(), reads: 1},
+		},
+		"Expired": {
+			reason: "A policy that was read longer than the TTL ago should be read again.",
+			ttl:    time.Minute,
+			setup: func(c *PolicyCache) {
+				c.now = func() time.Time { return now.Add(time.Minute) }
+			},
+			want: want{policy: testPolicy(), reads: 2},
+		},
+		"Invalidated": {
+			reason: "A policy should be read again once it was invalidated, e.g. after it was set.",
+			ttl:    time.Minute,
+			setup: func(c *PolicyCache) {
+				c.Invalidate(testBucket)
+			},
+			want: want{policy: testPolicy(), reads: 2},
+		},
+		"OtherBucketInvalidated": {
+			reason: "Invalidating the policy of another bucket should not invalidate this one.",
+			ttl:    time.Minute,
+			setup: func(c *PolicyCache) {
+				c.Invalidate("other-bucket")
+			},
+			want: want{policy: testPolicy(), reads: 1},
+		},
+		"NoTTL": {
+			reason: "A policy should always be read again if the TTL is not positive.",
+			ttl:    0,
+			want:   want{policy: testPolicy(), reads: 2},
+		},
+		"ErrorNotCached": {
+			reason: "An error reading a policy should not be cached.",
+			ttl:    time.Minute,
+			read:   func() (*storage.Policy, error) { return nil, errBoom },
+			want:   want{err: errBoom, reads: 2},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := NewPolicyCache(tc.ttl)
+			c.now = func() time.Time { return now }
+
+			reads := 0
+			read := func() (*storage.Policy, error) {
+				reads++
+				if tc.read != nil {
+					return tc.read()
+				}
+				return testPolicy(), nil
+			}
+
+			_, _ = c.Get(context.Background(), testBucket, read)
+			if tc.setup != nil {
+				tc.setup(c)
+			}
+			got, err := c.Get(context.Background(), testBucket, read)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGet(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.policy, got); diff != "" {
+				t.Errorf("\n%s\nGet(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.reads, reads); diff != "" {
+				t.Errorf("\n%s\nGet(...): -want reads, +got reads:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNilPolicyCache(t *testing.T) {
+	var c *PolicyCache
+	reads := 0
+	read := func() (*storage.Policy, error) {
+		reads++
+		return testPolicy(), nil
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := c.Get(context.Background(), testBucket, read); err != nil {
+			t.Fatalf("Get(...): %s", err)
+		}
+	}
+	c.Invalidate(testBucket)
+	if reads != 2 {
+		t.Errorf("Get(...): want 2 reads, got %d", reads)
+	}
+}
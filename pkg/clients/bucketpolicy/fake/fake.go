@@ -0,0 +1,220 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake provides an in-memory IAM policy backend for testing clients
+// of the IAM policies of Google Cloud Storage buckets.
+package fake
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/storage/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	"github.com/crossplane/provider-gcp/pkg/clients/bucketpolicy"
+)
+
+const errNewClient = "cannot create new Storage client"
+
+// A PolicyStore is an in-memory store of the IAM policies of buckets that
+// serves the parts of the Google Cloud Storage JSON API the policies are read
+// and set with. Like the API, it bumps the etag of a policy each time it is
+// set, and rejects setting a policy along with an etag other than the current
+// one with a 409 Conflict response rather than overwriting a concurrent change.
+type PolicyStore struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	policies map[string]*storage.Policy
+	versions map[string]int
+	calls    map[string]int
+	rejected map[string]int
+}
+
+// NewPolicyStore returns a PolicyStore that serves the supplied policies,
+// keyed by the name of their bucket. Buckets without a policy do not exist.
+// The returned PolicyStore must be closed once it is no longer used.
+func NewPolicyStore(policies map[string]*storage.Policy) *PolicyStore {
+	s := &PolicyStore{
+		policies: map[string]*storage.Policy{},
+		versions: map[string]int{},
+		calls:    map[string]int{},
+		rejected: map[string]int{},
+	}
+	for bucket, p := range policies {
+		s.store(bucket, p)
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// Client returns a bucketpolicy.Client of the policies in the store.
+func (s *PolicyStore) Client(ctx context.Context) (bucketpolicy.Client, error) {
+	svc, err := storage.NewService(ctx, option.WithEndpoint(s.server.URL), option.WithoutAuthentication())
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return storage.NewBucketsService(svc), nil
+}
+
+// Close the PolicyStore, blocking until all of its outstanding requests
+// completed.
+func (s *PolicyStore) Close() {
+	s.server.Close()
+}
+
+// Policy returns a copy of the current policy of the supplied bucket, or nil
+// if the bucket does not exist.
+func (s *PolicyStore) Policy(bucket string) *storage.Policy {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return copyPolicy(s.policies[bucket])
+}
+
+// SetPolicy sets the policy of the supplied bucket, creating the bucket if it
+// does not exist, as a concurrent change made by another client would.
+func (s *PolicyStore) SetPolicy(bucket string, p *storage.Policy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.store(bucket, p)
+}
+
+// RejectSet makes the store reject setting the policy of the supplied bucket
+// with the supplied HTTP status code, e.g. 403 Forbidden as the API does if
+// the client is not allowed to set it.
+func (s *PolicyStore) RejectSet(bucket string, code int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rejected[bucket] = code
+}
+
+// Calls returns how often the policies in the store were read, i.e. GET, and
+// set, i.e. PUT, keyed by HTTP method.
+func (s *PolicyStore) Calls() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	calls := make(map[string]int, len(s.calls))
+	for m, n := range s.calls {
+		calls[m] = n
+	}
+	return calls
+}
+
+// store the supplied policy of the supplied bucket with a new etag. The caller
+// must hold the lock of the store, if it is serving.
+func (s *PolicyStore) store(bucket string, p *storage.Policy) {
+	s.versions[bucket]++
+	stored := copyPolicy(p)
+	if stored == nil {
+		stored = &storage.Policy{}
+	}
+	stored.Etag = base64.StdEncoding.EncodeToString([]byte(strconv.Itoa(s.versions[bucket])))
+	stored.Kind = "storage#policy"
+	stored.ResourceId = "projects/_/buckets/" + bucket
+	s.policies[bucket] = stored
+}
+
+func (s *PolicyStore) serve(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	// The policy of a bucket is served at /b/{bucket}/iam, relative to the
+	// endpoint of the client.
+	path := strings.TrimPrefix(r.URL.Path, "/storage/v1")
+	if !strings.HasPrefix(path, "/b/") || !strings.HasSuffix(path, "/iam") {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	bucket := strings.TrimSuffix(strings.TrimPrefix(path, "/b/"), "/iam")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls[r.Method]++
+
+	current, ok := s.policies[bucket]
+	if !ok {
+		writeError(w, http.StatusNotFound, "The specified bucket does not exist.")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writePolicy(w, current)
+	case http.MethodPut:
+		if code, ok := s.rejected[bucket]; ok {
+			writeError(w, code, http.StatusText(code))
+			return
+		}
+		p := &storage.Policy{}
+		if err := json.NewDecoder(r.Body).Decode(p); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid argument")
+			return
+		}
+		if p.Etag != "" && p.Etag != current.Etag {
+			writeError(w, http.StatusConflict, "The etag of the policy is stale.")
+			return
+		}
+		s.store(bucket, p)
+		writePolicy(w, s.policies[bucket])
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+	}
+}
+
+func writePolicy(w http.ResponseWriter, p *storage.Policy) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error": map[string]interface{}{"code": code, "message": message},
+	})
+}
+
+// copyPolicy returns a copy of the supplied policy whose bindings may be
+// modified without modifying the supplied policy.
+func copyPolicy(in *storage.Policy) *storage.Policy {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	if in.Bindings == nil {
+		return &out
+	}
+	out.Bindings = make([]*storage.PolicyBindings, len(in.Bindings))
+	for i, b := range in.Bindings {
+		cb := *b
+		cb.Members = append([]string(nil), b.Members...)
+		if b.Condition != nil {
+			cond := *b.Condition
+			cb.Condition = &cond
+		}
+		out.Bindings[i] = &cb
+	}
+	return &out
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/api/storage/v1"
+
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const testBucket = "some-bucket"
+
+var (
+	viewers = &storage.PolicyBindings{Role: "roles/storage.objectViewer", Members: []string{"user:jane@example.com"}}
+	admins  = &storage.PolicyBindings{Role: "roles/storage.objectAdmin", Members: []string{"group:team@example.com"}}
+)
+
+// ignoreServerFields ignores the fields of a policy that the store sets.
+var ignoreServerFields = cmpopts.IgnoreFields(storage.Policy{}, "Etag", "Kind", "ResourceId", "ServerResponse")
+
+func TestPolicyStoreGetSet(t *testing.T) {
+	s := NewPolicyStore(map[string]*storage.Policy{testBucket: {Bindings: []*storage.PolicyBindings{viewers}}})
+	defer s.Close()
+	c, err := s.Client(context.Background())
+	if err != nil {
+		t.Fatalf("Client(...): %s", err)
+	}
+
+	p, err := c.GetIamPolicy(testBucket).Context(context.Background()).Do()
+	if err != nil {
+		t.Fatalf("GetIamPolicy(...): %s", err)
+	}
+	if diff := cmp.Diff(&storage.Policy{Bindings: []*storage.PolicyBindings{viewers}}, p, ignoreServerFields); diff != "" {
+		t.Errorf("GetIamPolicy(...): -want, +got:\n%s", diff)
+	}
+
+	read := p.Etag
+	p.Bindings = append(p.Bindings, admins)
+	set, err := c.SetIamPolicy(testBucket, p).Context(context.Background()).Do()
+	if err != nil {
+		t.Fatalf("SetIamPolicy(...): %s", err)
+	}
+	if set.Etag == read {
+		t.Errorf("SetIamPolicy(...): want etag to change from %q once set", read)
+	}
+	want := &storage.Policy{Bindings: []*storage.PolicyBindings{viewers, admins}}
+	if diff := cmp.Diff(want, s.Policy(testBucket), ignoreServerFields); diff != "" {
+		t.Errorf("Policy(...): -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]int{http.MethodGet: 1, http.MethodPut: 1}, s.Calls()); diff != "" {
+		t.Errorf("Calls(): -want, +got:\n%s", diff)
+	}
+}
+
+func TestPolicyStoreConflict(t *testing.T) {
+	type want struct {
+		conflict bool
+		policy   *storage.Policy
+	}
+	cases := map[string]struct {
+		reason string
+		change func(s *PolicyStore, read *storage.Policy)
+		want   want
+	}{
+		"StaleEtag": {
+			reason: "Setting a policy along with the etag it was read with should fail with a conflict once the policy was changed concurrently.",
+			change: func(s *PolicyStore, _ *storage.Policy) {
+				s.SetPolicy(testBucket, &storage.Policy{Bindings: []*storage.PolicyBindings{viewers}})
+			},
+			want: want{
+				conflict: true,
+				policy:   &storage.Policy{Bindings: []*storage.PolicyBindings{viewers}},
+			},
+		},
+		"NoEtag": {
+			reason: "Setting a policy without an etag should overwrite a concurrent change.",
+			change: func(s *PolicyStore, read *storage.Policy) {
+				s.SetPolicy(testBucket, &storage.Policy{Bindings: []*storage.PolicyBindings{viewers}})
+				read.Etag = ""
+			},
+			want: want{
+				policy: &storage.Policy{Bindings: []*storage.PolicyBindings{admins}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := NewPolicyStore(map[string]*storage.Policy{testBucket: {}})
+			defer s.Close()
+			c, err := s.Client(context.Background())
+			if err != nil {
+				t.Fatalf("Client(...): %s", err)
+			}
+			p, err := c.GetIamPolicy(testBucket).Context(context.Background()).Do()
+			if err != nil {
+				t.Fatalf("GetIamPolicy(...): %s", err)
+			}
+			tc.change(s, p)
+			p.Bindings = []*storage.PolicyBindings{admins}
+
+			_, err = c.SetIamPolicy(testBucket, p).Context(context.Background()).Do()
+			if diff := cmp.Diff(tc.want.conflict, gcp.IsErrorConflict(err)); diff != "" {
+				t.Errorf("\n%s\nSetIamPolicy(...): -want conflict, +got conflict:\n%s\n%v", tc.reason, diff, err)
+			}
+			if diff := cmp.Diff(tc.want.policy, s.Policy(testBucket), ignoreServerFields); diff != "" {
+				t.Errorf("\n%s\nPolicy(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPolicyStoreBucketNotFound(t *testing.T) {
+	s := NewPolicyStore(nil)
+	defer s.Close()
+	c, err := s.Client(context.Background())
+	if err != nil {
+		t.Fatalf("Client(...): %s", err)
+	}
+	if _, err := c.GetIamPolicy(testBucket).Context(context.Background()).Do(); !gcp.IsErrorNotFound(err) {
+		t.Errorf("GetIamPolicy(...): want not found error, got %v", err)
+	}
+	if _, err := c.SetIamPolicy(testBucket, &storage.Policy{}).Context(context.Background()).Do(); !gcp.IsErrorNotFound(err) {
+		t.Errorf("SetIamPolicy(...): want not found error, got %v", err)
+	}
+}
+
+func TestPolicyStoreRejectSet(t *testing.T) {
+	s := NewPolicyStore(map[string]*storage.Policy{testBucket: {Bindings: []*storage.PolicyBindings{viewers}}})
+	defer s.Close()
+	s.RejectSet(testBucket, http.StatusForbidden)
+	c, err := s.Client(context.Background())
+	if err != nil {
+		t.Fatalf("Client(...): %s", err)
+	}
+	p, err := c.GetIamPolicy(testBucket).Context(context.Background()).Do()
+	if err != nil {
+		t.Fatalf("GetIamPolicy(...): %s", err)
+	}
+	p.Bindings = []*storage.PolicyBindings{admins}
+	if _, err := c.SetIamPolicy(testBucket, p).Context(context.Background()).Do(); gcp.ErrorCode(err) != http.StatusForbidden {
+		t.Errorf("SetIamPolicy(...): want forbidden error, got %v", err)
+	}
+	if diff := cmp.Diff(&storage.Policy{Bindings: []*storage.PolicyBindings{viewers}}, s.Policy(testBucket), ignoreServerFields); diff != "" {
+		t.Errorf("Policy(...): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucketpolicy
+
+import (
+	"context"
+	"sync"
+)
+
+// BucketLocks serialize the changes the resources binding roles in the IAM
+// policies of buckets make to the policy of each bucket, so that they do not
+// set the policy of a bucket along with the same etag and all but one of them
+// fail. Changes to the policies of distinct buckets are not serialized. A nil
+// BucketLocks serializes nothing.
+type BucketLocks struct {
+	mu    sync.Mutex
+	locks map[string]*bucketLock
+}
+
+// A bucketLock is held by whoever sent to it, and is removed from its
+// BucketLocks once no one holds or waits for it.
+type bucketLock struct {
+	held  chan struct{}
+	users int
+}
+
+// NewBucketLocks returns BucketLocks that no one holds.
+func NewBucketLocks() *BucketLocks {
+	return &BucketLocks{locks: map[string]*bucketLock{}}
+}
+
+// Lock the IAM policy of the supplied bucket, blocking until it is unlocked or
+// the supplied context is done. The returned function unlocks the policy and
+// must be called once the change to it is done, unless an error is returned.
+func (l *BucketLocks) Lock(ctx context.Context, bucket string) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	l.mu.Lock()
+	b, ok := l.locks[bucket]
+	if !ok {
+		b = &bucketLock{held: make(chan struct{}, 1)}
+		l.locks[bucket] = b
+	}
+	b.users++
+	l.mu.Unlock()
+
+	select {
+	case b.held <- struct{}{}:
+		return func() {
+			<-b.held
+			l.release(bucket, b)
+		}, nil
+	case <-ctx.Done():
+		l.release(bucket, b)
+		return nil, ctx.Err()
+	}
+}
+
+func (l *BucketLocks) release(bucket string, b *bucketLock) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b.users--
+	if b.users == 0 {
+		delete(l.locks, bucket)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bucketpolicy
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBucketLocksSerialize(t *testing.T) {
+	l := NewBucketLocks()
+
+	var (
+		mu       sync.Mutex
+		holders  int
+		maxHeld  int
+		acquired int
+	)
+	const n = 10
+	wg := &sync.WaitGroup{}
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := l.Lock(context.Background(), testBucket)
+			if err != nil {
+				t.Errorf("Lock(...): %s", err)
+				return
+			}
+			mu.Lock()
+			holders++
+			acquired++
+			if holders > maxHeld {
+				maxHeld = holders
+			}
+			mu.Unlock()
+
+			time.Sleep(time.Millisecond)
+
+			mu.Lock()
+			holders--
+			mu.Unlock()
+			unlock()
+		}()
+	}
+	wg.Wait()
+
+	if maxHeld != 1 {
+		t.Errorf("Lock(...): want the policy of a bucket to be held by 1 caller at a time, got %d", maxHeld)
+	}
+	if acquired != n {
+		t.Errorf("Lock(...): want %d callers to hold the lock eventually, got %d", n, acquired)
+	}
+	if len(l.locks) != 0 {
+		t.Errorf("Lock(...): want unused locks to be removed, got %d", len(l.locks))
+	}
+}
+
+func TestBucketLocksDistinctBuckets(t *testing.T) {
+	l := NewBucketLocks()
+	unlock, err := l.Lock(context.Background(), testBucket)
+	if err != nil {
+		t.Fatalf("Lock(...): %s", err)
+	}
+	defer unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	other, err := l.Lock(ctx, "other-bucket")
+	if err != nil {
+		t.Fatalf("Lock(...): want the policy of another bucket not to be locked, got %s", err)
+	}
+	other()
+}
+
+func TestBucketLocksContextDone(t *testing.T) {
+	l := NewBucketLocks()
+	unlock, err := l.Lock(context.Background(), testBucket)
+	if err != nil {
+		t.Fatalf("Lock(...): %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := l.Lock(ctx, testBucket); err != context.DeadlineExceeded {
+		t.Errorf("Lock(...): want %s waiting for a held lock, got %v", context.DeadlineExceeded, err)
+	}
+
+	unlock()
+	if len(l.locks) != 0 {
+		t.Errorf("Lock(...): want unused locks to be removed, got %d", len(l.locks))
+	}
+}
+
+func TestBucketLocksNil(t *testing.T) {
+	var l *BucketLocks
+	unlock, err := l.Lock(context.Background(), testBucket)
+	if err != nil {
+		t.Fatalf("Lock(...): %s", err)
+	}
+	unlock()
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bulkinstance
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+var placeholder = regexp.MustCompile(`#+`)
+
+// NameExpression returns a regular expression that matches the names of the
+// instances generated from the supplied name pattern, e.g. inst-[0-9]{4} for
+// a name pattern of inst-####.
+func NameExpression(pattern string) string {
+	loc := placeholder.FindStringIndex(pattern)
+	if loc == nil {
+		return regexp.QuoteMeta(pattern)
+	}
+	return regexp.QuoteMeta(pattern[:loc[0]]) +
+		fmt.Sprintf("[0-9]{%d}", loc[1]-loc[0]) +
+		regexp.QuoteMeta(pattern[loc[1]:])
+}
+
+// Filter returns a filter for listing the instances generated from the
+// supplied name pattern.
+func Filter(pattern string) string {
+	return fmt.Sprintf("name eq %q", NameExpression(pattern))
+}
+
+// InstanceNames returns the sorted names of the supplied instances that are
+// generated from the supplied name pattern.
+func InstanceNames(pattern string, instances []*compute.Instance) []string {
+	re := regexp.MustCompile("^" + NameExpression(pattern) + "$")
+	var names []string
+	for _, i := range instances {
+		if re.MatchString(i.Name) {
+			names = append(names, i.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GenerateBulkInsert returns a request to create the supplied number of
+// instances from the supplied parameters.
+func GenerateBulkInsert(in v1alpha1.BulkInstanceParameters, count int64) *compute.BulkInsertInstanceResource {
+	r := &compute.BulkInsertInstanceResource{
+		Count:                  count,
+		NamePattern:            in.NamePattern,
+		SourceInstanceTemplate: in.SourceInstanceTemplate,
+	}
+	// NOTE: The minimum applies to a single request, which may create fewer
+	// instances than the total desired when scaling up.
+	if min := gcp.Int64Value(in.MinCount); min > 0 {
+		r.MinCount = min
+		if min > count {
+			r.MinCount = count
+		}
+	}
+	return r
+}
+
+// ScaleDown returns the instances that must be deleted in order for only the
+// supplied number of instances to remain. The most recently numbered
+// instances are deleted first. The supplied names must be sorted.
+func ScaleDown(names []string, count int64) []string {
+	if count < 0 || int64(len(names)) <= count {
+		return nil
+	}
+	return names[count:]
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bulkinstance
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+)
+
+const (
+	testNamePattern = "inst-####"
+	testTemplate    = "global/instanceTemplates/some-template"
+)
+
+func TestNameExpression(t *testing.T) {
+	cases := map[string]struct {
+		pattern string
+		want    string
+	}{
+		"Suffix": {
+			pattern: testNamePattern,
+			want:    "inst-[0-9]{4}",
+		},
+		"Infix": {
+			pattern: "inst-##-web",
+			want:    "inst-[0-9]{2}-web",
+		},
+		"NoPlaceholder": {
+			pattern: "inst",
+			want:    "inst",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, NameExpression(tc.pattern)); diff != "" {
+				t.Errorf("NameExpression(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInstanceNames(t *testing.T) {
+	in := []*compute.Instance{
+		{Name: "inst-0002"},
+		{Name: "inst-0001"},
+		{Name: "inst-01"},
+		{Name: "other-0003"},
+		{Name: "inst-00042"},
+	}
+	want := []string{"inst-0001", "inst-0002"}
+	if diff := cmp.Diff(want, InstanceNames(testNamePattern, in)); diff != "" {
+		t.Errorf("InstanceNames(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestGenerateBulkInsert(t *testing.T) {
+	min := int64(3)
+
+	type args struct {
+		in    v1alpha1.BulkInstanceParameters
+		count int64
+	}
+	cases := map[string]struct {
+		args args
+		want *compute.BulkInsertInstanceResource
+	}{
+		"NoMinimum": {
+			args: args{
+				in:    v1alpha1.BulkInstanceParameters{NamePattern: testNamePattern, SourceInstanceTemplate: testTemplate, Count: 5},
+				count: 5,
+			},
+			want: &compute.BulkInsertInstanceResource{Count: 5, NamePattern: testNamePattern, SourceInstanceTemplate: testTemplate},
+		},
+		"Minimum": {
+			args: args{
+				in:    v1alpha1.BulkInstanceParameters{NamePattern: testNamePattern, SourceInstanceTemplate: testTemplate, Count: 5, MinCount: &min},
+				count: 5,
+			},
+			want: &compute.BulkInsertInstanceResource{Count: 5, MinCount: 3, NamePattern: testNamePattern, SourceInstanceTemplate: testTemplate},
+		},
+		"MinimumAboveCount": {
+			args: args{
+				in:    v1alpha1.BulkInstanceParameters{NamePattern: testNamePattern, SourceInstanceTemplate: testTemplate, Count: 5, MinCount: &min},
+				count: 2,
+			},
+			want: &compute.BulkInsertInstanceResource{Count: 2, MinCount: 2, NamePattern: testNamePattern, SourceInstanceTemplate: testTemplate},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, GenerateBulkInsert(tc.args.in, tc.args.count)); diff != "" {
+				t.Errorf("GenerateBulkInsert(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestScaleDown(t *testing.T) {
+	names := []string{"inst-0001", "inst-0002", "inst-0003"}
+
+	cases := map[string]struct {
+		count int64
+		want  []string
+	}{
+		"ScaleDown": {
+			count: 1,
+			want:  []string{"inst-0002", "inst-0003"},
+		},
+		"ScaleToZero": {
+			count: 0,
+			want:  names,
+		},
+		"NothingToDelete": {
+			count: 3,
+		},
+		"ScaleUp": {
+			count: 5,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, ScaleDown(names, tc.count)); diff != "" {
+				t.Errorf("ScaleDown(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacitycommitment
+
+import (
+	"strings"
+	"time"
+
+	"google.golang.org/api/bigqueryreservation/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	"github.com/crossplane/provider-gcp/apis/bigquery/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// Error strings.
+const (
+	errChangeSlotCount = "the slot count of a capacity commitment cannot be changed; split or merge commitments instead"
+	errDowngradePlan   = "the plan of a capacity commitment can only be changed to a plan with a longer committed period"
+	errFmtCommitted    = "a capacity commitment cannot be deleted before its committed period ends at %s"
+)
+
+// planRanks orders the commitment plans by the length of their committed
+// period. A commitment can only be converted to a plan of a higher rank.
+var planRanks = map[string]int{
+	v1alpha1.CommitmentPlanTrial:   0,
+	v1alpha1.CommitmentPlanFlex:    1,
+	v1alpha1.CommitmentPlanMonthly: 2,
+	v1alpha1.CommitmentPlanAnnual:  3,
+}
+
+// Client should be satisfied to conduct CapacityCommitment operations.
+type Client interface {
+	Create(parent string, capacitycommitment *bigqueryreservation.CapacityCommitment) *bigqueryreservation.ProjectsLocationsCapacityCommitmentsCreateCall
+	Get(name string) *bigqueryreservation.ProjectsLocationsCapacityCommitmentsGetCall
+	Patch(name string, capacitycommitment *bigqueryreservation.CapacityCommitment) *bigqueryreservation.ProjectsLocationsCapacityCommitmentsPatchCall
+	Delete(name string) *bigqueryreservation.ProjectsLocationsCapacityCommitmentsDeleteCall
+}
+
+// GenerateCapacityCommitment generates *bigqueryreservation.CapacityCommitment
+// instance from CapacityCommitmentParameters.
+func GenerateCapacityCommitment(in v1alpha1.CapacityCommitmentParameters, c *bigqueryreservation.CapacityCommitment) {
+	c.SlotCount = in.SlotCount
+	c.Plan = in.Plan
+	c.RenewalPlan = gcp.StringValue(in.RenewalPlan)
+}
+
+// GenerateCapacityCommitmentObservation produces
+// CapacityCommitmentObservation object from
+// bigqueryreservation.CapacityCommitment object.
+func GenerateCapacityCommitmentObservation(in bigqueryreservation.CapacityCommitment) v1alpha1.CapacityCommitmentObservation {
+	o := v1alpha1.CapacityCommitmentObservation{
+		CommitmentEndTime:   in.CommitmentEndTime,
+		CommitmentStartTime: in.CommitmentStartTime,
+		Name:                in.Name,
+		State:               in.State,
+	}
+	if in.FailureStatus != nil {
+		o.FailureReason = in.FailureStatus.Message
+	}
+	return o
+}
+
+// LateInitializeCapacityCommitment fills unassigned fields with the values in
+// bigqueryreservation.CapacityCommitment object.
+func LateInitializeCapacityCommitment(spec *v1alpha1.CapacityCommitmentParameters, in bigqueryreservation.CapacityCommitment) {
+	spec.RenewalPlan = gcp.LateInitializeString(spec.RenewalPlan, in.RenewalPlan)
+}
+
+// IsCapacityCommitmentUpToDate checks whether current state is up-to-date
+// compared to the given set of parameters. It returns the update mask of the
+// fields that need to be patched. The slot count is compared too, but can not
+// be patched.
+func IsCapacityCommitmentUpToDate(in v1alpha1.CapacityCommitmentParameters, observed *bigqueryreservation.CapacityCommitment) (bool, string) {
+	um := make([]string, 0, 2)
+	if in.Plan != observed.Plan {
+		um = append(um, "plan")
+	}
+	if in.RenewalPlan != nil && *in.RenewalPlan != observed.RenewalPlan {
+		um = append(um, "renewalPlan")
+	}
+	return len(um) == 0 && in.SlotCount == observed.SlotCount, strings.Join(um, ",")
+}
+
+// ValidateCapacityCommitmentUpdate returns an error if the supplied
+// parameters can not be applied to the observed commitment, i.e. if they
+// would change its slot count or convert it to a plan with a shorter
+// committed period.
+func ValidateCapacityCommitmentUpdate(in v1alpha1.CapacityCommitmentParameters, observed *bigqueryreservation.CapacityCommitment) error {
+	if in.SlotCount != observed.SlotCount {
+		return errors.New(errChangeSlotCount)
+	}
+	if planRanks[in.Plan] < planRanks[observed.Plan] {
+		return errors.New(errDowngradePlan)
+	}
+	return nil
+}
+
+// ValidateCapacityCommitmentDeletion returns an error if the observed
+// commitment can not be deleted at the supplied time because its committed
+// period has not ended yet.
+func ValidateCapacityCommitmentDeletion(observed *bigqueryreservation.CapacityCommitment, now time.Time) error {
+	if observed.CommitmentEndTime == "" {
+		return nil
+	}
+	end, err := time.Parse(time.RFC3339, observed.CommitmentEndTime)
+	if err != nil || !now.Before(end) {
+		return nil
+	}
+	return errors.Errorf(errFmtCommitted, observed.CommitmentEndTime)
+}
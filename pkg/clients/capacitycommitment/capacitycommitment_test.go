@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capacitycommitment
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/bigqueryreservation/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/bigquery/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+func TestGenerateCapacityCommitmentObservation(t *testing.T) {
+	in := bigqueryreservation.CapacityCommitment{
+		Name:          "projects/p/locations/US/capacityCommitments/c",
+		State:         "FAILED",
+		FailureStatus: &bigqueryreservation.Status{Message: "not enough quota"},
+	}
+	want := v1alpha1.CapacityCommitmentObservation{
+		Name:          "projects/p/locations/US/capacityCommitments/c",
+		State:         "FAILED",
+		FailureReason: "not enough quota",
+	}
+	if diff := cmp.Diff(want, GenerateCapacityCommitmentObservation(in)); diff != "" {
+		t.Errorf("GenerateCapacityCommitmentObservation(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestIsCapacityCommitmentUpToDate(t *testing.T) {
+	type args struct {
+		in       v1alpha1.CapacityCommitmentParameters
+		observed *bigqueryreservation.CapacityCommitment
+	}
+	type want struct {
+		upToDate bool
+		mask     string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"UpToDate": {
+			args: args{
+				in:       v1alpha1.CapacityCommitmentParameters{SlotCount: 100, Plan: v1alpha1.CommitmentPlanAnnual, RenewalPlan: gcp.StringPtr(v1alpha1.CommitmentPlanFlex)},
+				observed: &bigqueryreservation.CapacityCommitment{SlotCount: 100, Plan: v1alpha1.CommitmentPlanAnnual, RenewalPlan: v1alpha1.CommitmentPlanFlex},
+			},
+			want: want{upToDate: true},
+		},
+		"SlotsDiffer": {
+			args: args{
+				in:       v1alpha1.CapacityCommitmentParameters{SlotCount: 200, Plan: v1alpha1.CommitmentPlanFlex},
+				observed: &bigqueryreservation.CapacityCommitment{SlotCount: 100, Plan: v1alpha1.CommitmentPlanFlex},
+			},
+			want: want{upToDate: false},
+		},
+		"RenewalPlanDiffers": {
+			args: args{
+				in:       v1alpha1.CapacityCommitmentParameters{SlotCount: 100, Plan: v1alpha1.CommitmentPlanAnnual, RenewalPlan: gcp.StringPtr(v1alpha1.CommitmentPlanAnnual)},
+				observed: &bigqueryreservation.CapacityCommitment{SlotCount: 100, Plan: v1alpha1.CommitmentPlanAnnual, RenewalPlan: v1alpha1.CommitmentPlanFlex},
+			},
+			want: want{upToDate: false, mask: "renewalPlan"},
+		},
+		"PlansDiffer": {
+			args: args{
+				in:       v1alpha1.CapacityCommitmentParameters{SlotCount: 100, Plan: v1alpha1.CommitmentPlanAnnual, RenewalPlan: gcp.StringPtr(v1alpha1.CommitmentPlanAnnual)},
+				observed: &bigqueryreservation.CapacityCommitment{SlotCount: 100, Plan: v1alpha1.CommitmentPlanFlex},
+			},
+			want: want{upToDate: false, mask: "plan,renewalPlan"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			u, um := IsCapacityCommitmentUpToDate(tc.args.in, tc.args.observed)
+			if diff := cmp.Diff(tc.want, want{upToDate: u, mask: um}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("IsCapacityCommitmentUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateCapacityCommitmentUpdate(t *testing.T) {
+	type args struct {
+		in       v1alpha1.CapacityCommitmentParameters
+		observed *bigqueryreservation.CapacityCommitment
+	}
+	cases := map[string]struct {
+		args args
+		want error
+	}{
+		"Upgrade": {
+			args: args{
+				in:       v1alpha1.CapacityCommitmentParameters{SlotCount: 100, Plan: v1alpha1.CommitmentPlanAnnual},
+				observed: &bigqueryreservation.CapacityCommitment{SlotCount: 100, Plan: v1alpha1.CommitmentPlanFlex},
+			},
+		},
+		"UpgradeTrial": {
+			args: args{
+				in:       v1alpha1.CapacityCommitmentParameters{SlotCount: 100, Plan: v1alpha1.CommitmentPlanMonthly},
+				observed: &bigqueryreservation.CapacityCommitment{SlotCount: 100, Plan: v1alpha1.CommitmentPlanTrial},
+			},
+		},
+		"Downgrade": {
+			args: args{
+				in:       v1alpha1.CapacityCommitmentParameters{SlotCount: 100, Plan: v1alpha1.CommitmentPlanFlex},
+				observed: &bigqueryreservation.CapacityCommitment{SlotCount: 100, Plan: v1alpha1.CommitmentPlanMonthly},
+			},
+			want: errors.New(errDowngradePlan),
+		},
+		"SlotsChanged": {
+			args: args{
+				in:       v1alpha1.CapacityCommitmentParameters{SlotCount: 200, Plan: v1alpha1.CommitmentPlanFlex},
+				observed: &bigqueryreservation.CapacityCommitment{SlotCount: 100, Plan: v1alpha1.CommitmentPlanFlex},
+			},
+			want: errors.New(errChangeSlotCount),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateCapacityCommitmentUpdate(tc.args.in, tc.args.observed)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateCapacityCommitmentUpdate(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateCapacityCommitmentDeletion(t *testing.T) {
+	now := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		observed *bigqueryreservation.CapacityCommitment
+		want     error
+	}{
+		"NotActive": {
+			observed: &bigqueryreservation.CapacityCommitment{State: "PENDING"},
+		},
+		"Committed": {
+			observed: &bigqueryreservation.CapacityCommitment{CommitmentEndTime: "2021-10-31T12:00:00.123Z"},
+			want:     errors.New(fmt.Sprintf(errFmtCommitted, "2021-10-31T12:00:00.123Z")),
+		},
+		"CommittedPeriodEnded": {
+			observed: &bigqueryreservation.CapacityCommitment{CommitmentEndTime: "2021-10-01T11:59:00Z"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateCapacityCommitmentDeletion(tc.observed, now)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateCapacityCommitmentDeletion(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package cloudsql
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
@@ -128,6 +129,15 @@ func GenerateDatabaseInstance(name string, in v1beta1.CloudSQLInstanceParameters
 		db.Settings.MaintenanceWindow.Hour = gcp.Int64Value(in.Settings.MaintenanceWindow.Hour)
 		db.Settings.MaintenanceWindow.UpdateTrack = gcp.StringValue(in.Settings.MaintenanceWindow.UpdateTrack)
 	}
+	if in.Settings.InsightsConfig != nil {
+		if db.Settings.InsightsConfig == nil {
+			db.Settings.InsightsConfig = &sqladmin.InsightsConfig{}
+		}
+		db.Settings.InsightsConfig.QueryInsightsEnabled = gcp.BoolValue(in.Settings.InsightsConfig.QueryInsightsEnabled)
+		db.Settings.InsightsConfig.QueryStringLength = gcp.Int64Value(in.Settings.InsightsConfig.QueryStringLength)
+		db.Settings.InsightsConfig.RecordApplicationTags = gcp.BoolValue(in.Settings.InsightsConfig.RecordApplicationTags)
+		db.Settings.InsightsConfig.ForceSendFields = []string{"QueryInsightsEnabled", "RecordApplicationTags"}
+	}
 	if len(in.Settings.DatabaseFlags) > 0 {
 		db.Settings.DatabaseFlags = make([]*sqladmin.DatabaseFlags, len(in.Settings.DatabaseFlags))
 	}
@@ -278,6 +288,14 @@ func LateInitializeSpec(spec *v1beta1.CloudSQLInstanceParameters, in sqladmin.Da
 			spec.Settings.MaintenanceWindow.Day = gcp.LateInitializeInt64(spec.Settings.MaintenanceWindow.Day, in.Settings.MaintenanceWindow.Day)
 			spec.Settings.MaintenanceWindow.Hour = gcp.LateInitializeInt64(spec.Settings.MaintenanceWindow.Hour, in.Settings.MaintenanceWindow.Hour)
 		}
+		if in.Settings.InsightsConfig != nil {
+			if spec.Settings.InsightsConfig == nil {
+				spec.Settings.InsightsConfig = &v1beta1.InsightsConfig{}
+			}
+			spec.Settings.InsightsConfig.QueryInsightsEnabled = gcp.LateInitializeBool(spec.Settings.InsightsConfig.QueryInsightsEnabled, in.Settings.InsightsConfig.QueryInsightsEnabled)
+			spec.Settings.InsightsConfig.QueryStringLength = gcp.LateInitializeInt64(spec.Settings.InsightsConfig.QueryStringLength, in.Settings.InsightsConfig.QueryStringLength)
+			spec.Settings.InsightsConfig.RecordApplicationTags = gcp.LateInitializeBool(spec.Settings.InsightsConfig.RecordApplicationTags, in.Settings.InsightsConfig.RecordApplicationTags)
+		}
 	}
 	if in.DiskEncryptionConfiguration != nil {
 		if spec.DiskEncryptionConfiguration == nil {
@@ -315,7 +333,79 @@ func IsUpToDate(name string, in *v1beta1.CloudSQLInstanceParameters, observed *s
 		return true, errors.New(errCheckUpToDate)
 	}
 	GenerateDatabaseInstance(name, *in, desired)
-	return cmp.Equal(desired, observed, cmpopts.EquateEmpty(), cmpopts.IgnoreFields(sqladmin.DatabaseInstance{}, "Settings.IpConfiguration.ForceSendFields")), nil
+	var flags []*sqladmin.DatabaseFlags
+	if observed.Settings != nil {
+		flags = observed.Settings.DatabaseFlags
+	}
+	// NOTE: Database flags are compared by name since their order is not
+	// significant.
+	if len(DatabaseFlagChanges(in.Settings.DatabaseFlags, flags)) != 0 {
+		return false, nil
+	}
+	return cmp.Equal(desired, observed, cmpopts.EquateEmpty(), cmpopts.IgnoreFields(sqladmin.DatabaseInstance{},
+		"Settings.IpConfiguration.ForceSendFields",
+		"Settings.InsightsConfig.ForceSendFields",
+		"Settings.DatabaseFlags")), nil
+}
+
+// DatabaseFlagChanges returns the sorted names of the observed database flags
+// that are added, changed or removed by the supplied ones.
+func DatabaseFlagChanges(in []*v1beta1.DatabaseFlags, observed []*sqladmin.DatabaseFlags) []string {
+	desired := make(map[string]string, len(in))
+	for _, f := range in {
+		desired[f.Name] = f.Value
+	}
+	current := make(map[string]string, len(observed))
+	for _, f := range observed {
+		current[f.Name] = f.Value
+	}
+	var changed []string
+	for k, v := range desired {
+		if cv, ok := current[k]; !ok || cv != v {
+			changed = append(changed, k)
+		}
+	}
+	for k := range current {
+		if _, ok := desired[k]; !ok {
+			changed = append(changed, k)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// RestartRequiredFlags returns the names of the supplied database flags that
+// restart the instance when they are changed.
+func RestartRequiredFlags(names []string, flags []*sqladmin.Flag) []string {
+	restart := map[string]bool{}
+	for _, f := range flags {
+		restart[f.Name] = f.RequiresRestart
+	}
+	var r []string
+	for _, n := range names {
+		if restart[n] {
+			r = append(r, n)
+		}
+	}
+	return r
+}
+
+// GenerateLabelRemovals sets the user labels of the observed instance that are
+// not in the supplied patch to null. The CloudSQL API merges the user labels of
+// a patch into the existing ones, so labels are only removed when explicitly
+// set to null.
+func GenerateLabelRemovals(observed, patch *sqladmin.DatabaseInstance) {
+	if observed.Settings == nil || patch.Settings == nil {
+		return
+	}
+	removed := gcp.RemovedLabels(patch.Settings.UserLabels, observed.Settings.UserLabels)
+	if len(removed) == 0 {
+		return
+	}
+	patch.Settings.ForceSendFields = append(patch.Settings.ForceSendFields, "UserLabels")
+	for _, k := range removed {
+		patch.Settings.NullFields = append(patch.Settings.NullFields, "UserLabels."+k)
+	}
 }
 
 // DatabaseUserName returns default database user name base on database version
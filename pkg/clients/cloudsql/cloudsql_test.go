@@ -80,6 +80,11 @@ func params(m ...func(*v1beta1.CloudSQLInstanceParameters)) *v1beta1.CloudSQLIns
 			DataDiskSizeGb:             gcp.Int64Ptr(2),
 			DatabaseReplicationEnabled: gcp.BoolPtr(true),
 			StorageAutoResizeLimit:     gcp.Int64Ptr(3),
+			InsightsConfig: &v1beta1.InsightsConfig{
+				QueryInsightsEnabled:  gcp.BoolPtr(true),
+				QueryStringLength:     gcp.Int64Ptr(1024),
+				RecordApplicationTags: gcp.BoolPtr(false),
+			},
 		},
 		DatabaseVersion:    gcp.StringPtr("3.2"),
 		MasterInstanceName: gcp.StringPtr("myFunnyMaster"),
@@ -189,6 +194,12 @@ func db(m ...func(*sqladmin.DatabaseInstance)) *sqladmin.DatabaseInstance {
 			DataDiskSizeGb:             2,
 			DatabaseReplicationEnabled: true,
 			StorageAutoResizeLimit:     3,
+			InsightsConfig: &sqladmin.InsightsConfig{
+				QueryInsightsEnabled:  true,
+				QueryStringLength:     1024,
+				RecordApplicationTags: false,
+				ForceSendFields:       []string{"QueryInsightsEnabled", "RecordApplicationTags"},
+			},
 		},
 		DatabaseVersion:    "3.2",
 		MasterInstanceName: "myFunnyMaster",
@@ -488,6 +499,53 @@ func TestIsUpToDate(t *testing.T) {
 			},
 			want: want{upToDate: false, isErr: false},
 		},
+		"FlagsInDifferentOrder": {
+			args: args{
+				params: params(func(p *v1beta1.CloudSQLInstanceParameters) {
+					p.Settings.DatabaseFlags = []*v1beta1.DatabaseFlags{{Name: "run", Value: "forest"}, {Name: "max_connections", Value: "100"}}
+				}),
+				db: db(func(db *sqladmin.DatabaseInstance) {
+					db.Settings.DatabaseFlags = []*sqladmin.DatabaseFlags{{Name: "max_connections", Value: "100"}, {Name: "run", Value: "forest"}}
+				}),
+			},
+			want: want{upToDate: true, isErr: false},
+		},
+		"FlagAdded": {
+			args: args{
+				params: params(func(p *v1beta1.CloudSQLInstanceParameters) {
+					p.Settings.DatabaseFlags = append(p.Settings.DatabaseFlags, &v1beta1.DatabaseFlags{Name: "max_connections", Value: "100"})
+				}),
+				db: db(),
+			},
+			want: want{upToDate: false, isErr: false},
+		},
+		"FlagChanged": {
+			args: args{
+				params: params(func(p *v1beta1.CloudSQLInstanceParameters) {
+					p.Settings.DatabaseFlags = []*v1beta1.DatabaseFlags{{Name: "run", Value: "away"}}
+				}),
+				db: db(),
+			},
+			want: want{upToDate: false, isErr: false},
+		},
+		"FlagRemoved": {
+			args: args{
+				params: params(),
+				db: db(func(db *sqladmin.DatabaseInstance) {
+					db.Settings.DatabaseFlags = append(db.Settings.DatabaseFlags, &sqladmin.DatabaseFlags{Name: "max_connections", Value: "100"})
+				}),
+			},
+			want: want{upToDate: false, isErr: false},
+		},
+		"InsightsConfigChanged": {
+			args: args{
+				params: params(func(p *v1beta1.CloudSQLInstanceParameters) {
+					p.Settings.InsightsConfig.RecordApplicationTags = gcp.BoolPtr(true)
+				}),
+				db: db(),
+			},
+			want: want{upToDate: false, isErr: false},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -501,3 +559,87 @@ func TestIsUpToDate(t *testing.T) {
 		})
 	}
 }
+
+func TestDatabaseFlagChanges(t *testing.T) {
+	type args struct {
+		in       []*v1beta1.DatabaseFlags
+		observed []*sqladmin.DatabaseFlags
+	}
+	cases := map[string]struct {
+		args args
+		want []string
+	}{
+		"NoChanges": {
+			args: args{
+				in:       []*v1beta1.DatabaseFlags{{Name: "run", Value: "forest"}, {Name: "max_connections", Value: "100"}},
+				observed: []*sqladmin.DatabaseFlags{{Name: "max_connections", Value: "100"}, {Name: "run", Value: "forest"}},
+			},
+		},
+		"Added": {
+			args: args{
+				in:       []*v1beta1.DatabaseFlags{{Name: "run", Value: "forest"}, {Name: "max_connections", Value: "100"}},
+				observed: []*sqladmin.DatabaseFlags{{Name: "run", Value: "forest"}},
+			},
+			want: []string{"max_connections"},
+		},
+		"Changed": {
+			args: args{
+				in:       []*v1beta1.DatabaseFlags{{Name: "run", Value: "away"}},
+				observed: []*sqladmin.DatabaseFlags{{Name: "run", Value: "forest"}},
+			},
+			want: []string{"run"},
+		},
+		"Removed": {
+			args: args{
+				in:       []*v1beta1.DatabaseFlags{{Name: "run", Value: "forest"}},
+				observed: []*sqladmin.DatabaseFlags{{Name: "run", Value: "forest"}, {Name: "max_connections", Value: "100"}},
+			},
+			want: []string{"max_connections"},
+		},
+		"AddedChangedAndRemoved": {
+			args: args{
+				in:       []*v1beta1.DatabaseFlags{{Name: "run", Value: "away"}, {Name: "max_connections", Value: "100"}},
+				observed: []*sqladmin.DatabaseFlags{{Name: "run", Value: "forest"}, {Name: "cloudsql.iam_authentication", Value: "on"}},
+			},
+			want: []string{"cloudsql.iam_authentication", "max_connections", "run"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := DatabaseFlagChanges(tc.args.in, tc.args.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("DatabaseFlagChanges(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRestartRequiredFlags(t *testing.T) {
+	flags := []*sqladmin.Flag{
+		{Name: "max_connections", RequiresRestart: true},
+		{Name: "run"},
+	}
+	cases := map[string]struct {
+		names []string
+		want  []string
+	}{
+		"NoRestart": {
+			names: []string{"run"},
+		},
+		"Restart": {
+			names: []string{"max_connections", "run"},
+			want:  []string{"max_connections"},
+		},
+		"UnknownFlag": {
+			names: []string{"unknown"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := RestartRequiredFlags(tc.names, flags)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("RestartRequiredFlags(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
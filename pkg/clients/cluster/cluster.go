@@ -20,6 +20,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"strings"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -51,8 +52,28 @@ const (
 const (
 	errNoSecretInfo  = "missing secret information for GKE cluster"
 	errCheckUpToDate = "unable to determine if external resource is up to date"
+
+	errMaintenanceWindows = "only one of a daily or a recurring maintenance window can be specified"
+	errRecurrencePart     = "recurrence rule part %q must be of the form NAME=VALUE"
+	errRecurrenceName     = "unknown recurrence rule part %q"
+	errRecurrenceFreq     = "recurrence rule must specify a FREQ of DAILY, WEEKLY, MONTHLY or YEARLY"
 )
 
+// recurrenceRuleParts are the parts of an RRULE as defined by
+// https://tools.ietf.org/html/rfc5545#section-3.3.10
+var recurrenceRuleParts = map[string]bool{
+	"FREQ": true, "UNTIL": true, "COUNT": true, "INTERVAL": true,
+	"BYSECOND": true, "BYMINUTE": true, "BYHOUR": true, "BYDAY": true,
+	"BYMONTHDAY": true, "BYYEARDAY": true, "BYWEEKNO": true, "BYMONTH": true,
+	"BYSETPOS": true, "WKST": true,
+}
+
+// recurrenceFrequencies are the frequencies GKE supports for recurring
+// maintenance windows.
+var recurrenceFrequencies = map[string]bool{
+	"DAILY": true, "WEEKLY": true, "MONTHLY": true, "YEARLY": true,
+}
+
 // AddNodePoolForCreate inserts the default node pool into *container.Cluster so
 // that it can be provisioned successfully.
 func AddNodePoolForCreate(in *container.Cluster) {
@@ -204,6 +225,7 @@ func GenerateAutoscaling(in *v1beta2.ClusterAutoscaling, cluster *container.Clus
 			}
 			cluster.Autoscaling.AutoprovisioningNodePoolDefaults.BootDiskKmsKey = gcp.StringValue(in.AutoprovisioningNodePoolDefaults.BootDiskKMSKey)
 			cluster.Autoscaling.AutoprovisioningNodePoolDefaults.DiskSizeGb = gcp.Int64Value(in.AutoprovisioningNodePoolDefaults.DiskSizeGb)
+			cluster.Autoscaling.AutoprovisioningNodePoolDefaults.DiskType = gcp.StringValue(in.AutoprovisioningNodePoolDefaults.DiskType)
 			cluster.Autoscaling.AutoprovisioningNodePoolDefaults.MinCpuPlatform = gcp.StringValue(in.AutoprovisioningNodePoolDefaults.MinCPUPlatform)
 			cluster.Autoscaling.AutoprovisioningNodePoolDefaults.OauthScopes = in.AutoprovisioningNodePoolDefaults.OauthScopes
 			cluster.Autoscaling.AutoprovisioningNodePoolDefaults.ServiceAccount = gcp.StringValue(in.AutoprovisioningNodePoolDefaults.ServiceAccount)
@@ -324,7 +346,10 @@ func GenerateMaintenancePolicy(in *v1beta2.MaintenancePolicySpec, cluster *conta
 		if cluster.MaintenancePolicy.Window == nil {
 			cluster.MaintenancePolicy.Window = &container.MaintenanceWindow{}
 		}
+		// NOTE: A daily and a recurring window are mutually exclusive, so
+		// specifying one replaces the other.
 		if in.Window.DailyMaintenanceWindow != nil {
+			cluster.MaintenancePolicy.Window.RecurringWindow = nil
 			if cluster.MaintenancePolicy.Window.DailyMaintenanceWindow == nil {
 				cluster.MaintenancePolicy.Window.DailyMaintenanceWindow = &container.DailyMaintenanceWindow{}
 			}
@@ -340,6 +365,7 @@ func GenerateMaintenancePolicy(in *v1beta2.MaintenancePolicySpec, cluster *conta
 			}
 		}
 		if in.Window.RecurringWindow != nil {
+			cluster.MaintenancePolicy.Window.DailyMaintenanceWindow = nil
 			if cluster.MaintenancePolicy.Window.RecurringWindow == nil {
 				cluster.MaintenancePolicy.Window.RecurringWindow = &container.RecurringTimeWindow{}
 			}
@@ -356,6 +382,43 @@ func GenerateMaintenancePolicy(in *v1beta2.MaintenancePolicySpec, cluster *conta
 	}
 }
 
+// ValidateMaintenancePolicy returns an error if the supplied maintenance
+// policy would be rejected by GKE.
+func ValidateMaintenancePolicy(in *v1beta2.MaintenancePolicySpec) error {
+	if in == nil {
+		return nil
+	}
+	if in.Window.DailyMaintenanceWindow != nil && in.Window.RecurringWindow != nil {
+		return errors.New(errMaintenanceWindows)
+	}
+	if in.Window.RecurringWindow == nil || in.Window.RecurringWindow.Recurrence == nil {
+		return nil
+	}
+	return ValidateRecurrence(*in.Window.RecurringWindow.Recurrence)
+}
+
+// ValidateRecurrence returns an error if the supplied RRULE is malformed, or
+// uses a frequency GKE does not support for maintenance windows.
+func ValidateRecurrence(rrule string) error {
+	freq := ""
+	for _, part := range strings.Split(rrule, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return errors.Errorf(errRecurrencePart, part)
+		}
+		if !recurrenceRuleParts[kv[0]] {
+			return errors.Errorf(errRecurrenceName, kv[0])
+		}
+		if kv[0] == "FREQ" {
+			freq = kv[1]
+		}
+	}
+	if !recurrenceFrequencies[freq] {
+		return errors.New(errRecurrenceFreq)
+	}
+	return nil
+}
+
 // GenerateMasterAuth generates *container.MasterAuth from *MasterAuth.
 func GenerateMasterAuth(in *v1beta2.MasterAuth, cluster *container.Cluster) {
 	if in != nil {
@@ -1060,10 +1123,15 @@ func newLoggingServiceUpdateFn(in *string) UpdateFn {
 }
 
 // newMaintenancePolicyUpdateFn returns a function that updates the MaintenancePolicy of a cluster.
-func newMaintenancePolicyUpdateFn(in *v1beta2.MaintenancePolicySpec) UpdateFn {
+func newMaintenancePolicyUpdateFn(in *v1beta2.MaintenancePolicySpec, observed *container.MaintenancePolicy) UpdateFn {
 	return func(ctx context.Context, s *container.Service, name string) (*container.Operation, error) {
 		out := &container.Cluster{}
 		GenerateMaintenancePolicy(in, out)
+		// GKE rejects updates to a policy that was changed since the
+		// supplied resource version was observed.
+		if out.MaintenancePolicy != nil && observed != nil {
+			out.MaintenancePolicy.ResourceVersion = observed.ResourceVersion
+		}
 		update := &container.SetMaintenancePolicyRequest{
 			MaintenancePolicy: out.MaintenancePolicy,
 		}
@@ -1289,7 +1357,10 @@ func IsUpToDate(name string, in *v1beta2.ClusterParameters, observed *container.
 	if !cmp.Equal(desired.Autopilot, observed.Autopilot, cmpopts.EquateEmpty()) {
 		return false, newAutopilotUpdateFn(in.Autopilot), nil
 	}
-	if !cmp.Equal(desired.Autoscaling, observed.Autoscaling, cmpopts.EquateEmpty()) {
+	// NOTE: Resource limits are a set keyed by resource type, so the order
+	// in which GKE returns them must not trigger an update.
+	if !cmp.Equal(desired.Autoscaling, observed.Autoscaling, cmpopts.EquateEmpty(),
+		cmpopts.SortSlices(func(a, b *container.ResourceLimit) bool { return a.ResourceType < b.ResourceType })) {
 		return false, newAutoscalingUpdateFn(in.Autoscaling), nil
 	}
 	if !cmp.Equal(desired.BinaryAuthorization, observed.BinaryAuthorization, cmpopts.EquateEmpty()) {
@@ -1308,7 +1379,7 @@ func IsUpToDate(name string, in *v1beta2.ClusterParameters, observed *container.
 		return false, newLoggingServiceUpdateFn(in.LoggingService), nil
 	}
 	if !cmp.Equal(desired.MaintenancePolicy, observed.MaintenancePolicy, cmpopts.EquateEmpty()) {
-		return false, newMaintenancePolicyUpdateFn(in.MaintenancePolicy), nil
+		return false, newMaintenancePolicyUpdateFn(in.MaintenancePolicy, observed.MaintenancePolicy), nil
 	}
 	if !cmp.Equal(desired.MasterAuthorizedNetworksConfig, observed.MasterAuthorizedNetworksConfig, cmpopts.EquateEmpty()) {
 		return false, newMasterAuthorizedNetworksConfigUpdateFn(in.MasterAuthorizedNetworksConfig), nil
@@ -476,6 +476,47 @@ func TestGenerateAutoscaling(t *testing.T) {
 				}
 			}),
 		},
+		"SuccessfulWithNodePoolDefaults": {
+			args: args{
+				cluster: cluster(),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.Autoscaling = &v1beta2.ClusterAutoscaling{
+						EnableNodeAutoprovisioning: gcp.BoolPtr(true),
+						AutoprovisioningNodePoolDefaults: &v1beta2.AutoprovisioningNodePoolDefaults{
+							DiskSizeGb:     gcp.Int64Ptr(50),
+							DiskType:       gcp.StringPtr("pd-ssd"),
+							ServiceAccount: gcp.StringPtr("nap@cool-project.iam.gserviceaccount.com"),
+							Management: &v1beta2.NodeManagement{
+								AutoRepair:  gcp.BoolPtr(true),
+								AutoUpgrade: gcp.BoolPtr(true),
+							},
+							ShieldedInstanceConfig: &v1beta2.ShieldedInstanceConfig{
+								EnableIntegrityMonitoring: gcp.BoolPtr(true),
+								EnableSecureBoot:          gcp.BoolPtr(true),
+							},
+						},
+					}
+				}),
+			},
+			want: cluster(func(c *container.Cluster) {
+				c.Autoscaling = &container.ClusterAutoscaling{
+					EnableNodeAutoprovisioning: true,
+					AutoprovisioningNodePoolDefaults: &container.AutoprovisioningNodePoolDefaults{
+						DiskSizeGb:     50,
+						DiskType:       "pd-ssd",
+						ServiceAccount: "nap@cool-project.iam.gserviceaccount.com",
+						Management: &container.NodeManagement{
+							AutoRepair:  true,
+							AutoUpgrade: true,
+						},
+						ShieldedInstanceConfig: &container.ShieldedInstanceConfig{
+							EnableIntegrityMonitoring: true,
+							EnableSecureBoot:          true,
+						},
+					},
+				}
+			}),
+		},
 		"SuccessfulNil": {
 			args: args{
 				cluster: cluster(),
@@ -848,6 +889,47 @@ func TestGenerateMaintenancePolicy(t *testing.T) {
 				}
 			}),
 		},
+		"RecurringWindowReplacesDailyWindow": {
+			args: args{
+				cluster: cluster(func(c *container.Cluster) {
+					c.MaintenancePolicy = &container.MaintenancePolicy{
+						ResourceVersion: "abc",
+						Window: &container.MaintenanceWindow{
+							DailyMaintenanceWindow: &container.DailyMaintenanceWindow{
+								StartTime: "13:13",
+							},
+						},
+					}
+				}),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.MaintenancePolicy = &v1beta2.MaintenancePolicySpec{
+						Window: v1beta2.MaintenanceWindowSpec{
+							RecurringWindow: &v1beta2.RecurringTimeWindow{
+								Recurrence: gcp.StringPtr("FREQ=WEEKLY;BYDAY=SA"),
+								Window: &v1beta2.TimeWindow{
+									StartTime: "2021-01-02T00:00:00Z",
+									EndTime:   "2021-01-02T06:00:00Z",
+								},
+							},
+						},
+					}
+				}),
+			},
+			want: cluster(func(c *container.Cluster) {
+				c.MaintenancePolicy = &container.MaintenancePolicy{
+					ResourceVersion: "abc",
+					Window: &container.MaintenanceWindow{
+						RecurringWindow: &container.RecurringTimeWindow{
+							Recurrence: "FREQ=WEEKLY;BYDAY=SA",
+							Window: &container.TimeWindow{
+								StartTime: "2021-01-02T00:00:00Z",
+								EndTime:   "2021-01-02T06:00:00Z",
+							},
+						},
+					},
+				}
+			}),
+		},
 		"SuccessfulNil": {
 			args: args{
 				cluster: cluster(),
@@ -866,6 +948,66 @@ func TestGenerateMaintenancePolicy(t *testing.T) {
 	}
 }
 
+func TestValidateMaintenancePolicy(t *testing.T) {
+	recurring := func(rrule string) *v1beta2.MaintenancePolicySpec {
+		return &v1beta2.MaintenancePolicySpec{
+			Window: v1beta2.MaintenanceWindowSpec{
+				RecurringWindow: &v1beta2.RecurringTimeWindow{Recurrence: &rrule},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		in   *v1beta2.MaintenancePolicySpec
+		want error
+	}{
+		"Nil": {},
+		"DailyWindow": {
+			in: &v1beta2.MaintenancePolicySpec{
+				Window: v1beta2.MaintenanceWindowSpec{
+					DailyMaintenanceWindow: &v1beta2.DailyMaintenanceWindowSpec{StartTime: "13:13"},
+				},
+			},
+		},
+		"BothWindows": {
+			in: &v1beta2.MaintenancePolicySpec{
+				Window: v1beta2.MaintenanceWindowSpec{
+					DailyMaintenanceWindow: &v1beta2.DailyMaintenanceWindowSpec{StartTime: "13:13"},
+					RecurringWindow:        &v1beta2.RecurringTimeWindow{Recurrence: gcp.StringPtr("FREQ=DAILY")},
+				},
+			},
+			want: errors.New(errMaintenanceWindows),
+		},
+		"ValidRecurrence": {
+			in: recurring("FREQ=MONTHLY;BYSETPOS=1;BYDAY=SA,SU"),
+		},
+		"MalformedPart": {
+			in:   recurring("FREQ=WEEKLY;BYDAY"),
+			want: errors.Errorf(errRecurrencePart, "BYDAY"),
+		},
+		"UnknownPart": {
+			in:   recurring("FREQ=WEEKLY;ON=SA"),
+			want: errors.Errorf(errRecurrenceName, "ON"),
+		},
+		"UnsupportedFrequency": {
+			in:   recurring("FREQ=HOURLY"),
+			want: errors.New(errRecurrenceFreq),
+		},
+		"NoFrequency": {
+			in:   recurring("BYDAY=SA"),
+			want: errors.New(errRecurrenceFreq),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateMaintenancePolicy(tc.in)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateMaintenancePolicy(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestGenerateMasterAuth(t *testing.T) {
 	var adminUser = "admin"
 
@@ -1572,6 +1714,86 @@ func TestIsUpToDate(t *testing.T) {
 				isErr:    false,
 			},
 		},
+		"UpToDateResourceLimitsOrder": {
+			args: args{
+				name: name,
+				cluster: cluster(func(c *container.Cluster) {
+					c.Autoscaling = &container.ClusterAutoscaling{
+						EnableNodeAutoprovisioning: true,
+						ResourceLimits: []*container.ResourceLimit{
+							{ResourceType: "memory", Minimum: 1, Maximum: 64},
+							{ResourceType: "cpu", Minimum: 1, Maximum: 16},
+						},
+					}
+				}),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.Autoscaling = &v1beta2.ClusterAutoscaling{
+						EnableNodeAutoprovisioning: gcp.BoolPtr(true),
+						ResourceLimits: []*v1beta2.ResourceLimit{
+							{ResourceType: gcp.StringPtr("cpu"), Minimum: gcp.Int64Ptr(1), Maximum: gcp.Int64Ptr(16)},
+							{ResourceType: gcp.StringPtr("memory"), Minimum: gcp.Int64Ptr(1), Maximum: gcp.Int64Ptr(64)},
+						},
+					}
+				}),
+			},
+			want: want{
+				upToDate: true,
+				isErr:    false,
+			},
+		},
+		"NeedsUpdateResourceLimits": {
+			args: args{
+				name: name,
+				cluster: cluster(func(c *container.Cluster) {
+					c.Autoscaling = &container.ClusterAutoscaling{
+						EnableNodeAutoprovisioning: true,
+						ResourceLimits: []*container.ResourceLimit{
+							{ResourceType: "cpu", Minimum: 1, Maximum: 16},
+						},
+					}
+				}),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.Autoscaling = &v1beta2.ClusterAutoscaling{
+						EnableNodeAutoprovisioning: gcp.BoolPtr(true),
+						ResourceLimits: []*v1beta2.ResourceLimit{
+							{ResourceType: gcp.StringPtr("cpu"), Minimum: gcp.Int64Ptr(1), Maximum: gcp.Int64Ptr(32)},
+						},
+					}
+				}),
+			},
+			want: want{
+				upToDate: false,
+				isErr:    false,
+			},
+		},
+		"NeedsUpdateNodePoolDefaults": {
+			args: args{
+				name: name,
+				cluster: cluster(func(c *container.Cluster) {
+					c.Autoscaling = &container.ClusterAutoscaling{
+						EnableNodeAutoprovisioning: true,
+						AutoprovisioningNodePoolDefaults: &container.AutoprovisioningNodePoolDefaults{
+							OauthScopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+						},
+					}
+				}),
+				params: params(func(p *v1beta2.ClusterParameters) {
+					p.Autoscaling = &v1beta2.ClusterAutoscaling{
+						EnableNodeAutoprovisioning: gcp.BoolPtr(true),
+						AutoprovisioningNodePoolDefaults: &v1beta2.AutoprovisioningNodePoolDefaults{
+							OauthScopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+							Management: &v1beta2.NodeManagement{
+								AutoRepair: gcp.BoolPtr(true),
+							},
+						},
+					}
+				}),
+			},
+			want: want{
+				upToDate: false,
+				isErr:    false,
+			},
+		},
 		"NoUpdateNotBootstrapNodePool": {
 			args: args{
 				name: name,
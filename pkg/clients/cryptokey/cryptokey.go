@@ -28,6 +28,7 @@ import (
 
 	"github.com/crossplane/provider-gcp/apis/kms/v1alpha1"
 	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/cryptokeyversion"
 )
 
 const errCheckUpToDate = "unable to determine if external resource is up to date"
@@ -55,7 +56,7 @@ func GenerateCryptoKeyInstance(in v1alpha1.CryptoKeyParameters, ck *cloudkms.Cry
 }
 
 // GenerateObservation produces CryptoKeyObservation object from cloudkms.CryptoKey object.
-func GenerateObservation(in cloudkms.CryptoKey) v1alpha1.CryptoKeyObservation { // nolint:gocyclo
+func GenerateObservation(in cloudkms.CryptoKey) v1alpha1.CryptoKeyObservation {
 	o := v1alpha1.CryptoKeyObservation{
 		CreateTime:       in.CreateTime,
 		Name:             in.Name,
@@ -63,30 +64,8 @@ func GenerateObservation(in cloudkms.CryptoKey) v1alpha1.CryptoKeyObservation {
 	}
 
 	if in.Primary != nil {
-		o.Primary = &v1alpha1.CryptoKeyVersion{
-			Algorithm:           in.Primary.Algorithm,
-			CreateTime:          in.Primary.CreateTime,
-			DestroyEventTime:    in.Primary.DestroyEventTime,
-			DestroyTime:         in.Primary.DestroyTime,
-			GenerateTime:        in.Primary.GenerateTime,
-			ImportFailureReason: in.Primary.ImportFailureReason,
-			ImportJob:           in.Primary.ImportJob,
-			ImportTime:          in.Primary.ImportTime,
-			Name:                in.Primary.Name,
-			ProtectionLevel:     in.Primary.ProtectionLevel,
-			State:               in.Primary.State,
-		}
-		if in.Primary.Attestation != nil {
-			o.Primary.Attestation = &v1alpha1.KeyOperationAttestation{
-				Content: in.Primary.Attestation.Content,
-				Format:  in.Primary.Attestation.Format,
-			}
-		}
-		if in.Primary.ExternalProtectionLevelOptions != nil {
-			o.Primary.ExternalProtectionLevelOptions = &v1alpha1.ExternalProtectionLevelOptions{
-				ExternalKeyUri: in.Primary.ExternalProtectionLevelOptions.ExternalKeyUri,
-			}
-		}
+		p := cryptokeyversion.GenerateObservation(*in.Primary)
+		o.Primary = &p
 	}
 
 	return o
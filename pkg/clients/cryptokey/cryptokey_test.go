@@ -83,7 +83,7 @@ func TestGenerateObservation(t *testing.T) {
 					CreateTime:       createTime,
 					Name:             testCryptoKey,
 					NextRotationTime: rotationTime,
-					Primary: &v1alpha1.CryptoKeyVersion{
+					Primary: &v1alpha1.CryptoKeyVersionObservation{
 						Algorithm:       "GOOGLE_SYMMETRIC_ENCRYPTION",
 						CreateTime:      createTime,
 						Name:            "latest-key",
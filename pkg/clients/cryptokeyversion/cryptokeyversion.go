@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cryptokeyversion
+
+import (
+	"path"
+
+	"google.golang.org/api/cloudkms/v1"
+
+	"github.com/crossplane/provider-gcp/apis/kms/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// States of a CryptoKeyVersion.
+const (
+	StatePendingGeneration = "PENDING_GENERATION"
+	StatePendingImport     = "PENDING_IMPORT"
+	StateEnabled           = "ENABLED"
+	StateDisabled          = "DISABLED"
+	StateDestroyScheduled  = "DESTROY_SCHEDULED"
+	StateDestroyed         = "DESTROYED"
+	StateImportFailed      = "IMPORT_FAILED"
+)
+
+// Client should be satisfied to conduct CryptoKeyVersion operations.
+type Client interface {
+	Create(parent string, cryptokeyversion *cloudkms.CryptoKeyVersion) *cloudkms.ProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsCreateCall
+	Get(name string) *cloudkms.ProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsGetCall
+	Patch(name string, cryptokeyversion *cloudkms.CryptoKeyVersion) *cloudkms.ProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsPatchCall
+	Destroy(name string, destroycryptokeyversionrequest *cloudkms.DestroyCryptoKeyVersionRequest) *cloudkms.ProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsDestroyCall
+	Restore(name string, restorecryptokeyversionrequest *cloudkms.RestoreCryptoKeyVersionRequest) *cloudkms.ProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsRestoreCall
+}
+
+// ID returns the version ID of the CryptoKeyVersion with the supplied
+// resource name, i.e. the last segment of
+// projects/*/locations/*/keyRings/*/cryptoKeys/*/cryptoKeyVersions/*.
+func ID(name string) string {
+	return path.Base(name)
+}
+
+// DesiredState returns the state the supplied parameters ask for.
+func DesiredState(in v1alpha1.CryptoKeyVersionParameters) string {
+	if in.State == nil {
+		return StateEnabled
+	}
+	return gcp.StringValue(in.State)
+}
+
+// GenerateObservation produces CryptoKeyVersionObservation object from
+// cloudkms.CryptoKeyVersion object.
+func GenerateObservation(in cloudkms.CryptoKeyVersion) v1alpha1.CryptoKeyVersionObservation {
+	o := v1alpha1.CryptoKeyVersionObservation{
+		Algorithm:           in.Algorithm,
+		CreateTime:          in.CreateTime,
+		DestroyEventTime:    in.DestroyEventTime,
+		DestroyTime:         in.DestroyTime,
+		GenerateTime:        in.GenerateTime,
+		ImportFailureReason: in.ImportFailureReason,
+		ImportJob:           in.ImportJob,
+		ImportTime:          in.ImportTime,
+		Name:                in.Name,
+		ProtectionLevel:     in.ProtectionLevel,
+		State:               in.State,
+	}
+	if in.Attestation != nil {
+		o.Attestation = &v1alpha1.KeyOperationAttestation{
+			Content: in.Attestation.Content,
+			Format:  in.Attestation.Format,
+		}
+	}
+	if in.ExternalProtectionLevelOptions != nil {
+		o.ExternalProtectionLevelOptions = &v1alpha1.ExternalProtectionLevelOptions{
+			ExternalKeyUri: in.ExternalProtectionLevelOptions.ExternalKeyUri,
+		}
+	}
+	return o
+}
+
+// IsUpToDate checks whether the observed state of the CryptoKeyVersion is
+// the desired one. Versions that are still pending, failed to be imported or
+// are destroyed cannot change their state and are considered up to date.
+func IsUpToDate(in v1alpha1.CryptoKeyVersionParameters, observed *cloudkms.CryptoKeyVersion) bool {
+	switch observed.State {
+	case StatePendingGeneration, StatePendingImport, StateImportFailed, StateDestroyed:
+		return true
+	}
+	return DesiredState(in) == observed.State
+}
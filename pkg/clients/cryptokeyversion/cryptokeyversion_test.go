@@ -0,0 +1,75 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cryptokeyversion
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/cloudkms/v1"
+
+	"github.com/crossplane/provider-gcp/apis/kms/v1alpha1"
+)
+
+func TestID(t *testing.T) {
+	name := "projects/p/locations/l/keyRings/r/cryptoKeys/k/cryptoKeyVersions/3"
+	if diff := cmp.Diff("3", ID(name)); diff != "" {
+		t.Errorf("ID(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	disabled := StateDisabled
+
+	cases := map[string]struct {
+		in       v1alpha1.CryptoKeyVersionParameters
+		observed *cloudkms.CryptoKeyVersion
+		want     bool
+	}{
+		"EnabledByDefault": {
+			observed: &cloudkms.CryptoKeyVersion{State: StateEnabled},
+			want:     true,
+		},
+		"NeedsDisabling": {
+			in:       v1alpha1.CryptoKeyVersionParameters{State: &disabled},
+			observed: &cloudkms.CryptoKeyVersion{State: StateEnabled},
+			want:     false,
+		},
+		"NeedsRestoring": {
+			in:       v1alpha1.CryptoKeyVersionParameters{State: &disabled},
+			observed: &cloudkms.CryptoKeyVersion{State: StateDestroyScheduled},
+			want:     false,
+		},
+		"PendingGeneration": {
+			in:       v1alpha1.CryptoKeyVersionParameters{State: &disabled},
+			observed: &cloudkms.CryptoKeyVersion{State: StatePendingGeneration},
+			want:     true,
+		},
+		"Destroyed": {
+			observed: &cloudkms.CryptoKeyVersion{State: StateDestroyed},
+			want:     true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, IsUpToDate(tc.in, tc.observed)); diff != "" {
+				t.Errorf("IsUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
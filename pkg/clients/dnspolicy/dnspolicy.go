@@ -0,0 +1,101 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnspolicy
+
+import (
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	dns "google.golang.org/api/dns/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1beta1"
+	"github.com/crossplane/provider-gcp/apis/dns/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// GeneratePolicy generates *dns.Policy instance from PolicyParameters.
+func GeneratePolicy(name string, in v1alpha1.PolicyParameters, p *dns.Policy) {
+	p.Name = name
+	p.Description = gcp.StringValue(in.Description)
+	p.EnableInboundForwarding = gcp.BoolValue(in.EnableInboundForwarding)
+	p.EnableLogging = gcp.BoolValue(in.EnableLogging)
+	// NOTE: Policies are updated as a whole, so disabled features must be
+	// sent explicitly.
+	p.ForceSendFields = []string{"EnableInboundForwarding", "EnableLogging"}
+
+	p.AlternativeNameServerConfig = nil
+	if in.AlternativeNameServerConfig != nil {
+		p.AlternativeNameServerConfig = &dns.PolicyAlternativeNameServerConfig{
+			TargetNameServers: make([]*dns.PolicyAlternativeNameServerConfigTargetNameServer, len(in.AlternativeNameServerConfig.TargetNameServers)),
+		}
+		for i, ns := range in.AlternativeNameServerConfig.TargetNameServers {
+			p.AlternativeNameServerConfig.TargetNameServers[i] = &dns.PolicyAlternativeNameServerConfigTargetNameServer{
+				Ipv4Address:    ns.IPv4Address,
+				ForwardingPath: gcp.StringValue(ns.ForwardingPath),
+			}
+		}
+	}
+
+	p.Networks = make([]*dns.PolicyNetwork, len(in.Networks))
+	for i, n := range in.Networks {
+		p.Networks[i] = &dns.PolicyNetwork{NetworkUrl: networkURL(n)}
+	}
+}
+
+// networkURL returns the fully qualified URL of the supplied network, which
+// is what Cloud DNS expects.
+func networkURL(n string) string {
+	if strings.HasPrefix(n, v1beta1.ComputeURIPrefix) {
+		return n
+	}
+	return v1beta1.ComputeURIPrefix + n
+}
+
+// GenerateObservation produces PolicyObservation object from dns.Policy
+// object.
+func GenerateObservation(in dns.Policy) v1alpha1.PolicyObservation {
+	return v1alpha1.PolicyObservation{ID: in.Id}
+}
+
+// LateInitializeSpec fills unassigned fields with the values in dns.Policy
+// object.
+func LateInitializeSpec(spec *v1alpha1.PolicyParameters, in dns.Policy) {
+	spec.Description = gcp.LateInitializeString(spec.Description, in.Description)
+	spec.EnableInboundForwarding = gcp.LateInitializeBool(spec.EnableInboundForwarding, in.EnableInboundForwarding)
+	spec.EnableLogging = gcp.LateInitializeBool(spec.EnableLogging, in.EnableLogging)
+}
+
+// IsUpToDate checks whether current state is up-to-date compared to the given
+// set of parameters. The order of the networks and of the target name
+// servers is not significant.
+func IsUpToDate(name string, in v1alpha1.PolicyParameters, observed *dns.Policy) bool {
+	desired := &dns.Policy{}
+	GeneratePolicy(name, in, desired)
+	return cmp.Equal(desired, observed,
+		cmpopts.EquateEmpty(),
+		gcp.EquateComputeURLs(),
+		cmpopts.IgnoreFields(dns.Policy{}, "Id", "Kind", "ServerResponse", "ForceSendFields", "NullFields"),
+		cmpopts.IgnoreFields(dns.PolicyAlternativeNameServerConfig{}, "Kind", "ForceSendFields", "NullFields"),
+		cmpopts.IgnoreFields(dns.PolicyAlternativeNameServerConfigTargetNameServer{}, "Kind", "ForceSendFields", "NullFields"),
+		cmpopts.IgnoreFields(dns.PolicyNetwork{}, "Kind", "ForceSendFields", "NullFields"),
+		cmpopts.SortSlices(func(a, b *dns.PolicyNetwork) bool { return a.NetworkUrl < b.NetworkUrl }),
+		cmpopts.SortSlices(func(a, b *dns.PolicyAlternativeNameServerConfigTargetNameServer) bool {
+			return a.Ipv4Address < b.Ipv4Address
+		}),
+	)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnspolicy
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	dns "google.golang.org/api/dns/v1"
+
+	"github.com/crossplane/provider-gcp/apis/dns/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	name     = "hybrid"
+	networkA = "projects/p/global/networks/a"
+	networkB = "projects/p/global/networks/b"
+)
+
+func params(m ...func(*v1alpha1.PolicyParameters)) v1alpha1.PolicyParameters {
+	p := v1alpha1.PolicyParameters{
+		EnableInboundForwarding: gcp.BoolPtr(true),
+		AlternativeNameServerConfig: &v1alpha1.PolicyAlternativeNameServerConfig{
+			TargetNameServers: []v1alpha1.PolicyTargetNameServer{{IPv4Address: "10.0.0.2"}, {IPv4Address: "10.0.0.3"}},
+		},
+		Networks: []string{networkA, networkB},
+	}
+	for _, f := range m {
+		f(&p)
+	}
+	return p
+}
+
+func policy(m ...func(*dns.Policy)) *dns.Policy {
+	p := &dns.Policy{
+		Id:                      42,
+		Kind:                    "dns#policy",
+		Name:                    name,
+		EnableInboundForwarding: true,
+		AlternativeNameServerConfig: &dns.PolicyAlternativeNameServerConfig{
+			Kind: "dns#policyAlternativeNameServerConfig",
+			TargetNameServers: []*dns.PolicyAlternativeNameServerConfigTargetNameServer{
+				{Kind: "dns#policyAlternativeNameServerConfigTargetNameServer", Ipv4Address: "10.0.0.3"},
+				{Kind: "dns#policyAlternativeNameServerConfigTargetNameServer", Ipv4Address: "10.0.0.2"},
+			},
+		},
+		Networks: []*dns.PolicyNetwork{
+			{Kind: "dns#policyNetwork", NetworkUrl: "https://www.googleapis.com/compute/v1/" + networkB},
+			{Kind: "dns#policyNetwork", NetworkUrl: "https://www.googleapis.com/compute/v1/" + networkA},
+		},
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		in       v1alpha1.PolicyParameters
+		observed *dns.Policy
+		want     bool
+	}{
+		"UpToDate": {
+			in:       params(),
+			observed: policy(),
+			want:     true,
+		},
+		"NetworkAdded": {
+			in: params(),
+			observed: policy(func(p *dns.Policy) {
+				p.Networks = p.Networks[:1]
+			}),
+			want: false,
+		},
+		"ForwarderChanged": {
+			in: params(func(p *v1alpha1.PolicyParameters) {
+				p.AlternativeNameServerConfig.TargetNameServers[1].IPv4Address = "10.0.0.4"
+			}),
+			observed: policy(),
+			want:     false,
+		},
+		"ForwardersRemoved": {
+			in: params(func(p *v1alpha1.PolicyParameters) {
+				p.AlternativeNameServerConfig = nil
+			}),
+			observed: policy(),
+			want:     false,
+		},
+		"LoggingEnabled": {
+			in: params(func(p *v1alpha1.PolicyParameters) {
+				p.EnableLogging = gcp.BoolPtr(true)
+			}),
+			observed: policy(),
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, IsUpToDate("hybrid", tc.in, tc.observed)); diff != "" {
+				t.Errorf("IsUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
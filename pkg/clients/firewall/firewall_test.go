@@ -267,6 +267,16 @@ func TestIsUpToDate(t *testing.T) {
 			},
 			want: want{upToDate: false, isErr: false},
 		},
+		"NotUpToDateDescription": {
+			args: args{
+				in: params(func(p *v1alpha1.FirewallParameters) {
+					d := "an updated description"
+					p.Description = &d
+				}),
+				current: firewall(),
+			},
+			want: want{upToDate: false, isErr: false},
+		},
 	}
 
 	for name, tc := range cases {
@@ -0,0 +1,195 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package forwardingrule
+
+import (
+	"path"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	cmpv1beta1 "github.com/crossplane/provider-gcp/apis/compute/v1beta1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/regionbackendservice"
+)
+
+// defaultIPProtocol is the protocol GCP assumes if a forwarding rule has none.
+const defaultIPProtocol = "TCP"
+
+// Error strings.
+const (
+	errTarget                = "exactly one of backendService and target must be set"
+	errAllPorts              = "allPorts is exclusive with ports and portRange"
+	errPorts                 = "ports is exclusive with portRange"
+	errPortsBackendService   = "allPorts and ports require a backendService"
+	errFmtGlobalAccess       = "only INTERNAL forwarding rules allow global access, not %s ones"
+	errFmtBackendServiceURL  = "cannot parse backend service URL %q"
+	errFmtSchemeMismatch     = "load balancing scheme %s of the forwarding rule does not match scheme %s of backend service %s"
+	errFmtRegionMismatch     = "region %s of the forwarding rule does not match region %s of backend service %s"
+	errFmtProtocolMismatch   = "IP protocol %s of the forwarding rule does not match protocol %s of backend service %s"
+	errFmtNetworkMismatch    = "network %s of the forwarding rule does not match network %s of backend service %s"
+	errFmtGlobalBackendScope = "backend service %s is global; a regional forwarding rule needs a regional backend service"
+)
+
+// Validate returns an error if the supplied parameters are inconsistent, e.g.
+// if they forward all ports to something other than a backend service.
+func Validate(in v1alpha1.ForwardingRuleParameters) error {
+	if (in.BackendService == nil) == (in.Target == nil) {
+		return errors.New(errTarget)
+	}
+	if gcp.BoolValue(in.AllPorts) && (len(in.Ports) > 0 || in.PortRange != nil) {
+		return errors.New(errAllPorts)
+	}
+	if len(in.Ports) > 0 && in.PortRange != nil {
+		return errors.New(errPorts)
+	}
+	if (gcp.BoolValue(in.AllPorts) || len(in.Ports) > 0) && in.BackendService == nil {
+		return errors.New(errPortsBackendService)
+	}
+	if gcp.BoolValue(in.AllowGlobalAccess) && in.LoadBalancingScheme != regionbackendservice.SchemeInternal {
+		return errors.Errorf(errFmtGlobalAccess, in.LoadBalancingScheme)
+	}
+	return nil
+}
+
+// ParseBackendService returns the project, region and name of the regional
+// backend service with the supplied full, partial or unqualified URL. The
+// project and region of the forwarding rule are assumed if the URL omits
+// them.
+func ParseBackendService(url, project, region string) (string, string, string, error) {
+	parts := strings.Split(strings.TrimPrefix(url, cmpv1beta1.ComputeURIPrefix), "/")
+	name := parts[len(parts)-1]
+	if name == "" {
+		return "", "", "", errors.Errorf(errFmtBackendServiceURL, url)
+	}
+	for i := 0; i < len(parts)-1; i++ {
+		switch parts[i] {
+		case "global":
+			return "", "", "", errors.Errorf(errFmtGlobalBackendScope, url)
+		case "projects":
+			project = parts[i+1]
+		case "regions":
+			region = parts[i+1]
+		}
+	}
+	return project, region, name, nil
+}
+
+// CheckBackendService returns an error if the supplied backend service can not
+// be the backend of a forwarding rule with the supplied parameters, i.e. if
+// their load balancing schemes or regions differ, or if an INTERNAL backend
+// service uses another protocol or network than the forwarding rule.
+func CheckBackendService(in v1alpha1.ForwardingRuleParameters, bs compute.BackendService) error {
+	if bs.LoadBalancingScheme != in.LoadBalancingScheme {
+		return errors.Errorf(errFmtSchemeMismatch, in.LoadBalancingScheme, bs.LoadBalancingScheme, bs.Name)
+	}
+	if r := path.Base(bs.Region); r != in.Region {
+		return errors.Errorf(errFmtRegionMismatch, in.Region, r, bs.Name)
+	}
+	if in.LoadBalancingScheme != regionbackendservice.SchemeInternal {
+		return nil
+	}
+	p := gcp.StringValue(in.IPProtocol)
+	if p == "" {
+		p = defaultIPProtocol
+	}
+	if bs.Protocol != "" && bs.Protocol != p {
+		return errors.Errorf(errFmtProtocolMismatch, p, bs.Protocol, bs.Name)
+	}
+	if in.Network != nil && bs.Network != "" && !cmp.Equal(*in.Network, bs.Network, gcp.EquateComputeURLs()) {
+		return errors.Errorf(errFmtNetworkMismatch, *in.Network, bs.Network, bs.Name)
+	}
+	return nil
+}
+
+// GenerateForwardingRule takes a *ForwardingRuleParameters and returns
+// *compute.ForwardingRule. It assigns only the fields that are writable, i.e.
+// not labelled as [Output Only] in Google's reference.
+func GenerateForwardingRule(name string, in v1alpha1.ForwardingRuleParameters, fr *compute.ForwardingRule) {
+	fr.Name = name
+	fr.LoadBalancingScheme = in.LoadBalancingScheme
+	fr.IPAddress = gcp.StringValue(in.IPAddress)
+	fr.IPProtocol = gcp.StringValue(in.IPProtocol)
+	fr.AllPorts = gcp.BoolValue(in.AllPorts)
+	fr.Ports = in.Ports
+	fr.PortRange = gcp.StringValue(in.PortRange)
+	fr.BackendService = gcp.StringValue(in.BackendService)
+	fr.Target = gcp.StringValue(in.Target)
+	fr.Network = gcp.StringValue(in.Network)
+	fr.Subnetwork = gcp.StringValue(in.Subnetwork)
+	fr.AllowGlobalAccess = gcp.BoolValue(in.AllowGlobalAccess)
+	fr.Description = gcp.StringValue(in.Description)
+}
+
+// GenerateGlobalAccessPatch returns the *compute.ForwardingRule that updates
+// whether the supplied forwarding rule can be reached from other regions.
+func GenerateGlobalAccessPatch(in v1alpha1.ForwardingRuleParameters) *compute.ForwardingRule {
+	return &compute.ForwardingRule{
+		AllowGlobalAccess: gcp.BoolValue(in.AllowGlobalAccess),
+		// Disallowing global access must be requested explicitly.
+		ForceSendFields: []string{"AllowGlobalAccess"},
+	}
+}
+
+// GenerateForwardingRuleObservation takes a compute.ForwardingRule and returns
+// *ForwardingRuleObservation.
+func GenerateForwardingRuleObservation(in compute.ForwardingRule) v1alpha1.ForwardingRuleObservation {
+	return v1alpha1.ForwardingRuleObservation{
+		CreationTimestamp: in.CreationTimestamp,
+		ID:                in.Id,
+		SelfLink:          in.SelfLink,
+		ServiceName:       in.ServiceName,
+	}
+}
+
+// LateInitializeSpec fills unassigned fields with the values in
+// compute.ForwardingRule object.
+func LateInitializeSpec(spec *v1alpha1.ForwardingRuleParameters, in compute.ForwardingRule) {
+	spec.IPAddress = gcp.LateInitializeString(spec.IPAddress, in.IPAddress)
+	spec.IPProtocol = gcp.LateInitializeString(spec.IPProtocol, in.IPProtocol)
+	spec.Network = gcp.LateInitializeString(spec.Network, in.Network)
+	spec.Subnetwork = gcp.LateInitializeString(spec.Subnetwork, in.Subnetwork)
+	spec.Description = gcp.LateInitializeString(spec.Description, in.Description)
+	if spec.AllowGlobalAccess == nil {
+		// AllowGlobalAccess is omitted from the response of rules that
+		// disallow it, so false is as valid a value to adopt as true.
+		spec.AllowGlobalAccess = gcp.BoolPtr(in.AllowGlobalAccess)
+	}
+}
+
+// IsGlobalAccessUpToDate returns true if the observed forwarding rule allows
+// global access as desired.
+func IsGlobalAccessUpToDate(in v1alpha1.ForwardingRuleParameters, observed compute.ForwardingRule) bool {
+	return in.AllowGlobalAccess == nil || *in.AllowGlobalAccess == observed.AllowGlobalAccess
+}
+
+// IsTargetUpToDate returns true if the observed forwarding rule forwards to
+// the desired target. Forwarding rules with a backend service have no target.
+func IsTargetUpToDate(in v1alpha1.ForwardingRuleParameters, observed compute.ForwardingRule) bool {
+	return in.Target == nil || cmp.Equal(*in.Target, observed.Target, gcp.EquateComputeURLs())
+}
+
+// IsUpToDate returns true if the target and global access of the observed
+// forwarding rule match the desired ones. Other fields can not be updated and
+// are not considered.
+func IsUpToDate(in v1alpha1.ForwardingRuleParameters, observed compute.ForwardingRule) bool {
+	return IsGlobalAccessUpToDate(in, observed) && IsTargetUpToDate(in, observed)
+}
@@ -0,0 +1,422 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package forwardingrule
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testName           = "some-name"
+	testProject        = "cool-project"
+	testRegion         = "us-central1"
+	testNetwork        = "global/networks/some-network"
+	testBackendService = "regions/us-central1/backendServices/some-service"
+	testSelfLink       = "https://www.googleapis.com/compute/v1/projects/cool-project/regions/us-central1/forwardingRules/some-name"
+)
+
+func params(m ...func(*v1alpha1.ForwardingRuleParameters)) *v1alpha1.ForwardingRuleParameters {
+	o := &v1alpha1.ForwardingRuleParameters{
+		Region:              testRegion,
+		LoadBalancingScheme: "INTERNAL",
+		IPAddress:           gcp.StringPtr("10.0.0.10"),
+		IPProtocol:          gcp.StringPtr("TCP"),
+		AllPorts:            gcp.BoolPtr(true),
+		BackendService:      gcp.StringPtr(testBackendService),
+		Network:             gcp.StringPtr(testNetwork),
+		Subnetwork:          gcp.StringPtr("regions/us-central1/subnetworks/some-subnetwork"),
+		AllowGlobalAccess:   gcp.BoolPtr(true),
+		Description:         gcp.StringPtr("some desc"),
+	}
+	for _, f := range m {
+		f(o)
+	}
+	return o
+}
+
+func rule(m ...func(*compute.ForwardingRule)) *compute.ForwardingRule {
+	o := &compute.ForwardingRule{
+		Name:                testName,
+		LoadBalancingScheme: "INTERNAL",
+		IPAddress:           "10.0.0.10",
+		IPProtocol:          "TCP",
+		AllPorts:            true,
+		BackendService:      testBackendService,
+		Network:             testNetwork,
+		Subnetwork:          "regions/us-central1/subnetworks/some-subnetwork",
+		AllowGlobalAccess:   true,
+		Description:         "some desc",
+	}
+	for _, f := range m {
+		f(o)
+	}
+	return o
+}
+
+func backendService(m ...func(*compute.BackendService)) compute.BackendService {
+	o := compute.BackendService{
+		Name:                "some-service",
+		LoadBalancingScheme: "INTERNAL",
+		Protocol:            "TCP",
+		Network:             "https://www.googleapis.com/compute/v1/projects/cool-project/" + testNetwork,
+		Region:              "https://www.googleapis.com/compute/v1/projects/cool-project/regions/us-central1",
+	}
+	for _, f := range m {
+		f(&o)
+	}
+	return o
+}
+
+func TestValidate(t *testing.T) {
+	cases := map[string]struct {
+		in   v1alpha1.ForwardingRuleParameters
+		want error
+	}{
+		"AllPortsToBackendService": {
+			in: *params(),
+		},
+		"PortsToBackendService": {
+			in: *params(func(p *v1alpha1.ForwardingRuleParameters) {
+				p.AllPorts = nil
+				p.Ports = []string{"80", "443"}
+			}),
+		},
+		"PortRangeToTarget": {
+			in: *params(func(p *v1alpha1.ForwardingRuleParameters) {
+				p.LoadBalancingScheme = "EXTERNAL"
+				p.AllPorts = nil
+				p.PortRange = gcp.StringPtr("80-80")
+				p.BackendService = nil
+				p.Target = gcp.StringPtr("zones/us-central1-a/targetInstances/some-target")
+				p.AllowGlobalAccess = nil
+			}),
+		},
+		"NoTarget": {
+			in:   *params(func(p *v1alpha1.ForwardingRuleParameters) { p.BackendService = nil }),
+			want: errors.New(errTarget),
+		},
+		"TwoTargets": {
+			in: *params(func(p *v1alpha1.ForwardingRuleParameters) {
+				p.Target = gcp.StringPtr("zones/us-central1-a/targetInstances/some-target")
+			}),
+			want: errors.New(errTarget),
+		},
+		"AllPortsAndPorts": {
+			in:   *params(func(p *v1alpha1.ForwardingRuleParameters) { p.Ports = []string{"80"} }),
+			want: errors.New(errAllPorts),
+		},
+		"PortsAndPortRange": {
+			in: *params(func(p *v1alpha1.ForwardingRuleParameters) {
+				p.AllPorts = nil
+				p.Ports = []string{"80"}
+				p.PortRange = gcp.StringPtr("80-80")
+			}),
+			want: errors.New(errPorts),
+		},
+		"AllPortsToTarget": {
+			in: *params(func(p *v1alpha1.ForwardingRuleParameters) {
+				p.BackendService = nil
+				p.Target = gcp.StringPtr("zones/us-central1-a/targetInstances/some-target")
+			}),
+			want: errors.New(errPortsBackendService),
+		},
+		"ExternalGlobalAccess": {
+			in:   *params(func(p *v1alpha1.ForwardingRuleParameters) { p.LoadBalancingScheme = "EXTERNAL" }),
+			want: errors.Errorf(errFmtGlobalAccess, "EXTERNAL"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, Validate(tc.in), test.EquateErrors()); diff != "" {
+				t.Errorf("Validate(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseBackendService(t *testing.T) {
+	type want struct {
+		parts []string
+		err   error
+	}
+	cases := map[string]struct {
+		url  string
+		want want
+	}{
+		"Unqualified": {
+			url:  "some-service",
+			want: want{parts: []string{testProject, testRegion, "some-service"}},
+		},
+		"PartiallyQualified": {
+			url:  "regions/us-east1/backendServices/some-service",
+			want: want{parts: []string{testProject, "us-east1", "some-service"}},
+		},
+		"FullyQualified": {
+			url:  "https://www.googleapis.com/compute/v1/projects/other-project/regions/us-east1/backendServices/some-service",
+			want: want{parts: []string{"other-project", "us-east1", "some-service"}},
+		},
+		"Global": {
+			url: "global/backendServices/some-service",
+			want: want{
+				parts: []string{"", "", ""},
+				err:   errors.Errorf(errFmtGlobalBackendScope, "global/backendServices/some-service"),
+			},
+		},
+		"Empty": {
+			url: "regions/us-east1/backendServices/",
+			want: want{
+				parts: []string{"", "", ""},
+				err:   errors.Errorf(errFmtBackendServiceURL, "regions/us-east1/backendServices/"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			project, region, name, err := ParseBackendService(tc.url, testProject, testRegion)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ParseBackendService(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.parts, []string{project, region, name}); diff != "" {
+				t.Errorf("ParseBackendService(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckBackendService(t *testing.T) {
+	cases := map[string]struct {
+		in   v1alpha1.ForwardingRuleParameters
+		bs   compute.BackendService
+		want error
+	}{
+		"Consistent": {
+			in: *params(),
+			bs: backendService(),
+		},
+		"DefaultProtocol": {
+			in: *params(func(p *v1alpha1.ForwardingRuleParameters) { p.IPProtocol = nil }),
+			bs: backendService(),
+		},
+		"SchemeMismatch": {
+			in:   *params(),
+			bs:   backendService(func(bs *compute.BackendService) { bs.LoadBalancingScheme = "EXTERNAL" }),
+			want: errors.Errorf(errFmtSchemeMismatch, "INTERNAL", "EXTERNAL", "some-service"),
+		},
+		"RegionMismatch": {
+			in:   *params(),
+			bs:   backendService(func(bs *compute.BackendService) { bs.Region = "regions/us-east1" }),
+			want: errors.Errorf(errFmtRegionMismatch, testRegion, "us-east1", "some-service"),
+		},
+		"ProtocolMismatch": {
+			in:   *params(),
+			bs:   backendService(func(bs *compute.BackendService) { bs.Protocol = "UDP" }),
+			want: errors.Errorf(errFmtProtocolMismatch, "TCP", "UDP", "some-service"),
+		},
+		"NetworkMismatch": {
+			in:   *params(),
+			bs:   backendService(func(bs *compute.BackendService) { bs.Network = "global/networks/other-network" }),
+			want: errors.Errorf(errFmtNetworkMismatch, testNetwork, "global/networks/other-network", "some-service"),
+		},
+		"ExternalProtocolNotChecked": {
+			in: *params(func(p *v1alpha1.ForwardingRuleParameters) { p.LoadBalancingScheme = "EXTERNAL" }),
+			bs: backendService(func(bs *compute.BackendService) {
+				bs.LoadBalancingScheme = "EXTERNAL"
+				bs.Protocol = "UDP"
+			}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, CheckBackendService(tc.in, tc.bs), test.EquateErrors()); diff != "" {
+				t.Errorf("CheckBackendService(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateForwardingRule(t *testing.T) {
+	cases := map[string]struct {
+		in   v1alpha1.ForwardingRuleParameters
+		want *compute.ForwardingRule
+	}{
+		"FullConversion": {
+			in:   *params(),
+			want: rule(),
+		},
+		"MissingFields": {
+			in: v1alpha1.ForwardingRuleParameters{
+				Region:              testRegion,
+				LoadBalancingScheme: "INTERNAL",
+				Ports:               []string{"80"},
+				BackendService:      gcp.StringPtr(testBackendService),
+			},
+			want: &compute.ForwardingRule{
+				Name:                testName,
+				LoadBalancingScheme: "INTERNAL",
+				Ports:               []string{"80"},
+				BackendService:      testBackendService,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &compute.ForwardingRule{}
+			GenerateForwardingRule(testName, tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateForwardingRule(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateGlobalAccessPatch(t *testing.T) {
+	want := &compute.ForwardingRule{ForceSendFields: []string{"AllowGlobalAccess"}}
+	got := GenerateGlobalAccessPatch(*params(func(p *v1alpha1.ForwardingRuleParameters) { p.AllowGlobalAccess = gcp.BoolPtr(false) }))
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GenerateGlobalAccessPatch(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestGenerateForwardingRuleObservation(t *testing.T) {
+	in := rule(func(fr *compute.ForwardingRule) {
+		fr.CreationTimestamp = "10/10/2023"
+		fr.Id = 2029819203
+		fr.SelfLink = testSelfLink
+		fr.ServiceName = "svc.some-name.il4.us-central1.lb.cool-project.internal"
+	})
+	want := v1alpha1.ForwardingRuleObservation{
+		CreationTimestamp: "10/10/2023",
+		ID:                2029819203,
+		SelfLink:          testSelfLink,
+		ServiceName:       "svc.some-name.il4.us-central1.lb.cool-project.internal",
+	}
+	if diff := cmp.Diff(want, GenerateForwardingRuleObservation(*in)); diff != "" {
+		t.Errorf("GenerateForwardingRuleObservation(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	type args struct {
+		spec *v1alpha1.ForwardingRuleParameters
+		in   compute.ForwardingRule
+	}
+	cases := map[string]struct {
+		args args
+		want *v1alpha1.ForwardingRuleParameters
+	}{
+		"AllFilledAlready": {
+			args: args{
+				spec: params(),
+				in:   *rule(),
+			},
+			want: params(),
+		},
+		"DefaultsFilled": {
+			args: args{
+				spec: params(func(p *v1alpha1.ForwardingRuleParameters) {
+					p.IPAddress = nil
+					p.IPProtocol = nil
+					p.Network = nil
+					p.Subnetwork = nil
+					p.AllowGlobalAccess = nil
+					p.Description = nil
+				}),
+				in: *rule(),
+			},
+			want: params(),
+		},
+		"GlobalAccessDisallowedFilled": {
+			args: args{
+				spec: params(func(p *v1alpha1.ForwardingRuleParameters) { p.AllowGlobalAccess = nil }),
+				in:   *rule(func(fr *compute.ForwardingRule) { fr.AllowGlobalAccess = false }),
+			},
+			want: params(func(p *v1alpha1.ForwardingRuleParameters) { p.AllowGlobalAccess = gcp.BoolPtr(false) }),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.args.spec, tc.args.in)
+			if diff := cmp.Diff(tc.want, tc.args.spec); diff != "" {
+				t.Errorf("LateInitializeSpec(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	target := func(p *v1alpha1.ForwardingRuleParameters) {
+		p.LoadBalancingScheme = "EXTERNAL"
+		p.AllPorts = nil
+		p.BackendService = nil
+		p.Target = gcp.StringPtr("zones/us-central1-a/targetInstances/some-target")
+		p.AllowGlobalAccess = nil
+	}
+	cases := map[string]struct {
+		in       v1alpha1.ForwardingRuleParameters
+		observed compute.ForwardingRule
+		want     bool
+	}{
+		"UpToDate": {
+			in:       *params(),
+			observed: *rule(),
+			want:     true,
+		},
+		"GlobalAccessChanged": {
+			in:       *params(func(p *v1alpha1.ForwardingRuleParameters) { p.AllowGlobalAccess = gcp.BoolPtr(false) }),
+			observed: *rule(),
+			want:     false,
+		},
+		"TargetUpToDate": {
+			in: *params(target),
+			observed: *rule(func(fr *compute.ForwardingRule) {
+				fr.Target = "https://www.googleapis.com/compute/v1/projects/cool-project/zones/us-central1-a/targetInstances/some-target"
+			}),
+			want: true,
+		},
+		"TargetChanged": {
+			in:       *params(target),
+			observed: *rule(func(fr *compute.ForwardingRule) { fr.Target = "zones/us-central1-a/targetInstances/other-target" }),
+			want:     false,
+		},
+		"ImmutableFieldChanged": {
+			in:       *params(),
+			observed: *rule(func(fr *compute.ForwardingRule) { fr.IPAddress = "10.0.0.11" }),
+			want:     true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, IsUpToDate(tc.in, tc.observed)); diff != "" {
+				t.Errorf("IsUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
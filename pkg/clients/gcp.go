@@ -18,20 +18,35 @@ package gcp
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
 	"net/http"
+	"net/url"
 	"path"
+	"regexp"
+	"sort"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
 	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	cmpv1beta1 "github.com/crossplane/provider-gcp/apis/compute/v1beta1"
@@ -39,9 +54,130 @@ import (
 	"github.com/crossplane/provider-gcp/apis/v1beta1"
 )
 
+// TypeGCPWarning resources have received one or more warnings from GCP, e.g.
+// about the use of a deprecated or soon to be removed feature. Warnings do
+// not fail the reconciliation.
+const TypeGCPWarning xpv1.ConditionType = "GCPWarning"
+
+// ReasonOperationWarning indicates the most recent GCP operation performed on
+// a resource completed with warnings.
+const ReasonOperationWarning xpv1.ConditionReason = "OperationWarning"
+
+// TypeDegraded resources are not behaving as expected, but are otherwise
+// still being reconciled as usual.
+const TypeDegraded xpv1.ConditionType = "Degraded"
+
+// ReasonCreationStalled indicates a resource has not become available within
+// the time it is expected to take to be created.
+const ReasonCreationStalled xpv1.ConditionReason = "CreationStalled"
+
+// AnnotationKeyDryRun is the annotation that, when set to "true", causes the
+// changes a controller would make to the external resource of a managed
+// resource to be reported rather than applied.
+const AnnotationKeyDryRun = "gcp.crossplane.io/dry-run"
+
+// TypeDryRun resources are annotated to report the changes that would be made
+// to their external resource rather than applying them.
+const TypeDryRun xpv1.ConditionType = "DryRun"
+
+// Reasons a resource's external changes were planned but not applied.
+const (
+	ReasonChangePlanned   xpv1.ConditionReason = "ChangePlanned"
+	ReasonDeletionPlanned xpv1.ConditionReason = "DeletionPlanned"
+)
+
+// AnnotationKeyRemoveExpired is the annotation that, when set to "true",
+// causes the IAM binding managed by a resource to be removed once the time
+// condition of the binding has expired, rather than left in the IAM policy.
+const AnnotationKeyRemoveExpired = "gcp.crossplane.io/remove-expired"
+
+// AnnotationKeyLastAppliedBinding is the annotation that records the IAM
+// binding a resource last applied, so that changes to the resource that leave
+// its binding unchanged do not change the IAM policy again.
+const AnnotationKeyLastAppliedBinding = "gcp.crossplane.io/last-applied-binding"
+
+// AnnotationKeyAllowPublicAccess is the annotation that, when set to "true",
+// allows the IAM binding managed by a resource to bind its role to allUsers or
+// allAuthenticatedUsers, i.e. to make its external resource public. Such
+// bindings are refused otherwise.
+const AnnotationKeyAllowPublicAccess = "gcp.crossplane.io/allow-public-access"
+
+// AnnotationKeyCheckCustomRole is the annotation that, when set to "true",
+// causes the IAM binding managed by a resource to be refused if it binds a
+// custom role that does not exist, rather than failing to set the IAM policy.
+const AnnotationKeyCheckCustomRole = "gcp.crossplane.io/check-custom-role"
+
+// ReasonExpired indicates the IAM binding managed by a resource no longer
+// grants its role because the time condition of the binding has expired.
+const ReasonExpired xpv1.ConditionReason = "Expired"
+
+// TypeIAMPolicyError resources failed to set the IAM policy of their
+// external resource the last time they tried to.
+const TypeIAMPolicyError xpv1.ConditionType = "IAMPolicyError"
+
+// Reasons a resource failed to set the IAM policy of its external resource.
+const (
+	ReasonPermissionDenied  xpv1.ConditionReason = "PermissionDenied"
+	ReasonResourceNotFound  xpv1.ConditionReason = "ResourceNotFound"
+	ReasonMalformedPolicy   xpv1.ConditionReason = "MalformedPolicy"
+	ReasonSetIAMPolicyError xpv1.ConditionReason = "SetIAMPolicyError"
+
+	// ReasonPublicAccessBlocked indicates the IAM policy was not set because
+	// it would have made the external resource public.
+	ReasonPublicAccessBlocked xpv1.ConditionReason = "PublicAccessBlocked"
+
+	// ReasonRoleNotFound indicates the IAM policy was not set because it
+	// would have bound a custom role that does not exist.
+	ReasonRoleNotFound xpv1.ConditionReason = "RoleNotFound"
+
+	// ReasonDomainNotAllowed indicates the IAM policy was not set because it
+	// would have bound a member of a domain the provider does not allow.
+	ReasonDomainNotAllowed xpv1.ConditionReason = "DomainNotAllowed"
+)
+
+// AnnotationKeyOperation is the annotation that records the name of the
+// pending Google Compute Engine operation creating the external resource of a
+// managed resource. Unlike its status, the annotations of a managed resource
+// are persisted once it was created.
+const AnnotationKeyOperation = "gcp.crossplane.io/operation"
+
+// OperationDone is the status of a finished Google Compute Engine operation.
+const OperationDone = "DONE"
+
+// AnnotationKeyRecreateOnImmutable is the annotation that, when set to "true",
+// causes the external resource of a managed resource to be deleted and
+// recreated when a field that can not be updated differs from the spec, rather
+// than the difference being reported as an error. Recreating an external
+// resource may lose its data, e.g. the unacknowledged messages of a Pub/Sub
+// subscription.
+const AnnotationKeyRecreateOnImmutable = "gcp.crossplane.io/recreate-on-immutable"
+
+// cloudPlatformScope is the OAuth scope requested for the credentials of a
+// ProviderConfig when the HTTP client is built by the provider rather than by
+// the individual GCP API clients.
+const cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+const (
+	errCertificateAuthority = "cannot get certificate authority bundle"
+	errNoCertificates       = "certificate authority bundle contains no PEM encoded certificates"
+	errNewTransport         = "cannot create HTTP transport"
+	errClientCertificate    = "cannot get client certificate"
+	errProxyURL             = "cannot parse proxy URL"
+	errRenderExternalName   = "cannot render external name template"
+	errUpdateManaged        = "cannot update managed resource"
+	errGetProviderConfig    = "cannot get ProviderConfig"
+
+	errFmtInvalidConventionalName = "external name %q that follows the naming convention of the ProviderConfig is not a valid GCP resource name: it must start with a lower case letter, consist of at most 63 lower case letters, digits and dashes, and not end with a dash"
+)
+
+// DefaultProviderConfigName is the name of the ProviderConfig used by managed
+// resources that reference neither a ProviderConfig nor a Provider.
+const DefaultProviderConfigName = "default"
+
 // GetAuthInfo returns the necessary authentication information that is necessary
 // to use when the controller connects to GCP API in order to reconcile the managed
-// resource.
+// resource. Each managed resource uses the credentials of the ProviderConfig it
+// references, so resources of the same kind may use different credentials.
 func GetAuthInfo(ctx context.Context, c client.Client, mg resource.Managed) (projectID string, opts option.ClientOption, err error) {
 	switch {
 	case mg.GetProviderConfigReference() != nil:
@@ -49,7 +185,10 @@ func GetAuthInfo(ctx context.Context, c client.Client, mg resource.Managed) (pro
 	case mg.GetProviderReference() != nil:
 		return UseProvider(ctx, c, mg)
 	default:
-		return "", nil, errors.New("neither providerConfigRef nor providerRef is given")
+		// The providerConfigRef is defaulted by the API server, but may be
+		// missing from resources created before it was.
+		mg.SetProviderConfigReference(&xpv1.Reference{Name: DefaultProviderConfigName})
+		return UseProviderConfig(ctx, c, mg)
 	}
 }
 
@@ -71,19 +210,264 @@ func UseProvider(ctx context.Context, c client.Client, mg resource.Managed) (pro
 
 // UseProviderConfig to return GCP authentication information.
 func UseProviderConfig(ctx context.Context, c client.Client, mg resource.Managed) (projectID string, opts option.ClientOption, err error) {
+	projectID, o, err := useProviderConfig(ctx, c, mg, false, "")
+	if err != nil {
+		return "", nil, err
+	}
+	return projectID, o[0], nil
+}
+
+// GetAuthInfoWithQuotaProject returns the same authentication information as
+// GetAuthInfo, plus the quota project the ProviderConfig of the managed
+// resource configures, if any. Requests are then billed to, and count against
+// the quota of, the quota project rather than the project of the credentials,
+// e.g. to manage requester pays buckets.
+func GetAuthInfoWithQuotaProject(ctx context.Context, c client.Client, mg resource.Managed) (projectID string, opts []option.ClientOption, err error) {
+	return GetAuthInfoForProject(ctx, c, mg, "")
+}
+
+// GetAuthInfoForProject returns the same authentication information as
+// GetAuthInfoWithQuotaProject, except that the supplied project, if any, is
+// returned and used as the quota project instead of the ones the ProviderConfig
+// of the managed resource configures. This lets a single credential manage
+// resources across many projects.
+func GetAuthInfoForProject(ctx context.Context, c client.Client, mg resource.Managed, project string) (projectID string, opts []option.ClientOption, err error) {
+	if mg.GetProviderConfigReference() == nil && mg.GetProviderReference() != nil {
+		projectID, o, err := UseProvider(ctx, c, mg)
+		if err != nil {
+			return "", nil, err
+		}
+		if project != "" {
+			return project, []option.ClientOption{o, option.WithQuotaProject(project)}, nil
+		}
+		return projectID, []option.ClientOption{o}, nil
+	}
+	if mg.GetProviderConfigReference() == nil {
+		mg.SetProviderConfigReference(&xpv1.Reference{Name: DefaultProviderConfigName})
+	}
+	return useProviderConfig(ctx, c, mg, true, project)
+}
+
+// useProviderConfig returns the authentication information of the
+// ProviderConfig of the supplied managed resource, including its quota project
+// if requested. The supplied project, if any, overrides both the project and
+// the quota project of the ProviderConfig. A quota project must be configured
+// on the HTTP transport when the provider builds the HTTP client, because GCP
+// API clients ignore any other options once they are supplied an HTTP client.
+func useProviderConfig(ctx context.Context, c client.Client, mg resource.Managed, quotaProject bool, project string) (projectID string, opts []option.ClientOption, err error) {
+	pc, data, err := providerConfigCredentials(ctx, c, mg)
+	if err != nil {
+		return "", nil, err
+	}
+	projectID, quotaProjectID := pc.Spec.ProjectID, pc.Spec.QuotaProject
+	if project != "" {
+		projectID, quotaProjectID = project, project
+	}
+	var quota []option.ClientOption
+	if quotaProject && quotaProjectID != "" {
+		quota = []option.ClientOption{option.WithQuotaProject(quotaProjectID)}
+	}
+	if pc.Spec.CertificateAuthority == nil && pc.Spec.ClientCertificate == nil && pc.Spec.ProxyURL == "" && pc.Spec.Impersonation == nil {
+		return projectID, append([]option.ClientOption{option.WithCredentialsJSON(data)}, quota...), nil
+	}
+	base, err := newProviderTransport(ctx, c, pc.Spec)
+	if err != nil {
+		return "", nil, err
+	}
+	rt, err := htransport.NewTransport(ctx, base, append(credentialOptions(data, pc.Spec.Impersonation), quota...)...)
+	if err != nil {
+		return "", nil, errors.Wrap(err, errNewTransport)
+	}
+	return projectID, []option.ClientOption{option.WithHTTPClient(&http.Client{Transport: rt})}, nil
+}
+
+// credentialOptions returns the options that authenticate requests to the GCP
+// API with the supplied credentials, as the supplied service account if they
+// impersonate one. Impersonation is configured on the HTTP transport the
+// provider builds, so that it is used by clients that are supplied a single
+// option, too.
+func credentialOptions(data []byte, i *v1beta1.ProviderImpersonation) []option.ClientOption {
+	opts := []option.ClientOption{option.WithCredentialsJSON(data), option.WithScopes(cloudPlatformScope)}
+	if i == nil {
+		return opts
+	}
+	// NOTE: The impersonate package supersedes this option, but requires a
+	// token source rather than credentials, which the transport creates.
+	return append(opts, option.ImpersonateCredentials(i.ServiceAccount, i.Delegates...)) // nolint:staticcheck
+}
+
+// providerConfigCredentials returns the ProviderConfig of the supplied managed
+// resource, and the credentials it configures.
+func providerConfigCredentials(ctx context.Context, c client.Client, mg resource.Managed) (*v1beta1.ProviderConfig, []byte, error) {
 	pc := &v1beta1.ProviderConfig{}
 	t := resource.NewProviderConfigUsageTracker(c, &v1beta1.ProviderConfigUsage{})
 	if err := t.Track(ctx, mg); err != nil {
+		return nil, nil, err
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: mg.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, nil, err
+	}
+	data, err := resource.CommonCredentialExtractor(ctx, pc.Spec.Credentials.Source, c, pc.Spec.Credentials.CommonCredentialSelectors)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot get credentials")
+	}
+	return pc, data, nil
+}
+
+// GetCredentialsMember returns the IAM member the credentials the supplied
+// managed resource is reconciled with authenticate as, e.g. to keep it from
+// removing its own access. No member is returned unless the credentials are
+// the key of a service account, or impersonate one.
+func GetCredentialsMember(ctx context.Context, c client.Client, mg resource.Managed) (string, error) {
+	if mg.GetProviderConfigReference() == nil && mg.GetProviderReference() != nil {
+		p := &v1alpha3.Provider{}
+		if err := c.Get(ctx, types.NamespacedName{Name: mg.GetProviderReference().Name}, p); err != nil {
+			return "", err
+		}
+		ref := p.Spec.CredentialsSecretRef
+		s := &v1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, s); err != nil {
+			return "", err
+		}
+		return CredentialsMember(s.Data[ref.Key]), nil
+	}
+	if mg.GetProviderConfigReference() == nil {
+		mg.SetProviderConfigReference(&xpv1.Reference{Name: DefaultProviderConfigName})
+	}
+	pc, data, err := providerConfigCredentials(ctx, c, mg)
+	if err != nil {
+		return "", err
+	}
+	if pc.Spec.Impersonation != nil {
+		return "serviceAccount:" + pc.Spec.Impersonation.ServiceAccount, nil
+	}
+	return CredentialsMember(data), nil
+}
+
+// CredentialsMember returns the IAM member the supplied JSON credentials
+// authenticate as if they are the key of a service account, or an empty
+// string otherwise.
+func CredentialsMember(data []byte) string {
+	key := struct {
+		Type        string `json:"type"`
+		ClientEmail string `json:"client_email"`
+	}{}
+	if err := json.Unmarshal(data, &key); err != nil || key.Type != "service_account" || key.ClientEmail == "" {
+		return ""
+	}
+	return "serviceAccount:" + key.ClientEmail
+}
+
+// Services whose endpoints can be configured per region.
+const (
+	ServiceStorage  = "storage"
+	ServiceSQLAdmin = "sqladmin"
+	ServiceRedis    = "redis"
+	ServiceRun      = "run"
+
+	// ServiceResourceManager is only used to bind tags to resources in a
+	// location, i.e. buckets.
+	ServiceResourceManager = "cloudresourcemanager"
+)
+
+// GetRegionalAuthInfo returns the same authentication information as
+// GetAuthInfo, plus the endpoint the ProviderConfig of the managed resource
+// configures for the supplied service in the supplied region, if any.
+func GetRegionalAuthInfo(ctx context.Context, c client.Client, mg resource.Managed, service, region string) (projectID string, opts []option.ClientOption, err error) {
+	projectID, o, err := GetAuthInfo(ctx, c, mg)
+	if err != nil {
 		return "", nil, err
 	}
+	opts = []option.ClientOption{o}
+	if mg.GetProviderConfigReference() == nil {
+		return projectID, opts, nil
+	}
+	pc := &v1beta1.ProviderConfig{}
 	if err := c.Get(ctx, types.NamespacedName{Name: mg.GetProviderConfigReference().Name}, pc); err != nil {
 		return "", nil, err
 	}
-	data, err := resource.CommonCredentialExtractor(ctx, pc.Spec.Credentials.Source, c, pc.Spec.Credentials.CommonCredentialSelectors)
+	if ep := ResolveEndpoint(pc.Spec.Endpoints, service, region); ep != "" {
+		opts = append(opts, option.WithEndpoint(ep))
+	}
+	return projectID, opts, nil
+}
+
+// ResolveEndpoint returns the URL of the endpoint of the supplied service in
+// the supplied region, or an empty string if none of the supplied endpoints
+// applies. An endpoint that lists the region takes precedence over one that
+// applies to all regions.
+func ResolveEndpoint(endpoints []v1beta1.ProviderEndpoint, service, region string) string {
+	region = strings.ToLower(region)
+	url := ""
+	for _, ep := range endpoints {
+		if ep.Service != service {
+			continue
+		}
+		if len(ep.Regions) == 0 {
+			if url == "" {
+				url = ep.URL
+			}
+			continue
+		}
+		for _, r := range ep.Regions {
+			if strings.ToLower(r) == region {
+				return strings.ReplaceAll(ep.URL, "{region}", region)
+			}
+		}
+	}
+	return strings.ReplaceAll(url, "{region}", region)
+}
+
+// newProviderTransport returns a copy of the default HTTP transport that
+// trusts the certificate authorities, presents the client certificate, and
+// sends requests through the proxy that the supplied ProviderConfig
+// configures, if any.
+func newProviderTransport(ctx context.Context, c client.Client, spec v1beta1.ProviderConfigSpec) (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	if spec.CertificateAuthority != nil {
+		ca, err := resource.CommonCredentialExtractor(ctx, spec.CertificateAuthority.Source, c, spec.CertificateAuthority.CommonCredentialSelectors)
+		if err != nil {
+			return nil, errors.Wrap(err, errCertificateAuthority)
+		}
+		if t, err = newTLSTransport(ca); err != nil {
+			return nil, err
+		}
+	}
+	if spec.ClientCertificate != nil {
+		data, err := resource.CommonCredentialExtractor(ctx, spec.ClientCertificate.Source, c, spec.ClientCertificate.CommonCredentialSelectors)
+		if err != nil {
+			return nil, errors.Wrap(err, errClientCertificate)
+		}
+		cert, err := tls.X509KeyPair(data, data)
+		if err != nil {
+			return nil, errors.Wrap(err, errClientCertificate)
+		}
+		t.TLSClientConfig.Certificates = []tls.Certificate{cert}
+	}
+	if spec.ProxyURL != "" {
+		u, err := url.Parse(spec.ProxyURL)
+		if err != nil {
+			return nil, errors.Wrap(err, errProxyURL)
+		}
+		t.Proxy = http.ProxyURL(u)
+	}
+	return t, nil
+}
+
+// newTLSTransport returns a copy of the default HTTP transport that trusts the
+// supplied PEM encoded certificate authorities in addition to the system ones.
+func newTLSTransport(ca []byte) (*http.Transport, error) {
+	pool, err := x509.SystemCertPool()
 	if err != nil {
-		return "", nil, errors.Wrap(err, "cannot get credentials")
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.New(errNoCertificates)
 	}
-	return pc.Spec.ProjectID, option.WithCredentialsJSON(data), nil
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	return t, nil
 }
 
 // IsErrorNotFoundGRPC gets a value indicating whether the given error represents
@@ -116,6 +500,18 @@ func IsErrorAlreadyExists(err error) bool {
 	return ok && googleapiErr.Code == http.StatusConflict
 }
 
+// IsErrorConflict gets a value indicating whether the given error represents a
+// conflicting concurrent change, i.e. a "conflict" or "precondition failed"
+// response from the Google API. Such requests can be retried once the
+// resource was read again.
+func IsErrorConflict(err error) bool {
+	if err == nil {
+		return false
+	}
+	googleapiErr, ok := err.(*googleapi.Error)
+	return ok && (googleapiErr.Code == http.StatusConflict || googleapiErr.Code == http.StatusPreconditionFailed)
+}
+
 // IsErrorBadRequest gets a value indicating whether the given error represents
 // a "bad request" response from the Google API
 func IsErrorBadRequest(err error) bool {
@@ -136,6 +532,123 @@ func IsErrorForbidden(err error) bool {
 	return ok && googleapiErr.Code == http.StatusForbidden
 }
 
+// ErrorCode returns the HTTP status code of the supplied error, or of the
+// error it wraps, if it is a response from the Google API, or 0 otherwise.
+func ErrorCode(err error) int {
+	var googleapiErr *googleapi.Error
+	if errors.As(err, &googleapiErr) {
+		return googleapiErr.Code
+	}
+	return 0
+}
+
+// IsErrorRetryable gets a value indicating whether the given error represents a
+// transient failure of the Google API, i.e. a "too many requests" response or
+// a server error. Such requests can be retried as is.
+func IsErrorRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if _, ok := err.(*callTimeoutError); ok {
+		return true
+	}
+	googleapiErr, ok := err.(*googleapi.Error)
+	return ok && (googleapiErr.Code == http.StatusTooManyRequests ||
+		googleapiErr.Code == http.StatusInternalServerError ||
+		googleapiErr.Code == http.StatusBadGateway ||
+		googleapiErr.Code == http.StatusServiceUnavailable)
+}
+
+// DefaultRetryBackoff is the backoff with which calls to the Google API that
+// fail transiently are retried, i.e. they are retried up to three times after
+// waiting about 0.5s, 1s and 2s.
+var DefaultRetryBackoff = wait.Backoff{
+	Duration: 500 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.2,
+	Steps:    4,
+}
+
+// DefaultConflictBackoff is the backoff with which a change to a resource of
+// the Google API that conflicts with a concurrent change to it is made again,
+// i.e. up to four more times after waiting about 0.1s, 0.2s, 0.4s and 0.8s.
+// Its jitter spreads out the attempts of concurrent reconcilers.
+var DefaultConflictBackoff = wait.Backoff{
+	Duration: 100 * time.Millisecond,
+	Factor:   2,
+	Jitter:   0.5,
+	Steps:    5,
+}
+
+// DefaultCallTimeout is how long a single call to the Google API may take by
+// default before it is abandoned.
+const DefaultCallTimeout = 30 * time.Second
+
+// A callTimeoutError is returned by calls to the Google API that were
+// abandoned because they did not complete within their timeout.
+type callTimeoutError struct {
+	timeout time.Duration
+}
+
+func (e *callTimeoutError) Error() string {
+	return "GCP API call did not complete within " + e.timeout.String()
+}
+
+// CallWithTimeout calls the supplied function, which should make a single call
+// to the Google API, with a context that is done once the supplied timeout
+// elapsed. A call that does not complete in time returns a retryable error,
+// i.e. one that satisfies IsErrorRetryable, unless the supplied context is done
+// too. A timeout of zero or less leaves the call unbounded.
+func CallWithTimeout(ctx context.Context, timeout time.Duration, call func(ctx context.Context) error) error {
+	if timeout <= 0 {
+		return call(ctx)
+	}
+	cctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	err := call(cctx)
+	if err != nil && errors.Is(cctx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		return &callTimeoutError{timeout: timeout}
+	}
+	return err
+}
+
+// Retry calls the supplied function, which should make a single call to the
+// Google API, until it returns an error that is not retryable or the steps of
+// the supplied backoff are exhausted. Its last error is returned. A backoff
+// with no more than one step never retries the call.
+func Retry(ctx context.Context, b wait.Backoff, call func() error) error {
+	return retry(ctx, b, IsErrorRetryable, call)
+}
+
+// RetryOnConflict calls the supplied function, which should read, change and
+// write a resource of the Google API that is guarded by an etag, e.g. an IAM
+// policy, until it returns an error that does not indicate a conflicting
+// concurrent change or the steps of the supplied backoff are exhausted. Its
+// last error is returned. Concurrent changes thus converge whether they are
+// made by reconcilers of the same or of distinct replicas of the provider,
+// though replicas only stop contending for the same resources altogether once
+// leader election is enabled. A backoff with no more than one step never
+// retries the change.
+func RetryOnConflict(ctx context.Context, b wait.Backoff, change func() error) error {
+	return retry(ctx, b, func(err error) bool { return IsErrorConflict(errors.Cause(err)) }, change)
+}
+
+func retry(ctx context.Context, b wait.Backoff, retryable func(error) bool, call func() error) error {
+	for {
+		err := call()
+		if !retryable(err) || b.Steps <= 1 {
+			return err
+		}
+		t := time.NewTimer(b.Step())
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return err
+		case <-t.C:
+		}
+	}
+}
+
 // StringValue converts the supplied string pointer to a string, returning the
 // empty string if the pointer is nil.
 func StringValue(v *string) string {
@@ -224,6 +737,20 @@ func LateInitializeStringMap(s map[string]string, from map[string]string) map[st
 	return from
 }
 
+// RemovedLabels returns the sorted keys of the observed labels that are not
+// desired, i.e. the labels that must be explicitly removed from an external
+// resource whose API merges rather than replaces labels when patched.
+func RemovedLabels(desired, observed map[string]string) []string {
+	var removed []string
+	for k := range observed {
+		if _, ok := desired[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+	sort.Strings(removed)
+	return removed
+}
+
 // EquateComputeURLs considers compute APIs to be equal whether they are fully
 // qualified, partially qualified, or unqualified. The compute API will accept
 // unqualified or partially qualified URLs for certain fields, but return fully
@@ -255,3 +782,357 @@ func EquateComputeURLs() cmp.Option {
 		return path.Base(ta) == path.Base(tb)
 	})
 }
+
+// OperationError returns a message describing the errors of the supplied
+// operation, or an empty string if it succeeded.
+func OperationError(op *compute.Operation) string {
+	if op.Error == nil || len(op.Error.Errors) == 0 {
+		return ""
+	}
+	msgs := make([]string, len(op.Error.Errors))
+	for i, e := range op.Error.Errors {
+		msgs[i] = e.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// SetOperationWarnings surfaces any warnings carried by the supplied compute
+// operation as a GCPWarning condition of the supplied resource. It does
+// nothing if the operation is nil or has no warnings.
+func SetOperationWarnings(c resource.Conditioned, op *compute.Operation) {
+	if op == nil || len(op.Warnings) == 0 {
+		return
+	}
+	msgs := make([]string, len(op.Warnings))
+	for i, w := range op.Warnings {
+		msgs[i] = w.Code + ": " + w.Message
+	}
+	c.SetConditions(xpv1.Condition{
+		Type:               TypeGCPWarning,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonOperationWarning,
+		Message:            strings.Join(msgs, "; "),
+	})
+}
+
+// IsDryRun returns true if the supplied object is annotated to report rather
+// than apply changes to its external resource.
+func IsDryRun(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyDryRun] == "true"
+}
+
+// SetDryRun sets a DryRun condition on the supplied resource that describes
+// the change that would have been made to its external resource.
+func SetDryRun(c resource.Conditioned, r xpv1.ConditionReason, change string) {
+	c.SetConditions(xpv1.Condition{
+		Type:               TypeDryRun,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             r,
+		Message:            change,
+	})
+}
+
+// ClearDryRun marks the DryRun condition of the supplied resource false if it
+// is set, i.e. once it is no longer annotated for dry-run.
+func ClearDryRun(c resource.Conditioned) {
+	if c.GetCondition(TypeDryRun).Status != v1.ConditionTrue {
+		return
+	}
+	c.SetConditions(xpv1.Condition{
+		Type:               TypeDryRun,
+		Status:             v1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             xpv1.ReasonReconcileSuccess,
+	})
+}
+
+// SetIAMPolicyError sets an IAMPolicyError condition on the supplied resource
+// that describes why the supplied error occurred setting the IAM policy of
+// its external resource, which is of the supplied kind, e.g. "bucket".
+func SetIAMPolicyError(c resource.Conditioned, kind string, err error) {
+	if err == nil {
+		return
+	}
+	reason, msg := ReasonSetIAMPolicyError, "cannot set the IAM policy of the "+kind+": "+err.Error()
+	var gErr *googleapi.Error
+	if errors.As(err, &gErr) {
+		detail := gErr.Message
+		if detail == "" {
+			detail = gErr.Error()
+		}
+		switch gErr.Code {
+		case http.StatusForbidden:
+			reason, msg = ReasonPermissionDenied, "permission denied: the provider's credentials are not allowed to set the IAM policy of the "+kind+": "+detail
+		case http.StatusNotFound:
+			reason, msg = ReasonResourceNotFound, "the "+kind+" does not exist: "+detail
+		case http.StatusBadRequest:
+			reason, msg = ReasonMalformedPolicy, "the IAM policy is malformed, e.g. a role, member or condition of one of its bindings is invalid: "+detail
+		}
+	}
+	c.SetConditions(xpv1.Condition{
+		Type:               TypeIAMPolicyError,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            msg,
+	})
+}
+
+// ClearIAMPolicyError marks the IAMPolicyError condition of the supplied
+// resource false if it is set, i.e. once its IAM policy was set.
+func ClearIAMPolicyError(c resource.Conditioned) {
+	if c.GetCondition(TypeIAMPolicyError).Status != v1.ConditionTrue {
+		return
+	}
+	c.SetConditions(xpv1.Condition{
+		Type:               TypeIAMPolicyError,
+		Status:             v1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             xpv1.ReasonReconcileSuccess,
+	})
+}
+
+// SetPublicAccessBlocked sets an IAMPolicyError condition on the supplied
+// resource that indicates its IAM policy, which is of a resource of the
+// supplied kind, e.g. "bucket", was not set because binding the supplied
+// public members would have made the resource public.
+func SetPublicAccessBlocked(c resource.Conditioned, kind string, members []string) {
+	c.SetConditions(xpv1.Condition{
+		Type:               TypeIAMPolicyError,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPublicAccessBlocked,
+		Message:            "binding " + strings.Join(members, ", ") + " would make the " + kind + " public: annotate the resource with " + AnnotationKeyAllowPublicAccess + `: "true" to allow public access`,
+	})
+}
+
+// SetDomainNotAllowed sets an IAMPolicyError condition on the supplied
+// resource that indicates its IAM policy was not set because the supplied
+// members are not of one of the supplied domains the provider allows.
+func SetDomainNotAllowed(c resource.Conditioned, members, allowed []string) {
+	c.SetConditions(xpv1.Condition{
+		Type:               TypeIAMPolicyError,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDomainNotAllowed,
+		Message:            "the domains of " + strings.Join(members, ", ") + " are not allowed: bind members of " + strings.Join(allowed, ", ") + " instead",
+	})
+}
+
+// SetResourceNotFound sets an IAMPolicyError condition on the supplied
+// resource that indicates the IAM policy of its external resource, which is of
+// the supplied kind, e.g. "bucket", and has the supplied name, could not be
+// read because the external resource does not exist (yet).
+func SetResourceNotFound(c resource.Conditioned, kind, name string) {
+	c.SetConditions(xpv1.Condition{
+		Type:               TypeIAMPolicyError,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonResourceNotFound,
+		Message:            "the " + kind + " " + name + " does not exist: create it, or reference an existing " + kind,
+	})
+}
+
+// SetRoleNotFound sets an IAMPolicyError condition on the supplied resource
+// that indicates its IAM policy was not set because the supplied custom role
+// does not exist.
+func SetRoleNotFound(c resource.Conditioned, role string) {
+	c.SetConditions(xpv1.Condition{
+		Type:               TypeIAMPolicyError,
+		Status:             v1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRoleNotFound,
+		Message:            "the custom role " + role + " does not exist: create it, or bind a predefined role instead",
+	})
+}
+
+// AllowsPublicAccess returns true if the supplied object is annotated to allow
+// the IAM binding it manages to make its external resource public.
+func AllowsPublicAccess(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyAllowPublicAccess] == "true"
+}
+
+// ChecksCustomRole returns true if the supplied object is annotated to have
+// the existence of the custom role of the IAM binding it manages checked
+// before the binding is applied.
+func ChecksCustomRole(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyCheckCustomRole] == "true"
+}
+
+// RemovesExpired returns true if the supplied object is annotated to have the
+// IAM binding it manages removed once the binding has expired.
+func RemovesExpired(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyRemoveExpired] == "true"
+}
+
+// RecreatesOnImmutable returns true if the supplied object is annotated to have
+// its external resource recreated when a field that can not be updated
+// differs from its spec.
+func RecreatesOnImmutable(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyRecreateOnImmutable] == "true"
+}
+
+// Expired returns a condition that indicates the IAM binding managed by a
+// resource is not available because it expired at the supplied time.
+func Expired(at time.Time) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             v1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonExpired,
+		Message:            "binding expired at " + at.UTC().Format(time.RFC3339),
+	}
+}
+
+// SetCreationStalled sets a Degraded condition on the supplied resource if it
+// is not yet available even though its creation succeeded longer than the
+// supplied ready timeout ago. The condition is cleared once the resource is
+// available. It does nothing if the timeout is zero or the creation time is
+// unknown, and must be called after the resource's Ready condition is set.
+func SetCreationStalled(c resource.Conditioned, created time.Time, timeout time.Duration) {
+	if timeout == 0 || created.IsZero() {
+		return
+	}
+	ready := c.GetCondition(xpv1.TypeReady).Status == v1.ConditionTrue
+	if ready {
+		if c.GetCondition(TypeDegraded).Status == v1.ConditionTrue {
+			c.SetConditions(xpv1.Condition{
+				Type:               TypeDegraded,
+				Status:             v1.ConditionFalse,
+				LastTransitionTime: metav1.Now(),
+				Reason:             xpv1.ReasonAvailable,
+			})
+		}
+		return
+	}
+	if time.Since(created) > timeout {
+		c.SetConditions(xpv1.Condition{
+			Type:               TypeDegraded,
+			Status:             v1.ConditionTrue,
+			LastTransitionTime: metav1.Now(),
+			Reason:             ReasonCreationStalled,
+			Message:            "resource is not available more than " + timeout.String() + " after it was created",
+		})
+	}
+}
+
+// MapConnectionDetails returns the supplied connection details with their keys
+// renamed according to the supplied mapping of default keys to desired keys.
+// Connection details that are not mapped keep their default key.
+func MapConnectionDetails(cd managed.ConnectionDetails, mapping map[string]string) managed.ConnectionDetails {
+	if len(mapping) == 0 {
+		return cd
+	}
+	out := make(managed.ConnectionDetails, len(cd))
+	for k, v := range cd {
+		if m, ok := mapping[k]; ok && m != "" {
+			k = m
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// ExternalNameTemplateData is the data an external name template is rendered
+// against, e.g. "crossplane-{{ .UID }}".
+type ExternalNameTemplateData struct {
+	Name      string
+	Namespace string
+	UID       string
+}
+
+// A TemplatedExternalName initializer sets the external name of a managed
+// resource that does not have one to a name rendered from a template. The
+// name is persisted before the external resource is created, so it remains
+// the same across reconciles.
+type TemplatedExternalName struct {
+	client   client.Client
+	template *template.Template
+}
+
+// NewTemplatedExternalName returns a new TemplatedExternalName that renders
+// the supplied template. It panics if the template cannot be parsed.
+func NewTemplatedExternalName(c client.Client, tmpl string) *TemplatedExternalName {
+	return &TemplatedExternalName{
+		client:   c,
+		template: template.Must(template.New("external-name").Parse(tmpl)),
+	}
+}
+
+// Initialize the external name of the supplied managed resource.
+func (a *TemplatedExternalName) Initialize(ctx context.Context, mg resource.Managed) error {
+	if meta.GetExternalName(mg) != "" {
+		return nil
+	}
+	name := &strings.Builder{}
+	d := ExternalNameTemplateData{Name: mg.GetName(), Namespace: mg.GetNamespace(), UID: string(mg.GetUID())}
+	if err := a.template.Execute(name, d); err != nil {
+		return errors.Wrap(err, errRenderExternalName)
+	}
+	n, err := ConventionalExternalName(ctx, a.client, mg, name.String())
+	if err != nil {
+		return err
+	}
+	meta.SetExternalName(mg, n)
+	return errors.Wrap(a.client.Update(ctx, mg), errUpdateManaged)
+}
+
+// conventionalName matches names that are valid names of any GCP resource,
+// i.e. RFC 1035 labels.
+var conventionalName = regexp.MustCompile(`^[a-z]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+// ConventionalExternalName returns the supplied generated external name of the
+// supplied managed resource prefixed and suffixed as configured by the
+// ProviderConfig it references, if any. It returns an error if the resulting
+// name is not a valid GCP resource name.
+func ConventionalExternalName(ctx context.Context, c client.Client, mg resource.Managed, name string) (string, error) {
+	// Managed resources that reference a deprecated Provider rather than a
+	// ProviderConfig follow no convention.
+	ref := mg.GetProviderConfigReference()
+	if ref == nil {
+		return name, nil
+	}
+	pc := &v1beta1.ProviderConfig{}
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name}, pc); err != nil {
+		return "", errors.Wrap(err, errGetProviderConfig)
+	}
+	en := pc.Spec.ExternalNames
+	if en == nil || (en.Prefix == "" && en.Suffix == "") {
+		return name, nil
+	}
+	name = en.Prefix + name + en.Suffix
+	if !conventionalName.MatchString(name) {
+		return "", errors.Errorf(errFmtInvalidConventionalName, name)
+	}
+	return name, nil
+}
+
+// A NameAsConventionalExternalName initializer sets the external name of a
+// managed resource that does not have one to its name, prefixed and suffixed
+// as configured by its ProviderConfig. It is the NameAsExternalName
+// initializer of managed resources whose external names follow the naming
+// convention of their ProviderConfig.
+type NameAsConventionalExternalName struct {
+	client client.Client
+}
+
+// NewNameAsConventionalExternalName returns a new
+// NameAsConventionalExternalName.
+func NewNameAsConventionalExternalName(c client.Client) *NameAsConventionalExternalName {
+	return &NameAsConventionalExternalName{client: c}
+}
+
+// Initialize the external name of the supplied managed resource.
+func (a *NameAsConventionalExternalName) Initialize(ctx context.Context, mg resource.Managed) error {
+	if meta.GetExternalName(mg) != "" {
+		return nil
+	}
+	name, err := ConventionalExternalName(ctx, a.client, mg, mg.GetName())
+	if err != nil {
+		return err
+	}
+	meta.SetExternalName(mg, name)
+	return errors.Wrap(a.client.Update(ctx, mg), errUpdateManaged)
+}
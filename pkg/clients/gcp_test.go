@@ -0,0 +1,1289 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gcp
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/v1beta1"
+)
+
+func TestTemplatedExternalName(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		template string
+		client   *test.MockClient
+		mg       *fake.Managed
+	}
+	type want struct {
+		externalName string
+		err          error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"AlreadySet": {
+			args: args{
+				template: "{{ .Name }}",
+				mg: &fake.Managed{ObjectMeta: metav1.ObjectMeta{
+					Name:        "cool-resource",
+					Annotations: map[string]string{meta.AnnotationKeyExternalName: "existing"},
+				}},
+			},
+			want: want{
+				externalName: "existing",
+			},
+		},
+		"Generated": {
+			args: args{
+				template: "crossplane-{{ .UID }}",
+				client:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				mg: &fake.Managed{ObjectMeta: metav1.ObjectMeta{
+					Name: "cool-resource",
+					UID:  "5e2f7e4e-ff2e-4c7c-a6a4-0e8f1b1a1d2c",
+				}},
+			},
+			want: want{
+				externalName: "crossplane-5e2f7e4e-ff2e-4c7c-a6a4-0e8f1b1a1d2c",
+			},
+		},
+		"RenderFailed": {
+			args: args{
+				template: "{{ .Unknown }}",
+				mg:       &fake.Managed{ObjectMeta: metav1.ObjectMeta{Name: "cool-resource"}},
+			},
+			want: want{
+				err: errors.Wrap(errors.New(`template: external-name:1:3: executing "external-name" at <.Unknown>: can't evaluate field Unknown in type gcp.ExternalNameTemplateData`), errRenderExternalName),
+			},
+		},
+		"GeneratedWithConvention": {
+			args: args{
+				template: "crossplane-{{ .Name }}",
+				client: &test.MockClient{
+					MockGet:    namingConvention(&v1beta1.ProviderExternalNames{Prefix: "acme-", Suffix: "-prod"}),
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
+				mg: &fake.Managed{
+					ObjectMeta:               metav1.ObjectMeta{Name: "cool-resource"},
+					ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "conventional"}},
+				},
+			},
+			want: want{
+				externalName: "acme-crossplane-cool-resource-prod",
+			},
+		},
+		"UpdateFailed": {
+			args: args{
+				template: "{{ .Name }}",
+				client:   &test.MockClient{MockUpdate: test.NewMockUpdateFn(errBoom)},
+				mg:       &fake.Managed{ObjectMeta: metav1.ObjectMeta{Name: "cool-resource"}},
+			},
+			want: want{
+				externalName: "cool-resource",
+				err:          errors.Wrap(errBoom, errUpdateManaged),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := NewTemplatedExternalName(tc.args.client, tc.args.template).Initialize(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Initialize(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(tc.args.mg)); diff != "" {
+				t.Errorf("Initialize(...): -want external name, +got external name:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTemplatedExternalNameStable(t *testing.T) {
+	mg := &fake.Managed{ObjectMeta: metav1.ObjectMeta{
+		Name: "cool-resource",
+		UID:  "5e2f7e4e-ff2e-4c7c-a6a4-0e8f1b1a1d2c",
+	}}
+	updates := 0
+	c := &test.MockClient{MockUpdate: func(_ context.Context, _ client.Object, _ ...client.UpdateOption) error {
+		updates++
+		return nil
+	}}
+	i := NewTemplatedExternalName(c, "crossplane-{{ .UID }}")
+
+	var names []string
+	for n := 0; n < 3; n++ {
+		if err := i.Initialize(context.Background(), mg); err != nil {
+			t.Fatalf("Initialize(...): %s", err)
+		}
+		names = append(names, meta.GetExternalName(mg))
+	}
+	want := []string{
+		"crossplane-5e2f7e4e-ff2e-4c7c-a6a4-0e8f1b1a1d2c",
+		"crossplane-5e2f7e4e-ff2e-4c7c-a6a4-0e8f1b1a1d2c",
+		"crossplane-5e2f7e4e-ff2e-4c7c-a6a4-0e8f1b1a1d2c",
+	}
+	if diff := cmp.Diff(want, names); diff != "" {
+		t.Errorf("Initialize(...): -want external names, +got external names:\n%s", diff)
+	}
+	if diff := cmp.Diff(1, updates); diff != "" {
+		t.Errorf("Initialize(...): -want updates, +got updates:\n%s", diff)
+	}
+}
+
+// namingConvention returns a MockGetFn that gets a ProviderConfig with the
+// supplied naming convention.
+func namingConvention(en *v1beta1.ProviderExternalNames) test.MockGetFn {
+	return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+		pc, ok := obj.(*v1beta1.ProviderConfig)
+		if !ok {
+			return errors.New("not a ProviderConfig")
+		}
+		pc.Spec.ExternalNames = en
+		return nil
+	}
+}
+
+func TestNameAsConventionalExternalName(t *testing.T) {
+	errBoom := errors.New("boom")
+	conventional := fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "conventional"}}
+
+	type args struct {
+		client *test.MockClient
+		mg     *fake.Managed
+	}
+	type want struct {
+		externalName string
+		err          error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"AlreadySet": {
+			args: args{
+				mg: &fake.Managed{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "cool-resource",
+						Annotations: map[string]string{meta.AnnotationKeyExternalName: "Existing_Name"},
+					},
+					ProviderConfigReferencer: conventional,
+				},
+			},
+			want: want{
+				externalName: "Existing_Name",
+			},
+		},
+		"NoConvention": {
+			args: args{
+				client: &test.MockClient{
+					MockGet:    namingConvention(nil),
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
+				mg: &fake.Managed{ObjectMeta: metav1.ObjectMeta{Name: "Cool_Resource"}, ProviderConfigReferencer: conventional},
+			},
+			want: want{
+				externalName: "Cool_Resource",
+			},
+		},
+		"DeprecatedProvider": {
+			args: args{
+				client: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				mg:     &fake.Managed{ObjectMeta: metav1.ObjectMeta{Name: "cool-resource"}},
+			},
+			want: want{
+				externalName: "cool-resource",
+			},
+		},
+		"PrefixedAndSuffixed": {
+			args: args{
+				client: &test.MockClient{
+					MockGet:    namingConvention(&v1beta1.ProviderExternalNames{Prefix: "acme-", Suffix: "-prod"}),
+					MockUpdate: test.NewMockUpdateFn(nil),
+				},
+				mg: &fake.Managed{ObjectMeta: metav1.ObjectMeta{Name: "cool-resource"}, ProviderConfigReferencer: conventional},
+			},
+			want: want{
+				externalName: "acme-cool-resource-prod",
+			},
+		},
+		"TooLong": {
+			args: args{
+				client: &test.MockClient{MockGet: namingConvention(&v1beta1.ProviderExternalNames{Prefix: "acme-"})},
+				mg: &fake.Managed{
+					ObjectMeta:               metav1.ObjectMeta{Name: "a-managed-resource-whose-name-is-only-valid-without-a-prefix"},
+					ProviderConfigReferencer: conventional,
+				},
+			},
+			want: want{
+				err: errors.Errorf(errFmtInvalidConventionalName, "acme-a-managed-resource-whose-name-is-only-valid-without-a-prefix"),
+			},
+		},
+		"InvalidCharacters": {
+			args: args{
+				client: &test.MockClient{MockGet: namingConvention(&v1beta1.ProviderExternalNames{Suffix: "-prod"})},
+				mg:     &fake.Managed{ObjectMeta: metav1.ObjectMeta{Name: "cool.resource"}, ProviderConfigReferencer: conventional},
+			},
+			want: want{
+				err: errors.Errorf(errFmtInvalidConventionalName, "cool.resource-prod"),
+			},
+		},
+		"GetProviderConfigFailed": {
+			args: args{
+				client: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				mg:     &fake.Managed{ObjectMeta: metav1.ObjectMeta{Name: "cool-resource"}, ProviderConfigReferencer: conventional},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errGetProviderConfig),
+			},
+		},
+		"UpdateFailed": {
+			args: args{
+				client: &test.MockClient{
+					MockGet:    namingConvention(&v1beta1.ProviderExternalNames{Prefix: "acme-"}),
+					MockUpdate: test.NewMockUpdateFn(errBoom),
+				},
+				mg: &fake.Managed{ObjectMeta: metav1.ObjectMeta{Name: "cool-resource"}, ProviderConfigReferencer: conventional},
+			},
+			want: want{
+				externalName: "acme-cool-resource",
+				err:          errors.Wrap(errBoom, errUpdateManaged),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := NewNameAsConventionalExternalName(tc.args.client).Initialize(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Initialize(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(tc.args.mg)); diff != "" {
+				t.Errorf("Initialize(...): -want external name, +got external name:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewTLSTransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	// The server's certificate must not be trusted by default for this test
+	// to be meaningful.
+	if rsp, err := http.Get(server.URL); err == nil {
+		_ = rsp.Body.Close()
+		t.Fatal("http.Get(...): server certificate is trusted without a certificate authority bundle")
+	}
+
+	type want struct {
+		err     error
+		trusted bool
+	}
+	cases := map[string]struct {
+		ca   []byte
+		want want
+	}{
+		"Trusted": {
+			ca: ca,
+			want: want{
+				trusted: true,
+			},
+		},
+		"NoCertificates": {
+			ca: []byte("not a certificate"),
+			want: want{
+				err: errors.New(errNoCertificates),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tr, err := newTLSTransport(tc.ca)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("newTLSTransport(...): -want error, +got error:\n%s", diff)
+			}
+			if err != nil {
+				return
+			}
+			rsp, err := (&http.Client{Transport: tr}).Get(server.URL)
+			if err == nil {
+				_ = rsp.Body.Close()
+			}
+			if diff := cmp.Diff(tc.want.trusted, err == nil); diff != "" {
+				t.Errorf("newTLSTransport(...): -want trusted, +got trusted:\n%s\n%v", diff, err)
+			}
+		})
+	}
+}
+
+// newClientCertificate returns a PEM encoded self-signed client certificate
+// followed by its private key.
+func newClientCertificate(t *testing.T) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey(...): %s", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "crossplane"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate(...): %s", err)
+	}
+	k, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey(...): %s", err)
+	}
+	return append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: k})...)
+}
+
+func TestNewProviderTransport(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.Host)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	var presented int
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		presented = len(r.TLS.PeerCertificates)
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert, MinVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+
+	secrets := map[string][]byte{
+		"ca":   pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}),
+		"cert": newClientCertificate(t),
+		"bad":  []byte("not a certificate"),
+	}
+	c := &test.MockClient{MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		obj.(*corev1.Secret).Data = map[string][]byte{"data": secrets[key.Name]}
+		return nil
+	}}
+	selector := func(name string) xpv1.CommonCredentialSelectors {
+		return xpv1.CommonCredentialSelectors{SecretRef: &xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Name: name, Namespace: "crossplane-system"},
+			Key:             "data",
+		}}
+	}
+
+	type want struct {
+		err       bool
+		proxied   []string
+		presented int
+	}
+	cases := map[string]struct {
+		reason string
+		spec   v1beta1.ProviderConfigSpec
+		url    string
+		want   want
+	}{
+		"Proxy": {
+			reason: "Requests should be sent through the proxy of the ProviderConfig.",
+			spec:   v1beta1.ProviderConfigSpec{ProxyURL: proxy.URL},
+			url:    "http://storage.googleapis.com/",
+			want:   want{proxied: []string{"storage.googleapis.com"}},
+		},
+		"ClientCertificate": {
+			reason: "The client certificate of the ProviderConfig should be presented to servers that require one.",
+			spec: v1beta1.ProviderConfigSpec{
+				CertificateAuthority: &v1beta1.ProviderCertificateAuthority{Source: xpv1.CredentialsSourceSecret, CommonCredentialSelectors: selector("ca")},
+				ClientCertificate:    &v1beta1.ProviderClientCertificate{Source: xpv1.CredentialsSourceSecret, CommonCredentialSelectors: selector("cert")},
+			},
+			url:  server.URL,
+			want: want{presented: 1},
+		},
+		"InvalidClientCertificate": {
+			reason: "A client certificate that cannot be parsed should be an error.",
+			spec: v1beta1.ProviderConfigSpec{
+				ClientCertificate: &v1beta1.ProviderClientCertificate{Source: xpv1.CredentialsSourceSecret, CommonCredentialSelectors: selector("bad")},
+			},
+			want: want{err: true},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			proxied, presented = nil, 0
+			tr, err := newProviderTransport(context.Background(), c, tc.spec)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("\n%s\nnewProviderTransport(...): -want error, +got error: %v", tc.reason, err)
+			}
+			if err != nil {
+				return
+			}
+			rsp, err := (&http.Client{Transport: tr}).Get(tc.url)
+			if err != nil {
+				t.Fatalf("\n%s\nGet(...): %s", tc.reason, err)
+			}
+			_ = rsp.Body.Close()
+			if diff := cmp.Diff(tc.want.proxied, proxied); diff != "" {
+				t.Errorf("\n%s\nnewProviderTransport(...): -want proxied hosts, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.presented, presented); diff != "" {
+				t.Errorf("\n%s\nnewProviderTransport(...): -want presented client certificates, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetAuthInfo(t *testing.T) {
+	// Each ProviderConfig reads the credentials from the secret of the same
+	// name, and uses a project of the same name.
+	c := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *v1beta1.ProviderConfig:
+				o.Spec = v1beta1.ProviderConfigSpec{
+					ProjectID: key.Name,
+					Credentials: v1beta1.ProviderCredentials{
+						Source: xpv1.CredentialsSourceSecret,
+						CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: &xpv1.SecretKeySelector{
+							SecretReference: xpv1.SecretReference{Name: key.Name, Namespace: "crossplane-system"},
+							Key:             "credentials",
+						}},
+					},
+				}
+			case *corev1.Secret:
+				o.Data = map[string][]byte{"credentials": []byte(key.Name + "-credentials")}
+			default:
+				return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+			}
+			return nil
+		},
+		MockCreate: test.NewMockCreateFn(nil),
+	}
+
+	type want struct {
+		projectID string
+		opts      option.ClientOption
+		ref       *xpv1.Reference
+	}
+	cases := map[string]struct {
+		reason string
+		mg     *fake.Managed
+		want   want
+	}{
+		"Privileged": {
+			reason: "A resource should use the credentials of the ProviderConfig it references",
+			mg:     &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "privileged"}}},
+			want: want{
+				projectID: "privileged",
+				opts:      option.WithCredentialsJSON([]byte("privileged-credentials")),
+				ref:       &xpv1.Reference{Name: "privileged"},
+			},
+		},
+		"Restricted": {
+			reason: "A resource of the same kind should be able to use the credentials of a different ProviderConfig",
+			mg:     &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "restricted"}}},
+			want: want{
+				projectID: "restricted",
+				opts:      option.WithCredentialsJSON([]byte("restricted-credentials")),
+				ref:       &xpv1.Reference{Name: "restricted"},
+			},
+		},
+		"Default": {
+			reason: "A resource that references no ProviderConfig should use the default one",
+			mg:     &fake.Managed{},
+			want: want{
+				projectID: DefaultProviderConfigName,
+				opts:      option.WithCredentialsJSON([]byte(DefaultProviderConfigName + "-credentials")),
+				ref:       &xpv1.Reference{Name: DefaultProviderConfigName},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			projectID, opts, err := GetAuthInfo(context.Background(), c, tc.mg)
+			if err != nil {
+				t.Fatalf("\n%s\nGetAuthInfo(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.projectID, projectID); diff != "" {
+				t.Errorf("\n%s\nGetAuthInfo(...): -want project, +got project:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.opts, opts); diff != "" {
+				t.Errorf("\n%s\nGetAuthInfo(...): -want options, +got options:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ref, tc.mg.GetProviderConfigReference()); diff != "" {
+				t.Errorf("\n%s\nGetAuthInfo(...): -want providerConfigRef, +got providerConfigRef:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetAuthInfoWithQuotaProject(t *testing.T) {
+	// Each ProviderConfig reads the credentials from the secret of the same
+	// name. Only the ProviderConfig named billed configures a quota project.
+	c := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *v1beta1.ProviderConfig:
+				o.Spec = v1beta1.ProviderConfigSpec{
+					ProjectID: key.Name,
+					Credentials: v1beta1.ProviderCredentials{
+						Source: xpv1.CredentialsSourceSecret,
+						CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: &xpv1.SecretKeySelector{
+							SecretReference: xpv1.SecretReference{Name: key.Name, Namespace: "crossplane-system"},
+							Key:             "credentials",
+						}},
+					},
+				}
+				if key.Name == "billed" {
+					o.Spec.QuotaProject = "billing-project"
+				}
+			case *corev1.Secret:
+				o.Data = map[string][]byte{"credentials": []byte(key.Name + "-credentials")}
+			default:
+				return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+			}
+			return nil
+		},
+		MockCreate: test.NewMockCreateFn(nil),
+	}
+
+	type want struct {
+		projectID string
+		opts      []option.ClientOption
+	}
+	cases := map[string]struct {
+		reason string
+		mg     *fake.Managed
+		want   want
+	}{
+		"QuotaProject": {
+			reason: "The quota project of the ProviderConfig should be added to the client options",
+			mg:     &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "billed"}}},
+			want: want{
+				projectID: "billed",
+				opts: []option.ClientOption{
+					option.WithCredentialsJSON([]byte("billed-credentials")),
+					option.WithQuotaProject("billing-project"),
+				},
+			},
+		},
+		"NoQuotaProject": {
+			reason: "No quota project should be added to the client options if the ProviderConfig configures none",
+			mg:     &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "unbilled"}}},
+			want: want{
+				projectID: "unbilled",
+				opts:      []option.ClientOption{option.WithCredentialsJSON([]byte("unbilled-credentials"))},
+			},
+		},
+		"Default": {
+			reason: "A resource that references no ProviderConfig should use the default one",
+			mg:     &fake.Managed{},
+			want: want{
+				projectID: DefaultProviderConfigName,
+				opts:      []option.ClientOption{option.WithCredentialsJSON([]byte(DefaultProviderConfigName + "-credentials"))},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			projectID, opts, err := GetAuthInfoWithQuotaProject(context.Background(), c, tc.mg)
+			if err != nil {
+				t.Fatalf("\n%s\nGetAuthInfoWithQuotaProject(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.projectID, projectID); diff != "" {
+				t.Errorf("\n%s\nGetAuthInfoWithQuotaProject(...): -want project, +got project:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.opts, opts); diff != "" {
+				t.Errorf("\n%s\nGetAuthInfoWithQuotaProject(...): -want options, +got options:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetAuthInfoForProject(t *testing.T) {
+	// The ProviderConfig configures the billing-project as its quota project.
+	c := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *v1beta1.ProviderConfig:
+				o.Spec = v1beta1.ProviderConfigSpec{
+					ProjectID:    "admin-project",
+					QuotaProject: "billing-project",
+					Credentials: v1beta1.ProviderCredentials{
+						Source: xpv1.CredentialsSourceSecret,
+						CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: &xpv1.SecretKeySelector{
+							SecretReference: xpv1.SecretReference{Name: key.Name, Namespace: "crossplane-system"},
+							Key:             "credentials",
+						}},
+					},
+				}
+			case *corev1.Secret:
+				o.Data = map[string][]byte{"credentials": []byte(key.Name + "-credentials")}
+			default:
+				return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+			}
+			return nil
+		},
+		MockCreate: test.NewMockCreateFn(nil),
+	}
+
+	type want struct {
+		projectID string
+		opts      []option.ClientOption
+	}
+	cases := map[string]struct {
+		reason  string
+		project string
+		want    want
+	}{
+		"Project": {
+			reason:  "The project of the resource should override the project and quota project of the ProviderConfig",
+			project: "bucket-project",
+			want: want{
+				projectID: "bucket-project",
+				opts: []option.ClientOption{
+					option.WithCredentialsJSON([]byte(DefaultProviderConfigName + "-credentials")),
+					option.WithQuotaProject("bucket-project"),
+				},
+			},
+		},
+		"NoProject": {
+			reason: "The project and quota project of the ProviderConfig should be used if the resource sets no project",
+			want: want{
+				projectID: "admin-project",
+				opts: []option.ClientOption{
+					option.WithCredentialsJSON([]byte(DefaultProviderConfigName + "-credentials")),
+					option.WithQuotaProject("billing-project"),
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			projectID, opts, err := GetAuthInfoForProject(context.Background(), c, &fake.Managed{}, tc.project)
+			if err != nil {
+				t.Fatalf("\n%s\nGetAuthInfoForProject(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.projectID, projectID); diff != "" {
+				t.Errorf("\n%s\nGetAuthInfoForProject(...): -want project, +got project:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.opts, opts); diff != "" {
+				t.Errorf("\n%s\nGetAuthInfoForProject(...): -want options, +got options:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCredentialsMember(t *testing.T) {
+	cases := map[string]struct {
+		data string
+		want string
+	}{
+		"ServiceAccountKey": {
+			data: `{"type": "service_account", "client_email": "crossplane@my-project.iam.gserviceaccount.com"}`,
+			want: "serviceAccount:crossplane@my-project.iam.gserviceaccount.com",
+		},
+		"AuthorizedUser": {
+			data: `{"type": "authorized_user", "client_id": "123.apps.googleusercontent.com"}`,
+		},
+		"NotJSON": {
+			data: "credentials",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, CredentialsMember([]byte(tc.data))); diff != "" {
+				t.Errorf("CredentialsMember(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCredentialOptions(t *testing.T) {
+	data := []byte("credentials")
+	cases := map[string]struct {
+		reason string
+		i      *v1beta1.ProviderImpersonation
+		want   []option.ClientOption
+	}{
+		"NoImpersonation": {
+			reason: "Credentials that impersonate no service account should authenticate as themselves",
+			want:   []option.ClientOption{option.WithCredentialsJSON(data), option.WithScopes(cloudPlatformScope)},
+		},
+		"ServiceAccount": {
+			reason: "Credentials that impersonate a service account should authenticate as it",
+			i:      &v1beta1.ProviderImpersonation{ServiceAccount: "crossplane@my-project.iam.gserviceaccount.com"},
+			want: []option.ClientOption{
+				option.WithCredentialsJSON(data),
+				option.WithScopes(cloudPlatformScope),
+				option.ImpersonateCredentials("crossplane@my-project.iam.gserviceaccount.com"), // nolint:staticcheck
+			},
+		},
+		"Delegates": {
+			reason: "Credentials that impersonate a service account through delegates should pass the delegates through in order",
+			i: &v1beta1.ProviderImpersonation{
+				ServiceAccount: "crossplane@my-project.iam.gserviceaccount.com",
+				Delegates:      []string{"first@my-project.iam.gserviceaccount.com", "second@my-project.iam.gserviceaccount.com"},
+			},
+			want: []option.ClientOption{
+				option.WithCredentialsJSON(data),
+				option.WithScopes(cloudPlatformScope),
+				option.ImpersonateCredentials("crossplane@my-project.iam.gserviceaccount.com", // nolint:staticcheck
+					"first@my-project.iam.gserviceaccount.com", "second@my-project.iam.gserviceaccount.com"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := credentialOptions(data, tc.i)
+			if diff := cmp.Diff(tc.want, got, cmp.Exporter(func(reflect.Type) bool { return true })); diff != "" {
+				t.Errorf("\n%s\ncredentialOptions(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetCredentialsMemberImpersonation(t *testing.T) {
+	c := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *v1beta1.ProviderConfig:
+				o.Spec = v1beta1.ProviderConfigSpec{
+					Credentials: v1beta1.ProviderCredentials{
+						Source: xpv1.CredentialsSourceSecret,
+						CommonCredentialSelectors: xpv1.CommonCredentialSelectors{SecretRef: &xpv1.SecretKeySelector{
+							SecretReference: xpv1.SecretReference{Name: key.Name, Namespace: "crossplane-system"},
+							Key:             "credentials",
+						}},
+					},
+				}
+				if key.Name == "impersonating" {
+					o.Spec.Impersonation = &v1beta1.ProviderImpersonation{
+						ServiceAccount: "impersonated@my-project.iam.gserviceaccount.com",
+						Delegates:      []string{"delegate@my-project.iam.gserviceaccount.com"},
+					}
+				}
+			case *corev1.Secret:
+				o.Data = map[string][]byte{"credentials": []byte(`{"type": "service_account", "client_email": "crossplane@my-project.iam.gserviceaccount.com"}`)}
+			default:
+				return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+			}
+			return nil
+		},
+		MockCreate: test.NewMockCreateFn(nil),
+	}
+
+	cases := map[string]struct {
+		reason string
+		mg     *fake.Managed
+		want   string
+	}{
+		"Impersonating": {
+			reason: "Credentials that impersonate a service account should authenticate as it",
+			mg:     &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "impersonating"}}},
+			want:   "serviceAccount:impersonated@my-project.iam.gserviceaccount.com",
+		},
+		"NotImpersonating": {
+			reason: "Credentials that impersonate no service account should authenticate as themselves",
+			mg:     &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "direct"}}},
+			want:   "serviceAccount:crossplane@my-project.iam.gserviceaccount.com",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := GetCredentialsMember(context.Background(), c, tc.mg)
+			if err != nil {
+				t.Fatalf("\n%s\nGetCredentialsMember(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGetCredentialsMember(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestResolveEndpoint(t *testing.T) {
+	endpoints := []v1beta1.ProviderEndpoint{
+		{Service: ServiceStorage, URL: "https://storage.{region}.rep.googleapis.com/storage/v1/"},
+		{Service: ServiceStorage, URL: "https://storage.example.com/storage/v1/", Regions: []string{"europe-west3"}},
+		{Service: ServiceSQLAdmin, URL: "https://sqladmin.{region}.rep.googleapis.com/", Regions: []string{"us-east4"}},
+	}
+
+	type args struct {
+		service string
+		region  string
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   string
+	}{
+		"Template": {
+			reason: "The region should be rendered into an endpoint that applies to all regions",
+			args:   args{service: ServiceStorage, region: "US-EAST4"},
+			want:   "https://storage.us-east4.rep.googleapis.com/storage/v1/",
+		},
+		"Region": {
+			reason: "An endpoint that lists the region should take precedence",
+			args:   args{service: ServiceStorage, region: "europe-west3"},
+			want:   "https://storage.example.com/storage/v1/",
+		},
+		"OtherRegion": {
+			reason: "No endpoint should be used for a region that is not listed",
+			args:   args{service: ServiceSQLAdmin, region: "us-central1"},
+			want:   "",
+		},
+		"OtherService": {
+			reason: "No endpoint should be used for a service that is not configured",
+			args:   args{service: ServiceRedis, region: "us-east4"},
+			want:   "",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ResolveEndpoint(endpoints, tc.args.service, tc.args.region)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nResolveEndpoint(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRemovedLabels(t *testing.T) {
+	desired := map[string]string{"team": "payments"}
+	observed := map[string]string{"team": "core", "env": "dev", "app": "api"}
+	want := []string{"app", "env"}
+	if diff := cmp.Diff(want, RemovedLabels(desired, observed)); diff != "" {
+		t.Errorf("RemovedLabels(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	backoff := wait.Backoff{Duration: time.Millisecond, Factor: 2, Steps: 3}
+
+	type want struct {
+		calls int
+		err   error
+	}
+	cases := map[string]struct {
+		reason string
+		errs   []error
+		want   want
+	}{
+		"Succeeded": {
+			reason: "A call that succeeds should not be retried",
+			errs:   []error{nil},
+			want:   want{calls: 1},
+		},
+		"RetriedUnavailable": {
+			reason: "A call that fails because the service is unavailable should be retried until it succeeds",
+			errs:   []error{&googleapi.Error{Code: http.StatusServiceUnavailable}, &googleapi.Error{Code: http.StatusTooManyRequests}, nil},
+			want:   want{calls: 3},
+		},
+		"RetriedTimedOut": {
+			reason: "A call that was abandoned because it timed out should be retried until it succeeds",
+			errs:   []error{&callTimeoutError{timeout: time.Second}, nil},
+			want:   want{calls: 2},
+		},
+		"StepsExhausted": {
+			reason: "A call that keeps failing transiently should return its last error once the backoff is exhausted",
+			errs: []error{
+				&googleapi.Error{Code: http.StatusServiceUnavailable},
+				&googleapi.Error{Code: http.StatusServiceUnavailable},
+				&googleapi.Error{Code: http.StatusInternalServerError},
+			},
+			want: want{calls: 3, err: &googleapi.Error{Code: http.StatusInternalServerError}},
+		},
+		"NotRetriedForbidden": {
+			reason: "A call that fails because it is forbidden should not be retried",
+			errs:   []error{&googleapi.Error{Code: http.StatusForbidden}, nil},
+			want:   want{calls: 1, err: &googleapi.Error{Code: http.StatusForbidden}},
+		},
+		"NotRetriedNotFound": {
+			reason: "A call that fails because the resource does not exist should not be retried",
+			errs:   []error{&googleapi.Error{Code: http.StatusNotFound}, nil},
+			want:   want{calls: 1, err: &googleapi.Error{Code: http.StatusNotFound}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			err := Retry(context.Background(), backoff, func() error {
+				calls++
+				return tc.errs[calls-1]
+			})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nRetry(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("\n%s\nRetry(...): -want calls, +got calls:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCallWithTimeout(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	// block returns once the context of the call is done, like a call whose
+	// response never arrives.
+	block := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	type args struct {
+		ctx     context.Context
+		timeout time.Duration
+		call    func(ctx context.Context) error
+	}
+	type want struct {
+		err       error
+		retryable bool
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Completed": {
+			reason: "A call that completes in time should return its error",
+			args: args{
+				ctx:     context.Background(),
+				timeout: time.Second,
+				call:    func(_ context.Context) error { return &googleapi.Error{Code: http.StatusForbidden} },
+			},
+			want: want{err: &googleapi.Error{Code: http.StatusForbidden}},
+		},
+		"TimedOut": {
+			reason: "A call that does not complete in time should return a retryable error",
+			args: args{
+				ctx:     context.Background(),
+				timeout: time.Millisecond,
+				call:    block,
+			},
+			want: want{err: &callTimeoutError{timeout: time.Millisecond}, retryable: true},
+		},
+		"ParentDone": {
+			reason: "A call whose parent context is done should return its error rather than a retryable one",
+			args: args{
+				ctx:     cancelled,
+				timeout: time.Second,
+				call:    block,
+			},
+			want: want{err: context.Canceled},
+		},
+		"Unbounded": {
+			reason: "A call without a timeout should be made with the supplied context",
+			args: args{
+				ctx: context.Background(),
+				call: func(ctx context.Context) error {
+					if _, ok := ctx.Deadline(); ok {
+						return errBoom
+					}
+					return nil
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := CallWithTimeout(tc.args.ctx, tc.args.timeout, tc.args.call)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCallWithTimeout(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.retryable, IsErrorRetryable(err)); diff != "" {
+				t.Errorf("\n%s\nIsErrorRetryable(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRetryOnConflict(t *testing.T) {
+	backoff := wait.Backoff{Duration: time.Millisecond, Factor: 2, Steps: 4}
+	stale := &googleapi.Error{Code: http.StatusPreconditionFailed}
+
+	type want struct {
+		changes int
+		err     error
+	}
+	cases := map[string]struct {
+		reason string
+		errs   []error
+		want   want
+	}{
+		"Succeeded": {
+			reason: "A change that succeeds should not be made again",
+			errs:   []error{nil},
+			want:   want{changes: 1},
+		},
+		"ConvergedAfterStaleEtags": {
+			reason: "A change that repeatedly conflicts with concurrent changes should be made again until it succeeds",
+			errs:   []error{stale, errors.Wrap(&googleapi.Error{Code: http.StatusConflict}, "cannot set policy"), stale, nil},
+			want:   want{changes: 4},
+		},
+		"StepsExhausted": {
+			reason: "A change that keeps conflicting should return its last error once the backoff is exhausted",
+			errs:   []error{stale, stale, stale, stale, nil},
+			want:   want{changes: 4, err: stale},
+		},
+		"NotRetriedUnavailable": {
+			reason: "A change that fails for any other reason should not be made again",
+			errs:   []error{&googleapi.Error{Code: http.StatusServiceUnavailable}, nil},
+			want:   want{changes: 1, err: &googleapi.Error{Code: http.StatusServiceUnavailable}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			changes := 0
+			err := RetryOnConflict(context.Background(), backoff, func() error {
+				changes++
+				return tc.errs[changes-1]
+			})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nRetryOnConflict(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.changes, changes); diff != "" {
+				t.Errorf("\n%s\nRetryOnConflict(...): -want changes, +got changes:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSetCreationStalled(t *testing.T) {
+	stalled := xpv1.Condition{
+		Type:    TypeDegraded,
+		Status:  corev1.ConditionTrue,
+		Reason:  ReasonCreationStalled,
+		Message: "resource is not available more than 1h0m0s after it was created",
+	}
+	recovered := xpv1.Condition{Type: TypeDegraded, Status: corev1.ConditionFalse, Reason: xpv1.ReasonAvailable}
+	created := time.Now().Add(-2 * time.Hour)
+
+	type args struct {
+		conditions []xpv1.Condition
+		created    time.Time
+		timeout    time.Duration
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   []xpv1.Condition
+	}{
+		"Stalled": {
+			reason: "A resource that is not available longer than its ready timeout after it was created should be degraded",
+			args:   args{conditions: []xpv1.Condition{xpv1.Creating()}, created: created, timeout: time.Hour},
+			want:   []xpv1.Condition{xpv1.Creating(), stalled},
+		},
+		"WithinTimeout": {
+			reason: "A resource that is not available within its ready timeout should not be degraded",
+			args:   args{conditions: []xpv1.Condition{xpv1.Creating()}, created: created, timeout: 3 * time.Hour},
+			want:   []xpv1.Condition{xpv1.Creating()},
+		},
+		"NoTimeout": {
+			reason: "A resource without a ready timeout should never be degraded",
+			args:   args{conditions: []xpv1.Condition{xpv1.Creating()}, created: created},
+			want:   []xpv1.Condition{xpv1.Creating()},
+		},
+		"NotCreated": {
+			reason: "A resource that was not yet created should not be degraded",
+			args:   args{conditions: []xpv1.Condition{xpv1.Creating()}, timeout: time.Hour},
+			want:   []xpv1.Condition{xpv1.Creating()},
+		},
+		"Recovered": {
+			reason: "A degraded resource should no longer be degraded once it becomes available",
+			args:   args{conditions: []xpv1.Condition{xpv1.Available(), stalled}, created: created, timeout: time.Hour},
+			want:   []xpv1.Condition{xpv1.Available(), recovered},
+		},
+		"Available": {
+			reason: "An available resource that was never degraded should not gain a Degraded condition",
+			args:   args{conditions: []xpv1.Condition{xpv1.Available()}, created: created, timeout: time.Hour},
+			want:   []xpv1.Condition{xpv1.Available()},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{}
+			mg.SetConditions(tc.args.conditions...)
+			SetCreationStalled(mg, tc.args.created, tc.args.timeout)
+			want := &fake.Managed{}
+			want.SetConditions(tc.want...)
+			if diff := cmp.Diff(want.Conditions, mg.Conditions, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nSetCreationStalled(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSetIAMPolicyError(t *testing.T) {
+	failed := func(r xpv1.ConditionReason, msg string) xpv1.Condition {
+		return xpv1.Condition{Type: TypeIAMPolicyError, Status: corev1.ConditionTrue, Reason: r, Message: msg}
+	}
+
+	type args struct {
+		conditions []xpv1.Condition
+		err        error
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   []xpv1.Condition
+	}{
+		"NoError": {
+			reason: "No condition should be set if the IAM policy was set",
+			args:   args{conditions: []xpv1.Condition{xpv1.Available()}},
+			want:   []xpv1.Condition{xpv1.Available()},
+		},
+		"Forbidden": {
+			reason: "A forbidden response should be reported as a missing permission",
+			args:   args{err: &googleapi.Error{Code: http.StatusForbidden, Message: "caller lacks storage.buckets.setIamPolicy"}},
+			want: []xpv1.Condition{failed(ReasonPermissionDenied,
+				"permission denied: the provider's credentials are not allowed to set the IAM policy of the bucket: caller lacks storage.buckets.setIamPolicy")},
+		},
+		"NotFound": {
+			reason: "A not found response should be reported as a missing resource",
+			args:   args{err: errors.Wrap(&googleapi.Error{Code: http.StatusNotFound, Message: "bucket not found"}, "cannot set policy")},
+			want:   []xpv1.Condition{failed(ReasonResourceNotFound, "the bucket does not exist: bucket not found")},
+		},
+		"BadRequest": {
+			reason: "A bad request response should be reported as a malformed policy",
+			args:   args{err: &googleapi.Error{Code: http.StatusBadRequest, Message: "invalid member"}},
+			want: []xpv1.Condition{failed(ReasonMalformedPolicy,
+				"the IAM policy is malformed, e.g. a role, member or condition of one of its bindings is invalid: invalid member")},
+		},
+		"Other": {
+			reason: "Any other error should be reported as is",
+			args:   args{err: errors.New("boom")},
+			want:   []xpv1.Condition{failed(ReasonSetIAMPolicyError, "cannot set the IAM policy of the bucket: boom")},
+		},
+		"Replaced": {
+			reason: "The condition should describe the most recent error only",
+			args: args{
+				conditions: []xpv1.Condition{failed(ReasonSetIAMPolicyError, "cannot set the IAM policy of the bucket: boom")},
+				err:        &googleapi.Error{Code: http.StatusNotFound, Message: "bucket not found"},
+			},
+			want: []xpv1.Condition{failed(ReasonResourceNotFound, "the bucket does not exist: bucket not found")},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{}
+			mg.SetConditions(tc.args.conditions...)
+			SetIAMPolicyError(mg, "bucket", tc.args.err)
+			want := &fake.Managed{}
+			want.SetConditions(tc.want...)
+			if diff := cmp.Diff(want.Conditions, mg.Conditions, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nSetIAMPolicyError(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestClearIAMPolicyError(t *testing.T) {
+	failed := xpv1.Condition{Type: TypeIAMPolicyError, Status: corev1.ConditionTrue, Reason: ReasonPermissionDenied}
+	cleared := xpv1.Condition{Type: TypeIAMPolicyError, Status: corev1.ConditionFalse, Reason: xpv1.ReasonReconcileSuccess}
+
+	cases := map[string]struct {
+		reason     string
+		conditions []xpv1.Condition
+		want       []xpv1.Condition
+	}{
+		"Failed": {
+			reason:     "A failure to set the IAM policy should be cleared",
+			conditions: []xpv1.Condition{failed},
+			want:       []xpv1.Condition{cleared},
+		},
+		"NeverFailed": {
+			reason: "A resource that never failed to set its IAM policy should not gain an IAMPolicyError condition",
+			want:   []xpv1.Condition{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			mg := &fake.Managed{}
+			mg.SetConditions(tc.conditions...)
+			ClearIAMPolicyError(mg)
+			want := &fake.Managed{}
+			want.SetConditions(tc.want...)
+			if diff := cmp.Diff(want.Conditions, mg.Conditions, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nClearIAMPolicyError(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestOperationError(t *testing.T) {
+	cases := map[string]struct {
+		op   *compute.Operation
+		want string
+	}{
+		"Succeeded": {
+			op: &compute.Operation{Status: OperationDone},
+		},
+		"Failed": {
+			op: &compute.Operation{Status: OperationDone, Error: &compute.OperationError{
+				Errors: []*compute.OperationErrorErrors{{Message: "quota exceeded"}, {Message: "zone exhausted"}},
+			}},
+			want: "quota exceeded; zone exhausted",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, OperationError(tc.op)); diff != "" {
+				t.Errorf("OperationError(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package globaladdress
 
 import (
+	"fmt"
+
 	compute "google.golang.org/api/compute/v1"
 
 	"github.com/crossplane/provider-gcp/apis/compute/v1beta1"
@@ -38,6 +40,7 @@ func GenerateGlobalAddress(name string, in v1beta1.GlobalAddressParameters, addr
 	address.IpVersion = gcp.StringValue(in.IPVersion)
 	address.Name = name
 	address.Network = gcp.StringValue(in.Network)
+	address.NetworkTier = gcp.StringValue(in.NetworkTier)
 	address.PrefixLength = gcp.Int64Value(in.PrefixLength)
 	address.Purpose = gcp.StringValue(in.Purpose)
 	address.Subnetwork = gcp.StringValue(in.Subnetwork)
@@ -52,11 +55,37 @@ func LateInitializeSpec(p *v1beta1.GlobalAddressParameters, observed compute.Add
 	p.Description = gcp.LateInitializeString(p.Description, observed.Description)
 	p.IPVersion = gcp.LateInitializeString(p.IPVersion, observed.IpVersion)
 	p.Network = gcp.LateInitializeString(p.Network, observed.Network)
+	p.NetworkTier = gcp.LateInitializeString(p.NetworkTier, observed.NetworkTier)
 	p.PrefixLength = gcp.LateInitializeInt64(p.PrefixLength, observed.PrefixLength)
 	p.Purpose = gcp.LateInitializeString(p.Purpose, observed.Purpose)
 	p.Subnetwork = gcp.LateInitializeString(p.Subnetwork, observed.Subnetwork)
 }
 
+// ImmutableDiff returns a description of each field of the supplied
+// GlobalAddressParameters that was set at creation time and differs from the
+// supplied observed Address, e.g. `networkTier: STANDARD != PREMIUM`. Addresses
+// cannot be updated, so any such difference can only be resolved by deleting
+// and recreating the address. Fields GCP does not report are not compared.
+func ImmutableDiff(p v1beta1.GlobalAddressParameters, observed compute.Address) []string {
+	fields := []struct {
+		name     string
+		desired  *string
+		observed string
+	}{
+		{name: "ipVersion", desired: p.IPVersion, observed: observed.IpVersion},
+		{name: "networkTier", desired: p.NetworkTier, observed: observed.NetworkTier},
+	}
+
+	var diff []string
+	for _, f := range fields {
+		if f.desired == nil || f.observed == "" || *f.desired == f.observed {
+			continue
+		}
+		diff = append(diff, fmt.Sprintf("%s: %s != %s", f.name, *f.desired, f.observed))
+	}
+	return diff
+}
+
 // GenerateGlobalAddressObservation takes a compute.Address and returns
 // *GlobalAddressObservation.
 func GenerateGlobalAddressObservation(observed compute.Address) v1beta1.GlobalAddressObservation {
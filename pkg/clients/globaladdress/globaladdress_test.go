@@ -32,6 +32,7 @@ var (
 	addressType        = "coolType"
 	ipVersion          = "coolVersion"
 	network            = "coolNetwork"
+	networkTier        = "coolTier"
 	purpose            = "beingCool"
 	subnetwork         = "coolSubnet"
 	prefixLength int64 = 3001
@@ -49,6 +50,7 @@ func params(m ...func(*v1beta1.GlobalAddressParameters)) *v1beta1.GlobalAddressP
 		Description:  &description,
 		IPVersion:    &ipVersion,
 		Network:      &network,
+		NetworkTier:  &networkTier,
 		PrefixLength: &prefixLength,
 		Purpose:      &purpose,
 		Subnetwork:   &subnetwork,
@@ -69,6 +71,7 @@ func address(m ...func(*compute.Address)) *compute.Address {
 		IpVersion:    ipVersion,
 		Name:         name,
 		Network:      network,
+		NetworkTier:  networkTier,
 		PrefixLength: prefixLength,
 		Purpose:      purpose,
 		Subnetwork:   subnetwork,
@@ -211,6 +214,16 @@ func TestLateInitializeSpec(t *testing.T) {
 				p.AddressType = &addressType
 			}),
 		},
+		"NetworkTierFilled": {
+			args: args{
+				spec: params(func(p *v1beta1.GlobalAddressParameters) {
+					p.IPVersion = nil
+					p.NetworkTier = nil
+				}),
+				in: *address(),
+			},
+			want: params(),
+		},
 	}
 
 	for name, tc := range cases {
@@ -222,3 +235,83 @@ func TestLateInitializeSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestImmutableDiff(t *testing.T) {
+	ipv4 := "IPV4"
+	ipv6 := "IPV6"
+	premium := "PREMIUM"
+	standard := "STANDARD"
+
+	type args struct {
+		p        v1beta1.GlobalAddressParameters
+		observed compute.Address
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   []string
+	}{
+		"NoDiff": {
+			reason: "No differences should be reported when the observed address matches the spec.",
+			args: args{
+				p:        *params(),
+				observed: *address(),
+			},
+		},
+		"UnsetSpec": {
+			reason: "Fields that are not set in the spec should not be compared.",
+			args: args{
+				p: *params(func(p *v1beta1.GlobalAddressParameters) {
+					p.IPVersion = nil
+					p.NetworkTier = nil
+				}),
+				observed: *address(),
+			},
+		},
+		"NotReported": {
+			reason: "Fields that GCP does not report should not be compared.",
+			args: args{
+				p: *params(),
+				observed: *address(func(a *compute.Address) {
+					a.IpVersion = ""
+					a.NetworkTier = ""
+				}),
+			},
+		},
+		"NetworkTierDiff": {
+			reason: "A network tier that differs from the observed one should be reported.",
+			args: args{
+				p: *params(func(p *v1beta1.GlobalAddressParameters) {
+					p.NetworkTier = &standard
+				}),
+				observed: *address(func(a *compute.Address) {
+					a.NetworkTier = premium
+				}),
+			},
+			want: []string{"networkTier: STANDARD != PREMIUM"},
+		},
+		"IPVersionAndNetworkTierDiff": {
+			reason: "Every differing field should be reported.",
+			args: args{
+				p: *params(func(p *v1beta1.GlobalAddressParameters) {
+					p.IPVersion = &ipv6
+					p.NetworkTier = &standard
+				}),
+				observed: *address(func(a *compute.Address) {
+					a.IpVersion = ipv4
+					a.NetworkTier = premium
+				}),
+			},
+			want: []string{"ipVersion: IPV6 != IPV4", "networkTier: STANDARD != PREMIUM"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ImmutableDiff(tc.args.p, tc.args.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nImmutableDiff(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,448 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// Instance statuses.
+const (
+	StatusRunning    = "RUNNING"
+	StatusTerminated = "TERMINATED"
+)
+
+// AnnotationKeyStoppedForUpdate is the annotation that marks an instance that
+// was stopped to be updated and is to be started again once it is.
+const AnnotationKeyStoppedForUpdate = "gcp.crossplane.io/stopped-for-update"
+
+const (
+	onHostMaintenanceMigrate   = "MIGRATE"
+	onHostMaintenanceTerminate = "TERMINATE"
+)
+
+// Error strings.
+const (
+	errBootDisk                 = "exactly one disk must be the boot disk"
+	errDiskSource               = "exactly one of source and initializeParams must be set for each disk"
+	errPreemptibleRestart       = "preemptible instances can not restart automatically"
+	errPreemptibleMaintenance   = "preemptible instances can not migrate on host maintenance"
+	errFmtPreemptibleScheduling = "%s; set automaticRestart to false and onHostMaintenance to TERMINATE"
+	errAcceleratorMaintenance   = "instances with guest accelerators can not migrate on host maintenance; set onHostMaintenance to TERMINATE"
+	errIntegrityMonitoringVtpm  = "integrity monitoring requires the vTPM; set enableVtpm to true or enableIntegrityMonitoring to false"
+	errConfidentialMaintenance  = "confidential instances can not migrate on host maintenance; set onHostMaintenance to TERMINATE"
+	errFmtConfidentialMachine   = "confidential instances require an N2D or C2D machine type, not %s"
+)
+
+// confidentialMachineFamilies are the machine families whose AMD EPYC CPUs
+// support Confidential VMs.
+var confidentialMachineFamilies = []string{"n2d-", "c2d-"}
+
+// Validate returns an error if the supplied parameters are inconsistent, e.g.
+// if a preemptible instance is to restart automatically, an instance with
+// accelerators is to migrate on host maintenance, or a confidential instance
+// has a machine type without AMD SEV.
+func Validate(in v1alpha1.InstanceParameters) error {
+	boot := 0
+	for _, d := range in.Disks {
+		if gcp.BoolValue(d.Boot) {
+			boot++
+		}
+		if (d.Source == nil) == (d.InitializeParams == nil) {
+			return errors.New(errDiskSource)
+		}
+	}
+	if boot != 1 {
+		return errors.New(errBootDisk)
+	}
+	// GCP migrates instances on host maintenance unless told otherwise.
+	terminates := in.Scheduling != nil && gcp.StringValue(in.Scheduling.OnHostMaintenance) == onHostMaintenanceTerminate
+	if len(in.GuestAccelerators) > 0 && !terminates {
+		return errors.New(errAcceleratorMaintenance)
+	}
+	if c := in.ShieldedInstanceConfig; c != nil && gcp.BoolValue(c.EnableIntegrityMonitoring) && c.EnableVtpm != nil && !*c.EnableVtpm {
+		return errors.New(errIntegrityMonitoringVtpm)
+	}
+	if c := in.ConfidentialInstanceConfig; c != nil && c.EnableConfidentialCompute {
+		if !isConfidentialMachineType(in.MachineType) {
+			return errors.Errorf(errFmtConfidentialMachine, path.Base(in.MachineType))
+		}
+		if !terminates {
+			return errors.New(errConfidentialMaintenance)
+		}
+	}
+	s := in.Scheduling
+	if s == nil || !gcp.BoolValue(s.Preemptible) {
+		return nil
+	}
+	if gcp.BoolValue(s.AutomaticRestart) {
+		return errors.Errorf(errFmtPreemptibleScheduling, errPreemptibleRestart)
+	}
+	if gcp.StringValue(s.OnHostMaintenance) == onHostMaintenanceMigrate {
+		return errors.Errorf(errFmtPreemptibleScheduling, errPreemptibleMaintenance)
+	}
+	return nil
+}
+
+// GenerateInstance takes a *InstanceParameters and returns *compute.Instance.
+// It assigns only the fields that are writable, i.e. not labelled as [Output
+// Only] in Google's reference.
+func GenerateInstance(name string, in v1alpha1.InstanceParameters, i *compute.Instance) {
+	i.Name = name
+	i.MachineType = in.MachineType
+	i.Description = gcp.StringValue(in.Description)
+	i.Scheduling = GenerateScheduling(in.Scheduling)
+	i.GuestAccelerators = GenerateAccelerators(in.Zone, in.GuestAccelerators)
+	i.ShieldedInstanceConfig = GenerateShieldedInstanceConfig(in.ShieldedInstanceConfig)
+	i.ConfidentialInstanceConfig = nil
+	if c := in.ConfidentialInstanceConfig; c != nil {
+		i.ConfidentialInstanceConfig = &compute.ConfidentialInstanceConfig{EnableConfidentialCompute: c.EnableConfidentialCompute}
+	}
+	i.Disks = nil
+	for _, d := range in.Disks {
+		ad := &compute.AttachedDisk{
+			Boot:       gcp.BoolValue(d.Boot),
+			DeviceName: gcp.StringValue(d.DeviceName),
+			AutoDelete: gcp.BoolValue(d.AutoDelete),
+			Source:     gcp.StringValue(d.Source),
+		}
+		if p := d.InitializeParams; p != nil {
+			ad.InitializeParams = &compute.AttachedDiskInitializeParams{
+				SourceImage: gcp.StringValue(p.SourceImage),
+				DiskSizeGb:  gcp.Int64Value(p.DiskSizeGb),
+				DiskType:    gcp.StringValue(p.DiskType),
+			}
+		}
+		i.Disks = append(i.Disks, ad)
+	}
+	i.NetworkInterfaces = nil
+	for _, n := range in.NetworkInterfaces {
+		i.NetworkInterfaces = append(i.NetworkInterfaces, &compute.NetworkInterface{
+			Network:    gcp.StringValue(n.Network),
+			Subnetwork: gcp.StringValue(n.Subnetwork),
+			NetworkIP:  gcp.StringValue(n.NetworkIP),
+		})
+	}
+}
+
+// GenerateScheduling takes a *Scheduling and returns the *compute.Scheduling
+// that is both part of a new instance and the body of a setScheduling
+// request. GCP resets omitted fields of the latter to their defaults.
+func GenerateScheduling(in *v1alpha1.Scheduling) *compute.Scheduling {
+	if in == nil {
+		return nil
+	}
+	return &compute.Scheduling{
+		Preemptible:       gcp.BoolValue(in.Preemptible),
+		AutomaticRestart:  in.AutomaticRestart,
+		OnHostMaintenance: gcp.StringValue(in.OnHostMaintenance),
+		// An instance stops being preemptible only if told so explicitly.
+		ForceSendFields: []string{"Preemptible"},
+	}
+}
+
+// GenerateAccelerators takes the desired accelerators of an instance in the
+// supplied zone and returns the []*compute.AcceleratorConfig that is both part
+// of a new instance and the body of a setMachineResources request. Accelerator
+// types given by name are qualified with the zone.
+func GenerateAccelerators(zone string, in []v1alpha1.AcceleratorConfig) []*compute.AcceleratorConfig {
+	var out []*compute.AcceleratorConfig
+	for _, a := range in {
+		t := a.AcceleratorType
+		if !strings.Contains(t, "/") {
+			t = path.Join("zones", zone, "acceleratorTypes", t)
+		}
+		out = append(out, &compute.AcceleratorConfig{AcceleratorType: t, AcceleratorCount: a.AcceleratorCount})
+	}
+	return out
+}
+
+// GenerateShieldedInstanceConfig takes a *ShieldedInstanceConfig and returns
+// the *compute.ShieldedInstanceConfig that is both part of a new instance and
+// the body of an updateShieldedInstanceConfig request. GCP keeps the current
+// value, or the default, of omitted options.
+func GenerateShieldedInstanceConfig(in *v1alpha1.ShieldedInstanceConfig) *compute.ShieldedInstanceConfig {
+	if in == nil {
+		return nil
+	}
+	c := &compute.ShieldedInstanceConfig{
+		EnableSecureBoot:          gcp.BoolValue(in.EnableSecureBoot),
+		EnableVtpm:                gcp.BoolValue(in.EnableVtpm),
+		EnableIntegrityMonitoring: gcp.BoolValue(in.EnableIntegrityMonitoring),
+	}
+	// Disabled options must be sent explicitly.
+	if in.EnableSecureBoot != nil {
+		c.ForceSendFields = append(c.ForceSendFields, "EnableSecureBoot")
+	}
+	if in.EnableVtpm != nil {
+		c.ForceSendFields = append(c.ForceSendFields, "EnableVtpm")
+	}
+	if in.EnableIntegrityMonitoring != nil {
+		c.ForceSendFields = append(c.ForceSendFields, "EnableIntegrityMonitoring")
+	}
+	return c
+}
+
+// GenerateInstanceObservation takes a compute.Instance and returns
+// *InstanceObservation.
+func GenerateInstanceObservation(in compute.Instance) v1alpha1.InstanceObservation {
+	return v1alpha1.InstanceObservation{
+		CreationTimestamp: in.CreationTimestamp,
+		ID:                in.Id,
+		SelfLink:          in.SelfLink,
+		Status:            in.Status,
+	}
+}
+
+// LateInitializeSpec fills unassigned fields with the values in
+// compute.Instance object.
+func LateInitializeSpec(spec *v1alpha1.InstanceParameters, in compute.Instance) {
+	spec.Description = gcp.LateInitializeString(spec.Description, in.Description)
+	if in.Scheduling != nil {
+		if spec.Scheduling == nil {
+			spec.Scheduling = &v1alpha1.Scheduling{}
+		}
+		if spec.Scheduling.Preemptible == nil {
+			// Preemptible is omitted from the response of instances that
+			// are not, so false is as valid a value to adopt as true.
+			spec.Scheduling.Preemptible = gcp.BoolPtr(in.Scheduling.Preemptible)
+		}
+		if spec.Scheduling.AutomaticRestart == nil && in.Scheduling.AutomaticRestart != nil {
+			spec.Scheduling.AutomaticRestart = gcp.BoolPtr(*in.Scheduling.AutomaticRestart)
+		}
+		spec.Scheduling.OnHostMaintenance = gcp.LateInitializeString(spec.Scheduling.OnHostMaintenance, in.Scheduling.OnHostMaintenance)
+	}
+	if in.ShieldedInstanceConfig != nil {
+		if spec.ShieldedInstanceConfig == nil {
+			spec.ShieldedInstanceConfig = &v1alpha1.ShieldedInstanceConfig{}
+		}
+		// Disabled options are omitted from the response, so false is as
+		// valid a value to adopt as true.
+		c := spec.ShieldedInstanceConfig
+		c.EnableSecureBoot = lateInitializeBool(c.EnableSecureBoot, in.ShieldedInstanceConfig.EnableSecureBoot)
+		c.EnableVtpm = lateInitializeBool(c.EnableVtpm, in.ShieldedInstanceConfig.EnableVtpm)
+		c.EnableIntegrityMonitoring = lateInitializeBool(c.EnableIntegrityMonitoring, in.ShieldedInstanceConfig.EnableIntegrityMonitoring)
+	}
+	if len(spec.NetworkInterfaces) != len(in.NetworkInterfaces) {
+		return
+	}
+	for i, n := range in.NetworkInterfaces {
+		spec.NetworkInterfaces[i].Network = gcp.LateInitializeString(spec.NetworkInterfaces[i].Network, n.Network)
+		spec.NetworkInterfaces[i].Subnetwork = gcp.LateInitializeString(spec.NetworkInterfaces[i].Subnetwork, n.Subnetwork)
+		spec.NetworkInterfaces[i].NetworkIP = gcp.LateInitializeString(spec.NetworkInterfaces[i].NetworkIP, n.NetworkIP)
+	}
+}
+
+func lateInitializeBool(v *bool, observed bool) *bool {
+	if v != nil {
+		return v
+	}
+	return gcp.BoolPtr(observed)
+}
+
+func isConfidentialMachineType(machineType string) bool {
+	for _, f := range confidentialMachineFamilies {
+		if strings.HasPrefix(path.Base(machineType), f) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSchedulingUpToDate returns true if the observed instance is scheduled as
+// desired. Unassigned fields are not considered.
+func IsSchedulingUpToDate(in v1alpha1.InstanceParameters, observed compute.Instance) bool {
+	s := in.Scheduling
+	if s == nil {
+		return true
+	}
+	o := observed.Scheduling
+	if o == nil {
+		o = &compute.Scheduling{}
+	}
+	switch {
+	case s.Preemptible != nil && *s.Preemptible != o.Preemptible:
+		return false
+	case s.AutomaticRestart != nil && (o.AutomaticRestart == nil || *s.AutomaticRestart != *o.AutomaticRestart):
+		return false
+	case s.OnHostMaintenance != nil && *s.OnHostMaintenance != o.OnHostMaintenance:
+		return false
+	}
+	return true
+}
+
+// AreAcceleratorsUpToDate returns true if the observed instance has the
+// desired accelerators, regardless of their order and of how their types are
+// qualified.
+func AreAcceleratorsUpToDate(in v1alpha1.InstanceParameters, observed compute.Instance) bool {
+	desired := map[string]int64{}
+	for _, a := range in.GuestAccelerators {
+		desired[path.Base(a.AcceleratorType)] += a.AcceleratorCount
+	}
+	actual := map[string]int64{}
+	for _, a := range observed.GuestAccelerators {
+		actual[path.Base(a.AcceleratorType)] += a.AcceleratorCount
+	}
+	return cmp.Equal(desired, actual)
+}
+
+// IsShieldedInstanceConfigUpToDate returns true if the observed instance has
+// the desired Shielded VM options. Unassigned options are not considered.
+func IsShieldedInstanceConfigUpToDate(in v1alpha1.InstanceParameters, observed compute.Instance) bool {
+	return shieldedDiff(in, observed, false) == nil
+}
+
+// shieldedDiff returns the names of the desired Shielded VM options that
+// differ from the observed ones, optionally only those that can not be
+// changed while the instance runs.
+func shieldedDiff(in v1alpha1.InstanceParameters, observed compute.Instance, requireStop bool) []string {
+	c := in.ShieldedInstanceConfig
+	if c == nil {
+		return nil
+	}
+	o := observed.ShieldedInstanceConfig
+	if o == nil {
+		o = &compute.ShieldedInstanceConfig{}
+	}
+	var diff []string
+	for _, f := range []struct {
+		name     string
+		desired  *bool
+		observed bool
+		stop     bool
+	}{
+		{name: "enableSecureBoot", desired: c.EnableSecureBoot, observed: o.EnableSecureBoot, stop: true},
+		{name: "enableVtpm", desired: c.EnableVtpm, observed: o.EnableVtpm, stop: true},
+		{name: "enableIntegrityMonitoring", desired: c.EnableIntegrityMonitoring, observed: o.EnableIntegrityMonitoring},
+	} {
+		if f.desired != nil && *f.desired != f.observed && (f.stop || !requireStop) {
+			diff = append(diff, f.name)
+		}
+	}
+	return diff
+}
+
+// ImmutableDiff returns a description of each field of the supplied
+// InstanceParameters that can not be updated and differs from the supplied
+// observed instance, e.g. `enableConfidentialCompute: true != false`. Such a
+// difference can only be resolved by deleting and recreating the instance.
+func ImmutableDiff(in v1alpha1.InstanceParameters, observed compute.Instance) []string {
+	c := in.ConfidentialInstanceConfig
+	if c == nil {
+		return nil
+	}
+	o := observed.ConfidentialInstanceConfig != nil && observed.ConfidentialInstanceConfig.EnableConfidentialCompute
+	if c.EnableConfidentialCompute == o {
+		return nil
+	}
+	return []string{fmt.Sprintf("enableConfidentialCompute: %t != %t", c.EnableConfidentialCompute, o)}
+}
+
+// A DiskAutoDelete is whether the attached disk with a device name is to be
+// deleted along with its instance.
+type DiskAutoDelete struct {
+	DeviceName string
+	AutoDelete bool
+}
+
+// AutoDeleteDiff returns the disks of the observed instance that are not
+// deleted along with it as desired. Disks are identified by their device name
+// or, failing that, their position. Unassigned flags are not considered.
+func AutoDeleteDiff(in v1alpha1.InstanceParameters, observed compute.Instance) []DiskAutoDelete {
+	var diff []DiskAutoDelete
+	for i, d := range in.Disks {
+		if d.AutoDelete == nil {
+			continue
+		}
+		o := observedDisk(d, i, observed.Disks)
+		if o != nil && o.AutoDelete != *d.AutoDelete {
+			diff = append(diff, DiskAutoDelete{DeviceName: o.DeviceName, AutoDelete: *d.AutoDelete})
+		}
+	}
+	return diff
+}
+
+// PreservedDisksDiff returns the disks of the observed instance that would be
+// deleted along with it although they are to be kept.
+func PreservedDisksDiff(in v1alpha1.InstanceParameters, observed compute.Instance) []DiskAutoDelete {
+	var diff []DiskAutoDelete
+	for _, d := range AutoDeleteDiff(in, observed) {
+		if !d.AutoDelete {
+			diff = append(diff, d)
+		}
+	}
+	return diff
+}
+
+func observedDisk(d v1alpha1.AttachedDisk, i int, observed []*compute.AttachedDisk) *compute.AttachedDisk {
+	if d.DeviceName == nil {
+		if i < len(observed) {
+			return observed[i]
+		}
+		return nil
+	}
+	for _, o := range observed {
+		if o.DeviceName == *d.DeviceName {
+			return o
+		}
+	}
+	return nil
+}
+
+// UpdatesAcceleratorsFirst returns true if the accelerators of the observed
+// instance are to be updated before its scheduling, i.e. if all of them are
+// removed. GCP refuses to let an instance migrate on host maintenance while
+// it has accelerators, and to add accelerators to one that does.
+func UpdatesAcceleratorsFirst(in v1alpha1.InstanceParameters, observed compute.Instance) bool {
+	return len(in.GuestAccelerators) == 0 && len(observed.GuestAccelerators) > 0
+}
+
+// RequiresStop returns true if the observed instance must be stopped before
+// it can be updated as desired, i.e. if whether it is preemptible, its
+// accelerators, or its Secure Boot or vTPM options change. Its restart and
+// host maintenance policies and integrity monitoring can be updated while it
+// runs.
+func RequiresStop(in v1alpha1.InstanceParameters, observed compute.Instance) bool {
+	if !AreAcceleratorsUpToDate(in, observed) || len(shieldedDiff(in, observed, true)) > 0 {
+		return true
+	}
+	if in.Scheduling == nil || in.Scheduling.Preemptible == nil {
+		return false
+	}
+	preemptible := observed.Scheduling != nil && observed.Scheduling.Preemptible
+	return *in.Scheduling.Preemptible != preemptible
+}
+
+// IsUpToDate returns true if the observed instance matches the desired one.
+// Only its scheduling, accelerators, Shielded VM options and which disks are
+// deleted along with it can be updated; other fields are not considered,
+// except whether it is a Confidential VM.
+func IsUpToDate(in v1alpha1.InstanceParameters, observed compute.Instance) bool {
+	return IsSchedulingUpToDate(in, observed) && AreAcceleratorsUpToDate(in, observed) &&
+		IsShieldedInstanceConfigUpToDate(in, observed) && len(AutoDeleteDiff(in, observed)) == 0 &&
+		len(ImmutableDiff(in, observed)) == 0
+}
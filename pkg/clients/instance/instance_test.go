@@ -0,0 +1,662 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testName        = "some-name"
+	testMachineType = "zones/us-central1-a/machineTypes/e2-medium"
+	testImage       = "projects/debian-cloud/global/images/family/debian-11"
+	testSubnetwork  = "regions/us-central1/subnetworks/some-subnetwork"
+)
+
+func params(m ...func(*v1alpha1.InstanceParameters)) *v1alpha1.InstanceParameters {
+	o := &v1alpha1.InstanceParameters{
+		Zone:        "us-central1-a",
+		MachineType: testMachineType,
+		Disks: []v1alpha1.AttachedDisk{{
+			Boot:             gcp.BoolPtr(true),
+			InitializeParams: &v1alpha1.AttachedDiskInitializeParams{SourceImage: gcp.StringPtr(testImage)},
+		}},
+		NetworkInterfaces: []v1alpha1.NetworkInterface{{Subnetwork: gcp.StringPtr(testSubnetwork)}},
+		Scheduling: &v1alpha1.Scheduling{
+			Preemptible:       gcp.BoolPtr(false),
+			AutomaticRestart:  gcp.BoolPtr(true),
+			OnHostMaintenance: gcp.StringPtr("MIGRATE"),
+		},
+		Description: gcp.StringPtr("some desc"),
+	}
+	for _, f := range m {
+		f(o)
+	}
+	return o
+}
+
+func instance(m ...func(*compute.Instance)) *compute.Instance {
+	o := &compute.Instance{
+		Name:        testName,
+		MachineType: testMachineType,
+		Disks: []*compute.AttachedDisk{{
+			Boot:             true,
+			InitializeParams: &compute.AttachedDiskInitializeParams{SourceImage: testImage},
+		}},
+		NetworkInterfaces: []*compute.NetworkInterface{{Subnetwork: testSubnetwork}},
+		Scheduling: &compute.Scheduling{
+			AutomaticRestart:  gcp.BoolPtr(true),
+			OnHostMaintenance: "MIGRATE",
+			ForceSendFields:   []string{"Preemptible"},
+		},
+		Description: "some desc",
+	}
+	for _, f := range m {
+		f(o)
+	}
+	return o
+}
+
+const testAcceleratorType = "https://www.googleapis.com/compute/v1/projects/cool-project/zones/us-central1-a/acceleratorTypes/nvidia-tesla-t4"
+
+// gpu attaches a GPU to an instance that terminates on host maintenance.
+func gpu(p *v1alpha1.InstanceParameters) {
+	p.Scheduling.OnHostMaintenance = gcp.StringPtr("TERMINATE")
+	p.GuestAccelerators = []v1alpha1.AcceleratorConfig{{AcceleratorType: "nvidia-tesla-t4", AcceleratorCount: 1}}
+}
+
+// shielded enables every Shielded VM option of an instance.
+func shielded(p *v1alpha1.InstanceParameters) {
+	p.ShieldedInstanceConfig = &v1alpha1.ShieldedInstanceConfig{
+		EnableSecureBoot:          gcp.BoolPtr(true),
+		EnableVtpm:                gcp.BoolPtr(true),
+		EnableIntegrityMonitoring: gcp.BoolPtr(true),
+	}
+}
+
+// confidential makes an instance a Confidential VM.
+func confidential(p *v1alpha1.InstanceParameters) {
+	p.MachineType = "zones/us-central1-a/machineTypes/n2d-standard-2"
+	p.Scheduling.OnHostMaintenance = gcp.StringPtr("TERMINATE")
+	p.ConfidentialInstanceConfig = &v1alpha1.ConfidentialInstanceConfig{EnableConfidentialCompute: true}
+}
+
+func spot(p *v1alpha1.InstanceParameters) {
+	p.Scheduling = &v1alpha1.Scheduling{
+		Preemptible:       gcp.BoolPtr(true),
+		AutomaticRestart:  gcp.BoolPtr(false),
+		OnHostMaintenance: gcp.StringPtr("TERMINATE"),
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := map[string]struct {
+		in   v1alpha1.InstanceParameters
+		want error
+	}{
+		"Standard": {
+			in: *params(),
+		},
+		"Preemptible": {
+			in: *params(spot),
+		},
+		"NoBootDisk": {
+			in:   *params(func(p *v1alpha1.InstanceParameters) { p.Disks[0].Boot = nil }),
+			want: errors.New(errBootDisk),
+		},
+		"TwoDiskSources": {
+			in: *params(func(p *v1alpha1.InstanceParameters) {
+				p.Disks[0].Source = gcp.StringPtr("zones/us-central1-a/disks/some-disk")
+			}),
+			want: errors.New(errDiskSource),
+		},
+		"PreemptibleRestart": {
+			in: *params(spot, func(p *v1alpha1.InstanceParameters) {
+				p.Scheduling.AutomaticRestart = gcp.BoolPtr(true)
+			}),
+			want: errors.Errorf(errFmtPreemptibleScheduling, errPreemptibleRestart),
+		},
+		"PreemptibleMigrate": {
+			in: *params(spot, func(p *v1alpha1.InstanceParameters) {
+				p.Scheduling.OnHostMaintenance = gcp.StringPtr("MIGRATE")
+			}),
+			want: errors.Errorf(errFmtPreemptibleScheduling, errPreemptibleMaintenance),
+		},
+		"Accelerators": {
+			in: *params(gpu),
+		},
+		"AcceleratorsMigrate": {
+			in: *params(gpu, func(p *v1alpha1.InstanceParameters) {
+				p.Scheduling.OnHostMaintenance = gcp.StringPtr("MIGRATE")
+			}),
+			want: errors.New(errAcceleratorMaintenance),
+		},
+		"AcceleratorsDefaultScheduling": {
+			in:   *params(gpu, func(p *v1alpha1.InstanceParameters) { p.Scheduling = nil }),
+			want: errors.New(errAcceleratorMaintenance),
+		},
+		"Shielded": {
+			in: *params(shielded),
+		},
+		"IntegrityMonitoringWithoutVtpm": {
+			in: *params(shielded, func(p *v1alpha1.InstanceParameters) {
+				p.ShieldedInstanceConfig.EnableVtpm = gcp.BoolPtr(false)
+			}),
+			want: errors.New(errIntegrityMonitoringVtpm),
+		},
+		"Confidential": {
+			in: *params(confidential),
+		},
+		"ConfidentialC2D": {
+			in: *params(confidential, func(p *v1alpha1.InstanceParameters) { p.MachineType = "c2d-standard-4" }),
+		},
+		"ConfidentialMachineType": {
+			in:   *params(confidential, func(p *v1alpha1.InstanceParameters) { p.MachineType = testMachineType }),
+			want: errors.Errorf(errFmtConfidentialMachine, "e2-medium"),
+		},
+		"ConfidentialMigrate": {
+			in: *params(confidential, func(p *v1alpha1.InstanceParameters) {
+				p.Scheduling.OnHostMaintenance = gcp.StringPtr("MIGRATE")
+			}),
+			want: errors.New(errConfidentialMaintenance),
+		},
+		"ConfidentialDisabled": {
+			in: *params(func(p *v1alpha1.InstanceParameters) {
+				p.ConfidentialInstanceConfig = &v1alpha1.ConfidentialInstanceConfig{}
+			}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := Validate(tc.in)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Validate(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateInstance(t *testing.T) {
+	cases := map[string]struct {
+		in   v1alpha1.InstanceParameters
+		want *compute.Instance
+	}{
+		"Standard": {
+			in:   *params(),
+			want: instance(),
+		},
+		"Preemptible": {
+			in: *params(spot),
+			want: instance(func(i *compute.Instance) {
+				i.Scheduling = &compute.Scheduling{
+					Preemptible:       true,
+					AutomaticRestart:  gcp.BoolPtr(false),
+					OnHostMaintenance: "TERMINATE",
+					ForceSendFields:   []string{"Preemptible"},
+				}
+			}),
+		},
+		"DefaultScheduling": {
+			in:   *params(func(p *v1alpha1.InstanceParameters) { p.Scheduling = nil }),
+			want: instance(func(i *compute.Instance) { i.Scheduling = nil }),
+		},
+		"Accelerators": {
+			in: *params(gpu, func(p *v1alpha1.InstanceParameters) {
+				p.GuestAccelerators = append(p.GuestAccelerators, v1alpha1.AcceleratorConfig{
+					AcceleratorType: "zones/us-central1-a/acceleratorTypes/nvidia-tesla-v100", AcceleratorCount: 2,
+				})
+			}),
+			want: instance(func(i *compute.Instance) {
+				i.Scheduling.OnHostMaintenance = "TERMINATE"
+				i.GuestAccelerators = []*compute.AcceleratorConfig{
+					{AcceleratorType: "zones/us-central1-a/acceleratorTypes/nvidia-tesla-t4", AcceleratorCount: 1},
+					{AcceleratorType: "zones/us-central1-a/acceleratorTypes/nvidia-tesla-v100", AcceleratorCount: 2},
+				}
+			}),
+		},
+		"AutoDelete": {
+			in:   *params(func(p *v1alpha1.InstanceParameters) { p.Disks[0].AutoDelete = gcp.BoolPtr(true) }),
+			want: instance(func(i *compute.Instance) { i.Disks[0].AutoDelete = true }),
+		},
+		"ShieldedAndConfidential": {
+			in: *params(confidential, func(p *v1alpha1.InstanceParameters) {
+				p.ShieldedInstanceConfig = &v1alpha1.ShieldedInstanceConfig{
+					EnableSecureBoot: gcp.BoolPtr(true),
+					EnableVtpm:       gcp.BoolPtr(false),
+				}
+			}),
+			want: instance(func(i *compute.Instance) {
+				i.MachineType = "zones/us-central1-a/machineTypes/n2d-standard-2"
+				i.Scheduling.OnHostMaintenance = "TERMINATE"
+				i.ShieldedInstanceConfig = &compute.ShieldedInstanceConfig{
+					EnableSecureBoot: true,
+					ForceSendFields:  []string{"EnableSecureBoot", "EnableVtpm"},
+				}
+				i.ConfidentialInstanceConfig = &compute.ConfidentialInstanceConfig{EnableConfidentialCompute: true}
+			}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &compute.Instance{}
+			GenerateInstance(testName, tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateInstance(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	cases := map[string]struct {
+		spec     *v1alpha1.InstanceParameters
+		observed compute.Instance
+		want     *v1alpha1.InstanceParameters
+	}{
+		"Empty": {
+			spec: params(func(p *v1alpha1.InstanceParameters) {
+				p.Scheduling = nil
+				p.Description = nil
+			}),
+			observed: *instance(func(i *compute.Instance) {
+				i.NetworkInterfaces[0].Network = "global/networks/some-network"
+				i.NetworkInterfaces[0].NetworkIP = "10.0.0.2"
+			}),
+			want: params(func(p *v1alpha1.InstanceParameters) {
+				p.NetworkInterfaces[0].Network = gcp.StringPtr("global/networks/some-network")
+				p.NetworkInterfaces[0].NetworkIP = gcp.StringPtr("10.0.0.2")
+			}),
+		},
+		"NoOverride": {
+			spec:     params(spot),
+			observed: *instance(),
+			want:     params(spot),
+		},
+		"ShieldedInstanceConfig": {
+			spec: params(func(p *v1alpha1.InstanceParameters) {
+				p.ShieldedInstanceConfig = &v1alpha1.ShieldedInstanceConfig{EnableSecureBoot: gcp.BoolPtr(true)}
+			}),
+			observed: *instance(func(i *compute.Instance) {
+				i.ShieldedInstanceConfig = &compute.ShieldedInstanceConfig{EnableVtpm: true}
+			}),
+			want: params(func(p *v1alpha1.InstanceParameters) {
+				p.ShieldedInstanceConfig = &v1alpha1.ShieldedInstanceConfig{
+					EnableSecureBoot:          gcp.BoolPtr(true),
+					EnableVtpm:                gcp.BoolPtr(true),
+					EnableIntegrityMonitoring: gcp.BoolPtr(false),
+				}
+			}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.spec, tc.observed)
+			if diff := cmp.Diff(tc.want, tc.spec); diff != "" {
+				t.Errorf("LateInitializeSpec(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsSchedulingUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		in       v1alpha1.InstanceParameters
+		observed compute.Instance
+		want     bool
+	}{
+		"UpToDate": {
+			in:       *params(),
+			observed: *instance(),
+			want:     true,
+		},
+		"Unset": {
+			in:       *params(func(p *v1alpha1.InstanceParameters) { p.Scheduling = nil }),
+			observed: *instance(func(i *compute.Instance) { i.Scheduling.Preemptible = true }),
+			want:     true,
+		},
+		"SpotToggled": {
+			in:       *params(spot),
+			observed: *instance(),
+		},
+		"RestartPolicyChanged": {
+			in:       *params(func(p *v1alpha1.InstanceParameters) { p.Scheduling.AutomaticRestart = gcp.BoolPtr(false) }),
+			observed: *instance(),
+		},
+		"MaintenancePolicyChanged": {
+			in:       *params(func(p *v1alpha1.InstanceParameters) { p.Scheduling.OnHostMaintenance = gcp.StringPtr("TERMINATE") }),
+			observed: *instance(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsSchedulingUpToDate(tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsSchedulingUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRequiresStop(t *testing.T) {
+	cases := map[string]struct {
+		in       v1alpha1.InstanceParameters
+		observed compute.Instance
+		want     bool
+	}{
+		"UpToDate": {
+			in:       *params(),
+			observed: *instance(),
+		},
+		"NoScheduling": {
+			in:       *params(),
+			observed: *instance(func(i *compute.Instance) { i.Scheduling = nil }),
+		},
+		"RestartPolicyChanged": {
+			in:       *params(func(p *v1alpha1.InstanceParameters) { p.Scheduling.AutomaticRestart = gcp.BoolPtr(false) }),
+			observed: *instance(),
+		},
+		"SpotEnabled": {
+			in:       *params(spot),
+			observed: *instance(),
+			want:     true,
+		},
+		"SpotDisabled": {
+			in:       *params(),
+			observed: *instance(func(i *compute.Instance) { i.Scheduling.Preemptible = true }),
+			want:     true,
+		},
+		"AcceleratorAdded": {
+			in:       *params(gpu),
+			observed: *instance(func(i *compute.Instance) { i.Scheduling.OnHostMaintenance = "TERMINATE" }),
+			want:     true,
+		},
+		"SecureBootEnabled": {
+			in:       *params(shielded),
+			observed: *instance(func(i *compute.Instance) { i.ShieldedInstanceConfig = shieldedObserved(false) }),
+			want:     true,
+		},
+		"IntegrityMonitoringDisabled": {
+			in: *params(shielded, func(p *v1alpha1.InstanceParameters) {
+				p.ShieldedInstanceConfig.EnableIntegrityMonitoring = gcp.BoolPtr(false)
+			}),
+			observed: *instance(func(i *compute.Instance) { i.ShieldedInstanceConfig = shieldedObserved(true) }),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := RequiresStop(tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("RequiresStop(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAreAcceleratorsUpToDate(t *testing.T) {
+	type want struct {
+		upToDate bool
+		first    bool
+	}
+	withGPUs := func(a ...*compute.AcceleratorConfig) func(*compute.Instance) {
+		return func(i *compute.Instance) {
+			i.Scheduling.OnHostMaintenance = "TERMINATE"
+			i.GuestAccelerators = a
+		}
+	}
+
+	cases := map[string]struct {
+		in       v1alpha1.InstanceParameters
+		observed compute.Instance
+		want     want
+	}{
+		"NoAccelerators": {
+			in:       *params(),
+			observed: *instance(),
+			want:     want{upToDate: true},
+		},
+		"UpToDate": {
+			in:       *params(gpu),
+			observed: *instance(withGPUs(&compute.AcceleratorConfig{AcceleratorType: testAcceleratorType, AcceleratorCount: 1})),
+			want:     want{upToDate: true},
+		},
+		"Reordered": {
+			in: *params(gpu, func(p *v1alpha1.InstanceParameters) {
+				p.GuestAccelerators = append(p.GuestAccelerators, v1alpha1.AcceleratorConfig{AcceleratorType: "nvidia-tesla-v100", AcceleratorCount: 2})
+			}),
+			observed: *instance(withGPUs(
+				&compute.AcceleratorConfig{AcceleratorType: "zones/us-central1-a/acceleratorTypes/nvidia-tesla-v100", AcceleratorCount: 2},
+				&compute.AcceleratorConfig{AcceleratorType: testAcceleratorType, AcceleratorCount: 1},
+			)),
+			want: want{upToDate: true},
+		},
+		"Added": {
+			in:       *params(gpu),
+			observed: *instance(),
+			want:     want{upToDate: false},
+		},
+		"CountChanged": {
+			in:       *params(gpu),
+			observed: *instance(withGPUs(&compute.AcceleratorConfig{AcceleratorType: testAcceleratorType, AcceleratorCount: 2})),
+			want:     want{upToDate: false},
+		},
+		"TypeChanged": {
+			in: *params(gpu),
+			observed: *instance(withGPUs(&compute.AcceleratorConfig{
+				AcceleratorType: "zones/us-central1-a/acceleratorTypes/nvidia-tesla-p4", AcceleratorCount: 1,
+			})),
+			want: want{upToDate: false},
+		},
+		"AllRemoved": {
+			in:       *params(),
+			observed: *instance(withGPUs(&compute.AcceleratorConfig{AcceleratorType: testAcceleratorType, AcceleratorCount: 1})),
+			want:     want{upToDate: false, first: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want.upToDate, AreAcceleratorsUpToDate(tc.in, tc.observed)); diff != "" {
+				t.Errorf("AreAcceleratorsUpToDate(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.first, UpdatesAcceleratorsFirst(tc.in, tc.observed)); diff != "" {
+				t.Errorf("UpdatesAcceleratorsFirst(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func shieldedObserved(secureBoot bool) *compute.ShieldedInstanceConfig {
+	return &compute.ShieldedInstanceConfig{EnableSecureBoot: secureBoot, EnableVtpm: true, EnableIntegrityMonitoring: true}
+}
+
+func TestIsShieldedInstanceConfigUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		in       v1alpha1.InstanceParameters
+		observed compute.Instance
+		want     bool
+	}{
+		"UpToDate": {
+			in:       *params(shielded),
+			observed: *instance(func(i *compute.Instance) { i.ShieldedInstanceConfig = shieldedObserved(true) }),
+			want:     true,
+		},
+		"Unset": {
+			in:       *params(),
+			observed: *instance(func(i *compute.Instance) { i.ShieldedInstanceConfig = shieldedObserved(true) }),
+			want:     true,
+		},
+		"PartiallySet": {
+			in: *params(func(p *v1alpha1.InstanceParameters) {
+				p.ShieldedInstanceConfig = &v1alpha1.ShieldedInstanceConfig{EnableSecureBoot: gcp.BoolPtr(false)}
+			}),
+			observed: *instance(func(i *compute.Instance) { i.ShieldedInstanceConfig = shieldedObserved(false) }),
+			want:     true,
+		},
+		"SecureBootEnabled": {
+			in:       *params(shielded),
+			observed: *instance(func(i *compute.Instance) { i.ShieldedInstanceConfig = shieldedObserved(false) }),
+		},
+		"IntegrityMonitoringDisabled": {
+			in: *params(shielded, func(p *v1alpha1.InstanceParameters) {
+				p.ShieldedInstanceConfig.EnableIntegrityMonitoring = gcp.BoolPtr(false)
+			}),
+			observed: *instance(func(i *compute.Instance) { i.ShieldedInstanceConfig = shieldedObserved(true) }),
+		},
+		"NotShielded": {
+			in:       *params(shielded),
+			observed: *instance(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsShieldedInstanceConfigUpToDate(tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsShieldedInstanceConfigUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestImmutableDiff(t *testing.T) {
+	confidentialObserved := func(i *compute.Instance) {
+		i.ConfidentialInstanceConfig = &compute.ConfidentialInstanceConfig{EnableConfidentialCompute: true}
+	}
+
+	cases := map[string]struct {
+		in       v1alpha1.InstanceParameters
+		observed compute.Instance
+		want     []string
+	}{
+		"Unset": {
+			in:       *params(),
+			observed: *instance(confidentialObserved),
+		},
+		"Confidential": {
+			in:       *params(confidential),
+			observed: *instance(confidentialObserved),
+		},
+		"ConfidentialEnabled": {
+			in:       *params(confidential),
+			observed: *instance(),
+			want:     []string{"enableConfidentialCompute: true != false"},
+		},
+		"ConfidentialDisabled": {
+			in: *params(func(p *v1alpha1.InstanceParameters) {
+				p.ConfidentialInstanceConfig = &v1alpha1.ConfidentialInstanceConfig{}
+			}),
+			observed: *instance(confidentialObserved),
+			want:     []string{"enableConfidentialCompute: false != true"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ImmutableDiff(tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ImmutableDiff(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAutoDeleteDiff(t *testing.T) {
+	const data = "data"
+	withData := func(autoDelete bool) func(*compute.Instance) {
+		return func(i *compute.Instance) {
+			i.Disks[0].DeviceName = "persistent-disk-0"
+			i.Disks[0].AutoDelete = true
+			i.Disks = append(i.Disks, &compute.AttachedDisk{DeviceName: data, AutoDelete: autoDelete})
+		}
+	}
+	dataDisk := func(autoDelete bool) func(*v1alpha1.InstanceParameters) {
+		return func(p *v1alpha1.InstanceParameters) {
+			p.Disks = append(p.Disks, v1alpha1.AttachedDisk{
+				DeviceName: gcp.StringPtr(data),
+				AutoDelete: gcp.BoolPtr(autoDelete),
+				Source:     gcp.StringPtr("zones/us-central1-a/disks/some-disk"),
+			})
+		}
+	}
+
+	type want struct {
+		diff      []DiskAutoDelete
+		preserved []DiskAutoDelete
+	}
+
+	cases := map[string]struct {
+		in       v1alpha1.InstanceParameters
+		observed compute.Instance
+		want     want
+	}{
+		"Unset": {
+			in:       *params(),
+			observed: *instance(withData(true)),
+		},
+		"UpToDate": {
+			in:       *params(dataDisk(true)),
+			observed: *instance(withData(true)),
+		},
+		"EnabledByPosition": {
+			in:       *params(func(p *v1alpha1.InstanceParameters) { p.Disks[0].AutoDelete = gcp.BoolPtr(true) }),
+			observed: *instance(func(i *compute.Instance) { i.Disks[0].DeviceName = "persistent-disk-0" }),
+			want: want{
+				diff: []DiskAutoDelete{{DeviceName: "persistent-disk-0", AutoDelete: true}},
+			},
+		},
+		"DisabledByDeviceName": {
+			in: *params(dataDisk(false), func(p *v1alpha1.InstanceParameters) {
+				// The data disk is listed first but attached second.
+				p.Disks[0], p.Disks[1] = p.Disks[1], p.Disks[0]
+			}),
+			observed: *instance(withData(true)),
+			want: want{
+				diff:      []DiskAutoDelete{{DeviceName: data, AutoDelete: false}},
+				preserved: []DiskAutoDelete{{DeviceName: data, AutoDelete: false}},
+			},
+		},
+		"NotAttached": {
+			in:       *params(dataDisk(false)),
+			observed: *instance(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want.diff, AutoDeleteDiff(tc.in, tc.observed)); diff != "" {
+				t.Errorf("AutoDeleteDiff(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.preserved, PreservedDisksDiff(tc.in, tc.observed)); diff != "" {
+				t.Errorf("PreservedDisksDiff(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package interconnectattachment
+
+import (
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// GenerateInterconnectAttachment takes a *InterconnectAttachmentParameters
+// and returns *compute.InterconnectAttachment. It assigns only the fields
+// that are writable, i.e. not labelled as [Output Only] in Google's
+// reference.
+func GenerateInterconnectAttachment(name string, in v1alpha1.InterconnectAttachmentParameters, ia *compute.InterconnectAttachment) {
+	ia.Name = name
+	ia.Router = in.Router
+	ia.Interconnect = gcp.StringValue(in.Interconnect)
+	ia.Type = gcp.StringValue(in.Type)
+	ia.VlanTag8021q = gcp.Int64Value(in.VlanTag8021q)
+	ia.Bandwidth = gcp.StringValue(in.Bandwidth)
+	ia.AdminEnabled = gcp.BoolValue(in.AdminEnabled)
+	ia.EdgeAvailabilityDomain = gcp.StringValue(in.EdgeAvailabilityDomain)
+	ia.CandidateSubnets = in.CandidateSubnets
+	ia.Description = gcp.StringValue(in.Description)
+	if in.AdminEnabled != nil {
+		// A disabled attachment must be requested explicitly; GCP enables
+		// attachments whose adminEnabled field is omitted.
+		ia.ForceSendFields = []string{"AdminEnabled"}
+	}
+}
+
+// GenerateInterconnectAttachmentPatch returns the *compute.InterconnectAttachment
+// that updates the fields of an attachment that can be changed once it was
+// created, i.e. its bandwidth and whether it is enabled.
+func GenerateInterconnectAttachmentPatch(in v1alpha1.InterconnectAttachmentParameters) *compute.InterconnectAttachment {
+	ia := &compute.InterconnectAttachment{
+		Bandwidth:    gcp.StringValue(in.Bandwidth),
+		AdminEnabled: gcp.BoolValue(in.AdminEnabled),
+	}
+	if in.AdminEnabled != nil {
+		ia.ForceSendFields = []string{"AdminEnabled"}
+	}
+	return ia
+}
+
+// GenerateInterconnectAttachmentObservation takes a
+// compute.InterconnectAttachment and returns
+// *InterconnectAttachmentObservation.
+func GenerateInterconnectAttachmentObservation(in compute.InterconnectAttachment) v1alpha1.InterconnectAttachmentObservation {
+	return v1alpha1.InterconnectAttachmentObservation{
+		CloudRouterIPAddress:    in.CloudRouterIpAddress,
+		CustomerRouterIPAddress: in.CustomerRouterIpAddress,
+		CreationTimestamp:       in.CreationTimestamp,
+		GoogleReferenceID:       in.GoogleReferenceId,
+		ID:                      in.Id,
+		OperationalStatus:       in.OperationalStatus,
+		PairingKey:              in.PairingKey,
+		SelfLink:                in.SelfLink,
+		State:                   in.State,
+	}
+}
+
+// LateInitializeSpec fills unassigned fields with the values in
+// compute.InterconnectAttachment object.
+func LateInitializeSpec(spec *v1alpha1.InterconnectAttachmentParameters, in compute.InterconnectAttachment) {
+	spec.Interconnect = gcp.LateInitializeString(spec.Interconnect, in.Interconnect)
+	spec.Type = gcp.LateInitializeString(spec.Type, in.Type)
+	spec.VlanTag8021q = gcp.LateInitializeInt64(spec.VlanTag8021q, in.VlanTag8021q)
+	spec.Bandwidth = gcp.LateInitializeString(spec.Bandwidth, in.Bandwidth)
+	if spec.AdminEnabled == nil {
+		// AdminEnabled is omitted from the response of disabled attachments,
+		// so false is as valid a value to adopt as true.
+		spec.AdminEnabled = gcp.BoolPtr(in.AdminEnabled)
+	}
+	spec.EdgeAvailabilityDomain = gcp.LateInitializeString(spec.EdgeAvailabilityDomain, in.EdgeAvailabilityDomain)
+	spec.CandidateSubnets = gcp.LateInitializeStringSlice(spec.CandidateSubnets, in.CandidateSubnets)
+	spec.Description = gcp.LateInitializeString(spec.Description, in.Description)
+}
+
+// IsUpToDate returns true if the bandwidth and the administrative state of the
+// observed attachment match the desired ones. Other fields can not be updated
+// and are not considered.
+func IsUpToDate(in v1alpha1.InterconnectAttachmentParameters, observed compute.InterconnectAttachment) bool {
+	if in.Bandwidth != nil && *in.Bandwidth != observed.Bandwidth {
+		return false
+	}
+	return in.AdminEnabled == nil || *in.AdminEnabled == observed.AdminEnabled
+}
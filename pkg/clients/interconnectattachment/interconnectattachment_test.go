@@ -0,0 +1,302 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package interconnectattachment
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+)
+
+const (
+	testName              = "some-name"
+	testRegion            = "us-central1"
+	testRouter            = "regions/us-central1/routers/some-router"
+	testCreationTimestamp = "10/10/2023"
+	testSelfLink          = "https://www.googleapis.com/compute/v1/projects/cool-project/regions/us-central1/interconnectAttachments/some-name"
+)
+
+var (
+	testInterconnect = "global/interconnects/some-interconnect"
+	testType         = "DEDICATED"
+	testVlanTag      = int64(1234)
+	testBandwidth    = "BPS_1G"
+	testAdminEnabled = true
+	testDescription  = "some desc"
+)
+
+func params(m ...func(*v1alpha1.InterconnectAttachmentParameters)) *v1alpha1.InterconnectAttachmentParameters {
+	o := &v1alpha1.InterconnectAttachmentParameters{
+		Region:           testRegion,
+		Router:           testRouter,
+		Interconnect:     &testInterconnect,
+		Type:             &testType,
+		VlanTag8021q:     &testVlanTag,
+		Bandwidth:        &testBandwidth,
+		AdminEnabled:     &testAdminEnabled,
+		CandidateSubnets: []string{"169.254.0.0/29"},
+		Description:      &testDescription,
+	}
+	for _, f := range m {
+		f(o)
+	}
+	return o
+}
+
+func attachment(m ...func(*compute.InterconnectAttachment)) *compute.InterconnectAttachment {
+	o := &compute.InterconnectAttachment{
+		Name:             testName,
+		Router:           testRouter,
+		Interconnect:     testInterconnect,
+		Type:             testType,
+		VlanTag8021q:     testVlanTag,
+		Bandwidth:        testBandwidth,
+		AdminEnabled:     testAdminEnabled,
+		CandidateSubnets: []string{"169.254.0.0/29"},
+		Description:      testDescription,
+		ForceSendFields:  []string{"AdminEnabled"},
+	}
+	for _, f := range m {
+		f(o)
+	}
+	return o
+}
+
+func TestGenerateInterconnectAttachment(t *testing.T) {
+	type args struct {
+		name string
+		in   v1alpha1.InterconnectAttachmentParameters
+	}
+	cases := map[string]struct {
+		args args
+		want *compute.InterconnectAttachment
+	}{
+		"FullConversion": {
+			args: args{
+				name: testName,
+				in:   *params(),
+			},
+			want: attachment(),
+		},
+		"MissingFields": {
+			args: args{
+				name: testName,
+				in: *params(func(p *v1alpha1.InterconnectAttachmentParameters) {
+					p.Interconnect = nil
+					p.Type = nil
+					p.VlanTag8021q = nil
+					p.Bandwidth = nil
+					p.AdminEnabled = nil
+					p.CandidateSubnets = nil
+					p.Description = nil
+				}),
+			},
+			want: &compute.InterconnectAttachment{Name: testName, Router: testRouter},
+		},
+		"Disabled": {
+			args: args{
+				name: testName,
+				in: *params(func(p *v1alpha1.InterconnectAttachmentParameters) {
+					f := false
+					p.AdminEnabled = &f
+				}),
+			},
+			want: attachment(func(ia *compute.InterconnectAttachment) { ia.AdminEnabled = false }),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &compute.InterconnectAttachment{}
+			GenerateInterconnectAttachment(tc.args.name, tc.args.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateInterconnectAttachment(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateInterconnectAttachmentPatch(t *testing.T) {
+	cases := map[string]struct {
+		in   v1alpha1.InterconnectAttachmentParameters
+		want *compute.InterconnectAttachment
+	}{
+		"BandwidthAndAdminEnabled": {
+			in: *params(),
+			want: &compute.InterconnectAttachment{
+				Bandwidth:       testBandwidth,
+				AdminEnabled:    true,
+				ForceSendFields: []string{"AdminEnabled"},
+			},
+		},
+		"AdminEnabledUnset": {
+			in:   *params(func(p *v1alpha1.InterconnectAttachmentParameters) { p.AdminEnabled = nil }),
+			want: &compute.InterconnectAttachment{Bandwidth: testBandwidth},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, GenerateInterconnectAttachmentPatch(tc.in)); diff != "" {
+				t.Errorf("GenerateInterconnectAttachmentPatch(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateInterconnectAttachmentObservation(t *testing.T) {
+	in := attachment(func(ia *compute.InterconnectAttachment) {
+		ia.CloudRouterIpAddress = "169.254.0.1/29"
+		ia.CustomerRouterIpAddress = "169.254.0.2/29"
+		ia.CreationTimestamp = testCreationTimestamp
+		ia.GoogleReferenceId = "123456789"
+		ia.Id = 2029819203
+		ia.OperationalStatus = "OS_ACTIVE"
+		ia.SelfLink = testSelfLink
+		ia.State = "ACTIVE"
+	})
+	want := v1alpha1.InterconnectAttachmentObservation{
+		CloudRouterIPAddress:    "169.254.0.1/29",
+		CustomerRouterIPAddress: "169.254.0.2/29",
+		CreationTimestamp:       testCreationTimestamp,
+		GoogleReferenceID:       "123456789",
+		ID:                      2029819203,
+		OperationalStatus:       "OS_ACTIVE",
+		SelfLink:                testSelfLink,
+		State:                   "ACTIVE",
+	}
+	if diff := cmp.Diff(want, GenerateInterconnectAttachmentObservation(*in)); diff != "" {
+		t.Errorf("GenerateInterconnectAttachmentObservation(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	type args struct {
+		spec *v1alpha1.InterconnectAttachmentParameters
+		in   compute.InterconnectAttachment
+	}
+	cases := map[string]struct {
+		args args
+		want *v1alpha1.InterconnectAttachmentParameters
+	}{
+		"AllFilledAlready": {
+			args: args{
+				spec: params(),
+				in:   *attachment(),
+			},
+			want: params(),
+		},
+		"DefaultsFilled": {
+			args: args{
+				spec: params(func(p *v1alpha1.InterconnectAttachmentParameters) {
+					p.Interconnect = nil
+					p.Type = nil
+					p.VlanTag8021q = nil
+					p.Bandwidth = nil
+					p.AdminEnabled = nil
+					p.CandidateSubnets = nil
+					p.Description = nil
+				}),
+				in: *attachment(),
+			},
+			want: params(),
+		},
+		"DisabledFilled": {
+			args: args{
+				spec: params(func(p *v1alpha1.InterconnectAttachmentParameters) { p.AdminEnabled = nil }),
+				in:   *attachment(func(ia *compute.InterconnectAttachment) { ia.AdminEnabled = false }),
+			},
+			want: params(func(p *v1alpha1.InterconnectAttachmentParameters) {
+				f := false
+				p.AdminEnabled = &f
+			}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.args.spec, tc.args.in)
+			if diff := cmp.Diff(tc.want, tc.args.spec); diff != "" {
+				t.Errorf("LateInitializeSpec(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	type args struct {
+		in       v1alpha1.InterconnectAttachmentParameters
+		observed compute.InterconnectAttachment
+	}
+	cases := map[string]struct {
+		args args
+		want bool
+	}{
+		"UpToDate": {
+			args: args{
+				in:       *params(),
+				observed: *attachment(),
+			},
+			want: true,
+		},
+		"BandwidthChanged": {
+			args: args{
+				in:       *params(),
+				observed: *attachment(func(ia *compute.InterconnectAttachment) { ia.Bandwidth = "BPS_500M" }),
+			},
+			want: false,
+		},
+		"AdminDisabled": {
+			args: args{
+				in: *params(func(p *v1alpha1.InterconnectAttachmentParameters) {
+					f := false
+					p.AdminEnabled = &f
+				}),
+				observed: *attachment(),
+			},
+			want: false,
+		},
+		"ImmutableFieldChanged": {
+			args: args{
+				in:       *params(),
+				observed: *attachment(func(ia *compute.InterconnectAttachment) { ia.VlanTag8021q = 4000 }),
+			},
+			want: true,
+		},
+		"NothingDesired": {
+			args: args{
+				in: *params(func(p *v1alpha1.InterconnectAttachmentParameters) {
+					p.Bandwidth = nil
+					p.AdminEnabled = nil
+				}),
+				observed: *attachment(),
+			},
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, IsUpToDate(tc.args.in, tc.args.observed)); diff != "" {
+				t.Errorf("IsUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logbucket
+
+import (
+	"strings"
+
+	loggingv2 "google.golang.org/api/logging/v2"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	"github.com/crossplane/provider-gcp/apis/logging/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// Error strings.
+const (
+	errUnlockBucket      = "a locked log bucket cannot be unlocked"
+	errDecreaseRetention = "the retention period of a locked log bucket cannot be decreased"
+)
+
+// Client should be satisfied to conduct LogBucket operations.
+type Client interface {
+	Create(parent string, logbucket *loggingv2.LogBucket) *loggingv2.ProjectsLocationsBucketsCreateCall
+	Get(name string) *loggingv2.ProjectsLocationsBucketsGetCall
+	Patch(name string, logbucket *loggingv2.LogBucket) *loggingv2.ProjectsLocationsBucketsPatchCall
+	Delete(name string) *loggingv2.ProjectsLocationsBucketsDeleteCall
+}
+
+// GenerateLogBucket generates *logging.LogBucket instance from
+// LogBucketParameters.
+func GenerateLogBucket(in v1alpha1.LogBucketParameters, b *loggingv2.LogBucket) {
+	b.Description = gcp.StringValue(in.Description)
+	b.RetentionDays = gcp.Int64Value(in.RetentionDays)
+	b.Locked = gcp.BoolValue(in.Locked)
+}
+
+// GenerateLogBucketObservation produces LogBucketObservation object from
+// logging.LogBucket object.
+func GenerateLogBucketObservation(in loggingv2.LogBucket) v1alpha1.LogBucketObservation {
+	return v1alpha1.LogBucketObservation{
+		CreateTime:     in.CreateTime,
+		LifecycleState: in.LifecycleState,
+		Name:           in.Name,
+		UpdateTime:     in.UpdateTime,
+	}
+}
+
+// LateInitializeLogBucket fills unassigned fields with the values in
+// logging.LogBucket object.
+func LateInitializeLogBucket(spec *v1alpha1.LogBucketParameters, in loggingv2.LogBucket) {
+	spec.Description = gcp.LateInitializeString(spec.Description, in.Description)
+	spec.RetentionDays = gcp.LateInitializeInt64(spec.RetentionDays, in.RetentionDays)
+	spec.Locked = gcp.LateInitializeBool(spec.Locked, in.Locked)
+}
+
+// IsLogBucketUpToDate checks whether current state is up-to-date compared to
+// the given set of parameters. It returns the update mask of the fields that
+// need to be patched.
+func IsLogBucketUpToDate(in v1alpha1.LogBucketParameters, observed *loggingv2.LogBucket) (bool, string) {
+	um := make([]string, 0, 3)
+	if gcp.StringValue(in.Description) != observed.Description {
+		um = append(um, "description")
+	}
+	if in.RetentionDays != nil && *in.RetentionDays != observed.RetentionDays {
+		um = append(um, "retentionDays")
+	}
+	if gcp.BoolValue(in.Locked) != observed.Locked {
+		um = append(um, "locked")
+	}
+	return len(um) == 0, strings.Join(um, ",")
+}
+
+// ValidateLogBucketUpdate returns an error if the supplied parameters can not
+// be applied to the observed bucket, i.e. if they would unlock a locked bucket
+// or decrease its retention period.
+func ValidateLogBucketUpdate(in v1alpha1.LogBucketParameters, observed *loggingv2.LogBucket) error {
+	if !observed.Locked {
+		return nil
+	}
+	if !gcp.BoolValue(in.Locked) {
+		return errors.New(errUnlockBucket)
+	}
+	if in.RetentionDays != nil && *in.RetentionDays < observed.RetentionDays {
+		return errors.New(errDecreaseRetention)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logbucket
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	loggingv2 "google.golang.org/api/logging/v2"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/logging/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+func TestLateInitializeLogBucket(t *testing.T) {
+	type args struct {
+		spec *v1alpha1.LogBucketParameters
+		in   loggingv2.LogBucket
+	}
+	cases := map[string]struct {
+		args args
+		want *v1alpha1.LogBucketParameters
+	}{
+		"Empty": {
+			args: args{
+				spec: &v1alpha1.LogBucketParameters{},
+				in:   loggingv2.LogBucket{Description: "audit", RetentionDays: 30, Locked: true},
+			},
+			want: &v1alpha1.LogBucketParameters{
+				Description:   gcp.StringPtr("audit"),
+				RetentionDays: gcp.Int64Ptr(30),
+				Locked:        gcp.BoolPtr(true),
+			},
+		},
+		"AlreadySet": {
+			args: args{
+				spec: &v1alpha1.LogBucketParameters{RetentionDays: gcp.Int64Ptr(90), Locked: gcp.BoolPtr(false)},
+				in:   loggingv2.LogBucket{RetentionDays: 30, Locked: true},
+			},
+			want: &v1alpha1.LogBucketParameters{RetentionDays: gcp.Int64Ptr(90), Locked: gcp.BoolPtr(false)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeLogBucket(tc.args.spec, tc.args.in)
+			if diff := cmp.Diff(tc.want, tc.args.spec); diff != "" {
+				t.Errorf("LateInitializeLogBucket(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsLogBucketUpToDate(t *testing.T) {
+	type args struct {
+		in       v1alpha1.LogBucketParameters
+		observed *loggingv2.LogBucket
+	}
+	type want struct {
+		upToDate bool
+		mask     string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"UpToDate": {
+			args: args{
+				in:       v1alpha1.LogBucketParameters{RetentionDays: gcp.Int64Ptr(30), Locked: gcp.BoolPtr(true)},
+				observed: &loggingv2.LogBucket{RetentionDays: 30, Locked: true},
+			},
+			want: want{upToDate: true},
+		},
+		"RetentionDiffers": {
+			args: args{
+				in:       v1alpha1.LogBucketParameters{RetentionDays: gcp.Int64Ptr(90)},
+				observed: &loggingv2.LogBucket{RetentionDays: 30},
+			},
+			want: want{upToDate: false, mask: "retentionDays"},
+		},
+		"AllDiffer": {
+			args: args{
+				in: v1alpha1.LogBucketParameters{
+					Description:   gcp.StringPtr("audit"),
+					RetentionDays: gcp.Int64Ptr(90),
+					Locked:        gcp.BoolPtr(true),
+				},
+				observed: &loggingv2.LogBucket{RetentionDays: 30},
+			},
+			want: want{upToDate: false, mask: "description,retentionDays,locked"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			u, um := IsLogBucketUpToDate(tc.args.in, tc.args.observed)
+			if diff := cmp.Diff(tc.want, want{upToDate: u, mask: um}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("IsLogBucketUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateLogBucketUpdate(t *testing.T) {
+	type args struct {
+		in       v1alpha1.LogBucketParameters
+		observed *loggingv2.LogBucket
+	}
+	cases := map[string]struct {
+		args args
+		want error
+	}{
+		"UnlockedDecrease": {
+			args: args{
+				in:       v1alpha1.LogBucketParameters{RetentionDays: gcp.Int64Ptr(7)},
+				observed: &loggingv2.LogBucket{RetentionDays: 30},
+			},
+		},
+		"Lock": {
+			args: args{
+				in:       v1alpha1.LogBucketParameters{RetentionDays: gcp.Int64Ptr(30), Locked: gcp.BoolPtr(true)},
+				observed: &loggingv2.LogBucket{RetentionDays: 30},
+			},
+		},
+		"LockedIncrease": {
+			args: args{
+				in:       v1alpha1.LogBucketParameters{RetentionDays: gcp.Int64Ptr(90), Locked: gcp.BoolPtr(true)},
+				observed: &loggingv2.LogBucket{RetentionDays: 30, Locked: true},
+			},
+		},
+		"LockedDecrease": {
+			args: args{
+				in:       v1alpha1.LogBucketParameters{RetentionDays: gcp.Int64Ptr(7), Locked: gcp.BoolPtr(true)},
+				observed: &loggingv2.LogBucket{RetentionDays: 30, Locked: true},
+			},
+			want: errors.New(errDecreaseRetention),
+		},
+		"Unlock": {
+			args: args{
+				in:       v1alpha1.LogBucketParameters{RetentionDays: gcp.Int64Ptr(30), Locked: gcp.BoolPtr(false)},
+				observed: &loggingv2.LogBucket{RetentionDays: 30, Locked: true},
+			},
+			want: errors.New(errUnlockBucket),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateLogBucketUpdate(tc.args.in, tc.args.observed)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateLogBucketUpdate(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logview
+
+import (
+	"strings"
+
+	loggingv2 "google.golang.org/api/logging/v2"
+
+	"github.com/crossplane/provider-gcp/apis/logging/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// Client should be satisfied to conduct LogView operations.
+type Client interface {
+	Create(parent string, logview *loggingv2.LogView) *loggingv2.ProjectsLocationsBucketsViewsCreateCall
+	Get(name string) *loggingv2.ProjectsLocationsBucketsViewsGetCall
+	Patch(name string, logview *loggingv2.LogView) *loggingv2.ProjectsLocationsBucketsViewsPatchCall
+	Delete(name string) *loggingv2.ProjectsLocationsBucketsViewsDeleteCall
+}
+
+// GenerateLogView generates *logging.LogView instance from LogViewParameters.
+func GenerateLogView(in v1alpha1.LogViewParameters, v *loggingv2.LogView) {
+	v.Description = gcp.StringValue(in.Description)
+	v.Filter = gcp.StringValue(in.Filter)
+}
+
+// GenerateLogViewObservation produces LogViewObservation object from
+// logging.LogView object.
+func GenerateLogViewObservation(in loggingv2.LogView) v1alpha1.LogViewObservation {
+	return v1alpha1.LogViewObservation{
+		CreateTime: in.CreateTime,
+		Name:       in.Name,
+		UpdateTime: in.UpdateTime,
+	}
+}
+
+// LateInitializeLogView fills unassigned fields with the values in
+// logging.LogView object.
+func LateInitializeLogView(spec *v1alpha1.LogViewParameters, in loggingv2.LogView) {
+	spec.Description = gcp.LateInitializeString(spec.Description, in.Description)
+	spec.Filter = gcp.LateInitializeString(spec.Filter, in.Filter)
+}
+
+// IsLogViewUpToDate checks whether current state is up-to-date compared to
+// the given set of parameters. It returns the update mask of the fields that
+// need to be patched.
+func IsLogViewUpToDate(in v1alpha1.LogViewParameters, observed *loggingv2.LogView) (bool, string) {
+	um := make([]string, 0, 2)
+	if gcp.StringValue(in.Description) != observed.Description {
+		um = append(um, "description")
+	}
+	if gcp.StringValue(in.Filter) != observed.Filter {
+		um = append(um, "filter")
+	}
+	return len(um) == 0, strings.Join(um, ",")
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logview
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	loggingv2 "google.golang.org/api/logging/v2"
+
+	"github.com/crossplane/provider-gcp/apis/logging/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+func TestIsLogViewUpToDate(t *testing.T) {
+	type args struct {
+		in       v1alpha1.LogViewParameters
+		observed *loggingv2.LogView
+	}
+	type want struct {
+		upToDate bool
+		mask     string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"UpToDate": {
+			args: args{
+				in:       v1alpha1.LogViewParameters{Filter: gcp.StringPtr(`SOURCE("projects/test")`)},
+				observed: &loggingv2.LogView{Filter: `SOURCE("projects/test")`},
+			},
+			want: want{upToDate: true},
+		},
+		"FilterDiffers": {
+			args: args{
+				in:       v1alpha1.LogViewParameters{Filter: gcp.StringPtr(`SOURCE("projects/test")`)},
+				observed: &loggingv2.LogView{Filter: `SOURCE("projects/other")`},
+			},
+			want: want{upToDate: false, mask: "filter"},
+		},
+		"DescriptionDiffers": {
+			args: args{
+				in:       v1alpha1.LogViewParameters{Description: gcp.StringPtr("audit")},
+				observed: &loggingv2.LogView{},
+			},
+			want: want{upToDate: false, mask: "description"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			u, um := IsLogViewUpToDate(tc.args.in, tc.args.observed)
+			if diff := cmp.Diff(tc.want, want{upToDate: u, mask: um}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("IsLogViewUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
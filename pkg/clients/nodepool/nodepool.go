@@ -442,10 +442,15 @@ func IsUpToDate(name string, in *v1beta1.NodePoolParameters, observed *container
 	if !cmp.Equal(desired.Management, observed.Management, cmpopts.EquateEmpty()) {
 		return false, newManagementUpdateFn(in.Management), nil
 	}
+	// NOTE: GKE does not guarantee the order in which node locations are
+	// returned, so they are compared as a set and applied via updateNodePool.
+	if !cmp.Equal(desired.Locations, observed.Locations, cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b string) bool { return a < b })) {
+		return false, newGeneralUpdateFn(in), nil
+	}
 
 	// TODO(hasheddan): remove manual ignore functions when resolution is
 	// reached on https://github.com/crossplane/crossplane-runtime/issues/120
-	if !cmp.Equal(desired, observed, cmpopts.EquateEmpty(), cmpopts.IgnoreSliceElements(func(c *container.NodeTaint) bool {
+	if !cmp.Equal(desired, observed, cmpopts.EquateEmpty(), cmpopts.IgnoreFields(container.NodePool{}, "Locations"), cmpopts.IgnoreSliceElements(func(c *container.NodeTaint) bool {
 		return c.Key == runtimeKey
 	}), cmpopts.IgnoreMapEntries(func(key, _ string) bool {
 		return key == runtimeKey
@@ -675,6 +675,36 @@ func TestIsUpToDate(t *testing.T) {
 				isErr:    false,
 			},
 		},
+		"UpToDateLocationsReordered": {
+			args: args{
+				name: name,
+				nodePool: nodePool(func(n *container.NodePool) {
+					n.Locations = []string{"us-central1-b", "us-central1-a"}
+				}),
+				params: params(func(p *v1beta1.NodePoolParameters) {
+					p.Locations = []string{"us-central1-a", "us-central1-b"}
+				}),
+			},
+			want: want{
+				upToDate: true,
+				isErr:    false,
+			},
+		},
+		"NeedsLocationsUpdate": {
+			args: args{
+				name: name,
+				nodePool: nodePool(func(n *container.NodePool) {
+					n.Locations = []string{"us-central1-a"}
+				}),
+				params: params(func(p *v1beta1.NodePoolParameters) {
+					p.Locations = []string{"us-central1-a", "us-central1-b"}
+				}),
+			},
+			want: want{
+				upToDate: false,
+				isErr:    false,
+			},
+		},
 	}
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -0,0 +1,104 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objecthold
+
+import (
+	"context"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/storage/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+)
+
+const (
+	errListObjects          = "cannot list objects of GCP bucket"
+	errFmtReleaseHold       = "cannot release event-based hold of object %s"
+	errFmtReleasedPartially = "released the event-based holds of %d objects before failing"
+)
+
+// listFields are the fields of listed objects that holds are released by.
+const listFields googleapi.Field = "items(name,eventBasedHold),nextPageToken"
+
+// errHeld stops listing objects once a held object was found.
+var errHeld = errors.New("object is held")
+
+// Client should be satisfied to release the event-based holds of objects.
+type Client interface {
+	List(bucket string) *storage.ObjectsListCall
+	Patch(bucket string, object string, o *storage.Object) *storage.ObjectsPatchCall
+}
+
+// Held returns true if any object of the supplied bucket whose name begins
+// with the supplied prefix is under an event-based hold. The supplied user
+// project is billed for the requests if it is not empty.
+func Held(ctx context.Context, c Client, bucket, prefix, userProject string) (bool, error) {
+	err := list(c, bucket, prefix, userProject).Pages(ctx, func(r *storage.Objects) error {
+		for _, o := range r.Items {
+			if o.EventBasedHold {
+				return errHeld
+			}
+		}
+		return nil
+	})
+	if err == errHeld {
+		return true, nil
+	}
+	return false, errors.Wrap(err, errListObjects)
+}
+
+// Release releases the event-based holds of the objects of the supplied
+// bucket whose names begin with the supplied prefix, so that their retention
+// periods start. It returns how many holds were released, even if releasing
+// one failed. The supplied user project is billed for the requests if it is
+// not empty.
+func Release(ctx context.Context, c Client, bucket, prefix, userProject string) (int64, error) {
+	var released int64
+	var patchErr error
+	err := list(c, bucket, prefix, userProject).Pages(ctx, func(r *storage.Objects) error {
+		for _, o := range r.Items {
+			if !o.EventBasedHold {
+				continue
+			}
+			call := c.Patch(bucket, o.Name, &storage.Object{EventBasedHold: false, ForceSendFields: []string{"EventBasedHold"}})
+			if userProject != "" {
+				call = call.UserProject(userProject)
+			}
+			if _, err := call.Fields("name").Context(ctx).Do(); err != nil {
+				patchErr = errors.Wrapf(err, errFmtReleaseHold, o.Name)
+				return patchErr
+			}
+			released++
+		}
+		return nil
+	})
+	if err != nil && patchErr == nil {
+		err = errors.Wrap(err, errListObjects)
+	}
+	if err != nil && released > 0 {
+		return released, errors.Wrapf(err, errFmtReleasedPartially, released)
+	}
+	return released, err
+}
+
+func list(c Client, bucket, prefix, userProject string) *storage.ObjectsListCall {
+	call := c.List(bucket).Prefix(prefix).Fields(listFields)
+	if userProject != "" {
+		call = call.UserProject(userProject)
+	}
+	return call
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objecthold
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/option"
+	"google.golang.org/api/storage/v1"
+)
+
+const testBucket = "my-bucket"
+
+// objects serves the supplied pages of objects of testBucket, and records the
+// objects whose holds are released. Releasing the named failing object fails.
+type objects struct {
+	t       *testing.T
+	pages   [][]*storage.Object
+	failing string
+
+	mu       sync.Mutex
+	prefix   string
+	released []string
+}
+
+func (o *objects) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	switch r.Method {
+	case http.MethodGet:
+		o.mu.Lock()
+		o.prefix = r.URL.Query().Get("prefix")
+		o.mu.Unlock()
+		page := 0
+		if tok := r.URL.Query().Get("pageToken"); tok != "" {
+			page = len(tok)
+		}
+		res := &storage.Objects{Items: o.pages[page]}
+		if page+1 < len(o.pages) {
+			res.NextPageToken = strings.Repeat("n", page+1)
+		}
+		_ = json.NewEncoder(w).Encode(res)
+	case http.MethodPatch:
+		name := strings.TrimPrefix(r.URL.Path, "/b/"+testBucket+"/o/")
+		patch := map[string]interface{}{}
+		_ = json.NewDecoder(r.Body).Decode(&patch)
+		if diff := cmp.Diff(map[string]interface{}{"eventBasedHold": false}, patch); diff != "" {
+			o.t.Errorf("Patch(...): -want, +got:\n%s", diff)
+		}
+		if name == o.failing {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		o.mu.Lock()
+		o.released = append(o.released, name)
+		o.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(&storage.Object{Name: name})
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func newClient(t *testing.T, h http.Handler) Client {
+	t.Helper()
+	server := httptest.NewServer(h)
+	t.Cleanup(server.Close)
+	s, err := storage.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return storage.NewObjectsService(s)
+}
+
+func TestRelease(t *testing.T) {
+	type want struct {
+		released int64
+		names    []string
+		err      bool
+	}
+	cases := map[string]struct {
+		reason  string
+		pages   [][]*storage.Object
+		failing string
+		want    want
+	}{
+		"ReleasesHeldObjects": {
+			reason: "The holds of held objects on all pages should be released.",
+			pages: [][]*storage.Object{
+				{{Name: "a", EventBasedHold: true}, {Name: "b"}},
+				{{Name: "c", EventBasedHold: true}},
+			},
+			want: want{released: 2, names: []string{"a", "c"}},
+		},
+		"NothingHeld": {
+			reason: "No holds should be released if no object is held.",
+			pages:  [][]*storage.Object{{{Name: "a"}, {Name: "b"}}},
+		},
+		"FailsPartially": {
+			reason:  "The holds released before releasing one failed should be counted.",
+			pages:   [][]*storage.Object{{{Name: "a", EventBasedHold: true}, {Name: "b", EventBasedHold: true}}},
+			failing: "b",
+			want:    want{released: 1, names: []string{"a"}, err: true},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o := &objects{t: t, pages: tc.pages, failing: tc.failing}
+			released, err := Release(context.Background(), newClient(t, o), testBucket, "logs/", "")
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nRelease(...): -want error, +got error:\n%s\n%v", tc.reason, diff, err)
+			}
+			if diff := cmp.Diff(tc.want.released, released); diff != "" {
+				t.Errorf("\n%s\nRelease(...): -want released, +got released:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.names, o.released); diff != "" {
+				t.Errorf("\n%s\nRelease(...): -want released objects, +got released objects:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff("logs/", o.prefix); diff != "" {
+				t.Errorf("\n%s\nRelease(...): -want prefix, +got prefix:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestHeld(t *testing.T) {
+	cases := map[string]struct {
+		pages [][]*storage.Object
+		want  bool
+	}{
+		"HeldOnLaterPage": {
+			pages: [][]*storage.Object{{{Name: "a"}}, {{Name: "b", EventBasedHold: true}}},
+			want:  true,
+		},
+		"NothingHeld": {
+			pages: [][]*storage.Object{{{Name: "a"}}, {{Name: "b"}}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Held(context.Background(), newClient(t, &objects{t: t, pages: tc.pages}), testBucket, "", "")
+			if err != nil {
+				t.Fatalf("Held(...): unexpected error %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Held(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
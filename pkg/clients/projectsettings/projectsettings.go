@@ -0,0 +1,85 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package projectsettings
+
+import (
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// GenerateObservation takes a compute.Project and returns
+// *ProjectSettingsObservation.
+func GenerateObservation(in compute.Project) v1alpha1.ProjectSettingsObservation {
+	o := v1alpha1.ProjectSettingsObservation{
+		Name:                  in.Name,
+		DefaultNetworkTier:    in.DefaultNetworkTier,
+		DefaultServiceAccount: in.DefaultServiceAccount,
+	}
+	if in.UsageExportLocation != nil {
+		o.UsageExportBucket = in.UsageExportLocation.BucketName
+		o.UsageExportReportNamePrefix = in.UsageExportLocation.ReportNamePrefix
+	}
+	return o
+}
+
+// GenerateUsageExportLocation takes a *ProjectSettingsParameters and returns
+// the *compute.UsageExportLocation to be set on the project. An empty
+// location disables usage export.
+func GenerateUsageExportLocation(in v1alpha1.ProjectSettingsParameters) *compute.UsageExportLocation {
+	return &compute.UsageExportLocation{
+		BucketName:       gcp.StringValue(in.UsageExportBucket),
+		ReportNamePrefix: gcp.StringValue(in.UsageExportReportNamePrefix),
+	}
+}
+
+// LateInitializeSpec fills unassigned fields with the values in
+// compute.Project object. The usage export location is not late initialized
+// so that deleting a ProjectSettings never disables a usage export it did not
+// configure.
+func LateInitializeSpec(spec *v1alpha1.ProjectSettingsParameters, in compute.Project) {
+	spec.DefaultNetworkTier = gcp.LateInitializeString(spec.DefaultNetworkTier, in.DefaultNetworkTier)
+}
+
+// IsDefaultNetworkTierUpToDate returns true if the default network tier of the
+// project matches the desired one, or none is desired.
+func IsDefaultNetworkTierUpToDate(in *v1alpha1.ProjectSettingsParameters, observed *compute.Project) bool {
+	return in.DefaultNetworkTier == nil || *in.DefaultNetworkTier == observed.DefaultNetworkTier
+}
+
+// IsUsageExportLocationUpToDate returns true if the usage export location of
+// the project matches the desired one, or none is desired.
+func IsUsageExportLocationUpToDate(in *v1alpha1.ProjectSettingsParameters, observed *compute.Project) bool {
+	if in.UsageExportBucket == nil {
+		return true
+	}
+	if observed.UsageExportLocation == nil {
+		return false
+	}
+	if *in.UsageExportBucket != observed.UsageExportLocation.BucketName {
+		return false
+	}
+	// GCP defaults the report name prefix if none is given.
+	return in.UsageExportReportNamePrefix == nil || *in.UsageExportReportNamePrefix == observed.UsageExportLocation.ReportNamePrefix
+}
+
+// IsUpToDate checks whether current state is up-to-date compared to the given
+// set of parameters.
+func IsUpToDate(in *v1alpha1.ProjectSettingsParameters, observed *compute.Project) bool {
+	return IsDefaultNetworkTierUpToDate(in, observed) && IsUsageExportLocationUpToDate(in, observed)
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package projectsettings
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+)
+
+const (
+	testProject = "test-project"
+	testBucket  = "test-bucket"
+	testPrefix  = "usage"
+)
+
+var (
+	tierStandard = "STANDARD"
+	tierPremium  = "PREMIUM"
+)
+
+func params(m ...func(*v1alpha1.ProjectSettingsParameters)) *v1alpha1.ProjectSettingsParameters {
+	bucket := testBucket
+	prefix := testPrefix
+	p := &v1alpha1.ProjectSettingsParameters{
+		DefaultNetworkTier:          &tierStandard,
+		UsageExportBucket:           &bucket,
+		UsageExportReportNamePrefix: &prefix,
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func project(m ...func(*compute.Project)) *compute.Project {
+	p := &compute.Project{
+		Name:               testProject,
+		DefaultNetworkTier: tierStandard,
+		UsageExportLocation: &compute.UsageExportLocation{
+			BucketName:       testBucket,
+			ReportNamePrefix: testPrefix,
+		},
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func TestGenerateObservation(t *testing.T) {
+	cases := map[string]struct {
+		in   compute.Project
+		want v1alpha1.ProjectSettingsObservation
+	}{
+		"Initialized": {
+			in: *project(),
+			want: v1alpha1.ProjectSettingsObservation{
+				Name:                        testProject,
+				DefaultNetworkTier:          tierStandard,
+				UsageExportBucket:           testBucket,
+				UsageExportReportNamePrefix: testPrefix,
+			},
+		},
+		"NotInitialized": {
+			in: compute.Project{Name: testProject},
+			want: v1alpha1.ProjectSettingsObservation{
+				Name: testProject,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateObservation(tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateObservation(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	type args struct {
+		spec *v1alpha1.ProjectSettingsParameters
+		in   compute.Project
+	}
+	cases := map[string]struct {
+		args args
+		want *v1alpha1.ProjectSettingsParameters
+	}{
+		"AllFilledNoDiff": {
+			args: args{
+				spec: params(),
+				in:   *project(),
+			},
+			want: params(),
+		},
+		"NetworkTierLateInitialized": {
+			args: args{
+				spec: params(func(p *v1alpha1.ProjectSettingsParameters) {
+					p.DefaultNetworkTier = nil
+				}),
+				in: *project(func(p *compute.Project) {
+					p.DefaultNetworkTier = tierPremium
+				}),
+			},
+			want: params(func(p *v1alpha1.ProjectSettingsParameters) {
+				p.DefaultNetworkTier = &tierPremium
+			}),
+		},
+		"UsageExportNotLateInitialized": {
+			args: args{
+				spec: &v1alpha1.ProjectSettingsParameters{},
+				in:   *project(),
+			},
+			want: &v1alpha1.ProjectSettingsParameters{
+				DefaultNetworkTier: &tierStandard,
+			},
+		},
+		"NotInitialized": {
+			args: args{
+				spec: &v1alpha1.ProjectSettingsParameters{},
+				in:   compute.Project{Name: testProject},
+			},
+			want: &v1alpha1.ProjectSettingsParameters{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.args.spec, tc.args.in)
+			if diff := cmp.Diff(tc.want, tc.args.spec); diff != "" {
+				t.Errorf("LateInitializeSpec(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	type args struct {
+		in       *v1alpha1.ProjectSettingsParameters
+		observed *compute.Project
+	}
+	cases := map[string]struct {
+		args args
+		want bool
+	}{
+		"UpToDate": {
+			args: args{
+				in:       params(),
+				observed: project(),
+			},
+			want: true,
+		},
+		"NothingDesired": {
+			args: args{
+				in:       &v1alpha1.ProjectSettingsParameters{},
+				observed: project(),
+			},
+			want: true,
+		},
+		"DefaultReportNamePrefix": {
+			args: args{
+				in: params(func(p *v1alpha1.ProjectSettingsParameters) {
+					p.UsageExportReportNamePrefix = nil
+				}),
+				observed: project(func(p *compute.Project) {
+					p.UsageExportLocation.ReportNamePrefix = "usage_gce"
+				}),
+			},
+			want: true,
+		},
+		"NetworkTierNotInitialized": {
+			args: args{
+				in: params(),
+				observed: project(func(p *compute.Project) {
+					p.DefaultNetworkTier = ""
+				}),
+			},
+			want: false,
+		},
+		"NetworkTierDiffers": {
+			args: args{
+				in: params(),
+				observed: project(func(p *compute.Project) {
+					p.DefaultNetworkTier = tierPremium
+				}),
+			},
+			want: false,
+		},
+		"UsageExportNotInitialized": {
+			args: args{
+				in: params(),
+				observed: project(func(p *compute.Project) {
+					p.UsageExportLocation = nil
+				}),
+			},
+			want: false,
+		},
+		"UsageExportBucketDiffers": {
+			args: args{
+				in: params(),
+				observed: project(func(p *compute.Project) {
+					p.UsageExportLocation.BucketName = "other-bucket"
+				}),
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsUpToDate(tc.args.in, tc.args.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package regionbackendservice
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/mitchellh/copystructure"
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// SchemeInternal is the load balancing scheme of the backend services and
+// forwarding rules of internal TCP/UDP load balancers.
+const SchemeInternal = "INTERNAL"
+
+const balancingModeConnection = "CONNECTION"
+
+// Error strings.
+const (
+	errCheckUpToDate           = "unable to determine if external resource is up to date"
+	errFmtNetworkScheme        = "only INTERNAL backend services belong to a network, not %s ones"
+	errFmtInternalProtocol     = "INTERNAL backend services must use the TCP or UDP protocol, not %s"
+	errFmtInternalHealthChecks = "INTERNAL backend services must have exactly one health check, not %d"
+	errFmtInternalBalancing    = "backends of INTERNAL backend services must use the CONNECTION balancing mode, not %s"
+)
+
+// Validate returns an error if the supplied parameters are inconsistent with
+// their load balancing scheme.
+func Validate(in v1alpha1.RegionBackendServiceParameters) error {
+	if in.LoadBalancingScheme != SchemeInternal {
+		if in.Network != nil {
+			return errors.Errorf(errFmtNetworkScheme, in.LoadBalancingScheme)
+		}
+		return nil
+	}
+	if p := gcp.StringValue(in.Protocol); p != "" && p != "TCP" && p != "UDP" {
+		return errors.Errorf(errFmtInternalProtocol, p)
+	}
+	if len(in.HealthChecks) != 1 {
+		return errors.Errorf(errFmtInternalHealthChecks, len(in.HealthChecks))
+	}
+	for _, b := range in.Backends {
+		if m := gcp.StringValue(b.BalancingMode); m != "" && m != balancingModeConnection {
+			return errors.Errorf(errFmtInternalBalancing, m)
+		}
+	}
+	return nil
+}
+
+// GenerateBackendService takes a *RegionBackendServiceParameters and returns
+// *compute.BackendService. It assigns only the fields that are writable, i.e.
+// not labelled as [Output Only] in Google's reference.
+func GenerateBackendService(name string, in v1alpha1.RegionBackendServiceParameters, bs *compute.BackendService) {
+	bs.Name = name
+	bs.LoadBalancingScheme = in.LoadBalancingScheme
+	bs.Protocol = gcp.StringValue(in.Protocol)
+	bs.Network = gcp.StringValue(in.Network)
+	bs.HealthChecks = in.HealthChecks
+	bs.SessionAffinity = gcp.StringValue(in.SessionAffinity)
+	bs.TimeoutSec = gcp.Int64Value(in.TimeoutSec)
+	bs.Description = gcp.StringValue(in.Description)
+	bs.Backends = nil
+	for _, b := range in.Backends {
+		bs.Backends = append(bs.Backends, &compute.Backend{
+			Group:         b.Group,
+			BalancingMode: gcp.StringValue(b.BalancingMode),
+			Failover:      gcp.BoolValue(b.Failover),
+			Description:   gcp.StringValue(b.Description),
+		})
+	}
+	if in.ConnectionDraining != nil {
+		bs.ConnectionDraining = &compute.ConnectionDraining{
+			DrainingTimeoutSec: in.ConnectionDraining.DrainingTimeoutSec,
+			// A zero timeout disables draining and must be sent explicitly.
+			ForceSendFields: []string{"DrainingTimeoutSec"},
+		}
+	}
+}
+
+// GenerateBackendServiceObservation takes a compute.BackendService and returns
+// *RegionBackendServiceObservation.
+func GenerateBackendServiceObservation(in compute.BackendService) v1alpha1.RegionBackendServiceObservation {
+	return v1alpha1.RegionBackendServiceObservation{
+		CreationTimestamp: in.CreationTimestamp,
+		Fingerprint:       in.Fingerprint,
+		ID:                in.Id,
+		SelfLink:          in.SelfLink,
+	}
+}
+
+// LateInitializeSpec fills unassigned fields with the values in
+// compute.BackendService object.
+func LateInitializeSpec(spec *v1alpha1.RegionBackendServiceParameters, in compute.BackendService) {
+	spec.Protocol = gcp.LateInitializeString(spec.Protocol, in.Protocol)
+	spec.Network = gcp.LateInitializeString(spec.Network, in.Network)
+	spec.HealthChecks = gcp.LateInitializeStringSlice(spec.HealthChecks, in.HealthChecks)
+	spec.SessionAffinity = gcp.LateInitializeString(spec.SessionAffinity, in.SessionAffinity)
+	spec.TimeoutSec = gcp.LateInitializeInt64(spec.TimeoutSec, in.TimeoutSec)
+	spec.Description = gcp.LateInitializeString(spec.Description, in.Description)
+	if spec.ConnectionDraining == nil && in.ConnectionDraining != nil {
+		spec.ConnectionDraining = &v1alpha1.ConnectionDraining{DrainingTimeoutSec: in.ConnectionDraining.DrainingTimeoutSec}
+	}
+	if len(spec.Backends) == 0 {
+		for _, b := range in.Backends {
+			spec.Backends = append(spec.Backends, v1alpha1.Backend{
+				Group:         b.Group,
+				BalancingMode: gcp.StringPtr(b.BalancingMode),
+				Failover:      gcp.BoolPtr(b.Failover),
+				Description:   gcp.LateInitializeString(nil, b.Description),
+			})
+		}
+		return
+	}
+	if len(spec.Backends) != len(in.Backends) {
+		return
+	}
+	for i, b := range in.Backends {
+		if !cmp.Equal(spec.Backends[i].Group, b.Group, gcp.EquateComputeURLs()) {
+			continue
+		}
+		spec.Backends[i].BalancingMode = gcp.LateInitializeString(spec.Backends[i].BalancingMode, b.BalancingMode)
+		spec.Backends[i].Description = gcp.LateInitializeString(spec.Backends[i].Description, b.Description)
+	}
+}
+
+// IsUpToDate returns true if the supplied Kubernetes resource does not differ
+// from the supplied GCP resource.
+func IsUpToDate(name string, in v1alpha1.RegionBackendServiceParameters, observed *compute.BackendService) (bool, error) {
+	generated, err := copystructure.Copy(observed)
+	if err != nil {
+		return true, errors.Wrap(err, errCheckUpToDate)
+	}
+	desired, ok := generated.(*compute.BackendService)
+	if !ok {
+		return true, errors.New(errCheckUpToDate)
+	}
+	GenerateBackendService(name, in, desired)
+	return cmp.Equal(desired, observed,
+		cmpopts.EquateEmpty(),
+		gcp.EquateComputeURLs(),
+		cmpopts.IgnoreFields(compute.ConnectionDraining{}, "ForceSendFields"),
+		// The capacity of backends is not configurable yet, so whatever
+		// GCP defaults it to is accepted.
+		cmpopts.IgnoreFields(compute.Backend{}, "CapacityScaler", "MaxConnections", "MaxConnectionsPerEndpoint",
+			"MaxConnectionsPerInstance", "MaxRate", "MaxRatePerEndpoint", "MaxRatePerInstance", "MaxUtilization"),
+	), nil
+}
@@ -0,0 +1,295 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package regionbackendservice
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testName        = "some-name"
+	testRegion      = "us-central1"
+	testNetwork     = "global/networks/some-network"
+	testHealthCheck = "regions/us-central1/healthChecks/some-check"
+	testGroup       = "zones/us-central1-a/instanceGroups/some-group"
+	testSelfLink    = "https://www.googleapis.com/compute/v1/projects/cool-project/regions/us-central1/backendServices/some-name"
+)
+
+func params(m ...func(*v1alpha1.RegionBackendServiceParameters)) *v1alpha1.RegionBackendServiceParameters {
+	o := &v1alpha1.RegionBackendServiceParameters{
+		Region:              testRegion,
+		LoadBalancingScheme: SchemeInternal,
+		Protocol:            gcp.StringPtr("TCP"),
+		Network:             gcp.StringPtr(testNetwork),
+		HealthChecks:        []string{testHealthCheck},
+		Backends: []v1alpha1.Backend{{
+			Group:         testGroup,
+			BalancingMode: gcp.StringPtr("CONNECTION"),
+			Failover:      gcp.BoolPtr(false),
+		}},
+		ConnectionDraining: &v1alpha1.ConnectionDraining{DrainingTimeoutSec: 300},
+		SessionAffinity:    gcp.StringPtr("NONE"),
+		TimeoutSec:         gcp.Int64Ptr(30),
+		Description:        gcp.StringPtr("some desc"),
+	}
+	for _, f := range m {
+		f(o)
+	}
+	return o
+}
+
+func backendService(m ...func(*compute.BackendService)) *compute.BackendService {
+	o := &compute.BackendService{
+		Name:                testName,
+		LoadBalancingScheme: SchemeInternal,
+		Protocol:            "TCP",
+		Network:             testNetwork,
+		HealthChecks:        []string{testHealthCheck},
+		Backends: []*compute.Backend{{
+			Group:         testGroup,
+			BalancingMode: "CONNECTION",
+		}},
+		ConnectionDraining: &compute.ConnectionDraining{DrainingTimeoutSec: 300, ForceSendFields: []string{"DrainingTimeoutSec"}},
+		SessionAffinity:    "NONE",
+		TimeoutSec:         30,
+		Description:        "some desc",
+	}
+	for _, f := range m {
+		f(o)
+	}
+	return o
+}
+
+func TestValidate(t *testing.T) {
+	cases := map[string]struct {
+		in   v1alpha1.RegionBackendServiceParameters
+		want error
+	}{
+		"ValidInternal": {
+			in: *params(),
+		},
+		"ValidExternal": {
+			in: *params(func(p *v1alpha1.RegionBackendServiceParameters) {
+				p.LoadBalancingScheme = "EXTERNAL"
+				p.Network = nil
+				p.HealthChecks = nil
+			}),
+		},
+		"ExternalWithNetwork": {
+			in:   *params(func(p *v1alpha1.RegionBackendServiceParameters) { p.LoadBalancingScheme = "EXTERNAL" }),
+			want: errors.Errorf(errFmtNetworkScheme, "EXTERNAL"),
+		},
+		"InternalHTTP": {
+			in:   *params(func(p *v1alpha1.RegionBackendServiceParameters) { p.Protocol = gcp.StringPtr("HTTP") }),
+			want: errors.Errorf(errFmtInternalProtocol, "HTTP"),
+		},
+		"InternalWithoutHealthCheck": {
+			in:   *params(func(p *v1alpha1.RegionBackendServiceParameters) { p.HealthChecks = nil }),
+			want: errors.Errorf(errFmtInternalHealthChecks, 0),
+		},
+		"InternalUtilization": {
+			in: *params(func(p *v1alpha1.RegionBackendServiceParameters) {
+				p.Backends[0].BalancingMode = gcp.StringPtr("UTILIZATION")
+			}),
+			want: errors.Errorf(errFmtInternalBalancing, "UTILIZATION"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, Validate(tc.in), test.EquateErrors()); diff != "" {
+				t.Errorf("Validate(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateBackendService(t *testing.T) {
+	cases := map[string]struct {
+		in   v1alpha1.RegionBackendServiceParameters
+		want *compute.BackendService
+	}{
+		"FullConversion": {
+			in:   *params(),
+			want: backendService(),
+		},
+		"MissingFields": {
+			in: v1alpha1.RegionBackendServiceParameters{
+				Region:              testRegion,
+				LoadBalancingScheme: SchemeInternal,
+			},
+			want: &compute.BackendService{Name: testName, LoadBalancingScheme: SchemeInternal},
+		},
+		"DrainingDisabled": {
+			in: *params(func(p *v1alpha1.RegionBackendServiceParameters) {
+				p.ConnectionDraining = &v1alpha1.ConnectionDraining{}
+			}),
+			want: backendService(func(bs *compute.BackendService) {
+				bs.ConnectionDraining = &compute.ConnectionDraining{ForceSendFields: []string{"DrainingTimeoutSec"}}
+			}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &compute.BackendService{}
+			GenerateBackendService(testName, tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateBackendService(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateBackendServiceObservation(t *testing.T) {
+	in := backendService(func(bs *compute.BackendService) {
+		bs.CreationTimestamp = "10/10/2023"
+		bs.Fingerprint = "fingerprint"
+		bs.Id = 2029819203
+		bs.SelfLink = testSelfLink
+	})
+	want := v1alpha1.RegionBackendServiceObservation{
+		CreationTimestamp: "10/10/2023",
+		Fingerprint:       "fingerprint",
+		ID:                2029819203,
+		SelfLink:          testSelfLink,
+	}
+	if diff := cmp.Diff(want, GenerateBackendServiceObservation(*in)); diff != "" {
+		t.Errorf("GenerateBackendServiceObservation(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	type args struct {
+		spec *v1alpha1.RegionBackendServiceParameters
+		in   compute.BackendService
+	}
+	cases := map[string]struct {
+		args args
+		want *v1alpha1.RegionBackendServiceParameters
+	}{
+		"AllFilledAlready": {
+			args: args{
+				spec: params(),
+				in:   *backendService(),
+			},
+			want: params(),
+		},
+		"DefaultsFilled": {
+			args: args{
+				spec: params(func(p *v1alpha1.RegionBackendServiceParameters) {
+					p.Protocol = nil
+					p.Network = nil
+					p.HealthChecks = nil
+					p.Backends[0].BalancingMode = nil
+					p.ConnectionDraining = nil
+					p.SessionAffinity = nil
+					p.TimeoutSec = nil
+					p.Description = nil
+				}),
+				in: *backendService(),
+			},
+			want: params(),
+		},
+		"BackendsFilled": {
+			args: args{
+				spec: params(func(p *v1alpha1.RegionBackendServiceParameters) { p.Backends = nil }),
+				in:   *backendService(),
+			},
+			want: params(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.args.spec, tc.args.in)
+			if diff := cmp.Diff(tc.want, tc.args.spec); diff != "" {
+				t.Errorf("LateInitializeSpec(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	type args struct {
+		in       v1alpha1.RegionBackendServiceParameters
+		observed *compute.BackendService
+	}
+	cases := map[string]struct {
+		args args
+		want bool
+	}{
+		"UpToDate": {
+			args: args{
+				in: *params(),
+				observed: backendService(func(bs *compute.BackendService) {
+					bs.Fingerprint = "fingerprint"
+					bs.Backends[0].CapacityScaler = 1
+					bs.ConnectionDraining.ForceSendFields = nil
+				}),
+			},
+			want: true,
+		},
+		"FullyQualifiedURLs": {
+			args: args{
+				in: *params(),
+				observed: backendService(func(bs *compute.BackendService) {
+					bs.HealthChecks = []string{"https://www.googleapis.com/compute/v1/projects/cool-project/" + testHealthCheck}
+				}),
+			},
+			want: true,
+		},
+		"DrainingChanged": {
+			args: args{
+				in: *params(),
+				observed: backendService(func(bs *compute.BackendService) {
+					bs.ConnectionDraining = &compute.ConnectionDraining{DrainingTimeoutSec: 60}
+				}),
+			},
+			want: false,
+		},
+		"BackendRemoved": {
+			args: args{
+				in: *params(),
+				observed: backendService(func(bs *compute.BackendService) {
+					bs.Backends = append(bs.Backends, &compute.Backend{Group: "zones/us-central1-b/instanceGroups/other", BalancingMode: "CONNECTION"})
+				}),
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := IsUpToDate(testName, tc.args.in, tc.args.observed)
+			if err != nil {
+				t.Errorf("IsUpToDate(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reservation
+
+import (
+	"strings"
+
+	"google.golang.org/api/bigqueryreservation/v1"
+
+	"github.com/crossplane/provider-gcp/apis/bigquery/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// Client should be satisfied to conduct Reservation operations.
+type Client interface {
+	Create(parent string, reservation *bigqueryreservation.Reservation) *bigqueryreservation.ProjectsLocationsReservationsCreateCall
+	Get(name string) *bigqueryreservation.ProjectsLocationsReservationsGetCall
+	Patch(name string, reservation *bigqueryreservation.Reservation) *bigqueryreservation.ProjectsLocationsReservationsPatchCall
+	Delete(name string) *bigqueryreservation.ProjectsLocationsReservationsDeleteCall
+}
+
+// GenerateReservation generates *bigqueryreservation.Reservation instance
+// from ReservationParameters.
+func GenerateReservation(in v1alpha1.ReservationParameters, r *bigqueryreservation.Reservation) {
+	r.SlotCapacity = in.SlotCapacity
+	r.IgnoreIdleSlots = gcp.BoolValue(in.IgnoreIdleSlots)
+}
+
+// GenerateReservationObservation produces ReservationObservation object from
+// bigqueryreservation.Reservation object.
+func GenerateReservationObservation(in bigqueryreservation.Reservation) v1alpha1.ReservationObservation {
+	return v1alpha1.ReservationObservation{
+		CreationTime: in.CreationTime,
+		Name:         in.Name,
+		UpdateTime:   in.UpdateTime,
+	}
+}
+
+// LateInitializeReservation fills unassigned fields with the values in
+// bigqueryreservation.Reservation object.
+func LateInitializeReservation(spec *v1alpha1.ReservationParameters, in bigqueryreservation.Reservation) {
+	spec.IgnoreIdleSlots = gcp.LateInitializeBool(spec.IgnoreIdleSlots, in.IgnoreIdleSlots)
+}
+
+// IsReservationUpToDate checks whether current state is up-to-date compared
+// to the given set of parameters. It returns the update mask of the fields
+// that need to be patched.
+func IsReservationUpToDate(in v1alpha1.ReservationParameters, observed *bigqueryreservation.Reservation) (bool, string) {
+	um := make([]string, 0, 2)
+	if in.SlotCapacity != observed.SlotCapacity {
+		um = append(um, "slotCapacity")
+	}
+	if in.IgnoreIdleSlots != nil && *in.IgnoreIdleSlots != observed.IgnoreIdleSlots {
+		um = append(um, "ignoreIdleSlots")
+	}
+	return len(um) == 0, strings.Join(um, ",")
+}
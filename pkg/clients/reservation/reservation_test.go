@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reservation
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/bigqueryreservation/v1"
+
+	"github.com/crossplane/provider-gcp/apis/bigquery/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+func TestLateInitializeReservation(t *testing.T) {
+	type args struct {
+		spec *v1alpha1.ReservationParameters
+		in   bigqueryreservation.Reservation
+	}
+	cases := map[string]struct {
+		args args
+		want *v1alpha1.ReservationParameters
+	}{
+		"Empty": {
+			args: args{
+				spec: &v1alpha1.ReservationParameters{SlotCapacity: 100},
+				in:   bigqueryreservation.Reservation{SlotCapacity: 500, IgnoreIdleSlots: true},
+			},
+			want: &v1alpha1.ReservationParameters{SlotCapacity: 100, IgnoreIdleSlots: gcp.BoolPtr(true)},
+		},
+		"AlreadySet": {
+			args: args{
+				spec: &v1alpha1.ReservationParameters{SlotCapacity: 100, IgnoreIdleSlots: gcp.BoolPtr(false)},
+				in:   bigqueryreservation.Reservation{SlotCapacity: 100, IgnoreIdleSlots: true},
+			},
+			want: &v1alpha1.ReservationParameters{SlotCapacity: 100, IgnoreIdleSlots: gcp.BoolPtr(false)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeReservation(tc.args.spec, tc.args.in)
+			if diff := cmp.Diff(tc.want, tc.args.spec); diff != "" {
+				t.Errorf("LateInitializeReservation(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsReservationUpToDate(t *testing.T) {
+	type args struct {
+		in       v1alpha1.ReservationParameters
+		observed *bigqueryreservation.Reservation
+	}
+	type want struct {
+		upToDate bool
+		mask     string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"UpToDate": {
+			args: args{
+				in:       v1alpha1.ReservationParameters{SlotCapacity: 100, IgnoreIdleSlots: gcp.BoolPtr(true)},
+				observed: &bigqueryreservation.Reservation{SlotCapacity: 100, IgnoreIdleSlots: true},
+			},
+			want: want{upToDate: true},
+		},
+		"SlotsIncreased": {
+			args: args{
+				in:       v1alpha1.ReservationParameters{SlotCapacity: 200},
+				observed: &bigqueryreservation.Reservation{SlotCapacity: 100},
+			},
+			want: want{upToDate: false, mask: "slotCapacity"},
+		},
+		"SlotsRemoved": {
+			args: args{
+				in:       v1alpha1.ReservationParameters{SlotCapacity: 0},
+				observed: &bigqueryreservation.Reservation{SlotCapacity: 100},
+			},
+			want: want{upToDate: false, mask: "slotCapacity"},
+		},
+		"AllDiffer": {
+			args: args{
+				in:       v1alpha1.ReservationParameters{SlotCapacity: 200, IgnoreIdleSlots: gcp.BoolPtr(true)},
+				observed: &bigqueryreservation.Reservation{SlotCapacity: 100},
+			},
+			want: want{upToDate: false, mask: "slotCapacity,ignoreIdleSlots"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			u, um := IsReservationUpToDate(tc.args.in, tc.args.observed)
+			if diff := cmp.Diff(tc.want, want{upToDate: u, mask: um}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("IsReservationUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
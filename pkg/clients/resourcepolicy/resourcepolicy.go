@@ -0,0 +1,149 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcepolicy
+
+import (
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// GenerateResourcePolicy takes a *ResourcePolicyParameters and returns
+// *compute.ResourcePolicy. It assigns only the fields that are writable, i.e.
+// not labelled as [Output Only] in Google's reference.
+func GenerateResourcePolicy(name string, in v1alpha1.ResourcePolicyParameters, rp *compute.ResourcePolicy) {
+	rp.Name = name
+	rp.Region = in.Region
+	rp.Description = gcp.StringValue(in.Description)
+
+	if in.SnapshotSchedulePolicy == nil {
+		return
+	}
+	sp := in.SnapshotSchedulePolicy
+	rp.SnapshotSchedulePolicy = &compute.ResourcePolicySnapshotSchedulePolicy{
+		Schedule: &compute.ResourcePolicySnapshotSchedulePolicySchedule{},
+	}
+	if s := sp.Schedule.DailySchedule; s != nil {
+		rp.SnapshotSchedulePolicy.Schedule.DailySchedule = &compute.ResourcePolicyDailyCycle{
+			DaysInCycle: s.DaysInCycle,
+			StartTime:   s.StartTime,
+		}
+	}
+	if s := sp.Schedule.HourlySchedule; s != nil {
+		rp.SnapshotSchedulePolicy.Schedule.HourlySchedule = &compute.ResourcePolicyHourlyCycle{
+			HoursInCycle: s.HoursInCycle,
+			StartTime:    s.StartTime,
+		}
+	}
+	if s := sp.Schedule.WeeklySchedule; s != nil {
+		rp.SnapshotSchedulePolicy.Schedule.WeeklySchedule = &compute.ResourcePolicyWeeklyCycle{
+			DayOfWeeks: make([]*compute.ResourcePolicyWeeklyCycleDayOfWeek, len(s.DayOfWeeks)),
+		}
+		for i, d := range s.DayOfWeeks {
+			rp.SnapshotSchedulePolicy.Schedule.WeeklySchedule.DayOfWeeks[i] = &compute.ResourcePolicyWeeklyCycleDayOfWeek{
+				Day:       d.Day,
+				StartTime: d.StartTime,
+			}
+		}
+	}
+	if r := sp.RetentionPolicy; r != nil {
+		rp.SnapshotSchedulePolicy.RetentionPolicy = &compute.ResourcePolicySnapshotSchedulePolicyRetentionPolicy{
+			MaxRetentionDays:   r.MaxRetentionDays,
+			OnSourceDiskDelete: gcp.StringValue(r.OnSourceDiskDelete),
+		}
+	}
+	if p := sp.SnapshotProperties; p != nil {
+		rp.SnapshotSchedulePolicy.SnapshotProperties = &compute.ResourcePolicySnapshotSchedulePolicySnapshotProperties{
+			ChainName:        gcp.StringValue(p.ChainName),
+			GuestFlush:       gcp.BoolValue(p.GuestFlush),
+			Labels:           p.Labels,
+			StorageLocations: p.StorageLocations,
+		}
+	}
+}
+
+// GenerateResourcePolicyObservation takes a compute.ResourcePolicy and returns
+// *ResourcePolicyObservation.
+func GenerateResourcePolicyObservation(in compute.ResourcePolicy) v1alpha1.ResourcePolicyObservation {
+	return v1alpha1.ResourcePolicyObservation{
+		CreationTimestamp: in.CreationTimestamp,
+		ID:                in.Id,
+		SelfLink:          in.SelfLink,
+		Status:            in.Status,
+	}
+}
+
+// LateInitializeSpec fills unassigned fields with the values in
+// compute.ResourcePolicy object.
+func LateInitializeSpec(spec *v1alpha1.ResourcePolicyParameters, in compute.ResourcePolicy) {
+	spec.Description = gcp.LateInitializeString(spec.Description, in.Description)
+
+	if spec.SnapshotSchedulePolicy == nil || in.SnapshotSchedulePolicy == nil {
+		return
+	}
+	sp := spec.SnapshotSchedulePolicy
+	if r := in.SnapshotSchedulePolicy.RetentionPolicy; r != nil && sp.RetentionPolicy != nil {
+		sp.RetentionPolicy.OnSourceDiskDelete = gcp.LateInitializeString(sp.RetentionPolicy.OnSourceDiskDelete, r.OnSourceDiskDelete)
+	}
+	if p := in.SnapshotSchedulePolicy.SnapshotProperties; p != nil {
+		if sp.SnapshotProperties == nil {
+			sp.SnapshotProperties = &v1alpha1.ResourcePolicySnapshotSchedulePolicySnapshotProperties{}
+		}
+		sp.SnapshotProperties.StorageLocations = gcp.LateInitializeStringSlice(sp.SnapshotProperties.StorageLocations, p.StorageLocations)
+	}
+}
+
+// BindingDelta returns the resource policies that must be added to and removed
+// from a disk whose resource policies are observed in order for it to have
+// exactly the desired resource policies. Resource policies are compared
+// whether they are fully qualified, partially qualified, or unqualified.
+func BindingDelta(desired, observed []string) (add []string, remove []string) {
+	for _, d := range desired {
+		if !containsURL(observed, d) {
+			add = append(add, d)
+		}
+	}
+	for _, o := range observed {
+		if !containsURL(desired, o) {
+			remove = append(remove, o)
+		}
+	}
+	return add, remove
+}
+
+// AttachedPolicies returns the desired resource policies that are among the
+// observed resource policies of a disk.
+func AttachedPolicies(desired, observed []string) []string {
+	var attached []string
+	for _, o := range observed {
+		if containsURL(desired, o) {
+			attached = append(attached, o)
+		}
+	}
+	return attached
+}
+
+func containsURL(urls []string, url string) bool {
+	for _, u := range urls {
+		if cmp.Equal(u, url, gcp.EquateComputeURLs()) {
+			return true
+		}
+	}
+	return false
+}
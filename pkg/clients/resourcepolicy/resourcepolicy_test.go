@@ -0,0 +1,282 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcepolicy
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+)
+
+const (
+	testName              = "some-name"
+	testRegion            = "us-central1"
+	testCreationTimestamp = "10/10/2023"
+	testSelfLink          = "https://www.googleapis.com/compute/v1/projects/cool-project/regions/us-central1/resourcePolicies/some-name"
+)
+
+var (
+	testDescription        = "some desc"
+	testOnSourceDiskDelete = "KEEP_AUTO_SNAPSHOTS"
+)
+
+func params(m ...func(*v1alpha1.ResourcePolicyParameters)) *v1alpha1.ResourcePolicyParameters {
+	o := &v1alpha1.ResourcePolicyParameters{
+		Region:      testRegion,
+		Description: &testDescription,
+		SnapshotSchedulePolicy: &v1alpha1.ResourcePolicySnapshotSchedulePolicy{
+			Schedule: v1alpha1.ResourcePolicySnapshotSchedulePolicySchedule{
+				WeeklySchedule: &v1alpha1.ResourcePolicyWeeklyCycle{
+					DayOfWeeks: []v1alpha1.ResourcePolicyWeeklyCycleDayOfWeek{
+						{Day: "MONDAY", StartTime: "04:00"},
+					},
+				},
+			},
+			RetentionPolicy: &v1alpha1.ResourcePolicySnapshotSchedulePolicyRetentionPolicy{
+				MaxRetentionDays:   14,
+				OnSourceDiskDelete: &testOnSourceDiskDelete,
+			},
+			SnapshotProperties: &v1alpha1.ResourcePolicySnapshotSchedulePolicySnapshotProperties{
+				StorageLocations: []string{"us"},
+			},
+		},
+	}
+
+	for _, f := range m {
+		f(o)
+	}
+
+	return o
+}
+
+func resourcePolicy(m ...func(*compute.ResourcePolicy)) *compute.ResourcePolicy {
+	o := &compute.ResourcePolicy{
+		Name:        testName,
+		Region:      testRegion,
+		Description: testDescription,
+		SnapshotSchedulePolicy: &compute.ResourcePolicySnapshotSchedulePolicy{
+			Schedule: &compute.ResourcePolicySnapshotSchedulePolicySchedule{
+				WeeklySchedule: &compute.ResourcePolicyWeeklyCycle{
+					DayOfWeeks: []*compute.ResourcePolicyWeeklyCycleDayOfWeek{
+						{Day: "MONDAY", StartTime: "04:00"},
+					},
+				},
+			},
+			RetentionPolicy: &compute.ResourcePolicySnapshotSchedulePolicyRetentionPolicy{
+				MaxRetentionDays:   14,
+				OnSourceDiskDelete: testOnSourceDiskDelete,
+			},
+			SnapshotProperties: &compute.ResourcePolicySnapshotSchedulePolicySnapshotProperties{
+				StorageLocations: []string{"us"},
+			},
+		},
+	}
+
+	for _, f := range m {
+		f(o)
+	}
+
+	return o
+}
+
+func TestGenerateResourcePolicy(t *testing.T) {
+	type args struct {
+		name string
+		in   v1alpha1.ResourcePolicyParameters
+	}
+	cases := map[string]struct {
+		args args
+		want *compute.ResourcePolicy
+	}{
+		"FullConversion": {
+			args: args{
+				name: testName,
+				in:   *params(),
+			},
+			want: resourcePolicy(),
+		},
+		"DailySchedule": {
+			args: args{
+				name: testName,
+				in: *params(func(p *v1alpha1.ResourcePolicyParameters) {
+					p.SnapshotSchedulePolicy.Schedule = v1alpha1.ResourcePolicySnapshotSchedulePolicySchedule{
+						DailySchedule: &v1alpha1.ResourcePolicyDailyCycle{DaysInCycle: 1, StartTime: "08:00"},
+					}
+					p.SnapshotSchedulePolicy.RetentionPolicy = nil
+					p.SnapshotSchedulePolicy.SnapshotProperties = nil
+				}),
+			},
+			want: resourcePolicy(func(rp *compute.ResourcePolicy) {
+				rp.SnapshotSchedulePolicy = &compute.ResourcePolicySnapshotSchedulePolicy{
+					Schedule: &compute.ResourcePolicySnapshotSchedulePolicySchedule{
+						DailySchedule: &compute.ResourcePolicyDailyCycle{DaysInCycle: 1, StartTime: "08:00"},
+					},
+				}
+			}),
+		},
+		"HourlySchedule": {
+			args: args{
+				name: testName,
+				in: *params(func(p *v1alpha1.ResourcePolicyParameters) {
+					p.SnapshotSchedulePolicy.Schedule = v1alpha1.ResourcePolicySnapshotSchedulePolicySchedule{
+						HourlySchedule: &v1alpha1.ResourcePolicyHourlyCycle{HoursInCycle: 4, StartTime: "00:00"},
+					}
+				}),
+			},
+			want: resourcePolicy(func(rp *compute.ResourcePolicy) {
+				rp.SnapshotSchedulePolicy.Schedule = &compute.ResourcePolicySnapshotSchedulePolicySchedule{
+					HourlySchedule: &compute.ResourcePolicyHourlyCycle{HoursInCycle: 4, StartTime: "00:00"},
+				}
+			}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &compute.ResourcePolicy{}
+			GenerateResourcePolicy(tc.args.name, tc.args.in, r)
+			if diff := cmp.Diff(tc.want, r); diff != "" {
+				t.Errorf("GenerateResourcePolicy(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateResourcePolicyObservation(t *testing.T) {
+	in := resourcePolicy(func(rp *compute.ResourcePolicy) {
+		rp.CreationTimestamp = testCreationTimestamp
+		rp.Id = 2029819203
+		rp.SelfLink = testSelfLink
+		rp.Status = v1alpha1.ResourcePolicyStatusReady
+	})
+	want := v1alpha1.ResourcePolicyObservation{
+		CreationTimestamp: testCreationTimestamp,
+		ID:                2029819203,
+		SelfLink:          testSelfLink,
+		Status:            v1alpha1.ResourcePolicyStatusReady,
+	}
+	if diff := cmp.Diff(want, GenerateResourcePolicyObservation(*in)); diff != "" {
+		t.Errorf("GenerateResourcePolicyObservation(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	type args struct {
+		spec *v1alpha1.ResourcePolicyParameters
+		in   compute.ResourcePolicy
+	}
+	cases := map[string]struct {
+		args args
+		want *v1alpha1.ResourcePolicyParameters
+	}{
+		"AllFilledAlready": {
+			args: args{
+				spec: params(),
+				in:   *resourcePolicy(),
+			},
+			want: params(),
+		},
+		"DefaultsFilled": {
+			args: args{
+				spec: params(func(p *v1alpha1.ResourcePolicyParameters) {
+					p.Description = nil
+					p.SnapshotSchedulePolicy.RetentionPolicy.OnSourceDiskDelete = nil
+					p.SnapshotSchedulePolicy.SnapshotProperties = nil
+				}),
+				in: *resourcePolicy(),
+			},
+			want: params(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.args.spec, tc.args.in)
+			if diff := cmp.Diff(tc.want, tc.args.spec); diff != "" {
+				t.Errorf("LateInitializeSpec(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBindingDelta(t *testing.T) {
+	daily := "projects/cool-project/regions/us-central1/resourcePolicies/daily"
+	weekly := "projects/cool-project/regions/us-central1/resourcePolicies/weekly"
+	observedDaily := "https://www.googleapis.com/compute/v1/" + daily
+
+	type args struct {
+		desired  []string
+		observed []string
+	}
+	type want struct {
+		add      []string
+		remove   []string
+		attached []string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"UpToDate": {
+			args: args{
+				desired:  []string{daily},
+				observed: []string{observedDaily},
+			},
+			want: want{
+				attached: []string{observedDaily},
+			},
+		},
+		"Add": {
+			args: args{
+				desired:  []string{daily, weekly},
+				observed: []string{observedDaily},
+			},
+			want: want{
+				add:      []string{weekly},
+				attached: []string{observedDaily},
+			},
+		},
+		"Remove": {
+			args: args{
+				desired:  []string{weekly},
+				observed: []string{observedDaily},
+			},
+			want: want{
+				add:    []string{weekly},
+				remove: []string{observedDaily},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			add, remove := BindingDelta(tc.args.desired, tc.args.observed)
+			if diff := cmp.Diff(tc.want.add, add); diff != "" {
+				t.Errorf("BindingDelta(...): -want add, +got add:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.remove, remove); diff != "" {
+				t.Errorf("BindingDelta(...): -want remove, +got remove:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.attached, AttachedPolicies(tc.args.desired, tc.args.observed)); diff != "" {
+				t.Errorf("AttachedPolicies(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
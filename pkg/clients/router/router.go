@@ -0,0 +1,178 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// GenerateRouterInterface takes a *RouterInterfaceParameters and returns
+// *compute.RouterInterface. It assigns only the fields that are writable,
+// i.e. not labelled as [Output Only] in Google's reference.
+func GenerateRouterInterface(name string, in v1alpha1.RouterInterfaceParameters, ri *compute.RouterInterface) {
+	ri.Name = name
+	ri.IpRange = gcp.StringValue(in.IPRange)
+	ri.LinkedVpnTunnel = gcp.StringValue(in.LinkedVPNTunnel)
+	ri.LinkedInterconnectAttachment = gcp.StringValue(in.LinkedInterconnectAttachment)
+}
+
+// GenerateRouterPeer takes a *RouterPeerParameters and returns
+// *compute.RouterBgpPeer. It assigns only the fields that are writable, i.e.
+// not labelled as [Output Only] in Google's reference.
+func GenerateRouterPeer(name string, in v1alpha1.RouterPeerParameters, p *compute.RouterBgpPeer) {
+	p.Name = name
+	p.InterfaceName = gcp.StringValue(in.InterfaceName)
+	p.IpAddress = gcp.StringValue(in.IPAddress)
+	p.PeerIpAddress = gcp.StringValue(in.PeerIPAddress)
+	p.PeerAsn = in.PeerASN
+	p.AdvertisedRoutePriority = gcp.Int64Value(in.AdvertisedRoutePriority)
+	p.AdvertiseMode = gcp.StringValue(in.AdvertiseMode)
+	p.AdvertisedGroups = in.AdvertisedGroups
+	p.AdvertisedIpRanges = nil
+	for _, r := range in.AdvertisedIPRanges {
+		p.AdvertisedIpRanges = append(p.AdvertisedIpRanges, &compute.RouterAdvertisedIpRange{
+			Range:       r.Range,
+			Description: gcp.StringValue(r.Description),
+		})
+	}
+}
+
+// GenerateRouterInterfaceObservation takes a compute.RouterInterface and
+// returns *RouterInterfaceObservation.
+func GenerateRouterInterfaceObservation(in compute.RouterInterface) v1alpha1.RouterInterfaceObservation {
+	return v1alpha1.RouterInterfaceObservation{ManagementType: in.ManagementType}
+}
+
+// GenerateRouterPeerObservation takes a compute.RouterBgpPeer and returns
+// *RouterPeerObservation.
+func GenerateRouterPeerObservation(in compute.RouterBgpPeer) v1alpha1.RouterPeerObservation {
+	return v1alpha1.RouterPeerObservation{ManagementType: in.ManagementType}
+}
+
+// LateInitializeRouterInterfaceSpec fills unassigned fields with the values
+// in compute.RouterInterface object.
+func LateInitializeRouterInterfaceSpec(spec *v1alpha1.RouterInterfaceParameters, in compute.RouterInterface) {
+	spec.IPRange = gcp.LateInitializeString(spec.IPRange, in.IpRange)
+	spec.LinkedVPNTunnel = gcp.LateInitializeString(spec.LinkedVPNTunnel, in.LinkedVpnTunnel)
+	spec.LinkedInterconnectAttachment = gcp.LateInitializeString(spec.LinkedInterconnectAttachment, in.LinkedInterconnectAttachment)
+}
+
+// LateInitializeRouterPeerSpec fills unassigned fields with the values in
+// compute.RouterBgpPeer object.
+func LateInitializeRouterPeerSpec(spec *v1alpha1.RouterPeerParameters, in compute.RouterBgpPeer) {
+	spec.InterfaceName = gcp.LateInitializeString(spec.InterfaceName, in.InterfaceName)
+	spec.IPAddress = gcp.LateInitializeString(spec.IPAddress, in.IpAddress)
+	spec.PeerIPAddress = gcp.LateInitializeString(spec.PeerIPAddress, in.PeerIpAddress)
+	spec.AdvertisedRoutePriority = gcp.LateInitializeInt64(spec.AdvertisedRoutePriority, in.AdvertisedRoutePriority)
+	spec.AdvertiseMode = gcp.LateInitializeString(spec.AdvertiseMode, in.AdvertiseMode)
+}
+
+// IsRouterInterfaceUpToDate checks whether current state is up-to-date
+// compared to the given set of parameters.
+func IsRouterInterfaceUpToDate(name string, in v1alpha1.RouterInterfaceParameters, observed compute.RouterInterface) bool {
+	desired := observed
+	GenerateRouterInterface(name, in, &desired)
+	return cmp.Equal(desired, observed)
+}
+
+// IsRouterPeerUpToDate checks whether current state is up-to-date compared to
+// the given set of parameters.
+func IsRouterPeerUpToDate(name string, in v1alpha1.RouterPeerParameters, observed compute.RouterBgpPeer) bool {
+	desired := observed
+	GenerateRouterPeer(name, in, &desired)
+	return cmp.Equal(desired, observed, cmpopts.EquateEmpty())
+}
+
+// FindInterface returns the interface of the supplied router with the supplied
+// name, or nil if the router has no such interface.
+func FindInterface(name string, r *compute.Router) *compute.RouterInterface {
+	for _, i := range r.Interfaces {
+		if i.Name == name {
+			return i
+		}
+	}
+	return nil
+}
+
+// FindPeer returns the BGP peer of the supplied router with the supplied name,
+// or nil if the router has no such BGP peer.
+func FindPeer(name string, r *compute.Router) *compute.RouterBgpPeer {
+	for _, p := range r.BgpPeers {
+		if p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// WithInterface returns a patch of the supplied router that adds the supplied
+// interface, or replaces the interface of the same name. Interfaces are
+// patched as a whole, so the patch includes all of the router's other
+// interfaces.
+func WithInterface(r *compute.Router, ri *compute.RouterInterface) *compute.Router {
+	p := &compute.Router{ForceSendFields: []string{"Interfaces"}}
+	for _, i := range r.Interfaces {
+		if i.Name != ri.Name {
+			p.Interfaces = append(p.Interfaces, i)
+		}
+	}
+	p.Interfaces = append(p.Interfaces, ri)
+	return p
+}
+
+// WithoutInterface returns a patch of the supplied router that removes the
+// interface with the supplied name.
+func WithoutInterface(r *compute.Router, name string) *compute.Router {
+	p := &compute.Router{ForceSendFields: []string{"Interfaces"}}
+	for _, i := range r.Interfaces {
+		if i.Name != name {
+			p.Interfaces = append(p.Interfaces, i)
+		}
+	}
+	return p
+}
+
+// WithPeer returns a patch of the supplied router that adds the supplied BGP
+// peer, or replaces the BGP peer of the same name. BGP peers are patched as a
+// whole, so the patch includes all of the router's other BGP peers.
+func WithPeer(r *compute.Router, bp *compute.RouterBgpPeer) *compute.Router {
+	p := &compute.Router{ForceSendFields: []string{"BgpPeers"}}
+	for _, b := range r.BgpPeers {
+		if b.Name != bp.Name {
+			p.BgpPeers = append(p.BgpPeers, b)
+		}
+	}
+	p.BgpPeers = append(p.BgpPeers, bp)
+	return p
+}
+
+// WithoutPeer returns a patch of the supplied router that removes the BGP peer
+// with the supplied name.
+func WithoutPeer(r *compute.Router, name string) *compute.Router {
+	p := &compute.Router{ForceSendFields: []string{"BgpPeers"}}
+	for _, b := range r.BgpPeers {
+		if b.Name != name {
+			p.BgpPeers = append(p.BgpPeers, b)
+		}
+	}
+	return p
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const testPeer = "onprem-peer"
+
+func TestIsRouterPeerUpToDate(t *testing.T) {
+	observed := compute.RouterBgpPeer{
+		Name:               testPeer,
+		PeerAsn:            65001,
+		AdvertiseMode:      "CUSTOM",
+		AdvertisedGroups:   []string{"ALL_SUBNETS"},
+		AdvertisedIpRanges: []*compute.RouterAdvertisedIpRange{{Range: "10.0.0.0/8"}},
+		ManagementType:     "MANAGED_BY_USER",
+	}
+
+	cases := map[string]struct {
+		in   v1alpha1.RouterPeerParameters
+		want bool
+	}{
+		"UpToDate": {
+			in: v1alpha1.RouterPeerParameters{
+				PeerASN:            65001,
+				AdvertiseMode:      gcp.StringPtr("CUSTOM"),
+				AdvertisedGroups:   []string{"ALL_SUBNETS"},
+				AdvertisedIPRanges: []v1alpha1.RouterAdvertisedIPRange{{Range: "10.0.0.0/8"}},
+			},
+			want: true,
+		},
+		"AdvertiseModeChanged": {
+			in: v1alpha1.RouterPeerParameters{
+				PeerASN:       65001,
+				AdvertiseMode: gcp.StringPtr("DEFAULT"),
+			},
+			want: false,
+		},
+		"AdvertisedRangeRemoved": {
+			in: v1alpha1.RouterPeerParameters{
+				PeerASN:          65001,
+				AdvertiseMode:    gcp.StringPtr("CUSTOM"),
+				AdvertisedGroups: []string{"ALL_SUBNETS"},
+			},
+			want: false,
+		},
+		"PeerASNChanged": {
+			in: v1alpha1.RouterPeerParameters{
+				PeerASN:            65002,
+				AdvertiseMode:      gcp.StringPtr("CUSTOM"),
+				AdvertisedGroups:   []string{"ALL_SUBNETS"},
+				AdvertisedIPRanges: []v1alpha1.RouterAdvertisedIPRange{{Range: "10.0.0.0/8"}},
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, IsRouterPeerUpToDate(testPeer, tc.in, observed)); diff != "" {
+				t.Errorf("IsRouterPeerUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWithPeer(t *testing.T) {
+	a := &compute.RouterBgpPeer{Name: "a", PeerAsn: 65001}
+	b := &compute.RouterBgpPeer{Name: "b", PeerAsn: 65002}
+	updated := &compute.RouterBgpPeer{Name: "a", PeerAsn: 65003}
+
+	cases := map[string]struct {
+		r    *compute.Router
+		p    *compute.RouterBgpPeer
+		want []*compute.RouterBgpPeer
+	}{
+		"Add": {
+			r:    &compute.Router{BgpPeers: []*compute.RouterBgpPeer{a}},
+			p:    b,
+			want: []*compute.RouterBgpPeer{a, b},
+		},
+		"Replace": {
+			r:    &compute.Router{BgpPeers: []*compute.RouterBgpPeer{a, b}},
+			p:    updated,
+			want: []*compute.RouterBgpPeer{b, updated},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, WithPeer(tc.r, tc.p).BgpPeers); diff != "" {
+				t.Errorf("WithPeer(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWithoutPeer(t *testing.T) {
+	a := &compute.RouterBgpPeer{Name: "a"}
+	b := &compute.RouterBgpPeer{Name: "b"}
+
+	got := WithoutPeer(&compute.Router{BgpPeers: []*compute.RouterBgpPeer{a, b}}, "a")
+	if diff := cmp.Diff([]*compute.RouterBgpPeer{b}, got.BgpPeers); diff != "" {
+		t.Errorf("WithoutPeer(...): -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"BgpPeers"}, got.ForceSendFields); diff != "" {
+		t.Errorf("WithoutPeer(...): -want force send fields, +got force send fields:\n%s", diff)
+	}
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runservice
+
+import (
+	"fmt"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	run "google.golang.org/api/run/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	"github.com/crossplane/provider-gcp/apis/run/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// Knative type metadata of Cloud Run services.
+const (
+	apiVersion = "serving.knative.dev/v1"
+	kind       = "Service"
+)
+
+// ConditionReady is the condition of a service that reports whether its
+// latest revision serves traffic as desired.
+const ConditionReady = "Ready"
+
+const (
+	serviceNameFormat = "namespaces/%s/services/%s"
+	endpointFormat    = "https://%s-run.googleapis.com/"
+)
+
+// Error strings.
+const (
+	errTrafficTarget     = "exactly one of revisionName and latestRevision must be set for each traffic target"
+	errFmtTrafficPercent = "the traffic percentages must sum to 100, not %d"
+)
+
+// GetFullyQualifiedName builds the fully qualified name of the service.
+func GetFullyQualifiedName(project, name string) string {
+	return fmt.Sprintf(serviceNameFormat, project, name)
+}
+
+// GetParent returns the namespace services of the supplied project are
+// created in.
+func GetParent(project string) string {
+	return "namespaces/" + project
+}
+
+// GetEndpoint returns the regional endpoint that manages the services of the
+// supplied region.
+func GetEndpoint(region string) string {
+	return fmt.Sprintf(endpointFormat, region)
+}
+
+// Validate returns an error if the supplied traffic split is inconsistent,
+// i.e. if its percentages do not sum to 100 and not all of its targets are
+// the latest revision.
+func Validate(in v1alpha1.ServiceParameters) error {
+	sum := int64(0)
+	latest := true
+	for _, t := range in.Traffic {
+		if (t.RevisionName == nil) == !gcp.BoolValue(t.LatestRevision) {
+			return errors.New(errTrafficTarget)
+		}
+		sum += gcp.Int64Value(t.Percent)
+		latest = latest && gcp.BoolValue(t.LatestRevision) && t.Percent == nil
+	}
+	if len(in.Traffic) > 0 && sum != 100 && !latest {
+		return errors.Errorf(errFmtTrafficPercent, sum)
+	}
+	return nil
+}
+
+// GenerateService takes a *ServiceParameters and assigns the fields of the
+// supplied *run.Service it configures. Fields of an observed service that the
+// parameters do not configure, e.g. the defaulted resources of its container,
+// are kept so that the service can be replaced with the result.
+func GenerateService(project, name string, in v1alpha1.ServiceParameters, s *run.Service) {
+	s.ApiVersion = apiVersion
+	s.Kind = kind
+	if s.Metadata == nil {
+		s.Metadata = &run.ObjectMeta{}
+	}
+	s.Metadata.Name = name
+	s.Metadata.Namespace = project
+	if s.Spec == nil {
+		s.Spec = &run.ServiceSpec{}
+	}
+	s.Spec.Traffic = GenerateTraffic(in.Traffic)
+	if s.Spec.Template == nil {
+		s.Spec.Template = &run.RevisionTemplate{}
+	}
+	t := s.Spec.Template
+	if t.Metadata == nil {
+		t.Metadata = &run.ObjectMeta{}
+	}
+	t.Metadata.Name = gcp.StringValue(in.Template.Name)
+	if t.Spec == nil {
+		t.Spec = &run.RevisionSpec{}
+	}
+	t.Spec.ServiceAccountName = gcp.StringValue(in.Template.ServiceAccountName)
+	containers := make([]*run.Container, len(in.Template.Containers))
+	for i, c := range in.Template.Containers {
+		containers[i] = &run.Container{}
+		if i < len(t.Spec.Containers) {
+			containers[i] = t.Spec.Containers[i]
+		}
+		containers[i].Image = c.Image
+		containers[i].Args = c.Args
+		containers[i].Env = nil
+		for _, e := range c.Env {
+			containers[i].Env = append(containers[i].Env, &run.EnvVar{Name: e.Name, Value: gcp.StringValue(e.Value)})
+		}
+	}
+	t.Spec.Containers = containers
+}
+
+// GenerateTraffic takes the desired []TrafficTarget and returns the
+// []*run.TrafficTarget of a service. Targets that are all the latest revision
+// and set no percentage route all traffic to the first of them.
+func GenerateTraffic(in []v1alpha1.TrafficTarget) []*run.TrafficTarget {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]*run.TrafficTarget, len(in))
+	latest := true
+	for i, t := range in {
+		out[i] = &run.TrafficTarget{
+			RevisionName:   gcp.StringValue(t.RevisionName),
+			LatestRevision: gcp.BoolValue(t.LatestRevision),
+			Percent:        gcp.Int64Value(t.Percent),
+			Tag:            gcp.StringValue(t.Tag),
+		}
+		latest = latest && out[i].LatestRevision && t.Percent == nil
+	}
+	if latest {
+		out[0].Percent = 100
+	}
+	return out
+}
+
+// GenerateServiceObservation takes a run.Service and returns
+// *ServiceObservation.
+func GenerateServiceObservation(in run.Service) v1alpha1.ServiceObservation {
+	o := v1alpha1.ServiceObservation{}
+	if in.Status == nil {
+		return o
+	}
+	o.URL = in.Status.Url
+	o.LatestCreatedRevisionName = in.Status.LatestCreatedRevisionName
+	o.LatestReadyRevisionName = in.Status.LatestReadyRevisionName
+	o.ObservedGeneration = in.Status.ObservedGeneration
+	for _, t := range in.Status.Traffic {
+		o.Traffic = append(o.Traffic, v1alpha1.TrafficTargetStatus{
+			RevisionName:   t.RevisionName,
+			LatestRevision: t.LatestRevision,
+			Percent:        t.Percent,
+			Tag:            t.Tag,
+			URL:            t.Url,
+		})
+	}
+	return o
+}
+
+// GetCondition returns the condition of the supplied type of the supplied
+// service, or nil if it has none.
+func GetCondition(in run.Service, ct string) *run.GoogleCloudRunV1Condition {
+	if in.Status == nil {
+		return nil
+	}
+	for _, c := range in.Status.Conditions {
+		if c.Type == ct {
+			return c
+		}
+	}
+	return nil
+}
+
+// LateInitializeSpec fills unassigned fields with the values in run.Service
+// object.
+func LateInitializeSpec(spec *v1alpha1.ServiceParameters, in run.Service) {
+	if in.Spec == nil {
+		return
+	}
+	if t := in.Spec.Template; t != nil && t.Spec != nil {
+		spec.Template.ServiceAccountName = gcp.LateInitializeString(spec.Template.ServiceAccountName, t.Spec.ServiceAccountName)
+	}
+	if len(spec.Traffic) > 0 {
+		return
+	}
+	for _, t := range in.Spec.Traffic {
+		tt := v1alpha1.TrafficTarget{
+			RevisionName: gcp.LateInitializeString(nil, t.RevisionName),
+			Percent:      gcp.Int64Ptr(t.Percent),
+			Tag:          gcp.LateInitializeString(nil, t.Tag),
+		}
+		if t.LatestRevision {
+			tt.LatestRevision = gcp.BoolPtr(true)
+		}
+		spec.Traffic = append(spec.Traffic, tt)
+	}
+}
+
+// IsTrafficUpToDate returns true if the observed service splits its traffic
+// as desired, regardless of the order of the traffic targets.
+func IsTrafficUpToDate(in v1alpha1.ServiceParameters, observed run.Service) bool {
+	var current []*run.TrafficTarget
+	if observed.Spec != nil {
+		current = observed.Spec.Traffic
+	}
+	return cmp.Equal(GenerateTraffic(in.Traffic), current,
+		cmpopts.EquateEmpty(),
+		cmpopts.IgnoreFields(run.TrafficTarget{}, "ConfigurationName", "Url"),
+		cmpopts.SortSlices(func(a, b *run.TrafficTarget) bool {
+			return fmt.Sprintf("%s/%s/%t", a.Tag, a.RevisionName, a.LatestRevision) < fmt.Sprintf("%s/%s/%t", b.Tag, b.RevisionName, b.LatestRevision)
+		}),
+	)
+}
+
+// IsTemplateUpToDate returns true if the observed service creates its
+// revisions from the desired template. Defaulted fields of the template that
+// the parameters do not configure are not considered.
+func IsTemplateUpToDate(project, name string, in v1alpha1.ServiceParameters, observed run.Service) bool {
+	if observed.Spec == nil || observed.Spec.Template == nil {
+		return false
+	}
+	current := observed.Spec.Template
+	desired := &run.Service{}
+	GenerateService(project, name, in, desired)
+	if in.Template.Name == nil {
+		// A generated revision name is not desired to be kept.
+		desired.Spec.Template.Metadata.Name = ""
+		current = &run.RevisionTemplate{Metadata: &run.ObjectMeta{}, Spec: current.Spec}
+	}
+	if in.Template.ServiceAccountName == nil && current.Spec != nil {
+		desired.Spec.Template.Spec.ServiceAccountName = current.Spec.ServiceAccountName
+	}
+	return cmp.Equal(desired.Spec.Template, current,
+		cmpopts.EquateEmpty(),
+		cmpopts.IgnoreFields(run.ObjectMeta{}, "Annotations", "Labels", "Generation", "CreationTimestamp"),
+		cmpopts.IgnoreFields(run.RevisionSpec{}, "ContainerConcurrency", "TimeoutSeconds", "Volumes"),
+		cmpopts.IgnoreFields(run.Container{}, "Name", "Ports", "Resources", "Command", "EnvFrom", "ImagePullPolicy",
+			"LivenessProbe", "ReadinessProbe", "SecurityContext", "StartupProbe", "TerminationMessagePath",
+			"TerminationMessagePolicy", "VolumeMounts", "WorkingDir"),
+	)
+}
+
+// IsUpToDate returns true if the observed service runs the desired template
+// and splits its traffic as desired.
+func IsUpToDate(project, name string, in v1alpha1.ServiceParameters, observed run.Service) bool {
+	return IsTemplateUpToDate(project, name, in, observed) && IsTrafficUpToDate(in, observed)
+}
@@ -0,0 +1,323 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runservice
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	run "google.golang.org/api/run/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/run/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testProject = "cool-project"
+	testName    = "hello"
+	testImage   = "us-docker.pkg.dev/cloudrun/container/hello"
+)
+
+func split(stable, canary int64) []v1alpha1.TrafficTarget {
+	return []v1alpha1.TrafficTarget{
+		{RevisionName: gcp.StringPtr("hello-v1"), Percent: gcp.Int64Ptr(stable), Tag: gcp.StringPtr("stable")},
+		{RevisionName: gcp.StringPtr("hello-v2"), Percent: gcp.Int64Ptr(canary), Tag: gcp.StringPtr("canary")},
+	}
+}
+
+func params(m ...func(*v1alpha1.ServiceParameters)) *v1alpha1.ServiceParameters {
+	o := &v1alpha1.ServiceParameters{
+		Location: "us-central1",
+		Template: v1alpha1.RevisionTemplate{
+			Name:       gcp.StringPtr("hello-v2"),
+			Containers: []v1alpha1.Container{{Image: testImage, Env: []v1alpha1.EnvVar{{Name: "GREETING", Value: gcp.StringPtr("hi")}}}},
+		},
+		Traffic: split(90, 10),
+	}
+	for _, f := range m {
+		f(o)
+	}
+	return o
+}
+
+func service(m ...func(*run.Service)) *run.Service {
+	o := &run.Service{
+		ApiVersion: "serving.knative.dev/v1",
+		Kind:       "Service",
+		Metadata:   &run.ObjectMeta{Name: testName, Namespace: testProject},
+		Spec: &run.ServiceSpec{
+			Template: &run.RevisionTemplate{
+				Metadata: &run.ObjectMeta{Name: "hello-v2"},
+				Spec: &run.RevisionSpec{
+					Containers: []*run.Container{{Image: testImage, Env: []*run.EnvVar{{Name: "GREETING", Value: "hi"}}}},
+				},
+			},
+			Traffic: []*run.TrafficTarget{
+				{RevisionName: "hello-v1", Percent: 90, Tag: "stable"},
+				{RevisionName: "hello-v2", Percent: 10, Tag: "canary"},
+			},
+		},
+	}
+	for _, f := range m {
+		f(o)
+	}
+	return o
+}
+
+func TestValidate(t *testing.T) {
+	cases := map[string]struct {
+		in   v1alpha1.ServiceParameters
+		want error
+	}{
+		"Split": {
+			in: *params(),
+		},
+		"NoTraffic": {
+			in: *params(func(p *v1alpha1.ServiceParameters) { p.Traffic = nil }),
+		},
+		"AllLatest": {
+			in: *params(func(p *v1alpha1.ServiceParameters) {
+				p.Traffic = []v1alpha1.TrafficTarget{
+					{LatestRevision: gcp.BoolPtr(true)},
+					{LatestRevision: gcp.BoolPtr(true), Tag: gcp.StringPtr("latest")},
+				}
+			}),
+		},
+		"TaggedWithoutTraffic": {
+			in: *params(func(p *v1alpha1.ServiceParameters) {
+				p.Traffic = append(split(100, 0), v1alpha1.TrafficTarget{LatestRevision: gcp.BoolPtr(true), Tag: gcp.StringPtr("latest")})
+			}),
+		},
+		"NotHundred": {
+			in:   *params(func(p *v1alpha1.ServiceParameters) { p.Traffic = split(90, 20) }),
+			want: errors.Errorf(errFmtTrafficPercent, 110),
+		},
+		"LatestWithoutHundred": {
+			in: *params(func(p *v1alpha1.ServiceParameters) {
+				p.Traffic = []v1alpha1.TrafficTarget{{LatestRevision: gcp.BoolPtr(true), Percent: gcp.Int64Ptr(50)}}
+			}),
+			want: errors.Errorf(errFmtTrafficPercent, 50),
+		},
+		"TwoTargets": {
+			in: *params(func(p *v1alpha1.ServiceParameters) {
+				p.Traffic[0].LatestRevision = gcp.BoolPtr(true)
+			}),
+			want: errors.New(errTrafficTarget),
+		},
+		"NoTarget": {
+			in: *params(func(p *v1alpha1.ServiceParameters) {
+				p.Traffic[0].RevisionName = nil
+			}),
+			want: errors.New(errTrafficTarget),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := Validate(tc.in)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Validate(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateService(t *testing.T) {
+	cases := map[string]struct {
+		in       v1alpha1.ServiceParameters
+		observed *run.Service
+		want     *run.Service
+	}{
+		"New": {
+			in:       *params(),
+			observed: &run.Service{},
+			want:     service(),
+		},
+		"AllLatest": {
+			in: *params(func(p *v1alpha1.ServiceParameters) {
+				p.Traffic = []v1alpha1.TrafficTarget{{LatestRevision: gcp.BoolPtr(true)}}
+			}),
+			observed: &run.Service{},
+			want: service(func(s *run.Service) {
+				s.Spec.Traffic = []*run.TrafficTarget{{LatestRevision: true, Percent: 100}}
+			}),
+		},
+		"KeepDefaults": {
+			in: *params(func(p *v1alpha1.ServiceParameters) { p.Traffic = split(50, 50) }),
+			observed: service(func(s *run.Service) {
+				s.Metadata.Generation = 2
+				s.Spec.Template.Spec.TimeoutSeconds = 300
+				s.Spec.Template.Spec.Containers[0].Ports = []*run.ContainerPort{{ContainerPort: 8080}}
+			}),
+			want: service(func(s *run.Service) {
+				s.Metadata.Generation = 2
+				s.Spec.Template.Spec.TimeoutSeconds = 300
+				s.Spec.Template.Spec.Containers[0].Ports = []*run.ContainerPort{{ContainerPort: 8080}}
+				s.Spec.Traffic[0].Percent = 50
+				s.Spec.Traffic[1].Percent = 50
+			}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			GenerateService(testProject, testName, tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, tc.observed); diff != "" {
+				t.Errorf("GenerateService(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateServiceObservation(t *testing.T) {
+	cases := map[string]struct {
+		in   run.Service
+		want v1alpha1.ServiceObservation
+	}{
+		"NoStatus": {
+			in: *service(),
+		},
+		"TaggedTargets": {
+			in: *service(func(s *run.Service) {
+				s.Status = &run.ServiceStatus{
+					Url:                     "https://hello-abc123-uc.a.run.app",
+					LatestReadyRevisionName: "hello-v2",
+					Traffic: []*run.TrafficTarget{
+						{RevisionName: "hello-v1", Percent: 90, Tag: "stable", Url: "https://stable---hello-abc123-uc.a.run.app"},
+						{RevisionName: "hello-v2", Percent: 10, Tag: "canary", Url: "https://canary---hello-abc123-uc.a.run.app"},
+					},
+				}
+			}),
+			want: v1alpha1.ServiceObservation{
+				URL:                     "https://hello-abc123-uc.a.run.app",
+				LatestReadyRevisionName: "hello-v2",
+				Traffic: []v1alpha1.TrafficTargetStatus{
+					{RevisionName: "hello-v1", Percent: 90, Tag: "stable", URL: "https://stable---hello-abc123-uc.a.run.app"},
+					{RevisionName: "hello-v2", Percent: 10, Tag: "canary", URL: "https://canary---hello-abc123-uc.a.run.app"},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateServiceObservation(tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateServiceObservation(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	cases := map[string]struct {
+		spec     *v1alpha1.ServiceParameters
+		observed run.Service
+		want     *v1alpha1.ServiceParameters
+	}{
+		"DefaultTraffic": {
+			spec: params(func(p *v1alpha1.ServiceParameters) { p.Traffic = nil }),
+			observed: *service(func(s *run.Service) {
+				s.Spec.Template.Spec.ServiceAccountName = "123-compute@developer.gserviceaccount.com"
+				s.Spec.Traffic = []*run.TrafficTarget{{LatestRevision: true, Percent: 100}}
+			}),
+			want: params(func(p *v1alpha1.ServiceParameters) {
+				p.Template.ServiceAccountName = gcp.StringPtr("123-compute@developer.gserviceaccount.com")
+				p.Traffic = []v1alpha1.TrafficTarget{{LatestRevision: gcp.BoolPtr(true), Percent: gcp.Int64Ptr(100)}}
+			}),
+		},
+		"NoOverride": {
+			spec: params(),
+			observed: *service(func(s *run.Service) {
+				s.Spec.Traffic = []*run.TrafficTarget{{LatestRevision: true, Percent: 100}}
+			}),
+			want: params(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.spec, tc.observed)
+			if diff := cmp.Diff(tc.want, tc.spec); diff != "" {
+				t.Errorf("LateInitializeSpec(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		in       v1alpha1.ServiceParameters
+		observed run.Service
+		want     bool
+	}{
+		"UpToDate": {
+			in:       *params(),
+			observed: *service(),
+			want:     true,
+		},
+		"TargetsReordered": {
+			in: *params(),
+			observed: *service(func(s *run.Service) {
+				s.Spec.Traffic[0], s.Spec.Traffic[1] = s.Spec.Traffic[1], s.Spec.Traffic[0]
+			}),
+			want: true,
+		},
+		"DefaultsIgnored": {
+			in: *params(func(p *v1alpha1.ServiceParameters) { p.Template.Name = nil }),
+			observed: *service(func(s *run.Service) {
+				s.Spec.Template.Metadata.Annotations = map[string]string{"autoscaling.knative.dev/maxScale": "100"}
+				s.Spec.Template.Spec.ContainerConcurrency = 80
+				s.Spec.Template.Spec.ServiceAccountName = "123-compute@developer.gserviceaccount.com"
+				s.Spec.Template.Spec.Containers[0].Resources = &run.ResourceRequirements{Limits: map[string]string{"cpu": "1000m"}}
+			}),
+			want: true,
+		},
+		"SplitChanged": {
+			in:       *params(func(p *v1alpha1.ServiceParameters) { p.Traffic = split(50, 50) }),
+			observed: *service(),
+		},
+		"TagChanged": {
+			in: *params(func(p *v1alpha1.ServiceParameters) {
+				p.Traffic[1].Tag = gcp.StringPtr("beta")
+			}),
+			observed: *service(),
+		},
+		"ImageChanged": {
+			in: *params(func(p *v1alpha1.ServiceParameters) {
+				p.Template.Containers[0].Image = "us-docker.pkg.dev/cloudrun/container/hello:v3"
+			}),
+			observed: *service(),
+		},
+		"RevisionNameChanged": {
+			in:       *params(func(p *v1alpha1.ServiceParameters) { p.Template.Name = gcp.StringPtr("hello-v3") }),
+			observed: *service(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsUpToDate(testProject, testName, tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
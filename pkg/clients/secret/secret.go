@@ -0,0 +1,238 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	secretmanager "google.golang.org/api/secretmanager/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	"github.com/crossplane/provider-gcp/apis/secretmanager/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	projectNameFormat = "projects/%s"
+	secretNameFormat  = "projects/%s/secrets/%s"
+	topicNameFormat   = "projects/%s/topics/%s"
+)
+
+// Error strings.
+const (
+	errRotationTopics     = "rotation requires at least one topic to notify"
+	errRotationPeriodTime = "rotationPeriod requires nextRotationTime"
+)
+
+// GetProjectName returns the name of the supplied project, i.e. the parent of
+// its secrets.
+func GetProjectName(project string) string {
+	return fmt.Sprintf(projectNameFormat, project)
+}
+
+// GetFullyQualifiedName builds the fully qualified name of the secret.
+func GetFullyQualifiedName(project, name string) string {
+	return fmt.Sprintf(secretNameFormat, project, name)
+}
+
+// Validate returns an error if the supplied parameters are inconsistent, e.g.
+// if the secret is to be rotated without a topic to notify of it.
+func Validate(in v1alpha1.SecretParameters) error {
+	if in.Rotation == nil {
+		return nil
+	}
+	if len(in.Topics) == 0 {
+		return errors.New(errRotationTopics)
+	}
+	if in.Rotation.RotationPeriod != nil && in.Rotation.NextRotationTime == nil {
+		return errors.New(errRotationPeriodTime)
+	}
+	return nil
+}
+
+// GenerateSecret takes a SecretParameters and returns the *secretmanager.Secret
+// that creates it in the supplied project.
+func GenerateSecret(projectID string, in v1alpha1.SecretParameters) *secretmanager.Secret {
+	s := &secretmanager.Secret{
+		Labels:      in.Labels,
+		Replication: &secretmanager.Replication{Automatic: &secretmanager.Automatic{}},
+		Topics:      generateTopics(projectID, in.Topics),
+		Rotation:    generateRotation(in.Rotation),
+	}
+	if r := in.Replication; r != nil && r.UserManaged != nil {
+		um := &secretmanager.UserManaged{}
+		for _, l := range r.UserManaged.Locations {
+			um.Replicas = append(um.Replicas, &secretmanager.Replica{Location: l})
+		}
+		s.Replication = &secretmanager.Replication{UserManaged: um}
+	}
+	return s
+}
+
+// TopicName returns the full name of the supplied topic, which is assumed to
+// belong to the supplied project if it is not qualified.
+func TopicName(projectID, topic string) string {
+	if strings.Contains(topic, "/") {
+		return topic
+	}
+	return fmt.Sprintf(topicNameFormat, projectID, topic)
+}
+
+func generateTopics(projectID string, in []string) []*secretmanager.Topic {
+	var out []*secretmanager.Topic
+	for _, t := range in {
+		out = append(out, &secretmanager.Topic{Name: TopicName(projectID, t)})
+	}
+	return out
+}
+
+func generateRotation(in *v1alpha1.Rotation) *secretmanager.Rotation {
+	if in == nil {
+		return nil
+	}
+	return &secretmanager.Rotation{
+		NextRotationTime: gcp.StringValue(in.NextRotationTime),
+		RotationPeriod:   gcp.StringValue(in.RotationPeriod),
+	}
+}
+
+// GenerateObservation takes a secretmanager.Secret and returns
+// *SecretObservation.
+func GenerateObservation(in secretmanager.Secret) v1alpha1.SecretObservation {
+	o := v1alpha1.SecretObservation{
+		CreateTime: in.CreateTime,
+		Name:       in.Name,
+	}
+	if in.Rotation != nil {
+		o.NextRotationTime = in.Rotation.NextRotationTime
+	}
+	return o
+}
+
+// LateInitialize fills the empty fields of SecretParameters with the values of
+// the supplied secret. Topics and rotation are not late initialized; omitting
+// them removes them from the secret.
+func LateInitialize(spec *v1alpha1.SecretParameters, in secretmanager.Secret) {
+	if len(spec.Labels) == 0 && len(in.Labels) != 0 {
+		spec.Labels = map[string]string{}
+		for k, v := range in.Labels {
+			spec.Labels[k] = v
+		}
+	}
+	if spec.Replication == nil && in.Replication != nil && in.Replication.UserManaged != nil {
+		um := &v1alpha1.UserManagedReplication{}
+		for _, r := range in.Replication.UserManaged.Replicas {
+			um.Locations = append(um.Locations, r.Location)
+		}
+		spec.Replication = &v1alpha1.Replication{UserManaged: um}
+	}
+}
+
+// AreTopicsUpToDate returns true if the supplied secret notifies the desired
+// topics, regardless of their order and of whether they are qualified.
+func AreTopicsUpToDate(projectID string, in v1alpha1.SecretParameters, observed secretmanager.Secret) bool {
+	desired := make([]string, len(in.Topics))
+	for i, t := range in.Topics {
+		desired[i] = TopicName(projectID, t)
+	}
+	actual := make([]string, len(observed.Topics))
+	for i, t := range observed.Topics {
+		actual[i] = t.Name
+	}
+	return cmp.Equal(desired, actual, cmpopts.SortSlices(func(a, b string) bool { return a < b }))
+}
+
+// IsRotationUpToDate returns true if the supplied secret is rotated as
+// desired at the supplied time. A desired next rotation time that has passed
+// is up to date, because GCP advances it by the rotation period after each
+// rotation.
+func IsRotationUpToDate(in v1alpha1.SecretParameters, observed secretmanager.Secret, now time.Time) bool {
+	d, o := in.Rotation, observed.Rotation
+	if d == nil || o == nil {
+		return d == nil && o == nil
+	}
+	return isRotationPeriodUpToDate(d.RotationPeriod, o.RotationPeriod) &&
+		isNextRotationTimeUpToDate(d.NextRotationTime, o.NextRotationTime, now)
+}
+
+func isRotationPeriodUpToDate(desired *string, observed string) bool {
+	if desired == nil {
+		return observed == ""
+	}
+	dp, derr := time.ParseDuration(*desired)
+	op, oerr := time.ParseDuration(observed)
+	if derr != nil || oerr != nil {
+		return *desired == observed
+	}
+	return dp == op
+}
+
+func isNextRotationTimeUpToDate(desired *string, observed string, now time.Time) bool {
+	if desired == nil {
+		return observed == ""
+	}
+	dt, err := time.Parse(time.RFC3339, *desired)
+	if err != nil {
+		return *desired == observed
+	}
+	if !dt.After(now) {
+		return true
+	}
+	ot, err := time.Parse(time.RFC3339, observed)
+	return err == nil && ot.Equal(dt)
+}
+
+// IsUpToDate returns true if the labels, topics and rotation of the supplied
+// secret match the desired ones at the supplied time. Its replication can not
+// be updated and is not considered.
+func IsUpToDate(projectID string, in v1alpha1.SecretParameters, observed secretmanager.Secret, now time.Time) bool {
+	return cmp.Equal(in.Labels, observed.Labels, cmpopts.EquateEmpty()) &&
+		AreTopicsUpToDate(projectID, in, observed) &&
+		IsRotationUpToDate(in, observed, now)
+}
+
+// GenerateUpdate returns the *secretmanager.Secret that patches the supplied
+// secret as desired at the supplied time, and the update mask of the fields it
+// changes.
+func GenerateUpdate(projectID string, in v1alpha1.SecretParameters, observed secretmanager.Secret, now time.Time) (*secretmanager.Secret, string) {
+	s := &secretmanager.Secret{}
+	var mask []string
+	if !cmp.Equal(in.Labels, observed.Labels, cmpopts.EquateEmpty()) {
+		mask = append(mask, "labels")
+		s.Labels = in.Labels
+	}
+	if !AreTopicsUpToDate(projectID, in, observed) {
+		mask = append(mask, "topics")
+		s.Topics = generateTopics(projectID, in.Topics)
+	}
+	if !IsRotationUpToDate(in, observed, now) {
+		mask = append(mask, "rotation")
+		s.Rotation = generateRotation(in.Rotation)
+		// GCP rejects a next rotation time that has passed, so the one it
+		// advanced to is kept.
+		if s.Rotation != nil && observed.Rotation != nil &&
+			isNextRotationTimeUpToDate(in.Rotation.NextRotationTime, observed.Rotation.NextRotationTime, now) {
+			s.Rotation.NextRotationTime = observed.Rotation.NextRotationTime
+		}
+	}
+	return s, strings.Join(mask, ",")
+}
@@ -0,0 +1,313 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	secretmanager "google.golang.org/api/secretmanager/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/secretmanager/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	projectID  = "cool-project"
+	testTopic  = "projects/cool-project/topics/rotations"
+	testPeriod = "2592000s"
+	pastTime   = "2021-01-01T00:00:00Z"
+	futureTime = "2099-01-01T00:00:00Z"
+)
+
+var now = time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func params(m ...func(*v1alpha1.SecretParameters)) *v1alpha1.SecretParameters {
+	p := &v1alpha1.SecretParameters{
+		Labels: map[string]string{"team": "payments"},
+		Topics: []string{"rotations"},
+		Rotation: &v1alpha1.Rotation{
+			NextRotationTime: gcp.StringPtr(futureTime),
+			RotationPeriod:   gcp.StringPtr(testPeriod),
+		},
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func secret(m ...func(*secretmanager.Secret)) *secretmanager.Secret {
+	s := &secretmanager.Secret{
+		Labels:      map[string]string{"team": "payments"},
+		Replication: &secretmanager.Replication{Automatic: &secretmanager.Automatic{}},
+		Topics:      []*secretmanager.Topic{{Name: testTopic}},
+		Rotation:    &secretmanager.Rotation{NextRotationTime: futureTime, RotationPeriod: testPeriod},
+	}
+	for _, f := range m {
+		f(s)
+	}
+	return s
+}
+
+func TestValidate(t *testing.T) {
+	cases := map[string]struct {
+		in   v1alpha1.SecretParameters
+		want error
+	}{
+		"Valid": {
+			in: *params(),
+		},
+		"NoRotation": {
+			in: *params(func(p *v1alpha1.SecretParameters) {
+				p.Topics = nil
+				p.Rotation = nil
+			}),
+		},
+		"RotationWithoutTopics": {
+			in:   *params(func(p *v1alpha1.SecretParameters) { p.Topics = nil }),
+			want: errors.New(errRotationTopics),
+		},
+		"PeriodWithoutTime": {
+			in:   *params(func(p *v1alpha1.SecretParameters) { p.Rotation.NextRotationTime = nil }),
+			want: errors.New(errRotationPeriodTime),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := Validate(tc.in)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Validate(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	cases := map[string]struct {
+		in   v1alpha1.SecretParameters
+		want *secretmanager.Secret
+	}{
+		"Automatic": {
+			in:   *params(),
+			want: secret(),
+		},
+		"UserManaged": {
+			in: *params(func(p *v1alpha1.SecretParameters) {
+				p.Replication = &v1alpha1.Replication{UserManaged: &v1alpha1.UserManagedReplication{Locations: []string{"us-east1"}}}
+				p.Topics = []string{"projects/other-project/topics/rotations"}
+				p.Rotation = nil
+			}),
+			want: secret(func(s *secretmanager.Secret) {
+				s.Replication = &secretmanager.Replication{UserManaged: &secretmanager.UserManaged{
+					Replicas: []*secretmanager.Replica{{Location: "us-east1"}},
+				}}
+				s.Topics = []*secretmanager.Topic{{Name: "projects/other-project/topics/rotations"}}
+				s.Rotation = nil
+			}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateSecret(projectID, tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateSecret(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLateInitialize(t *testing.T) {
+	cases := map[string]struct {
+		spec     *v1alpha1.SecretParameters
+		observed secretmanager.Secret
+		want     *v1alpha1.SecretParameters
+	}{
+		"Empty": {
+			spec: &v1alpha1.SecretParameters{},
+			observed: *secret(func(s *secretmanager.Secret) {
+				s.Replication = &secretmanager.Replication{UserManaged: &secretmanager.UserManaged{
+					Replicas: []*secretmanager.Replica{{Location: "us-east1"}},
+				}}
+			}),
+			// Topics and rotation are removed rather than adopted.
+			want: &v1alpha1.SecretParameters{
+				Labels:      map[string]string{"team": "payments"},
+				Replication: &v1alpha1.Replication{UserManaged: &v1alpha1.UserManagedReplication{Locations: []string{"us-east1"}}},
+			},
+		},
+		"NoOverride": {
+			spec:     params(func(p *v1alpha1.SecretParameters) { p.Labels = map[string]string{"team": "risk"} }),
+			observed: *secret(),
+			want:     params(func(p *v1alpha1.SecretParameters) { p.Labels = map[string]string{"team": "risk"} }),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitialize(tc.spec, tc.observed)
+			if diff := cmp.Diff(tc.want, tc.spec); diff != "" {
+				t.Errorf("LateInitialize(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		in       v1alpha1.SecretParameters
+		observed secretmanager.Secret
+		want     bool
+	}{
+		"UpToDate": {
+			in:       *params(),
+			observed: *secret(),
+			want:     true,
+		},
+		"TopicsReordered": {
+			in: *params(func(p *v1alpha1.SecretParameters) {
+				p.Topics = []string{"projects/other-project/topics/audit", "rotations"}
+			}),
+			observed: *secret(func(s *secretmanager.Secret) {
+				s.Topics = append(s.Topics, &secretmanager.Topic{Name: "projects/other-project/topics/audit"})
+			}),
+			want: true,
+		},
+		"TopicAdded": {
+			in:       *params(func(p *v1alpha1.SecretParameters) { p.Topics = append(p.Topics, "audit") }),
+			observed: *secret(),
+		},
+		"RotationAdded": {
+			in:       *params(),
+			observed: *secret(func(s *secretmanager.Secret) { s.Rotation = nil }),
+		},
+		"RotationRemoved": {
+			in:       *params(func(p *v1alpha1.SecretParameters) { p.Rotation = nil }),
+			observed: *secret(),
+		},
+		"PeriodChanged": {
+			in:       *params(func(p *v1alpha1.SecretParameters) { p.Rotation.RotationPeriod = gcp.StringPtr("86400s") }),
+			observed: *secret(),
+		},
+		"PeriodFormatted": {
+			in:       *params(func(p *v1alpha1.SecretParameters) { p.Rotation.RotationPeriod = gcp.StringPtr("2592000.000s") }),
+			observed: *secret(),
+			want:     true,
+		},
+		"NextRotationTimeChanged": {
+			in: *params(func(p *v1alpha1.SecretParameters) {
+				p.Rotation.NextRotationTime = gcp.StringPtr("2098-01-01T00:00:00Z")
+			}),
+			observed: *secret(),
+		},
+		"NextRotationTimePassed": {
+			in:       *params(func(p *v1alpha1.SecretParameters) { p.Rotation.NextRotationTime = gcp.StringPtr(pastTime) }),
+			observed: *secret(func(s *secretmanager.Secret) { s.Rotation.NextRotationTime = "2021-06-30T00:00:00Z" }),
+			want:     true,
+		},
+		"LabelsChanged": {
+			in:       *params(func(p *v1alpha1.SecretParameters) { p.Labels = nil }),
+			observed: *secret(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsUpToDate(projectID, tc.in, tc.observed, now)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateUpdate(t *testing.T) {
+	type want struct {
+		secret *secretmanager.Secret
+		mask   string
+	}
+
+	cases := map[string]struct {
+		in       v1alpha1.SecretParameters
+		observed secretmanager.Secret
+		want     want
+	}{
+		"UpToDate": {
+			in:       *params(),
+			observed: *secret(),
+			want:     want{secret: &secretmanager.Secret{}},
+		},
+		"RotationSet": {
+			in: *params(),
+			observed: *secret(func(s *secretmanager.Secret) {
+				s.Rotation = nil
+			}),
+			want: want{
+				secret: &secretmanager.Secret{Rotation: &secretmanager.Rotation{NextRotationTime: futureTime, RotationPeriod: testPeriod}},
+				mask:   "rotation",
+			},
+		},
+		"TopicAdded": {
+			in:       *params(func(p *v1alpha1.SecretParameters) { p.Topics = append(p.Topics, "audit") }),
+			observed: *secret(),
+			want: want{
+				secret: &secretmanager.Secret{Topics: []*secretmanager.Topic{
+					{Name: testTopic},
+					{Name: "projects/cool-project/topics/audit"},
+				}},
+				mask: "topics",
+			},
+		},
+		"PeriodChangedAfterRotation": {
+			in: *params(func(p *v1alpha1.SecretParameters) {
+				p.Rotation = &v1alpha1.Rotation{NextRotationTime: gcp.StringPtr(pastTime), RotationPeriod: gcp.StringPtr("86400s")}
+			}),
+			observed: *secret(func(s *secretmanager.Secret) { s.Rotation.NextRotationTime = "2021-06-30T00:00:00Z" }),
+			want: want{
+				// The next rotation time GCP advanced to is kept.
+				secret: &secretmanager.Secret{Rotation: &secretmanager.Rotation{NextRotationTime: "2021-06-30T00:00:00Z", RotationPeriod: "86400s"}},
+				mask:   "rotation",
+			},
+		},
+		"Removed": {
+			in:       v1alpha1.SecretParameters{},
+			observed: *secret(),
+			want: want{
+				secret: &secretmanager.Secret{},
+				mask:   "labels,topics,rotation",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, mask := GenerateUpdate(projectID, tc.in, tc.observed, now)
+			if diff := cmp.Diff(tc.want.secret, got); diff != "" {
+				t.Errorf("GenerateUpdate(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mask, mask); diff != "" {
+				t.Errorf("GenerateUpdate(...): -want mask, +got mask:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretpolicy
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	secretmanager "google.golang.org/api/secretmanager/v1"
+
+	iamv1alpha1 "github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
+	"github.com/crossplane/provider-gcp/apis/secretmanager/v1alpha1"
+)
+
+const (
+	testRole   = "roles/secretmanager.secretAccessor"
+	testMember = "serviceAccount:app@test-project.iam.gserviceaccount.com"
+	testOther  = "user:alice@example.com"
+	testExpr   = "request.time < timestamp(\"2021-12-31T00:00:00Z\")"
+)
+
+var testTitle = "expirable access"
+
+func params(condition *iamv1alpha1.Expr) v1alpha1.SecretPolicyMemberParameters {
+	m := testMember
+	return v1alpha1.SecretPolicyMemberParameters{
+		Secret:    "projects/test-project/secrets/test-secret",
+		Role:      testRole,
+		Member:    &m,
+		Condition: condition,
+	}
+}
+
+func TestBindRoleToMember(t *testing.T) {
+	condition := &iamv1alpha1.Expr{Title: &testTitle, Expression: testExpr}
+
+	type args struct {
+		in v1alpha1.SecretPolicyMemberParameters
+		p  *secretmanager.Policy
+	}
+	type want struct {
+		changed bool
+		p       *secretmanager.Policy
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NewBinding": {
+			args: args{
+				in: params(nil),
+				p:  &secretmanager.Policy{},
+			},
+			want: want{
+				changed: true,
+				p: &secretmanager.Policy{Version: iamv1alpha1.PolicyVersion, Bindings: []*secretmanager.Binding{
+					{Role: testRole, Members: []string{testMember}},
+				}},
+			},
+		},
+		"ExistingBinding": {
+			args: args{
+				in: params(nil),
+				p: &secretmanager.Policy{Bindings: []*secretmanager.Binding{
+					{Role: testRole, Members: []string{testOther}},
+				}},
+			},
+			want: want{
+				changed: true,
+				p: &secretmanager.Policy{Version: iamv1alpha1.PolicyVersion, Bindings: []*secretmanager.Binding{
+					{Role: testRole, Members: []string{testOther, testMember}},
+				}},
+			},
+		},
+		"AlreadyBound": {
+			args: args{
+				in: params(nil),
+				p: &secretmanager.Policy{Bindings: []*secretmanager.Binding{
+					{Role: testRole, Members: []string{testMember}},
+				}},
+			},
+			want: want{
+				p: &secretmanager.Policy{Version: iamv1alpha1.PolicyVersion, Bindings: []*secretmanager.Binding{
+					{Role: testRole, Members: []string{testMember}},
+				}},
+			},
+		},
+		"NewConditionalBinding": {
+			args: args{
+				in: params(condition),
+				p: &secretmanager.Policy{Bindings: []*secretmanager.Binding{
+					{Role: testRole, Members: []string{testMember}},
+				}},
+			},
+			want: want{
+				changed: true,
+				p: &secretmanager.Policy{Version: iamv1alpha1.PolicyVersion, Bindings: []*secretmanager.Binding{
+					{Role: testRole, Members: []string{testMember}},
+					{Role: testRole, Members: []string{testMember}, Condition: &secretmanager.Expr{Title: testTitle, Expression: testExpr}},
+				}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			changed := BindRoleToMember(tc.args.in, tc.args.p)
+			if diff := cmp.Diff(tc.want, want{changed: changed, p: tc.args.p}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("BindRoleToMember(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnbindRoleFromMember(t *testing.T) {
+	condition := &iamv1alpha1.Expr{Title: &testTitle, Expression: testExpr}
+
+	type args struct {
+		in v1alpha1.SecretPolicyMemberParameters
+		p  *secretmanager.Policy
+	}
+	type want struct {
+		changed bool
+		p       *secretmanager.Policy
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"RemoveMember": {
+			args: args{
+				in: params(nil),
+				p: &secretmanager.Policy{Bindings: []*secretmanager.Binding{
+					{Role: testRole, Members: []string{testOther, testMember}},
+				}},
+			},
+			want: want{
+				changed: true,
+				p: &secretmanager.Policy{Bindings: []*secretmanager.Binding{
+					{Role: testRole, Members: []string{testOther}},
+				}},
+			},
+		},
+		"RemoveBinding": {
+			args: args{
+				in: params(condition),
+				p: &secretmanager.Policy{Bindings: []*secretmanager.Binding{
+					{Role: testRole, Members: []string{testMember}},
+					{Role: testRole, Members: []string{testMember}, Condition: &secretmanager.Expr{Title: testTitle, Expression: testExpr}},
+				}},
+			},
+			want: want{
+				changed: true,
+				p: &secretmanager.Policy{Bindings: []*secretmanager.Binding{
+					{Role: testRole, Members: []string{testMember}},
+				}},
+			},
+		},
+		"NotBound": {
+			args: args{
+				in: params(nil),
+				p: &secretmanager.Policy{Bindings: []*secretmanager.Binding{
+					{Role: testRole, Members: []string{testOther}},
+				}},
+			},
+			want: want{
+				p: &secretmanager.Policy{Bindings: []*secretmanager.Binding{
+					{Role: testRole, Members: []string{testOther}},
+				}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			changed := UnbindRoleFromMember(tc.args.in, tc.args.p)
+			if diff := cmp.Diff(tc.want, want{changed: changed, p: tc.args.p}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("UnbindRoleFromMember(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
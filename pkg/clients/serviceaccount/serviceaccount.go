@@ -24,4 +24,6 @@ type Client interface {
 	Get(name string) *iam.ProjectsServiceAccountsGetCall
 	Patch(name string, patchserviceaccountrequest *iam.PatchServiceAccountRequest) *iam.ProjectsServiceAccountsPatchCall
 	Delete(name string) *iam.ProjectsServiceAccountsDeleteCall
+	Enable(name string, enableserviceaccountrequest *iam.EnableServiceAccountRequest) *iam.ProjectsServiceAccountsEnableCall
+	Disable(name string, disableserviceaccountrequest *iam.DisableServiceAccountRequest) *iam.ProjectsServiceAccountsDisableCall
 }
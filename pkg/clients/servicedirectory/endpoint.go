@@ -0,0 +1,81 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicedirectory
+
+import (
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	sd "google.golang.org/api/servicedirectory/v1beta1"
+
+	"github.com/crossplane/provider-gcp/apis/servicedirectory/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// EndpointClient should be satisfied to conduct Endpoint operations.
+type EndpointClient interface {
+	Create(parent string, endpoint *sd.Endpoint) *sd.ProjectsLocationsNamespacesServicesEndpointsCreateCall
+	Get(name string) *sd.ProjectsLocationsNamespacesServicesEndpointsGetCall
+	Patch(name string, endpoint *sd.Endpoint) *sd.ProjectsLocationsNamespacesServicesEndpointsPatchCall
+	Delete(name string) *sd.ProjectsLocationsNamespacesServicesEndpointsDeleteCall
+}
+
+// GenerateEndpoint generates *sd.Endpoint instance from EndpointParameters.
+func GenerateEndpoint(in v1alpha1.EndpointParameters, e *sd.Endpoint) {
+	e.Address = gcp.StringValue(in.Address)
+	e.Port = gcp.Int64Value(in.Port)
+	e.Network = gcp.StringValue(in.Network)
+	e.Metadata = in.Metadata
+}
+
+// GenerateEndpointObservation produces EndpointObservation object from
+// sd.Endpoint object.
+func GenerateEndpointObservation(in sd.Endpoint) v1alpha1.EndpointObservation {
+	return v1alpha1.EndpointObservation{
+		CreateTime: in.CreateTime,
+		Name:       in.Name,
+		UpdateTime: in.UpdateTime,
+	}
+}
+
+// LateInitializeEndpoint fills unassigned fields with the values in
+// sd.Endpoint object. The metadata is owned by the spec, so that it can be
+// removed, and is never late initialized.
+func LateInitializeEndpoint(spec *v1alpha1.EndpointParameters, in sd.Endpoint) {
+	spec.Address = gcp.LateInitializeString(spec.Address, in.Address)
+	spec.Port = gcp.LateInitializeInt64(spec.Port, in.Port)
+	spec.Network = gcp.LateInitializeString(spec.Network, in.Network)
+}
+
+// IsEndpointUpToDate checks whether current state is up-to-date compared to
+// the given set of parameters. It returns the update mask of the fields that
+// need to be patched. The network of an endpoint is immutable, so it is not
+// considered.
+func IsEndpointUpToDate(in v1alpha1.EndpointParameters, observed *sd.Endpoint) (bool, string) {
+	um := make([]string, 0, 3)
+	if gcp.StringValue(in.Address) != observed.Address {
+		um = append(um, "address")
+	}
+	if gcp.Int64Value(in.Port) != observed.Port {
+		um = append(um, "port")
+	}
+	if !cmp.Equal(in.Metadata, observed.Metadata, cmpopts.EquateEmpty()) {
+		um = append(um, "metadata")
+	}
+	return len(um) == 0, strings.Join(um, ",")
+}
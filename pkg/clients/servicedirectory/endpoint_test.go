@@ -0,0 +1,169 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicedirectory
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	sd "google.golang.org/api/servicedirectory/v1beta1"
+
+	"github.com/crossplane/provider-gcp/apis/servicedirectory/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testAddress = "10.0.0.1"
+	testNetwork = "projects/123456/locations/global/networks/default"
+)
+
+func TestGenerateEndpoint(t *testing.T) {
+	cases := map[string]struct {
+		in   v1alpha1.EndpointParameters
+		want *sd.Endpoint
+	}{
+		"Empty": {
+			in:   v1alpha1.EndpointParameters{},
+			want: &sd.Endpoint{},
+		},
+		"Full": {
+			in: v1alpha1.EndpointParameters{
+				Address:  gcp.StringPtr(testAddress),
+				Port:     gcp.Int64Ptr(8080),
+				Network:  gcp.StringPtr(testNetwork),
+				Metadata: map[string]string{"zone": "us-east1-b"},
+			},
+			want: &sd.Endpoint{
+				Address:  testAddress,
+				Port:     8080,
+				Network:  testNetwork,
+				Metadata: map[string]string{"zone": "us-east1-b"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &sd.Endpoint{}
+			GenerateEndpoint(tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateEndpoint(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeEndpoint(t *testing.T) {
+	type args struct {
+		spec *v1alpha1.EndpointParameters
+		in   sd.Endpoint
+	}
+	cases := map[string]struct {
+		args args
+		want *v1alpha1.EndpointParameters
+	}{
+		"Empty": {
+			args: args{
+				spec: &v1alpha1.EndpointParameters{},
+				in: sd.Endpoint{
+					Address: testAddress,
+					Port:    8080,
+					Network: testNetwork,
+				},
+			},
+			want: &v1alpha1.EndpointParameters{
+				Address: gcp.StringPtr(testAddress),
+				Port:    gcp.Int64Ptr(8080),
+				Network: gcp.StringPtr(testNetwork),
+			},
+		},
+		"AlreadySet": {
+			args: args{
+				spec: &v1alpha1.EndpointParameters{Port: gcp.Int64Ptr(443)},
+				in:   sd.Endpoint{Port: 8080},
+			},
+			want: &v1alpha1.EndpointParameters{Port: gcp.Int64Ptr(443)},
+		},
+		"MetadataRemoved": {
+			args: args{
+				spec: &v1alpha1.EndpointParameters{Port: gcp.Int64Ptr(8080)},
+				in:   sd.Endpoint{Port: 8080, Metadata: map[string]string{"zone": "us-east1-b"}},
+			},
+			want: &v1alpha1.EndpointParameters{Port: gcp.Int64Ptr(8080)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeEndpoint(tc.args.spec, tc.args.in)
+			if diff := cmp.Diff(tc.want, tc.args.spec); diff != "" {
+				t.Errorf("LateInitializeEndpoint(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsEndpointUpToDate(t *testing.T) {
+	type args struct {
+		in       v1alpha1.EndpointParameters
+		observed *sd.Endpoint
+	}
+	type want struct {
+		upToDate bool
+		mask     string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"UpToDate": {
+			args: args{
+				in: v1alpha1.EndpointParameters{
+					Address: gcp.StringPtr(testAddress),
+					Port:    gcp.Int64Ptr(8080),
+					Network: gcp.StringPtr(testNetwork),
+				},
+				observed: &sd.Endpoint{Address: testAddress, Port: 8080, Network: testNetwork},
+			},
+			want: want{upToDate: true},
+		},
+		"AddressAndMetadataDiffer": {
+			args: args{
+				in: v1alpha1.EndpointParameters{
+					Address:  gcp.StringPtr("10.0.0.2"),
+					Port:     gcp.Int64Ptr(8080),
+					Metadata: map[string]string{"zone": "us-east1-b"},
+				},
+				observed: &sd.Endpoint{Address: testAddress, Port: 8080},
+			},
+			want: want{upToDate: false, mask: "address,metadata"},
+		},
+		"PortDiffers": {
+			args: args{
+				in:       v1alpha1.EndpointParameters{Address: gcp.StringPtr(testAddress), Port: gcp.Int64Ptr(443)},
+				observed: &sd.Endpoint{Address: testAddress, Port: 8080},
+			},
+			want: want{upToDate: false, mask: "port"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			u, um := IsEndpointUpToDate(tc.args.in, tc.args.observed)
+			if diff := cmp.Diff(tc.want, want{upToDate: u, mask: um}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("IsEndpointUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
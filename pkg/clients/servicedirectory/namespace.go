@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicedirectory
+
+import (
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	sd "google.golang.org/api/servicedirectory/v1beta1"
+
+	"github.com/crossplane/provider-gcp/apis/servicedirectory/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// NamespaceClient should be satisfied to conduct Namespace operations.
+type NamespaceClient interface {
+	Create(parent string, namespace *sd.Namespace) *sd.ProjectsLocationsNamespacesCreateCall
+	Get(name string) *sd.ProjectsLocationsNamespacesGetCall
+	Patch(name string, namespace *sd.Namespace) *sd.ProjectsLocationsNamespacesPatchCall
+	Delete(name string) *sd.ProjectsLocationsNamespacesDeleteCall
+}
+
+// GenerateNamespace generates *sd.Namespace instance from NamespaceParameters.
+func GenerateNamespace(in v1alpha1.NamespaceParameters, ns *sd.Namespace) {
+	ns.Labels = in.Labels
+}
+
+// GenerateNamespaceObservation produces NamespaceObservation object from
+// sd.Namespace object.
+func GenerateNamespaceObservation(in sd.Namespace) v1alpha1.NamespaceObservation {
+	return v1alpha1.NamespaceObservation{
+		CreateTime: in.CreateTime,
+		Name:       in.Name,
+		UpdateTime: in.UpdateTime,
+	}
+}
+
+// LateInitializeNamespace fills unassigned fields with the values in
+// sd.Namespace object.
+func LateInitializeNamespace(spec *v1alpha1.NamespaceParameters, in sd.Namespace) {
+	spec.Labels = gcp.LateInitializeStringMap(spec.Labels, in.Labels)
+}
+
+// IsNamespaceUpToDate checks whether current state is up-to-date compared to
+// the given set of parameters. It returns the update mask of the fields that
+// need to be patched.
+func IsNamespaceUpToDate(in v1alpha1.NamespaceParameters, observed *sd.Namespace) (bool, string) {
+	um := make([]string, 0, 1)
+	if !cmp.Equal(in.Labels, observed.Labels, cmpopts.EquateEmpty()) {
+		um = append(um, "labels")
+	}
+	return len(um) == 0, strings.Join(um, ",")
+}
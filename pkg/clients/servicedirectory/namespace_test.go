@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicedirectory
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	sd "google.golang.org/api/servicedirectory/v1beta1"
+
+	"github.com/crossplane/provider-gcp/apis/servicedirectory/v1alpha1"
+)
+
+func TestLateInitializeNamespace(t *testing.T) {
+	type args struct {
+		spec *v1alpha1.NamespaceParameters
+		in   sd.Namespace
+	}
+	cases := map[string]struct {
+		args args
+		want *v1alpha1.NamespaceParameters
+	}{
+		"Empty": {
+			args: args{
+				spec: &v1alpha1.NamespaceParameters{},
+				in:   sd.Namespace{Labels: map[string]string{"team": "payments"}},
+			},
+			want: &v1alpha1.NamespaceParameters{Labels: map[string]string{"team": "payments"}},
+		},
+		"AlreadySet": {
+			args: args{
+				spec: &v1alpha1.NamespaceParameters{Labels: map[string]string{"team": "core"}},
+				in:   sd.Namespace{Labels: map[string]string{"team": "payments"}},
+			},
+			want: &v1alpha1.NamespaceParameters{Labels: map[string]string{"team": "core"}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeNamespace(tc.args.spec, tc.args.in)
+			if diff := cmp.Diff(tc.want, tc.args.spec); diff != "" {
+				t.Errorf("LateInitializeNamespace(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsNamespaceUpToDate(t *testing.T) {
+	type args struct {
+		in       v1alpha1.NamespaceParameters
+		observed *sd.Namespace
+	}
+	type want struct {
+		upToDate bool
+		mask     string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"UpToDate": {
+			args: args{
+				in:       v1alpha1.NamespaceParameters{Labels: map[string]string{"team": "payments"}},
+				observed: &sd.Namespace{Labels: map[string]string{"team": "payments"}},
+			},
+			want: want{upToDate: true},
+		},
+		"EmptyLabelsUpToDate": {
+			args: args{
+				in:       v1alpha1.NamespaceParameters{},
+				observed: &sd.Namespace{Labels: map[string]string{}},
+			},
+			want: want{upToDate: true},
+		},
+		"LabelsDiffer": {
+			args: args{
+				in:       v1alpha1.NamespaceParameters{Labels: map[string]string{"team": "core"}},
+				observed: &sd.Namespace{Labels: map[string]string{"team": "payments"}},
+			},
+			want: want{upToDate: false, mask: "labels"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			u, um := IsNamespaceUpToDate(tc.args.in, tc.args.observed)
+			if diff := cmp.Diff(tc.want, want{upToDate: u, mask: um}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("IsNamespaceUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
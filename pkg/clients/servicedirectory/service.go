@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicedirectory
+
+import (
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	sd "google.golang.org/api/servicedirectory/v1beta1"
+
+	"github.com/crossplane/provider-gcp/apis/servicedirectory/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// ServiceClient should be satisfied to conduct Service operations.
+type ServiceClient interface {
+	Create(parent string, service *sd.Service) *sd.ProjectsLocationsNamespacesServicesCreateCall
+	Get(name string) *sd.ProjectsLocationsNamespacesServicesGetCall
+	Patch(name string, service *sd.Service) *sd.ProjectsLocationsNamespacesServicesPatchCall
+	Delete(name string) *sd.ProjectsLocationsNamespacesServicesDeleteCall
+}
+
+// GenerateService generates *sd.Service instance from ServiceParameters.
+func GenerateService(in v1alpha1.ServiceParameters, s *sd.Service) {
+	s.Metadata = in.Metadata
+}
+
+// GenerateServiceObservation produces ServiceObservation object from
+// sd.Service object.
+func GenerateServiceObservation(in sd.Service) v1alpha1.ServiceObservation {
+	return v1alpha1.ServiceObservation{
+		CreateTime: in.CreateTime,
+		Name:       in.Name,
+		UpdateTime: in.UpdateTime,
+	}
+}
+
+// LateInitializeService fills unassigned fields with the values in sd.Service
+// object.
+func LateInitializeService(spec *v1alpha1.ServiceParameters, in sd.Service) {
+	spec.Metadata = gcp.LateInitializeStringMap(spec.Metadata, in.Metadata)
+}
+
+// IsServiceUpToDate checks whether current state is up-to-date compared to the
+// given set of parameters. It returns the update mask of the fields that need
+// to be patched.
+func IsServiceUpToDate(in v1alpha1.ServiceParameters, observed *sd.Service) (bool, string) {
+	um := make([]string, 0, 1)
+	if !cmp.Equal(in.Metadata, observed.Metadata, cmpopts.EquateEmpty()) {
+		um = append(um, "metadata")
+	}
+	return len(um) == 0, strings.Join(um, ",")
+}
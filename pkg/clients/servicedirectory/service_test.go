@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicedirectory
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	sd "google.golang.org/api/servicedirectory/v1beta1"
+
+	"github.com/crossplane/provider-gcp/apis/servicedirectory/v1alpha1"
+)
+
+func TestGenerateServiceObservation(t *testing.T) {
+	createTime := "2021-08-15T11:31:46.958565764Z"
+	name := "projects/test-project/locations/us-east1/namespaces/test-ns/services/test-svc"
+	cases := map[string]struct {
+		in   sd.Service
+		want v1alpha1.ServiceObservation
+	}{
+		"Empty": {
+			in:   sd.Service{},
+			want: v1alpha1.ServiceObservation{},
+		},
+		"Valid": {
+			in: sd.Service{
+				CreateTime: createTime,
+				Name:       name,
+				UpdateTime: createTime,
+				Metadata:   map[string]string{"owner": "payments"},
+			},
+			want: v1alpha1.ServiceObservation{
+				CreateTime: createTime,
+				Name:       name,
+				UpdateTime: createTime,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateServiceObservation(tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateServiceObservation(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsServiceUpToDate(t *testing.T) {
+	type args struct {
+		in       v1alpha1.ServiceParameters
+		observed *sd.Service
+	}
+	type want struct {
+		upToDate bool
+		mask     string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"UpToDate": {
+			args: args{
+				in:       v1alpha1.ServiceParameters{Metadata: map[string]string{"owner": "payments"}},
+				observed: &sd.Service{Metadata: map[string]string{"owner": "payments"}},
+			},
+			want: want{upToDate: true},
+		},
+		"MetadataAdded": {
+			args: args{
+				in:       v1alpha1.ServiceParameters{Metadata: map[string]string{"owner": "payments", "tier": "1"}},
+				observed: &sd.Service{Metadata: map[string]string{"owner": "payments"}},
+			},
+			want: want{upToDate: false, mask: "metadata"},
+		},
+		"MetadataRemoved": {
+			args: args{
+				in:       v1alpha1.ServiceParameters{},
+				observed: &sd.Service{Metadata: map[string]string{"owner": "payments"}},
+			},
+			want: want{upToDate: false, mask: "metadata"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			u, um := IsServiceUpToDate(tc.args.in, tc.args.observed)
+			if diff := cmp.Diff(tc.want, want{upToDate: u, mask: um}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("IsServiceUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
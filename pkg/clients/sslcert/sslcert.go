@@ -0,0 +1,62 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sslcert
+
+import (
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/crossplane/provider-gcp/apis/database/v1alpha1"
+)
+
+// Client should be satisfied to conduct SSLCert operations.
+type Client interface {
+	Get(project string, instance string, sha1Fingerprint string) *sqladmin.SslCertsGetCall
+	Insert(project string, instance string, sslcertsinsertrequest *sqladmin.SslCertsInsertRequest) *sqladmin.SslCertsInsertCall
+	Delete(project string, instance string, sha1Fingerprint string) *sqladmin.SslCertsDeleteCall
+}
+
+// GenerateSSLCertObservation produces SSLCertObservation object from
+// sqladmin.SslCert object.
+func GenerateSSLCertObservation(in sqladmin.SslCert) v1alpha1.SSLCertObservation {
+	return v1alpha1.SSLCertObservation{
+		CertSerialNumber: in.CertSerialNumber,
+		CreateTime:       in.CreateTime,
+		ExpirationTime:   in.ExpirationTime,
+		SelfLink:         in.SelfLink,
+		Sha1Fingerprint:  in.Sha1Fingerprint,
+	}
+}
+
+// GetConnectionDetails returns the connection details of the supplied
+// client certificate, i.e. the certificate and, if known, its private key
+// and the server CA certificate of its instance. The private key is only
+// returned by GCP when the certificate is created.
+func GetConnectionDetails(cert *sqladmin.SslCert, privateKey string, serverCA *sqladmin.SslCert) managed.ConnectionDetails {
+	cd := managed.ConnectionDetails{}
+	if cert != nil && cert.Cert != "" {
+		cd[v1alpha1.SSLCertSecretClientCertificateKey] = []byte(cert.Cert)
+	}
+	if privateKey != "" {
+		cd[v1alpha1.SSLCertSecretClientPrivateKeyKey] = []byte(privateKey)
+	}
+	if serverCA != nil && serverCA.Cert != "" {
+		cd[v1alpha1.SSLCertSecretServerCACertificateKey] = []byte(serverCA.Cert)
+	}
+	return cd
+}
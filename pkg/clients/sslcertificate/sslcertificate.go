@@ -0,0 +1,115 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sslcertificate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// Managed statuses of an SSL certificate.
+const (
+	StatusActive       = "ACTIVE"
+	StatusProvisioning = "PROVISIONING"
+)
+
+const typeManaged = "MANAGED"
+
+// GenerateSSLCertificate takes a *SSLCertificateParameters and returns
+// *compute.SslCertificate. It assigns only the fields that are writable, i.e.
+// not labelled as [Output Only] in Google's reference.
+func GenerateSSLCertificate(name string, in v1alpha1.SSLCertificateParameters, c *compute.SslCertificate) {
+	c.Name = name
+	c.Type = typeManaged
+	c.Managed = &compute.SslCertificateManagedSslCertificate{Domains: in.Managed.Domains}
+	c.Description = gcp.StringValue(in.Description)
+}
+
+// GenerateSSLCertificateObservation takes a compute.SslCertificate and returns
+// *SSLCertificateObservation.
+func GenerateSSLCertificateObservation(in compute.SslCertificate) v1alpha1.SSLCertificateObservation {
+	o := v1alpha1.SSLCertificateObservation{
+		CreationTimestamp:       in.CreationTimestamp,
+		ExpireTime:              in.ExpireTime,
+		ID:                      in.Id,
+		SelfLink:                in.SelfLink,
+		SubjectAlternativeNames: in.SubjectAlternativeNames,
+	}
+	if in.Managed != nil {
+		o.ManagedStatus = in.Managed.Status
+		o.DomainStatus = in.Managed.DomainStatus
+	}
+	return o
+}
+
+// LateInitializeSpec fills unassigned fields with the values in
+// compute.SslCertificate object.
+func LateInitializeSpec(spec *v1alpha1.SSLCertificateParameters, in compute.SslCertificate) {
+	spec.Description = gcp.LateInitializeString(spec.Description, in.Description)
+	if len(spec.Managed.Domains) == 0 && in.Managed != nil {
+		spec.Managed.Domains = in.Managed.Domains
+	}
+}
+
+// DomainDiff returns a description of the domains that were added to and
+// removed from the desired domains of the supplied certificate, or an empty
+// string if they are the domains of the certificate. The order of the domains
+// is not considered.
+func DomainDiff(in v1alpha1.SSLCertificateParameters, observed compute.SslCertificate) string {
+	var domains []string
+	if observed.Managed != nil {
+		domains = observed.Managed.Domains
+	}
+	add := missing(in.Managed.Domains, domains)
+	remove := missing(domains, in.Managed.Domains)
+	var diff []string
+	if len(add) > 0 {
+		diff = append(diff, fmt.Sprintf("added %s", strings.Join(add, ", ")))
+	}
+	if len(remove) > 0 {
+		diff = append(diff, fmt.Sprintf("removed %s", strings.Join(remove, ", ")))
+	}
+	return strings.Join(diff, "; ")
+}
+
+// missing returns the sorted domains of a that are not in b.
+func missing(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, d := range b {
+		in[d] = true
+	}
+	var m []string
+	for _, d := range a {
+		if !in[d] {
+			m = append(m, d)
+		}
+	}
+	sort.Strings(m)
+	return m
+}
+
+// IsUpToDate returns true if the observed certificate is provisioned for the
+// desired domains. Other fields can not be updated and are not considered.
+func IsUpToDate(in v1alpha1.SSLCertificateParameters, observed compute.SslCertificate) bool {
+	return DomainDiff(in, observed) == ""
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sslcertificate
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testName     = "some-name"
+	testSelfLink = "https://www.googleapis.com/compute/v1/projects/cool-project/global/sslCertificates/some-name"
+)
+
+func params(m ...func(*v1alpha1.SSLCertificateParameters)) *v1alpha1.SSLCertificateParameters {
+	o := &v1alpha1.SSLCertificateParameters{
+		Managed:     v1alpha1.ManagedSSLCertificate{Domains: []string{"example.com", "www.example.com"}},
+		Description: gcp.StringPtr("some desc"),
+	}
+	for _, f := range m {
+		f(o)
+	}
+	return o
+}
+
+func certificate(m ...func(*compute.SslCertificate)) *compute.SslCertificate {
+	o := &compute.SslCertificate{
+		Name:        testName,
+		Type:        "MANAGED",
+		Managed:     &compute.SslCertificateManagedSslCertificate{Domains: []string{"example.com", "www.example.com"}},
+		Description: "some desc",
+	}
+	for _, f := range m {
+		f(o)
+	}
+	return o
+}
+
+func domains(d ...string) func(*v1alpha1.SSLCertificateParameters) {
+	return func(p *v1alpha1.SSLCertificateParameters) { p.Managed.Domains = d }
+}
+
+func TestGenerateSSLCertificate(t *testing.T) {
+	cases := map[string]struct {
+		in   v1alpha1.SSLCertificateParameters
+		want *compute.SslCertificate
+	}{
+		"Full": {
+			in:   *params(),
+			want: certificate(),
+		},
+		"NoDescription": {
+			in:   *params(func(p *v1alpha1.SSLCertificateParameters) { p.Description = nil }),
+			want: certificate(func(c *compute.SslCertificate) { c.Description = "" }),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &compute.SslCertificate{}
+			GenerateSSLCertificate(testName, tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateSSLCertificate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateSSLCertificateObservation(t *testing.T) {
+	cases := map[string]struct {
+		in   compute.SslCertificate
+		want v1alpha1.SSLCertificateObservation
+	}{
+		"Provisioning": {
+			in: *certificate(func(c *compute.SslCertificate) {
+				c.Id = 42
+				c.SelfLink = testSelfLink
+				c.Managed.Status = StatusProvisioning
+				c.Managed.DomainStatus = map[string]string{"example.com": "ACTIVE", "www.example.com": "PROVISIONING"}
+			}),
+			want: v1alpha1.SSLCertificateObservation{
+				ID:            42,
+				SelfLink:      testSelfLink,
+				ManagedStatus: StatusProvisioning,
+				DomainStatus:  map[string]string{"example.com": "ACTIVE", "www.example.com": "PROVISIONING"},
+			},
+		},
+		"Active": {
+			in: *certificate(func(c *compute.SslCertificate) {
+				c.ExpireTime = "2022-01-01T00:00:00.000-08:00"
+				c.SubjectAlternativeNames = []string{"example.com", "www.example.com"}
+				c.Managed.Status = StatusActive
+			}),
+			want: v1alpha1.SSLCertificateObservation{
+				ExpireTime:              "2022-01-01T00:00:00.000-08:00",
+				SubjectAlternativeNames: []string{"example.com", "www.example.com"},
+				ManagedStatus:           StatusActive,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GenerateSSLCertificateObservation(tc.in)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateSSLCertificateObservation(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	cases := map[string]struct {
+		spec     *v1alpha1.SSLCertificateParameters
+		observed compute.SslCertificate
+		want     *v1alpha1.SSLCertificateParameters
+	}{
+		"AllFilled": {
+			spec:     params(),
+			observed: *certificate(func(c *compute.SslCertificate) { c.Description = "other desc" }),
+			want:     params(),
+		},
+		"DescriptionEmpty": {
+			spec:     params(func(p *v1alpha1.SSLCertificateParameters) { p.Description = nil }),
+			observed: *certificate(),
+			want:     params(),
+		},
+		"DomainsNotAdopted": {
+			spec:     params(domains("example.com")),
+			observed: *certificate(),
+			want:     params(domains("example.com")),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.spec, tc.observed)
+			if diff := cmp.Diff(tc.want, tc.spec); diff != "" {
+				t.Errorf("LateInitializeSpec(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDomainDiff(t *testing.T) {
+	cases := map[string]struct {
+		in       v1alpha1.SSLCertificateParameters
+		observed compute.SslCertificate
+		want     string
+		upToDate bool
+	}{
+		"Unchanged": {
+			in:       *params(),
+			observed: *certificate(),
+			upToDate: true,
+		},
+		"Reordered": {
+			in:       *params(domains("www.example.com", "example.com")),
+			observed: *certificate(),
+			upToDate: true,
+		},
+		"DomainAdded": {
+			in:       *params(domains("example.com", "www.example.com", "api.example.com")),
+			observed: *certificate(),
+			want:     "added api.example.com",
+		},
+		"DomainRemoved": {
+			in:       *params(domains("example.com")),
+			observed: *certificate(),
+			want:     "removed www.example.com",
+		},
+		"DomainReplaced": {
+			in:       *params(domains("example.org", "www.example.com")),
+			observed: *certificate(),
+			want:     "added example.org; removed example.com",
+		},
+		"NotManaged": {
+			in:       *params(),
+			observed: *certificate(func(c *compute.SslCertificate) { c.Managed = nil }),
+			want:     "added example.com, www.example.com",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := DomainDiff(tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("DomainDiff(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.upToDate, IsUpToDate(tc.in, tc.observed)); diff != "" {
+				t.Errorf("IsUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package subscription
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	pubsub "google.golang.org/api/pubsub/v1"
+
+	"github.com/crossplane/provider-gcp/apis/pubsub/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/topic"
+)
+
+const (
+	subscriptionNameFormat = "projects/%s/subscriptions/%s"
+)
+
+// GetFullyQualifiedName builds the fully qualified name of the subscription.
+func GetFullyQualifiedName(project string, name string) string {
+	return fmt.Sprintf(subscriptionNameFormat, project, name)
+}
+
+// GetTopicName returns the fully qualified name of the supplied topic, which
+// is assumed to be in the supplied project unless it is fully qualified.
+func GetTopicName(project string, name string) string {
+	if strings.HasPrefix(name, "projects/") {
+		return name
+	}
+	return topic.GetFullyQualifiedName(project, name)
+}
+
+// GenerateSubscription produces a Subscription that is configured via given
+// SubscriptionParameters.
+func GenerateSubscription(projectID, name string, s v1alpha1.SubscriptionParameters) *pubsub.Subscription {
+	return &pubsub.Subscription{
+		Name:                     GetFullyQualifiedName(projectID, name),
+		Topic:                    GetTopicName(projectID, gcp.StringValue(s.Topic)),
+		Filter:                   gcp.StringValue(s.Filter),
+		AckDeadlineSeconds:       gcp.Int64Value(s.AckDeadlineSeconds),
+		MessageRetentionDuration: gcp.StringValue(s.MessageRetentionDuration),
+		RetainAckedMessages:      gcp.BoolValue(s.RetainAckedMessages),
+		EnableMessageOrdering:    gcp.BoolValue(s.EnableMessageOrdering),
+		Labels:                   s.Labels,
+	}
+}
+
+// LateInitialize fills the empty fields of SubscriptionParameters if the
+// corresponding fields are given in Subscription.
+func LateInitialize(s *v1alpha1.SubscriptionParameters, sub pubsub.Subscription) {
+	s.Topic = gcp.LateInitializeString(s.Topic, sub.Topic)
+	s.Filter = gcp.LateInitializeString(s.Filter, sub.Filter)
+	s.AckDeadlineSeconds = gcp.LateInitializeInt64(s.AckDeadlineSeconds, sub.AckDeadlineSeconds)
+	s.MessageRetentionDuration = gcp.LateInitializeString(s.MessageRetentionDuration, sub.MessageRetentionDuration)
+	if s.RetainAckedMessages == nil {
+		s.RetainAckedMessages = gcp.BoolPtr(sub.RetainAckedMessages)
+	}
+	if s.EnableMessageOrdering == nil {
+		s.EnableMessageOrdering = gcp.BoolPtr(sub.EnableMessageOrdering)
+	}
+	s.Labels = gcp.LateInitializeStringMap(s.Labels, sub.Labels)
+}
+
+// ImmutableDiff returns a description of each field of the supplied
+// SubscriptionParameters that can not be updated and differs from the
+// supplied observed Subscription, e.g. `filter: "a" != "b"`. Any such
+// difference can only be resolved by deleting and recreating the
+// subscription.
+func ImmutableDiff(projectID string, s v1alpha1.SubscriptionParameters, sub pubsub.Subscription) []string {
+	var diff []string
+	if s.Topic != nil && GetTopicName(projectID, *s.Topic) != sub.Topic {
+		diff = append(diff, fmt.Sprintf("topic: %s != %s", GetTopicName(projectID, *s.Topic), sub.Topic))
+	}
+	if s.Filter != nil && *s.Filter != sub.Filter {
+		diff = append(diff, fmt.Sprintf("filter: %q != %q", *s.Filter, sub.Filter))
+	}
+	if s.EnableMessageOrdering != nil && *s.EnableMessageOrdering != sub.EnableMessageOrdering {
+		diff = append(diff, fmt.Sprintf("enableMessageOrdering: %t != %t", *s.EnableMessageOrdering, sub.EnableMessageOrdering))
+	}
+	return diff
+}
+
+// IsUpToDate checks whether Subscription is configured with given
+// SubscriptionParameters, including the fields that can not be updated.
+func IsUpToDate(projectID string, s v1alpha1.SubscriptionParameters, sub pubsub.Subscription) bool {
+	if len(ImmutableDiff(projectID, s, sub)) > 0 {
+		return false
+	}
+	return GenerateUpdateRequest(projectID, "", s, sub).UpdateMask == ""
+}
+
+// GenerateUpdateRequest produces an UpdateSubscriptionRequest with the
+// difference between the SubscriptionParameters and the Subscription in the
+// fields that can be updated.
+func GenerateUpdateRequest(projectID, name string, s v1alpha1.SubscriptionParameters, sub pubsub.Subscription) *pubsub.UpdateSubscriptionRequest {
+	us := &pubsub.UpdateSubscriptionRequest{
+		Subscription: &pubsub.Subscription{Name: GetFullyQualifiedName(projectID, name)},
+	}
+	mask := []string{}
+	if s.AckDeadlineSeconds != nil && *s.AckDeadlineSeconds != sub.AckDeadlineSeconds {
+		mask = append(mask, "ackDeadlineSeconds")
+		us.Subscription.AckDeadlineSeconds = *s.AckDeadlineSeconds
+	}
+	if s.MessageRetentionDuration != nil && *s.MessageRetentionDuration != sub.MessageRetentionDuration {
+		mask = append(mask, "messageRetentionDuration")
+		us.Subscription.MessageRetentionDuration = *s.MessageRetentionDuration
+	}
+	if s.RetainAckedMessages != nil && *s.RetainAckedMessages != sub.RetainAckedMessages {
+		mask = append(mask, "retainAckedMessages")
+		us.Subscription.RetainAckedMessages = *s.RetainAckedMessages
+	}
+	if !cmp.Equal(s.Labels, sub.Labels, cmpopts.EquateEmpty()) {
+		mask = append(mask, "labels")
+		us.Subscription.Labels = s.Labels
+	}
+	us.UpdateMask = strings.Join(mask, ",")
+	return us
+}
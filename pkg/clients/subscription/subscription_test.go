@@ -0,0 +1,287 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package subscription
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	pubsub "google.golang.org/api/pubsub/v1"
+
+	"github.com/crossplane/provider-gcp/apis/pubsub/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	projectID = "fooproject"
+	name      = "barname"
+	topicName = "projects/fooproject/topics/bartopic"
+)
+
+func params() *v1alpha1.SubscriptionParameters {
+	return &v1alpha1.SubscriptionParameters{
+		Topic:                    gcp.StringPtr("bartopic"),
+		Filter:                   gcp.StringPtr(`attributes.region = "eu"`),
+		AckDeadlineSeconds:       gcp.Int64Ptr(30),
+		MessageRetentionDuration: gcp.StringPtr("600s"),
+		RetainAckedMessages:      gcp.BoolPtr(true),
+		EnableMessageOrdering:    gcp.BoolPtr(false),
+		Labels: map[string]string{
+			"foo": "bar",
+		},
+	}
+}
+
+func subscription() *pubsub.Subscription {
+	return &pubsub.Subscription{
+		Name:                     GetFullyQualifiedName(projectID, name),
+		Topic:                    topicName,
+		Filter:                   `attributes.region = "eu"`,
+		AckDeadlineSeconds:       30,
+		MessageRetentionDuration: "600s",
+		RetainAckedMessages:      true,
+		Labels: map[string]string{
+			"foo": "bar",
+		},
+	}
+}
+
+func TestGetTopicName(t *testing.T) {
+	cases := map[string]struct {
+		name string
+		out  string
+	}{
+		"Unqualified": {
+			name: "bartopic",
+			out:  topicName,
+		},
+		"FullyQualified": {
+			name: "projects/otherproject/topics/bartopic",
+			out:  "projects/otherproject/topics/bartopic",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.out, GetTopicName(projectID, tc.name)); diff != "" {
+				t.Errorf("GetTopicName(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateSubscription(t *testing.T) {
+	cases := map[string]struct {
+		s   v1alpha1.SubscriptionParameters
+		out *pubsub.Subscription
+	}{
+		"Full": {
+			s:   *params(),
+			out: subscription(),
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			got := GenerateSubscription(projectID, name, tc.s)
+			if diff := cmp.Diff(tc.out, got); diff != "" {
+				t.Errorf("GenerateSubscription(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLateInitialize(t *testing.T) {
+	cases := map[string]struct {
+		s   *v1alpha1.SubscriptionParameters
+		sub pubsub.Subscription
+		out *v1alpha1.SubscriptionParameters
+	}{
+		"Empty": {
+			s:   &v1alpha1.SubscriptionParameters{},
+			sub: *subscription(),
+			out: &v1alpha1.SubscriptionParameters{
+				Topic:                    gcp.StringPtr(topicName),
+				Filter:                   gcp.StringPtr(`attributes.region = "eu"`),
+				AckDeadlineSeconds:       gcp.Int64Ptr(30),
+				MessageRetentionDuration: gcp.StringPtr("600s"),
+				RetainAckedMessages:      gcp.BoolPtr(true),
+				EnableMessageOrdering:    gcp.BoolPtr(false),
+				Labels: map[string]string{
+					"foo": "bar",
+				},
+			},
+		},
+		"NoOverride": {
+			s:   params(),
+			sub: pubsub.Subscription{Topic: "projects/fooproject/topics/other", AckDeadlineSeconds: 10},
+			out: params(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitialize(tc.s, tc.sub)
+			if diff := cmp.Diff(tc.out, tc.s); diff != "" {
+				t.Errorf("LateInitialize(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestImmutableDiff(t *testing.T) {
+	cases := map[string]struct {
+		s   v1alpha1.SubscriptionParameters
+		sub pubsub.Subscription
+		out []string
+	}{
+		"Same": {
+			s:   *params(),
+			sub: *subscription(),
+		},
+		"MutableChanged": {
+			s: func() v1alpha1.SubscriptionParameters {
+				p := params()
+				p.AckDeadlineSeconds = gcp.Int64Ptr(60)
+				return *p
+			}(),
+			sub: *subscription(),
+		},
+		"FilterChanged": {
+			s: func() v1alpha1.SubscriptionParameters {
+				p := params()
+				p.Filter = gcp.StringPtr(`attributes.region = "us"`)
+				return *p
+			}(),
+			sub: *subscription(),
+			out: []string{`filter: "attributes.region = \"us\"" != "attributes.region = \"eu\""`},
+		},
+		"FilterRemoved": {
+			s: func() v1alpha1.SubscriptionParameters {
+				p := params()
+				p.Filter = gcp.StringPtr("")
+				return *p
+			}(),
+			sub: *subscription(),
+			out: []string{`filter: "" != "attributes.region = \"eu\""`},
+		},
+		"TopicAndOrderingChanged": {
+			s: func() v1alpha1.SubscriptionParameters {
+				p := params()
+				p.Topic = gcp.StringPtr("othertopic")
+				p.EnableMessageOrdering = gcp.BoolPtr(true)
+				return *p
+			}(),
+			sub: *subscription(),
+			out: []string{
+				"topic: projects/fooproject/topics/othertopic != " + topicName,
+				"enableMessageOrdering: true != false",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ImmutableDiff(projectID, tc.s, tc.sub)
+			if diff := cmp.Diff(tc.out, got); diff != "" {
+				t.Errorf("ImmutableDiff(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		s   v1alpha1.SubscriptionParameters
+		sub pubsub.Subscription
+		out bool
+	}{
+		"UpToDate": {
+			s:   *params(),
+			sub: *subscription(),
+			out: true,
+		},
+		"AckDeadlineChanged": {
+			s: func() v1alpha1.SubscriptionParameters {
+				p := params()
+				p.AckDeadlineSeconds = gcp.Int64Ptr(60)
+				return *p
+			}(),
+			sub: *subscription(),
+		},
+		"FilterDrifted": {
+			s: *params(),
+			sub: func() pubsub.Subscription {
+				s := subscription()
+				s.Filter = `attributes.region = "us"`
+				return *s
+			}(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsUpToDate(projectID, tc.s, tc.sub)
+			if diff := cmp.Diff(tc.out, got); diff != "" {
+				t.Errorf("IsUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateUpdateRequest(t *testing.T) {
+	cases := map[string]struct {
+		s   v1alpha1.SubscriptionParameters
+		sub pubsub.Subscription
+		out *pubsub.UpdateSubscriptionRequest
+	}{
+		"NoChange": {
+			s:   *params(),
+			sub: *subscription(),
+			out: &pubsub.UpdateSubscriptionRequest{
+				Subscription: &pubsub.Subscription{Name: GetFullyQualifiedName(projectID, name)},
+			},
+		},
+		"MutableChanged": {
+			s: *params(),
+			sub: pubsub.Subscription{
+				Topic:  topicName,
+				Filter: `attributes.region = "us"`,
+			},
+			out: &pubsub.UpdateSubscriptionRequest{
+				Subscription: &pubsub.Subscription{
+					Name:                     GetFullyQualifiedName(projectID, name),
+					AckDeadlineSeconds:       30,
+					MessageRetentionDuration: "600s",
+					RetainAckedMessages:      true,
+					Labels: map[string]string{
+						"foo": "bar",
+					},
+				},
+				UpdateMask: "ackDeadlineSeconds,messageRetentionDuration,retainAckedMessages,labels",
+			},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			got := GenerateUpdateRequest(projectID, name, tc.s, tc.sub)
+			if diff := cmp.Diff(tc.out, got); diff != "" {
+				t.Errorf("GenerateUpdateRequest(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tablepolicy
+
+import (
+	"regexp"
+
+	bigquery "google.golang.org/api/bigquery/v2"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	"github.com/crossplane/provider-gcp/apis/bigquery/v1alpha1"
+	iamv1alpha1 "github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const errFmtInvalidMember = "invalid member %q: must be allUsers, allAuthenticatedUsers, a principal:// or principalSet:// identifier, or an identity prefixed with its type, e.g. user:, serviceAccount:, group: or domain:"
+
+// memberFormat matches the identities accepted as members of a Table IAM
+// policy binding.
+var memberFormat = regexp.MustCompile(`^(allUsers|allAuthenticatedUsers|(user|serviceAccount|group|domain|projectOwner|projectEditor|projectViewer|deleted:user|deleted:serviceAccount|deleted:group):.+|principal(Set)?://.+)$`)
+
+// Client should be satisfied to conduct Table Policy operations.
+type Client interface {
+	GetIamPolicy(resource string, getiampolicyrequest *bigquery.GetIamPolicyRequest) *bigquery.TablesGetIamPolicyCall
+	SetIamPolicy(resource string, setiampolicyrequest *bigquery.SetIamPolicyRequest) *bigquery.TablesSetIamPolicyCall
+}
+
+// GetIamPolicyRequest returns a request for the Table IAM policy that
+// includes the conditions of its bindings.
+func GetIamPolicyRequest() *bigquery.GetIamPolicyRequest {
+	return &bigquery.GetIamPolicyRequest{
+		Options: &bigquery.GetPolicyOptions{RequestedPolicyVersion: iamv1alpha1.PolicyVersion},
+	}
+}
+
+// ValidateMember returns an error if the supplied member is not a well formed
+// identity, e.g. a bare email address without its identity type.
+func ValidateMember(member string) error {
+	if !memberFormat.MatchString(member) {
+		return errors.Errorf(errFmtInvalidMember, member)
+	}
+	return nil
+}
+
+// BindRoleToMember updates *bigquery.Policy instance with
+// TablePolicyMemberParameters.
+// returns true if policy changed
+func BindRoleToMember(in v1alpha1.TablePolicyMemberParameters, p *bigquery.Policy) bool {
+	p.Version = iamv1alpha1.PolicyVersion
+	for _, b := range p.Bindings {
+		if isBinding(b, in.Role, in.Condition) {
+			for _, m := range b.Members {
+				if m == gcp.StringValue(in.Member) {
+					// role already bound to member, no change
+					return false
+				}
+			}
+			// binding already exist, add member
+			b.Members = append(b.Members, gcp.StringValue(in.Member))
+			return true
+		}
+	}
+	// binding does not exist, add binding with role, condition and member
+	b := &bigquery.Binding{
+		Role:    in.Role,
+		Members: []string{gcp.StringValue(in.Member)},
+	}
+	if in.Condition != nil {
+		b.Condition = &bigquery.Expr{
+			Description: gcp.StringValue(in.Condition.Description),
+			Expression:  in.Condition.Expression,
+			Location:    gcp.StringValue(in.Condition.Location),
+			Title:       gcp.StringValue(in.Condition.Title),
+		}
+	}
+	p.Bindings = append(p.Bindings, b)
+	return true
+}
+
+// UnbindRoleFromMember removes the member from the binding of the given role
+// and condition in *bigquery.Policy instance. The binding itself is
+// removed once it has no members left.
+// returns true if bound (i.e. policy changed)
+func UnbindRoleFromMember(in v1alpha1.TablePolicyMemberParameters, p *bigquery.Policy) bool {
+	for i, b := range p.Bindings {
+		if !isBinding(b, in.Role, in.Condition) {
+			continue
+		}
+		for j, m := range b.Members {
+			if m != gcp.StringValue(in.Member) {
+				continue
+			}
+			if len(b.Members) == 1 {
+				// remove binding located at index i
+				p.Bindings = append(p.Bindings[:i], p.Bindings[i+1:]...)
+				return true
+			}
+			// remove member located at index j
+			b.Members = append(b.Members[:j], b.Members[j+1:]...)
+			return true
+		}
+		return false
+	}
+	return false
+}
+
+// isBinding returns true if the supplied binding is the one identified by the
+// supplied role and condition. A nil condition only matches the unconditional
+// binding of the role.
+func isBinding(b *bigquery.Binding, role string, condition *iamv1alpha1.Expr) bool {
+	if b.Role != role {
+		return false
+	}
+	if condition == nil || b.Condition == nil {
+		return condition == nil && b.Condition == nil
+	}
+	return condition.Expression == b.Condition.Expression &&
+		gcp.StringValue(condition.Title) == b.Condition.Title
+}
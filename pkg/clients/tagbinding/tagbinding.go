@@ -0,0 +1,81 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tagbinding
+
+import (
+	"sort"
+	"strings"
+
+	crmv3 "google.golang.org/api/cloudresourcemanager/v3"
+)
+
+// Client should be satisfied to conduct TagBinding operations.
+type Client interface {
+	List() *crmv3.TagBindingsListCall
+	Create(tagbinding *crmv3.TagBinding) *crmv3.TagBindingsCreateCall
+	Delete(name string) *crmv3.TagBindingsDeleteCall
+}
+
+// BucketResourceName returns the full resource name of the named bucket, which
+// is the parent of the tag bindings of the bucket.
+func BucketResourceName(bucket string) string {
+	return "//storage.googleapis.com/projects/_/buckets/" + bucket
+}
+
+// LocationEndpoint returns the endpoint of the Resource Manager API that
+// manages the tag bindings of resources in the supplied location, e.g. of
+// buckets. Such resources are not bound to tags via the global endpoint.
+func LocationEndpoint(location string) string {
+	return "https://" + strings.ToLower(location) + "-cloudresourcemanager.googleapis.com/"
+}
+
+// TagValues returns the sorted tag values bound by the supplied tag bindings.
+func TagValues(bindings []*crmv3.TagBinding) []string {
+	if len(bindings) == 0 {
+		return nil
+	}
+	v := make([]string, len(bindings))
+	for i, b := range bindings {
+		v[i] = b.TagValue
+	}
+	sort.Strings(v)
+	return v
+}
+
+// Diff returns the desired tag values that are not bound by any of the
+// observed tag bindings, and the observed tag bindings whose tag values are
+// not desired.
+func Diff(desired []string, observed []*crmv3.TagBinding) (bind []string, unbind []*crmv3.TagBinding) {
+	want := make(map[string]bool, len(desired))
+	for _, v := range desired {
+		want[v] = true
+	}
+	bound := make(map[string]bool, len(observed))
+	for _, b := range observed {
+		bound[b.TagValue] = true
+		if !want[b.TagValue] {
+			unbind = append(unbind, b)
+		}
+	}
+	for _, v := range desired {
+		if !bound[v] {
+			bind = append(bind, v)
+			bound[v] = true
+		}
+	}
+	return bind, unbind
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tagbinding
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	crmv3 "google.golang.org/api/cloudresourcemanager/v3"
+)
+
+func TestDiff(t *testing.T) {
+	env := &crmv3.TagBinding{Name: "tagBindings/env", TagValue: "tagValues/111"}
+	team := &crmv3.TagBinding{Name: "tagBindings/team", TagValue: "tagValues/222"}
+
+	type want struct {
+		bind   []string
+		unbind []*crmv3.TagBinding
+	}
+	cases := map[string]struct {
+		desired  []string
+		observed []*crmv3.TagBinding
+		want     want
+	}{
+		"UpToDate": {
+			desired:  []string{"tagValues/111", "tagValues/222"},
+			observed: []*crmv3.TagBinding{team, env},
+		},
+		"Attach": {
+			desired:  []string{"tagValues/111", "tagValues/333", "tagValues/333"},
+			observed: []*crmv3.TagBinding{env},
+			want:     want{bind: []string{"tagValues/333"}},
+		},
+		"Detach": {
+			desired:  []string{"tagValues/222"},
+			observed: []*crmv3.TagBinding{env, team},
+			want:     want{unbind: []*crmv3.TagBinding{env}},
+		},
+		"DetachAll": {
+			desired:  []string{},
+			observed: []*crmv3.TagBinding{env},
+			want:     want{unbind: []*crmv3.TagBinding{env}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			bind, unbind := Diff(tc.desired, tc.observed)
+			if diff := cmp.Diff(tc.want.bind, bind); diff != "" {
+				t.Errorf("Diff(...): -want bind, +got bind:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.unbind, unbind); diff != "" {
+				t.Errorf("Diff(...): -want unbind, +got unbind:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLocationEndpoint(t *testing.T) {
+	if diff := cmp.Diff("https://us-central1-cloudresourcemanager.googleapis.com/", LocationEndpoint("US-CENTRAL1")); diff != "" {
+		t.Errorf("LocationEndpoint(...): -want, +got:\n%s", diff)
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targetinstance
+
+import (
+	compute "google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// GenerateTargetInstance takes a *TargetInstanceParameters and returns
+// *compute.TargetInstance. It assigns only the fields that are writable, i.e.
+// not labelled as [Output Only] in Google's reference.
+func GenerateTargetInstance(name string, in v1alpha1.TargetInstanceParameters, ti *compute.TargetInstance) {
+	ti.Name = name
+	ti.Instance = in.Instance
+	ti.NatPolicy = gcp.StringValue(in.NatPolicy)
+	ti.Description = gcp.StringValue(in.Description)
+}
+
+// GenerateTargetInstanceObservation takes a compute.TargetInstance and returns
+// *TargetInstanceObservation.
+func GenerateTargetInstanceObservation(in compute.TargetInstance) v1alpha1.TargetInstanceObservation {
+	return v1alpha1.TargetInstanceObservation{
+		CreationTimestamp: in.CreationTimestamp,
+		ID:                in.Id,
+		SelfLink:          in.SelfLink,
+	}
+}
+
+// LateInitializeSpec fills unassigned fields with the values in
+// compute.TargetInstance object.
+func LateInitializeSpec(spec *v1alpha1.TargetInstanceParameters, in compute.TargetInstance) {
+	spec.NatPolicy = gcp.LateInitializeString(spec.NatPolicy, in.NatPolicy)
+	spec.Description = gcp.LateInitializeString(spec.Description, in.Description)
+}
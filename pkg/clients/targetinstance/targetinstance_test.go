@@ -0,0 +1,161 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package targetinstance
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+)
+
+const (
+	testName              = "some-name"
+	testZone              = "us-central1-a"
+	testInstance          = "zones/us-central1-a/instances/some-instance"
+	testCreationTimestamp = "10/10/2023"
+	testSelfLink          = "https://www.googleapis.com/compute/v1/projects/cool-project/zones/us-central1-a/targetInstances/some-name"
+)
+
+var (
+	testDescription = "some desc"
+	testNatPolicy   = "NO_NAT"
+)
+
+func params(m ...func(*v1alpha1.TargetInstanceParameters)) *v1alpha1.TargetInstanceParameters {
+	o := &v1alpha1.TargetInstanceParameters{
+		Zone:        testZone,
+		Instance:    testInstance,
+		NatPolicy:   &testNatPolicy,
+		Description: &testDescription,
+	}
+	for _, f := range m {
+		f(o)
+	}
+	return o
+}
+
+func targetInstance(m ...func(*compute.TargetInstance)) *compute.TargetInstance {
+	o := &compute.TargetInstance{
+		Name:        testName,
+		Instance:    testInstance,
+		NatPolicy:   testNatPolicy,
+		Description: testDescription,
+	}
+	for _, f := range m {
+		f(o)
+	}
+	return o
+}
+
+func TestGenerateTargetInstance(t *testing.T) {
+	type args struct {
+		name string
+		in   v1alpha1.TargetInstanceParameters
+	}
+	cases := map[string]struct {
+		args args
+		want *compute.TargetInstance
+	}{
+		"FullConversion": {
+			args: args{
+				name: testName,
+				in:   *params(),
+			},
+			want: targetInstance(),
+		},
+		"MissingFields": {
+			args: args{
+				name: testName,
+				in: *params(func(p *v1alpha1.TargetInstanceParameters) {
+					p.NatPolicy = nil
+					p.Description = nil
+				}),
+			},
+			want: targetInstance(func(ti *compute.TargetInstance) {
+				ti.NatPolicy = ""
+				ti.Description = ""
+			}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &compute.TargetInstance{}
+			GenerateTargetInstance(tc.args.name, tc.args.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateTargetInstance(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateTargetInstanceObservation(t *testing.T) {
+	in := targetInstance(func(ti *compute.TargetInstance) {
+		ti.CreationTimestamp = testCreationTimestamp
+		ti.Id = 2029819203
+		ti.SelfLink = testSelfLink
+	})
+	want := v1alpha1.TargetInstanceObservation{
+		CreationTimestamp: testCreationTimestamp,
+		ID:                2029819203,
+		SelfLink:          testSelfLink,
+	}
+	if diff := cmp.Diff(want, GenerateTargetInstanceObservation(*in)); diff != "" {
+		t.Errorf("GenerateTargetInstanceObservation(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestLateInitializeSpec(t *testing.T) {
+	type args struct {
+		spec *v1alpha1.TargetInstanceParameters
+		in   compute.TargetInstance
+	}
+	cases := map[string]struct {
+		args args
+		want *v1alpha1.TargetInstanceParameters
+	}{
+		"AllFilledAlready": {
+			args: args{
+				spec: params(),
+				in:   *targetInstance(),
+			},
+			want: params(),
+		},
+		"DefaultsFilled": {
+			args: args{
+				spec: params(func(p *v1alpha1.TargetInstanceParameters) {
+					p.NatPolicy = nil
+					p.Description = nil
+				}),
+				in: *targetInstance(),
+			},
+			want: params(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeSpec(tc.args.spec, tc.args.in)
+			if diff := cmp.Diff(tc.want, tc.args.spec); diff != "" {
+				t.Errorf("LateInitializeSpec(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadidentitypool
+
+import (
+	"strings"
+
+	iamv1 "google.golang.org/api/iam/v1"
+
+	"github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// Client should be satisfied to conduct WorkloadIdentityPool operations.
+type Client interface {
+	Create(parent string, workloadidentitypool *iamv1.WorkloadIdentityPool) *iamv1.ProjectsLocationsWorkloadIdentityPoolsCreateCall
+	Get(name string) *iamv1.ProjectsLocationsWorkloadIdentityPoolsGetCall
+	Patch(name string, workloadidentitypool *iamv1.WorkloadIdentityPool) *iamv1.ProjectsLocationsWorkloadIdentityPoolsPatchCall
+	Delete(name string) *iamv1.ProjectsLocationsWorkloadIdentityPoolsDeleteCall
+	Undelete(name string, undeleteworkloadidentitypoolrequest *iamv1.UndeleteWorkloadIdentityPoolRequest) *iamv1.ProjectsLocationsWorkloadIdentityPoolsUndeleteCall
+}
+
+// GenerateWorkloadIdentityPool generates *iamv1.WorkloadIdentityPool instance
+// from WorkloadIdentityPoolParameters.
+func GenerateWorkloadIdentityPool(in v1alpha1.WorkloadIdentityPoolParameters, p *iamv1.WorkloadIdentityPool) {
+	p.DisplayName = gcp.StringValue(in.DisplayName)
+	p.Description = gcp.StringValue(in.Description)
+	p.Disabled = gcp.BoolValue(in.Disabled)
+}
+
+// GenerateWorkloadIdentityPoolObservation produces
+// WorkloadIdentityPoolObservation object from iamv1.WorkloadIdentityPool
+// object.
+func GenerateWorkloadIdentityPoolObservation(in iamv1.WorkloadIdentityPool) v1alpha1.WorkloadIdentityPoolObservation {
+	return v1alpha1.WorkloadIdentityPoolObservation{
+		Name:  in.Name,
+		State: in.State,
+	}
+}
+
+// LateInitializeWorkloadIdentityPool fills unassigned fields with the values
+// in iamv1.WorkloadIdentityPool object.
+func LateInitializeWorkloadIdentityPool(spec *v1alpha1.WorkloadIdentityPoolParameters, in iamv1.WorkloadIdentityPool) {
+	spec.DisplayName = gcp.LateInitializeString(spec.DisplayName, in.DisplayName)
+	spec.Description = gcp.LateInitializeString(spec.Description, in.Description)
+	spec.Disabled = gcp.LateInitializeBool(spec.Disabled, in.Disabled)
+}
+
+// IsWorkloadIdentityPoolUpToDate checks whether current state is up-to-date
+// compared to the given set of parameters. It returns the update mask of the
+// fields that need to be patched.
+func IsWorkloadIdentityPoolUpToDate(in v1alpha1.WorkloadIdentityPoolParameters, observed *iamv1.WorkloadIdentityPool) (bool, string) {
+	um := make([]string, 0, 3)
+	if gcp.StringValue(in.DisplayName) != observed.DisplayName {
+		um = append(um, "displayName")
+	}
+	if gcp.StringValue(in.Description) != observed.Description {
+		um = append(um, "description")
+	}
+	if gcp.BoolValue(in.Disabled) != observed.Disabled {
+		um = append(um, "disabled")
+	}
+	return len(um) == 0, strings.Join(um, ",")
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadidentitypool
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	iamv1 "google.golang.org/api/iam/v1"
+
+	"github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+func TestIsWorkloadIdentityPoolUpToDate(t *testing.T) {
+	type want struct {
+		upToDate bool
+		mask     string
+	}
+	cases := map[string]struct {
+		in       v1alpha1.WorkloadIdentityPoolParameters
+		observed *iamv1.WorkloadIdentityPool
+		want     want
+	}{
+		"UpToDate": {
+			in:       v1alpha1.WorkloadIdentityPoolParameters{DisplayName: gcp.StringPtr("pool"), Disabled: gcp.BoolPtr(false)},
+			observed: &iamv1.WorkloadIdentityPool{DisplayName: "pool"},
+			want:     want{upToDate: true},
+		},
+		"Disabled": {
+			in:       v1alpha1.WorkloadIdentityPoolParameters{DisplayName: gcp.StringPtr("pool"), Disabled: gcp.BoolPtr(true)},
+			observed: &iamv1.WorkloadIdentityPool{DisplayName: "pool"},
+			want:     want{mask: "disabled"},
+		},
+		"Enabled": {
+			in:       v1alpha1.WorkloadIdentityPoolParameters{Disabled: gcp.BoolPtr(false)},
+			observed: &iamv1.WorkloadIdentityPool{Disabled: true},
+			want:     want{mask: "disabled"},
+		},
+		"Renamed": {
+			in:       v1alpha1.WorkloadIdentityPoolParameters{DisplayName: gcp.StringPtr("new"), Description: gcp.StringPtr("desc")},
+			observed: &iamv1.WorkloadIdentityPool{DisplayName: "old"},
+			want:     want{mask: "displayName,description"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			upToDate, mask := IsWorkloadIdentityPoolUpToDate(tc.in, tc.observed)
+			if diff := cmp.Diff(tc.want, want{upToDate: upToDate, mask: mask}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("IsWorkloadIdentityPoolUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadidentitypoolprovider
+
+import (
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	iamv1 "google.golang.org/api/iam/v1"
+
+	"github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// Client should be satisfied to conduct WorkloadIdentityPoolProvider
+// operations.
+type Client interface {
+	Create(parent string, workloadidentitypoolprovider *iamv1.WorkloadIdentityPoolProvider) *iamv1.ProjectsLocationsWorkloadIdentityPoolsProvidersCreateCall
+	Get(name string) *iamv1.ProjectsLocationsWorkloadIdentityPoolsProvidersGetCall
+	Patch(name string, workloadidentitypoolprovider *iamv1.WorkloadIdentityPoolProvider) *iamv1.ProjectsLocationsWorkloadIdentityPoolsProvidersPatchCall
+	Delete(name string) *iamv1.ProjectsLocationsWorkloadIdentityPoolsProvidersDeleteCall
+	Undelete(name string, undeleteworkloadidentitypoolproviderrequest *iamv1.UndeleteWorkloadIdentityPoolProviderRequest) *iamv1.ProjectsLocationsWorkloadIdentityPoolsProvidersUndeleteCall
+}
+
+// GenerateWorkloadIdentityPoolProvider generates
+// *iamv1.WorkloadIdentityPoolProvider instance from
+// WorkloadIdentityPoolProviderParameters.
+func GenerateWorkloadIdentityPoolProvider(in v1alpha1.WorkloadIdentityPoolProviderParameters, p *iamv1.WorkloadIdentityPoolProvider) {
+	p.DisplayName = gcp.StringValue(in.DisplayName)
+	p.Description = gcp.StringValue(in.Description)
+	p.Disabled = gcp.BoolValue(in.Disabled)
+	p.AttributeMapping = in.AttributeMapping
+	p.AttributeCondition = gcp.StringValue(in.AttributeCondition)
+	p.Oidc = nil
+	if in.OIDC != nil {
+		p.Oidc = &iamv1.Oidc{
+			IssuerUri:        in.OIDC.IssuerURI,
+			AllowedAudiences: in.OIDC.AllowedAudiences,
+		}
+	}
+	p.Aws = nil
+	if in.AWS != nil {
+		p.Aws = &iamv1.Aws{AccountId: in.AWS.AccountID}
+	}
+}
+
+// GenerateWorkloadIdentityPoolProviderObservation produces
+// WorkloadIdentityPoolProviderObservation object from
+// iamv1.WorkloadIdentityPoolProvider object.
+func GenerateWorkloadIdentityPoolProviderObservation(in iamv1.WorkloadIdentityPoolProvider) v1alpha1.WorkloadIdentityPoolProviderObservation {
+	return v1alpha1.WorkloadIdentityPoolProviderObservation{
+		Name:  in.Name,
+		State: in.State,
+	}
+}
+
+// LateInitializeWorkloadIdentityPoolProvider fills unassigned fields with the
+// values in iamv1.WorkloadIdentityPoolProvider object. The attribute mapping
+// is late initialized because AWS providers get a default mapping if none is
+// supplied.
+func LateInitializeWorkloadIdentityPoolProvider(spec *v1alpha1.WorkloadIdentityPoolProviderParameters, in iamv1.WorkloadIdentityPoolProvider) {
+	spec.DisplayName = gcp.LateInitializeString(spec.DisplayName, in.DisplayName)
+	spec.Description = gcp.LateInitializeString(spec.Description, in.Description)
+	spec.Disabled = gcp.LateInitializeBool(spec.Disabled, in.Disabled)
+	spec.AttributeMapping = gcp.LateInitializeStringMap(spec.AttributeMapping, in.AttributeMapping)
+	spec.AttributeCondition = gcp.LateInitializeString(spec.AttributeCondition, in.AttributeCondition)
+}
+
+// IsWorkloadIdentityPoolProviderUpToDate checks whether current state is
+// up-to-date compared to the given set of parameters. It returns the update
+// mask of the fields that need to be patched.
+func IsWorkloadIdentityPoolProviderUpToDate(in v1alpha1.WorkloadIdentityPoolProviderParameters, observed *iamv1.WorkloadIdentityPoolProvider) (bool, string) {
+	um := make([]string, 0, 7)
+	if gcp.StringValue(in.DisplayName) != observed.DisplayName {
+		um = append(um, "displayName")
+	}
+	if gcp.StringValue(in.Description) != observed.Description {
+		um = append(um, "description")
+	}
+	if gcp.BoolValue(in.Disabled) != observed.Disabled {
+		um = append(um, "disabled")
+	}
+	if !cmp.Equal(in.AttributeMapping, observed.AttributeMapping, cmpopts.EquateEmpty()) {
+		um = append(um, "attributeMapping")
+	}
+	if gcp.StringValue(in.AttributeCondition) != observed.AttributeCondition {
+		um = append(um, "attributeCondition")
+	}
+	if in.OIDC != nil && !isOIDCUpToDate(*in.OIDC, observed.Oidc) {
+		um = append(um, "oidc")
+	}
+	if in.AWS != nil && (observed.Aws == nil || in.AWS.AccountID != observed.Aws.AccountId) {
+		um = append(um, "aws")
+	}
+	return len(um) == 0, strings.Join(um, ",")
+}
+
+func isOIDCUpToDate(in v1alpha1.WorkloadIdentityPoolProviderOIDC, observed *iamv1.Oidc) bool {
+	if observed == nil {
+		return false
+	}
+	return in.IssuerURI == observed.IssuerUri &&
+		cmp.Equal(in.AllowedAudiences, observed.AllowedAudiences, cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b string) bool { return a < b }))
+}
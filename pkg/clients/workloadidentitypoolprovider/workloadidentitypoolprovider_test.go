@@ -0,0 +1,136 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadidentitypoolprovider
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	iamv1 "google.golang.org/api/iam/v1"
+
+	"github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testIssuer    = "https://token.actions.githubusercontent.com"
+	testCondition = "assertion.repository_owner == 'crossplane'"
+)
+
+func TestGenerateWorkloadIdentityPoolProvider(t *testing.T) {
+	cases := map[string]struct {
+		in   v1alpha1.WorkloadIdentityPoolProviderParameters
+		want *iamv1.WorkloadIdentityPoolProvider
+	}{
+		"OIDC": {
+			in: v1alpha1.WorkloadIdentityPoolProviderParameters{
+				AttributeMapping:   map[string]string{"google.subject": "assertion.sub"},
+				AttributeCondition: gcp.StringPtr(testCondition),
+				OIDC:               &v1alpha1.WorkloadIdentityPoolProviderOIDC{IssuerURI: testIssuer, AllowedAudiences: []string{"aud"}},
+			},
+			want: &iamv1.WorkloadIdentityPoolProvider{
+				AttributeMapping:   map[string]string{"google.subject": "assertion.sub"},
+				AttributeCondition: testCondition,
+				Oidc:               &iamv1.Oidc{IssuerUri: testIssuer, AllowedAudiences: []string{"aud"}},
+			},
+		},
+		"AWS": {
+			in: v1alpha1.WorkloadIdentityPoolProviderParameters{
+				DisplayName: gcp.StringPtr("aws"),
+				AWS:         &v1alpha1.WorkloadIdentityPoolProviderAWS{AccountID: "123456789012"},
+			},
+			want: &iamv1.WorkloadIdentityPoolProvider{
+				DisplayName: "aws",
+				Aws:         &iamv1.Aws{AccountId: "123456789012"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &iamv1.WorkloadIdentityPoolProvider{}
+			GenerateWorkloadIdentityPoolProvider(tc.in, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GenerateWorkloadIdentityPoolProvider(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsWorkloadIdentityPoolProviderUpToDate(t *testing.T) {
+	observed := &iamv1.WorkloadIdentityPoolProvider{
+		AttributeMapping:   map[string]string{"google.subject": "assertion.sub"},
+		AttributeCondition: testCondition,
+		Oidc:               &iamv1.Oidc{IssuerUri: testIssuer, AllowedAudiences: []string{"a", "b"}},
+	}
+
+	type want struct {
+		upToDate bool
+		mask     string
+	}
+	cases := map[string]struct {
+		in   v1alpha1.WorkloadIdentityPoolProviderParameters
+		want want
+	}{
+		"UpToDate": {
+			in: v1alpha1.WorkloadIdentityPoolProviderParameters{
+				AttributeMapping:   map[string]string{"google.subject": "assertion.sub"},
+				AttributeCondition: gcp.StringPtr(testCondition),
+				OIDC:               &v1alpha1.WorkloadIdentityPoolProviderOIDC{IssuerURI: testIssuer, AllowedAudiences: []string{"b", "a"}},
+			},
+			want: want{upToDate: true},
+		},
+		"AttributeMappingDiffers": {
+			in: v1alpha1.WorkloadIdentityPoolProviderParameters{
+				AttributeMapping:   map[string]string{"google.subject": "assertion.sub", "attribute.actor": "assertion.actor"},
+				AttributeCondition: gcp.StringPtr(testCondition),
+				OIDC:               &v1alpha1.WorkloadIdentityPoolProviderOIDC{IssuerURI: testIssuer, AllowedAudiences: []string{"a", "b"}},
+			},
+			want: want{mask: "attributeMapping"},
+		},
+		"AttributeConditionRemoved": {
+			in: v1alpha1.WorkloadIdentityPoolProviderParameters{
+				AttributeMapping: map[string]string{"google.subject": "assertion.sub"},
+				OIDC:             &v1alpha1.WorkloadIdentityPoolProviderOIDC{IssuerURI: testIssuer, AllowedAudiences: []string{"a", "b"}},
+			},
+			want: want{mask: "attributeCondition"},
+		},
+		"AllowedAudiencesDiffer": {
+			in: v1alpha1.WorkloadIdentityPoolProviderParameters{
+				AttributeMapping:   map[string]string{"google.subject": "assertion.sub"},
+				AttributeCondition: gcp.StringPtr(testCondition),
+				OIDC:               &v1alpha1.WorkloadIdentityPoolProviderOIDC{IssuerURI: testIssuer, AllowedAudiences: []string{"a"}},
+			},
+			want: want{mask: "oidc"},
+		},
+		"AWSAccountDiffers": {
+			in: v1alpha1.WorkloadIdentityPoolProviderParameters{
+				AttributeMapping:   map[string]string{"google.subject": "assertion.sub"},
+				AttributeCondition: gcp.StringPtr(testCondition),
+				AWS:                &v1alpha1.WorkloadIdentityPoolProviderAWS{AccountID: "123456789012"},
+			},
+			want: want{mask: "aws"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			upToDate, mask := IsWorkloadIdentityPoolProviderUpToDate(tc.in, observed)
+			if diff := cmp.Diff(tc.want, want{upToDate: upToDate, mask: mask}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("IsWorkloadIdentityPoolProviderUpToDate(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigquery
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/bigqueryreservation/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/bigquery/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/capacitycommitment"
+)
+
+// Error strings.
+const (
+	errNotCapacityCommitment    = "managed resource is not a GCP BigQuery CapacityCommitment"
+	errGetCapacityCommitment    = "cannot get GCP BigQuery capacity commitment"
+	errCreateCapacityCommitment = "cannot create GCP BigQuery capacity commitment"
+	errUpdateCapacityCommitment = "cannot update GCP BigQuery capacity commitment"
+	errDeleteCapacityCommitment = "cannot delete GCP BigQuery capacity commitment"
+)
+
+// SetupCapacityCommitment adds a controller that reconciles BigQuery capacity
+// commitments.
+func SetupCapacityCommitment(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.CapacityCommitmentGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.CapacityCommitment{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.CapacityCommitmentGroupVersionKind),
+			managed.WithExternalConnecter(&capacityCommitmentConnecter{client: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type capacityCommitmentConnecter struct {
+	client client.Client
+}
+
+// Connect sets up BigQuery Reservation client using credentials from the
+// provider
+func (c *capacityCommitmentConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := bigqueryreservation.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewReservationClient)
+	}
+	return &capacityCommitmentExternal{
+		commitments: bigqueryreservation.NewProjectsLocationsCapacityCommitmentsService(s),
+		projectID:   projectID,
+		now:         time.Now,
+	}, nil
+}
+
+type capacityCommitmentExternal struct {
+	commitments capacitycommitment.Client
+	projectID   string
+	now         func() time.Time
+}
+
+func (e *capacityCommitmentExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.CapacityCommitment)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotCapacityCommitment)
+	}
+
+	instance, err := e.commitments.Get(capacityCommitmentRRN(e.projectID, cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetCapacityCommitment)
+	}
+
+	cr.Status.AtProvider = capacitycommitment.GenerateCapacityCommitmentObservation(*instance)
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	capacitycommitment.LateInitializeCapacityCommitment(&cr.Spec.ForProvider, *instance)
+
+	switch instance.State {
+	case v1alpha1.CommitmentStateActive:
+		cr.Status.SetConditions(xpv1.Available())
+	case v1alpha1.CommitmentStatePending:
+		cr.Status.SetConditions(xpv1.Creating())
+	default:
+		cr.Status.SetConditions(xpv1.Unavailable())
+	}
+
+	upToDate, _ := capacitycommitment.IsCapacityCommitmentUpToDate(cr.Spec.ForProvider, instance)
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: !cmp.Equal(currentSpec, &cr.Spec.ForProvider),
+		ResourceUpToDate:        upToDate,
+	}, nil
+}
+
+func (e *capacityCommitmentExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.CapacityCommitment)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotCapacityCommitment)
+	}
+	cr.SetConditions(xpv1.Creating())
+	instance := &bigqueryreservation.CapacityCommitment{}
+	capacitycommitment.GenerateCapacityCommitment(cr.Spec.ForProvider, instance)
+
+	if _, err := e.commitments.Create(locationRRN(e.projectID, cr.Spec.ForProvider.Location), instance).
+		CapacityCommitmentId(meta.GetExternalName(cr)).Context(ctx).Do(); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateCapacityCommitment)
+	}
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *capacityCommitmentExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.CapacityCommitment)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotCapacityCommitment)
+	}
+	// We have to get the commitment again here to calculate update mask (what
+	// to patch) and to check it against the constraints of commitment plans.
+	instance, err := e.commitments.Get(capacityCommitmentRRN(e.projectID, cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetCapacityCommitment)
+	}
+
+	u, um := capacitycommitment.IsCapacityCommitmentUpToDate(cr.Spec.ForProvider, instance)
+	if u {
+		return managed.ExternalUpdate{}, nil
+	}
+	if err := capacitycommitment.ValidateCapacityCommitmentUpdate(cr.Spec.ForProvider, instance); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateCapacityCommitment)
+	}
+
+	capacitycommitment.GenerateCapacityCommitment(cr.Spec.ForProvider, instance)
+	if _, err := e.commitments.Patch(capacityCommitmentRRN(e.projectID, cr), instance).UpdateMask(um).
+		Context(ctx).Do(); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateCapacityCommitment)
+	}
+
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *capacityCommitmentExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.CapacityCommitment)
+	if !ok {
+		return errors.New(errNotCapacityCommitment)
+	}
+	// NOTE: A commitment can not be deleted before its committed period ends,
+	// so deleting it is retried until it does rather than requested early.
+	instance, err := e.commitments.Get(capacityCommitmentRRN(e.projectID, cr)).Context(ctx).Do()
+	if err != nil {
+		return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetCapacityCommitment)
+	}
+	if err := capacitycommitment.ValidateCapacityCommitmentDeletion(instance, e.now()); err != nil {
+		return errors.Wrap(err, errDeleteCapacityCommitment)
+	}
+	_, err = e.commitments.Delete(capacityCommitmentRRN(e.projectID, cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteCapacityCommitment)
+}
+
+func capacityCommitmentRRN(projectID string, cr *v1alpha1.CapacityCommitment) string {
+	return fmt.Sprintf("%s/capacityCommitments/%s", locationRRN(projectID, cr.Spec.ForProvider.Location), meta.GetExternalName(cr))
+}
@@ -0,0 +1,305 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigquery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/bigqueryreservation/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/bigquery/v1alpha1"
+)
+
+const commitmentName = "annual"
+
+var commitmentResourceName = "projects/" + projectID + "/locations/" + location + "/capacityCommitments/" + commitmentName
+
+type commitmentModifier func(*v1alpha1.CapacityCommitment)
+
+func ccWithRenewalPlan(p string) commitmentModifier {
+	return func(c *v1alpha1.CapacityCommitment) { c.Spec.ForProvider.RenewalPlan = &p }
+}
+
+func ccWithAtProvider(o v1alpha1.CapacityCommitmentObservation) commitmentModifier {
+	return func(c *v1alpha1.CapacityCommitment) { c.Status.AtProvider = o }
+}
+
+func ccWithCondition(co xpv1.Condition) commitmentModifier {
+	return func(c *v1alpha1.CapacityCommitment) { c.SetConditions(co) }
+}
+
+func newCapacityCommitment(slots int64, plan string, m ...commitmentModifier) *v1alpha1.CapacityCommitment {
+	c := &v1alpha1.CapacityCommitment{
+		ObjectMeta: metav1.ObjectMeta{Name: commitmentName},
+		Spec: v1alpha1.CapacityCommitmentSpec{
+			ForProvider: v1alpha1.CapacityCommitmentParameters{Location: location, SlotCount: slots, Plan: plan},
+		},
+	}
+	meta.SetExternalName(c, commitmentName)
+	for _, f := range m {
+		f(c)
+	}
+	return c
+}
+
+func TestCapacityCommitmentObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+	cases := map[string]struct {
+		reason   string
+		status   int
+		observed *bigqueryreservation.CapacityCommitment
+		mg       resource.Managed
+		want     want
+	}{
+		"NotCapacityCommitment": {
+			reason: "Should return an error if the managed resource is not a CapacityCommitment",
+			mg:     &strange{},
+			want: want{
+				mg:  &strange{},
+				err: errors.New(errNotCapacityCommitment),
+			},
+		},
+		"NotFound": {
+			reason: "Should report that the CapacityCommitment does not exist",
+			status: http.StatusNotFound,
+			mg:     newCapacityCommitment(100, v1alpha1.CommitmentPlanFlex),
+			want: want{
+				mg: newCapacityCommitment(100, v1alpha1.CommitmentPlanFlex),
+			},
+		},
+		"Pending": {
+			reason: "Should report a pending CapacityCommitment as being created",
+			status: http.StatusOK,
+			observed: &bigqueryreservation.CapacityCommitment{
+				Name: commitmentResourceName, SlotCount: 100, Plan: v1alpha1.CommitmentPlanFlex, State: v1alpha1.CommitmentStatePending,
+			},
+			mg: newCapacityCommitment(100, v1alpha1.CommitmentPlanFlex),
+			want: want{
+				mg: newCapacityCommitment(100, v1alpha1.CommitmentPlanFlex,
+					ccWithAtProvider(v1alpha1.CapacityCommitmentObservation{Name: commitmentResourceName, State: v1alpha1.CommitmentStatePending}),
+					ccWithCondition(xpv1.Creating())),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"LateInitializedAndUpToDate": {
+			reason: "Should late initialize the renewal plan and report an active CapacityCommitment as available",
+			status: http.StatusOK,
+			observed: &bigqueryreservation.CapacityCommitment{
+				Name: commitmentResourceName, SlotCount: 100, Plan: v1alpha1.CommitmentPlanAnnual, RenewalPlan: v1alpha1.CommitmentPlanFlex,
+				State: v1alpha1.CommitmentStateActive, CommitmentEndTime: "2022-10-01T12:00:00Z",
+			},
+			mg: newCapacityCommitment(100, v1alpha1.CommitmentPlanAnnual),
+			want: want{
+				mg: newCapacityCommitment(100, v1alpha1.CommitmentPlanAnnual,
+					ccWithRenewalPlan(v1alpha1.CommitmentPlanFlex),
+					ccWithAtProvider(v1alpha1.CapacityCommitmentObservation{
+						Name: commitmentResourceName, State: v1alpha1.CommitmentStateActive, CommitmentEndTime: "2022-10-01T12:00:00Z",
+					}),
+					ccWithCondition(xpv1.Available())),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			},
+		},
+		"SlotCountDiffers": {
+			reason: "Should report the CapacityCommitment as not up to date if its slot count differs",
+			status: http.StatusOK,
+			observed: &bigqueryreservation.CapacityCommitment{
+				Name: commitmentResourceName, SlotCount: 100, Plan: v1alpha1.CommitmentPlanFlex, State: v1alpha1.CommitmentStateActive,
+			},
+			mg: newCapacityCommitment(200, v1alpha1.CommitmentPlanFlex),
+			want: want{
+				mg: newCapacityCommitment(200, v1alpha1.CommitmentPlanFlex,
+					ccWithAtProvider(v1alpha1.CapacityCommitmentObservation{Name: commitmentResourceName, State: v1alpha1.CommitmentStateActive}),
+					ccWithCondition(xpv1.Available())),
+				obs: managed.ExternalObservation{ResourceExists: true},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(tc.status)
+				if tc.status == http.StatusOK {
+					_ = json.NewEncoder(w).Encode(tc.observed)
+				}
+			}))
+			defer server.Close()
+			s, _ := bigqueryreservation.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &capacityCommitmentExternal{commitments: bigqueryreservation.NewProjectsLocationsCapacityCommitmentsService(s), projectID: projectID}
+			obs, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCapacityCommitmentUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		observed *bigqueryreservation.CapacityCommitment
+		mask     string
+		mg       resource.Managed
+		err      error
+	}{
+		"UpgradedPlan": {
+			reason:   "Should patch the plan of the CapacityCommitment",
+			observed: &bigqueryreservation.CapacityCommitment{Name: commitmentResourceName, SlotCount: 100, Plan: v1alpha1.CommitmentPlanFlex},
+			mask:     "plan",
+			mg:       newCapacityCommitment(100, v1alpha1.CommitmentPlanAnnual),
+		},
+		"RenewalPlan": {
+			reason:   "Should patch the renewal plan of the CapacityCommitment",
+			observed: &bigqueryreservation.CapacityCommitment{Name: commitmentResourceName, SlotCount: 100, Plan: v1alpha1.CommitmentPlanAnnual, RenewalPlan: v1alpha1.CommitmentPlanFlex},
+			mask:     "renewalPlan",
+			mg:       newCapacityCommitment(100, v1alpha1.CommitmentPlanAnnual, ccWithRenewalPlan(v1alpha1.CommitmentPlanAnnual)),
+		},
+		"ChangedSlotCount": {
+			reason:   "Should return an error if the slot count of the CapacityCommitment would be changed",
+			observed: &bigqueryreservation.CapacityCommitment{Name: commitmentResourceName, SlotCount: 100, Plan: v1alpha1.CommitmentPlanFlex},
+			mg:       newCapacityCommitment(200, v1alpha1.CommitmentPlanFlex),
+			err:      errors.Wrap(errors.New("the slot count of a capacity commitment cannot be changed; split or merge commitments instead"), errUpdateCapacityCommitment),
+		},
+		"DowngradedPlan": {
+			reason:   "Should return an error if the CapacityCommitment would be converted to a shorter plan",
+			observed: &bigqueryreservation.CapacityCommitment{Name: commitmentResourceName, SlotCount: 100, Plan: v1alpha1.CommitmentPlanAnnual},
+			mg:       newCapacityCommitment(100, v1alpha1.CommitmentPlanMonthly),
+			err:      errors.Wrap(errors.New("the plan of a capacity commitment can only be changed to a plan with a longer committed period"), errUpdateCapacityCommitment),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				switch r.Method {
+				case http.MethodGet:
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(tc.observed)
+				case http.MethodPatch:
+					if tc.err != nil {
+						t.Errorf("\n%s\nunexpected patch of the CapacityCommitment", tc.reason)
+					}
+					if diff := cmp.Diff(tc.mask, r.URL.Query().Get("updateMask")); diff != "" {
+						t.Errorf("updateMask: -want, +got:\n%s", diff)
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(tc.observed)
+				default:
+					t.Errorf("unexpected method %s", r.Method)
+				}
+			}))
+			defer server.Close()
+			s, _ := bigqueryreservation.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &capacityCommitmentExternal{commitments: bigqueryreservation.NewProjectsLocationsCapacityCommitmentsService(s), projectID: projectID}
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCapacityCommitmentDelete(t *testing.T) {
+	now := time.Date(2021, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		reason   string
+		observed *bigqueryreservation.CapacityCommitment
+		deleted  bool
+		err      error
+	}{
+		"Deleted": {
+			reason:   "Should delete a CapacityCommitment whose committed period has ended",
+			observed: &bigqueryreservation.CapacityCommitment{Name: commitmentResourceName, CommitmentEndTime: "2021-10-01T11:00:00Z"},
+			deleted:  true,
+		},
+		"Committed": {
+			reason:   "Should not delete a CapacityCommitment before its committed period ends",
+			observed: &bigqueryreservation.CapacityCommitment{Name: commitmentResourceName, CommitmentEndTime: "2022-10-01T12:00:00Z"},
+			err:      errors.Wrap(errors.New(fmt.Sprintf("a capacity commitment cannot be deleted before its committed period ends at %s", "2022-10-01T12:00:00Z")), errDeleteCapacityCommitment),
+		},
+		"AlreadyGone": {
+			reason: "Should not return an error if the CapacityCommitment is already gone",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			deleted := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				switch r.Method {
+				case http.MethodGet:
+					if tc.observed == nil {
+						w.WriteHeader(http.StatusNotFound)
+						return
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(tc.observed)
+				case http.MethodDelete:
+					deleted = true
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&bigqueryreservation.Empty{})
+				default:
+					t.Errorf("unexpected method %s", r.Method)
+				}
+			}))
+			defer server.Close()
+			s, _ := bigqueryreservation.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &capacityCommitmentExternal{
+				commitments: bigqueryreservation.NewProjectsLocationsCapacityCommitmentsService(s),
+				projectID:   projectID,
+				now:         func() time.Time { return now },
+			}
+			err := e.Delete(context.Background(), newCapacityCommitment(100, v1alpha1.CommitmentPlanAnnual))
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.deleted, deleted); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want deleted, +got deleted:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
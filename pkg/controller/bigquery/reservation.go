@@ -0,0 +1,183 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigquery
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/bigqueryreservation/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/bigquery/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/reservation"
+)
+
+// Error strings.
+const (
+	errNewReservationClient = "cannot create new GCP BigQuery Reservation API client"
+	errNotReservation       = "managed resource is not a GCP BigQuery Reservation"
+	errGetReservation       = "cannot get GCP BigQuery reservation"
+	errCreateReservation    = "cannot create GCP BigQuery reservation"
+	errUpdateReservation    = "cannot update GCP BigQuery reservation"
+	errDeleteReservation    = "cannot delete GCP BigQuery reservation"
+)
+
+// SetupReservation adds a controller that reconciles BigQuery reservations.
+func SetupReservation(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.ReservationGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.Reservation{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.ReservationGroupVersionKind),
+			managed.WithExternalConnecter(&reservationConnecter{client: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type reservationConnecter struct {
+	client client.Client
+}
+
+// Connect sets up BigQuery Reservation client using credentials from the
+// provider
+func (c *reservationConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := bigqueryreservation.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewReservationClient)
+	}
+	return &reservationExternal{reservations: bigqueryreservation.NewProjectsLocationsReservationsService(s), projectID: projectID}, nil
+}
+
+type reservationExternal struct {
+	reservations reservation.Client
+	projectID    string
+}
+
+func (e *reservationExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Reservation)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotReservation)
+	}
+
+	instance, err := e.reservations.Get(reservationRRN(e.projectID, cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetReservation)
+	}
+
+	cr.Status.AtProvider = reservation.GenerateReservationObservation(*instance)
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	reservation.LateInitializeReservation(&cr.Spec.ForProvider, *instance)
+
+	cr.Status.SetConditions(xpv1.Available())
+
+	upToDate, _ := reservation.IsReservationUpToDate(cr.Spec.ForProvider, instance)
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: !cmp.Equal(currentSpec, &cr.Spec.ForProvider),
+		ResourceUpToDate:        upToDate,
+	}, nil
+}
+
+func (e *reservationExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Reservation)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotReservation)
+	}
+	cr.SetConditions(xpv1.Creating())
+	instance := &bigqueryreservation.Reservation{}
+	reservation.GenerateReservation(cr.Spec.ForProvider, instance)
+
+	if _, err := e.reservations.Create(locationRRN(e.projectID, cr.Spec.ForProvider.Location), instance).
+		ReservationId(meta.GetExternalName(cr)).Context(ctx).Do(); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateReservation)
+	}
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *reservationExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Reservation)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotReservation)
+	}
+	// We have to get the reservation again here to calculate update mask
+	// (what to patch).
+	instance, err := e.reservations.Get(reservationRRN(e.projectID, cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetReservation)
+	}
+
+	u, um := reservation.IsReservationUpToDate(cr.Spec.ForProvider, instance)
+	if u {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	reservation.GenerateReservation(cr.Spec.ForProvider, instance)
+	if _, err := e.reservations.Patch(reservationRRN(e.projectID, cr), instance).UpdateMask(um).
+		Context(ctx).Do(); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateReservation)
+	}
+
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *reservationExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Reservation)
+	if !ok {
+		return errors.New(errNotReservation)
+	}
+	_, err := e.reservations.Delete(reservationRRN(e.projectID, cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteReservation)
+}
+
+func locationRRN(projectID, location string) string {
+	return fmt.Sprintf("projects/%s/locations/%s", projectID, location)
+}
+
+func reservationRRN(projectID string, cr *v1alpha1.Reservation) string {
+	return fmt.Sprintf("%s/reservations/%s", locationRRN(projectID, cr.Spec.ForProvider.Location), meta.GetExternalName(cr))
+}
@@ -0,0 +1,337 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigquery
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/bigqueryreservation/v1"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/bigquery/v1alpha1"
+)
+
+const (
+	projectID       = "test-project"
+	location        = "US"
+	reservationName = "prod"
+)
+
+var reservationResourceName = "projects/" + projectID + "/locations/" + location + "/reservations/" + reservationName
+
+func gError(code int, message string) *googleapi.Error {
+	return &googleapi.Error{
+		Code:    code,
+		Body:    "",
+		Message: message,
+	}
+}
+
+type reservationModifier func(*v1alpha1.Reservation)
+
+func rWithIgnoreIdleSlots(i bool) reservationModifier {
+	return func(r *v1alpha1.Reservation) { r.Spec.ForProvider.IgnoreIdleSlots = &i }
+}
+
+func rWithAtProvider(o v1alpha1.ReservationObservation) reservationModifier {
+	return func(r *v1alpha1.Reservation) { r.Status.AtProvider = o }
+}
+
+func rWithCondition(c xpv1.Condition) reservationModifier {
+	return func(r *v1alpha1.Reservation) { r.SetConditions(c) }
+}
+
+func newReservation(slots int64, m ...reservationModifier) *v1alpha1.Reservation {
+	r := &v1alpha1.Reservation{
+		ObjectMeta: metav1.ObjectMeta{Name: reservationName},
+		Spec: v1alpha1.ReservationSpec{
+			ForProvider: v1alpha1.ReservationParameters{Location: location, SlotCapacity: slots},
+		},
+	}
+	meta.SetExternalName(r, reservationName)
+	for _, f := range m {
+		f(r)
+	}
+	return r
+}
+
+func TestReservationObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+	cases := map[string]struct {
+		reason   string
+		status   int
+		observed *bigqueryreservation.Reservation
+		mg       resource.Managed
+		want     want
+	}{
+		"NotReservation": {
+			reason: "Should return an error if the managed resource is not a Reservation",
+			mg:     &strange{},
+			want: want{
+				mg:  &strange{},
+				err: errors.New(errNotReservation),
+			},
+		},
+		"NotFound": {
+			reason: "Should report that the Reservation does not exist",
+			status: http.StatusNotFound,
+			mg:     newReservation(100),
+			want: want{
+				mg: newReservation(100),
+			},
+		},
+		"GetFailed": {
+			reason: "Should return an error if the Reservation cannot be fetched",
+			status: http.StatusBadRequest,
+			mg:     newReservation(100),
+			want: want{
+				mg:  newReservation(100),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetReservation),
+			},
+		},
+		"LateInitializedAndUpToDate": {
+			reason:   "Should late initialize whether idle slots are ignored and report the Reservation as up to date",
+			status:   http.StatusOK,
+			observed: &bigqueryreservation.Reservation{Name: reservationResourceName, SlotCapacity: 100, IgnoreIdleSlots: true},
+			mg:       newReservation(100),
+			want: want{
+				mg: newReservation(100,
+					rWithIgnoreIdleSlots(true),
+					rWithAtProvider(v1alpha1.ReservationObservation{Name: reservationResourceName}),
+					rWithCondition(xpv1.Available())),
+				obs: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+			},
+		},
+		"SlotCapacityDiffers": {
+			reason:   "Should report the Reservation as not up to date if its slot capacity differs",
+			status:   http.StatusOK,
+			observed: &bigqueryreservation.Reservation{Name: reservationResourceName, SlotCapacity: 100},
+			mg:       newReservation(500),
+			want: want{
+				mg: newReservation(500,
+					rWithAtProvider(v1alpha1.ReservationObservation{Name: reservationResourceName}),
+					rWithCondition(xpv1.Available())),
+				obs: managed.ExternalObservation{
+					ResourceExists: true,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if !strings.HasSuffix(r.URL.Path, reservationResourceName) {
+					t.Errorf("requested URL.Path should end with %s, got %s instead", reservationResourceName, r.URL.Path)
+				}
+				w.WriteHeader(tc.status)
+				if tc.status == http.StatusOK {
+					_ = json.NewEncoder(w).Encode(tc.observed)
+				}
+			}))
+			defer server.Close()
+			s, _ := bigqueryreservation.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &reservationExternal{reservations: bigqueryreservation.NewProjectsLocationsReservationsService(s), projectID: projectID}
+			obs, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReservationCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		status int
+		mg     resource.Managed
+		err    error
+	}{
+		"Created": {
+			reason: "Should create the Reservation in the configured location",
+			status: http.StatusOK,
+			mg:     newReservation(100),
+		},
+		"CreateFailed": {
+			reason: "Should return an error if the Reservation cannot be created",
+			status: http.StatusBadRequest,
+			mg:     newReservation(100),
+			err:    errors.Wrap(gError(http.StatusBadRequest, ""), errCreateReservation),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff(reservationName, r.URL.Query().Get("reservationId")); diff != "" {
+					t.Errorf("reservationId: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(tc.status)
+				if tc.status == http.StatusOK {
+					_ = json.NewEncoder(w).Encode(&bigqueryreservation.Reservation{Name: reservationResourceName})
+				}
+			}))
+			defer server.Close()
+			s, _ := bigqueryreservation.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &reservationExternal{reservations: bigqueryreservation.NewProjectsLocationsReservationsService(s), projectID: projectID}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReservationUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		observed *bigqueryreservation.Reservation
+		mask     string
+		slots    int64
+		mg       resource.Managed
+	}{
+		"IncreasedSlotCapacity": {
+			reason:   "Should patch only the slot capacity of the Reservation",
+			observed: &bigqueryreservation.Reservation{Name: reservationResourceName, SlotCapacity: 100},
+			mask:     "slotCapacity",
+			slots:    500,
+			mg:       newReservation(500),
+		},
+		"RemovedSlotCapacity": {
+			reason:   "Should patch the slot capacity of the Reservation down to zero",
+			observed: &bigqueryreservation.Reservation{Name: reservationResourceName, SlotCapacity: 100},
+			mask:     "slotCapacity",
+			mg:       newReservation(0),
+		},
+		"IgnoreIdleSlots": {
+			reason:   "Should patch whether the Reservation ignores idle slots",
+			observed: &bigqueryreservation.Reservation{Name: reservationResourceName, SlotCapacity: 100},
+			mask:     "ignoreIdleSlots",
+			slots:    100,
+			mg:       newReservation(100, rWithIgnoreIdleSlots(true)),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer func() { _ = r.Body.Close() }()
+				switch r.Method {
+				case http.MethodGet:
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(tc.observed)
+				case http.MethodPatch:
+					if diff := cmp.Diff(tc.mask, r.URL.Query().Get("updateMask")); diff != "" {
+						t.Errorf("updateMask: -want, +got:\n%s", diff)
+					}
+					got := &bigqueryreservation.Reservation{}
+					_ = json.NewDecoder(r.Body).Decode(got)
+					if diff := cmp.Diff(tc.slots, got.SlotCapacity); diff != "" {
+						t.Errorf("\n%s\nslotCapacity: -want, +got:\n%s", tc.reason, diff)
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(tc.observed)
+				default:
+					t.Errorf("unexpected method %s", r.Method)
+				}
+			}))
+			defer server.Close()
+			s, _ := bigqueryreservation.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &reservationExternal{reservations: bigqueryreservation.NewProjectsLocationsReservationsService(s), projectID: projectID}
+			if _, err := e.Update(context.Background(), tc.mg); err != nil {
+				t.Errorf("\n%s\nUpdate(...): unexpected error: %v", tc.reason, err)
+			}
+		})
+	}
+}
+
+func TestReservationDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		status int
+		err    error
+	}{
+		"Deleted": {
+			reason: "Should delete the Reservation",
+			status: http.StatusOK,
+		},
+		"AlreadyGone": {
+			reason: "Should not return an error if the Reservation is already gone",
+			status: http.StatusNotFound,
+		},
+		"DeleteFailed": {
+			reason: "Should return an error if the Reservation cannot be deleted",
+			status: http.StatusBadRequest,
+			err:    errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteReservation),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(tc.status)
+				if tc.status == http.StatusOK {
+					_ = json.NewEncoder(w).Encode(&bigqueryreservation.Empty{})
+				}
+			}))
+			defer server.Close()
+			s, _ := bigqueryreservation.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &reservationExternal{reservations: bigqueryreservation.NewProjectsLocationsReservationsService(s), projectID: projectID}
+			err := e.Delete(context.Background(), newReservation(100))
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
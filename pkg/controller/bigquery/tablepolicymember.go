@@ -0,0 +1,166 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigquery
+
+import (
+	"context"
+	"time"
+
+	bigquery "google.golang.org/api/bigquery/v2"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/bigquery/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/tablepolicy"
+)
+
+// Error strings.
+const (
+	errNewClient            = "cannot create new GCP BigQuery API client"
+	errNotTablePolicyMember = "managed resource is not a GCP TablePolicyMember"
+	errGetPolicy            = "cannot get GCP Table IAM policy via BigQuery API"
+	errSetPolicy            = "cannot set GCP Table IAM policy via BigQuery API"
+)
+
+// SetupTablePolicyMember adds a controller that reconciles
+// TablePolicyMembers.
+func SetupTablePolicyMember(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.TablePolicyMemberGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.TablePolicyMember{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.TablePolicyMemberGroupVersionKind),
+			managed.WithExternalConnecter(&tablePolicyMemberConnecter{client: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type tablePolicyMemberConnecter struct {
+	client client.Client
+}
+
+// Connect sets up BigQuery client using credentials from the provider
+func (c *tablePolicyMemberConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, opts, err := gcp.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := bigquery.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &tablePolicyMemberExternal{tablepolicy: bigquery.NewTablesService(s)}, nil
+}
+
+type tablePolicyMemberExternal struct {
+	tablepolicy tablepolicy.Client
+}
+
+func (e *tablePolicyMemberExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.TablePolicyMember)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTablePolicyMember)
+	}
+
+	instance, err := e.tablepolicy.GetIamPolicy(cr.Spec.ForProvider.Table, tablepolicy.GetIamPolicyRequest()).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetPolicy)
+	}
+
+	changed := tablepolicy.BindRoleToMember(cr.Spec.ForProvider, instance)
+	if !changed {
+		cr.Status.SetConditions(xpv1.Available())
+		return managed.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: true,
+		}, nil
+	}
+
+	return managed.ExternalObservation{}, nil
+}
+
+func (e *tablePolicyMemberExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.TablePolicyMember)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTablePolicyMember)
+	}
+	if err := tablepolicy.ValidateMember(gcp.StringValue(cr.Spec.ForProvider.Member)); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	instance, err := e.tablepolicy.GetIamPolicy(cr.Spec.ForProvider.Table, tablepolicy.GetIamPolicyRequest()).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errGetPolicy)
+	}
+
+	changed := tablepolicy.BindRoleToMember(cr.Spec.ForProvider, instance)
+	if !changed {
+		return managed.ExternalCreation{}, nil
+	}
+
+	if _, err := e.tablepolicy.SetIamPolicy(cr.Spec.ForProvider.Table, &bigquery.SetIamPolicyRequest{Policy: instance}).
+		Context(ctx).Do(); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errSetPolicy)
+	}
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *tablePolicyMemberExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	_, err := e.Create(ctx, mg)
+	return managed.ExternalUpdate{}, err
+}
+
+func (e *tablePolicyMemberExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.TablePolicyMember)
+	if !ok {
+		return errors.New(errNotTablePolicyMember)
+	}
+	instance, err := e.tablepolicy.GetIamPolicy(cr.Spec.ForProvider.Table, tablepolicy.GetIamPolicyRequest()).Context(ctx).Do()
+	if err != nil {
+		return errors.Wrap(err, errGetPolicy)
+	}
+
+	changed := tablepolicy.UnbindRoleFromMember(cr.Spec.ForProvider, instance)
+	if !changed {
+		return nil
+	}
+	if _, err := e.tablepolicy.SetIamPolicy(cr.Spec.ForProvider.Table, &bigquery.SetIamPolicyRequest{Policy: instance}).
+		Context(ctx).Do(); err != nil {
+		return errors.Wrap(err, errSetPolicy)
+	}
+
+	return nil
+}
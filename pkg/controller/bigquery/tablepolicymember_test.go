@@ -0,0 +1,257 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bigquery
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	bigquery "google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/bigquery/v1alpha1"
+	iamv1alpha1 "github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
+)
+
+const (
+	testTable  = "projects/test-project/datasets/test_dataset/tables/test_table"
+	testRole   = "roles/bigquery.dataViewer"
+	testMember = "serviceAccount:app@test-project.iam.gserviceaccount.com"
+	testOther  = "user:alice@example.com"
+)
+
+type strange struct {
+	resource.Managed
+}
+
+type tpmModifier func(*v1alpha1.TablePolicyMember)
+
+func tpmWithMember(m string) tpmModifier {
+	return func(tpm *v1alpha1.TablePolicyMember) { tpm.Spec.ForProvider.Member = &m }
+}
+
+func tpmWithCondition(c xpv1.Condition) tpmModifier {
+	return func(tpm *v1alpha1.TablePolicyMember) { tpm.SetConditions(c) }
+}
+
+func newTablePolicyMember(m ...tpmModifier) *v1alpha1.TablePolicyMember {
+	member := testMember
+	tpm := &v1alpha1.TablePolicyMember{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-table-policy-member"},
+		Spec: v1alpha1.TablePolicyMemberSpec{
+			ForProvider: v1alpha1.TablePolicyMemberParameters{
+				Table:  testTable,
+				Role:   testRole,
+				Member: &member,
+			},
+		},
+	}
+	for _, f := range m {
+		f(tpm)
+	}
+	return tpm
+}
+
+// policyServer serves the supplied policy and records the policy it is asked
+// to set.
+func policyServer(t *testing.T, p *bigquery.Policy, set **bigquery.Policy) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() { _ = r.Body.Close() }()
+		// Both getting and setting the policy of a Table are POST requests.
+		switch {
+		case strings.HasSuffix(r.URL.Path, ":getIamPolicy"):
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(p)
+		case strings.HasSuffix(r.URL.Path, ":setIamPolicy"):
+			req := &bigquery.SetIamPolicyRequest{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				t.Errorf("cannot decode request: %s", err)
+			}
+			*set = req.Policy
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(req.Policy)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+func TestTablePolicyMemberObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		policy *bigquery.Policy
+		mg     resource.Managed
+		want   want
+	}{
+		"NotTablePolicyMember": {
+			reason: "Should return an error if the managed resource is not a TablePolicyMember",
+			mg:     &strange{},
+			want: want{
+				mg:  &strange{},
+				err: errors.New(errNotTablePolicyMember),
+			},
+		},
+		"NotBound": {
+			reason: "Should report that the TablePolicyMember does not exist if the member is not bound",
+			policy: &bigquery.Policy{Bindings: []*bigquery.Binding{{Role: testRole, Members: []string{testOther}}}},
+			mg:     newTablePolicyMember(),
+			want: want{
+				mg: newTablePolicyMember(),
+			},
+		},
+		"Bound": {
+			reason: "Should report that the TablePolicyMember exists and is up to date if the member is bound",
+			policy: &bigquery.Policy{Bindings: []*bigquery.Binding{{Role: testRole, Members: []string{testOther, testMember}}}},
+			mg:     newTablePolicyMember(),
+			want: want{
+				mg:  newTablePolicyMember(tpmWithCondition(xpv1.Available())),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var set *bigquery.Policy
+			server := policyServer(t, tc.policy, &set)
+			defer server.Close()
+			s, _ := bigquery.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &tablePolicyMemberExternal{tablepolicy: bigquery.NewTablesService(s)}
+			obs, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTablePolicyMemberCreate(t *testing.T) {
+	type want struct {
+		set *bigquery.Policy
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		policy *bigquery.Policy
+		mg     resource.Managed
+		want   want
+	}{
+		"AddedViewer": {
+			reason: "Should add the member to the viewer binding of the Table",
+			policy: &bigquery.Policy{Etag: "BwWWja0YfJA=", Bindings: []*bigquery.Binding{{Role: testRole, Members: []string{testOther}}}},
+			mg:     newTablePolicyMember(),
+			want: want{
+				set: &bigquery.Policy{Etag: "BwWWja0YfJA=", Version: iamv1alpha1.PolicyVersion, Bindings: []*bigquery.Binding{
+					{Role: testRole, Members: []string{testOther, testMember}},
+				}},
+			},
+		},
+		"AlreadyBound": {
+			reason: "Should not set the policy if the member is already bound",
+			policy: &bigquery.Policy{Bindings: []*bigquery.Binding{{Role: testRole, Members: []string{testMember}}}},
+			mg:     newTablePolicyMember(),
+		},
+		"InvalidMember": {
+			reason: "Should return an error if the member is not a well formed identity",
+			mg:     newTablePolicyMember(tpmWithMember("alice@example.com")),
+			want: want{
+				err: errors.Errorf("invalid member %q: must be allUsers, allAuthenticatedUsers, a principal:// or principalSet:// identifier, or an identity prefixed with its type, e.g. user:, serviceAccount:, group: or domain:", "alice@example.com"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var set *bigquery.Policy
+			server := policyServer(t, tc.policy, &set)
+			defer server.Close()
+			s, _ := bigquery.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &tablePolicyMemberExternal{tablepolicy: bigquery.NewTablesService(s)}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.set, set); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want policy, +got policy:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTablePolicyMemberDelete(t *testing.T) {
+	type want struct {
+		set *bigquery.Policy
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		policy *bigquery.Policy
+		mg     resource.Managed
+		want   want
+	}{
+		"RemovedViewer": {
+			reason: "Should remove the member from the viewer binding of the Table",
+			policy: &bigquery.Policy{Bindings: []*bigquery.Binding{{Role: testRole, Members: []string{testOther, testMember}}}},
+			mg:     newTablePolicyMember(),
+			want: want{
+				set: &bigquery.Policy{Bindings: []*bigquery.Binding{{Role: testRole, Members: []string{testOther}}}},
+			},
+		},
+		"NotBound": {
+			reason: "Should not set the policy if the member is not bound",
+			policy: &bigquery.Policy{Bindings: []*bigquery.Binding{{Role: testRole, Members: []string{testOther}}}},
+			mg:     newTablePolicyMember(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var set *bigquery.Policy
+			server := policyServer(t, tc.policy, &set)
+			defer server.Close()
+			s, _ := bigquery.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &tablePolicyMemberExternal{tablepolicy: bigquery.NewTablesService(s)}
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.set, set); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want policy, +got policy:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -58,7 +58,7 @@ const (
 
 // SetupCloudMemorystoreInstance adds a controller that reconciles
 // CloudMemorystoreInstances.
-func SetupCloudMemorystoreInstance(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupCloudMemorystoreInstance(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
 	name := managed.ControllerName(v1beta1.CloudMemorystoreInstanceGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -70,7 +70,9 @@ func SetupCloudMemorystoreInstance(mgr ctrl.Manager, l logging.Logger, rl workqu
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1beta1.CloudMemorystoreInstanceGroupVersionKind),
 			managed.WithExternalConnecter(&connecter{client: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
 			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
@@ -80,11 +82,15 @@ type connecter struct {
 }
 
 func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	projectID, opts, err := gcp.GetAuthInfo(ctx, c.client, mg)
+	cr, ok := mg.(*v1beta1.CloudMemorystoreInstance)
+	if !ok {
+		return nil, errors.New(errNotInstance)
+	}
+	projectID, opts, err := gcp.GetRegionalAuthInfo(ctx, c.client, mg, gcp.ServiceRedis, cr.Spec.ForProvider.Region)
 	if err != nil {
 		return nil, err
 	}
-	s, err := redis.NewService(ctx, opts)
+	s, err := redis.NewService(ctx, opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
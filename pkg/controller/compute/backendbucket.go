@@ -0,0 +1,213 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/backendbucket"
+)
+
+// Error strings.
+const (
+	errNotBackendBucket           = "managed resource is not a BackendBucket"
+	errGetBackendBucket           = "cannot get GCP backend bucket"
+	errCreateBackendBucket        = "cannot create GCP backend bucket"
+	errUpdateBackendBucket        = "cannot update GCP backend bucket"
+	errDeleteBackendBucket        = "cannot delete GCP backend bucket"
+	errCheckBackendBucketUpToDate = "cannot determine if GCP backend bucket is up to date"
+	errAddSignedURLKey            = "cannot add signed URL key to GCP backend bucket"
+	errDeleteSignedURLKey         = "cannot delete signed URL key from GCP backend bucket"
+	errGetSignedURLKeySecret      = "cannot get signed URL key secret"
+)
+
+// SetupBackendBucket adds a controller that reconciles BackendBucket managed
+// resources.
+func SetupBackendBucket(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.BackendBucketGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.BackendBucket{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.BackendBucketGroupVersionKind),
+			managed.WithExternalConnecter(&backendBucketConnector{kube: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithConnectionPublishers(),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type backendBucketConnector struct {
+	kube client.Client
+}
+
+func (c *backendBucketConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := compute.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &backendBucketExternal{Service: s, kube: c.kube, projectID: projectID}, nil
+}
+
+type backendBucketExternal struct {
+	kube client.Client
+	*compute.Service
+	projectID string
+}
+
+func (e *backendBucketExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.BackendBucket)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotBackendBucket)
+	}
+	observed, err := e.BackendBuckets.Get(e.projectID, meta.GetExternalName(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetBackendBucket)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	backendbucket.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+	lateInitialized := !cmp.Equal(currentSpec, &cr.Spec.ForProvider)
+
+	cr.Status.AtProvider = backendbucket.GenerateObservation(*observed)
+	cr.Status.SetConditions(xpv1.Available())
+
+	u, err := backendbucket.IsUpToDate(meta.GetExternalName(cr), &cr.Spec.ForProvider, observed)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errCheckBackendBucketUpToDate)
+	}
+	add, remove := backendbucket.DiffSignedURLKeys(cr.Spec.ForProvider.SignedURLKeys, observed)
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: lateInitialized,
+		ResourceUpToDate:        u && len(add) == 0 && len(remove) == 0,
+	}, nil
+}
+
+func (e *backendBucketExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.BackendBucket)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotBackendBucket)
+	}
+	cr.SetConditions(xpv1.Creating())
+
+	// Signed URL keys are added once the backend bucket exists.
+	bb := &compute.BackendBucket{}
+	backendbucket.GenerateBackendBucket(meta.GetExternalName(cr), cr.Spec.ForProvider, bb)
+	op, err := e.BackendBuckets.Insert(e.projectID, bb).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateBackendBucket)
+}
+
+func (e *backendBucketExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.BackendBucket)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotBackendBucket)
+	}
+
+	observed, err := e.BackendBuckets.Get(e.projectID, meta.GetExternalName(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetBackendBucket)
+	}
+
+	// Changes to a backend bucket are made one operation at a time, so if
+	// there are multiple changes the next one is made after the current one
+	// is observed.
+	u, err := backendbucket.IsUpToDate(meta.GetExternalName(cr), &cr.Spec.ForProvider, observed)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errCheckBackendBucketUpToDate)
+	}
+	if !u {
+		bb := &compute.BackendBucket{}
+		backendbucket.GenerateBackendBucket(meta.GetExternalName(cr), cr.Spec.ForProvider, bb)
+		op, err := e.BackendBuckets.Patch(e.projectID, meta.GetExternalName(cr), bb).Context(ctx).Do()
+		gcp.SetOperationWarnings(cr, op)
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateBackendBucket)
+	}
+
+	// A rotated key is deleted before its replacement is added.
+	add, remove := backendbucket.DiffSignedURLKeys(cr.Spec.ForProvider.SignedURLKeys, observed)
+	if len(remove) > 0 {
+		op, err := e.BackendBuckets.DeleteSignedUrlKey(e.projectID, meta.GetExternalName(cr), remove[0]).Context(ctx).Do()
+		gcp.SetOperationWarnings(cr, op)
+		return managed.ExternalUpdate{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteSignedURLKey)
+	}
+	if len(add) > 0 {
+		value, err := e.signedURLKeyValue(ctx, add[0].KeyValueSecretRef)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		op, err := e.BackendBuckets.AddSignedUrlKey(e.projectID, meta.GetExternalName(cr),
+			&compute.SignedUrlKey{KeyName: add[0].KeyName, KeyValue: value}).Context(ctx).Do()
+		gcp.SetOperationWarnings(cr, op)
+		return managed.ExternalUpdate{}, errors.Wrap(err, errAddSignedURLKey)
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *backendBucketExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.BackendBucket)
+	if !ok {
+		return errors.New(errNotBackendBucket)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+	op, err := e.BackendBuckets.Delete(e.projectID, meta.GetExternalName(cr)).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteBackendBucket)
+}
+
+func (e *backendBucketExternal) signedURLKeyValue(ctx context.Context, ref xpv1.SecretKeySelector) (string, error) {
+	s := &v1.Secret{}
+	if err := e.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return "", errors.Wrap(err, errGetSignedURLKeySecret)
+	}
+	return string(s.Data[ref.Key]), nil
+}
@@ -0,0 +1,342 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testBackendBucket = "static-assets"
+	testBucketName    = "my-bucket"
+	testKeyValue      = "c2lnbmVkLXVybC1rZXk="
+)
+
+var _ managed.ExternalConnecter = &backendBucketConnector{}
+var _ managed.ExternalClient = &backendBucketExternal{}
+
+type bbModifier func(*v1alpha1.BackendBucket)
+
+func bbWithConditions(c ...xpv1.Condition) bbModifier {
+	return func(i *v1alpha1.BackendBucket) { i.Status.SetConditions(c...) }
+}
+
+func bbWithSignedURLKeys(n ...string) bbModifier {
+	return func(i *v1alpha1.BackendBucket) {
+		for _, name := range n {
+			i.Spec.ForProvider.SignedURLKeys = append(i.Spec.ForProvider.SignedURLKeys, v1alpha1.SignedURLKey{
+				KeyName: name,
+				KeyValueSecretRef: xpv1.SecretKeySelector{
+					SecretReference: xpv1.SecretReference{Name: name, Namespace: "default"},
+					Key:             "key",
+				},
+			})
+		}
+	}
+}
+
+func bbWithActiveKeys(n ...string) bbModifier {
+	return func(i *v1alpha1.BackendBucket) { i.Status.AtProvider.SignedURLKeyNames = n }
+}
+
+func bbObj(im ...bbModifier) *v1alpha1.BackendBucket {
+	i := &v1alpha1.BackendBucket{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testBackendBucket,
+			Annotations: map[string]string{
+				meta.AnnotationKeyExternalName: testBackendBucket,
+			},
+		},
+		Spec: v1alpha1.BackendBucketSpec{
+			ForProvider: v1alpha1.BackendBucketParameters{
+				BucketName:  gcp.StringPtr(testBucketName),
+				Description: gcp.StringPtr(""),
+				EnableCDN:   gcp.BoolPtr(true),
+				CDNPolicy:   &v1alpha1.BackendBucketCDNPolicy{},
+			},
+		},
+	}
+
+	for _, m := range im {
+		m(i)
+	}
+
+	return i
+}
+
+func observedBackendBucket(keys ...string) *compute.BackendBucket {
+	return &compute.BackendBucket{
+		Name:       testBackendBucket,
+		BucketName: testBucketName,
+		EnableCdn:  true,
+		CdnPolicy:  &compute.BackendBucketCdnPolicy{SignedUrlKeyNames: keys},
+	}
+}
+
+func TestBackendBucketObserve(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		observed *compute.BackendBucket
+		args     args
+		want     want
+	}{
+		"NotBackendBucket": {
+			args: args{
+				mg: &v1alpha1.Firewall{},
+			},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotBackendBucket),
+			},
+		},
+		"NotFound": {
+			args: args{
+				mg: bbObj(),
+			},
+			want: want{
+				mg: bbObj(),
+			},
+		},
+		"UpToDate": {
+			observed: observedBackendBucket("key-1"),
+			args: args{
+				mg: bbObj(bbWithSignedURLKeys("key-1")),
+			},
+			want: want{
+				mg:  bbObj(bbWithSignedURLKeys("key-1"), bbWithActiveKeys("key-1"), bbWithConditions(xpv1.Available())),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"SignedURLKeyMissing": {
+			observed: observedBackendBucket(),
+			args: args{
+				mg: bbObj(bbWithSignedURLKeys("key-1")),
+			},
+			want: want{
+				mg:  bbObj(bbWithSignedURLKeys("key-1"), bbWithConditions(xpv1.Available())),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"SignedURLKeyUndesired": {
+			observed: observedBackendBucket("key-1"),
+			args: args{
+				mg: bbObj(),
+			},
+			want: want{
+				mg:  bbObj(bbWithActiveKeys("key-1"), bbWithConditions(xpv1.Available())),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if tc.observed == nil {
+					w.WriteHeader(http.StatusNotFound)
+					_ = json.NewEncoder(w).Encode(&compute.BackendBucket{})
+					return
+				}
+				_ = json.NewEncoder(w).Encode(tc.observed)
+			}))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := backendBucketExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			obs, err := e.Observe(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBackendBucketUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		kube client.Client
+		mg   resource.Managed
+	}
+	type want struct {
+		added   *compute.SignedUrlKey
+		deleted string
+		patched bool
+		err     error
+	}
+
+	cases := map[string]struct {
+		observed *compute.BackendBucket
+		args     args
+		want     want
+	}{
+		"NotBackendBucket": {
+			args: args{
+				mg: &v1alpha1.Firewall{},
+			},
+			want: want{
+				err: errors.New(errNotBackendBucket),
+			},
+		},
+		"PatchBackendBucket": {
+			observed: &compute.BackendBucket{Name: testBackendBucket, BucketName: testBucketName},
+			args: args{
+				mg: bbObj(bbWithSignedURLKeys("key-1")),
+			},
+			want: want{
+				patched: true,
+			},
+		},
+		"AddSignedURLKey": {
+			observed: observedBackendBucket(),
+			args: args{
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						if diff := cmp.Diff("key-1", key.Name); diff != "" {
+							t.Errorf("r: -want, +got:\n%s", diff)
+						}
+						obj.(*v1.Secret).Data = map[string][]byte{"key": []byte(testKeyValue)}
+						return nil
+					},
+				},
+				mg: bbObj(bbWithSignedURLKeys("key-1")),
+			},
+			want: want{
+				added: &compute.SignedUrlKey{KeyName: "key-1", KeyValue: testKeyValue},
+			},
+		},
+		"AddSignedURLKeySecretFailed": {
+			observed: observedBackendBucket(),
+			args: args{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+				mg: bbObj(bbWithSignedURLKeys("key-1")),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errGetSignedURLKeySecret),
+			},
+		},
+		"DeleteSignedURLKey": {
+			observed: observedBackendBucket("key-1", "key-2"),
+			args: args{
+				mg: bbObj(bbWithSignedURLKeys("key-2")),
+			},
+			want: want{
+				deleted: "key-1",
+			},
+		},
+		"RotateSignedURLKey": {
+			observed: observedBackendBucket("key-1"),
+			args: args{
+				mg: bbObj(bbWithSignedURLKeys("key-2")),
+			},
+			want: want{
+				deleted: "key-1",
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var added *compute.SignedUrlKey
+			var deleted string
+			var patched bool
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := ioutil.ReadAll(r.Body)
+				_ = r.Body.Close()
+				action := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+				switch {
+				case r.Method == http.MethodGet:
+					_ = json.NewEncoder(w).Encode(tc.observed)
+					return
+				case r.Method == http.MethodPatch:
+					patched = true
+				case action == "addSignedUrlKey":
+					added = &compute.SignedUrlKey{}
+					_ = json.Unmarshal(b, added)
+				case action == "deleteSignedUrlKey":
+					deleted = r.URL.Query().Get("keyName")
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := backendBucketExternal{
+				kube:      tc.args.kube,
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Update(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.added, added); diff != "" {
+				t.Errorf("Update(...): -want added key, +got added key:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("Update(...): -want deleted key, +got deleted key:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.patched, patched); diff != "" {
+				t.Errorf("Update(...): -want patched, +got patched:\n%s", diff)
+			}
+		})
+	}
+}
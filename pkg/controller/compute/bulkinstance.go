@@ -0,0 +1,206 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/api/compute/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/bulkinstance"
+)
+
+// Error strings.
+const (
+	errNotBulkInstance    = "managed resource is not a BulkInstance"
+	errListInstances      = "cannot list GCP instances"
+	errGetBulkOperation   = "cannot get GCP bulk insert operation"
+	errBulkInsertFailed   = "GCP bulk insert operation failed"
+	errBulkInsertInstance = "cannot bulk insert GCP instances"
+	errDeleteInstance     = "cannot delete GCP instance"
+)
+
+// SetupBulkInstance adds a controller that reconciles BulkInstance managed
+// resources.
+func SetupBulkInstance(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.BulkInstanceGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.BulkInstance{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.BulkInstanceGroupVersionKind),
+			managed.WithExternalConnecter(&biConnector{kube: mgr.GetClient()}),
+			managed.WithConnectionPublishers(),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type biConnector struct {
+	kube client.Client
+}
+
+func (c *biConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := compute.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &biExternal{Service: s, projectID: projectID}, nil
+}
+
+type biExternal struct {
+	projectID string
+	*compute.Service
+}
+
+func (e *biExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) { // nolint:gocyclo
+	cr, ok := mg.(*v1alpha1.BulkInstance)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotBulkInstance)
+	}
+
+	pending := false
+	if name := cr.Status.AtProvider.Operation; name != "" {
+		op, err := e.ZoneOperations.Get(e.projectID, cr.Spec.ForProvider.Zone, name).Context(ctx).Do()
+		if resource.Ignore(gcp.IsErrorNotFound, err) != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetBulkOperation)
+		}
+		pending = err == nil && op.Status != gcp.OperationDone
+		if !pending {
+			cr.Status.AtProvider.Operation = ""
+		}
+		if err == nil && !pending && gcp.OperationError(op) != "" {
+			return managed.ExternalObservation{}, errors.Wrap(errors.New(gcp.OperationError(op)), errBulkInsertFailed)
+		}
+	}
+
+	var instances []*compute.Instance
+	err := e.Instances.List(e.projectID, cr.Spec.ForProvider.Zone).
+		Filter(bulkinstance.Filter(cr.Spec.ForProvider.NamePattern)).
+		Pages(ctx, func(l *compute.InstanceList) error {
+			instances = append(instances, l.Items...)
+			return nil
+		})
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errListInstances)
+	}
+	names := bulkinstance.InstanceNames(cr.Spec.ForProvider.NamePattern, instances)
+
+	cr.Status.AtProvider.Instances = names
+	cr.Status.AtProvider.CreatedCount = int64(len(names))
+	cr.Status.AtProvider.DesiredCount = cr.Spec.ForProvider.Count
+
+	// NOTE: The set of instances exists as long as it is not deleted, even
+	// when it is empty. It is gone once deleted and no instances remain.
+	if meta.WasDeleted(cr) && len(names) == 0 && !pending {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	switch {
+	case pending:
+		cr.SetConditions(xpv1.Creating())
+	case cr.Status.AtProvider.CreatedCount == cr.Spec.ForProvider.Count:
+		cr.SetConditions(xpv1.Available())
+	default:
+		cr.SetConditions(xpv1.Unavailable())
+	}
+
+	// An in flight bulk insert must finish before the instances can be
+	// scaled again.
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: pending || cr.Status.AtProvider.CreatedCount == cr.Spec.ForProvider.Count,
+	}, nil
+}
+
+func (e *biExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	// The instances are created when the set is scaled up.
+	_, err := e.Update(ctx, mg)
+	return managed.ExternalCreation{}, err
+}
+
+func (e *biExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.BulkInstance)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotBulkInstance)
+	}
+	if cr.Status.AtProvider.Operation != "" {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	if count := cr.Spec.ForProvider.Count - cr.Status.AtProvider.CreatedCount; count > 0 {
+		op, err := e.Instances.BulkInsert(e.projectID, cr.Spec.ForProvider.Zone,
+			bulkinstance.GenerateBulkInsert(cr.Spec.ForProvider, count)).Context(ctx).Do()
+		gcp.SetOperationWarnings(cr, op)
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errBulkInsertInstance)
+		}
+		cr.Status.AtProvider.Operation = op.Name
+		return managed.ExternalUpdate{}, nil
+	}
+
+	for _, name := range bulkinstance.ScaleDown(cr.Status.AtProvider.Instances, cr.Spec.ForProvider.Count) {
+		op, err := e.Instances.Delete(e.projectID, cr.Spec.ForProvider.Zone, name).Context(ctx).Do()
+		gcp.SetOperationWarnings(cr, op)
+		if resource.Ignore(gcp.IsErrorNotFound, err) != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errDeleteInstance)
+		}
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *biExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.BulkInstance)
+	if !ok {
+		return errors.New(errNotBulkInstance)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+	for _, name := range cr.Status.AtProvider.Instances {
+		op, err := e.Instances.Delete(e.projectID, cr.Spec.ForProvider.Zone, name).Context(ctx).Do()
+		gcp.SetOperationWarnings(cr, op)
+		if resource.Ignore(gcp.IsErrorNotFound, err) != nil {
+			return errors.Wrap(err, errDeleteInstance)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,442 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+)
+
+const (
+	testNamePattern      = "worker-###"
+	testInstanceTemplate = "global/instanceTemplates/worker"
+	testOperation        = "operation-bulk-insert"
+)
+
+var _ managed.ExternalConnecter = &biConnector{}
+var _ managed.ExternalClient = &biExternal{}
+
+type biModifier func(*v1alpha1.BulkInstance)
+
+func biWithConditions(c ...xpv1.Condition) biModifier {
+	return func(i *v1alpha1.BulkInstance) { i.Status.SetConditions(c...) }
+}
+
+func biWithCount(c int64) biModifier {
+	return func(i *v1alpha1.BulkInstance) { i.Spec.ForProvider.Count = c }
+}
+
+func biWithInstances(n ...string) biModifier {
+	return func(i *v1alpha1.BulkInstance) {
+		i.Status.AtProvider.Instances = n
+		i.Status.AtProvider.CreatedCount = int64(len(n))
+	}
+}
+
+func biWithDesiredCount(c int64) biModifier {
+	return func(i *v1alpha1.BulkInstance) { i.Status.AtProvider.DesiredCount = c }
+}
+
+func biWithOperation(op string) biModifier {
+	return func(i *v1alpha1.BulkInstance) { i.Status.AtProvider.Operation = op }
+}
+
+func biWithDeletionTimestamp(t metav1.Time) biModifier {
+	return func(i *v1alpha1.BulkInstance) { i.SetDeletionTimestamp(&t) }
+}
+
+func biObj(im ...biModifier) *v1alpha1.BulkInstance {
+	i := &v1alpha1.BulkInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "workers",
+		},
+		Spec: v1alpha1.BulkInstanceSpec{
+			ForProvider: v1alpha1.BulkInstanceParameters{
+				Zone:                   testZone,
+				SourceInstanceTemplate: testInstanceTemplate,
+				NamePattern:            testNamePattern,
+				Count:                  2,
+			},
+		},
+	}
+
+	for _, m := range im {
+		m(i)
+	}
+
+	return i
+}
+
+func instancesHandler(t *testing.T, op *compute.Operation, names ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_ = r.Body.Close()
+		if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+			t.Errorf("r: -want, +got:\n%s", diff)
+		}
+		if strings.Contains(r.URL.Path, "/operations/") {
+			if op == nil {
+				w.WriteHeader(http.StatusNotFound)
+			}
+			_ = json.NewEncoder(w).Encode(op)
+			return
+		}
+		l := &compute.InstanceList{}
+		for _, n := range names {
+			l.Items = append(l.Items, &compute.Instance{Name: n})
+		}
+		_ = json.NewEncoder(w).Encode(l)
+	}
+}
+
+func TestBulkInstanceObserve(t *testing.T) {
+	deleted := metav1.Now()
+
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"NotBulkInstance": {
+			handler: nil,
+			args: args{
+				mg: &v1alpha1.Firewall{},
+			},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotBulkInstance),
+			},
+		},
+		"ListFailed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.InstanceList{})
+			}),
+			args: args{
+				mg: biObj(),
+			},
+			want: want{
+				mg:  biObj(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errListInstances),
+			},
+		},
+		"UpToDate": {
+			handler: instancesHandler(t, nil, "worker-001", "unrelated", "worker-002"),
+			args: args{
+				mg: biObj(),
+			},
+			want: want{
+				mg: biObj(
+					biWithInstances("worker-001", "worker-002"),
+					biWithDesiredCount(2),
+					biWithConditions(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"NeedsScaleUp": {
+			handler: instancesHandler(t, nil, "worker-001"),
+			args: args{
+				mg: biObj(),
+			},
+			want: want{
+				mg: biObj(
+					biWithInstances("worker-001"),
+					biWithDesiredCount(2),
+					biWithConditions(xpv1.Unavailable()),
+				),
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"BulkInsertPending": {
+			handler: instancesHandler(t, &compute.Operation{Name: testOperation, Status: "RUNNING"}, "worker-001"),
+			args: args{
+				mg: biObj(biWithOperation(testOperation)),
+			},
+			want: want{
+				mg: biObj(
+					biWithOperation(testOperation),
+					biWithInstances("worker-001"),
+					biWithDesiredCount(2),
+					biWithConditions(xpv1.Creating()),
+				),
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"BulkInsertDone": {
+			handler: instancesHandler(t, &compute.Operation{Name: testOperation, Status: "DONE"}, "worker-001", "worker-002"),
+			args: args{
+				mg: biObj(biWithOperation(testOperation)),
+			},
+			want: want{
+				mg: biObj(
+					biWithInstances("worker-001", "worker-002"),
+					biWithDesiredCount(2),
+					biWithConditions(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"BulkInsertFailed": {
+			handler: instancesHandler(t, &compute.Operation{
+				Name:   testOperation,
+				Status: "DONE",
+				Error: &compute.OperationError{Errors: []*compute.OperationErrorErrors{
+					{Message: "quota exceeded"},
+				}},
+			}),
+			args: args{
+				mg: biObj(biWithOperation(testOperation)),
+			},
+			want: want{
+				mg:  biObj(),
+				err: errors.Wrap(errors.New("quota exceeded"), errBulkInsertFailed),
+			},
+		},
+		"DeletedAndGone": {
+			handler: instancesHandler(t, nil),
+			args: args{
+				mg: biObj(biWithDeletionTimestamp(deleted)),
+			},
+			want: want{
+				mg: biObj(
+					biWithDeletionTimestamp(deleted),
+					biWithDesiredCount(2),
+				),
+				obs: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := biExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			obs, err := e.Observe(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBulkInstanceUpdate(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg      resource.Managed
+		insert  *compute.BulkInsertInstanceResource
+		deleted []string
+		err     error
+	}
+
+	cases := map[string]struct {
+		failOn string
+		args   args
+		want   want
+	}{
+		"NotBulkInstance": {
+			args: args{
+				mg: &v1alpha1.Firewall{},
+			},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotBulkInstance),
+			},
+		},
+		"ScaleUp": {
+			args: args{
+				mg: biObj(biWithInstances("worker-001")),
+			},
+			want: want{
+				mg: biObj(biWithInstances("worker-001"), biWithOperation(testOperation)),
+				insert: &compute.BulkInsertInstanceResource{
+					Count:                  1,
+					NamePattern:            testNamePattern,
+					SourceInstanceTemplate: testInstanceTemplate,
+				},
+			},
+		},
+		"ScaleUpFailed": {
+			failOn: "bulkInsert",
+			args: args{
+				mg: biObj(),
+			},
+			want: want{
+				mg: biObj(),
+				insert: &compute.BulkInsertInstanceResource{
+					Count:                  2,
+					NamePattern:            testNamePattern,
+					SourceInstanceTemplate: testInstanceTemplate,
+				},
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errBulkInsertInstance),
+			},
+		},
+		"ScaleDown": {
+			args: args{
+				mg: biObj(biWithCount(1), biWithInstances("worker-001", "worker-002", "worker-003")),
+			},
+			want: want{
+				mg:      biObj(biWithCount(1), biWithInstances("worker-001", "worker-002", "worker-003")),
+				deleted: []string{"worker-002", "worker-003"},
+			},
+		},
+		"ScaleDownFailed": {
+			failOn: "worker-002",
+			args: args{
+				mg: biObj(biWithCount(1), biWithInstances("worker-001", "worker-002")),
+			},
+			want: want{
+				mg:      biObj(biWithCount(1), biWithInstances("worker-001", "worker-002")),
+				deleted: []string{"worker-002"},
+				err:     errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteInstance),
+			},
+		},
+		"BulkInsertPending": {
+			args: args{
+				mg: biObj(biWithOperation(testOperation)),
+			},
+			want: want{
+				mg: biObj(biWithOperation(testOperation)),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var insert *compute.BulkInsertInstanceResource
+			var deleted []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := ioutil.ReadAll(r.Body)
+				_ = r.Body.Close()
+				action := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+				switch r.Method {
+				case http.MethodPost:
+					insert = &compute.BulkInsertInstanceResource{}
+					_ = json.Unmarshal(b, insert)
+				case http.MethodDelete:
+					deleted = append(deleted, action)
+				}
+				if action == tc.failOn {
+					w.WriteHeader(http.StatusBadRequest)
+					_ = json.NewEncoder(w).Encode(&compute.Operation{})
+					return
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{Name: testOperation})
+			}))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := biExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Update(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.insert, insert); diff != "" {
+				t.Errorf("Update(...): -want bulk insert, +got bulk insert:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("Update(...): -want deleted instances, +got deleted instances:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Update(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBulkInstanceDelete(t *testing.T) {
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.Body.Close()
+		if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+			t.Errorf("r: -want, +got:\n%s", diff)
+		}
+		name := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		deleted = append(deleted, name)
+		if name == "worker-001" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		_ = json.NewEncoder(w).Encode(&compute.Operation{})
+	}))
+	defer server.Close()
+	s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	e := biExternal{
+		projectID: projectID,
+		Service:   s,
+	}
+	err := e.Delete(context.Background(), biObj(biWithInstances("worker-001", "worker-002")))
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"worker-001", "worker-002"}, deleted); diff != "" {
+		t.Errorf("Delete(...): -want deleted instances, +got deleted instances:\n%s", diff)
+	}
+}
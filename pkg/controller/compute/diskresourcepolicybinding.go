@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/api/compute/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/resourcepolicy"
+)
+
+// Error strings.
+const (
+	errNotDiskResourcePolicyBinding = "managed resource is not a DiskResourcePolicyBinding"
+	errGetDisk                      = "cannot get GCP disk"
+	errAddResourcePolicies          = "cannot add resource policies to GCP disk"
+	errRemoveResourcePolicies       = "cannot remove resource policies from GCP disk"
+)
+
+// SetupDiskResourcePolicyBinding adds a controller that reconciles
+// DiskResourcePolicyBinding managed resources.
+func SetupDiskResourcePolicyBinding(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.DiskResourcePolicyBindingGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.DiskResourcePolicyBinding{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.DiskResourcePolicyBindingGroupVersionKind),
+			managed.WithExternalConnecter(&drpbConnector{kube: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithConnectionPublishers(),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type drpbConnector struct {
+	kube client.Client
+}
+
+func (c *drpbConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := compute.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &drpbExternal{Service: s, projectID: projectID}, nil
+}
+
+type drpbExternal struct {
+	projectID string
+	*compute.Service
+}
+
+func (e *drpbExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.DiskResourcePolicyBinding)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotDiskResourcePolicyBinding)
+	}
+	disk, err := e.Disks.Get(e.projectID, cr.Spec.ForProvider.Zone, cr.Spec.ForProvider.Disk).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetDisk)
+	}
+
+	cr.Status.AtProvider.ResourcePolicies = disk.ResourcePolicies
+
+	// NOTE: The binding exists as long as the disk does. It is considered
+	// gone once deleted and none of our resource policies remain attached.
+	if meta.WasDeleted(cr) && len(resourcepolicy.AttachedPolicies(cr.Spec.ForProvider.ResourcePolicies, disk.ResourcePolicies)) == 0 {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.SetConditions(xpv1.Available())
+
+	add, remove := resourcepolicy.BindingDelta(cr.Spec.ForProvider.ResourcePolicies, disk.ResourcePolicies)
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: len(add) == 0 && len(remove) == 0,
+	}, nil
+}
+
+func (e *drpbExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	// The disk is never created, only its resource policies updated.
+	_, err := e.Update(ctx, mg)
+	return managed.ExternalCreation{}, err
+}
+
+func (e *drpbExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.DiskResourcePolicyBinding)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotDiskResourcePolicyBinding)
+	}
+	disk, err := e.Disks.Get(e.projectID, cr.Spec.ForProvider.Zone, cr.Spec.ForProvider.Disk).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetDisk)
+	}
+
+	add, remove := resourcepolicy.BindingDelta(cr.Spec.ForProvider.ResourcePolicies, disk.ResourcePolicies)
+	if len(remove) != 0 {
+		op, err := e.Disks.RemoveResourcePolicies(e.projectID, cr.Spec.ForProvider.Zone, cr.Spec.ForProvider.Disk,
+			&compute.DisksRemoveResourcePoliciesRequest{ResourcePolicies: remove}).Context(ctx).Do()
+		gcp.SetOperationWarnings(cr, op)
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errRemoveResourcePolicies)
+		}
+	}
+	if len(add) != 0 {
+		op, err := e.Disks.AddResourcePolicies(e.projectID, cr.Spec.ForProvider.Zone, cr.Spec.ForProvider.Disk,
+			&compute.DisksAddResourcePoliciesRequest{ResourcePolicies: add}).Context(ctx).Do()
+		gcp.SetOperationWarnings(cr, op)
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errAddResourcePolicies)
+		}
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *drpbExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.DiskResourcePolicyBinding)
+	if !ok {
+		return errors.New(errNotDiskResourcePolicyBinding)
+	}
+	disk, err := e.Disks.Get(e.projectID, cr.Spec.ForProvider.Zone, cr.Spec.ForProvider.Disk).Context(ctx).Do()
+	if err != nil {
+		return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetDisk)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+	attached := resourcepolicy.AttachedPolicies(cr.Spec.ForProvider.ResourcePolicies, disk.ResourcePolicies)
+	if len(attached) == 0 {
+		return nil
+	}
+	op, err := e.Disks.RemoveResourcePolicies(e.projectID, cr.Spec.ForProvider.Zone, cr.Spec.ForProvider.Disk,
+		&compute.DisksRemoveResourcePoliciesRequest{ResourcePolicies: attached}).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errRemoveResourcePolicies)
+}
@@ -0,0 +1,391 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+)
+
+const (
+	testDiskName = "cool-disk"
+	testZone     = "us-central1-a"
+
+	testDailyPolicy  = "projects/cool-project/regions/us-central1/resourcePolicies/daily"
+	testWeeklyPolicy = "projects/cool-project/regions/us-central1/resourcePolicies/weekly"
+)
+
+var _ managed.ExternalConnecter = &drpbConnector{}
+var _ managed.ExternalClient = &drpbExternal{}
+
+type drpbModifier func(*v1alpha1.DiskResourcePolicyBinding)
+
+func drpbWithConditions(c ...xpv1.Condition) drpbModifier {
+	return func(i *v1alpha1.DiskResourcePolicyBinding) { i.Status.SetConditions(c...) }
+}
+
+func drpbWithObservedPolicies(p ...string) drpbModifier {
+	return func(i *v1alpha1.DiskResourcePolicyBinding) { i.Status.AtProvider.ResourcePolicies = p }
+}
+
+func drpbWithDeletionTimestamp(t metav1.Time) drpbModifier {
+	return func(i *v1alpha1.DiskResourcePolicyBinding) { i.SetDeletionTimestamp(&t) }
+}
+
+func drpbObj(im ...drpbModifier) *v1alpha1.DiskResourcePolicyBinding {
+	i := &v1alpha1.DiskResourcePolicyBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: testDiskName,
+		},
+		Spec: v1alpha1.DiskResourcePolicyBindingSpec{
+			ForProvider: v1alpha1.DiskResourcePolicyBindingParameters{
+				Disk:             testDiskName,
+				Zone:             testZone,
+				ResourcePolicies: []string{testWeeklyPolicy},
+			},
+		},
+	}
+
+	for _, m := range im {
+		m(i)
+	}
+
+	return i
+}
+
+func fullyQualified(p string) string {
+	return "https://www.googleapis.com/compute/v1/" + p
+}
+
+func diskHandler(t *testing.T, policies ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_ = r.Body.Close()
+		if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+			t.Errorf("r: -want, +got:\n%s", diff)
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(&compute.Disk{Name: testDiskName, ResourcePolicies: policies})
+	}
+}
+
+func TestDiskResourcePolicyBindingObserve(t *testing.T) {
+	deleted := metav1.Now()
+
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"NotDiskResourcePolicyBinding": {
+			handler: nil,
+			args: args{
+				mg: &v1alpha1.Firewall{},
+			},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotDiskResourcePolicyBinding),
+			},
+		},
+		"DiskNotFound": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&compute.Disk{})
+			}),
+			args: args{
+				mg: drpbObj(),
+			},
+			want: want{
+				mg: drpbObj(),
+			},
+		},
+		"GetFailed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Disk{})
+			}),
+			args: args{
+				mg: drpbObj(),
+			},
+			want: want{
+				mg:  drpbObj(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetDisk),
+			},
+		},
+		"UpToDate": {
+			handler: diskHandler(t, fullyQualified(testWeeklyPolicy)),
+			args: args{
+				mg: drpbObj(),
+			},
+			want: want{
+				mg: drpbObj(
+					drpbWithObservedPolicies(fullyQualified(testWeeklyPolicy)),
+					drpbWithConditions(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"NotUpToDate": {
+			handler: diskHandler(t, fullyQualified(testDailyPolicy)),
+			args: args{
+				mg: drpbObj(),
+			},
+			want: want{
+				mg: drpbObj(
+					drpbWithObservedPolicies(fullyQualified(testDailyPolicy)),
+					drpbWithConditions(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"DeletedAndDetached": {
+			handler: diskHandler(t, fullyQualified(testDailyPolicy)),
+			args: args{
+				mg: drpbObj(drpbWithDeletionTimestamp(deleted)),
+			},
+			want: want{
+				mg: drpbObj(
+					drpbWithDeletionTimestamp(deleted),
+					drpbWithObservedPolicies(fullyQualified(testDailyPolicy)),
+				),
+				obs: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := drpbExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			obs, err := e.Observe(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiskResourcePolicyBindingUpdate(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		add    []string
+		remove []string
+		err    error
+	}
+
+	cases := map[string]struct {
+		observed []string
+		failOn   string
+		args     args
+		want     want
+	}{
+		"NotDiskResourcePolicyBinding": {
+			args: args{
+				mg: &v1alpha1.Firewall{},
+			},
+			want: want{
+				err: errors.New(errNotDiskResourcePolicyBinding),
+			},
+		},
+		"AddAndRemove": {
+			observed: []string{fullyQualified(testDailyPolicy)},
+			args: args{
+				mg: drpbObj(),
+			},
+			want: want{
+				add:    []string{testWeeklyPolicy},
+				remove: []string{fullyQualified(testDailyPolicy)},
+			},
+		},
+		"OnlyAdd": {
+			args: args{
+				mg: drpbObj(),
+			},
+			want: want{
+				add: []string{testWeeklyPolicy},
+			},
+		},
+		"RemoveFailed": {
+			observed: []string{fullyQualified(testDailyPolicy)},
+			failOn:   "removeResourcePolicies",
+			args: args{
+				mg: drpbObj(),
+			},
+			want: want{
+				remove: []string{fullyQualified(testDailyPolicy)},
+				err:    errors.Wrap(gError(http.StatusBadRequest, ""), errRemoveResourcePolicies),
+			},
+		},
+		"AddFailed": {
+			failOn: "addResourcePolicies",
+			args: args{
+				mg: drpbObj(),
+			},
+			want: want{
+				add: []string{testWeeklyPolicy},
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errAddResourcePolicies),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var add, remove []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					diskHandler(t, tc.observed...)(w, r)
+					return
+				}
+				req := &compute.DisksAddResourcePoliciesRequest{}
+				b, _ := ioutil.ReadAll(r.Body)
+				_ = r.Body.Close()
+				_ = json.Unmarshal(b, req)
+				action := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+				switch action {
+				case "addResourcePolicies":
+					add = req.ResourcePolicies
+				case "removeResourcePolicies":
+					remove = req.ResourcePolicies
+				}
+				if action == tc.failOn {
+					w.WriteHeader(http.StatusBadRequest)
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := drpbExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Update(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.add, add); diff != "" {
+				t.Errorf("Update(...): -want added policies, +got added policies:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.remove, remove); diff != "" {
+				t.Errorf("Update(...): -want removed policies, +got removed policies:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiskResourcePolicyBindingDelete(t *testing.T) {
+	type want struct {
+		remove []string
+		err    error
+	}
+
+	cases := map[string]struct {
+		observed []string
+		want     want
+	}{
+		"RemovesOnlyOurPolicies": {
+			observed: []string{fullyQualified(testDailyPolicy), fullyQualified(testWeeklyPolicy)},
+			want: want{
+				remove: []string{fullyQualified(testWeeklyPolicy)},
+			},
+		},
+		"AlreadyDetached": {
+			observed: []string{fullyQualified(testDailyPolicy)},
+			want:     want{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var remove []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					diskHandler(t, tc.observed...)(w, r)
+					return
+				}
+				if diff := cmp.Diff("removeResourcePolicies", r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				req := &compute.DisksRemoveResourcePoliciesRequest{}
+				b, _ := ioutil.ReadAll(r.Body)
+				_ = r.Body.Close()
+				_ = json.Unmarshal(b, req)
+				remove = req.ResourcePolicies
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := drpbExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			err := e.Delete(context.Background(), drpbObj())
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.remove, remove); diff != "" {
+				t.Errorf("Delete(...): -want removed policies, +got removed policies:\n%s", diff)
+			}
+		})
+	}
+}
@@ -54,7 +54,7 @@ const (
 
 // SetupFirewall adds a controller that reconciles Firewall managed
 // resources.
-func SetupFirewall(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupFirewall(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
 	name := managed.ControllerName(v1alpha1.FirewallGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -68,7 +68,9 @@ func SetupFirewall(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter,
 			managed.WithExternalConnecter(&firewallConnector{kube: mgr.GetClient()}),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
 			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
 
@@ -135,9 +137,10 @@ func (c *firewallExternal) Create(ctx context.Context, mg resource.Managed) (man
 
 	fw := &compute.Firewall{}
 	firewall.GenerateFirewall(meta.GetExternalName(cr), cr.Spec.ForProvider, fw)
-	_, err := c.Firewalls.Insert(c.projectID, fw).
+	op, err := c.Firewalls.Insert(c.projectID, fw).
 		Context(ctx).
 		Do()
+	gcp.SetOperationWarnings(cr, op)
 	return managed.ExternalCreation{}, errors.Wrap(err, errFirewallCreateFailed)
 }
 
@@ -163,9 +166,10 @@ func (c *firewallExternal) Update(ctx context.Context, mg resource.Managed) (man
 	fw := &compute.Firewall{}
 	firewall.GenerateFirewall(meta.GetExternalName(cr), cr.Spec.ForProvider, fw)
 
-	_, err = c.Firewalls.Patch(c.projectID, meta.GetExternalName(cr), fw).
+	op, err := c.Firewalls.Patch(c.projectID, meta.GetExternalName(cr), fw).
 		Context(ctx).
 		Do()
+	gcp.SetOperationWarnings(cr, op)
 	return managed.ExternalUpdate{}, errors.Wrap(err, errFirewallUpdateFailed)
 }
 
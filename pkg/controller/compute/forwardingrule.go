@@ -0,0 +1,243 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/forwardingrule"
+)
+
+// Error strings.
+const (
+	errNotForwardingRule          = "managed resource is not a ForwardingRule"
+	errGetForwardingRule          = "cannot get external ForwardingRule resource"
+	errGetForwardingRuleOperation = "cannot get GCP forwarding rule operation"
+	errForwardingRuleOpFailed     = "GCP forwarding rule operation failed"
+	errCreateForwardingRule       = "cannot create external ForwardingRule resource"
+	errUpdateForwardingRule       = "cannot update external ForwardingRule resource"
+	errDeleteForwardingRule       = "cannot delete external ForwardingRule resource"
+	errManagedForwardingRule      = "cannot update managed ForwardingRule resource"
+	errInvalidForwardingRule      = "invalid ForwardingRule"
+	errGetBackendService          = "cannot get the backend service of the ForwardingRule"
+)
+
+// SetupForwardingRule adds a controller that reconciles ForwardingRule managed
+// resources.
+func SetupForwardingRule(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.ForwardingRuleGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.ForwardingRule{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.ForwardingRuleGroupVersionKind),
+			managed.WithExternalConnecter(&frConnector{kube: mgr.GetClient()}),
+			managed.WithConnectionPublishers(),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type frConnector struct {
+	kube client.Client
+}
+
+func (c *frConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := compute.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &frExternal{kube: c.kube, Service: s, projectID: projectID}, nil
+}
+
+type frExternal struct {
+	kube      client.Client
+	projectID string
+	*compute.Service
+}
+
+func (e *frExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) { // nolint:gocyclo
+	cr, ok := mg.(*v1alpha1.ForwardingRule)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotForwardingRule)
+	}
+
+	// The annotation holds the pending insert, patch or set target
+	// operation.
+	pending := false
+	if name := cr.GetAnnotations()[gcp.AnnotationKeyOperation]; name != "" {
+		op, err := e.RegionOperations.Get(e.projectID, cr.Spec.ForProvider.Region, name).Context(ctx).Do()
+		if resource.Ignore(gcp.IsErrorNotFound, err) != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetForwardingRuleOperation)
+		}
+		pending = err == nil && op.Status != gcp.OperationDone
+		if !pending {
+			meta.RemoveAnnotations(cr, gcp.AnnotationKeyOperation)
+			if err := e.kube.Update(ctx, cr); err != nil {
+				return managed.ExternalObservation{}, errors.Wrap(err, errManagedForwardingRule)
+			}
+		}
+		if err == nil && !pending && gcp.OperationError(op) != "" {
+			return managed.ExternalObservation{}, errors.Wrap(errors.New(gcp.OperationError(op)), errForwardingRuleOpFailed)
+		}
+	}
+
+	observed, err := e.ForwardingRules.Get(e.projectID, cr.Spec.ForProvider.Region, meta.GetExternalName(cr)).Context(ctx).Do()
+	if gcp.IsErrorNotFound(err) && pending {
+		// The forwarding rule exists once its insert operation is done.
+		cr.SetConditions(xpv1.Creating())
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetForwardingRule)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	forwardingrule.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := e.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errManagedForwardingRule)
+		}
+	}
+
+	cr.Status.AtProvider = forwardingrule.GenerateForwardingRuleObservation(*observed)
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists: true,
+		// A pending operation is observed before changing the forwarding
+		// rule again.
+		ResourceUpToDate: pending || forwardingrule.IsUpToDate(cr.Spec.ForProvider, *observed),
+	}, nil
+}
+
+func (e *frExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.ForwardingRule)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotForwardingRule)
+	}
+	if err := e.validate(ctx, cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+	fr := &compute.ForwardingRule{}
+	forwardingrule.GenerateForwardingRule(meta.GetExternalName(cr), cr.Spec.ForProvider, fr)
+	op, err := e.ForwardingRules.Insert(e.projectID, cr.Spec.ForProvider.Region, fr).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateForwardingRule)
+	}
+	meta.AddAnnotations(cr, map[string]string{gcp.AnnotationKeyOperation: op.Name})
+	return managed.ExternalCreation{}, nil
+}
+
+// validate returns an error if the supplied parameters are inconsistent, or
+// inconsistent with the backend service they forward to.
+func (e *frExternal) validate(ctx context.Context, in v1alpha1.ForwardingRuleParameters) error {
+	if err := forwardingrule.Validate(in); err != nil {
+		return errors.Wrap(err, errInvalidForwardingRule)
+	}
+	if in.BackendService == nil {
+		return nil
+	}
+	project, region, name, err := forwardingrule.ParseBackendService(*in.BackendService, e.projectID, in.Region)
+	if err != nil {
+		return errors.Wrap(err, errInvalidForwardingRule)
+	}
+	bs, err := e.RegionBackendServices.Get(project, region, name).Context(ctx).Do()
+	if err != nil {
+		return errors.Wrap(err, errGetBackendService)
+	}
+	return errors.Wrap(forwardingrule.CheckBackendService(in, *bs), errInvalidForwardingRule)
+}
+
+func (e *frExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.ForwardingRule)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotForwardingRule)
+	}
+	if err := forwardingrule.Validate(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errInvalidForwardingRule)
+	}
+
+	observed, err := e.ForwardingRules.Get(e.projectID, cr.Spec.ForProvider.Region, meta.GetExternalName(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetForwardingRule)
+	}
+
+	// Global access and the target are changed by different calls, so one
+	// is changed at a time and the other once its operation is done.
+	var op *compute.Operation
+	if !forwardingrule.IsGlobalAccessUpToDate(cr.Spec.ForProvider, *observed) {
+		patch := forwardingrule.GenerateGlobalAccessPatch(cr.Spec.ForProvider)
+		op, err = e.ForwardingRules.Patch(e.projectID, cr.Spec.ForProvider.Region, meta.GetExternalName(cr), patch).Context(ctx).Do()
+	} else {
+		ref := &compute.TargetReference{Target: gcp.StringValue(cr.Spec.ForProvider.Target)}
+		op, err = e.ForwardingRules.SetTarget(e.projectID, cr.Spec.ForProvider.Region, meta.GetExternalName(cr), ref).Context(ctx).Do()
+	}
+	gcp.SetOperationWarnings(cr, op)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateForwardingRule)
+	}
+
+	// Annotations are not persisted after an update, so the pending
+	// operation is recorded explicitly.
+	meta.AddAnnotations(cr, map[string]string{gcp.AnnotationKeyOperation: op.Name})
+	return managed.ExternalUpdate{}, errors.Wrap(e.kube.Update(ctx, cr), errManagedForwardingRule)
+}
+
+func (e *frExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.ForwardingRule)
+	if !ok {
+		return errors.New(errNotForwardingRule)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+	op, err := e.ForwardingRules.Delete(e.projectID, cr.Spec.ForProvider.Region, meta.GetExternalName(cr)).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteForwardingRule)
+}
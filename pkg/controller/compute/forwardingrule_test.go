@@ -0,0 +1,486 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testForwardingRuleName = "ilb"
+	testForwardingRuleOp   = "operation-forwarding-rule"
+	testFRBackendService   = "regions/us-central1/backendServices/ilb"
+	testFRAddress          = "10.0.0.10"
+	testFRTarget           = "zones/us-central1-a/targetInstances/ilb"
+)
+
+var _ managed.ExternalConnecter = &frConnector{}
+var _ managed.ExternalClient = &frExternal{}
+
+type frModifier func(*v1alpha1.ForwardingRule)
+
+func frWithConditions(c ...xpv1.Condition) frModifier {
+	return func(f *v1alpha1.ForwardingRule) { f.Status.SetConditions(c...) }
+}
+
+func frWithOperation(op string) frModifier {
+	return func(f *v1alpha1.ForwardingRule) {
+		meta.AddAnnotations(f, map[string]string{gcp.AnnotationKeyOperation: op})
+	}
+}
+
+func frWithLateInit() frModifier {
+	return func(f *v1alpha1.ForwardingRule) {
+		f.Spec.ForProvider.IPAddress = gcp.StringPtr(testFRAddress)
+		f.Spec.ForProvider.IPProtocol = gcp.StringPtr("TCP")
+		if f.Spec.ForProvider.AllowGlobalAccess == nil {
+			f.Spec.ForProvider.AllowGlobalAccess = gcp.BoolPtr(false)
+		}
+	}
+}
+
+func frWithGlobalAccess(a bool) frModifier {
+	return func(f *v1alpha1.ForwardingRule) { f.Spec.ForProvider.AllowGlobalAccess = &a }
+}
+
+func frWithPorts(p ...string) frModifier {
+	return func(f *v1alpha1.ForwardingRule) {
+		f.Spec.ForProvider.AllPorts = nil
+		f.Spec.ForProvider.Ports = p
+	}
+}
+
+func frWithTarget(t string) frModifier {
+	return func(f *v1alpha1.ForwardingRule) {
+		f.Spec.ForProvider.LoadBalancingScheme = "EXTERNAL"
+		f.Spec.ForProvider.AllPorts = nil
+		f.Spec.ForProvider.BackendService = nil
+		f.Spec.ForProvider.Target = &t
+	}
+}
+
+func frObj(m ...frModifier) *v1alpha1.ForwardingRule {
+	f := &v1alpha1.ForwardingRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        testForwardingRuleName,
+			Annotations: map[string]string{meta.AnnotationKeyExternalName: testForwardingRuleName},
+		},
+		Spec: v1alpha1.ForwardingRuleSpec{
+			ForProvider: v1alpha1.ForwardingRuleParameters{
+				Region:              testRegion,
+				LoadBalancingScheme: "INTERNAL",
+				AllPorts:            gcp.BoolPtr(true),
+				BackendService:      gcp.StringPtr(testFRBackendService),
+			},
+		},
+	}
+
+	for _, mod := range m {
+		mod(f)
+	}
+
+	return f
+}
+
+func frObserved(m ...func(*compute.ForwardingRule)) *compute.ForwardingRule {
+	fr := &compute.ForwardingRule{
+		Name:                testForwardingRuleName,
+		LoadBalancingScheme: "INTERNAL",
+		IPAddress:           testFRAddress,
+		IPProtocol:          "TCP",
+		AllPorts:            true,
+		BackendService:      testFRBackendService,
+	}
+	for _, f := range m {
+		f(fr)
+	}
+	return fr
+}
+
+// forwardingRuleHandler serves the supplied operation, forwarding rule and
+// backend service, and records the body of any other request.
+func forwardingRuleHandler(op *compute.Operation, fr *compute.ForwardingRule, bs *compute.BackendService, got *map[string]interface{}, fail bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		_ = r.Body.Close()
+		switch {
+		case r.Method != http.MethodGet:
+			_ = json.Unmarshal(b, got)
+			if fail {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(&compute.Operation{Name: testForwardingRuleOp})
+		case strings.Contains(r.URL.Path, "/operations/"):
+			if op == nil {
+				w.WriteHeader(http.StatusNotFound)
+			}
+			_ = json.NewEncoder(w).Encode(op)
+		case strings.Contains(r.URL.Path, "/backendServices/"):
+			if bs == nil {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&compute.BackendService{})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(bs)
+		default:
+			if fr == nil {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&compute.ForwardingRule{})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(fr)
+		}
+	}
+}
+
+func TestForwardingRuleObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		op   *compute.Operation
+		fr   *compute.ForwardingRule
+		mg   resource.Managed
+		want want
+	}{
+		"NotForwardingRule": {
+			mg: &v1alpha1.Firewall{},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotForwardingRule),
+			},
+		},
+		"NotFound": {
+			mg: frObj(),
+			want: want{
+				mg:  frObj(),
+				obs: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Available": {
+			fr: frObserved(),
+			mg: frObj(),
+			want: want{
+				mg:  frObj(frWithLateInit(), frWithConditions(xpv1.Available())),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"GlobalAccessChanged": {
+			fr: frObserved(),
+			mg: frObj(frWithGlobalAccess(true)),
+			want: want{
+				mg:  frObj(frWithGlobalAccess(true), frWithLateInit(), frWithConditions(xpv1.Available())),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"TargetChanged": {
+			fr: frObserved(func(fr *compute.ForwardingRule) {
+				fr.LoadBalancingScheme = "EXTERNAL"
+				fr.AllPorts = false
+				fr.BackendService = ""
+				fr.Target = "zones/us-central1-a/targetInstances/old"
+			}),
+			mg: frObj(frWithTarget(testFRTarget)),
+			want: want{
+				mg:  frObj(frWithTarget(testFRTarget), frWithLateInit(), frWithConditions(xpv1.Available())),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"InsertPending": {
+			op: &compute.Operation{Name: testForwardingRuleOp, Status: "RUNNING"},
+			mg: frObj(frWithOperation(testForwardingRuleOp)),
+			want: want{
+				mg:  frObj(frWithOperation(testForwardingRuleOp), frWithConditions(xpv1.Creating())),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(forwardingRuleHandler(tc.op, tc.fr, nil, nil, false))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := frExternal{
+				kube:      &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				projectID: projectID,
+				Service:   s,
+			}
+			obs, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestForwardingRuleCreate(t *testing.T) {
+	internal := &compute.BackendService{
+		Name:                "ilb",
+		LoadBalancingScheme: "INTERNAL",
+		Protocol:            "TCP",
+		Region:              "https://www.googleapis.com/compute/v1/projects/" + projectID + "/regions/" + testRegion,
+	}
+
+	type want struct {
+		mg     resource.Managed
+		insert map[string]interface{}
+		err    error
+	}
+
+	cases := map[string]struct {
+		bs   *compute.BackendService
+		fail bool
+		mg   resource.Managed
+		want want
+	}{
+		"NotForwardingRule": {
+			mg: &v1alpha1.Firewall{},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotForwardingRule),
+			},
+		},
+		"Created": {
+			bs: internal,
+			mg: frObj(frWithPorts("80", "443")),
+			want: want{
+				mg: frObj(frWithPorts("80", "443"), frWithOperation(testForwardingRuleOp), frWithConditions(xpv1.Creating())),
+				insert: map[string]interface{}{
+					"name":                testForwardingRuleName,
+					"loadBalancingScheme": "INTERNAL",
+					"ports":               []interface{}{"80", "443"},
+					"backendService":      testFRBackendService,
+				},
+			},
+		},
+		"Invalid": {
+			mg: frObj(frWithGlobalAccess(true), frWithTarget(testFRTarget)),
+			want: want{
+				mg:  frObj(frWithGlobalAccess(true), frWithTarget(testFRTarget)),
+				err: errors.Wrap(errors.New("only INTERNAL forwarding rules allow global access, not EXTERNAL ones"), errInvalidForwardingRule),
+			},
+		},
+		"SchemeMismatch": {
+			bs: &compute.BackendService{Name: "ilb", LoadBalancingScheme: "EXTERNAL"},
+			mg: frObj(),
+			want: want{
+				mg:  frObj(),
+				err: errors.Wrap(errors.New("load balancing scheme INTERNAL of the forwarding rule does not match scheme EXTERNAL of backend service ilb"), errInvalidForwardingRule),
+			},
+		},
+		"BackendServiceNotFound": {
+			mg: frObj(),
+			want: want{
+				mg:  frObj(),
+				err: errors.Wrap(gError(http.StatusNotFound, ""), errGetBackendService),
+			},
+		},
+		"CreateFailed": {
+			bs:   internal,
+			fail: true,
+			mg:   frObj(),
+			want: want{
+				mg: frObj(frWithConditions(xpv1.Creating())),
+				insert: map[string]interface{}{
+					"name":                testForwardingRuleName,
+					"loadBalancingScheme": "INTERNAL",
+					"allPorts":            true,
+					"backendService":      testFRBackendService,
+				},
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateForwardingRule),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var insert map[string]interface{}
+			server := httptest.NewServer(forwardingRuleHandler(nil, nil, tc.bs, &insert, tc.fail))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := frExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.insert, insert); diff != "" {
+				t.Errorf("Create(...): -want insert, +got insert:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Create(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestForwardingRuleUpdate(t *testing.T) {
+	type want struct {
+		mg   resource.Managed
+		body map[string]interface{}
+		err  error
+	}
+
+	cases := map[string]struct {
+		fr   *compute.ForwardingRule
+		fail bool
+		mg   resource.Managed
+		want want
+	}{
+		"NotForwardingRule": {
+			mg: &v1alpha1.Firewall{},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotForwardingRule),
+			},
+		},
+		"GlobalAccessDisallowed": {
+			fr: frObserved(func(fr *compute.ForwardingRule) { fr.AllowGlobalAccess = true }),
+			mg: frObj(frWithGlobalAccess(false)),
+			want: want{
+				mg: frObj(frWithGlobalAccess(false), frWithOperation(testForwardingRuleOp)),
+				// Disallowing global access must be sent explicitly.
+				body: map[string]interface{}{"allowGlobalAccess": false},
+			},
+		},
+		"TargetChanged": {
+			fr: frObserved(func(fr *compute.ForwardingRule) { fr.Target = "zones/us-central1-a/targetInstances/old" }),
+			mg: frObj(frWithTarget(testFRTarget)),
+			want: want{
+				mg:   frObj(frWithTarget(testFRTarget), frWithOperation(testForwardingRuleOp)),
+				body: map[string]interface{}{"target": testFRTarget},
+			},
+		},
+		"UpdateFailed": {
+			fr:   frObserved(),
+			fail: true,
+			mg:   frObj(frWithGlobalAccess(true)),
+			want: want{
+				mg:   frObj(frWithGlobalAccess(true)),
+				body: map[string]interface{}{"allowGlobalAccess": true},
+				err:  errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateForwardingRule),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var body map[string]interface{}
+			server := httptest.NewServer(forwardingRuleHandler(nil, tc.fr, nil, &body, tc.fail))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := frExternal{
+				kube:      &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.body, body); diff != "" {
+				t.Errorf("Update(...): -want body, +got body:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Update(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestForwardingRuleDelete(t *testing.T) {
+	cases := map[string]struct {
+		status int
+		mg     resource.Managed
+		want   error
+	}{
+		"NotForwardingRule": {
+			mg:   &v1alpha1.Firewall{},
+			want: errors.New(errNotForwardingRule),
+		},
+		"Deleted": {
+			status: http.StatusOK,
+			mg:     frObj(),
+		},
+		"AlreadyGone": {
+			status: http.StatusNotFound,
+			mg:     frObj(),
+		},
+		"DeleteFailed": {
+			status: http.StatusBadRequest,
+			mg:     frObj(),
+			want:   errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteForwardingRule),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(tc.status)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := frExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
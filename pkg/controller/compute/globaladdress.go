@@ -18,6 +18,7 @@ package compute
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
@@ -48,11 +49,12 @@ const (
 	errCreateAddress        = "cannot create external Address resource"
 	errDeleteAddress        = "cannot delete external Address resource"
 	errManagedAddressUpdate = "cannot update managed GlobalAddress resource"
+	errFmtImmutableAddress  = "cannot change immutable fields of an existing global address (%s): delete and recreate the GlobalAddress instead"
 )
 
 // SetupGlobalAddress adds a controller that reconciles
 // GlobalAddress managed resources.
-func SetupGlobalAddress(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupGlobalAddress(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
 	name := managed.ControllerName(v1beta1.GlobalAddressGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -66,7 +68,9 @@ func SetupGlobalAddress(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLim
 			managed.WithExternalConnecter(&gaConnector{kube: mgr.GetClient()}),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithConnectionPublishers(),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
 			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
@@ -103,7 +107,8 @@ func (e *gaExternal) Observe(ctx context.Context, mg resource.Managed) (managed.
 		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetAddress)
 	}
 
-	// Global addresses are always "up to date" because they can't be updated. ¯\_(ツ)_/¯
+	// Global addresses can't be updated, so they are "up to date" unless a
+	// field that was set at creation time no longer matches the spec.
 	eo := managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}
 
 	currentSpec := cr.Spec.ForProvider.DeepCopy()
@@ -114,6 +119,11 @@ func (e *gaExternal) Observe(ctx context.Context, mg resource.Managed) (managed.
 		}
 	}
 
+	if diff := globaladdress.ImmutableDiff(cr.Spec.ForProvider, *observed); len(diff) > 0 {
+		eo.ResourceUpToDate = false
+		eo.Diff = strings.Join(diff, ", ")
+	}
+
 	cr.Status.AtProvider = globaladdress.GenerateGlobalAddressObservation(*observed)
 
 	switch cr.Status.AtProvider.Status {
@@ -135,12 +145,26 @@ func (e *gaExternal) Create(ctx context.Context, mg resource.Managed) (managed.E
 	cr.Status.SetConditions(xpv1.Creating())
 	address := &compute.Address{}
 	globaladdress.GenerateGlobalAddress(meta.GetExternalName(cr), cr.Spec.ForProvider, address)
-	_, err := e.GlobalAddresses.Insert(e.projectID, address).Context(ctx).Do()
+	op, err := e.GlobalAddresses.Insert(e.projectID, address).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
 	return managed.ExternalCreation{}, errors.Wrap(err, errCreateAddress)
 }
 
-func (e *gaExternal) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
-	// Global addresses cannot be updated.
+func (e *gaExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1beta1.GlobalAddress)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotGlobalAddress)
+	}
+
+	// Global addresses cannot be updated. Report the fields that differ so
+	// that they can be reverted, or the address recreated.
+	observed, err := e.GlobalAddresses.Get(e.projectID, meta.GetExternalName(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetAddress)
+	}
+	if diff := globaladdress.ImmutableDiff(cr.Spec.ForProvider, *observed); len(diff) > 0 {
+		return managed.ExternalUpdate{}, errors.Errorf(errFmtImmutableAddress, strings.Join(diff, ", "))
+	}
 	return managed.ExternalUpdate{}, nil
 }
 
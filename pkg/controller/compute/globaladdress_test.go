@@ -62,6 +62,14 @@ func addressWithDescription(d string) addressModifier {
 	return func(i *v1beta1.GlobalAddress) { i.Spec.ForProvider.Description = &d }
 }
 
+func addressWithIPVersion(v string) addressModifier {
+	return func(i *v1beta1.GlobalAddress) { i.Spec.ForProvider.IPVersion = &v }
+}
+
+func addressWithNetworkTier(tier string) addressModifier {
+	return func(i *v1beta1.GlobalAddress) { i.Spec.ForProvider.NetworkTier = &tier }
+}
+
 func addressWithStatus(status string) addressModifier {
 	return func(i *v1beta1.GlobalAddress) { i.Status.AtProvider.Status = status }
 }
@@ -233,6 +241,35 @@ func TestGlobalAddressObserve(t *testing.T) {
 				),
 			},
 		},
+		"NetworkTierChanged": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				c := &compute.Address{}
+				globaladdress.GenerateGlobalAddress(testGAName, addressObj().Spec.ForProvider, c)
+				c.NetworkTier = "PREMIUM"
+				c.Status = v1beta1.StatusReserved
+				_ = json.NewEncoder(w).Encode(c)
+			}),
+			args: args{
+				mg: addressObj(addressWithNetworkTier("STANDARD")),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+					Diff:             "networkTier: STANDARD != PREMIUM",
+				},
+				mg: addressObj(
+					addressWithNetworkTier("STANDARD"),
+					addressWithConditions(xpv1.Available()),
+					addressWithStatus(v1beta1.StatusReserved),
+				),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -313,6 +350,41 @@ func TestGlobalAddressCreate(t *testing.T) {
 				err: nil,
 			},
 		},
+		"SuccessfulIPv6": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				i := &compute.Address{}
+				b, err := ioutil.ReadAll(r.Body)
+				if diff := cmp.Diff(err, nil); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				err = json.Unmarshal(b, i)
+				if diff := cmp.Diff(err, nil); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				want := &compute.Address{Name: testGAName, IpVersion: "IPV6", NetworkTier: "PREMIUM"}
+				if diff := cmp.Diff(want, i); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = r.Body.Close()
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: addressObj(addressWithIPVersion("IPV6"), addressWithNetworkTier("PREMIUM")),
+			},
+			want: want{
+				mg: addressObj(
+					addressWithIPVersion("IPV6"),
+					addressWithNetworkTier("PREMIUM"),
+					addressWithConditions(xpv1.Creating()),
+				),
+				cre: managed.ExternalCreation{},
+				err: nil,
+			},
+		},
 		"AlreadyExists": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				_ = r.Body.Close()
@@ -485,13 +557,67 @@ func TestGlobalAddressUpdate(t *testing.T) {
 		args    args
 		want    want
 	}{
-		"Noop": {
+		"NotGlobalAddress": {
 			handler: nil,
-			args:    args{},
+			args: args{
+				mg: &v1beta1.Subnetwork{},
+			},
+			want: want{
+				mg:  &v1beta1.Subnetwork{},
+				err: errors.New(errNotGlobalAddress),
+			},
+		},
+		"Noop": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&compute.Address{NetworkTier: "STANDARD"})
+			}),
+			args: args{
+				mg: addressObj(addressWithNetworkTier("STANDARD")),
+			},
 			want: want{
+				mg:  addressObj(addressWithNetworkTier("STANDARD")),
 				upd: managed.ExternalUpdate{},
 			},
 		},
+		"GetFailed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Address{})
+			}),
+			args: args{
+				mg: addressObj(),
+			},
+			want: want{
+				mg:  addressObj(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetAddress),
+			},
+		},
+		"ImmutableFieldChanged": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&compute.Address{IpVersion: "IPV4", NetworkTier: "PREMIUM"})
+			}),
+			args: args{
+				mg: addressObj(addressWithIPVersion("IPV6"), addressWithNetworkTier("STANDARD")),
+			},
+			want: want{
+				mg:  addressObj(addressWithIPVersion("IPV6"), addressWithNetworkTier("STANDARD")),
+				err: errors.Errorf(errFmtImmutableAddress, "ipVersion: IPV6 != IPV4, networkTier: STANDARD != PREMIUM"),
+			},
+		},
 	}
 
 	for name, tc := range cases {
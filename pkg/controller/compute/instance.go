@@ -0,0 +1,308 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/instance"
+)
+
+// Error strings.
+const (
+	errNotInstance          = "managed resource is not an Instance"
+	errGetInstance          = "cannot get external Instance resource"
+	errGetInstanceOperation = "cannot get GCP instance operation"
+	errInstanceOpFailed     = "GCP instance operation failed"
+	errCreateInstance       = "cannot create external Instance resource"
+	errUpdateInstance       = "cannot update external Instance resource"
+	errStopInstance         = "cannot stop external Instance resource"
+	errStartInstance        = "cannot start external Instance resource"
+	errManagedInstance      = "cannot update managed Instance resource"
+	errInvalidInstance      = "invalid Instance"
+	errStopForUpdate        = "the desired changes can only be applied to a stopped instance; set allowStopForUpdate to true to stop it"
+	errFmtImmutableInstance = "cannot change immutable fields of an existing instance (%s): delete and recreate the Instance instead"
+)
+
+// SetupInstance adds a controller that reconciles Instance managed resources.
+func SetupInstance(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.InstanceGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.Instance{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.InstanceGroupVersionKind),
+			managed.WithExternalConnecter(&instConnector{kube: mgr.GetClient()}),
+			managed.WithConnectionPublishers(),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type instConnector struct {
+	kube client.Client
+}
+
+func (c *instConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := compute.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &instExternal{kube: c.kube, Service: s, projectID: projectID}, nil
+}
+
+type instExternal struct {
+	kube      client.Client
+	projectID string
+	*compute.Service
+}
+
+func (e *instExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) { // nolint:gocyclo
+	cr, ok := mg.(*v1alpha1.Instance)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotInstance)
+	}
+
+	// The annotation holds the pending insert, stop, start or update
+	// operation.
+	pending := false
+	if name := cr.GetAnnotations()[gcp.AnnotationKeyOperation]; name != "" {
+		op, err := e.ZoneOperations.Get(e.projectID, cr.Spec.ForProvider.Zone, name).Context(ctx).Do()
+		if resource.Ignore(gcp.IsErrorNotFound, err) != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetInstanceOperation)
+		}
+		pending = err == nil && op.Status != gcp.OperationDone
+		if !pending {
+			meta.RemoveAnnotations(cr, gcp.AnnotationKeyOperation)
+			if err := e.kube.Update(ctx, cr); err != nil {
+				return managed.ExternalObservation{}, errors.Wrap(err, errManagedInstance)
+			}
+		}
+		if err == nil && !pending && gcp.OperationError(op) != "" {
+			return managed.ExternalObservation{}, errors.Wrap(errors.New(gcp.OperationError(op)), errInstanceOpFailed)
+		}
+	}
+
+	observed, err := e.Instances.Get(e.projectID, cr.Spec.ForProvider.Zone, meta.GetExternalName(cr)).Context(ctx).Do()
+	if gcp.IsErrorNotFound(err) && pending {
+		// The instance exists once its insert operation is done.
+		cr.SetConditions(xpv1.Creating())
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetInstance)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	instance.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := e.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errManagedInstance)
+		}
+	}
+
+	cr.Status.AtProvider = instance.GenerateInstanceObservation(*observed)
+	switch cr.Status.AtProvider.Status {
+	case instance.StatusRunning:
+		cr.SetConditions(xpv1.Available())
+	case "PROVISIONING", "STAGING":
+		cr.SetConditions(xpv1.Creating())
+	default:
+		cr.SetConditions(xpv1.Unavailable())
+	}
+
+	// An instance that was stopped to be updated is not up to date until it
+	// is started again.
+	stopped := cr.GetAnnotations()[instance.AnnotationKeyStoppedForUpdate] != ""
+	return managed.ExternalObservation{
+		ResourceExists: true,
+		// A pending operation is observed before changing the instance
+		// again.
+		ResourceUpToDate: pending || (!stopped && instance.IsUpToDate(cr.Spec.ForProvider, *observed)),
+	}, nil
+}
+
+func (e *instExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Instance)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotInstance)
+	}
+	if err := instance.Validate(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errInvalidInstance)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+	i := &compute.Instance{}
+	instance.GenerateInstance(meta.GetExternalName(cr), cr.Spec.ForProvider, i)
+	op, err := e.Instances.Insert(e.projectID, cr.Spec.ForProvider.Zone, i).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateInstance)
+	}
+	meta.AddAnnotations(cr, map[string]string{gcp.AnnotationKeyOperation: op.Name})
+	return managed.ExternalCreation{}, nil
+}
+
+// Update applies one change to the instance at a time and records its
+// operation, which is observed before applying the next one. Changes that
+// require the instance to be stopped stop it first and start it again once
+// all changes are applied.
+func (e *instExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Instance)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotInstance)
+	}
+	if err := instance.Validate(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errInvalidInstance)
+	}
+
+	name := meta.GetExternalName(cr)
+	zone := cr.Spec.ForProvider.Zone
+	observed, err := e.Instances.Get(e.projectID, zone, name).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetInstance)
+	}
+
+	// An instance is not stopped for changes that can not be applied.
+	if diff := instance.ImmutableDiff(cr.Spec.ForProvider, *observed); len(diff) > 0 {
+		return managed.ExternalUpdate{}, errors.Errorf(errFmtImmutableInstance, strings.Join(diff, ", "))
+	}
+
+	var op *compute.Operation
+	switch {
+	case instance.RequiresStop(cr.Spec.ForProvider, *observed) && observed.Status != instance.StatusTerminated:
+		if !gcp.BoolValue(cr.Spec.ForProvider.AllowStopForUpdate) {
+			return managed.ExternalUpdate{}, errors.New(errStopForUpdate)
+		}
+		op, err = e.Instances.Stop(e.projectID, zone, name).Context(ctx).Do()
+		if err != nil {
+			err = errors.Wrap(err, errStopInstance)
+			break
+		}
+		meta.AddAnnotations(cr, map[string]string{instance.AnnotationKeyStoppedForUpdate: "true"})
+	case instance.UpdatesAcceleratorsFirst(cr.Spec.ForProvider, *observed):
+		op, err = e.setAccelerators(ctx, cr)
+	case !instance.IsSchedulingUpToDate(cr.Spec.ForProvider, *observed):
+		op, err = e.Instances.SetScheduling(e.projectID, zone, name, instance.GenerateScheduling(cr.Spec.ForProvider.Scheduling)).Context(ctx).Do()
+		err = errors.Wrap(err, errUpdateInstance)
+	case !instance.AreAcceleratorsUpToDate(cr.Spec.ForProvider, *observed):
+		op, err = e.setAccelerators(ctx, cr)
+	case !instance.IsShieldedInstanceConfigUpToDate(cr.Spec.ForProvider, *observed):
+		op, err = e.Instances.UpdateShieldedInstanceConfig(e.projectID, zone, name, instance.GenerateShieldedInstanceConfig(cr.Spec.ForProvider.ShieldedInstanceConfig)).Context(ctx).Do()
+		err = errors.Wrap(err, errUpdateInstance)
+	case len(instance.AutoDeleteDiff(cr.Spec.ForProvider, *observed)) > 0:
+		// Disks are updated one at a time, as each update is an operation.
+		d := instance.AutoDeleteDiff(cr.Spec.ForProvider, *observed)[0]
+		op, err = e.Instances.SetDiskAutoDelete(e.projectID, zone, name, d.AutoDelete, d.DeviceName).Context(ctx).Do()
+		err = errors.Wrap(err, errUpdateInstance)
+	case cr.GetAnnotations()[instance.AnnotationKeyStoppedForUpdate] != "":
+		// All changes are applied, so an instance stopped to apply them
+		// is started again.
+		op, err = e.Instances.Start(e.projectID, zone, name).Context(ctx).Do()
+		if err != nil {
+			err = errors.Wrap(err, errStartInstance)
+			break
+		}
+		meta.RemoveAnnotations(cr, instance.AnnotationKeyStoppedForUpdate)
+	default:
+		return managed.ExternalUpdate{}, nil
+	}
+	gcp.SetOperationWarnings(cr, op)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// Annotations are not persisted after an update, so the pending
+	// operation is recorded explicitly.
+	meta.AddAnnotations(cr, map[string]string{gcp.AnnotationKeyOperation: op.Name})
+	return managed.ExternalUpdate{}, errors.Wrap(e.kube.Update(ctx, cr), errManagedInstance)
+}
+
+// setAccelerators sets the accelerators of the supplied stopped instance.
+func (e *instExternal) setAccelerators(ctx context.Context, cr *v1alpha1.Instance) (*compute.Operation, error) {
+	rb := &compute.InstancesSetMachineResourcesRequest{
+		GuestAccelerators: instance.GenerateAccelerators(cr.Spec.ForProvider.Zone, cr.Spec.ForProvider.GuestAccelerators),
+		// An empty list removes all accelerators.
+		ForceSendFields: []string{"GuestAccelerators"},
+	}
+	op, err := e.Instances.SetMachineResources(e.projectID, cr.Spec.ForProvider.Zone, meta.GetExternalName(cr), rb).Context(ctx).Do()
+	return op, errors.Wrap(err, errUpdateInstance)
+}
+
+func (e *instExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Instance)
+	if !ok {
+		return errors.New(errNotInstance)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+	if cr.GetAnnotations()[gcp.AnnotationKeyOperation] != "" {
+		// The instance is deleted once its pending operation is done, e.g.
+		// the one that keeps a disk from being deleted along with it.
+		return nil
+	}
+
+	name := meta.GetExternalName(cr)
+	zone := cr.Spec.ForProvider.Zone
+	observed, err := e.Instances.Get(e.projectID, zone, name).Context(ctx).Do()
+	if err != nil {
+		return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetInstance)
+	}
+	if diff := instance.PreservedDisksDiff(cr.Spec.ForProvider, *observed); len(diff) > 0 {
+		op, err := e.Instances.SetDiskAutoDelete(e.projectID, zone, name, false, diff[0].DeviceName).Context(ctx).Do()
+		gcp.SetOperationWarnings(cr, op)
+		if err != nil {
+			return errors.Wrap(err, errUpdateInstance)
+		}
+		meta.AddAnnotations(cr, map[string]string{gcp.AnnotationKeyOperation: op.Name})
+		return errors.Wrap(e.kube.Update(ctx, cr), errManagedInstance)
+	}
+
+	op, err := e.Instances.Delete(e.projectID, zone, name).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteInstance)
+}
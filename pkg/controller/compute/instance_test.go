@@ -0,0 +1,905 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/instance"
+)
+
+const (
+	testInstanceName        = "vm"
+	testInstanceOp          = "operation-instance"
+	testInstanceMachineType = "zones/us-central1-a/machineTypes/e2-medium"
+	testInstanceImage       = "projects/debian-cloud/global/images/family/debian-11"
+	testInstanceGPU         = "nvidia-tesla-t4"
+	testInstanceDisk        = "persistent-disk-0"
+)
+
+var _ managed.ExternalConnecter = &instConnector{}
+var _ managed.ExternalClient = &instExternal{}
+
+type instModifier func(*v1alpha1.Instance)
+
+func instWithConditions(c ...xpv1.Condition) instModifier {
+	return func(i *v1alpha1.Instance) { i.Status.SetConditions(c...) }
+}
+
+func instWithOperation(op string) instModifier {
+	return func(i *v1alpha1.Instance) {
+		meta.AddAnnotations(i, map[string]string{gcp.AnnotationKeyOperation: op})
+	}
+}
+
+func instWithStoppedForUpdate() instModifier {
+	return func(i *v1alpha1.Instance) {
+		meta.AddAnnotations(i, map[string]string{instance.AnnotationKeyStoppedForUpdate: "true"})
+	}
+}
+
+func instWithScheduling(preemptible, restart bool, maintenance string) instModifier {
+	return func(i *v1alpha1.Instance) {
+		i.Spec.ForProvider.Scheduling = &v1alpha1.Scheduling{
+			Preemptible:       gcp.BoolPtr(preemptible),
+			AutomaticRestart:  gcp.BoolPtr(restart),
+			OnHostMaintenance: gcp.StringPtr(maintenance),
+		}
+	}
+}
+
+func instWithAllowStopForUpdate() instModifier {
+	return func(i *v1alpha1.Instance) { i.Spec.ForProvider.AllowStopForUpdate = gcp.BoolPtr(true) }
+}
+
+func instWithGPU() instModifier {
+	return func(i *v1alpha1.Instance) {
+		i.Spec.ForProvider.GuestAccelerators = []v1alpha1.AcceleratorConfig{{AcceleratorType: testInstanceGPU, AcceleratorCount: 1}}
+	}
+}
+
+func instWithShielded(secureBoot, integrityMonitoring bool) instModifier {
+	return func(i *v1alpha1.Instance) {
+		i.Spec.ForProvider.ShieldedInstanceConfig = &v1alpha1.ShieldedInstanceConfig{
+			EnableSecureBoot:          gcp.BoolPtr(secureBoot),
+			EnableVtpm:                gcp.BoolPtr(true),
+			EnableIntegrityMonitoring: gcp.BoolPtr(integrityMonitoring),
+		}
+	}
+}
+
+func instWithConfidential() instModifier {
+	return func(i *v1alpha1.Instance) {
+		i.Spec.ForProvider.MachineType = "zones/" + testZone + "/machineTypes/n2d-standard-2"
+		i.Spec.ForProvider.ConfidentialInstanceConfig = &v1alpha1.ConfidentialInstanceConfig{EnableConfidentialCompute: true}
+	}
+}
+
+func instShielded(i *compute.Instance) {
+	i.ShieldedInstanceConfig = &compute.ShieldedInstanceConfig{EnableVtpm: true, EnableIntegrityMonitoring: true}
+}
+
+func instWithAutoDelete(autoDelete bool) instModifier {
+	return func(i *v1alpha1.Instance) { i.Spec.ForProvider.Disks[0].AutoDelete = gcp.BoolPtr(autoDelete) }
+}
+
+func instWithDisk(autoDelete bool) func(*compute.Instance) {
+	return func(i *compute.Instance) {
+		i.Disks = []*compute.AttachedDisk{{Boot: true, DeviceName: testInstanceDisk, AutoDelete: autoDelete}}
+	}
+}
+
+func instWithStatus(s string) instModifier {
+	return func(i *v1alpha1.Instance) { i.Status.AtProvider.Status = s }
+}
+
+func instObj(m ...instModifier) *v1alpha1.Instance {
+	i := &v1alpha1.Instance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        testInstanceName,
+			Annotations: map[string]string{meta.AnnotationKeyExternalName: testInstanceName},
+		},
+		Spec: v1alpha1.InstanceSpec{
+			ForProvider: v1alpha1.InstanceParameters{
+				Zone:        testZone,
+				MachineType: testInstanceMachineType,
+				Disks: []v1alpha1.AttachedDisk{{
+					Boot:             gcp.BoolPtr(true),
+					InitializeParams: &v1alpha1.AttachedDiskInitializeParams{SourceImage: gcp.StringPtr(testInstanceImage)},
+				}},
+				NetworkInterfaces: []v1alpha1.NetworkInterface{{Network: gcp.StringPtr("global/networks/default")}},
+			},
+		},
+	}
+
+	for _, f := range m {
+		f(i)
+	}
+
+	return i
+}
+
+func instObserved(status string, m ...func(*compute.Instance)) *compute.Instance {
+	i := &compute.Instance{
+		Name:              testInstanceName,
+		MachineType:       testInstanceMachineType,
+		NetworkInterfaces: []*compute.NetworkInterface{{Network: "global/networks/default"}},
+		Scheduling: &compute.Scheduling{
+			AutomaticRestart:  gcp.BoolPtr(true),
+			OnHostMaintenance: "MIGRATE",
+		},
+		Status: status,
+	}
+	for _, f := range m {
+		f(i)
+	}
+	return i
+}
+
+// instanceHandler serves the supplied operation and instance, and records the
+// custom method or, failing that, the HTTP method of other requests and the
+// body, or the disk arguments, of the last one.
+func instanceHandler(op *compute.Operation, i *compute.Instance, calls *[]string, got *map[string]interface{}, fail bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		_ = r.Body.Close()
+		switch {
+		case r.Method != http.MethodGet:
+			call := r.Method
+			if m := path.Base(r.URL.Path); r.Method != http.MethodDelete && m != "instances" {
+				call = m
+			}
+			*calls = append(*calls, call)
+			_ = json.Unmarshal(b, got)
+			if q := r.URL.Query(); q.Get("deviceName") != "" {
+				*got = map[string]interface{}{"autoDelete": q.Get("autoDelete"), "deviceName": q.Get("deviceName")}
+			}
+			if fail {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(&compute.Operation{Name: testInstanceOp})
+		case strings.Contains(r.URL.Path, "/operations/"):
+			if op == nil {
+				w.WriteHeader(http.StatusNotFound)
+			}
+			_ = json.NewEncoder(w).Encode(op)
+		default:
+			if i == nil {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&compute.Instance{})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(i)
+		}
+	}
+}
+
+func TestInstanceObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		op   *compute.Operation
+		i    *compute.Instance
+		mg   resource.Managed
+		want want
+	}{
+		"NotInstance": {
+			mg: &v1alpha1.Firewall{},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotInstance),
+			},
+		},
+		"NotFound": {
+			mg: instObj(),
+			want: want{
+				mg:  instObj(),
+				obs: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Running": {
+			i:  instObserved(instance.StatusRunning),
+			mg: instObj(),
+			want: want{
+				mg: instObj(
+					instWithScheduling(false, true, "MIGRATE"),
+					instWithStatus(instance.StatusRunning),
+					instWithConditions(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"SpotToggled": {
+			i:  instObserved(instance.StatusRunning),
+			mg: instObj(instWithScheduling(true, false, "TERMINATE")),
+			want: want{
+				mg: instObj(
+					instWithScheduling(true, false, "TERMINATE"),
+					instWithStatus(instance.StatusRunning),
+					instWithConditions(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"RestartPolicyChanged": {
+			i:  instObserved(instance.StatusRunning),
+			mg: instObj(instWithScheduling(false, false, "MIGRATE")),
+			want: want{
+				mg: instObj(
+					instWithScheduling(false, false, "MIGRATE"),
+					instWithStatus(instance.StatusRunning),
+					instWithConditions(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"GPUAdded": {
+			i:  instObserved(instance.StatusTerminated),
+			mg: instObj(instWithScheduling(false, false, "TERMINATE"), instWithGPU()),
+			want: want{
+				mg: instObj(
+					instWithScheduling(false, false, "TERMINATE"),
+					instWithGPU(),
+					instWithStatus(instance.StatusTerminated),
+					instWithConditions(xpv1.Unavailable()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"ShieldedConfigChanged": {
+			i:  instObserved(instance.StatusRunning, instShielded),
+			mg: instObj(instWithScheduling(false, true, "MIGRATE"), instWithShielded(false, false)),
+			want: want{
+				mg: instObj(
+					instWithScheduling(false, true, "MIGRATE"),
+					instWithShielded(false, false),
+					instWithStatus(instance.StatusRunning),
+					instWithConditions(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"ConfidentialComputeChanged": {
+			i:  instObserved(instance.StatusRunning),
+			mg: instObj(instWithScheduling(false, true, "TERMINATE"), instWithConfidential()),
+			want: want{
+				mg: instObj(
+					instWithScheduling(false, true, "TERMINATE"),
+					instWithConfidential(),
+					instWithStatus(instance.StatusRunning),
+					instWithConditions(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"AutoDeleteChanged": {
+			i:  instObserved(instance.StatusRunning, instWithDisk(false)),
+			mg: instObj(instWithScheduling(false, true, "MIGRATE"), instWithAutoDelete(true)),
+			want: want{
+				mg: instObj(
+					instWithScheduling(false, true, "MIGRATE"),
+					instWithAutoDelete(true),
+					instWithStatus(instance.StatusRunning),
+					instWithConditions(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"StoppedForUpdate": {
+			i:  instObserved(instance.StatusTerminated),
+			mg: instObj(instWithScheduling(false, true, "MIGRATE"), instWithStoppedForUpdate()),
+			want: want{
+				mg: instObj(
+					instWithScheduling(false, true, "MIGRATE"),
+					instWithStoppedForUpdate(),
+					instWithStatus(instance.StatusTerminated),
+					instWithConditions(xpv1.Unavailable()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"OperationPending": {
+			op: &compute.Operation{Name: testInstanceOp, Status: "RUNNING"},
+			i:  instObserved("STOPPING"),
+			mg: instObj(instWithScheduling(true, false, "TERMINATE"), instWithOperation(testInstanceOp)),
+			want: want{
+				mg: instObj(
+					instWithScheduling(true, false, "TERMINATE"),
+					instWithOperation(testInstanceOp),
+					instWithStatus("STOPPING"),
+					instWithConditions(xpv1.Unavailable()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"InsertPending": {
+			op: &compute.Operation{Name: testInstanceOp, Status: "RUNNING"},
+			mg: instObj(instWithOperation(testInstanceOp)),
+			want: want{
+				mg:  instObj(instWithOperation(testInstanceOp), instWithConditions(xpv1.Creating())),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"OperationFailed": {
+			op: &compute.Operation{
+				Name:   testInstanceOp,
+				Status: "DONE",
+				Error: &compute.OperationError{Errors: []*compute.OperationErrorErrors{
+					{Message: "quota exceeded"},
+				}},
+			},
+			mg: instObj(instWithOperation(testInstanceOp)),
+			want: want{
+				mg:  instObj(),
+				err: errors.Wrap(errors.New("quota exceeded"), errInstanceOpFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var calls []string
+			var body map[string]interface{}
+			server := httptest.NewServer(instanceHandler(tc.op, tc.i, &calls, &body, false))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := instExternal{
+				kube:      &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				projectID: projectID,
+				Service:   s,
+			}
+			obs, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInstanceCreate(t *testing.T) {
+	type want struct {
+		mg    resource.Managed
+		calls []string
+		body  map[string]interface{}
+		err   error
+	}
+
+	cases := map[string]struct {
+		fail bool
+		mg   resource.Managed
+		want want
+	}{
+		"NotInstance": {
+			mg: &v1alpha1.Firewall{},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotInstance),
+			},
+		},
+		"Created": {
+			mg: instObj(instWithScheduling(true, false, "TERMINATE")),
+			want: want{
+				mg: instObj(
+					instWithScheduling(true, false, "TERMINATE"),
+					instWithOperation(testInstanceOp),
+					instWithConditions(xpv1.Creating()),
+				),
+				calls: []string{http.MethodPost},
+				body: map[string]interface{}{
+					"name":        testInstanceName,
+					"machineType": testInstanceMachineType,
+					"disks": []interface{}{map[string]interface{}{
+						"boot":             true,
+						"initializeParams": map[string]interface{}{"sourceImage": testInstanceImage},
+					}},
+					"networkInterfaces": []interface{}{map[string]interface{}{"network": "global/networks/default"}},
+					"scheduling": map[string]interface{}{
+						"preemptible":       true,
+						"automaticRestart":  false,
+						"onHostMaintenance": "TERMINATE",
+					},
+				},
+			},
+		},
+		"PreemptibleRestart": {
+			mg: instObj(instWithScheduling(true, true, "TERMINATE")),
+			want: want{
+				mg:  instObj(instWithScheduling(true, true, "TERMINATE")),
+				err: errors.Wrap(errors.New("preemptible instances can not restart automatically; set automaticRestart to false and onHostMaintenance to TERMINATE"), errInvalidInstance),
+			},
+		},
+		"CreateFailed": {
+			fail: true,
+			mg:   instObj(),
+			want: want{
+				mg:    instObj(instWithConditions(xpv1.Creating())),
+				calls: []string{http.MethodPost},
+				body: map[string]interface{}{
+					"name":        testInstanceName,
+					"machineType": testInstanceMachineType,
+					"disks": []interface{}{map[string]interface{}{
+						"boot":             true,
+						"initializeParams": map[string]interface{}{"sourceImage": testInstanceImage},
+					}},
+					"networkInterfaces": []interface{}{map[string]interface{}{"network": "global/networks/default"}},
+				},
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateInstance),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var calls []string
+			var body map[string]interface{}
+			server := httptest.NewServer(instanceHandler(nil, nil, &calls, &body, tc.fail))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := instExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("Create(...): -want calls, +got calls:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.body, body); diff != "" {
+				t.Errorf("Create(...): -want body, +got body:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Create(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInstanceUpdate(t *testing.T) {
+	type want struct {
+		mg    resource.Managed
+		calls []string
+		body  map[string]interface{}
+		err   error
+	}
+
+	cases := map[string]struct {
+		i    *compute.Instance
+		fail bool
+		mg   resource.Managed
+		want want
+	}{
+		"NotInstance": {
+			mg: &v1alpha1.Firewall{},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotInstance),
+			},
+		},
+		"RestartPolicyChanged": {
+			i:  instObserved(instance.StatusRunning),
+			mg: instObj(instWithScheduling(false, false, "MIGRATE")),
+			want: want{
+				mg: instObj(instWithScheduling(false, false, "MIGRATE"), instWithOperation(testInstanceOp)),
+				// The restart policy is updated while the instance runs.
+				calls: []string{"setScheduling"},
+				body: map[string]interface{}{
+					"preemptible":       false,
+					"automaticRestart":  false,
+					"onHostMaintenance": "MIGRATE",
+				},
+			},
+		},
+		"SpotWithoutStop": {
+			i:  instObserved(instance.StatusRunning),
+			mg: instObj(instWithScheduling(true, false, "TERMINATE")),
+			want: want{
+				mg:  instObj(instWithScheduling(true, false, "TERMINATE")),
+				err: errors.New(errStopForUpdate),
+			},
+		},
+		"SpotStopsInstance": {
+			i:  instObserved(instance.StatusRunning),
+			mg: instObj(instWithScheduling(true, false, "TERMINATE"), instWithAllowStopForUpdate()),
+			want: want{
+				mg: instObj(
+					instWithScheduling(true, false, "TERMINATE"),
+					instWithAllowStopForUpdate(),
+					instWithStoppedForUpdate(),
+					instWithOperation(testInstanceOp),
+				),
+				calls: []string{"stop"},
+			},
+		},
+		"SpotOnStoppedInstance": {
+			i: instObserved(instance.StatusTerminated),
+			mg: instObj(
+				instWithScheduling(true, false, "TERMINATE"),
+				instWithAllowStopForUpdate(),
+				instWithStoppedForUpdate(),
+			),
+			want: want{
+				mg: instObj(
+					instWithScheduling(true, false, "TERMINATE"),
+					instWithAllowStopForUpdate(),
+					instWithStoppedForUpdate(),
+					instWithOperation(testInstanceOp),
+				),
+				calls: []string{"setScheduling"},
+				body: map[string]interface{}{
+					"preemptible":       true,
+					"automaticRestart":  false,
+					"onHostMaintenance": "TERMINATE",
+				},
+			},
+		},
+		"SpotDisabledOnInstanceStoppedByUser": {
+			i: instObserved(instance.StatusTerminated, func(i *compute.Instance) {
+				i.Scheduling = &compute.Scheduling{Preemptible: true, AutomaticRestart: gcp.BoolPtr(false), OnHostMaintenance: "TERMINATE"}
+			}),
+			mg: instObj(instWithScheduling(false, true, "MIGRATE")),
+			want: want{
+				mg: instObj(instWithScheduling(false, true, "MIGRATE"), instWithOperation(testInstanceOp)),
+				// A stopped instance need not be stopped first, nor is it
+				// started afterwards.
+				calls: []string{"setScheduling"},
+				body: map[string]interface{}{
+					"preemptible":       false,
+					"automaticRestart":  true,
+					"onHostMaintenance": "MIGRATE",
+				},
+			},
+		},
+		"GPUWithoutStop": {
+			i:  instObserved(instance.StatusRunning),
+			mg: instObj(instWithScheduling(false, false, "TERMINATE"), instWithGPU()),
+			want: want{
+				mg:  instObj(instWithScheduling(false, false, "TERMINATE"), instWithGPU()),
+				err: errors.New(errStopForUpdate),
+			},
+		},
+		"GPUStopsInstance": {
+			i:  instObserved(instance.StatusRunning),
+			mg: instObj(instWithScheduling(false, false, "TERMINATE"), instWithGPU(), instWithAllowStopForUpdate()),
+			want: want{
+				mg: instObj(
+					instWithScheduling(false, false, "TERMINATE"),
+					instWithGPU(),
+					instWithAllowStopForUpdate(),
+					instWithStoppedForUpdate(),
+					instWithOperation(testInstanceOp),
+				),
+				calls: []string{"stop"},
+			},
+		},
+		"GPUAddedToStoppedInstance": {
+			i: instObserved(instance.StatusTerminated, func(i *compute.Instance) {
+				i.Scheduling = &compute.Scheduling{AutomaticRestart: gcp.BoolPtr(false), OnHostMaintenance: "TERMINATE"}
+			}),
+			mg: instObj(instWithScheduling(false, false, "TERMINATE"), instWithGPU()),
+			want: want{
+				mg:    instObj(instWithScheduling(false, false, "TERMINATE"), instWithGPU(), instWithOperation(testInstanceOp)),
+				calls: []string{"setMachineResources"},
+				body: map[string]interface{}{
+					"guestAccelerators": []interface{}{map[string]interface{}{
+						"acceleratorType":  "zones/" + testZone + "/acceleratorTypes/" + testInstanceGPU,
+						"acceleratorCount": float64(1),
+					}},
+				},
+			},
+		},
+		"GPURemovedBeforeMigrating": {
+			i: instObserved(instance.StatusTerminated, func(i *compute.Instance) {
+				i.Scheduling = &compute.Scheduling{AutomaticRestart: gcp.BoolPtr(true), OnHostMaintenance: "TERMINATE"}
+				i.GuestAccelerators = []*compute.AcceleratorConfig{{AcceleratorType: testInstanceGPU, AcceleratorCount: 1}}
+			}),
+			mg: instObj(instWithScheduling(false, true, "MIGRATE")),
+			want: want{
+				mg: instObj(instWithScheduling(false, true, "MIGRATE"), instWithOperation(testInstanceOp)),
+				// The instance can only migrate once its GPU is gone.
+				calls: []string{"setMachineResources"},
+				body:  map[string]interface{}{"guestAccelerators": []interface{}{}},
+			},
+		},
+		"IntegrityMonitoringDisabled": {
+			i:  instObserved(instance.StatusRunning, instShielded),
+			mg: instObj(instWithScheduling(false, true, "MIGRATE"), instWithShielded(false, false)),
+			want: want{
+				mg: instObj(instWithScheduling(false, true, "MIGRATE"), instWithShielded(false, false), instWithOperation(testInstanceOp)),
+				// Integrity monitoring is updated while the instance runs.
+				calls: []string{"updateShieldedInstanceConfig"},
+				body: map[string]interface{}{
+					"enableSecureBoot":          false,
+					"enableVtpm":                true,
+					"enableIntegrityMonitoring": false,
+				},
+			},
+		},
+		"SecureBootWithoutStop": {
+			i:  instObserved(instance.StatusRunning, instShielded),
+			mg: instObj(instWithScheduling(false, true, "MIGRATE"), instWithShielded(true, true)),
+			want: want{
+				mg:  instObj(instWithScheduling(false, true, "MIGRATE"), instWithShielded(true, true)),
+				err: errors.New(errStopForUpdate),
+			},
+		},
+		"SecureBootOnStoppedInstance": {
+			i: instObserved(instance.StatusTerminated, instShielded),
+			mg: instObj(
+				instWithScheduling(false, true, "MIGRATE"),
+				instWithShielded(true, true),
+				instWithAllowStopForUpdate(),
+				instWithStoppedForUpdate(),
+			),
+			want: want{
+				mg: instObj(
+					instWithScheduling(false, true, "MIGRATE"),
+					instWithShielded(true, true),
+					instWithAllowStopForUpdate(),
+					instWithStoppedForUpdate(),
+					instWithOperation(testInstanceOp),
+				),
+				calls: []string{"updateShieldedInstanceConfig"},
+				body: map[string]interface{}{
+					"enableSecureBoot":          true,
+					"enableVtpm":                true,
+					"enableIntegrityMonitoring": true,
+				},
+			},
+		},
+		"ConfidentialComputeImmutable": {
+			i:  instObserved(instance.StatusRunning),
+			mg: instObj(instWithScheduling(false, true, "TERMINATE"), instWithConfidential(), instWithAllowStopForUpdate()),
+			want: want{
+				mg: instObj(instWithScheduling(false, true, "TERMINATE"), instWithConfidential(), instWithAllowStopForUpdate()),
+				// The instance is not stopped for a change GCP rejects.
+				err: errors.Errorf(errFmtImmutableInstance, "enableConfidentialCompute: true != false"),
+			},
+		},
+		"AutoDeleteEnabled": {
+			i:  instObserved(instance.StatusRunning, instWithDisk(false)),
+			mg: instObj(instWithScheduling(false, true, "MIGRATE"), instWithAutoDelete(true)),
+			want: want{
+				mg: instObj(instWithScheduling(false, true, "MIGRATE"), instWithAutoDelete(true), instWithOperation(testInstanceOp)),
+				// Whether a disk is deleted along with the instance is
+				// updated while it runs.
+				calls: []string{"setDiskAutoDelete"},
+				body:  map[string]interface{}{"autoDelete": "true", "deviceName": testInstanceDisk},
+			},
+		},
+		"AutoDeleteDisabled": {
+			i: instObserved(instance.StatusRunning, instWithDisk(true)),
+			mg: instObj(instWithScheduling(false, true, "MIGRATE"), func(i *v1alpha1.Instance) {
+				i.Spec.ForProvider.Disks[0].AutoDelete = gcp.BoolPtr(false)
+				i.Spec.ForProvider.Disks[0].DeviceName = gcp.StringPtr(testInstanceDisk)
+			}),
+			want: want{
+				mg: instObj(instWithScheduling(false, true, "MIGRATE"), instWithOperation(testInstanceOp), func(i *v1alpha1.Instance) {
+					i.Spec.ForProvider.Disks[0].AutoDelete = gcp.BoolPtr(false)
+					i.Spec.ForProvider.Disks[0].DeviceName = gcp.StringPtr(testInstanceDisk)
+				}),
+				calls: []string{"setDiskAutoDelete"},
+				body:  map[string]interface{}{"autoDelete": "false", "deviceName": testInstanceDisk},
+			},
+		},
+		"StartedAfterUpdate": {
+			i: instObserved(instance.StatusTerminated, func(i *compute.Instance) {
+				i.Scheduling = &compute.Scheduling{Preemptible: true, AutomaticRestart: gcp.BoolPtr(false), OnHostMaintenance: "TERMINATE"}
+			}),
+			mg: instObj(
+				instWithScheduling(true, false, "TERMINATE"),
+				instWithAllowStopForUpdate(),
+				instWithStoppedForUpdate(),
+			),
+			want: want{
+				mg: instObj(
+					instWithScheduling(true, false, "TERMINATE"),
+					instWithAllowStopForUpdate(),
+					instWithOperation(testInstanceOp),
+				),
+				calls: []string{"start"},
+			},
+		},
+		"UpdateFailed": {
+			i:    instObserved(instance.StatusRunning),
+			fail: true,
+			mg:   instObj(instWithScheduling(false, true, "TERMINATE")),
+			want: want{
+				mg:    instObj(instWithScheduling(false, true, "TERMINATE")),
+				calls: []string{"setScheduling"},
+				body: map[string]interface{}{
+					"preemptible":       false,
+					"automaticRestart":  true,
+					"onHostMaintenance": "TERMINATE",
+				},
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateInstance),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var calls []string
+			var body map[string]interface{}
+			server := httptest.NewServer(instanceHandler(nil, tc.i, &calls, &body, tc.fail))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := instExternal{
+				kube:      &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("Update(...): -want calls, +got calls:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.body, body); diff != "" {
+				t.Errorf("Update(...): -want body, +got body:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Update(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInstanceDelete(t *testing.T) {
+	type want struct {
+		mg    resource.Managed
+		calls []string
+		body  map[string]interface{}
+		err   error
+	}
+
+	cases := map[string]struct {
+		i    *compute.Instance
+		fail bool
+		mg   resource.Managed
+		want want
+	}{
+		"NotInstance": {
+			mg: &v1alpha1.Firewall{},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotInstance),
+			},
+		},
+		"Deleted": {
+			i:  instObserved(instance.StatusRunning, instWithDisk(true)),
+			mg: instObj(),
+			want: want{
+				mg:    instObj(instWithConditions(xpv1.Deleting())),
+				calls: []string{http.MethodDelete},
+			},
+		},
+		"AlreadyGone": {
+			mg: instObj(),
+			want: want{
+				mg: instObj(instWithConditions(xpv1.Deleting())),
+			},
+		},
+		"DeleteFailed": {
+			i:    instObserved(instance.StatusRunning),
+			fail: true,
+			mg:   instObj(),
+			want: want{
+				mg:    instObj(instWithConditions(xpv1.Deleting())),
+				calls: []string{http.MethodDelete},
+				err:   errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteInstance),
+			},
+		},
+		"DiskPreserved": {
+			i:  instObserved(instance.StatusRunning, instWithDisk(true)),
+			mg: instObj(instWithAutoDelete(false)),
+			want: want{
+				mg: instObj(
+					instWithAutoDelete(false),
+					instWithOperation(testInstanceOp),
+					instWithConditions(xpv1.Deleting()),
+				),
+				// The instance is deleted once the disk is kept from being
+				// deleted along with it.
+				calls: []string{"setDiskAutoDelete"},
+				body:  map[string]interface{}{"autoDelete": "false", "deviceName": testInstanceDisk},
+			},
+		},
+		"DiskDeletedWithInstance": {
+			i:  instObserved(instance.StatusRunning, instWithDisk(false)),
+			mg: instObj(instWithAutoDelete(true)),
+			want: want{
+				mg:    instObj(instWithAutoDelete(true), instWithConditions(xpv1.Deleting())),
+				calls: []string{http.MethodDelete},
+			},
+		},
+		"OperationPending": {
+			i:  instObserved(instance.StatusRunning, instWithDisk(true)),
+			mg: instObj(instWithAutoDelete(false), instWithOperation(testInstanceOp)),
+			want: want{
+				mg: instObj(
+					instWithAutoDelete(false),
+					instWithOperation(testInstanceOp),
+					instWithConditions(xpv1.Deleting()),
+				),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var calls []string
+			var body map[string]interface{}
+			server := httptest.NewServer(instanceHandler(nil, tc.i, &calls, &body, tc.fail))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := instExternal{
+				kube:      &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				projectID: projectID,
+				Service:   s,
+			}
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("Delete(...): -want calls, +got calls:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.body, body); diff != "" {
+				t.Errorf("Delete(...): -want body, +got body:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Delete(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/interconnectattachment"
+)
+
+// Error strings.
+const (
+	errNotInterconnectAttachment          = "managed resource is not an InterconnectAttachment"
+	errGetInterconnectAttachment          = "cannot get external InterconnectAttachment resource"
+	errGetInterconnectAttachmentOperation = "cannot get GCP interconnect attachment operation"
+	errInterconnectAttachmentOpFailed     = "GCP interconnect attachment operation failed"
+	errCreateInterconnectAttachment       = "cannot create external InterconnectAttachment resource"
+	errUpdateInterconnectAttachment       = "cannot update external InterconnectAttachment resource"
+	errDeleteInterconnectAttachment       = "cannot delete external InterconnectAttachment resource"
+	errManagedInterconnectAttachment      = "cannot update managed InterconnectAttachment resource"
+)
+
+// Interconnect attachment states.
+const (
+	interconnectAttachmentStateActive          = "ACTIVE"
+	interconnectAttachmentStatePendingPartner  = "PENDING_PARTNER"
+	interconnectAttachmentStatePendingCustomer = "PENDING_CUSTOMER"
+	interconnectAttachmentStateRequestReceived = "PARTNER_REQUEST_RECEIVED"
+)
+
+// SetupInterconnectAttachment adds a controller that reconciles
+// InterconnectAttachment managed resources.
+func SetupInterconnectAttachment(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.InterconnectAttachmentGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.InterconnectAttachment{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.InterconnectAttachmentGroupVersionKind),
+			managed.WithExternalConnecter(&iaConnector{kube: mgr.GetClient()}),
+			managed.WithConnectionPublishers(),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type iaConnector struct {
+	kube client.Client
+}
+
+func (c *iaConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := compute.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &iaExternal{kube: c.kube, Service: s, projectID: projectID}, nil
+}
+
+type iaExternal struct {
+	kube      client.Client
+	projectID string
+	*compute.Service
+}
+
+func (e *iaExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) { // nolint:gocyclo
+	cr, ok := mg.(*v1alpha1.InterconnectAttachment)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotInterconnectAttachment)
+	}
+
+	// The annotation holds the pending insert or patch operation.
+	pending := false
+	if name := cr.GetAnnotations()[gcp.AnnotationKeyOperation]; name != "" {
+		op, err := e.RegionOperations.Get(e.projectID, cr.Spec.ForProvider.Region, name).Context(ctx).Do()
+		if resource.Ignore(gcp.IsErrorNotFound, err) != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetInterconnectAttachmentOperation)
+		}
+		pending = err == nil && op.Status != gcp.OperationDone
+		if !pending {
+			meta.RemoveAnnotations(cr, gcp.AnnotationKeyOperation)
+			if err := e.kube.Update(ctx, cr); err != nil {
+				return managed.ExternalObservation{}, errors.Wrap(err, errManagedInterconnectAttachment)
+			}
+		}
+		if err == nil && !pending && gcp.OperationError(op) != "" {
+			return managed.ExternalObservation{}, errors.Wrap(errors.New(gcp.OperationError(op)), errInterconnectAttachmentOpFailed)
+		}
+	}
+
+	observed, err := e.InterconnectAttachments.Get(e.projectID, cr.Spec.ForProvider.Region, meta.GetExternalName(cr)).Context(ctx).Do()
+	if gcp.IsErrorNotFound(err) && pending {
+		// The interconnect attachment exists once its insert operation is
+		// done.
+		cr.SetConditions(xpv1.Creating())
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetInterconnectAttachment)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	interconnectattachment.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := e.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errManagedInterconnectAttachment)
+		}
+	}
+
+	cr.Status.AtProvider = interconnectattachment.GenerateInterconnectAttachmentObservation(*observed)
+	switch cr.Status.AtProvider.State {
+	case interconnectAttachmentStateActive:
+		cr.SetConditions(xpv1.Available())
+	case interconnectAttachmentStatePendingPartner, interconnectAttachmentStatePendingCustomer, interconnectAttachmentStateRequestReceived:
+		// Partner attachments are provisioned once the partner and the
+		// customer complete the pairing.
+		cr.SetConditions(xpv1.Creating())
+	default:
+		cr.SetConditions(xpv1.Unavailable())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists: true,
+		// A pending patch operation is observed before changing the
+		// attachment again.
+		ResourceUpToDate: pending || interconnectattachment.IsUpToDate(cr.Spec.ForProvider, *observed),
+		ConnectionDetails: managed.ConnectionDetails{
+			v1alpha1.ConnectionSecretKeyCloudRouterIPAddress:    []byte(observed.CloudRouterIpAddress),
+			v1alpha1.ConnectionSecretKeyCustomerRouterIPAddress: []byte(observed.CustomerRouterIpAddress),
+		},
+	}, nil
+}
+
+func (e *iaExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.InterconnectAttachment)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotInterconnectAttachment)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+	ia := &compute.InterconnectAttachment{}
+	interconnectattachment.GenerateInterconnectAttachment(meta.GetExternalName(cr), cr.Spec.ForProvider, ia)
+	op, err := e.InterconnectAttachments.Insert(e.projectID, cr.Spec.ForProvider.Region, ia).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateInterconnectAttachment)
+	}
+	meta.AddAnnotations(cr, map[string]string{gcp.AnnotationKeyOperation: op.Name})
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *iaExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.InterconnectAttachment)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotInterconnectAttachment)
+	}
+
+	// Only the bandwidth and the administrative state can be updated.
+	patch := interconnectattachment.GenerateInterconnectAttachmentPatch(cr.Spec.ForProvider)
+	op, err := e.InterconnectAttachments.Patch(e.projectID, cr.Spec.ForProvider.Region, meta.GetExternalName(cr), patch).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateInterconnectAttachment)
+	}
+
+	// Annotations are not persisted after an update, so the pending patch
+	// operation is recorded explicitly.
+	meta.AddAnnotations(cr, map[string]string{gcp.AnnotationKeyOperation: op.Name})
+	return managed.ExternalUpdate{}, errors.Wrap(e.kube.Update(ctx, cr), errManagedInterconnectAttachment)
+}
+
+func (e *iaExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.InterconnectAttachment)
+	if !ok {
+		return errors.New(errNotInterconnectAttachment)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+	op, err := e.InterconnectAttachments.Delete(e.projectID, cr.Spec.ForProvider.Region, meta.GetExternalName(cr)).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteInterconnectAttachment)
+}
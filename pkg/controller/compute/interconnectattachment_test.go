@@ -0,0 +1,563 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testInterconnectAttachmentName = "vlan"
+	testInterconnectAttachmentOp   = "operation-interconnect-attachment"
+	testIARouter                   = "regions/us-central1/routers/edge"
+	testIACloudRouterIP            = "169.254.0.1/29"
+	testIACustomerRouterIP         = "169.254.0.2/29"
+)
+
+var _ managed.ExternalConnecter = &iaConnector{}
+var _ managed.ExternalClient = &iaExternal{}
+
+type iaModifier func(*v1alpha1.InterconnectAttachment)
+
+func iaWithConditions(c ...xpv1.Condition) iaModifier {
+	return func(i *v1alpha1.InterconnectAttachment) { i.Status.SetConditions(c...) }
+}
+
+func iaWithOperation(op string) iaModifier {
+	return func(i *v1alpha1.InterconnectAttachment) {
+		meta.AddAnnotations(i, map[string]string{gcp.AnnotationKeyOperation: op})
+	}
+}
+
+func iaWithBandwidth(b string) iaModifier {
+	return func(i *v1alpha1.InterconnectAttachment) { i.Spec.ForProvider.Bandwidth = &b }
+}
+
+func iaWithAdminEnabled(e bool) iaModifier {
+	return func(i *v1alpha1.InterconnectAttachment) { i.Spec.ForProvider.AdminEnabled = &e }
+}
+
+func iaWithObservation(o v1alpha1.InterconnectAttachmentObservation) iaModifier {
+	return func(i *v1alpha1.InterconnectAttachment) { i.Status.AtProvider = o }
+}
+
+func iaObj(im ...iaModifier) *v1alpha1.InterconnectAttachment {
+	i := &v1alpha1.InterconnectAttachment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        testInterconnectAttachmentName,
+			Annotations: map[string]string{meta.AnnotationKeyExternalName: testInterconnectAttachmentName},
+		},
+		Spec: v1alpha1.InterconnectAttachmentSpec{
+			ForProvider: v1alpha1.InterconnectAttachmentParameters{
+				Region: testRegion,
+				Router: testIARouter,
+			},
+		},
+	}
+
+	for _, m := range im {
+		m(i)
+	}
+
+	return i
+}
+
+func interconnectAttachmentHandler(t *testing.T, op *compute.Operation, ia *compute.InterconnectAttachment) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_ = r.Body.Close()
+		if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+			t.Errorf("r: -want, +got:\n%s", diff)
+		}
+		if strings.Contains(r.URL.Path, "/operations/") {
+			if op == nil {
+				w.WriteHeader(http.StatusNotFound)
+			}
+			_ = json.NewEncoder(w).Encode(op)
+			return
+		}
+		if ia == nil {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(&compute.InterconnectAttachment{})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(ia)
+	}
+}
+
+func TestInterconnectAttachmentObserve(t *testing.T) {
+	observed := func(m ...func(*compute.InterconnectAttachment)) *compute.InterconnectAttachment {
+		ia := &compute.InterconnectAttachment{
+			Name:                    testInterconnectAttachmentName,
+			Router:                  testIARouter,
+			Bandwidth:               "BPS_1G",
+			AdminEnabled:            true,
+			CloudRouterIpAddress:    testIACloudRouterIP,
+			CustomerRouterIpAddress: testIACustomerRouterIP,
+			OperationalStatus:       "OS_ACTIVE",
+			State:                   "ACTIVE",
+		}
+		for _, f := range m {
+			f(ia)
+		}
+		return ia
+	}
+	active := v1alpha1.InterconnectAttachmentObservation{
+		CloudRouterIPAddress:    testIACloudRouterIP,
+		CustomerRouterIPAddress: testIACustomerRouterIP,
+		OperationalStatus:       "OS_ACTIVE",
+		State:                   "ACTIVE",
+	}
+	connection := managed.ConnectionDetails{
+		v1alpha1.ConnectionSecretKeyCloudRouterIPAddress:    []byte(testIACloudRouterIP),
+		v1alpha1.ConnectionSecretKeyCustomerRouterIPAddress: []byte(testIACustomerRouterIP),
+	}
+
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"NotInterconnectAttachment": {
+			args: args{
+				mg: &v1alpha1.Firewall{},
+			},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotInterconnectAttachment),
+			},
+		},
+		"NotFound": {
+			handler: interconnectAttachmentHandler(t, nil, nil),
+			args: args{
+				mg: iaObj(),
+			},
+			want: want{
+				mg:  iaObj(),
+				obs: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"GetFailed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.InterconnectAttachment{})
+			}),
+			args: args{
+				mg: iaObj(),
+			},
+			want: want{
+				mg:  iaObj(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetInterconnectAttachment),
+			},
+		},
+		"Available": {
+			handler: interconnectAttachmentHandler(t, nil, observed()),
+			args: args{
+				mg: iaObj(),
+			},
+			want: want{
+				mg: iaObj(
+					iaWithBandwidth("BPS_1G"),
+					iaWithAdminEnabled(true),
+					iaWithObservation(active),
+					iaWithConditions(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: connection},
+			},
+		},
+		"BandwidthChanged": {
+			handler: interconnectAttachmentHandler(t, nil, observed()),
+			args: args{
+				mg: iaObj(iaWithBandwidth("BPS_10G"), iaWithAdminEnabled(true)),
+			},
+			want: want{
+				mg: iaObj(
+					iaWithBandwidth("BPS_10G"),
+					iaWithAdminEnabled(true),
+					iaWithObservation(active),
+					iaWithConditions(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: connection},
+			},
+		},
+		"AdminDisabled": {
+			handler: interconnectAttachmentHandler(t, nil, observed()),
+			args: args{
+				mg: iaObj(iaWithBandwidth("BPS_1G"), iaWithAdminEnabled(false)),
+			},
+			want: want{
+				mg: iaObj(
+					iaWithBandwidth("BPS_1G"),
+					iaWithAdminEnabled(false),
+					iaWithObservation(active),
+					iaWithConditions(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: connection},
+			},
+		},
+		"PatchPending": {
+			handler: interconnectAttachmentHandler(t, &compute.Operation{Name: testInterconnectAttachmentOp, Status: "RUNNING"}, observed()),
+			args: args{
+				mg: iaObj(iaWithOperation(testInterconnectAttachmentOp), iaWithBandwidth("BPS_10G"), iaWithAdminEnabled(true)),
+			},
+			want: want{
+				mg: iaObj(
+					iaWithOperation(testInterconnectAttachmentOp),
+					iaWithBandwidth("BPS_10G"),
+					iaWithAdminEnabled(true),
+					iaWithObservation(active),
+					iaWithConditions(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: connection},
+			},
+		},
+		"PendingPartner": {
+			handler: interconnectAttachmentHandler(t, nil, observed(func(ia *compute.InterconnectAttachment) {
+				ia.CloudRouterIpAddress = ""
+				ia.CustomerRouterIpAddress = ""
+				ia.OperationalStatus = "OS_UNPROVISIONED"
+				ia.PairingKey = "7e51371e-72a3-40b5-b844-2e3efefaee59/us-central1/1"
+				ia.State = "PENDING_PARTNER"
+			})),
+			args: args{
+				mg: iaObj(iaWithBandwidth("BPS_1G"), iaWithAdminEnabled(true)),
+			},
+			want: want{
+				mg: iaObj(
+					iaWithBandwidth("BPS_1G"),
+					iaWithAdminEnabled(true),
+					iaWithObservation(v1alpha1.InterconnectAttachmentObservation{
+						OperationalStatus: "OS_UNPROVISIONED",
+						PairingKey:        "7e51371e-72a3-40b5-b844-2e3efefaee59/us-central1/1",
+						State:             "PENDING_PARTNER",
+					}),
+					iaWithConditions(xpv1.Creating()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{
+					v1alpha1.ConnectionSecretKeyCloudRouterIPAddress:    []byte(""),
+					v1alpha1.ConnectionSecretKeyCustomerRouterIPAddress: []byte(""),
+				}},
+			},
+		},
+		"InsertPending": {
+			handler: interconnectAttachmentHandler(t, &compute.Operation{Name: testInterconnectAttachmentOp, Status: "RUNNING"}, nil),
+			args: args{
+				mg: iaObj(iaWithOperation(testInterconnectAttachmentOp)),
+			},
+			want: want{
+				mg: iaObj(
+					iaWithOperation(testInterconnectAttachmentOp),
+					iaWithConditions(xpv1.Creating()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"OperationFailed": {
+			handler: interconnectAttachmentHandler(t, &compute.Operation{
+				Name:   testInterconnectAttachmentOp,
+				Status: "DONE",
+				Error: &compute.OperationError{Errors: []*compute.OperationErrorErrors{
+					{Message: "VLAN tag already in use"},
+				}},
+			}, nil),
+			args: args{
+				mg: iaObj(iaWithOperation(testInterconnectAttachmentOp)),
+			},
+			want: want{
+				mg:  iaObj(),
+				err: errors.Wrap(errors.New("VLAN tag already in use"), errInterconnectAttachmentOpFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := iaExternal{
+				kube:      &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				projectID: projectID,
+				Service:   s,
+			}
+			obs, err := e.Observe(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInterconnectAttachmentCreate(t *testing.T) {
+	type want struct {
+		mg     resource.Managed
+		insert *compute.InterconnectAttachment
+		err    error
+	}
+
+	cases := map[string]struct {
+		fail bool
+		mg   resource.Managed
+		want want
+	}{
+		"NotInterconnectAttachment": {
+			mg: &v1alpha1.Firewall{},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotInterconnectAttachment),
+			},
+		},
+		"Created": {
+			mg: iaObj(iaWithBandwidth("BPS_1G"), iaWithAdminEnabled(false)),
+			want: want{
+				mg: iaObj(
+					iaWithBandwidth("BPS_1G"),
+					iaWithAdminEnabled(false),
+					iaWithOperation(testInterconnectAttachmentOp),
+					iaWithConditions(xpv1.Creating()),
+				),
+				insert: &compute.InterconnectAttachment{
+					Name:      testInterconnectAttachmentName,
+					Router:    testIARouter,
+					Bandwidth: "BPS_1G",
+				},
+			},
+		},
+		"CreateFailed": {
+			fail: true,
+			mg:   iaObj(),
+			want: want{
+				mg:     iaObj(iaWithConditions(xpv1.Creating())),
+				insert: &compute.InterconnectAttachment{Name: testInterconnectAttachmentName, Router: testIARouter},
+				err:    errors.Wrap(gError(http.StatusBadRequest, ""), errCreateInterconnectAttachment),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var insert *compute.InterconnectAttachment
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := ioutil.ReadAll(r.Body)
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				insert = &compute.InterconnectAttachment{}
+				_ = json.Unmarshal(b, insert)
+				if tc.fail {
+					w.WriteHeader(http.StatusBadRequest)
+					_ = json.NewEncoder(w).Encode(&compute.Operation{})
+					return
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{Name: testInterconnectAttachmentOp})
+			}))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := iaExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.insert, insert); diff != "" {
+				t.Errorf("Create(...): -want insert, +got insert:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Create(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInterconnectAttachmentUpdate(t *testing.T) {
+	type want struct {
+		mg    resource.Managed
+		patch map[string]interface{}
+		err   error
+	}
+
+	cases := map[string]struct {
+		fail bool
+		mg   resource.Managed
+		want want
+	}{
+		"NotInterconnectAttachment": {
+			mg: &v1alpha1.Firewall{},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotInterconnectAttachment),
+			},
+		},
+		"BandwidthChanged": {
+			mg: iaObj(iaWithBandwidth("BPS_10G"), iaWithAdminEnabled(true)),
+			want: want{
+				mg: iaObj(
+					iaWithBandwidth("BPS_10G"),
+					iaWithAdminEnabled(true),
+					iaWithOperation(testInterconnectAttachmentOp),
+				),
+				patch: map[string]interface{}{"bandwidth": "BPS_10G", "adminEnabled": true},
+			},
+		},
+		"AdminDisabled": {
+			mg: iaObj(iaWithBandwidth("BPS_1G"), iaWithAdminEnabled(false)),
+			want: want{
+				mg: iaObj(
+					iaWithBandwidth("BPS_1G"),
+					iaWithAdminEnabled(false),
+					iaWithOperation(testInterconnectAttachmentOp),
+				),
+				// Disabling the attachment must be sent explicitly.
+				patch: map[string]interface{}{"bandwidth": "BPS_1G", "adminEnabled": false},
+			},
+		},
+		"PatchFailed": {
+			fail: true,
+			mg:   iaObj(iaWithBandwidth("BPS_10G")),
+			want: want{
+				mg:    iaObj(iaWithBandwidth("BPS_10G")),
+				patch: map[string]interface{}{"bandwidth": "BPS_10G"},
+				err:   errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateInterconnectAttachment),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var patch map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := ioutil.ReadAll(r.Body)
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodPatch, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.Unmarshal(b, &patch)
+				if tc.fail {
+					w.WriteHeader(http.StatusBadRequest)
+					_ = json.NewEncoder(w).Encode(&compute.Operation{})
+					return
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{Name: testInterconnectAttachmentOp})
+			}))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := iaExternal{
+				kube:      &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.patch, patch); diff != "" {
+				t.Errorf("Update(...): -want patch, +got patch:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Update(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInterconnectAttachmentDelete(t *testing.T) {
+	cases := map[string]struct {
+		status int
+		mg     resource.Managed
+		want   error
+	}{
+		"NotInterconnectAttachment": {
+			mg:   &v1alpha1.Firewall{},
+			want: errors.New(errNotInterconnectAttachment),
+		},
+		"Deleted": {
+			status: http.StatusOK,
+			mg:     iaObj(),
+		},
+		"AlreadyGone": {
+			status: http.StatusNotFound,
+			mg:     iaObj(),
+		},
+		"DeleteFailed": {
+			status: http.StatusBadRequest,
+			mg:     iaObj(),
+			want:   errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteInterconnectAttachment),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(tc.status)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := iaExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
@@ -56,7 +56,7 @@ const (
 
 // SetupNetwork adds a controller that reconciles Network managed
 // resources.
-func SetupNetwork(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupNetwork(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
 	name := managed.ControllerName(v1beta1.NetworkGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -70,7 +70,9 @@ func SetupNetwork(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter,
 			managed.WithExternalConnecter(&networkConnector{kube: mgr.GetClient()}),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithConnectionPublishers(),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
 			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
@@ -140,9 +142,10 @@ func (c *networkExternal) Create(ctx context.Context, mg resource.Managed) (mana
 
 	net := &compute.Network{}
 	network.GenerateNetwork(meta.GetExternalName(cr), cr.Spec.ForProvider, net)
-	_, err := c.Networks.Insert(c.projectID, net).
+	op, err := c.Networks.Insert(c.projectID, net).
 		Context(ctx).
 		Do()
+	gcp.SetOperationWarnings(cr, op)
 	return managed.ExternalCreation{}, errors.Wrap(err, errNetworkCreateFailed)
 }
 
@@ -165,7 +168,8 @@ func (c *networkExternal) Update(ctx context.Context, mg resource.Managed) (mana
 		return managed.ExternalUpdate{}, nil
 	}
 	if switchToCustom {
-		_, err := c.Networks.SwitchToCustomMode(c.projectID, meta.GetExternalName(cr)).Context(ctx).Do()
+		op, err := c.Networks.SwitchToCustomMode(c.projectID, meta.GetExternalName(cr)).Context(ctx).Do()
+		gcp.SetOperationWarnings(cr, op)
 		return managed.ExternalUpdate{}, errors.Wrap(err, errNetworkUpdateFailed)
 	}
 
@@ -174,9 +178,10 @@ func (c *networkExternal) Update(ctx context.Context, mg resource.Managed) (mana
 
 	// NOTE(muvaf): All parameters except routing config are
 	// immutable.
-	_, err = c.Networks.Patch(c.projectID, meta.GetExternalName(cr), net).
+	op, err := c.Networks.Patch(c.projectID, meta.GetExternalName(cr), net).
 		Context(ctx).
 		Do()
+	gcp.SetOperationWarnings(cr, op)
 	return managed.ExternalUpdate{}, errors.Wrap(err, errNetworkUpdateFailed)
 }
 
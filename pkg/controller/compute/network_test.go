@@ -28,6 +28,7 @@ import (
 	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -39,6 +40,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane/provider-gcp/apis/compute/v1beta1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
 	"github.com/crossplane/provider-gcp/pkg/clients/network"
 )
 
@@ -279,6 +281,36 @@ func TestNetworkCreate(t *testing.T) {
 				err: nil,
 			},
 		},
+		"SuccessfulWithWarnings": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{
+					Warnings: []*compute.OperationWarnings{
+						{
+							Code:    "DEPRECATED_RESOURCE_USED",
+							Message: "The resource is deprecated.",
+						},
+					},
+				})
+			}),
+			args: args{
+				mg: networkObj(),
+			},
+			want: want{
+				mg: networkObj(networkWithConditions(xpv1.Creating(), xpv1.Condition{
+					Type:    gcp.TypeGCPWarning,
+					Status:  corev1.ConditionTrue,
+					Reason:  gcp.ReasonOperationWarning,
+					Message: "DEPRECATED_RESOURCE_USED: The resource is deprecated.",
+				})),
+				cre: managed.ExternalCreation{},
+				err: nil,
+			},
+		},
 		"AlreadyExists": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				_ = r.Body.Close()
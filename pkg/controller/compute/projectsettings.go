@@ -0,0 +1,186 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/projectsettings"
+)
+
+const (
+	// Error strings.
+	errNotProjectSettings           = "managed resource is not a ProjectSettings resource"
+	errGetProjectSettings           = "cannot get GCP project"
+	errManagedProjectSettingsUpdate = "unable to update ProjectSettings managed resource"
+
+	errSetDefaultNetworkTier    = "cannot set default network tier of GCP project"
+	errSetUsageExportBucket     = "cannot set usage export bucket of GCP project"
+	errDisableUsageExportBucket = "cannot disable usage export of GCP project"
+)
+
+// SetupProjectSettings adds a controller that reconciles ProjectSettings
+// managed resources.
+func SetupProjectSettings(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.ProjectSettingsGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.ProjectSettings{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.ProjectSettingsGroupVersionKind),
+			managed.WithExternalConnecter(&projectSettingsConnector{kube: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithConnectionPublishers(),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type projectSettingsConnector struct {
+	kube client.Client
+}
+
+func (c *projectSettingsConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := compute.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &projectSettingsExternal{Service: s, kube: c.kube, projectID: projectID}, nil
+}
+
+type projectSettingsExternal struct {
+	kube client.Client
+	*compute.Service
+	projectID string
+}
+
+func (c *projectSettingsExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.ProjectSettings)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotProjectSettings)
+	}
+	observed, err := c.Projects.Get(c.projectID).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetProjectSettings)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	projectsettings.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := c.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errManagedProjectSettingsUpdate)
+		}
+	}
+
+	cr.Status.AtProvider = projectsettings.GenerateObservation(*observed)
+
+	// NOTE: The project settings exist as long as the project does. They
+	// are considered gone once deleted and the usage export we configured,
+	// if any, has been disabled.
+	if meta.WasDeleted(cr) && (cr.Spec.ForProvider.UsageExportBucket == nil || observed.UsageExportLocation == nil) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: projectsettings.IsUpToDate(&cr.Spec.ForProvider, observed),
+	}, nil
+}
+
+func (c *projectSettingsExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	// The settings of a project can not be created, only updated.
+	_, err := c.Update(ctx, mg)
+	return managed.ExternalCreation{}, err
+}
+
+func (c *projectSettingsExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.ProjectSettings)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotProjectSettings)
+	}
+
+	observed, err := c.Projects.Get(c.projectID).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetProjectSettings)
+	}
+
+	if !projectsettings.IsDefaultNetworkTierUpToDate(&cr.Spec.ForProvider, observed) {
+		req := &compute.ProjectsSetDefaultNetworkTierRequest{NetworkTier: gcp.StringValue(cr.Spec.ForProvider.DefaultNetworkTier)}
+		op, err := c.Projects.SetDefaultNetworkTier(c.projectID, req).Context(ctx).Do()
+		gcp.SetOperationWarnings(cr, op)
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errSetDefaultNetworkTier)
+		}
+	}
+
+	if !projectsettings.IsUsageExportLocationUpToDate(&cr.Spec.ForProvider, observed) {
+		op, err := c.Projects.SetUsageExportBucket(c.projectID, projectsettings.GenerateUsageExportLocation(cr.Spec.ForProvider)).Context(ctx).Do()
+		gcp.SetOperationWarnings(cr, op)
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errSetUsageExportBucket)
+		}
+	}
+
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *projectSettingsExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.ProjectSettings)
+	if !ok {
+		return errors.New(errNotProjectSettings)
+	}
+	if cr.Spec.ForProvider.UsageExportBucket == nil {
+		return nil
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+
+	// An empty usage export location disables the usage export. The default
+	// network tier can not be unset, so it is left as is.
+	_, err := c.Projects.SetUsageExportBucket(c.projectID, &compute.UsageExportLocation{}).Context(ctx).Do()
+	return errors.Wrap(err, errDisableUsageExportBucket)
+}
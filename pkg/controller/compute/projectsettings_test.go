@@ -0,0 +1,424 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	"github.com/crossplane/provider-gcp/apis/compute/v1beta1"
+	"github.com/crossplane/provider-gcp/pkg/clients/projectsettings"
+)
+
+var _ managed.ExternalConnecter = &projectSettingsConnector{}
+var _ managed.ExternalClient = &projectSettingsExternal{}
+
+const (
+	testProjectSettingsName = "test-project-settings"
+	testUsageExportBucket   = "test-usage-bucket"
+)
+
+type projectSettingsModifier func(*v1alpha1.ProjectSettings)
+
+func projectSettingsWithConditions(c ...xpv1.Condition) projectSettingsModifier {
+	return func(i *v1alpha1.ProjectSettings) { i.Status.SetConditions(c...) }
+}
+
+func projectSettingsWithNetworkTier(t string) projectSettingsModifier {
+	return func(i *v1alpha1.ProjectSettings) { i.Spec.ForProvider.DefaultNetworkTier = &t }
+}
+
+func projectSettingsWithUsageExportBucket(b string) projectSettingsModifier {
+	return func(i *v1alpha1.ProjectSettings) { i.Spec.ForProvider.UsageExportBucket = &b }
+}
+
+func projectSettingsWithObservation(p *compute.Project) projectSettingsModifier {
+	return func(i *v1alpha1.ProjectSettings) { i.Status.AtProvider = projectsettings.GenerateObservation(*p) }
+}
+
+func projectSettingsWithDeletionTimestamp(t metav1.Time) projectSettingsModifier {
+	return func(i *v1alpha1.ProjectSettings) { i.SetDeletionTimestamp(&t) }
+}
+
+func projectSettingsObj(im ...projectSettingsModifier) *v1alpha1.ProjectSettings {
+	i := &v1alpha1.ProjectSettings{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testProjectSettingsName,
+			Finalizers: []string{},
+		},
+		Spec: v1alpha1.ProjectSettingsSpec{
+			ForProvider: v1alpha1.ProjectSettingsParameters{},
+		},
+	}
+
+	for _, m := range im {
+		m(i)
+	}
+
+	return i
+}
+
+func TestProjectSettingsObserve(t *testing.T) {
+	deleted := metav1.Now()
+	uninitialized := &compute.Project{Name: projectID}
+	initialized := &compute.Project{
+		Name:               projectID,
+		DefaultNetworkTier: "STANDARD",
+		UsageExportLocation: &compute.UsageExportLocation{
+			BucketName: testUsageExportBucket,
+		},
+	}
+
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		kube    client.Client
+		args    args
+		want    want
+	}{
+		"NotProjectSettings": {
+			handler: nil,
+			args: args{
+				mg: &v1beta1.Subnetwork{},
+			},
+			want: want{
+				mg:  &v1beta1.Subnetwork{},
+				err: errors.New(errNotProjectSettings),
+			},
+		},
+		"GetFailed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Project{})
+			}),
+			args: args{
+				mg: projectSettingsObj(),
+			},
+			want: want{
+				mg:  projectSettingsObj(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetProjectSettings),
+			},
+		},
+		"NotInitialized": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(uninitialized)
+			}),
+			args: args{
+				mg: projectSettingsObj(
+					projectSettingsWithNetworkTier("STANDARD"),
+					projectSettingsWithUsageExportBucket(testUsageExportBucket)),
+			},
+			want: want{
+				mg: projectSettingsObj(
+					projectSettingsWithNetworkTier("STANDARD"),
+					projectSettingsWithUsageExportBucket(testUsageExportBucket),
+					projectSettingsWithObservation(uninitialized),
+					projectSettingsWithConditions(xpv1.Available())),
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+		"LateInitializedAndUpToDate": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(initialized)
+			}),
+			kube: &test.MockClient{
+				MockUpdate: test.NewMockUpdateFn(nil),
+			},
+			args: args{
+				mg: projectSettingsObj(),
+			},
+			want: want{
+				mg: projectSettingsObj(
+					projectSettingsWithNetworkTier("STANDARD"),
+					projectSettingsWithObservation(initialized),
+					projectSettingsWithConditions(xpv1.Available())),
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"DeletedUsageExportDisabled": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(uninitialized)
+			}),
+			args: args{
+				mg: projectSettingsObj(
+					projectSettingsWithDeletionTimestamp(deleted),
+					projectSettingsWithUsageExportBucket(testUsageExportBucket)),
+			},
+			want: want{
+				mg: projectSettingsObj(
+					projectSettingsWithDeletionTimestamp(deleted),
+					projectSettingsWithUsageExportBucket(testUsageExportBucket),
+					projectSettingsWithObservation(uninitialized)),
+				obs: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := projectSettingsExternal{
+				kube:      tc.kube,
+				projectID: projectID,
+				Service:   s,
+			}
+			obs, err := e.Observe(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProjectSettingsUpdate(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"NotProjectSettings": {
+			handler: nil,
+			args: args{
+				mg: &v1beta1.Subnetwork{},
+			},
+			want: want{
+				mg:  &v1beta1.Subnetwork{},
+				err: errors.New(errNotProjectSettings),
+			},
+		},
+		"Successful": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				switch {
+				case r.Method == http.MethodGet:
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&compute.Project{Name: projectID})
+				case strings.HasSuffix(r.URL.Path, "/setDefaultNetworkTier"):
+					req := &compute.ProjectsSetDefaultNetworkTierRequest{}
+					b, _ := ioutil.ReadAll(r.Body)
+					_ = json.Unmarshal(b, req)
+					if diff := cmp.Diff("STANDARD", req.NetworkTier); diff != "" {
+						t.Errorf("r: -want, +got:\n%s", diff)
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&compute.Operation{})
+				case strings.HasSuffix(r.URL.Path, "/setUsageExportBucket"):
+					req := &compute.UsageExportLocation{}
+					b, _ := ioutil.ReadAll(r.Body)
+					_ = json.Unmarshal(b, req)
+					if diff := cmp.Diff(testUsageExportBucket, req.BucketName); diff != "" {
+						t.Errorf("r: -want, +got:\n%s", diff)
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&compute.Operation{})
+				default:
+					t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			}),
+			args: args{
+				mg: projectSettingsObj(
+					projectSettingsWithNetworkTier("STANDARD"),
+					projectSettingsWithUsageExportBucket(testUsageExportBucket)),
+			},
+			want: want{
+				mg: projectSettingsObj(
+					projectSettingsWithNetworkTier("STANDARD"),
+					projectSettingsWithUsageExportBucket(testUsageExportBucket)),
+			},
+		},
+		"SetDefaultNetworkTierFailed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&compute.Project{Name: projectID})
+					return
+				}
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: projectSettingsObj(projectSettingsWithNetworkTier("STANDARD")),
+			},
+			want: want{
+				mg:  projectSettingsObj(projectSettingsWithNetworkTier("STANDARD")),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errSetDefaultNetworkTier),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := projectSettingsExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Update(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Update(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProjectSettingsDelete(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"NotProjectSettings": {
+			handler: nil,
+			args: args{
+				mg: &v1beta1.Subnetwork{},
+			},
+			want: want{
+				mg:  &v1beta1.Subnetwork{},
+				err: errors.New(errNotProjectSettings),
+			},
+		},
+		"NoUsageExport": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			args: args{
+				mg: projectSettingsObj(projectSettingsWithNetworkTier("STANDARD")),
+			},
+			want: want{
+				mg: projectSettingsObj(projectSettingsWithNetworkTier("STANDARD")),
+			},
+		},
+		"UsageExportDisabled": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				req := &compute.UsageExportLocation{}
+				b, _ := ioutil.ReadAll(r.Body)
+				_ = json.Unmarshal(b, req)
+				if diff := cmp.Diff(&compute.UsageExportLocation{}, req); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: projectSettingsObj(projectSettingsWithUsageExportBucket(testUsageExportBucket)),
+			},
+			want: want{
+				mg: projectSettingsObj(
+					projectSettingsWithUsageExportBucket(testUsageExportBucket),
+					projectSettingsWithConditions(xpv1.Deleting())),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := projectSettingsExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			err := e.Delete(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Delete(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
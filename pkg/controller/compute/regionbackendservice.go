@@ -0,0 +1,215 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/regionbackendservice"
+)
+
+// Error strings.
+const (
+	errNotRegionBackendService          = "managed resource is not a RegionBackendService"
+	errGetRegionBackendService          = "cannot get external RegionBackendService resource"
+	errGetRegionBackendServiceOperation = "cannot get GCP regional backend service operation"
+	errRegionBackendServiceOpFailed     = "GCP regional backend service operation failed"
+	errCreateRegionBackendService       = "cannot create external RegionBackendService resource"
+	errUpdateRegionBackendService       = "cannot update external RegionBackendService resource"
+	errDeleteRegionBackendService       = "cannot delete external RegionBackendService resource"
+	errManagedRegionBackendService      = "cannot update managed RegionBackendService resource"
+	errInvalidRegionBackendService      = "invalid RegionBackendService"
+)
+
+// SetupRegionBackendService adds a controller that reconciles
+// RegionBackendService managed resources.
+func SetupRegionBackendService(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.RegionBackendServiceGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.RegionBackendService{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.RegionBackendServiceGroupVersionKind),
+			managed.WithExternalConnecter(&rbsConnector{kube: mgr.GetClient()}),
+			managed.WithConnectionPublishers(),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type rbsConnector struct {
+	kube client.Client
+}
+
+func (c *rbsConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := compute.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &rbsExternal{kube: c.kube, Service: s, projectID: projectID}, nil
+}
+
+type rbsExternal struct {
+	kube      client.Client
+	projectID string
+	*compute.Service
+}
+
+func (e *rbsExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) { // nolint:gocyclo
+	cr, ok := mg.(*v1alpha1.RegionBackendService)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRegionBackendService)
+	}
+
+	// The annotation holds the pending insert or patch operation.
+	pending := false
+	if name := cr.GetAnnotations()[gcp.AnnotationKeyOperation]; name != "" {
+		op, err := e.RegionOperations.Get(e.projectID, cr.Spec.ForProvider.Region, name).Context(ctx).Do()
+		if resource.Ignore(gcp.IsErrorNotFound, err) != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetRegionBackendServiceOperation)
+		}
+		pending = err == nil && op.Status != gcp.OperationDone
+		if !pending {
+			meta.RemoveAnnotations(cr, gcp.AnnotationKeyOperation)
+			if err := e.kube.Update(ctx, cr); err != nil {
+				return managed.ExternalObservation{}, errors.Wrap(err, errManagedRegionBackendService)
+			}
+		}
+		if err == nil && !pending && gcp.OperationError(op) != "" {
+			return managed.ExternalObservation{}, errors.Wrap(errors.New(gcp.OperationError(op)), errRegionBackendServiceOpFailed)
+		}
+	}
+
+	observed, err := e.RegionBackendServices.Get(e.projectID, cr.Spec.ForProvider.Region, meta.GetExternalName(cr)).Context(ctx).Do()
+	if gcp.IsErrorNotFound(err) && pending {
+		// The backend service exists once its insert operation is done.
+		cr.SetConditions(xpv1.Creating())
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetRegionBackendService)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	regionbackendservice.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := e.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errManagedRegionBackendService)
+		}
+	}
+
+	cr.Status.AtProvider = regionbackendservice.GenerateBackendServiceObservation(*observed)
+	cr.SetConditions(xpv1.Available())
+
+	upToDate, err := regionbackendservice.IsUpToDate(meta.GetExternalName(cr), cr.Spec.ForProvider, observed)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	return managed.ExternalObservation{
+		ResourceExists: true,
+		// A pending patch operation is observed before changing the
+		// backend service again.
+		ResourceUpToDate: pending || upToDate,
+	}, nil
+}
+
+func (e *rbsExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RegionBackendService)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRegionBackendService)
+	}
+	if err := regionbackendservice.Validate(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errInvalidRegionBackendService)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+	bs := &compute.BackendService{}
+	regionbackendservice.GenerateBackendService(meta.GetExternalName(cr), cr.Spec.ForProvider, bs)
+	op, err := e.RegionBackendServices.Insert(e.projectID, cr.Spec.ForProvider.Region, bs).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateRegionBackendService)
+	}
+	meta.AddAnnotations(cr, map[string]string{gcp.AnnotationKeyOperation: op.Name})
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *rbsExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RegionBackendService)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRegionBackendService)
+	}
+	if err := regionbackendservice.Validate(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errInvalidRegionBackendService)
+	}
+
+	// The observed fingerprint makes GCP refuse the patch if the backend
+	// service changed since it was observed.
+	bs := &compute.BackendService{Fingerprint: cr.Status.AtProvider.Fingerprint}
+	regionbackendservice.GenerateBackendService(meta.GetExternalName(cr), cr.Spec.ForProvider, bs)
+	op, err := e.RegionBackendServices.Patch(e.projectID, cr.Spec.ForProvider.Region, meta.GetExternalName(cr), bs).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateRegionBackendService)
+	}
+
+	// Annotations are not persisted after an update, so the pending patch
+	// operation is recorded explicitly.
+	meta.AddAnnotations(cr, map[string]string{gcp.AnnotationKeyOperation: op.Name})
+	return managed.ExternalUpdate{}, errors.Wrap(e.kube.Update(ctx, cr), errManagedRegionBackendService)
+}
+
+func (e *rbsExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.RegionBackendService)
+	if !ok {
+		return errors.New(errNotRegionBackendService)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+	op, err := e.RegionBackendServices.Delete(e.projectID, cr.Spec.ForProvider.Region, meta.GetExternalName(cr)).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteRegionBackendService)
+}
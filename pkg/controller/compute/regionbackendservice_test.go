@@ -0,0 +1,488 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testRegionBackendServiceName = "ilb"
+	testRegionBackendServiceOp   = "operation-region-backend-service"
+	testRBSHealthCheck           = "regions/us-central1/healthChecks/ilb"
+	testRBSFingerprint           = "fingerprint"
+)
+
+var _ managed.ExternalConnecter = &rbsConnector{}
+var _ managed.ExternalClient = &rbsExternal{}
+
+type rbsModifier func(*v1alpha1.RegionBackendService)
+
+func rbsWithConditions(c ...xpv1.Condition) rbsModifier {
+	return func(b *v1alpha1.RegionBackendService) { b.Status.SetConditions(c...) }
+}
+
+func rbsWithOperation(op string) rbsModifier {
+	return func(b *v1alpha1.RegionBackendService) {
+		meta.AddAnnotations(b, map[string]string{gcp.AnnotationKeyOperation: op})
+	}
+}
+
+func rbsWithProtocol(p string) rbsModifier {
+	return func(b *v1alpha1.RegionBackendService) { b.Spec.ForProvider.Protocol = &p }
+}
+
+func rbsWithDraining(s int64) rbsModifier {
+	return func(b *v1alpha1.RegionBackendService) {
+		b.Spec.ForProvider.ConnectionDraining = &v1alpha1.ConnectionDraining{DrainingTimeoutSec: s}
+	}
+}
+
+func rbsWithObservation(o v1alpha1.RegionBackendServiceObservation) rbsModifier {
+	return func(b *v1alpha1.RegionBackendService) { b.Status.AtProvider = o }
+}
+
+func rbsObj(m ...rbsModifier) *v1alpha1.RegionBackendService {
+	b := &v1alpha1.RegionBackendService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        testRegionBackendServiceName,
+			Annotations: map[string]string{meta.AnnotationKeyExternalName: testRegionBackendServiceName},
+		},
+		Spec: v1alpha1.RegionBackendServiceSpec{
+			ForProvider: v1alpha1.RegionBackendServiceParameters{
+				Region:              testRegion,
+				LoadBalancingScheme: "INTERNAL",
+				HealthChecks:        []string{testRBSHealthCheck},
+			},
+		},
+	}
+
+	for _, f := range m {
+		f(b)
+	}
+
+	return b
+}
+
+func regionBackendServiceHandler(t *testing.T, op *compute.Operation, bs *compute.BackendService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_ = r.Body.Close()
+		if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+			t.Errorf("r: -want, +got:\n%s", diff)
+		}
+		if strings.Contains(r.URL.Path, "/operations/") {
+			if op == nil {
+				w.WriteHeader(http.StatusNotFound)
+			}
+			_ = json.NewEncoder(w).Encode(op)
+			return
+		}
+		if bs == nil {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(&compute.BackendService{})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(bs)
+	}
+}
+
+func TestRegionBackendServiceObserve(t *testing.T) {
+	observed := func(m ...func(*compute.BackendService)) *compute.BackendService {
+		bs := &compute.BackendService{
+			Name:                testRegionBackendServiceName,
+			LoadBalancingScheme: "INTERNAL",
+			Protocol:            "TCP",
+			HealthChecks:        []string{testRBSHealthCheck},
+			ConnectionDraining:  &compute.ConnectionDraining{DrainingTimeoutSec: 300},
+			Fingerprint:         testRBSFingerprint,
+		}
+		for _, f := range m {
+			f(bs)
+		}
+		return bs
+	}
+	available := v1alpha1.RegionBackendServiceObservation{Fingerprint: testRBSFingerprint}
+
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"NotRegionBackendService": {
+			mg: &v1alpha1.Firewall{},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotRegionBackendService),
+			},
+		},
+		"NotFound": {
+			handler: regionBackendServiceHandler(t, nil, nil),
+			mg:      rbsObj(),
+			want: want{
+				mg:  rbsObj(),
+				obs: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Available": {
+			handler: regionBackendServiceHandler(t, nil, observed()),
+			mg:      rbsObj(),
+			want: want{
+				mg: rbsObj(
+					rbsWithProtocol("TCP"),
+					rbsWithDraining(300),
+					rbsWithObservation(available),
+					rbsWithConditions(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"DrainingChanged": {
+			handler: regionBackendServiceHandler(t, nil, observed()),
+			mg:      rbsObj(rbsWithDraining(60)),
+			want: want{
+				mg: rbsObj(
+					rbsWithProtocol("TCP"),
+					rbsWithDraining(60),
+					rbsWithObservation(available),
+					rbsWithConditions(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"PatchPending": {
+			handler: regionBackendServiceHandler(t, &compute.Operation{Name: testRegionBackendServiceOp, Status: "RUNNING"}, observed()),
+			mg:      rbsObj(rbsWithOperation(testRegionBackendServiceOp), rbsWithDraining(60)),
+			want: want{
+				mg: rbsObj(
+					rbsWithOperation(testRegionBackendServiceOp),
+					rbsWithProtocol("TCP"),
+					rbsWithDraining(60),
+					rbsWithObservation(available),
+					rbsWithConditions(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"InsertPending": {
+			handler: regionBackendServiceHandler(t, &compute.Operation{Name: testRegionBackendServiceOp, Status: "RUNNING"}, nil),
+			mg:      rbsObj(rbsWithOperation(testRegionBackendServiceOp)),
+			want: want{
+				mg:  rbsObj(rbsWithOperation(testRegionBackendServiceOp), rbsWithConditions(xpv1.Creating())),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"OperationFailed": {
+			handler: regionBackendServiceHandler(t, &compute.Operation{
+				Name:   testRegionBackendServiceOp,
+				Status: "DONE",
+				Error: &compute.OperationError{Errors: []*compute.OperationErrorErrors{
+					{Message: "health check not found"},
+				}},
+			}, nil),
+			mg: rbsObj(rbsWithOperation(testRegionBackendServiceOp)),
+			want: want{
+				mg:  rbsObj(),
+				err: errors.Wrap(errors.New("health check not found"), errRegionBackendServiceOpFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := rbsExternal{
+				kube:      &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				projectID: projectID,
+				Service:   s,
+			}
+			obs, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRegionBackendServiceCreate(t *testing.T) {
+	type want struct {
+		mg     resource.Managed
+		insert *compute.BackendService
+		err    error
+	}
+
+	cases := map[string]struct {
+		fail bool
+		mg   resource.Managed
+		want want
+	}{
+		"NotRegionBackendService": {
+			mg: &v1alpha1.Firewall{},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotRegionBackendService),
+			},
+		},
+		"Created": {
+			mg: rbsObj(rbsWithProtocol("TCP"), rbsWithDraining(0)),
+			want: want{
+				mg: rbsObj(
+					rbsWithProtocol("TCP"),
+					rbsWithDraining(0),
+					rbsWithOperation(testRegionBackendServiceOp),
+					rbsWithConditions(xpv1.Creating()),
+				),
+				insert: &compute.BackendService{
+					Name:                testRegionBackendServiceName,
+					LoadBalancingScheme: "INTERNAL",
+					Protocol:            "TCP",
+					HealthChecks:        []string{testRBSHealthCheck},
+					// Draining is disabled explicitly.
+					ConnectionDraining: &compute.ConnectionDraining{},
+				},
+			},
+		},
+		"InvalidScheme": {
+			mg: rbsObj(rbsWithProtocol("HTTP")),
+			want: want{
+				mg:  rbsObj(rbsWithProtocol("HTTP")),
+				err: errors.Wrap(errors.New("INTERNAL backend services must use the TCP or UDP protocol, not HTTP"), errInvalidRegionBackendService),
+			},
+		},
+		"CreateFailed": {
+			fail: true,
+			mg:   rbsObj(),
+			want: want{
+				mg: rbsObj(rbsWithConditions(xpv1.Creating())),
+				insert: &compute.BackendService{
+					Name:                testRegionBackendServiceName,
+					LoadBalancingScheme: "INTERNAL",
+					HealthChecks:        []string{testRBSHealthCheck},
+				},
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateRegionBackendService),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var insert *compute.BackendService
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := ioutil.ReadAll(r.Body)
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				insert = &compute.BackendService{}
+				_ = json.Unmarshal(b, insert)
+				if tc.fail {
+					w.WriteHeader(http.StatusBadRequest)
+					_ = json.NewEncoder(w).Encode(&compute.Operation{})
+					return
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{Name: testRegionBackendServiceOp})
+			}))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := rbsExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.insert, insert); diff != "" {
+				t.Errorf("Create(...): -want insert, +got insert:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Create(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRegionBackendServiceUpdate(t *testing.T) {
+	type want struct {
+		mg    resource.Managed
+		patch map[string]interface{}
+		err   error
+	}
+
+	cases := map[string]struct {
+		fail bool
+		mg   resource.Managed
+		want want
+	}{
+		"NotRegionBackendService": {
+			mg: &v1alpha1.Firewall{},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotRegionBackendService),
+			},
+		},
+		"DrainingChanged": {
+			mg: rbsObj(rbsWithDraining(60), rbsWithObservation(v1alpha1.RegionBackendServiceObservation{Fingerprint: testRBSFingerprint})),
+			want: want{
+				mg: rbsObj(
+					rbsWithDraining(60),
+					rbsWithObservation(v1alpha1.RegionBackendServiceObservation{Fingerprint: testRBSFingerprint}),
+					rbsWithOperation(testRegionBackendServiceOp),
+				),
+				patch: map[string]interface{}{
+					"name":                testRegionBackendServiceName,
+					"loadBalancingScheme": "INTERNAL",
+					"healthChecks":        []interface{}{testRBSHealthCheck},
+					"connectionDraining":  map[string]interface{}{"drainingTimeoutSec": float64(60)},
+					"fingerprint":         testRBSFingerprint,
+				},
+			},
+		},
+		"PatchFailed": {
+			fail: true,
+			mg:   rbsObj(),
+			want: want{
+				mg: rbsObj(),
+				patch: map[string]interface{}{
+					"name":                testRegionBackendServiceName,
+					"loadBalancingScheme": "INTERNAL",
+					"healthChecks":        []interface{}{testRBSHealthCheck},
+				},
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateRegionBackendService),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var patch map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := ioutil.ReadAll(r.Body)
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodPatch, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.Unmarshal(b, &patch)
+				if tc.fail {
+					w.WriteHeader(http.StatusBadRequest)
+					_ = json.NewEncoder(w).Encode(&compute.Operation{})
+					return
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{Name: testRegionBackendServiceOp})
+			}))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := rbsExternal{
+				kube:      &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.patch, patch); diff != "" {
+				t.Errorf("Update(...): -want patch, +got patch:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Update(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRegionBackendServiceDelete(t *testing.T) {
+	cases := map[string]struct {
+		status int
+		mg     resource.Managed
+		want   error
+	}{
+		"NotRegionBackendService": {
+			mg:   &v1alpha1.Firewall{},
+			want: errors.New(errNotRegionBackendService),
+		},
+		"Deleted": {
+			status: http.StatusOK,
+			mg:     rbsObj(),
+		},
+		"AlreadyGone": {
+			status: http.StatusNotFound,
+			mg:     rbsObj(),
+		},
+		"DeleteFailed": {
+			status: http.StatusBadRequest,
+			mg:     rbsObj(),
+			want:   errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteRegionBackendService),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(tc.status)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := rbsExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/resourcepolicy"
+)
+
+// Error strings.
+const (
+	errNotResourcePolicy     = "managed resource is not a ResourcePolicy"
+	errGetResourcePolicy     = "cannot get external ResourcePolicy resource"
+	errCreateResourcePolicy  = "cannot create external ResourcePolicy resource"
+	errDeleteResourcePolicy  = "cannot delete external ResourcePolicy resource"
+	errManagedResourcePolicy = "cannot update managed ResourcePolicy resource"
+)
+
+// SetupResourcePolicy adds a controller that reconciles ResourcePolicy
+// managed resources.
+func SetupResourcePolicy(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.ResourcePolicyGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.ResourcePolicy{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.ResourcePolicyGroupVersionKind),
+			managed.WithExternalConnecter(&rpConnector{kube: mgr.GetClient()}),
+			managed.WithConnectionPublishers(),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type rpConnector struct {
+	kube client.Client
+}
+
+func (c *rpConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := compute.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &rpExternal{kube: c.kube, Service: s, projectID: projectID}, nil
+}
+
+type rpExternal struct {
+	kube      client.Client
+	projectID string
+	*compute.Service
+}
+
+func (e *rpExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.ResourcePolicy)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotResourcePolicy)
+	}
+	observed, err := e.ResourcePolicies.Get(e.projectID, cr.Spec.ForProvider.Region, meta.GetExternalName(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetResourcePolicy)
+	}
+
+	// Resource policies are always "up to date" because they can't be updated.
+	eo := managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	resourcepolicy.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := e.kube.Update(ctx, cr); err != nil {
+			return eo, errors.Wrap(err, errManagedResourcePolicy)
+		}
+	}
+
+	cr.Status.AtProvider = resourcepolicy.GenerateResourcePolicyObservation(*observed)
+
+	switch cr.Status.AtProvider.Status {
+	case v1alpha1.ResourcePolicyStatusCreating:
+		cr.SetConditions(xpv1.Creating())
+	case v1alpha1.ResourcePolicyStatusDeleting:
+		cr.SetConditions(xpv1.Deleting())
+	case v1alpha1.ResourcePolicyStatusReady:
+		cr.SetConditions(xpv1.Available())
+	default:
+		cr.SetConditions(xpv1.Unavailable())
+	}
+
+	return eo, nil
+}
+
+func (e *rpExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.ResourcePolicy)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotResourcePolicy)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+	rp := &compute.ResourcePolicy{}
+	resourcepolicy.GenerateResourcePolicy(meta.GetExternalName(cr), cr.Spec.ForProvider, rp)
+	op, err := e.ResourcePolicies.Insert(e.projectID, cr.Spec.ForProvider.Region, rp).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateResourcePolicy)
+}
+
+func (e *rpExternal) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	// Resource policies cannot be updated.
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *rpExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.ResourcePolicy)
+	if !ok {
+		return errors.New(errNotResourcePolicy)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+	_, err := e.ResourcePolicies.Delete(e.projectID, cr.Spec.ForProvider.Region, meta.GetExternalName(cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteResourcePolicy)
+}
@@ -0,0 +1,485 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	"github.com/crossplane/provider-gcp/pkg/clients/resourcepolicy"
+)
+
+const (
+	testResourcePolicyName = "weekly-snapshots"
+	testRegion             = "us-central1"
+)
+
+var _ managed.ExternalConnecter = &rpConnector{}
+var _ managed.ExternalClient = &rpExternal{}
+
+type resourcePolicyModifier func(*v1alpha1.ResourcePolicy)
+
+func resourcePolicyWithConditions(c ...xpv1.Condition) resourcePolicyModifier {
+	return func(i *v1alpha1.ResourcePolicy) { i.Status.SetConditions(c...) }
+}
+
+func resourcePolicyWithDescription(d string) resourcePolicyModifier {
+	return func(i *v1alpha1.ResourcePolicy) { i.Spec.ForProvider.Description = &d }
+}
+
+func resourcePolicyWithStatus(status string) resourcePolicyModifier {
+	return func(i *v1alpha1.ResourcePolicy) { i.Status.AtProvider.Status = status }
+}
+
+func resourcePolicyObj(im ...resourcePolicyModifier) *v1alpha1.ResourcePolicy {
+	i := &v1alpha1.ResourcePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       testResourcePolicyName,
+			Finalizers: []string{},
+			Annotations: map[string]string{
+				meta.AnnotationKeyExternalName: testResourcePolicyName,
+			},
+		},
+		Spec: v1alpha1.ResourcePolicySpec{
+			ForProvider: v1alpha1.ResourcePolicyParameters{
+				Region: testRegion,
+				SnapshotSchedulePolicy: &v1alpha1.ResourcePolicySnapshotSchedulePolicy{
+					Schedule: v1alpha1.ResourcePolicySnapshotSchedulePolicySchedule{
+						WeeklySchedule: &v1alpha1.ResourcePolicyWeeklyCycle{
+							DayOfWeeks: []v1alpha1.ResourcePolicyWeeklyCycleDayOfWeek{
+								{Day: "SUNDAY", StartTime: "04:00"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, m := range im {
+		m(i)
+	}
+
+	return i
+}
+
+func TestResourcePolicyObserve(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		kube    client.Client
+		args    args
+		want    want
+	}{
+		"NotResourcePolicy": {
+			handler: nil,
+			args: args{
+				mg: &v1alpha1.Firewall{},
+			},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotResourcePolicy),
+			},
+		},
+		"NotFound": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&compute.ResourcePolicy{})
+			}),
+			args: args{
+				mg: resourcePolicyObj(),
+			},
+			want: want{
+				mg:  resourcePolicyObj(),
+				err: nil,
+			},
+		},
+		"GetFailed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.ResourcePolicy{})
+			}),
+			args: args{
+				mg: resourcePolicyObj(),
+			},
+			want: want{
+				mg:  resourcePolicyObj(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetResourcePolicy),
+			},
+		},
+		"LateInitFailed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				rp := &compute.ResourcePolicy{}
+				resourcepolicy.GenerateResourcePolicy(testResourcePolicyName, resourcePolicyObj().Spec.ForProvider, rp)
+				rp.Description = "a very interesting description"
+				_ = json.NewEncoder(w).Encode(rp)
+			}),
+			kube: &test.MockClient{
+				MockUpdate: test.NewMockUpdateFn(errBoom),
+			},
+			args: args{
+				mg: resourcePolicyObj(),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg:  resourcePolicyObj(resourcePolicyWithDescription("a very interesting description")),
+				err: errors.Wrap(errBoom, errManagedResourcePolicy),
+			},
+		},
+		"Creating": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				rp := &compute.ResourcePolicy{}
+				resourcepolicy.GenerateResourcePolicy(testResourcePolicyName, resourcePolicyObj().Spec.ForProvider, rp)
+				rp.Status = v1alpha1.ResourcePolicyStatusCreating
+				_ = json.NewEncoder(w).Encode(rp)
+			}),
+			args: args{
+				mg: resourcePolicyObj(),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: resourcePolicyObj(
+					resourcePolicyWithConditions(xpv1.Creating()),
+					resourcePolicyWithStatus(v1alpha1.ResourcePolicyStatusCreating),
+				),
+			},
+		},
+		"Ready": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				rp := &compute.ResourcePolicy{}
+				resourcepolicy.GenerateResourcePolicy(testResourcePolicyName, resourcePolicyObj().Spec.ForProvider, rp)
+				rp.Status = v1alpha1.ResourcePolicyStatusReady
+				_ = json.NewEncoder(w).Encode(rp)
+			}),
+			args: args{
+				mg: resourcePolicyObj(),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: resourcePolicyObj(
+					resourcePolicyWithConditions(xpv1.Available()),
+					resourcePolicyWithStatus(v1alpha1.ResourcePolicyStatusReady),
+				),
+			},
+		},
+		"Invalid": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				rp := &compute.ResourcePolicy{}
+				resourcepolicy.GenerateResourcePolicy(testResourcePolicyName, resourcePolicyObj().Spec.ForProvider, rp)
+				rp.Status = "INVALID"
+				_ = json.NewEncoder(w).Encode(rp)
+			}),
+			args: args{
+				mg: resourcePolicyObj(),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+				mg: resourcePolicyObj(
+					resourcePolicyWithConditions(xpv1.Unavailable()),
+					resourcePolicyWithStatus("INVALID"),
+				),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := rpExternal{
+				kube:      tc.kube,
+				projectID: projectID,
+				Service:   s,
+			}
+			obs, err := e.Observe(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResourcePolicyCreate(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		cre managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		kube    client.Client
+		args    args
+		want    want
+	}{
+		"NotResourcePolicy": {
+			handler: nil,
+			args: args{
+				mg: &v1alpha1.Firewall{},
+			},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotResourcePolicy),
+			},
+		},
+		"Successful": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				i := &compute.ResourcePolicy{}
+				b, err := ioutil.ReadAll(r.Body)
+				if diff := cmp.Diff(err, nil); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				err = json.Unmarshal(b, i)
+				if diff := cmp.Diff(err, nil); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				want := &compute.ResourcePolicy{}
+				resourcepolicy.GenerateResourcePolicy(testResourcePolicyName, resourcePolicyObj().Spec.ForProvider, want)
+				if diff := cmp.Diff(want, i); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = r.Body.Close()
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: resourcePolicyObj(),
+			},
+			want: want{
+				mg:  resourcePolicyObj(resourcePolicyWithConditions(xpv1.Creating())),
+				cre: managed.ExternalCreation{},
+				err: nil,
+			},
+		},
+		"Failed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: resourcePolicyObj(),
+			},
+			want: want{
+				mg:  resourcePolicyObj(resourcePolicyWithConditions(xpv1.Creating())),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateResourcePolicy),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := rpExternal{
+				kube:      tc.kube,
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg); diff != "" {
+				t.Errorf("Create(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResourcePolicyDelete(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		kube    client.Client
+		args    args
+		want    want
+	}{
+		"NotResourcePolicy": {
+			handler: nil,
+			args: args{
+				mg: &v1alpha1.Firewall{},
+			},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotResourcePolicy),
+			},
+		},
+		"Successful": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: resourcePolicyObj(),
+			},
+			want: want{
+				mg:  resourcePolicyObj(resourcePolicyWithConditions(xpv1.Deleting())),
+				err: nil,
+			},
+		},
+		"AlreadyGone": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: resourcePolicyObj(),
+			},
+			want: want{
+				mg:  resourcePolicyObj(resourcePolicyWithConditions(xpv1.Deleting())),
+				err: nil,
+			},
+		},
+		"Failed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}),
+			args: args{
+				mg: resourcePolicyObj(),
+			},
+			want: want{
+				mg:  resourcePolicyObj(resourcePolicyWithConditions(xpv1.Deleting())),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteResourcePolicy),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := rpExternal{
+				kube:      tc.kube,
+				projectID: projectID,
+				Service:   s,
+			}
+			err := e.Delete(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg); diff != "" {
+				t.Errorf("Delete(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
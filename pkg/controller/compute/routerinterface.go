@@ -0,0 +1,179 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/router"
+)
+
+// Error strings.
+const (
+	errNotRouterInterface    = "managed resource is not a RouterInterface"
+	errGetRouter             = "cannot get GCP router"
+	errAddRouterInterface    = "cannot add interface to GCP router"
+	errUpdateRouterInterface = "cannot update interface of GCP router"
+	errDeleteRouterInterface = "cannot delete interface from GCP router"
+)
+
+// SetupRouterInterface adds a controller that reconciles RouterInterface
+// managed resources.
+func SetupRouterInterface(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.RouterInterfaceGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.RouterInterface{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.RouterInterfaceGroupVersionKind),
+			managed.WithExternalConnecter(&riConnector{kube: mgr.GetClient()}),
+			managed.WithConnectionPublishers(),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type riConnector struct {
+	kube client.Client
+}
+
+func (c *riConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := compute.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &riExternal{Service: s, projectID: projectID}, nil
+}
+
+type riExternal struct {
+	*compute.Service
+	projectID string
+}
+
+func (e *riExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RouterInterface)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRouterInterface)
+	}
+	r, err := e.Routers.Get(e.projectID, cr.Spec.ForProvider.Region, cr.Spec.ForProvider.Router).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetRouter)
+	}
+	observed := router.FindInterface(meta.GetExternalName(cr), r)
+	if observed == nil {
+		return managed.ExternalObservation{}, nil
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	router.LateInitializeRouterInterfaceSpec(&cr.Spec.ForProvider, *observed)
+
+	cr.Status.AtProvider = router.GenerateRouterInterfaceObservation(*observed)
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        router.IsRouterInterfaceUpToDate(meta.GetExternalName(cr), cr.Spec.ForProvider, *observed),
+		ResourceLateInitialized: !cmp.Equal(currentSpec, &cr.Spec.ForProvider),
+	}, nil
+}
+
+func (e *riExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RouterInterface)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRouterInterface)
+	}
+	cr.SetConditions(xpv1.Creating())
+	err := e.patch(ctx, cr)
+	return managed.ExternalCreation{}, errors.Wrap(err, errAddRouterInterface)
+}
+
+func (e *riExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RouterInterface)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRouterInterface)
+	}
+	err := e.patch(ctx, cr)
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateRouterInterface)
+}
+
+func (e *riExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.RouterInterface)
+	if !ok {
+		return errors.New(errNotRouterInterface)
+	}
+	cr.SetConditions(xpv1.Deleting())
+
+	r, err := e.Routers.Get(e.projectID, cr.Spec.ForProvider.Region, cr.Spec.ForProvider.Router).Context(ctx).Do()
+	if err != nil {
+		return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetRouter)
+	}
+	if router.FindInterface(meta.GetExternalName(cr), r) == nil {
+		return nil
+	}
+	op, err := e.Routers.Patch(e.projectID, cr.Spec.ForProvider.Region, cr.Spec.ForProvider.Router,
+		router.WithoutInterface(r, meta.GetExternalName(cr))).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteRouterInterface)
+}
+
+// patch adds the desired interface to, or updates it within, the router's
+// interfaces. The desired interface is generated on top of the observed one,
+// if any, so that fields that are not part of the spec are preserved.
+func (e *riExternal) patch(ctx context.Context, cr *v1alpha1.RouterInterface) error {
+	r, err := e.Routers.Get(e.projectID, cr.Spec.ForProvider.Region, cr.Spec.ForProvider.Router).Context(ctx).Do()
+	if err != nil {
+		return errors.Wrap(err, errGetRouter)
+	}
+	ri := &compute.RouterInterface{}
+	if observed := router.FindInterface(meta.GetExternalName(cr), r); observed != nil {
+		*ri = *observed
+	}
+	router.GenerateRouterInterface(meta.GetExternalName(cr), cr.Spec.ForProvider, ri)
+	op, err := e.Routers.Patch(e.projectID, cr.Spec.ForProvider.Region, cr.Spec.ForProvider.Router,
+		router.WithInterface(r, ri)).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
+	return err
+}
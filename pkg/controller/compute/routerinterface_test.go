@@ -0,0 +1,158 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const testVPNTunnel = "projects/my-project/regions/us-central1/vpnTunnels/tunnel-1"
+
+var _ managed.ExternalConnecter = &riConnector{}
+var _ managed.ExternalClient = &riExternal{}
+
+func riObj(ipRange string) *v1alpha1.RouterInterface {
+	return &v1alpha1.RouterInterface{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        testInterface,
+			Annotations: map[string]string{meta.AnnotationKeyExternalName: testInterface},
+		},
+		Spec: v1alpha1.RouterInterfaceSpec{
+			ForProvider: v1alpha1.RouterInterfaceParameters{
+				Region:          testRegion,
+				Router:          testRouter,
+				IPRange:         gcp.StringPtr(ipRange),
+				LinkedVPNTunnel: gcp.StringPtr(testVPNTunnel),
+			},
+		},
+	}
+}
+
+func TestRouterInterfaceObserve(t *testing.T) {
+	observed := &compute.Router{Name: testRouter, Interfaces: []*compute.RouterInterface{
+		{Name: testInterface, IpRange: "169.254.0.1/30", LinkedVpnTunnel: testVPNTunnel, ManagementType: "MANAGED_BY_USER"},
+	}}
+
+	type want struct {
+		obs managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		router *compute.Router
+		mg     resource.Managed
+		want   want
+	}{
+		"NotRouterInterface": {
+			reason: "An error should be returned if the managed resource is not a RouterInterface",
+			mg:     &v1alpha1.Firewall{},
+			want:   want{err: errors.New(errNotRouterInterface)},
+		},
+		"InterfaceNotFound": {
+			reason: "An interface that is not part of the router's interfaces does not exist",
+			router: &compute.Router{Name: testRouter},
+			mg:     riObj("169.254.0.1/30"),
+		},
+		"UpToDate": {
+			reason: "An interface that matches the desired state is up to date",
+			router: observed,
+			mg:     riObj("169.254.0.1/30"),
+			want:   want{obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"IPRangeChanged": {
+			reason: "An interface whose IP range differs from the desired state is not up to date",
+			router: observed,
+			mg:     riObj("169.254.1.1/30"),
+			want:   want{obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				_ = json.NewEncoder(w).Encode(tc.router)
+			}))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := riExternal{Service: s, projectID: projectID}
+			obs, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if cr, ok := tc.mg.(*v1alpha1.RouterInterface); ok && obs.ResourceExists {
+				if diff := cmp.Diff(xpv1.Available(), cr.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+					t.Errorf("\n%s\nObserve(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestRouterInterfaceCreate(t *testing.T) {
+	other := &compute.RouterInterface{Name: "other-interface", IpRange: "169.254.2.1/30"}
+
+	var patch *compute.Router
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		_ = r.Body.Close()
+		if r.Method == http.MethodPatch {
+			patch = &compute.Router{}
+			_ = json.Unmarshal(b, patch)
+			_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&compute.Router{Name: testRouter, Interfaces: []*compute.RouterInterface{other}})
+	}))
+	defer server.Close()
+	s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	e := riExternal{Service: s, projectID: projectID}
+	if _, err := e.Create(context.Background(), riObj("169.254.0.1/30")); err != nil {
+		t.Errorf("Create(...): unexpected error: %v", err)
+	}
+	want := &compute.Router{Interfaces: []*compute.RouterInterface{
+		other,
+		{Name: testInterface, IpRange: "169.254.0.1/30", LinkedVpnTunnel: testVPNTunnel},
+	}}
+	if diff := cmp.Diff(want, patch); diff != "" {
+		t.Errorf("Create(...): -want router patch, +got router patch:\n%s", diff)
+	}
+}
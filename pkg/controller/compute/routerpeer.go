@@ -0,0 +1,179 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/router"
+)
+
+// Error strings.
+const (
+	errNotRouterPeer    = "managed resource is not a RouterPeer"
+	errAddRouterPeer    = "cannot add BGP peer to GCP router"
+	errUpdateRouterPeer = "cannot update BGP peer of GCP router"
+	errDeleteRouterPeer = "cannot delete BGP peer from GCP router"
+)
+
+// SetupRouterPeer adds a controller that reconciles RouterPeer managed
+// resources.
+func SetupRouterPeer(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.RouterPeerGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.RouterPeer{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.RouterPeerGroupVersionKind),
+			managed.WithExternalConnecter(&rpeerConnector{kube: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithConnectionPublishers(),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type rpeerConnector struct {
+	kube client.Client
+}
+
+func (c *rpeerConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := compute.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &rpeerExternal{Service: s, projectID: projectID}, nil
+}
+
+type rpeerExternal struct {
+	*compute.Service
+	projectID string
+}
+
+func (e *rpeerExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RouterPeer)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRouterPeer)
+	}
+	r, err := e.Routers.Get(e.projectID, cr.Spec.ForProvider.Region, cr.Spec.ForProvider.Router).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetRouter)
+	}
+	observed := router.FindPeer(meta.GetExternalName(cr), r)
+	if observed == nil {
+		return managed.ExternalObservation{}, nil
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	router.LateInitializeRouterPeerSpec(&cr.Spec.ForProvider, *observed)
+
+	cr.Status.AtProvider = router.GenerateRouterPeerObservation(*observed)
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        router.IsRouterPeerUpToDate(meta.GetExternalName(cr), cr.Spec.ForProvider, *observed),
+		ResourceLateInitialized: !cmp.Equal(currentSpec, &cr.Spec.ForProvider),
+	}, nil
+}
+
+func (e *rpeerExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RouterPeer)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRouterPeer)
+	}
+	cr.SetConditions(xpv1.Creating())
+	err := e.patch(ctx, cr)
+	return managed.ExternalCreation{}, errors.Wrap(err, errAddRouterPeer)
+}
+
+func (e *rpeerExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RouterPeer)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRouterPeer)
+	}
+	err := e.patch(ctx, cr)
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateRouterPeer)
+}
+
+func (e *rpeerExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.RouterPeer)
+	if !ok {
+		return errors.New(errNotRouterPeer)
+	}
+	cr.SetConditions(xpv1.Deleting())
+
+	r, err := e.Routers.Get(e.projectID, cr.Spec.ForProvider.Region, cr.Spec.ForProvider.Router).Context(ctx).Do()
+	if err != nil {
+		return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetRouter)
+	}
+	if router.FindPeer(meta.GetExternalName(cr), r) == nil {
+		return nil
+	}
+	op, err := e.Routers.Patch(e.projectID, cr.Spec.ForProvider.Region, cr.Spec.ForProvider.Router,
+		router.WithoutPeer(r, meta.GetExternalName(cr))).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteRouterPeer)
+}
+
+// patch adds the desired BGP peer to, or updates it within, the router's BGP
+// peers. The desired BGP peer is generated on top of the observed one, if
+// any, so that fields that are not part of the spec are preserved.
+func (e *rpeerExternal) patch(ctx context.Context, cr *v1alpha1.RouterPeer) error {
+	r, err := e.Routers.Get(e.projectID, cr.Spec.ForProvider.Region, cr.Spec.ForProvider.Router).Context(ctx).Do()
+	if err != nil {
+		return errors.Wrap(err, errGetRouter)
+	}
+	p := &compute.RouterBgpPeer{}
+	if observed := router.FindPeer(meta.GetExternalName(cr), r); observed != nil {
+		*p = *observed
+	}
+	router.GenerateRouterPeer(meta.GetExternalName(cr), cr.Spec.ForProvider, p)
+	op, err := e.Routers.Patch(e.projectID, cr.Spec.ForProvider.Region, cr.Spec.ForProvider.Router,
+		router.WithPeer(r, p)).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
+	return err
+}
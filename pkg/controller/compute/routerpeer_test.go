@@ -0,0 +1,324 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testRouter    = "edge-router"
+	testPeer      = "onprem-peer"
+	testInterface = "tunnel-interface"
+)
+
+var _ managed.ExternalConnecter = &rpeerConnector{}
+var _ managed.ExternalClient = &rpeerExternal{}
+
+type rpeerModifier func(*v1alpha1.RouterPeer)
+
+func rpeerWithConditions(c ...xpv1.Condition) rpeerModifier {
+	return func(p *v1alpha1.RouterPeer) { p.Status.SetConditions(c...) }
+}
+
+func rpeerWithAdvertiseMode(m string) rpeerModifier {
+	return func(p *v1alpha1.RouterPeer) { p.Spec.ForProvider.AdvertiseMode = gcp.StringPtr(m) }
+}
+
+func rpeerWithAdvertisedGroups(g ...string) rpeerModifier {
+	return func(p *v1alpha1.RouterPeer) { p.Spec.ForProvider.AdvertisedGroups = g }
+}
+
+func rpeerObj(m ...rpeerModifier) *v1alpha1.RouterPeer {
+	p := &v1alpha1.RouterPeer{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        testPeer,
+			Annotations: map[string]string{meta.AnnotationKeyExternalName: testPeer},
+		},
+		Spec: v1alpha1.RouterPeerSpec{
+			ForProvider: v1alpha1.RouterPeerParameters{
+				Region:                  testRegion,
+				Router:                  testRouter,
+				InterfaceName:           gcp.StringPtr(testInterface),
+				IPAddress:               gcp.StringPtr("169.254.0.1"),
+				PeerIPAddress:           gcp.StringPtr("169.254.0.2"),
+				PeerASN:                 65001,
+				AdvertisedRoutePriority: gcp.Int64Ptr(100),
+				AdvertiseMode:           gcp.StringPtr("DEFAULT"),
+			},
+		},
+	}
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func testBgpPeer() *compute.RouterBgpPeer {
+	return &compute.RouterBgpPeer{
+		Name:                    testPeer,
+		InterfaceName:           testInterface,
+		IpAddress:               "169.254.0.1",
+		PeerIpAddress:           "169.254.0.2",
+		PeerAsn:                 65001,
+		AdvertisedRoutePriority: 100,
+		AdvertiseMode:           "DEFAULT",
+		ManagementType:          "MANAGED_BY_USER",
+	}
+}
+
+func TestRouterPeerObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		router *compute.Router
+		mg     resource.Managed
+		want   want
+	}{
+		"NotRouterPeer": {
+			reason: "An error should be returned if the managed resource is not a RouterPeer",
+			mg:     &v1alpha1.Firewall{},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotRouterPeer),
+			},
+		},
+		"RouterNotFound": {
+			reason: "A BGP peer of a router that does not exist does not exist",
+			mg:     rpeerObj(),
+			want: want{
+				mg: rpeerObj(),
+			},
+		},
+		"PeerNotFound": {
+			reason: "A BGP peer that is not part of the router's BGP peers does not exist",
+			router: &compute.Router{Name: testRouter, BgpPeers: []*compute.RouterBgpPeer{{Name: "other-peer"}}},
+			mg:     rpeerObj(),
+			want: want{
+				mg: rpeerObj(),
+			},
+		},
+		"UpToDate": {
+			reason: "A BGP peer that matches the desired state is up to date",
+			router: &compute.Router{Name: testRouter, BgpPeers: []*compute.RouterBgpPeer{testBgpPeer()}},
+			mg:     rpeerObj(),
+			want: want{
+				mg: rpeerObj(rpeerWithConditions(xpv1.Available()), func(p *v1alpha1.RouterPeer) {
+					p.Status.AtProvider.ManagementType = "MANAGED_BY_USER"
+				}),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"AdvertiseModeChanged": {
+			reason: "A BGP peer whose advertise mode differs from the desired state is not up to date",
+			router: &compute.Router{Name: testRouter, BgpPeers: []*compute.RouterBgpPeer{testBgpPeer()}},
+			mg:     rpeerObj(rpeerWithAdvertiseMode("CUSTOM"), rpeerWithAdvertisedGroups("ALL_SUBNETS")),
+			want: want{
+				mg: rpeerObj(rpeerWithAdvertiseMode("CUSTOM"), rpeerWithAdvertisedGroups("ALL_SUBNETS"), rpeerWithConditions(xpv1.Available()), func(p *v1alpha1.RouterPeer) {
+					p.Status.AtProvider.ManagementType = "MANAGED_BY_USER"
+				}),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if tc.router == nil {
+					w.WriteHeader(http.StatusNotFound)
+					_ = json.NewEncoder(w).Encode(&compute.Router{})
+					return
+				}
+				_ = json.NewEncoder(w).Encode(tc.router)
+			}))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := rpeerExternal{Service: s, projectID: projectID}
+			obs, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestRouterPeerReconcile(t *testing.T) {
+	other := &compute.RouterBgpPeer{Name: "other-peer", InterfaceName: "other-interface", PeerAsn: 65002}
+
+	type want struct {
+		patch *compute.Router
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason    string
+		router    *compute.Router
+		mg        resource.Managed
+		reconcile func(e *rpeerExternal, mg resource.Managed) error
+		want      want
+	}{
+		"AddPeer": {
+			reason: "Creating a BGP peer should add it to the router's existing BGP peers",
+			router: &compute.Router{Name: testRouter, BgpPeers: []*compute.RouterBgpPeer{other}},
+			mg:     rpeerObj(),
+			reconcile: func(e *rpeerExternal, mg resource.Managed) error {
+				_, err := e.Create(context.Background(), mg)
+				return err
+			},
+			want: want{
+				patch: &compute.Router{BgpPeers: []*compute.RouterBgpPeer{other, func() *compute.RouterBgpPeer {
+					p := testBgpPeer()
+					p.ManagementType = ""
+					return p
+				}()}},
+			},
+		},
+		"ChangeAdvertiseMode": {
+			reason: "Updating a BGP peer should replace it within the router's BGP peers",
+			router: &compute.Router{Name: testRouter, BgpPeers: []*compute.RouterBgpPeer{testBgpPeer(), other}},
+			mg:     rpeerObj(rpeerWithAdvertiseMode("CUSTOM"), rpeerWithAdvertisedGroups("ALL_SUBNETS")),
+			reconcile: func(e *rpeerExternal, mg resource.Managed) error {
+				_, err := e.Update(context.Background(), mg)
+				return err
+			},
+			want: want{
+				patch: &compute.Router{BgpPeers: []*compute.RouterBgpPeer{other, func() *compute.RouterBgpPeer {
+					p := testBgpPeer()
+					p.AdvertiseMode = "CUSTOM"
+					p.AdvertisedGroups = []string{"ALL_SUBNETS"}
+					return p
+				}()}},
+			},
+		},
+		"RemovePeer": {
+			reason: "Deleting a BGP peer should remove it from the router's BGP peers",
+			router: &compute.Router{Name: testRouter, BgpPeers: []*compute.RouterBgpPeer{testBgpPeer(), other}},
+			mg:     rpeerObj(),
+			reconcile: func(e *rpeerExternal, mg resource.Managed) error {
+				return e.Delete(context.Background(), mg)
+			},
+			want: want{
+				patch: &compute.Router{BgpPeers: []*compute.RouterBgpPeer{other}},
+			},
+		},
+		"RemoveLastPeer": {
+			reason: "Deleting the only BGP peer of a router should leave it without BGP peers",
+			router: &compute.Router{Name: testRouter, BgpPeers: []*compute.RouterBgpPeer{testBgpPeer()}},
+			mg:     rpeerObj(),
+			reconcile: func(e *rpeerExternal, mg resource.Managed) error {
+				return e.Delete(context.Background(), mg)
+			},
+			want: want{
+				patch: &compute.Router{BgpPeers: []*compute.RouterBgpPeer{}},
+			},
+		},
+		"AlreadyRemoved": {
+			reason: "Deleting a BGP peer that is not part of the router should not patch the router",
+			router: &compute.Router{Name: testRouter, BgpPeers: []*compute.RouterBgpPeer{other}},
+			mg:     rpeerObj(),
+			reconcile: func(e *rpeerExternal, mg resource.Managed) error {
+				return e.Delete(context.Background(), mg)
+			},
+		},
+		"PatchFailed": {
+			reason: "Errors patching the router should be returned",
+			router: &compute.Router{Name: testRouter},
+			mg:     rpeerObj(),
+			reconcile: func(e *rpeerExternal, mg resource.Managed) error {
+				_, err := e.Create(context.Background(), mg)
+				return err
+			},
+			want: want{
+				patch: &compute.Router{BgpPeers: []*compute.RouterBgpPeer{func() *compute.RouterBgpPeer {
+					p := testBgpPeer()
+					p.ManagementType = ""
+					return p
+				}()}},
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errAddRouterPeer),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var patch *compute.Router
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := ioutil.ReadAll(r.Body)
+				_ = r.Body.Close()
+				switch r.Method {
+				case http.MethodGet:
+					_ = json.NewEncoder(w).Encode(tc.router)
+				case http.MethodPatch:
+					patch = &compute.Router{}
+					_ = json.Unmarshal(b, patch)
+					if tc.want.err != nil {
+						w.WriteHeader(http.StatusBadRequest)
+					}
+					_ = json.NewEncoder(w).Encode(&compute.Operation{})
+				default:
+					t.Errorf("r: unexpected method %s", r.Method)
+				}
+			}))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &rpeerExternal{Service: s, projectID: projectID}
+			err := tc.reconcile(e, tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\n-want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.patch, patch); diff != "" {
+				t.Errorf("\n%s\n-want router patch, +got router patch:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
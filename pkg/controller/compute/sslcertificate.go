@@ -0,0 +1,233 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/sslcertificate"
+)
+
+// Error strings.
+const (
+	errNotSSLCertificate           = "managed resource is not an SSLCertificate"
+	errGetSSLCertificate           = "cannot get external SSLCertificate resource"
+	errGetSSLCertificateOperation  = "cannot get GCP SSL certificate operation"
+	errSSLCertificateOpFailed      = "GCP SSL certificate operation failed"
+	errCreateSSLCertificate        = "cannot create external SSLCertificate resource"
+	errDeleteSSLCertificate        = "cannot delete external SSLCertificate resource"
+	errManagedSSLCertificate       = "cannot update managed SSLCertificate resource"
+	errRecreateSSLCertificate      = "cannot delete external SSLCertificate resource to recreate it"
+	errSSLCertificateInUse         = "cannot delete external SSLCertificate resource to recreate it while it is in use; repoint the target proxies that use it to another certificate first"
+	errFmtImmutableDomains         = "cannot change the domains of an existing SSL certificate (%s): annotate the SSLCertificate with %s: \"true\" to recreate it, or delete and recreate it instead"
+	errFmtSSLCertificateStatus     = "SSL certificate is %s"
+	errFmtRecreatingSSLCertificate = "recreating SSL certificate because its domains changed (%s); target proxies that used it must be repointed to it once it is recreated"
+)
+
+// SetupSSLCertificate adds a controller that reconciles SSLCertificate managed
+// resources.
+func SetupSSLCertificate(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.SSLCertificateGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.SSLCertificate{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.SSLCertificateGroupVersionKind),
+			managed.WithExternalConnecter(&sslCertConnector{kube: mgr.GetClient()}),
+			managed.WithConnectionPublishers(),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type sslCertConnector struct {
+	kube client.Client
+}
+
+func (c *sslCertConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := compute.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &sslCertExternal{kube: c.kube, Service: s, projectID: projectID}, nil
+}
+
+type sslCertExternal struct {
+	kube      client.Client
+	projectID string
+	*compute.Service
+}
+
+func (e *sslCertExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.SSLCertificate)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSSLCertificate)
+	}
+
+	// The annotation holds the pending insert operation, or the pending
+	// delete operation of a certificate that is recreated.
+	pending := false
+	if name := cr.GetAnnotations()[gcp.AnnotationKeyOperation]; name != "" {
+		op, err := e.GlobalOperations.Get(e.projectID, name).Context(ctx).Do()
+		if resource.Ignore(gcp.IsErrorNotFound, err) != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetSSLCertificateOperation)
+		}
+		pending = err == nil && op.Status != gcp.OperationDone
+		if !pending {
+			meta.RemoveAnnotations(cr, gcp.AnnotationKeyOperation)
+			if err := e.kube.Update(ctx, cr); err != nil {
+				return managed.ExternalObservation{}, errors.Wrap(err, errManagedSSLCertificate)
+			}
+		}
+		if err == nil && !pending && gcp.OperationError(op) != "" {
+			return managed.ExternalObservation{}, errors.Wrap(errors.New(gcp.OperationError(op)), errSSLCertificateOpFailed)
+		}
+	}
+
+	observed, err := e.SslCertificates.Get(e.projectID, meta.GetExternalName(cr)).Context(ctx).Do()
+	if gcp.IsErrorNotFound(err) && pending {
+		// The certificate is (re)created once the pending operation is
+		// done.
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetSSLCertificate)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	sslcertificate.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := e.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errManagedSSLCertificate)
+		}
+	}
+
+	cr.Status.AtProvider = sslcertificate.GenerateSSLCertificateObservation(*observed)
+	switch cr.Status.AtProvider.ManagedStatus {
+	case sslcertificate.StatusActive:
+		cr.SetConditions(xpv1.Available())
+	case sslcertificate.StatusProvisioning, "":
+		cr.SetConditions(xpv1.Creating())
+	default:
+		cr.SetConditions(xpv1.Unavailable().WithMessage(errors.Errorf(errFmtSSLCertificateStatus, cr.Status.AtProvider.ManagedStatus).Error()))
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists: true,
+		// A pending operation is observed before changing the certificate
+		// again.
+		ResourceUpToDate: pending || sslcertificate.IsUpToDate(cr.Spec.ForProvider, *observed),
+	}, nil
+}
+
+func (e *sslCertExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.SSLCertificate)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotSSLCertificate)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+	c := &compute.SslCertificate{}
+	sslcertificate.GenerateSSLCertificate(meta.GetExternalName(cr), cr.Spec.ForProvider, c)
+	op, err := e.SslCertificates.Insert(e.projectID, c).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateSSLCertificate)
+	}
+	meta.AddAnnotations(cr, map[string]string{gcp.AnnotationKeyOperation: op.Name})
+	return managed.ExternalCreation{}, nil
+}
+
+// Update recreates a certificate whose domains changed if the SSLCertificate
+// allows it, since the domains of a certificate can not be updated. The
+// certificate is deleted, and the next observation creates it anew once the
+// delete operation is done.
+func (e *sslCertExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.SSLCertificate)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotSSLCertificate)
+	}
+
+	observed, err := e.SslCertificates.Get(e.projectID, meta.GetExternalName(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetSSLCertificate)
+	}
+	diff := sslcertificate.DomainDiff(cr.Spec.ForProvider, *observed)
+	if diff == "" {
+		return managed.ExternalUpdate{}, nil
+	}
+	if !gcp.RecreatesOnImmutable(cr) {
+		return managed.ExternalUpdate{}, errors.Errorf(errFmtImmutableDomains, diff, gcp.AnnotationKeyRecreateOnImmutable)
+	}
+
+	op, err := e.SslCertificates.Delete(e.projectID, meta.GetExternalName(cr)).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
+	if gcp.IsErrorBadRequest(err) {
+		// GCP refuses to delete a certificate that a target proxy uses.
+		return managed.ExternalUpdate{}, errors.Wrap(err, errSSLCertificateInUse)
+	}
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errRecreateSSLCertificate)
+	}
+	cr.SetConditions(xpv1.Unavailable().WithMessage(errors.Errorf(errFmtRecreatingSSLCertificate, diff).Error()))
+
+	// Annotations are not persisted after an update, so the pending
+	// operation is recorded explicitly.
+	meta.AddAnnotations(cr, map[string]string{gcp.AnnotationKeyOperation: op.Name})
+	return managed.ExternalUpdate{}, errors.Wrap(e.kube.Update(ctx, cr), errManagedSSLCertificate)
+}
+
+func (e *sslCertExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.SSLCertificate)
+	if !ok {
+		return errors.New(errNotSSLCertificate)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+	op, err := e.SslCertificates.Delete(e.projectID, meta.GetExternalName(cr)).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteSSLCertificate)
+}
@@ -0,0 +1,478 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testSSLCertificateName = "https-lb"
+	testSSLCertificateOp   = "operation-ssl-certificate"
+)
+
+var _ managed.ExternalConnecter = &sslCertConnector{}
+var _ managed.ExternalClient = &sslCertExternal{}
+
+type sslCertModifier func(*v1alpha1.SSLCertificate)
+
+func sslCertWithConditions(c ...xpv1.Condition) sslCertModifier {
+	return func(s *v1alpha1.SSLCertificate) { s.Status.SetConditions(c...) }
+}
+
+func sslCertWithOperation(op string) sslCertModifier {
+	return func(s *v1alpha1.SSLCertificate) {
+		meta.AddAnnotations(s, map[string]string{gcp.AnnotationKeyOperation: op})
+	}
+}
+
+func sslCertWithRecreate() sslCertModifier {
+	return func(s *v1alpha1.SSLCertificate) {
+		meta.AddAnnotations(s, map[string]string{gcp.AnnotationKeyRecreateOnImmutable: "true"})
+	}
+}
+
+func sslCertWithDomains(d ...string) sslCertModifier {
+	return func(s *v1alpha1.SSLCertificate) { s.Spec.ForProvider.Managed.Domains = d }
+}
+
+func sslCertWithObservation(status string) sslCertModifier {
+	return func(s *v1alpha1.SSLCertificate) {
+		s.Status.AtProvider = v1alpha1.SSLCertificateObservation{ManagedStatus: status}
+	}
+}
+
+func sslCertObj(m ...sslCertModifier) *v1alpha1.SSLCertificate {
+	s := &v1alpha1.SSLCertificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        testSSLCertificateName,
+			Annotations: map[string]string{meta.AnnotationKeyExternalName: testSSLCertificateName},
+		},
+		Spec: v1alpha1.SSLCertificateSpec{
+			ForProvider: v1alpha1.SSLCertificateParameters{
+				Managed: v1alpha1.ManagedSSLCertificate{Domains: []string{"example.com", "www.example.com"}},
+			},
+		},
+	}
+
+	for _, mod := range m {
+		mod(s)
+	}
+
+	return s
+}
+
+func sslCertObserved(status string) *compute.SslCertificate {
+	return &compute.SslCertificate{
+		Name: testSSLCertificateName,
+		Type: "MANAGED",
+		Managed: &compute.SslCertificateManagedSslCertificate{
+			Domains: []string{"example.com", "www.example.com"},
+			Status:  status,
+		},
+	}
+}
+
+// sslCertificateHandler serves the supplied operation and certificate, and
+// records the method and body of any other request. Other requests fail with
+// the supplied status, if any.
+func sslCertificateHandler(op *compute.Operation, c *compute.SslCertificate, method *string, got *map[string]interface{}, status int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		_ = r.Body.Close()
+		switch {
+		case r.Method != http.MethodGet:
+			*method = r.Method
+			_ = json.Unmarshal(b, got)
+			if status != 0 {
+				w.WriteHeader(status)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(&compute.Operation{Name: testSSLCertificateOp})
+		case strings.Contains(r.URL.Path, "/operations/"):
+			if op == nil {
+				w.WriteHeader(http.StatusNotFound)
+			}
+			_ = json.NewEncoder(w).Encode(op)
+		default:
+			if c == nil {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&compute.SslCertificate{})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(c)
+		}
+	}
+}
+
+func TestSSLCertificateObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		op   *compute.Operation
+		c    *compute.SslCertificate
+		mg   resource.Managed
+		want want
+	}{
+		"NotSSLCertificate": {
+			mg: &v1alpha1.Firewall{},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotSSLCertificate),
+			},
+		},
+		"NotFound": {
+			mg: sslCertObj(),
+			want: want{
+				mg:  sslCertObj(),
+				obs: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Active": {
+			c:  sslCertObserved("ACTIVE"),
+			mg: sslCertObj(),
+			want: want{
+				mg:  sslCertObj(sslCertWithObservation("ACTIVE"), sslCertWithConditions(xpv1.Available())),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"Provisioning": {
+			c:  sslCertObserved("PROVISIONING"),
+			mg: sslCertObj(),
+			want: want{
+				mg:  sslCertObj(sslCertWithObservation("PROVISIONING"), sslCertWithConditions(xpv1.Creating())),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"ProvisioningFailed": {
+			c:  sslCertObserved("PROVISIONING_FAILED"),
+			mg: sslCertObj(),
+			want: want{
+				mg: sslCertObj(sslCertWithObservation("PROVISIONING_FAILED"),
+					sslCertWithConditions(xpv1.Unavailable().WithMessage("SSL certificate is PROVISIONING_FAILED"))),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"DomainsDrifted": {
+			c:  sslCertObserved("ACTIVE"),
+			mg: sslCertObj(sslCertWithDomains("example.com", "api.example.com")),
+			want: want{
+				mg: sslCertObj(sslCertWithDomains("example.com", "api.example.com"),
+					sslCertWithObservation("ACTIVE"), sslCertWithConditions(xpv1.Available())),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"DomainsReordered": {
+			c:  sslCertObserved("ACTIVE"),
+			mg: sslCertObj(sslCertWithDomains("www.example.com", "example.com")),
+			want: want{
+				mg: sslCertObj(sslCertWithDomains("www.example.com", "example.com"),
+					sslCertWithObservation("ACTIVE"), sslCertWithConditions(xpv1.Available())),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"RecreateDeletePending": {
+			op: &compute.Operation{Name: testSSLCertificateOp, Status: "RUNNING"},
+			c:  sslCertObserved("ACTIVE"),
+			mg: sslCertObj(sslCertWithDomains("example.com"), sslCertWithOperation(testSSLCertificateOp)),
+			want: want{
+				mg: sslCertObj(sslCertWithDomains("example.com"), sslCertWithOperation(testSSLCertificateOp),
+					sslCertWithObservation("ACTIVE"), sslCertWithConditions(xpv1.Available())),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"RecreateDeleteDone": {
+			op: &compute.Operation{Name: testSSLCertificateOp, Status: "DONE"},
+			mg: sslCertObj(sslCertWithDomains("example.com"), sslCertWithOperation(testSSLCertificateOp)),
+			want: want{
+				mg:  sslCertObj(sslCertWithDomains("example.com")),
+				obs: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var method string
+			var body map[string]interface{}
+			server := httptest.NewServer(sslCertificateHandler(tc.op, tc.c, &method, &body, 0))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := sslCertExternal{
+				kube:      &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				projectID: projectID,
+				Service:   s,
+			}
+			obs, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSSLCertificateCreate(t *testing.T) {
+	type want struct {
+		mg     resource.Managed
+		insert map[string]interface{}
+		err    error
+	}
+
+	cases := map[string]struct {
+		status int
+		mg     resource.Managed
+		want   want
+	}{
+		"NotSSLCertificate": {
+			mg: &v1alpha1.Firewall{},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotSSLCertificate),
+			},
+		},
+		"Created": {
+			mg: sslCertObj(),
+			want: want{
+				mg: sslCertObj(sslCertWithOperation(testSSLCertificateOp), sslCertWithConditions(xpv1.Creating())),
+				insert: map[string]interface{}{
+					"name":    testSSLCertificateName,
+					"type":    "MANAGED",
+					"managed": map[string]interface{}{"domains": []interface{}{"example.com", "www.example.com"}},
+				},
+			},
+		},
+		"CreateFailed": {
+			status: http.StatusBadRequest,
+			mg:     sslCertObj(),
+			want: want{
+				mg: sslCertObj(sslCertWithConditions(xpv1.Creating())),
+				insert: map[string]interface{}{
+					"name":    testSSLCertificateName,
+					"type":    "MANAGED",
+					"managed": map[string]interface{}{"domains": []interface{}{"example.com", "www.example.com"}},
+				},
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateSSLCertificate),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var method string
+			var body map[string]interface{}
+			server := httptest.NewServer(sslCertificateHandler(nil, nil, &method, &body, tc.status))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := sslCertExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.insert, body); diff != "" {
+				t.Errorf("Create(...): -want insert, +got insert:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Create(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSSLCertificateUpdate(t *testing.T) {
+	type want struct {
+		mg     resource.Managed
+		method string
+		err    error
+	}
+
+	cases := map[string]struct {
+		c      *compute.SslCertificate
+		status int
+		mg     resource.Managed
+		want   want
+	}{
+		"NotSSLCertificate": {
+			mg: &v1alpha1.Firewall{},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotSSLCertificate),
+			},
+		},
+		"NotFound": {
+			mg: sslCertObj(sslCertWithDomains("example.com")),
+			want: want{
+				mg:  sslCertObj(sslCertWithDomains("example.com")),
+				err: errors.Wrap(gError(http.StatusNotFound, ""), errGetSSLCertificate),
+			},
+		},
+		"UpToDate": {
+			c:  sslCertObserved("ACTIVE"),
+			mg: sslCertObj(sslCertWithRecreate()),
+			want: want{
+				mg: sslCertObj(sslCertWithRecreate()),
+			},
+		},
+		"DomainsImmutable": {
+			c:  sslCertObserved("ACTIVE"),
+			mg: sslCertObj(sslCertWithDomains("example.org", "www.example.com")),
+			want: want{
+				mg: sslCertObj(sslCertWithDomains("example.org", "www.example.com")),
+				err: errors.Errorf(errFmtImmutableDomains, "added example.org; removed example.com",
+					gcp.AnnotationKeyRecreateOnImmutable),
+			},
+		},
+		"DomainsRecreated": {
+			c:  sslCertObserved("ACTIVE"),
+			mg: sslCertObj(sslCertWithRecreate(), sslCertWithDomains("example.com")),
+			want: want{
+				mg: sslCertObj(sslCertWithRecreate(), sslCertWithDomains("example.com"),
+					sslCertWithOperation(testSSLCertificateOp),
+					sslCertWithConditions(xpv1.Unavailable().WithMessage(errors.Errorf(errFmtRecreatingSSLCertificate, "removed www.example.com").Error()))),
+				method: http.MethodDelete,
+			},
+		},
+		"RecreateInUse": {
+			c:      sslCertObserved("ACTIVE"),
+			status: http.StatusBadRequest,
+			mg:     sslCertObj(sslCertWithRecreate(), sslCertWithDomains("example.com")),
+			want: want{
+				mg:     sslCertObj(sslCertWithRecreate(), sslCertWithDomains("example.com")),
+				method: http.MethodDelete,
+				err:    errors.Wrap(gError(http.StatusBadRequest, ""), errSSLCertificateInUse),
+			},
+		},
+		"RecreateFailed": {
+			c:      sslCertObserved("ACTIVE"),
+			status: http.StatusForbidden,
+			mg:     sslCertObj(sslCertWithRecreate(), sslCertWithDomains("example.com")),
+			want: want{
+				mg:     sslCertObj(sslCertWithRecreate(), sslCertWithDomains("example.com")),
+				method: http.MethodDelete,
+				err:    errors.Wrap(gError(http.StatusForbidden, ""), errRecreateSSLCertificate),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var method string
+			var body map[string]interface{}
+			server := httptest.NewServer(sslCertificateHandler(nil, tc.c, &method, &body, tc.status))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := sslCertExternal{
+				kube:      &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.method, method); diff != "" {
+				t.Errorf("Update(...): -want method, +got method:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Update(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSSLCertificateDelete(t *testing.T) {
+	cases := map[string]struct {
+		status int
+		mg     resource.Managed
+		want   error
+	}{
+		"NotSSLCertificate": {
+			mg:   &v1alpha1.Firewall{},
+			want: errors.New(errNotSSLCertificate),
+		},
+		"Deleted": {
+			status: http.StatusOK,
+			mg:     sslCertObj(),
+		},
+		"AlreadyGone": {
+			status: http.StatusNotFound,
+			mg:     sslCertObj(),
+		},
+		"DeleteFailed": {
+			status: http.StatusBadRequest,
+			mg:     sslCertObj(),
+			want:   errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteSSLCertificate),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(tc.status)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := sslCertExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
@@ -56,7 +56,7 @@ const (
 
 // SetupSubnetwork adds a controller that reconciles Subnetwork
 // managed resources.
-func SetupSubnetwork(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupSubnetwork(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
 	name := managed.ControllerName(v1beta1.SubnetworkGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -70,7 +70,9 @@ func SetupSubnetwork(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimite
 			managed.WithExternalConnecter(&subnetworkConnector{kube: mgr.GetClient()}),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithConnectionPublishers(),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
 			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
@@ -139,9 +141,10 @@ func (c *subnetworkExternal) Create(ctx context.Context, mg resource.Managed) (m
 
 	subnet := &googlecompute.Subnetwork{}
 	subnetwork.GenerateSubnetwork(meta.GetExternalName(cr), cr.Spec.ForProvider, subnet)
-	_, err := c.Subnetworks.Insert(c.projectID, cr.Spec.ForProvider.Region, subnet).
+	op, err := c.Subnetworks.Insert(c.projectID, cr.Spec.ForProvider.Region, subnet).
 		Context(ctx).
 		Do()
+	gcp.SetOperationWarnings(cr, op)
 	return managed.ExternalCreation{}, errors.Wrap(err, errCreateSubnetworkFailed)
 }
 
@@ -165,12 +168,14 @@ func (c *subnetworkExternal) Update(ctx context.Context, mg resource.Managed) (m
 	}
 	if privateAccess {
 		update := &googlecompute.SubnetworksSetPrivateIpGoogleAccessRequest{PrivateIpGoogleAccess: *cr.Spec.ForProvider.PrivateIPGoogleAccess}
-		_, err = c.Subnetworks.SetPrivateIpGoogleAccess(c.projectID, cr.Spec.ForProvider.Region, meta.GetExternalName(cr), update).Context(ctx).Do()
+		op, err := c.Subnetworks.SetPrivateIpGoogleAccess(c.projectID, cr.Spec.ForProvider.Region, meta.GetExternalName(cr), update).Context(ctx).Do()
+		gcp.SetOperationWarnings(cr, op)
 		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateSubnetworkPAFailed)
 	}
 
 	subnetUpdate := subnetwork.GenerateSubnetworkForUpdate(*cr, meta.GetExternalName(cr))
-	_, err = c.Subnetworks.Patch(c.projectID, cr.Spec.ForProvider.Region, meta.GetExternalName(cr), subnetUpdate).Context(ctx).Do()
+	op, err := c.Subnetworks.Patch(c.projectID, cr.Spec.ForProvider.Region, meta.GetExternalName(cr), subnetUpdate).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
 	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateSubnetworkFailed)
 }
 
@@ -0,0 +1,183 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/targetinstance"
+)
+
+// Error strings.
+const (
+	errNotTargetInstance          = "managed resource is not a TargetInstance"
+	errGetTargetInstance          = "cannot get external TargetInstance resource"
+	errGetTargetInstanceOperation = "cannot get GCP target instance insert operation"
+	errInsertTargetInstanceFailed = "GCP target instance insert operation failed"
+	errCreateTargetInstance       = "cannot create external TargetInstance resource"
+	errDeleteTargetInstance       = "cannot delete external TargetInstance resource"
+	errManagedTargetInstance      = "cannot update managed TargetInstance resource"
+)
+
+// SetupTargetInstance adds a controller that reconciles TargetInstance
+// managed resources.
+func SetupTargetInstance(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.TargetInstanceGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.TargetInstance{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.TargetInstanceGroupVersionKind),
+			managed.WithExternalConnecter(&tiConnector{kube: mgr.GetClient()}),
+			managed.WithConnectionPublishers(),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type tiConnector struct {
+	kube client.Client
+}
+
+func (c *tiConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := compute.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &tiExternal{kube: c.kube, Service: s, projectID: projectID}, nil
+}
+
+type tiExternal struct {
+	kube      client.Client
+	projectID string
+	*compute.Service
+}
+
+func (e *tiExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) { // nolint:gocyclo
+	cr, ok := mg.(*v1alpha1.TargetInstance)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTargetInstance)
+	}
+
+	pending := false
+	if name := cr.GetAnnotations()[gcp.AnnotationKeyOperation]; name != "" {
+		op, err := e.ZoneOperations.Get(e.projectID, cr.Spec.ForProvider.Zone, name).Context(ctx).Do()
+		if resource.Ignore(gcp.IsErrorNotFound, err) != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetTargetInstanceOperation)
+		}
+		pending = err == nil && op.Status != gcp.OperationDone
+		if !pending {
+			meta.RemoveAnnotations(cr, gcp.AnnotationKeyOperation)
+			if err := e.kube.Update(ctx, cr); err != nil {
+				return managed.ExternalObservation{}, errors.Wrap(err, errManagedTargetInstance)
+			}
+		}
+		if err == nil && !pending && gcp.OperationError(op) != "" {
+			return managed.ExternalObservation{}, errors.Wrap(errors.New(gcp.OperationError(op)), errInsertTargetInstanceFailed)
+		}
+	}
+
+	observed, err := e.TargetInstances.Get(e.projectID, cr.Spec.ForProvider.Zone, meta.GetExternalName(cr)).Context(ctx).Do()
+	if gcp.IsErrorNotFound(err) && pending {
+		// The target instance exists once its insert operation is done.
+		cr.SetConditions(xpv1.Creating())
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetTargetInstance)
+	}
+
+	// Target instances are always "up to date" because they can't be updated.
+	eo := managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	targetinstance.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := e.kube.Update(ctx, cr); err != nil {
+			return eo, errors.Wrap(err, errManagedTargetInstance)
+		}
+	}
+
+	cr.Status.AtProvider = targetinstance.GenerateTargetInstanceObservation(*observed)
+	cr.SetConditions(xpv1.Available())
+
+	return eo, nil
+}
+
+func (e *tiExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.TargetInstance)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTargetInstance)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+	ti := &compute.TargetInstance{}
+	targetinstance.GenerateTargetInstance(meta.GetExternalName(cr), cr.Spec.ForProvider, ti)
+	op, err := e.TargetInstances.Insert(e.projectID, cr.Spec.ForProvider.Zone, ti).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateTargetInstance)
+	}
+	meta.AddAnnotations(cr, map[string]string{gcp.AnnotationKeyOperation: op.Name})
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *tiExternal) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	// Target instances cannot be updated.
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *tiExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.TargetInstance)
+	if !ok {
+		return errors.New(errNotTargetInstance)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+	op, err := e.TargetInstances.Delete(e.projectID, cr.Spec.ForProvider.Zone, meta.GetExternalName(cr)).Context(ctx).Do()
+	gcp.SetOperationWarnings(cr, op)
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteTargetInstance)
+}
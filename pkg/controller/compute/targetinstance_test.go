@@ -0,0 +1,379 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testTargetInstanceName = "forwarder"
+	testTargetInstance     = "zones/us-central1-a/instances/backend"
+	testTargetInstanceOp   = "operation-target-instance-insert"
+)
+
+var _ managed.ExternalConnecter = &tiConnector{}
+var _ managed.ExternalClient = &tiExternal{}
+
+type tiModifier func(*v1alpha1.TargetInstance)
+
+func tiWithConditions(c ...xpv1.Condition) tiModifier {
+	return func(i *v1alpha1.TargetInstance) { i.Status.SetConditions(c...) }
+}
+
+func tiWithOperation(op string) tiModifier {
+	return func(i *v1alpha1.TargetInstance) {
+		meta.AddAnnotations(i, map[string]string{gcp.AnnotationKeyOperation: op})
+	}
+}
+
+func tiWithNatPolicy(p string) tiModifier {
+	return func(i *v1alpha1.TargetInstance) { i.Spec.ForProvider.NatPolicy = &p }
+}
+
+func tiWithID(id uint64) tiModifier {
+	return func(i *v1alpha1.TargetInstance) { i.Status.AtProvider.ID = id }
+}
+
+func tiObj(im ...tiModifier) *v1alpha1.TargetInstance {
+	i := &v1alpha1.TargetInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        testTargetInstanceName,
+			Annotations: map[string]string{meta.AnnotationKeyExternalName: testTargetInstanceName},
+		},
+		Spec: v1alpha1.TargetInstanceSpec{
+			ForProvider: v1alpha1.TargetInstanceParameters{
+				Zone:     testZone,
+				Instance: testTargetInstance,
+			},
+		},
+	}
+
+	for _, m := range im {
+		m(i)
+	}
+
+	return i
+}
+
+func targetInstanceHandler(t *testing.T, op *compute.Operation, ti *compute.TargetInstance) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_ = r.Body.Close()
+		if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+			t.Errorf("r: -want, +got:\n%s", diff)
+		}
+		if strings.Contains(r.URL.Path, "/operations/") {
+			if op == nil {
+				w.WriteHeader(http.StatusNotFound)
+			}
+			_ = json.NewEncoder(w).Encode(op)
+			return
+		}
+		if ti == nil {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(&compute.TargetInstance{})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(ti)
+	}
+}
+
+func TestTargetInstanceObserve(t *testing.T) {
+	observed := &compute.TargetInstance{
+		Name:      testTargetInstanceName,
+		Instance:  testTargetInstance,
+		NatPolicy: "NO_NAT",
+		Id:        42,
+	}
+
+	type args struct {
+		mg resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"NotTargetInstance": {
+			args: args{
+				mg: &v1alpha1.Firewall{},
+			},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotTargetInstance),
+			},
+		},
+		"NotFound": {
+			handler: targetInstanceHandler(t, nil, nil),
+			args: args{
+				mg: tiObj(),
+			},
+			want: want{
+				mg:  tiObj(),
+				obs: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"GetFailed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&compute.TargetInstance{})
+			}),
+			args: args{
+				mg: tiObj(),
+			},
+			want: want{
+				mg:  tiObj(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetTargetInstance),
+			},
+		},
+		"Available": {
+			handler: targetInstanceHandler(t, nil, observed),
+			args: args{
+				mg: tiObj(),
+			},
+			want: want{
+				mg: tiObj(
+					tiWithNatPolicy("NO_NAT"),
+					tiWithID(42),
+					tiWithConditions(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"InsertPending": {
+			handler: targetInstanceHandler(t, &compute.Operation{Name: testTargetInstanceOp, Status: "RUNNING"}, nil),
+			args: args{
+				mg: tiObj(tiWithOperation(testTargetInstanceOp)),
+			},
+			want: want{
+				mg: tiObj(
+					tiWithOperation(testTargetInstanceOp),
+					tiWithConditions(xpv1.Creating()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"InsertDone": {
+			handler: targetInstanceHandler(t, &compute.Operation{Name: testTargetInstanceOp, Status: "DONE"}, observed),
+			args: args{
+				mg: tiObj(tiWithOperation(testTargetInstanceOp)),
+			},
+			want: want{
+				mg: tiObj(
+					tiWithNatPolicy("NO_NAT"),
+					tiWithID(42),
+					tiWithConditions(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"InsertFailed": {
+			handler: targetInstanceHandler(t, &compute.Operation{
+				Name:   testTargetInstanceOp,
+				Status: "DONE",
+				Error: &compute.OperationError{Errors: []*compute.OperationErrorErrors{
+					{Message: "instance not found"},
+				}},
+			}, nil),
+			args: args{
+				mg: tiObj(tiWithOperation(testTargetInstanceOp)),
+			},
+			want: want{
+				mg:  tiObj(),
+				err: errors.Wrap(errors.New("instance not found"), errInsertTargetInstanceFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := tiExternal{
+				kube:      &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				projectID: projectID,
+				Service:   s,
+			}
+			obs, err := e.Observe(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTargetInstanceCreate(t *testing.T) {
+	type want struct {
+		mg     resource.Managed
+		insert *compute.TargetInstance
+		err    error
+	}
+
+	cases := map[string]struct {
+		fail bool
+		mg   resource.Managed
+		want want
+	}{
+		"NotTargetInstance": {
+			mg: &v1alpha1.Firewall{},
+			want: want{
+				mg:  &v1alpha1.Firewall{},
+				err: errors.New(errNotTargetInstance),
+			},
+		},
+		"Created": {
+			mg: tiObj(),
+			want: want{
+				mg: tiObj(
+					tiWithOperation(testTargetInstanceOp),
+					tiWithConditions(xpv1.Creating()),
+				),
+				insert: &compute.TargetInstance{Name: testTargetInstanceName, Instance: testTargetInstance},
+			},
+		},
+		"CreateFailed": {
+			fail: true,
+			mg:   tiObj(),
+			want: want{
+				mg:     tiObj(tiWithConditions(xpv1.Creating())),
+				insert: &compute.TargetInstance{Name: testTargetInstanceName, Instance: testTargetInstance},
+				err:    errors.Wrap(gError(http.StatusBadRequest, ""), errCreateTargetInstance),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var insert *compute.TargetInstance
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := ioutil.ReadAll(r.Body)
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				insert = &compute.TargetInstance{}
+				_ = json.Unmarshal(b, insert)
+				if tc.fail {
+					w.WriteHeader(http.StatusBadRequest)
+					_ = json.NewEncoder(w).Encode(&compute.Operation{})
+					return
+				}
+				_ = json.NewEncoder(w).Encode(&compute.Operation{Name: testTargetInstanceOp})
+			}))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := tiExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.insert, insert); diff != "" {
+				t.Errorf("Create(...): -want insert, +got insert:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Create(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTargetInstanceDelete(t *testing.T) {
+	cases := map[string]struct {
+		status int
+		mg     resource.Managed
+		want   error
+	}{
+		"NotTargetInstance": {
+			mg:   &v1alpha1.Firewall{},
+			want: errors.New(errNotTargetInstance),
+		},
+		"Deleted": {
+			status: http.StatusOK,
+			mg:     tiObj(),
+		},
+		"AlreadyGone": {
+			status: http.StatusNotFound,
+			mg:     tiObj(),
+		},
+		"DeleteFailed": {
+			status: http.StatusBadRequest,
+			mg:     tiObj(),
+			want:   errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteTargetInstance),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(tc.status)
+				_ = json.NewEncoder(w).Encode(&compute.Operation{})
+			}))
+			defer server.Close()
+			s, _ := compute.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := tiExternal{
+				projectID: projectID,
+				Service:   s,
+			}
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
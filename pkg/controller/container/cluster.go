@@ -52,11 +52,13 @@ const (
 	errUpdateCluster        = "cannot update GKE cluster"
 	errDeleteCluster        = "cannot delete GKE cluster"
 	errCheckClusterUpToDate = "cannot determine if GKE cluster is up to date"
+	errMaintenancePolicy    = "invalid GKE cluster maintenance policy"
 )
 
 // SetupCluster adds a controller that reconciles Cluster
-// managed resources.
-func SetupCluster(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+// managed resources. Clusters that are not available the supplied ready
+// timeout after they were created are marked as degraded.
+func SetupCluster(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout, ready time.Duration) error {
 	name := managed.ControllerName(v1beta2.ClusterGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -67,15 +69,18 @@ func SetupCluster(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter,
 		For(&v1beta2.Cluster{}).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1beta2.ClusterGroupVersionKind),
-			managed.WithExternalConnecter(&clusterConnector{kube: mgr.GetClient()}),
+			managed.WithExternalConnecter(&clusterConnector{kube: mgr.GetClient(), readyTimeout: ready}),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
 			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
 
 type clusterConnector struct {
-	kube client.Client
+	kube         client.Client
+	readyTimeout time.Duration
 }
 
 func (c *clusterConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -87,13 +92,14 @@ func (c *clusterConnector) Connect(ctx context.Context, mg resource.Managed) (ma
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
-	return &clusterExternal{cluster: s, projectID: projectID, kube: c.kube}, errors.Wrap(err, errNewClient)
+	return &clusterExternal{cluster: s, projectID: projectID, kube: c.kube, readyTimeout: c.readyTimeout}, errors.Wrap(err, errNewClient)
 }
 
 type clusterExternal struct {
-	kube      client.Client
-	cluster   *container.Service
-	projectID string
+	kube         client.Client
+	cluster      *container.Service
+	projectID    string
+	readyTimeout time.Duration
 }
 
 func (e *clusterExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) { // nolint:gocyclo
@@ -124,6 +130,7 @@ func (e *clusterExternal) Observe(ctx context.Context, mg resource.Managed) (man
 	case v1beta2.ClusterStateUnspecified, v1beta2.ClusterStateDegraded, v1beta2.ClusterStateError:
 		cr.Status.SetConditions(xpv1.Unavailable())
 	}
+	gcp.SetCreationStalled(cr, meta.GetExternalCreateSucceeded(cr), e.readyTimeout)
 
 	u, _, err := gke.IsUpToDate(meta.GetExternalName(cr), &cr.Spec.ForProvider, existing)
 	if err != nil {
@@ -149,6 +156,10 @@ func (e *clusterExternal) Create(ctx context.Context, mg resource.Managed) (mana
 		return managed.ExternalCreation{}, nil
 	}
 
+	if err := gke.ValidateMaintenancePolicy(cr.Spec.ForProvider.MaintenancePolicy); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errMaintenancePolicy)
+	}
+
 	// Generate GKE cluster from resource spec.
 	cluster := &container.Cluster{}
 	gke.GenerateCluster(meta.GetExternalName(cr), cr.Spec.ForProvider, cluster)
@@ -179,6 +190,9 @@ func (e *clusterExternal) Update(ctx context.Context, mg resource.Managed) (mana
 	if cr.Status.AtProvider.Status == v1beta2.ClusterStateReconciling || cr.Status.AtProvider.Status == v1beta2.ClusterStateProvisioning {
 		return managed.ExternalUpdate{}, nil
 	}
+	if err := gke.ValidateMaintenancePolicy(cr.Spec.ForProvider.MaintenancePolicy); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errMaintenancePolicy)
+	}
 	// We have to get the cluster again here to determine how to update.
 	existing, err := e.cluster.Projects.Locations.Clusters.Get(gke.GetFullyQualifiedName(e.projectID, cr.Spec.ForProvider, meta.GetExternalName(cr))).Context(ctx).Do()
 	if err != nil {
@@ -24,6 +24,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -41,6 +42,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane/provider-gcp/apis/container/v1beta2"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
 	gke "github.com/crossplane/provider-gcp/pkg/clients/cluster"
 )
 
@@ -86,6 +88,10 @@ func withUsername(u string) clusterModifier {
 	}
 }
 
+func withMaintenancePolicy(p *v1beta2.MaintenancePolicySpec) clusterModifier {
+	return func(i *v1beta2.Cluster) { i.Spec.ForProvider.MaintenancePolicy = p }
+}
+
 func cluster(im ...clusterModifier) *v1beta2.Cluster {
 	i := &v1beta2.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
@@ -791,6 +797,80 @@ func TestUpdate(t *testing.T) {
 				err: errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateCluster),
 			},
 		},
+		"MaintenanceExclusionsChanged": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&container.Cluster{
+						MaintenancePolicy: &container.MaintenancePolicy{
+							ResourceVersion: "abc",
+							Window: &container.MaintenanceWindow{
+								MaintenanceExclusions: map[string]container.TimeWindow{
+									"freeze": {StartTime: "2021-12-20T00:00:00Z", EndTime: "2021-12-27T00:00:00Z"},
+								},
+							},
+						},
+					})
+				case http.MethodPost:
+					if !strings.HasSuffix(r.URL.Path, ":setMaintenancePolicy") {
+						t.Errorf("r.URL.Path: want suffix :setMaintenancePolicy, got %s", r.URL.Path)
+					}
+					req := &container.SetMaintenancePolicyRequest{}
+					_ = json.NewDecoder(r.Body).Decode(req)
+					want := &container.MaintenancePolicy{
+						ResourceVersion: "abc",
+						Window: &container.MaintenanceWindow{
+							MaintenanceExclusions: map[string]container.TimeWindow{
+								"holiday": {StartTime: "2021-12-24T00:00:00Z", EndTime: "2021-12-26T00:00:00Z"},
+							},
+						},
+					}
+					if diff := cmp.Diff(want, req.MaintenancePolicy); diff != "" {
+						t.Errorf("r: -want, +got:\n%s", diff)
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&container.Operation{})
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+				}
+				_ = r.Body.Close()
+			}),
+			args: args{
+				mg: cluster(withMaintenancePolicy(&v1beta2.MaintenancePolicySpec{
+					Window: v1beta2.MaintenanceWindowSpec{
+						MaintenanceExclusions: map[string]v1beta2.TimeWindow{
+							"holiday": {StartTime: "2021-12-24T00:00:00Z", EndTime: "2021-12-26T00:00:00Z"},
+						},
+					},
+				})),
+			},
+			want: want{
+				mg: cluster(withMaintenancePolicy(&v1beta2.MaintenancePolicySpec{
+					Window: v1beta2.MaintenanceWindowSpec{
+						MaintenanceExclusions: map[string]v1beta2.TimeWindow{
+							"holiday": {StartTime: "2021-12-24T00:00:00Z", EndTime: "2021-12-26T00:00:00Z"},
+						},
+					},
+				})),
+			},
+		},
+		"InvalidRecurrence": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}),
+			args: args{
+				mg: cluster(withMaintenancePolicy(&v1beta2.MaintenancePolicySpec{
+					Window: v1beta2.MaintenanceWindowSpec{
+						RecurringWindow: &v1beta2.RecurringTimeWindow{Recurrence: gcp.StringPtr("FREQ=HOURLY")},
+					},
+				})),
+			},
+			want: want{
+				err: errors.Wrap(errors.New("recurrence rule must specify a FREQ of DAILY, WEEKLY, MONTHLY or YEARLY"), errMaintenancePolicy),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -54,7 +54,7 @@ const (
 
 // SetupNodePool adds a controller that reconciles NodePool managed
 // resources.
-func SetupNodePool(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupNodePool(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
 	name := managed.ControllerName(v1beta1.NodePoolGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -67,7 +67,9 @@ func SetupNodePool(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter,
 			resource.ManagedKind(v1beta1.NodePoolGroupVersionKind),
 			managed.WithExternalConnecter(&nodePoolConnector{kube: mgr.GetClient()}),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
 			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
 			managed.WithLogger(l),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
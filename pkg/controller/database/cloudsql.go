@@ -18,6 +18,7 @@ package database
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
@@ -55,21 +56,29 @@ const (
 	errGetFailed        = "cannot get the CloudSQL instance"
 	errGeneratePassword = "cannot generate root password"
 	errCheckUpToDate    = "cannot determine if CloudSQL instance is up to date"
+	errListFlags        = "cannot list the CloudSQL database flags"
+)
+
+const (
+	reasonDatabaseFlags event.Reason = "UpdatedDatabaseFlags"
 )
 
 // SetupCloudSQLInstance adds a controller that reconciles
-// CloudSQLInstance managed resources.
-func SetupCloudSQLInstance(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+// CloudSQLInstance managed resources. Instances that are not available the
+// supplied ready timeout after they were created are marked as degraded.
+func SetupCloudSQLInstance(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout, ready time.Duration) error {
 	name := managed.ControllerName(v1beta1.CloudSQLInstanceGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1beta1.CloudSQLInstanceGroupVersionKind),
-		managed.WithExternalConnecter(&cloudsqlConnector{kube: mgr.GetClient()}),
-		managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient()), managed.NewNameAsExternalName(mgr.GetClient()), &cloudsqlTagger{kube: mgr.GetClient()}),
+		managed.WithExternalConnecter(&cloudsqlConnector{kube: mgr.GetClient(), record: record, readyTimeout: ready}),
+		managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient()), gcp.NewNameAsConventionalExternalName(mgr.GetClient()), &cloudsqlTagger{kube: mgr.GetClient()}),
 		managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 		managed.WithPollInterval(poll),
+		managed.WithTimeout(timeout),
 		managed.WithLogger(l.WithValues("controller", name)),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+		managed.WithRecorder(record))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
@@ -81,25 +90,34 @@ func SetupCloudSQLInstance(mgr ctrl.Manager, l logging.Logger, rl workqueue.Rate
 }
 
 type cloudsqlConnector struct {
-	kube client.Client
+	kube         client.Client
+	record       event.Recorder
+	readyTimeout time.Duration
 }
 
 func (c *cloudsqlConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	cr, ok := mg.(*v1beta1.CloudSQLInstance)
+	if !ok {
+		return nil, errors.New(errNotCloudSQL)
+	}
+	projectID, opts, err := gcp.GetRegionalAuthInfo(ctx, c.kube, mg, gcp.ServiceSQLAdmin, cr.Spec.ForProvider.Region)
 	if err != nil {
 		return nil, err
 	}
-	s, err := sqladmin.NewService(ctx, opts)
+	s, err := sqladmin.NewService(ctx, opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
-	return &cloudsqlExternal{kube: c.kube, db: s.Instances, projectID: projectID}, nil
+	return &cloudsqlExternal{kube: c.kube, db: s.Instances, flags: s.Flags, record: c.record, projectID: projectID, readyTimeout: c.readyTimeout}, nil
 }
 
 type cloudsqlExternal struct {
-	kube      client.Client
-	db        *sqladmin.InstancesService
-	projectID string
+	kube         client.Client
+	db           *sqladmin.InstancesService
+	flags        *sqladmin.FlagsService
+	record       event.Recorder
+	projectID    string
+	readyTimeout time.Duration
 }
 
 func (c *cloudsqlExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -129,6 +147,7 @@ func (c *cloudsqlExternal) Observe(ctx context.Context, mg resource.Managed) (ma
 	case v1beta1.StateCreationFailed, v1beta1.StateSuspended, v1beta1.StateMaintenance, v1beta1.StateUnknownState:
 		cr.Status.SetConditions(xpv1.Unavailable())
 	}
+	gcp.SetCreationStalled(cr, meta.GetExternalCreateSucceeded(cr), c.readyTimeout)
 
 	upToDate, err := cloudsql.IsUpToDate(meta.GetExternalName(cr), &cr.Spec.ForProvider, instance)
 	if err != nil {
@@ -178,12 +197,45 @@ func (c *cloudsqlExternal) Update(ctx context.Context, mg resource.Managed) (man
 	if cr.Status.AtProvider.State == v1beta1.StateCreating {
 		return managed.ExternalUpdate{}, nil
 	}
+	existing, err := c.db.Get(c.projectID, meta.GetExternalName(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetFailed)
+	}
 	instance := &sqladmin.DatabaseInstance{}
 	cloudsql.GenerateDatabaseInstance(meta.GetExternalName(cr), cr.Spec.ForProvider, instance)
+	cloudsql.GenerateLabelRemovals(existing, instance)
+	var changed []string
+	if existing.Settings != nil {
+		// NOTE: The settings version guards against overwriting settings that
+		// were changed since we observed them.
+		instance.Settings.SettingsVersion = existing.Settings.SettingsVersion
+		changed = cloudsql.DatabaseFlagChanges(cr.Spec.ForProvider.Settings.DatabaseFlags, existing.Settings.DatabaseFlags)
+	}
 	// TODO(muvaf): the returned operation handle could help us not to send Patch
 	// request aggressively.
-	_, err := c.db.Patch(c.projectID, meta.GetExternalName(cr), instance).Context(ctx).Do()
-	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	if _, err := c.db.Patch(c.projectID, meta.GetExternalName(cr), instance).Context(ctx).Do(); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+	if len(changed) != 0 {
+		c.recordFlagChanges(ctx, cr, existing.DatabaseVersion, changed)
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+// recordFlagChanges records an event for the supplied changed database flags,
+// noting those that restart the instance.
+func (c *cloudsqlExternal) recordFlagChanges(ctx context.Context, cr *v1beta1.CloudSQLInstance, version string, changed []string) {
+	flags, err := c.flags.List().DatabaseVersion(version).Context(ctx).Do()
+	if err != nil {
+		c.record.Event(cr, event.Warning(reasonDatabaseFlags, errors.Wrap(err, errListFlags)))
+		return
+	}
+	restart := cloudsql.RestartRequiredFlags(changed, flags.Items)
+	if len(restart) == 0 {
+		c.record.Event(cr, event.Normal(reasonDatabaseFlags, fmt.Sprintf("Updated database flags %s", strings.Join(changed, ", "))))
+		return
+	}
+	c.record.Event(cr, event.Normal(reasonDatabaseFlags, fmt.Sprintf("Updated database flags %s, the instance is restarted to apply %s", strings.Join(changed, ", "), strings.Join(restart, ", "))))
 }
 
 func (c *cloudsqlExternal) Delete(ctx context.Context, mg resource.Managed) error {
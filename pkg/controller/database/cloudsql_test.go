@@ -22,23 +22,29 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane/provider-gcp/apis/database/v1beta1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
 	"github.com/crossplane/provider-gcp/pkg/clients/cloudsql"
 )
 
@@ -61,6 +67,10 @@ func withProviderState(s string) instanceModifier {
 	return func(i *v1beta1.CloudSQLInstance) { i.Status.AtProvider.State = s }
 }
 
+func withExternalCreateSucceeded(t time.Time) instanceModifier {
+	return func(i *v1beta1.CloudSQLInstance) { meta.SetExternalCreateSucceeded(i, t) }
+}
+
 func withPublicIP(ip string) instanceModifier {
 	return func(i *v1beta1.CloudSQLInstance) {
 		i.Status.AtProvider.IPAddresses = append(i.Status.AtProvider.IPAddresses, &v1beta1.IPMapping{
@@ -144,6 +154,18 @@ func gError(code int, message string) *googleapi.Error {
 	}
 }
 
+type eventRecorder struct {
+	events []event.Event
+}
+
+func (r *eventRecorder) Event(_ runtime.Object, e event.Event) { r.events = append(r.events, e) }
+
+func (r *eventRecorder) WithAnnotations(_ ...string) event.Recorder { return r }
+
+func withDatabaseFlags(f ...*v1beta1.DatabaseFlags) instanceModifier {
+	return func(i *v1beta1.CloudSQLInstance) { i.Spec.ForProvider.Settings.DatabaseFlags = f }
+}
+
 var _ managed.ExternalConnecter = &cloudsqlConnector{}
 var _ managed.ExternalClient = &cloudsqlExternal{}
 
@@ -157,11 +179,22 @@ func TestObserve(t *testing.T) {
 		err error
 	}
 
+	created := time.Now().Add(-2 * time.Hour)
+	creating := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.Body.Close()
+		w.WriteHeader(http.StatusOK)
+		db := &sqladmin.DatabaseInstance{}
+		cloudsql.GenerateDatabaseInstance(meta.GetExternalName(instance()), instance().Spec.ForProvider, db)
+		db.State = v1beta1.StateCreating
+		_ = json.NewEncoder(w).Encode(db)
+	})
+
 	cases := map[string]struct {
-		handler http.Handler
-		kube    client.Client
-		args    args
-		want    want
+		handler      http.Handler
+		kube         client.Client
+		readyTimeout time.Duration
+		args         args
+		want         want
 	}{
 		"NotFound": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -245,6 +278,41 @@ func TestObserve(t *testing.T) {
 				mg: instance(withProviderState(v1beta1.StateCreating), withConditions(xpv1.Creating())),
 			},
 		},
+		"CreationStalled": {
+			handler:      creating,
+			readyTimeout: time.Hour,
+			args: args{
+				mg: instance(withExternalCreateSucceeded(created)),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: connDetails("", ""),
+				},
+				mg: instance(withExternalCreateSucceeded(created), withProviderState(v1beta1.StateCreating), withConditions(xpv1.Creating(), xpv1.Condition{
+					Type:    gcp.TypeDegraded,
+					Status:  corev1.ConditionTrue,
+					Reason:  gcp.ReasonCreationStalled,
+					Message: "resource is not available more than 1h0m0s after it was created",
+				})),
+			},
+		},
+		"CreatingWithinReadyTimeout": {
+			handler:      creating,
+			readyTimeout: 3 * time.Hour,
+			args: args{
+				mg: instance(withExternalCreateSucceeded(created)),
+			},
+			want: want{
+				obs: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: connDetails("", ""),
+				},
+				mg: instance(withExternalCreateSucceeded(created), withProviderState(v1beta1.StateCreating), withConditions(xpv1.Creating())),
+			},
+		},
 		"Unavailable": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				_ = r.Body.Close()
@@ -308,9 +376,10 @@ func TestObserve(t *testing.T) {
 			defer server.Close()
 			s, _ := sqladmin.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
 			e := cloudsqlExternal{
-				kube:      tc.kube,
-				projectID: projectID,
-				db:        s.Instances,
+				kube:         tc.kube,
+				projectID:    projectID,
+				db:           s.Instances,
+				readyTimeout: tc.readyTimeout,
 			}
 			obs, err := e.Observe(context.Background(), tc.args.mg)
 			if tc.want.err != nil && err != nil {
@@ -571,9 +640,18 @@ func TestUpdate(t *testing.T) {
 		mg resource.Managed
 	}
 	type want struct {
-		mg  resource.Managed
-		upd managed.ExternalUpdate
-		err error
+		mg     resource.Managed
+		upd    managed.ExternalUpdate
+		events []event.Event
+		err    error
+	}
+
+	flags := func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(&sqladmin.FlagsListResponse{Items: []*sqladmin.Flag{
+			{Name: "max_connections", RequiresRestart: true},
+			{Name: "log_min_duration_statement"},
+		}})
 	}
 
 	cases := map[string]struct {
@@ -585,6 +663,11 @@ func TestUpdate(t *testing.T) {
 		"Successful": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				_ = r.Body.Close()
+				if r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&sqladmin.DatabaseInstance{})
+					return
+				}
 				if diff := cmp.Diff(http.MethodPatch, r.Method); diff != "" {
 					t.Errorf("r: -want, +got:\n%s", diff)
 				}
@@ -611,9 +694,194 @@ func TestUpdate(t *testing.T) {
 				err: nil,
 			},
 		},
+		"RemovedLabel": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					_ = r.Body.Close()
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&sqladmin.DatabaseInstance{Settings: &sqladmin.Settings{
+						UserLabels: map[string]string{"team": "payments"},
+					}})
+					return
+				}
+				if diff := cmp.Diff(http.MethodPatch, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				instance := &struct {
+					Settings struct {
+						UserLabels map[string]*string `json:"userLabels"`
+					} `json:"settings"`
+				}{}
+				_ = json.NewDecoder(r.Body).Decode(instance)
+				_ = r.Body.Close()
+				if diff := cmp.Diff(map[string]*string{"team": nil}, instance.Settings.UserLabels); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+			}),
+			args: args{
+				mg: instance(),
+			},
+			want: want{
+				mg:  instance(),
+				err: nil,
+			},
+		},
+		"AddedFlag": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/flags") {
+					_ = r.Body.Close()
+					flags(w)
+					return
+				}
+				if r.Method == http.MethodGet {
+					_ = r.Body.Close()
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&sqladmin.DatabaseInstance{Settings: &sqladmin.Settings{
+						SettingsVersion: 3,
+						DatabaseFlags:   []*sqladmin.DatabaseFlags{{Name: "log_min_duration_statement", Value: "100"}},
+					}})
+					return
+				}
+				if diff := cmp.Diff(http.MethodPatch, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				instance := &sqladmin.DatabaseInstance{}
+				_ = json.NewDecoder(r.Body).Decode(instance)
+				_ = r.Body.Close()
+				if diff := cmp.Diff(int64(3), instance.Settings.SettingsVersion); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				want := []*sqladmin.DatabaseFlags{{Name: "log_min_duration_statement", Value: "100"}, {Name: "max_connections", Value: "200"}}
+				if diff := cmp.Diff(want, instance.Settings.DatabaseFlags); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+			}),
+			args: args{
+				mg: instance(withDatabaseFlags(
+					&v1beta1.DatabaseFlags{Name: "log_min_duration_statement", Value: "100"},
+					&v1beta1.DatabaseFlags{Name: "max_connections", Value: "200"},
+				)),
+			},
+			want: want{
+				mg: instance(withDatabaseFlags(
+					&v1beta1.DatabaseFlags{Name: "log_min_duration_statement", Value: "100"},
+					&v1beta1.DatabaseFlags{Name: "max_connections", Value: "200"},
+				)),
+				events: []event.Event{event.Normal(reasonDatabaseFlags, "Updated database flags max_connections, the instance is restarted to apply max_connections")},
+			},
+		},
+		"ChangedFlag": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if strings.HasSuffix(r.URL.Path, "/flags") {
+					flags(w)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				if r.Method == http.MethodGet {
+					_ = json.NewEncoder(w).Encode(&sqladmin.DatabaseInstance{Settings: &sqladmin.Settings{
+						DatabaseFlags: []*sqladmin.DatabaseFlags{{Name: "log_min_duration_statement", Value: "100"}},
+					}})
+					return
+				}
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+			}),
+			args: args{
+				mg: instance(withDatabaseFlags(&v1beta1.DatabaseFlags{Name: "log_min_duration_statement", Value: "500"})),
+			},
+			want: want{
+				mg:     instance(withDatabaseFlags(&v1beta1.DatabaseFlags{Name: "log_min_duration_statement", Value: "500"})),
+				events: []event.Event{event.Normal(reasonDatabaseFlags, "Updated database flags log_min_duration_statement")},
+			},
+		},
+		"RemovedFlag": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.HasSuffix(r.URL.Path, "/flags") {
+					_ = r.Body.Close()
+					flags(w)
+					return
+				}
+				if r.Method == http.MethodGet {
+					_ = r.Body.Close()
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&sqladmin.DatabaseInstance{Settings: &sqladmin.Settings{
+						DatabaseFlags: []*sqladmin.DatabaseFlags{
+							{Name: "log_min_duration_statement", Value: "100"},
+							{Name: "max_connections", Value: "200"},
+						},
+					}})
+					return
+				}
+				instance := &sqladmin.DatabaseInstance{}
+				_ = json.NewDecoder(r.Body).Decode(instance)
+				_ = r.Body.Close()
+				want := []*sqladmin.DatabaseFlags{{Name: "log_min_duration_statement", Value: "100"}}
+				if diff := cmp.Diff(want, instance.Settings.DatabaseFlags); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+			}),
+			args: args{
+				mg: instance(withDatabaseFlags(&v1beta1.DatabaseFlags{Name: "log_min_duration_statement", Value: "100"})),
+			},
+			want: want{
+				mg:     instance(withDatabaseFlags(&v1beta1.DatabaseFlags{Name: "log_min_duration_statement", Value: "100"})),
+				events: []event.Event{event.Normal(reasonDatabaseFlags, "Updated database flags max_connections, the instance is restarted to apply max_connections")},
+			},
+		},
+		"ListFlagsFails": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if strings.HasSuffix(r.URL.Path, "/flags") {
+					w.WriteHeader(http.StatusBadRequest)
+					_ = json.NewEncoder(w).Encode(&sqladmin.FlagsListResponse{})
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				if r.Method == http.MethodGet {
+					_ = json.NewEncoder(w).Encode(&sqladmin.DatabaseInstance{Settings: &sqladmin.Settings{}})
+					return
+				}
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+			}),
+			args: args{
+				mg: instance(withDatabaseFlags(&v1beta1.DatabaseFlags{Name: "max_connections", Value: "200"})),
+			},
+			want: want{
+				mg:     instance(withDatabaseFlags(&v1beta1.DatabaseFlags{Name: "max_connections", Value: "200"})),
+				events: []event.Event{event.Warning(reasonDatabaseFlags, errors.Wrap(gError(http.StatusBadRequest, ""), errListFlags))},
+			},
+		},
+		"GetFails": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&sqladmin.DatabaseInstance{})
+			}),
+			args: args{
+				mg: instance(),
+			},
+			want: want{
+				mg:  instance(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetFailed),
+			},
+		},
 		"PatchFails": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				_ = r.Body.Close()
+				if r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&sqladmin.DatabaseInstance{})
+					return
+				}
 				if diff := cmp.Diff(http.MethodPatch, r.Method); diff != "" {
 					t.Errorf("r: -want, +got:\n%s", diff)
 				}
@@ -643,10 +911,13 @@ func TestUpdate(t *testing.T) {
 			server := httptest.NewServer(tc.handler)
 			defer server.Close()
 			s, _ := sqladmin.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			record := &eventRecorder{}
 			e := cloudsqlExternal{
 				kube:      tc.kube,
 				projectID: projectID,
 				db:        s.Instances,
+				flags:     s.Flags,
+				record:    record,
 			}
 			upd, err := e.Update(context.Background(), tc.args.mg)
 			if tc.want.err != nil && err != nil {
@@ -666,6 +937,9 @@ func TestUpdate(t *testing.T) {
 				if diff := cmp.Diff(tc.want.upd, upd); diff != "" {
 					t.Errorf("Update(...): -want, +got:\n%s", diff)
 				}
+				if diff := cmp.Diff(tc.want.events, record.events); diff != "" {
+					t.Errorf("Update(...): -want events, +got events:\n%s", diff)
+				}
 			}
 
 		})
@@ -0,0 +1,161 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"time"
+
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/database/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/sslcert"
+)
+
+const (
+	errNotSSLCert    = "managed resource is not a SSLCert custom resource"
+	errGetSSLCert    = "cannot get the CloudSQL client certificate"
+	errCreateSSLCert = "cannot create the CloudSQL client certificate"
+	errDeleteSSLCert = "cannot delete the CloudSQL client certificate"
+)
+
+// SetupSSLCert adds a controller that reconciles SSLCert managed resources.
+func SetupSSLCert(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.SSLCertGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.SSLCert{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.SSLCertGroupVersionKind),
+			// The external name is the fingerprint of the certificate,
+			// which is only known once it is created.
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithExternalConnecter(&sslCertConnector{kube: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type sslCertConnector struct {
+	kube client.Client
+}
+
+func (c *sslCertConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := sqladmin.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &sslCertExternal{certs: s.SslCerts, projectID: projectID}, nil
+}
+
+type sslCertExternal struct {
+	certs     sslcert.Client
+	projectID string
+}
+
+func (e *sslCertExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.SSLCert)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSSLCert)
+	}
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cert, err := e.certs.Get(e.projectID, gcp.StringValue(cr.Spec.ForProvider.Instance), meta.GetExternalName(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetSSLCert)
+	}
+
+	cr.Status.AtProvider = sslcert.GenerateSSLCertObservation(*cert)
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists: true,
+		// Client certificates can not be updated, only created and deleted.
+		ResourceUpToDate:  true,
+		ConnectionDetails: sslcert.GetConnectionDetails(cert, "", nil),
+	}, nil
+}
+
+func (e *sslCertExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.SSLCert)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotSSLCert)
+	}
+	cr.SetConditions(xpv1.Creating())
+
+	// Technically Instance can be nil, but reference resolution should
+	// always make sure a value is set before we get to this point.
+	rsp, err := e.certs.Insert(e.projectID, gcp.StringValue(cr.Spec.ForProvider.Instance), &sqladmin.SslCertsInsertRequest{
+		CommonName: cr.Spec.ForProvider.CommonName,
+	}).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateSSLCert)
+	}
+	if rsp.ClientCert == nil || rsp.ClientCert.CertInfo == nil {
+		return managed.ExternalCreation{}, errors.New(errCreateSSLCert)
+	}
+
+	meta.SetExternalName(cr, rsp.ClientCert.CertInfo.Sha1Fingerprint)
+
+	return managed.ExternalCreation{
+		ExternalNameAssigned: true,
+		ConnectionDetails:    sslcert.GetConnectionDetails(rsp.ClientCert.CertInfo, rsp.ClientCert.CertPrivateKey, rsp.ServerCaCert),
+	}, nil
+}
+
+func (e *sslCertExternal) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	// Client certificates are immutable, the Cloud SQL Admin API does not
+	// provide an update method for them.
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *sslCertExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.SSLCert)
+	if !ok {
+		return errors.New(errNotSSLCert)
+	}
+	cr.SetConditions(xpv1.Deleting())
+
+	_, err := e.certs.Delete(e.projectID, gcp.StringValue(cr.Spec.ForProvider.Instance), meta.GetExternalName(cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteSSLCert)
+}
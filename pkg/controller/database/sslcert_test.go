@@ -0,0 +1,322 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/option"
+	sqladmin "google.golang.org/api/sqladmin/v1beta4"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/database/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	certInstance    = "test-sql"
+	certFingerprint = "fingerprint"
+	certCommonName  = "client"
+	certPEM         = "client-cert"
+	certPrivateKey  = "client-key"
+	certServerCA    = "server-ca"
+)
+
+var _ managed.ExternalConnecter = &sslCertConnector{}
+var _ managed.ExternalClient = &sslCertExternal{}
+
+type sslCertModifier func(*v1alpha1.SSLCert)
+
+func sslCertWithConditions(c ...xpv1.Condition) sslCertModifier {
+	return func(i *v1alpha1.SSLCert) { i.Status.SetConditions(c...) }
+}
+
+func sslCertWithExternalName(n string) sslCertModifier {
+	return func(i *v1alpha1.SSLCert) { meta.SetExternalName(i, n) }
+}
+
+func sslCertWithAtProvider(o v1alpha1.SSLCertObservation) sslCertModifier {
+	return func(i *v1alpha1.SSLCert) { i.Status.AtProvider = o }
+}
+
+func sslCert(im ...sslCertModifier) *v1alpha1.SSLCert {
+	i := &v1alpha1.SSLCert{
+		Spec: v1alpha1.SSLCertSpec{
+			ForProvider: v1alpha1.SSLCertParameters{
+				Instance:   gcp.StringPtr(certInstance),
+				CommonName: certCommonName,
+			},
+		},
+	}
+	for _, m := range im {
+		m(i)
+	}
+	return i
+}
+
+func TestSSLCertObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"NotCreated": {
+			reason: "A client certificate without an external name should not exist",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			}),
+			mg: sslCert(),
+			want: want{
+				mg:  sslCert(),
+				obs: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"NotFound": {
+			reason: "A client certificate that is not found should not exist",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&sqladmin.SslCert{})
+			}),
+			mg: sslCert(sslCertWithExternalName(certFingerprint)),
+			want: want{
+				mg:  sslCert(sslCertWithExternalName(certFingerprint)),
+				obs: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"GetFailed": {
+			reason: "Errors getting the client certificate should be returned",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&sqladmin.SslCert{})
+			}),
+			mg: sslCert(sslCertWithExternalName(certFingerprint)),
+			want: want{
+				mg:  sslCert(sslCertWithExternalName(certFingerprint)),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetSSLCert),
+			},
+		},
+		"UpToDate": {
+			reason: "An existing client certificate should always be up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sqladmin.SslCert{
+					Cert:            certPEM,
+					CommonName:      certCommonName,
+					Sha1Fingerprint: certFingerprint,
+				})
+			}),
+			mg: sslCert(sslCertWithExternalName(certFingerprint)),
+			want: want{
+				mg: sslCert(
+					sslCertWithExternalName(certFingerprint),
+					sslCertWithAtProvider(v1alpha1.SSLCertObservation{Sha1Fingerprint: certFingerprint}),
+					sslCertWithConditions(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						v1alpha1.SSLCertSecretClientCertificateKey: []byte(certPEM),
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := sqladmin.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := sslCertExternal{certs: s.SslCerts, projectID: projectID}
+			obs, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSSLCertCreate(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		cre managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"Successful": {
+			reason: "The fingerprint of a created client certificate should be its external name",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				req := &sqladmin.SslCertsInsertRequest{}
+				_ = json.NewDecoder(r.Body).Decode(req)
+				_ = r.Body.Close()
+				if diff := cmp.Diff(certCommonName, req.CommonName); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sqladmin.SslCertsInsertResponse{
+					ClientCert: &sqladmin.SslCertDetail{
+						CertInfo:       &sqladmin.SslCert{Cert: certPEM, Sha1Fingerprint: certFingerprint},
+						CertPrivateKey: certPrivateKey,
+					},
+					ServerCaCert: &sqladmin.SslCert{Cert: certServerCA},
+				})
+			}),
+			mg: sslCert(),
+			want: want{
+				mg: sslCert(
+					sslCertWithExternalName(certFingerprint),
+					sslCertWithConditions(xpv1.Creating()),
+				),
+				cre: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						v1alpha1.SSLCertSecretClientCertificateKey:   []byte(certPEM),
+						v1alpha1.SSLCertSecretClientPrivateKeyKey:    []byte(certPrivateKey),
+						v1alpha1.SSLCertSecretServerCACertificateKey: []byte(certServerCA),
+					},
+				},
+			},
+		},
+		"Failed": {
+			reason: "Errors creating the client certificate should be returned",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&sqladmin.SslCertsInsertResponse{})
+			}),
+			mg: sslCert(),
+			want: want{
+				mg:  sslCert(sslCertWithConditions(xpv1.Creating())),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateSSLCert),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := sqladmin.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := sslCertExternal{certs: s.SslCerts, projectID: projectID}
+			cre, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cre, cre); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSSLCertDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		want    error
+	}{
+		"Successful": {
+			reason: "Deleting an existing client certificate should succeed",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+			}),
+		},
+		"AlreadyGone": {
+			reason: "Deleting a client certificate that does not exist should succeed",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+			}),
+		},
+		"Failed": {
+			reason: "Errors deleting the client certificate should be returned",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&sqladmin.Operation{})
+			}),
+			want: errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteSSLCert),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := sqladmin.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := sslCertExternal{certs: s.SslCerts, projectID: projectID}
+			mg := sslCert(sslCertWithExternalName(certFingerprint))
+			err := e.Delete(context.Background(), mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			want := sslCert(sslCertWithExternalName(certFingerprint), sslCertWithConditions(xpv1.Deleting()))
+			if diff := cmp.Diff(want, mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,304 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	dns "google.golang.org/api/dns/v1"
+	"google.golang.org/api/option"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/dns/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	policyName = "hybrid"
+	networkA   = "projects/myproject-id-1234/global/networks/a"
+	networkB   = "projects/myproject-id-1234/global/networks/b"
+	computeURL = "https://www.googleapis.com/compute/v1/"
+)
+
+type policyOption func(*v1alpha1.Policy)
+
+func newPolicy(opts ...policyOption) *v1alpha1.Policy {
+	p := &v1alpha1.Policy{}
+	meta.SetExternalName(p, policyName)
+	p.Spec.ForProvider = v1alpha1.PolicyParameters{
+		Description:             gcp.StringPtr("hybrid resolution"),
+		EnableInboundForwarding: gcp.BoolPtr(true),
+		EnableLogging:           gcp.BoolPtr(false),
+		Networks:                []string{networkA},
+	}
+	for _, f := range opts {
+		f(p)
+	}
+	return p
+}
+
+func withPolicyNetworks(n ...string) policyOption {
+	return func(p *v1alpha1.Policy) { p.Spec.ForProvider.Networks = n }
+}
+
+func withTargetNameServers(ips ...string) policyOption {
+	return func(p *v1alpha1.Policy) {
+		p.Spec.ForProvider.AlternativeNameServerConfig = &v1alpha1.PolicyAlternativeNameServerConfig{}
+		for _, ip := range ips {
+			p.Spec.ForProvider.AlternativeNameServerConfig.TargetNameServers = append(
+				p.Spec.ForProvider.AlternativeNameServerConfig.TargetNameServers, v1alpha1.PolicyTargetNameServer{IPv4Address: ip})
+		}
+	}
+}
+
+func observedPolicy() *dns.Policy {
+	return &dns.Policy{
+		Id:                      42,
+		Kind:                    "dns#policy",
+		Name:                    policyName,
+		Description:             "hybrid resolution",
+		EnableInboundForwarding: true,
+		Networks:                []*dns.PolicyNetwork{{Kind: "dns#policyNetwork", NetworkUrl: computeURL + networkA}},
+	}
+}
+
+func TestPolicyObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		e   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		kube    client.Client
+		mg      resource.Managed
+		want    want
+	}{
+		"NotPolicy": {
+			reason: "Should return an error if the resource is not a Policy",
+			mg:     unexpectedObject,
+			want: want{
+				mg:  unexpectedObject,
+				err: errors.New(errNotPolicy),
+			},
+		},
+		"NotFound": {
+			reason: "Should not return an error if the API response is 404",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+			}),
+			mg: newPolicy(),
+			want: want{
+				mg: newPolicy(),
+			},
+		},
+		"UpToDate": {
+			reason: "Should report a Policy whose networks and forwarding config match as up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				_ = json.NewEncoder(w).Encode(observedPolicy())
+			}),
+			mg: newPolicy(),
+			want: want{
+				mg: newPolicy(func(p *v1alpha1.Policy) {
+					p.Status.AtProvider.ID = 42
+					p.SetConditions(xpv1.Available())
+				}),
+				e: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"NetworkAdded": {
+			reason: "Should report a Policy that does not apply to a desired network as not up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				_ = json.NewEncoder(w).Encode(observedPolicy())
+			}),
+			mg: newPolicy(withPolicyNetworks(networkA, networkB)),
+			want: want{
+				mg: newPolicy(withPolicyNetworks(networkA, networkB), func(p *v1alpha1.Policy) {
+					p.Status.AtProvider.ID = 42
+					p.SetConditions(xpv1.Available())
+				}),
+				e: managed.ExternalObservation{ResourceExists: true},
+			},
+		},
+		"LateInitFailed": {
+			reason: "Should return an error if the late initialized Policy cannot be updated",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				_ = json.NewEncoder(w).Encode(observedPolicy())
+			}),
+			kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(errBoom)},
+			mg: newPolicy(func(p *v1alpha1.Policy) {
+				p.Spec.ForProvider.Description = nil
+			}),
+			want: want{
+				mg:  newPolicy(),
+				err: errors.Wrap(errBoom, errPolicyManageUpdate),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := dns.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := policyExternal{
+				kube:      tc.kube,
+				projectID: projectID,
+				policies:  s.Policies,
+			}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.e, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPolicyUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		want   *dns.Policy
+	}{
+		"AddNetwork": {
+			reason: "Should replace the Policy with one that applies to all desired networks",
+			mg:     newPolicy(withPolicyNetworks(networkA, networkB)),
+			want: &dns.Policy{
+				Name:                    policyName,
+				Description:             "hybrid resolution",
+				EnableInboundForwarding: true,
+				Networks: []*dns.PolicyNetwork{
+					{NetworkUrl: computeURL + networkA},
+					{NetworkUrl: computeURL + networkB},
+				},
+			},
+		},
+		"ChangeForwarders": {
+			reason: "Should replace the Policy with one that forwards to the desired name servers",
+			mg:     newPolicy(withTargetNameServers("10.0.0.2", "10.0.0.3")),
+			want: &dns.Policy{
+				Name:                    policyName,
+				Description:             "hybrid resolution",
+				EnableInboundForwarding: true,
+				AlternativeNameServerConfig: &dns.PolicyAlternativeNameServerConfig{
+					TargetNameServers: []*dns.PolicyAlternativeNameServerConfigTargetNameServer{
+						{Ipv4Address: "10.0.0.2"},
+						{Ipv4Address: "10.0.0.3"},
+					},
+				},
+				Networks: []*dns.PolicyNetwork{{NetworkUrl: computeURL + networkA}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff(http.MethodPut, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				got := &dns.Policy{}
+				if err := json.NewDecoder(r.Body).Decode(got); err != nil {
+					t.Error(err)
+				}
+				_ = r.Body.Close()
+				if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(dns.Policy{}, "ServerResponse")); diff != "" {
+					t.Errorf("\n%s\nUpdate(...): -want body, +got body:\n%s", tc.reason, diff)
+				}
+				_ = json.NewEncoder(w).Encode(&dns.PoliciesUpdateResponse{})
+			}))
+			defer server.Close()
+			s, _ := dns.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := policyExternal{
+				projectID: projectID,
+				policies:  s.Policies,
+			}
+			if _, err := e.Update(context.Background(), tc.mg); err != nil {
+				t.Errorf("\n%s\nUpdate(...): %s", tc.reason, err)
+			}
+		})
+	}
+}
+
+func TestPolicyDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		want    error
+	}{
+		"Deleted": {
+			reason: "Should not return an error if the Policy is deleted",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+			}),
+		},
+		"NotFound": {
+			reason: "Should not return an error if the Policy is already gone",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+			}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := dns.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := policyExternal{
+				projectID: projectID,
+				policies:  s.Policies,
+			}
+			err := e.Delete(context.Background(), newPolicy())
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -53,7 +53,7 @@ const (
 
 // SetupResourceRecordSet adds a controller that reconciles
 // ResourceRecordSet managed resources.
-func SetupResourceRecordSet(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupResourceRecordSet(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
 	name := managed.ControllerName(v1alpha1.ResourceRecordSetGroupKind)
 
 	r := managed.NewReconciler(mgr,
@@ -70,6 +70,7 @@ func SetupResourceRecordSet(mgr ctrl.Manager, l logging.Logger, rl workqueue.Rat
 			managed.NewAPISimpleReferenceResolver(mgr.GetClient()),
 		),
 		managed.WithPollInterval(poll),
+		managed.WithTimeout(timeout),
 		managed.WithLogger(l.WithValues("controller", name)),
 		managed.WithRecorder(event.NewAPIRecorder(
 			mgr.GetEventRecorderFor(name)),
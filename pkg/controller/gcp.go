@@ -17,13 +17,37 @@ limitations under the License.
 package controller
 
 import (
+	"strconv"
+	"strings"
 	"time"
 
+	"golang.org/x/time/rate"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 
+	bigqueryv1alpha1 "github.com/crossplane/provider-gcp/apis/bigquery/v1alpha1"
+	cachev1beta1 "github.com/crossplane/provider-gcp/apis/cache/v1beta1"
+	computev1alpha1 "github.com/crossplane/provider-gcp/apis/compute/v1alpha1"
+	computev1beta1 "github.com/crossplane/provider-gcp/apis/compute/v1beta1"
+	containerv1beta1 "github.com/crossplane/provider-gcp/apis/container/v1beta1"
+	containerv1beta2 "github.com/crossplane/provider-gcp/apis/container/v1beta2"
+	databasev1alpha1 "github.com/crossplane/provider-gcp/apis/database/v1alpha1"
+	databasev1beta1 "github.com/crossplane/provider-gcp/apis/database/v1beta1"
+	dnsv1alpha1 "github.com/crossplane/provider-gcp/apis/dns/v1alpha1"
+	iamv1alpha1 "github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
+	kmsv1alpha1 "github.com/crossplane/provider-gcp/apis/kms/v1alpha1"
+	loggingv1alpha1 "github.com/crossplane/provider-gcp/apis/logging/v1alpha1"
+	pubsubv1alpha1 "github.com/crossplane/provider-gcp/apis/pubsub/v1alpha1"
+	runv1alpha1 "github.com/crossplane/provider-gcp/apis/run/v1alpha1"
+	secretmanagerv1alpha1 "github.com/crossplane/provider-gcp/apis/secretmanager/v1alpha1"
+	servicedirectoryv1alpha1 "github.com/crossplane/provider-gcp/apis/servicedirectory/v1alpha1"
+	servicenetworkingv1beta1 "github.com/crossplane/provider-gcp/apis/servicenetworking/v1beta1"
+	storagev1alpha1 "github.com/crossplane/provider-gcp/apis/storage/v1alpha1"
+	storagev1alpha3 "github.com/crossplane/provider-gcp/apis/storage/v1alpha3"
+	"github.com/crossplane/provider-gcp/pkg/controller/bigquery"
 	"github.com/crossplane/provider-gcp/pkg/controller/cache"
 	"github.com/crossplane/provider-gcp/pkg/controller/compute"
 	"github.com/crossplane/provider-gcp/pkg/controller/config"
@@ -32,39 +56,251 @@ import (
 	"github.com/crossplane/provider-gcp/pkg/controller/dns"
 	"github.com/crossplane/provider-gcp/pkg/controller/iam"
 	"github.com/crossplane/provider-gcp/pkg/controller/kms"
+	gcplogging "github.com/crossplane/provider-gcp/pkg/controller/logging"
 	"github.com/crossplane/provider-gcp/pkg/controller/pubsub"
+	"github.com/crossplane/provider-gcp/pkg/controller/run"
+	"github.com/crossplane/provider-gcp/pkg/controller/secretmanager"
+	"github.com/crossplane/provider-gcp/pkg/controller/servicedirectory"
 	"github.com/crossplane/provider-gcp/pkg/controller/servicenetworking"
 	"github.com/crossplane/provider-gcp/pkg/controller/storage"
 )
 
+const (
+	errFmtParseTimeout      = "cannot parse reconcile timeout of %s"
+	errFmtParseReadyTimeout = "cannot parse ready timeout of %s"
+	errFmtParseAPIRateLimit = "cannot parse GCP API rate limit of %s: must be QPS or QPS/BURST"
+	errNonPositiveRateLimit = "rate limit must be positive"
+)
+
+// DefaultReconcileTimeout is the default timeout of a single reconcile of a
+// managed resource, including all calls it makes to the GCP API. It is
+// distinct from the poll interval, which controls how often a resource is
+// reconciled.
+const DefaultReconcileTimeout = 1 * time.Minute
+
+// DefaultReconcileTimeouts are the default reconcile timeouts of the kinds of
+// managed resources whose GCP API calls are known to take longer than
+// DefaultReconcileTimeout, keyed by their group kind.
+var DefaultReconcileTimeouts = map[string]time.Duration{
+	containerv1beta2.ClusterGroupKind:         5 * time.Minute,
+	containerv1beta1.NodePoolGroupKind:        5 * time.Minute,
+	databasev1beta1.CloudSQLInstanceGroupKind: 5 * time.Minute,
+}
+
+// DefaultReadyTimeouts are the default ready timeouts of the kinds of managed
+// resources whose creation is known to occasionally stall, keyed by their
+// group kind. A resource that is not available this long after it was created
+// is marked as degraded.
+var DefaultReadyTimeouts = map[string]time.Duration{
+	containerv1beta2.ClusterGroupKind:         30 * time.Minute,
+	databasev1beta1.CloudSQLInstanceGroupKind: 30 * time.Minute,
+}
+
+// Timeouts configures the reconcile timeout of each kind of managed resource.
+type Timeouts struct {
+	// Default is the reconcile timeout of kinds that are not overridden.
+	Default time.Duration
+
+	// Kinds overrides the reconcile timeout of the kinds of managed
+	// resources, keyed by their group kind, e.g.
+	// Cluster.container.gcp.crossplane.io.
+	Kinds map[string]time.Duration
+
+	// Ready overrides the ready timeout of the kinds of managed resources
+	// that support one, keyed by their group kind. A ready timeout of zero
+	// disables it.
+	Ready map[string]time.Duration
+}
+
+// NewTimeouts returns the reconcile timeouts that result from applying the
+// supplied overrides, which are durations keyed by group kind, to the default
+// reconcile timeout of each kind.
+func NewTimeouts(def time.Duration, overrides map[string]string) (Timeouts, error) {
+	t := Timeouts{Default: def, Kinds: make(map[string]time.Duration, len(DefaultReconcileTimeouts)+len(overrides))}
+	for k, d := range DefaultReconcileTimeouts {
+		t.Kinds[k] = d
+	}
+	for k, s := range overrides {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return Timeouts{}, errors.Wrapf(err, errFmtParseTimeout, k)
+		}
+		t.Kinds[k] = d
+	}
+	return t, nil
+}
+
+// WithReadyTimeouts returns the supplied timeouts with their ready timeouts
+// overridden by the supplied durations, keyed by group kind.
+func (t Timeouts) WithReadyTimeouts(overrides map[string]string) (Timeouts, error) {
+	t.Ready = make(map[string]time.Duration, len(overrides))
+	for k, s := range overrides {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return Timeouts{}, errors.Wrapf(err, errFmtParseReadyTimeout, k)
+		}
+		t.Ready[k] = d
+	}
+	return t, nil
+}
+
+// ReadyFor returns the ready timeout of the supplied group kind.
+func (t Timeouts) ReadyFor(kind string) time.Duration {
+	if d, ok := t.Ready[kind]; ok {
+		return d
+	}
+	return DefaultReadyTimeouts[kind]
+}
+
+// For returns the reconcile timeout of the supplied group kind.
+func (t Timeouts) For(kind string) time.Duration {
+	if d, ok := t.Kinds[kind]; ok {
+		return d
+	}
+	if t.Default == 0 {
+		return DefaultReconcileTimeout
+	}
+	return t.Default
+}
+
+// APIRateLimits limit the rate at which the controllers of kinds of managed
+// resources call the GCP API, keyed by their group kind. Unlike the rate
+// limiter of a controller's workqueue, which limits how often resources are
+// reconciled, they smooth bursts of calls made by concurrent reconciles.
+type APIRateLimits map[string]*rate.Limiter
+
+// NewAPIRateLimits returns the GCP API rate limits that result from the
+// supplied limits, which are a number of calls per second optionally followed
+// by a slash and the number of calls that may burst, keyed by group kind. The
+// burst defaults to one call.
+func NewAPIRateLimits(limits map[string]string) (APIRateLimits, error) {
+	r := make(APIRateLimits, len(limits))
+	for k, s := range limits {
+		qps, burst, err := parseAPIRateLimit(s)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtParseAPIRateLimit, k)
+		}
+		r[k] = rate.NewLimiter(rate.Limit(qps), burst)
+	}
+	return r, nil
+}
+
+func parseAPIRateLimit(s string) (float64, int, error) {
+	burst := 1
+	q := s
+	if i := strings.Index(s, "/"); i >= 0 {
+		b, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return 0, 0, err
+		}
+		q, burst = s[:i], b
+	}
+	qps, err := strconv.ParseFloat(q, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	if qps <= 0 || burst <= 0 {
+		return 0, 0, errors.New(errNonPositiveRateLimit)
+	}
+	return qps, burst, nil
+}
+
+// For returns the GCP API rate limiter of the supplied group kind. Kinds that
+// are not limited may call the GCP API at any rate.
+func (r APIRateLimits) For(kind string) *rate.Limiter {
+	if l, ok := r[kind]; ok {
+		return l
+	}
+	return rate.NewLimiter(rate.Inf, 0)
+}
+
 // Setup creates all GCP controllers with the supplied logger and adds them to
-// the supplied manager.
-func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
-	for _, setup := range []func(ctrl.Manager, logging.Logger, workqueue.RateLimiter, time.Duration) error{
-		cache.SetupCloudMemorystoreInstance,
-		compute.SetupGlobalAddress,
-		compute.SetupNetwork,
-		compute.SetupSubnetwork,
-		compute.SetupFirewall,
-		container.SetupCluster,
-		container.SetupNodePool,
-		database.SetupCloudSQLInstance,
-		dns.SetupResourceRecordSet,
-		iam.SetupServiceAccount,
-		iam.SetupServiceAccountKey,
-		iam.SetupServiceAccountPolicy,
-		kms.SetupKeyRing,
-		kms.SetupCryptoKey,
-		kms.SetupCryptoKeyPolicy,
-		pubsub.SetupTopic,
-		servicenetworking.SetupConnection,
-		storage.SetupBucket,
-		storage.SetupBucketPolicy,
-		storage.SetupBucketPolicyMember,
+// the supplied manager. The BucketPolicyMember controller is configured by the
+// supplied options. In read-only mode the controllers that support it never
+// change their external resources.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, t Timeouts, bpm storage.BucketPolicyMemberOptions) error {
+	for _, c := range []struct {
+		kind  string
+		setup func(ctrl.Manager, logging.Logger, workqueue.RateLimiter, time.Duration, time.Duration) error
+	}{
+		{bigqueryv1alpha1.TablePolicyMemberGroupKind, bigquery.SetupTablePolicyMember},
+		{bigqueryv1alpha1.ReservationGroupKind, bigquery.SetupReservation},
+		{bigqueryv1alpha1.CapacityCommitmentGroupKind, bigquery.SetupCapacityCommitment},
+		{cachev1beta1.CloudMemorystoreInstanceGroupKind, cache.SetupCloudMemorystoreInstance},
+		{computev1beta1.GlobalAddressGroupKind, compute.SetupGlobalAddress},
+		{computev1beta1.NetworkGroupKind, compute.SetupNetwork},
+		{computev1beta1.SubnetworkGroupKind, compute.SetupSubnetwork},
+		{computev1alpha1.FirewallGroupKind, compute.SetupFirewall},
+		{computev1alpha1.ProjectSettingsGroupKind, compute.SetupProjectSettings},
+		{computev1alpha1.ResourcePolicyGroupKind, compute.SetupResourcePolicy},
+		{computev1alpha1.DiskResourcePolicyBindingGroupKind, compute.SetupDiskResourcePolicyBinding},
+		{computev1alpha1.BulkInstanceGroupKind, compute.SetupBulkInstance},
+		{computev1alpha1.BackendBucketGroupKind, compute.SetupBackendBucket},
+		{computev1alpha1.RouterInterfaceGroupKind, compute.SetupRouterInterface},
+		{computev1alpha1.RouterPeerGroupKind, compute.SetupRouterPeer},
+		{computev1alpha1.TargetInstanceGroupKind, compute.SetupTargetInstance},
+		{computev1alpha1.InterconnectAttachmentGroupKind, compute.SetupInterconnectAttachment},
+		{computev1alpha1.RegionBackendServiceGroupKind, compute.SetupRegionBackendService},
+		{computev1alpha1.ForwardingRuleGroupKind, compute.SetupForwardingRule},
+		{computev1alpha1.InstanceGroupKind, compute.SetupInstance},
+		{computev1alpha1.SSLCertificateGroupKind, compute.SetupSSLCertificate},
+		{containerv1beta1.NodePoolGroupKind, container.SetupNodePool},
+		{databasev1alpha1.SSLCertGroupKind, database.SetupSSLCert},
+		{dnsv1alpha1.ResourceRecordSetGroupKind, dns.SetupResourceRecordSet},
+		{dnsv1alpha1.PolicyGroupKind, dns.SetupPolicy},
+		{iamv1alpha1.ServiceAccountGroupKind, iam.SetupServiceAccount},
+		{iamv1alpha1.ServiceAccountKeyGroupKind, iam.SetupServiceAccountKey},
+		{iamv1alpha1.ServiceAccountPolicyGroupKind, iam.SetupServiceAccountPolicy},
+		{iamv1alpha1.WorkloadIdentityPoolGroupKind, iam.SetupWorkloadIdentityPool},
+		{iamv1alpha1.WorkloadIdentityPoolProviderGroupKind, iam.SetupWorkloadIdentityPoolProvider},
+		{kmsv1alpha1.KeyRingGroupKind, kms.SetupKeyRing},
+		{kmsv1alpha1.CryptoKeyGroupKind, kms.SetupCryptoKey},
+		{kmsv1alpha1.CryptoKeyPolicyGroupKind, kms.SetupCryptoKeyPolicy},
+		{kmsv1alpha1.CryptoKeyVersionGroupKind, kms.SetupCryptoKeyVersion},
+		{loggingv1alpha1.LogBucketGroupKind, gcplogging.SetupLogBucket},
+		{loggingv1alpha1.LogViewGroupKind, gcplogging.SetupLogView},
+		{pubsubv1alpha1.TopicGroupKind, pubsub.SetupTopic},
+		{pubsubv1alpha1.SubscriptionGroupKind, pubsub.SetupSubscription},
+		{runv1alpha1.ServiceGroupKind, run.SetupService},
+		{secretmanagerv1alpha1.SecretGroupKind, secretmanager.SetupSecret},
+		{secretmanagerv1alpha1.SecretPolicyMemberGroupKind, secretmanager.SetupSecretPolicyMember},
+		{servicedirectoryv1alpha1.NamespaceGroupKind, servicedirectory.SetupServiceDirectoryNamespace},
+		{servicedirectoryv1alpha1.ServiceGroupKind, servicedirectory.SetupService},
+		{servicedirectoryv1alpha1.EndpointGroupKind, servicedirectory.SetupEndpoint},
+		{servicenetworkingv1beta1.ConnectionGroupKind, servicenetworking.SetupConnection},
+		{storagev1alpha3.BucketGroupKind, storage.SetupBucket},
+		{storagev1alpha1.BucketObjectGroupKind, storage.SetupBucketObject},
+	} {
+		if err := c.setup(mgr, l, rl, poll, t.For(c.kind)); err != nil {
+			return err
+		}
+	}
+	// These kinds also support read-only mode.
+	for _, c := range []struct {
+		kind  string
+		setup func(ctrl.Manager, logging.Logger, workqueue.RateLimiter, time.Duration, time.Duration, bool) error
+	}{
+		{storagev1alpha1.BucketPolicyGroupKind, storage.SetupBucketPolicy},
+		{storagev1alpha1.BucketPolicyBindingGroupKind, storage.SetupBucketPolicyBinding},
+	} {
+		if err := c.setup(mgr, l, rl, poll, t.For(c.kind), bpm.ReadOnly); err != nil {
+			return err
+		}
+	}
+	// These kinds also mark resources whose creation stalls as degraded.
+	for _, c := range []struct {
+		kind  string
+		setup func(ctrl.Manager, logging.Logger, workqueue.RateLimiter, time.Duration, time.Duration, time.Duration) error
+	}{
+		{containerv1beta2.ClusterGroupKind, container.SetupCluster},
+		{databasev1beta1.CloudSQLInstanceGroupKind, database.SetupCloudSQLInstance},
 	} {
-		if err := setup(mgr, l, rl, poll); err != nil {
+		if err := c.setup(mgr, l, rl, poll, t.For(c.kind), t.ReadyFor(c.kind)); err != nil {
 			return err
 		}
 	}
+	if err := storage.SetupBucketPolicyMember(mgr, l, rl, poll, t.For(storagev1alpha1.BucketPolicyMemberGroupKind), bpm); err != nil {
+		return err
+	}
 	return config.Setup(mgr, l, rl)
 }
@@ -0,0 +1,236 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/time/rate"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	containerv1beta2 "github.com/crossplane/provider-gcp/apis/container/v1beta2"
+	databasev1beta1 "github.com/crossplane/provider-gcp/apis/database/v1beta1"
+	pubsubv1alpha1 "github.com/crossplane/provider-gcp/apis/pubsub/v1alpha1"
+	storagev1alpha1 "github.com/crossplane/provider-gcp/apis/storage/v1alpha1"
+)
+
+func TestNewTimeouts(t *testing.T) {
+	_, errParse := time.ParseDuration("ten seconds")
+
+	type args struct {
+		def       time.Duration
+		overrides map[string]string
+	}
+	type want struct {
+		timeouts map[string]time.Duration
+		err      error
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Defaults": {
+			reason: "Kinds that are not overridden should use the default timeout, or their own default if they have one",
+			args: args{
+				def: 30 * time.Second,
+			},
+			want: want{
+				timeouts: map[string]time.Duration{
+					pubsubv1alpha1.TopicGroupKind:     30 * time.Second,
+					containerv1beta2.ClusterGroupKind: 5 * time.Minute,
+				},
+			},
+		},
+		"Overridden": {
+			reason: "Overridden kinds should use the overridden timeout",
+			args: args{
+				def: 30 * time.Second,
+				overrides: map[string]string{
+					pubsubv1alpha1.TopicGroupKind:     "10s",
+					containerv1beta2.ClusterGroupKind: "20m",
+				},
+			},
+			want: want{
+				timeouts: map[string]time.Duration{
+					pubsubv1alpha1.TopicGroupKind:     10 * time.Second,
+					containerv1beta2.ClusterGroupKind: 20 * time.Minute,
+				},
+			},
+		},
+		"NoDefault": {
+			reason: "Kinds that are not overridden should use DefaultReconcileTimeout if no default timeout is supplied",
+			want: want{
+				timeouts: map[string]time.Duration{
+					pubsubv1alpha1.TopicGroupKind: DefaultReconcileTimeout,
+				},
+			},
+		},
+		"InvalidOverride": {
+			reason: "An override that is not a duration should return an error",
+			args: args{
+				overrides: map[string]string{pubsubv1alpha1.TopicGroupKind: "ten seconds"},
+			},
+			want: want{
+				err: errors.Wrapf(errParse, errFmtParseTimeout, pubsubv1alpha1.TopicGroupKind),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := NewTimeouts(tc.args.def, tc.args.overrides)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("\n%s\nNewTimeouts(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			for kind, want := range tc.want.timeouts {
+				if diff := cmp.Diff(want, got.For(kind)); diff != "" {
+					t.Errorf("\n%s\nFor(%s): -want, +got:\n%s", tc.reason, kind, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestWithReadyTimeouts(t *testing.T) {
+	_, errParse := time.ParseDuration("an hour")
+
+	type want struct {
+		ready map[string]time.Duration
+		err   error
+	}
+	cases := map[string]struct {
+		reason    string
+		overrides map[string]string
+		want      want
+	}{
+		"Defaults": {
+			reason: "Kinds that are not overridden should use their default ready timeout, if any",
+			want: want{
+				ready: map[string]time.Duration{
+					containerv1beta2.ClusterGroupKind: DefaultReadyTimeouts[containerv1beta2.ClusterGroupKind],
+					pubsubv1alpha1.TopicGroupKind:     0,
+				},
+			},
+		},
+		"Overridden": {
+			reason: "Overridden kinds should use the overridden ready timeout, including zero to disable it",
+			overrides: map[string]string{
+				containerv1beta2.ClusterGroupKind:         "1h",
+				databasev1beta1.CloudSQLInstanceGroupKind: "0",
+			},
+			want: want{
+				ready: map[string]time.Duration{
+					containerv1beta2.ClusterGroupKind:         time.Hour,
+					databasev1beta1.CloudSQLInstanceGroupKind: 0,
+				},
+			},
+		},
+		"InvalidOverride": {
+			reason:    "An override that is not a duration should return an error",
+			overrides: map[string]string{containerv1beta2.ClusterGroupKind: "an hour"},
+			want: want{
+				err: errors.Wrapf(errParse, errFmtParseReadyTimeout, containerv1beta2.ClusterGroupKind),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Timeouts{}.WithReadyTimeouts(tc.overrides)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("\n%s\nWithReadyTimeouts(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			for kind, want := range tc.want.ready {
+				if diff := cmp.Diff(want, got.ReadyFor(kind)); diff != "" {
+					t.Errorf("\n%s\nReadyFor(%s): -want, +got:\n%s", tc.reason, kind, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestNewAPIRateLimits(t *testing.T) {
+	_, errParse := strconv.ParseFloat("ten", 64)
+
+	type limit struct {
+		Limit rate.Limit
+		Burst int
+	}
+	type want struct {
+		limits map[string]limit
+		err    error
+	}
+	cases := map[string]struct {
+		reason string
+		limits map[string]string
+		want   want
+	}{
+		"Defaults": {
+			reason: "Kinds that are not limited should call the GCP API at any rate",
+			want: want{
+				limits: map[string]limit{
+					storagev1alpha1.BucketPolicyMemberGroupKind: {Limit: rate.Inf},
+				},
+			},
+		},
+		"Limited": {
+			reason: "Limited kinds should use the supplied rate, and a burst of one call unless one is supplied",
+			limits: map[string]string{
+				storagev1alpha1.BucketPolicyMemberGroupKind: "2.5",
+				pubsubv1alpha1.TopicGroupKind:               "10/20",
+			},
+			want: want{
+				limits: map[string]limit{
+					storagev1alpha1.BucketPolicyMemberGroupKind: {Limit: 2.5, Burst: 1},
+					pubsubv1alpha1.TopicGroupKind:               {Limit: 10, Burst: 20},
+				},
+			},
+		},
+		"InvalidLimit": {
+			reason: "A limit that is not a number should return an error",
+			limits: map[string]string{storagev1alpha1.BucketPolicyMemberGroupKind: "ten"},
+			want: want{
+				err: errors.Wrapf(errParse, errFmtParseAPIRateLimit, storagev1alpha1.BucketPolicyMemberGroupKind),
+			},
+		},
+		"NonPositiveBurst": {
+			reason: "A burst that is not positive should return an error",
+			limits: map[string]string{storagev1alpha1.BucketPolicyMemberGroupKind: "10/0"},
+			want: want{
+				err: errors.Wrapf(errors.New(errNonPositiveRateLimit), errFmtParseAPIRateLimit, storagev1alpha1.BucketPolicyMemberGroupKind),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := NewAPIRateLimits(tc.limits)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("\n%s\nNewAPIRateLimits(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			for kind, want := range tc.want.limits {
+				l := got.For(kind)
+				if diff := cmp.Diff(want, limit{Limit: l.Limit(), Burst: l.Burst()}); diff != "" {
+					t.Errorf("\n%s\nFor(%s): -want, +got:\n%s", tc.reason, kind, diff)
+				}
+			}
+		})
+	}
+}
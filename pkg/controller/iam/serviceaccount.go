@@ -49,10 +49,15 @@ const (
 	errCreate            = "cannot create GCP ServiceAccount object via IAM API"
 	errUpdate            = "cannot update GCP ServiceAccount object via IAM API"
 	errDelete            = "cannot delete GCP ServiceAccount object via IAM API"
+	errEnable            = "cannot enable GCP ServiceAccount object via IAM API"
+	errDisable           = "cannot disable GCP ServiceAccount object via IAM API"
+
+	// connection detail keys
+	keyEmail = "email"
 )
 
 // SetupServiceAccount adds a controller that reconciles ServiceAccounts.
-func SetupServiceAccount(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupServiceAccount(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
 	name := managed.ControllerName(v1alpha1.ServiceAccountGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -64,7 +69,9 @@ func SetupServiceAccount(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLi
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.ServiceAccountGroupVersionKind),
 			managed.WithExternalConnecter(&connecter{client: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
 			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
@@ -114,10 +121,18 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	return managed.ExternalObservation{
 		ResourceExists:    true,
 		ResourceUpToDate:  isUpToDate(&cr.Spec.ForProvider, fromProvider),
-		ConnectionDetails: managed.ConnectionDetails{},
+		ConnectionDetails: gcp.MapConnectionDetails(serviceAccountConnectionDetails(fromProvider), cr.Spec.ConnectionDetailsMapping),
 	}, nil
 }
 
+func serviceAccountConnectionDetails(fromProvider *iamv1.ServiceAccount) managed.ConnectionDetails {
+	cd := managed.ConnectionDetails{}
+	if fromProvider.Email != "" {
+		cd[keyEmail] = []byte(fromProvider.Email)
+	}
+	return cd
+}
+
 // https://cloud.google.com/iam/docs/reference/rest/v1/projects.serviceAccounts/create
 // Note that the metadata.Name from the Kubernetes custom resource is used as the AccountID parameter
 // All other API methods use the external-name annotation
@@ -163,8 +178,21 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	req := e.serviceAccounts.Patch(e.rrn.ResourceName(cr), psar)
 	// we don't pay attention to the result of the patch request because it is only guaranteed to contain
 	// `description` and `displayName` ie the fields we are trying to change
-	_, err := req.Context(ctx).Do()
-	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+	if _, err := req.Context(ctx).Do(); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+	}
+
+	// The disabled state can't be patched, it is changed by enabling or
+	// disabling the service account.
+	if cr.Spec.ForProvider.Disabled == nil || *cr.Spec.ForProvider.Disabled == cr.Status.AtProvider.Disabled {
+		return managed.ExternalUpdate{}, nil
+	}
+	if *cr.Spec.ForProvider.Disabled {
+		_, err := e.serviceAccounts.Disable(e.rrn.ResourceName(cr), &iamv1.DisableServiceAccountRequest{}).Context(ctx).Do()
+		return managed.ExternalUpdate{}, errors.Wrap(err, errDisable)
+	}
+	_, err := e.serviceAccounts.Enable(e.rrn.ResourceName(cr), &iamv1.EnableServiceAccountRequest{}).Context(ctx).Do()
+	return managed.ExternalUpdate{}, errors.Wrap(err, errEnable)
 }
 
 // https://cloud.google.com/iam/docs/reference/rest/v1/projects.serviceAccounts/delete
@@ -194,6 +222,11 @@ func isUpToDate(in *v1alpha1.ServiceAccountParameters, observed *iamv1.ServiceAc
 	if in.Description != nil && *in.Description != observed.Description {
 		return false
 	}
+	// A service account that was just created may not be fully available
+	// yet, so its disabled state is only compared once it has an email.
+	if in.Disabled != nil && observed.Email != "" && *in.Disabled != observed.Disabled {
+		return false
+	}
 	return true
 }
 
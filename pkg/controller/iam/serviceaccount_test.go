@@ -23,6 +23,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -92,6 +93,14 @@ func withDisabled(b bool) valueModifier {
 	return func(i *v1alpha1.ServiceAccount) { i.Status.AtProvider.Disabled = b }
 }
 
+func withDesiredDisabled(b bool) valueModifier {
+	return func(i *v1alpha1.ServiceAccount) { i.Spec.ForProvider.Disabled = &b }
+}
+
+func withConnectionDetailsMapping(m map[string]string) valueModifier {
+	return func(i *v1alpha1.ServiceAccount) { i.Spec.ConnectionDetailsMapping = m }
+}
+
 func withCondition(condition xpv1.Condition) valueModifier {
 	return func(i *v1alpha1.ServiceAccount) { i.SetConditions(condition) }
 }
@@ -233,6 +242,120 @@ func TestObserve(t *testing.T) {
 					withExternalNameAnnotation(fqName),
 					withCondition(xpv1.Available()),
 					withDisabled(false)),
+				observation: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						keyEmail: []byte(accountEmail),
+					},
+				},
+			},
+		},
+		"ObservedAccountGotWithConnectionDetailsMapping": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				sa := &iamv1.ServiceAccount{
+					Name:        fqName,
+					UniqueId:    uniqueID,
+					Email:       accountEmail,
+					DisplayName: displayName,
+				}
+				_ = json.NewEncoder(w).Encode(sa)
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg: serviceAccount(
+					withName(fqName),
+					withExternalNameAnnotation(fqName),
+					withConnectionDetailsMapping(map[string]string{keyEmail: "client_email"}),
+				),
+			},
+			want: want{
+				mg: serviceAccount(
+					withName(fqName),
+					withUniqueID(uniqueID),
+					withEmail(accountEmail),
+					withDisplayName(displayName),
+					withExternalNameAnnotation(fqName),
+					withConnectionDetailsMapping(map[string]string{keyEmail: "client_email"}),
+					withCondition(xpv1.Available()),
+					withDisabled(false)),
+				observation: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						"client_email": []byte(accountEmail),
+					},
+				},
+			},
+		},
+		"ObservedAccountDisabledOutOfBand": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				sa := &iamv1.ServiceAccount{
+					Name:        fqName,
+					UniqueId:    uniqueID,
+					Email:       accountEmail,
+					DisplayName: displayName,
+					Disabled:    true,
+				}
+				_ = json.NewEncoder(w).Encode(sa)
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg: serviceAccount(
+					withName(fqName),
+					withExternalNameAnnotation(fqName),
+					withDesiredDisabled(false),
+				),
+			},
+			want: want{
+				mg: serviceAccount(
+					withName(fqName),
+					withUniqueID(uniqueID),
+					withEmail(accountEmail),
+					withDisplayName(displayName),
+					withExternalNameAnnotation(fqName),
+					withDesiredDisabled(false),
+					withCondition(xpv1.Available()),
+					withDisabled(true)),
+				observation: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+					ConnectionDetails: managed.ConnectionDetails{
+						keyEmail: []byte(accountEmail),
+					},
+				},
+			},
+		},
+		"ObservedAccountNotYetAvailable": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				sa := &iamv1.ServiceAccount{
+					Name:        fqName,
+					DisplayName: displayName,
+					Disabled:    true,
+				}
+				_ = json.NewEncoder(w).Encode(sa)
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg: serviceAccount(
+					withName(fqName),
+					withExternalNameAnnotation(fqName),
+					withDesiredDisabled(false),
+				),
+			},
+			want: want{
+				mg: serviceAccount(
+					withName(fqName),
+					withDisplayName(displayName),
+					withExternalNameAnnotation(fqName),
+					withDesiredDisabled(false),
+					withDisabled(true)),
 				observation: managed.ExternalObservation{
 					ResourceExists:    true,
 					ResourceUpToDate:  true,
@@ -240,6 +363,46 @@ func TestObserve(t *testing.T) {
 				},
 			},
 		},
+		"ObservedAccountDescriptionChanged": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				sa := &iamv1.ServiceAccount{
+					Name:        fqName,
+					UniqueId:    uniqueID,
+					Email:       accountEmail,
+					DisplayName: displayName,
+					Description: "an outdated description",
+				}
+				_ = json.NewEncoder(w).Encode(sa)
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg: serviceAccount(
+					withName(fqName),
+					withExternalNameAnnotation(fqName),
+					withDescription(description),
+				),
+			},
+			want: want{
+				mg: serviceAccount(
+					withName(fqName),
+					withUniqueID(uniqueID),
+					withEmail(accountEmail),
+					withDisplayName(displayName),
+					withDescription(description),
+					withExternalNameAnnotation(fqName),
+					withCondition(xpv1.Available()),
+					withDisabled(false)),
+				observation: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+					ConnectionDetails: managed.ConnectionDetails{
+						keyEmail: []byte(accountEmail),
+					},
+				},
+			},
+		},
 		"ObservedServiceAccountDoesNotExist": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				r.Body.Close()
@@ -525,6 +688,71 @@ func TestUpdate(t *testing.T) {
 				),
 			},
 		},
+		"EnabledInstance": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				switch {
+				case r.Method == http.MethodPatch:
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&iamv1.ServiceAccount{})
+				case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, ":enable"):
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&iamv1.Empty{})
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					respondWith(w, http.StatusInternalServerError, &iamv1.ServiceAccount{})
+				}
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg: serviceAccount(
+					withName(fqName),
+					withEmail(accountEmail),
+					withDesiredDisabled(false),
+					withDisabled(true),
+				),
+			},
+			want: want{
+				mg: serviceAccount(
+					withName(fqName),
+					withEmail(accountEmail),
+					withDesiredDisabled(false),
+					withDisabled(true),
+				),
+			},
+		},
+		"DisableFailed": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				switch {
+				case r.Method == http.MethodPatch:
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&iamv1.ServiceAccount{})
+				case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, ":disable"):
+					w.WriteHeader(http.StatusInternalServerError)
+					_ = json.NewEncoder(w).Encode(&iamv1.Empty{})
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					respondWith(w, http.StatusInternalServerError, &iamv1.ServiceAccount{})
+				}
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg: serviceAccount(
+					withName(fqName),
+					withEmail(accountEmail),
+					withDesiredDisabled(true),
+				),
+			},
+			want: want{
+				mg: serviceAccount(
+					withName(fqName),
+					withEmail(accountEmail),
+					withDesiredDisabled(true),
+				),
+				err: errors.Wrap(err500, errDisable),
+			},
+		},
 		"NotServiceAccount": {
 			args: args{
 				ctx: context.Background(),
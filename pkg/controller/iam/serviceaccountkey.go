@@ -66,7 +66,7 @@ const (
 )
 
 // SetupServiceAccountKey adds a controller that reconciles ServiceAccountKeys.
-func SetupServiceAccountKey(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupServiceAccountKey(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
 	name := managed.ControllerName(v1alpha1.ServiceAccountKeyGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -80,6 +80,7 @@ func SetupServiceAccountKey(mgr ctrl.Manager, l logging.Logger, rl workqueue.Rat
 			managed.WithInitializers(),
 			managed.WithExternalConnecter(&serviceAccountKeyServiceConnector{client: mgr.GetClient()}),
 			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
@@ -153,7 +154,7 @@ func (s *serviceAccountKeyExternalClient) Observe(ctx context.Context, mg resour
 		ResourceExists: true,
 		// all service account key parameters are immutable, no update method exists in Google Cloud API for SA keys
 		ResourceUpToDate:  true,
-		ConnectionDetails: connDetails,
+		ConnectionDetails: gcp.MapConnectionDetails(connDetails, cr.Spec.ConnectionDetailsMapping),
 	}, nil
 }
 
@@ -186,7 +187,7 @@ func (s *serviceAccountKeyExternalClient) Create(ctx context.Context, mg resourc
 
 	meta.SetExternalName(cr, keyID) // set external name to key id parsing it from Google Cloud API relative resource name
 
-	return managed.ExternalCreation{ExternalNameAssigned: true, ConnectionDetails: connDetails}, nil
+	return managed.ExternalCreation{ExternalNameAssigned: true, ConnectionDetails: gcp.MapConnectionDetails(connDetails, cr.Spec.ConnectionDetailsMapping)}, nil
 }
 
 func (s *serviceAccountKeyExternalClient) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
@@ -390,6 +390,47 @@ func TestServiceAccountKeyCreate(t *testing.T) {
 				),
 			},
 		},
+		"GoogleCloudAPIReadSuccessWithConnectionDetailsMapping": {
+			reason: "connection detail keys should be renamed according to the connection details mapping",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewEncoder(w).Encode(
+					getIAMSaKeyGetObjectWithEncodedKeyData(iamSaKeyCreateObject)); err != nil {
+					t.Logf(
+						"Google Cloud API response failed. Failed to serialize iam.ServiceAccountKey: %s", err)
+
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg: newServiceAccountKey(
+					setServiceAccount(rrnTestServiceAccount),
+					setPublicKeyType(valIAMPublicKeyType),
+					setPrivateKeyType(valIAMPrivateKeyType),
+					setConnectionDetailsMapping(map[string]string{keyPrivateKeyData: "credentials.json"}),
+				),
+			},
+			want: want{
+				c: managed.ExternalCreation{
+					ExternalNameAssigned: true,
+					ConnectionDetails: map[string][]byte{
+						keyPublicKeyType:   []byte(valIAMPublicKeyType),
+						keyPublicKeyData:   []byte(valIAMPublicKeyData),
+						keyPrivateKeyType:  []byte(valIAMPrivateKeyType),
+						"credentials.json": []byte(valIAMPrivateKeyData),
+					},
+				},
+				mg: newServiceAccountKey(
+					setServiceAccount(rrnTestServiceAccount),
+					setAnnotations(map[string]string{
+						meta.AnnotationKeyExternalName: nameExternalServiceAccountKey,
+					}),
+					setPublicKeyType(valIAMPublicKeyType),
+					setPrivateKeyType(valIAMPrivateKeyType),
+					setConnectionDetailsMapping(map[string]string{keyPrivateKeyData: "credentials.json"}),
+				),
+			},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -646,6 +687,12 @@ func setPrivateKeyType(privateKeyType string) serviceAccountKeyModifier {
 	}
 }
 
+func setConnectionDetailsMapping(m map[string]string) serviceAccountKeyModifier {
+	return func(saKey *v1alpha1.ServiceAccountKey) {
+		saKey.Spec.ConnectionDetailsMapping = m
+	}
+}
+
 func setObservedIAMServiceAccountKey(provider *iamv1.ServiceAccountKey, keyID string) serviceAccountKeyModifier {
 	return func(saKey *v1alpha1.ServiceAccountKey) {
 		saKey.Status.AtProvider.KeyID = keyID
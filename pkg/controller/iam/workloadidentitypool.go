@@ -0,0 +1,208 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iam
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	iamv1 "google.golang.org/api/iam/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/workloadidentitypool"
+)
+
+// Error strings.
+const (
+	errNotWorkloadIdentityPool      = "managed resource is not a GCP WorkloadIdentityPool"
+	errGetWorkloadIdentityPool      = "cannot get GCP WorkloadIdentityPool object via IAM API"
+	errCreateWorkloadIdentityPool   = "cannot create GCP WorkloadIdentityPool object via IAM API"
+	errUpdateWorkloadIdentityPool   = "cannot update GCP WorkloadIdentityPool object via IAM API"
+	errUndeleteWorkloadIdentityPool = "cannot undelete GCP WorkloadIdentityPool object via IAM API"
+	errDeleteWorkloadIdentityPool   = "cannot delete GCP WorkloadIdentityPool object via IAM API"
+)
+
+// SetupWorkloadIdentityPool adds a controller that reconciles
+// WorkloadIdentityPools.
+func SetupWorkloadIdentityPool(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.WorkloadIdentityPoolGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.WorkloadIdentityPool{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.WorkloadIdentityPoolGroupVersionKind),
+			managed.WithExternalConnecter(&workloadIdentityPoolConnecter{client: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type workloadIdentityPoolConnecter struct {
+	client client.Client
+}
+
+// Connect sets up iam client using credentials from the provider
+func (c *workloadIdentityPoolConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := iamv1.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &workloadIdentityPoolExternal{pools: iamv1.NewProjectsLocationsWorkloadIdentityPoolsService(s), projectID: projectID}, nil
+}
+
+type workloadIdentityPoolExternal struct {
+	pools     workloadidentitypool.Client
+	projectID string
+}
+
+func (e *workloadIdentityPoolExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.WorkloadIdentityPool)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotWorkloadIdentityPool)
+	}
+
+	instance, err := e.pools.Get(workloadIdentityPoolRRN(e.projectID, meta.GetExternalName(cr))).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetWorkloadIdentityPool)
+	}
+
+	cr.Status.AtProvider = workloadidentitypool.GenerateWorkloadIdentityPoolObservation(*instance)
+
+	// NOTE: Deleted pools are kept in the DELETED state for 30 days before
+	// they are purged. Their IDs cannot be reused in the meantime, so a pool
+	// that was deleted out of band is undeleted rather than created again.
+	if instance.State == v1alpha1.WorkloadIdentityStateDeleted {
+		if meta.WasDeleted(cr) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		cr.Status.SetConditions(xpv1.Unavailable())
+		return managed.ExternalObservation{ResourceExists: true}, nil
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	workloadidentitypool.LateInitializeWorkloadIdentityPool(&cr.Spec.ForProvider, *instance)
+
+	cr.Status.SetConditions(xpv1.Available())
+
+	upToDate, _ := workloadidentitypool.IsWorkloadIdentityPoolUpToDate(cr.Spec.ForProvider, instance)
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: !cmp.Equal(currentSpec, &cr.Spec.ForProvider),
+		ResourceUpToDate:        upToDate,
+	}, nil
+}
+
+func (e *workloadIdentityPoolExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.WorkloadIdentityPool)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotWorkloadIdentityPool)
+	}
+	cr.SetConditions(xpv1.Creating())
+	instance := &iamv1.WorkloadIdentityPool{}
+	workloadidentitypool.GenerateWorkloadIdentityPool(cr.Spec.ForProvider, instance)
+
+	if _, err := e.pools.Create(globalLocationRRN(e.projectID), instance).
+		WorkloadIdentityPoolId(meta.GetExternalName(cr)).Context(ctx).Do(); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateWorkloadIdentityPool)
+	}
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *workloadIdentityPoolExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.WorkloadIdentityPool)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotWorkloadIdentityPool)
+	}
+	name := workloadIdentityPoolRRN(e.projectID, meta.GetExternalName(cr))
+
+	// We have to get the pool again here to calculate update mask (what to
+	// patch).
+	instance, err := e.pools.Get(name).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetWorkloadIdentityPool)
+	}
+
+	// The pool is patched on the next reconcile, once it was undeleted.
+	if instance.State == v1alpha1.WorkloadIdentityStateDeleted {
+		_, err := e.pools.Undelete(name, &iamv1.UndeleteWorkloadIdentityPoolRequest{}).Context(ctx).Do()
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUndeleteWorkloadIdentityPool)
+	}
+
+	u, um := workloadidentitypool.IsWorkloadIdentityPoolUpToDate(cr.Spec.ForProvider, instance)
+	if u {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	workloadidentitypool.GenerateWorkloadIdentityPool(cr.Spec.ForProvider, instance)
+	if _, err := e.pools.Patch(name, instance).UpdateMask(um).Context(ctx).Do(); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateWorkloadIdentityPool)
+	}
+
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *workloadIdentityPoolExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.WorkloadIdentityPool)
+	if !ok {
+		return errors.New(errNotWorkloadIdentityPool)
+	}
+	// A pool that is already soft deleted cannot be deleted again.
+	if cr.Status.AtProvider.State == v1alpha1.WorkloadIdentityStateDeleted {
+		return nil
+	}
+	cr.SetConditions(xpv1.Deleting())
+	_, err := e.pools.Delete(workloadIdentityPoolRRN(e.projectID, meta.GetExternalName(cr))).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteWorkloadIdentityPool)
+}
+
+// Workload identity pools and their providers are always in the global
+// location.
+func globalLocationRRN(projectID string) string {
+	return fmt.Sprintf("projects/%s/locations/global", projectID)
+}
+
+func workloadIdentityPoolRRN(projectID, pool string) string {
+	return fmt.Sprintf("%s/workloadIdentityPools/%s", globalLocationRRN(projectID), pool)
+}
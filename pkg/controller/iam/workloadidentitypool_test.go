@@ -0,0 +1,380 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	iamv1 "google.golang.org/api/iam/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
+)
+
+const (
+	poolName = "test-pool"
+)
+
+var poolRRN = "projects/" + project + "/locations/global/workloadIdentityPools/" + poolName
+
+type workloadIdentityPoolModifier func(*v1alpha1.WorkloadIdentityPool)
+
+func wipWithDisplayName(n string) workloadIdentityPoolModifier {
+	return func(p *v1alpha1.WorkloadIdentityPool) { p.Spec.ForProvider.DisplayName = &n }
+}
+
+func wipWithDisabled(d bool) workloadIdentityPoolModifier {
+	return func(p *v1alpha1.WorkloadIdentityPool) { p.Spec.ForProvider.Disabled = &d }
+}
+
+func wipWithAtProvider(o v1alpha1.WorkloadIdentityPoolObservation) workloadIdentityPoolModifier {
+	return func(p *v1alpha1.WorkloadIdentityPool) { p.Status.AtProvider = o }
+}
+
+func wipWithCondition(c xpv1.Condition) workloadIdentityPoolModifier {
+	return func(p *v1alpha1.WorkloadIdentityPool) { p.SetConditions(c) }
+}
+
+func wipWithDeletionTimestamp() workloadIdentityPoolModifier {
+	return func(p *v1alpha1.WorkloadIdentityPool) {
+		now := metav1.Unix(1, 0)
+		p.SetDeletionTimestamp(&now)
+	}
+}
+
+func newWorkloadIdentityPool(m ...workloadIdentityPoolModifier) *v1alpha1.WorkloadIdentityPool {
+	p := &v1alpha1.WorkloadIdentityPool{
+		ObjectMeta: metav1.ObjectMeta{Name: poolName},
+	}
+	meta.SetExternalName(p, poolName)
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func newWorkloadIdentityPoolExternal(t *testing.T, url string) *workloadIdentityPoolExternal {
+	t.Helper()
+	s, err := iamv1.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("cannot create IAM service: %v", err)
+	}
+	return &workloadIdentityPoolExternal{pools: iamv1.NewProjectsLocationsWorkloadIdentityPoolsService(s), projectID: project}
+}
+
+func TestWorkloadIdentityPoolObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"NotWorkloadIdentityPool": {
+			reason: "Should return an error if the managed resource is not a WorkloadIdentityPool",
+			mg:     &strange{},
+			want: want{
+				mg:  &strange{},
+				err: errors.New(errNotWorkloadIdentityPool),
+			},
+		},
+		"NotFound": {
+			reason: "Should report that the WorkloadIdentityPool does not exist",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+			}),
+			mg: newWorkloadIdentityPool(),
+			want: want{
+				mg: newWorkloadIdentityPool(),
+			},
+		},
+		"GetFailed": {
+			reason: "Should return an error if the WorkloadIdentityPool cannot be fetched",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			mg: newWorkloadIdentityPool(),
+			want: want{
+				mg:  newWorkloadIdentityPool(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGetWorkloadIdentityPool),
+			},
+		},
+		"DeletedWhileDeleting": {
+			reason: "Should report that a soft deleted WorkloadIdentityPool does not exist once it is being deleted",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&iamv1.WorkloadIdentityPool{Name: poolRRN, State: v1alpha1.WorkloadIdentityStateDeleted})
+			}),
+			mg: newWorkloadIdentityPool(wipWithDeletionTimestamp()),
+			want: want{
+				mg: newWorkloadIdentityPool(wipWithDeletionTimestamp(), wipWithAtProvider(v1alpha1.WorkloadIdentityPoolObservation{
+					Name:  poolRRN,
+					State: v1alpha1.WorkloadIdentityStateDeleted,
+				})),
+			},
+		},
+		"DeletedOutOfBand": {
+			reason: "Should report a soft deleted WorkloadIdentityPool that is not being deleted as existing but outdated",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&iamv1.WorkloadIdentityPool{Name: poolRRN, State: v1alpha1.WorkloadIdentityStateDeleted})
+			}),
+			mg: newWorkloadIdentityPool(),
+			want: want{
+				mg: newWorkloadIdentityPool(
+					wipWithAtProvider(v1alpha1.WorkloadIdentityPoolObservation{Name: poolRRN, State: v1alpha1.WorkloadIdentityStateDeleted}),
+					wipWithCondition(xpv1.Unavailable())),
+				obs: managed.ExternalObservation{ResourceExists: true},
+			},
+		},
+		"LateInitializedAndUpToDate": {
+			reason: "Should late initialize the display name and report the WorkloadIdentityPool as up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if !strings.HasSuffix(r.URL.Path, poolRRN) {
+					t.Errorf("requested URL.Path should end with %s, got %s instead", poolRRN, r.URL.Path)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&iamv1.WorkloadIdentityPool{Name: poolRRN, State: v1alpha1.WorkloadIdentityStateActive, DisplayName: "pool"})
+			}),
+			mg: newWorkloadIdentityPool(wipWithDisabled(false)),
+			want: want{
+				mg: newWorkloadIdentityPool(
+					wipWithDisabled(false),
+					wipWithDisplayName("pool"),
+					wipWithAtProvider(v1alpha1.WorkloadIdentityPoolObservation{Name: poolRRN, State: v1alpha1.WorkloadIdentityStateActive}),
+					wipWithCondition(xpv1.Available())),
+				obs: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+			},
+		},
+		"DisabledDiffers": {
+			reason: "Should report the WorkloadIdentityPool as not up to date if it should be disabled",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&iamv1.WorkloadIdentityPool{Name: poolRRN, State: v1alpha1.WorkloadIdentityStateActive, DisplayName: "pool"})
+			}),
+			mg: newWorkloadIdentityPool(wipWithDisplayName("pool"), wipWithDisabled(true)),
+			want: want{
+				mg: newWorkloadIdentityPool(
+					wipWithDisplayName("pool"),
+					wipWithDisabled(true),
+					wipWithAtProvider(v1alpha1.WorkloadIdentityPoolObservation{Name: poolRRN, State: v1alpha1.WorkloadIdentityStateActive}),
+					wipWithCondition(xpv1.Available())),
+				obs: managed.ExternalObservation{ResourceExists: true},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newWorkloadIdentityPoolExternal(t, server.URL)
+			obs, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWorkloadIdentityPoolCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"Created": {
+			reason: "Should create the WorkloadIdentityPool with its external name as ID",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff(poolName, r.URL.Query().Get("workloadIdentityPoolId")); diff != "" {
+					t.Errorf("workloadIdentityPoolId: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&iamv1.Operation{})
+			}),
+			mg: newWorkloadIdentityPool(wipWithDisplayName("pool")),
+		},
+		"CreateFailed": {
+			reason: "Should return an error if the WorkloadIdentityPool cannot be created",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			mg:  newWorkloadIdentityPool(),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateWorkloadIdentityPool),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newWorkloadIdentityPoolExternal(t, server.URL)
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWorkloadIdentityPoolUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		observed *iamv1.WorkloadIdentityPool
+		path     string
+		mask     string
+		mg       resource.Managed
+	}{
+		"Disabled": {
+			reason:   "Should patch only the disabled state of the WorkloadIdentityPool",
+			observed: &iamv1.WorkloadIdentityPool{Name: poolRRN, State: v1alpha1.WorkloadIdentityStateActive, DisplayName: "pool"},
+			path:     poolRRN,
+			mask:     "disabled",
+			mg:       newWorkloadIdentityPool(wipWithDisplayName("pool"), wipWithDisabled(true)),
+		},
+		"Undeleted": {
+			reason:   "Should undelete a soft deleted WorkloadIdentityPool instead of patching it",
+			observed: &iamv1.WorkloadIdentityPool{Name: poolRRN, State: v1alpha1.WorkloadIdentityStateDeleted},
+			path:     poolRRN + ":undelete",
+			mg:       newWorkloadIdentityPool(wipWithDisabled(true)),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(tc.observed)
+					return
+				}
+				if !strings.HasSuffix(r.URL.Path, tc.path) {
+					t.Errorf("\n%s\nrequested URL.Path should end with %s, got %s instead", tc.reason, tc.path, r.URL.Path)
+				}
+				if diff := cmp.Diff(tc.mask, r.URL.Query().Get("updateMask")); diff != "" {
+					t.Errorf("updateMask: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&iamv1.Operation{})
+			}))
+			defer server.Close()
+			e := newWorkloadIdentityPoolExternal(t, server.URL)
+			if _, err := e.Update(context.Background(), tc.mg); err != nil {
+				t.Errorf("\n%s\nUpdate(...): unexpected error: %v", tc.reason, err)
+			}
+		})
+	}
+}
+
+func TestWorkloadIdentityPoolDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"Deleted": {
+			reason: "Should delete the WorkloadIdentityPool",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&iamv1.Operation{})
+			}),
+			mg: newWorkloadIdentityPool(),
+		},
+		"AlreadySoftDeleted": {
+			reason: "Should not delete a WorkloadIdentityPool that is already soft deleted",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}),
+			mg: newWorkloadIdentityPool(wipWithAtProvider(v1alpha1.WorkloadIdentityPoolObservation{State: v1alpha1.WorkloadIdentityStateDeleted})),
+		},
+		"AlreadyGone": {
+			reason: "Should not return an error if the WorkloadIdentityPool is already gone",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+			}),
+			mg: newWorkloadIdentityPool(),
+		},
+		"DeleteFailed": {
+			reason: "Should return an error if the WorkloadIdentityPool cannot be deleted",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			mg:  newWorkloadIdentityPool(),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteWorkloadIdentityPool),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			e := newWorkloadIdentityPoolExternal(t, server.URL)
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
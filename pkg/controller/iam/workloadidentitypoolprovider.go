@@ -0,0 +1,203 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iam
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	iamv1 "google.golang.org/api/iam/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/workloadidentitypoolprovider"
+)
+
+// Error strings.
+const (
+	errNotWorkloadIdentityPoolProvider      = "managed resource is not a GCP WorkloadIdentityPoolProvider"
+	errGetWorkloadIdentityPoolProvider      = "cannot get GCP WorkloadIdentityPoolProvider object via IAM API"
+	errCreateWorkloadIdentityPoolProvider   = "cannot create GCP WorkloadIdentityPoolProvider object via IAM API"
+	errUpdateWorkloadIdentityPoolProvider   = "cannot update GCP WorkloadIdentityPoolProvider object via IAM API"
+	errUndeleteWorkloadIdentityPoolProvider = "cannot undelete GCP WorkloadIdentityPoolProvider object via IAM API"
+	errDeleteWorkloadIdentityPoolProvider   = "cannot delete GCP WorkloadIdentityPoolProvider object via IAM API"
+)
+
+// SetupWorkloadIdentityPoolProvider adds a controller that reconciles
+// WorkloadIdentityPoolProviders.
+func SetupWorkloadIdentityPoolProvider(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.WorkloadIdentityPoolProviderGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.WorkloadIdentityPoolProvider{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.WorkloadIdentityPoolProviderGroupVersionKind),
+			managed.WithExternalConnecter(&workloadIdentityPoolProviderConnecter{client: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type workloadIdentityPoolProviderConnecter struct {
+	client client.Client
+}
+
+// Connect sets up iam client using credentials from the provider
+func (c *workloadIdentityPoolProviderConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := iamv1.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &workloadIdentityPoolProviderExternal{providers: iamv1.NewProjectsLocationsWorkloadIdentityPoolsProvidersService(s), projectID: projectID}, nil
+}
+
+type workloadIdentityPoolProviderExternal struct {
+	providers workloadidentitypoolprovider.Client
+	projectID string
+}
+
+func (e *workloadIdentityPoolProviderExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.WorkloadIdentityPoolProvider)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotWorkloadIdentityPoolProvider)
+	}
+
+	instance, err := e.providers.Get(workloadIdentityPoolProviderRRN(e.projectID, cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetWorkloadIdentityPoolProvider)
+	}
+
+	cr.Status.AtProvider = workloadidentitypoolprovider.GenerateWorkloadIdentityPoolProviderObservation(*instance)
+
+	// NOTE: Deleted providers are kept in the DELETED state for 30 days
+	// before they are purged, just like pools.
+	if instance.State == v1alpha1.WorkloadIdentityStateDeleted {
+		if meta.WasDeleted(cr) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		cr.Status.SetConditions(xpv1.Unavailable())
+		return managed.ExternalObservation{ResourceExists: true}, nil
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	workloadidentitypoolprovider.LateInitializeWorkloadIdentityPoolProvider(&cr.Spec.ForProvider, *instance)
+
+	cr.Status.SetConditions(xpv1.Available())
+
+	upToDate, _ := workloadidentitypoolprovider.IsWorkloadIdentityPoolProviderUpToDate(cr.Spec.ForProvider, instance)
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: !cmp.Equal(currentSpec, &cr.Spec.ForProvider),
+		ResourceUpToDate:        upToDate,
+	}, nil
+}
+
+func (e *workloadIdentityPoolProviderExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.WorkloadIdentityPoolProvider)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotWorkloadIdentityPoolProvider)
+	}
+	cr.SetConditions(xpv1.Creating())
+	instance := &iamv1.WorkloadIdentityPoolProvider{}
+	workloadidentitypoolprovider.GenerateWorkloadIdentityPoolProvider(cr.Spec.ForProvider, instance)
+
+	parent := workloadIdentityPoolRRN(e.projectID, gcp.StringValue(cr.Spec.ForProvider.WorkloadIdentityPool))
+	if _, err := e.providers.Create(parent, instance).
+		WorkloadIdentityPoolProviderId(meta.GetExternalName(cr)).Context(ctx).Do(); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateWorkloadIdentityPoolProvider)
+	}
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *workloadIdentityPoolProviderExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.WorkloadIdentityPoolProvider)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotWorkloadIdentityPoolProvider)
+	}
+	name := workloadIdentityPoolProviderRRN(e.projectID, cr)
+
+	// We have to get the provider again here to calculate update mask (what
+	// to patch).
+	instance, err := e.providers.Get(name).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetWorkloadIdentityPoolProvider)
+	}
+
+	// The provider is patched on the next reconcile, once it was undeleted.
+	if instance.State == v1alpha1.WorkloadIdentityStateDeleted {
+		_, err := e.providers.Undelete(name, &iamv1.UndeleteWorkloadIdentityPoolProviderRequest{}).Context(ctx).Do()
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUndeleteWorkloadIdentityPoolProvider)
+	}
+
+	u, um := workloadidentitypoolprovider.IsWorkloadIdentityPoolProviderUpToDate(cr.Spec.ForProvider, instance)
+	if u {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	workloadidentitypoolprovider.GenerateWorkloadIdentityPoolProvider(cr.Spec.ForProvider, instance)
+	if _, err := e.providers.Patch(name, instance).UpdateMask(um).Context(ctx).Do(); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateWorkloadIdentityPoolProvider)
+	}
+
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *workloadIdentityPoolProviderExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.WorkloadIdentityPoolProvider)
+	if !ok {
+		return errors.New(errNotWorkloadIdentityPoolProvider)
+	}
+	// A provider that is already soft deleted cannot be deleted again.
+	if cr.Status.AtProvider.State == v1alpha1.WorkloadIdentityStateDeleted {
+		return nil
+	}
+	cr.SetConditions(xpv1.Deleting())
+	_, err := e.providers.Delete(workloadIdentityPoolProviderRRN(e.projectID, cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteWorkloadIdentityPoolProvider)
+}
+
+func workloadIdentityPoolProviderRRN(projectID string, cr *v1alpha1.WorkloadIdentityPoolProvider) string {
+	return fmt.Sprintf("%s/providers/%s",
+		workloadIdentityPoolRRN(projectID, gcp.StringValue(cr.Spec.ForProvider.WorkloadIdentityPool)), meta.GetExternalName(cr))
+}
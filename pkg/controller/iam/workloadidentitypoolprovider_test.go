@@ -0,0 +1,293 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	iamv1 "google.golang.org/api/iam/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
+)
+
+const (
+	poolProviderName = "test-provider"
+	testIssuerURI    = "https://token.actions.githubusercontent.com"
+)
+
+var (
+	poolProviderRRN = poolRRN + "/providers/" + poolProviderName
+	testMapping     = map[string]string{"google.subject": "assertion.sub"}
+)
+
+type workloadIdentityPoolProviderModifier func(*v1alpha1.WorkloadIdentityPoolProvider)
+
+func wippWithAttributeMapping(m map[string]string) workloadIdentityPoolProviderModifier {
+	return func(p *v1alpha1.WorkloadIdentityPoolProvider) { p.Spec.ForProvider.AttributeMapping = m }
+}
+
+func wippWithAttributeCondition(c string) workloadIdentityPoolProviderModifier {
+	return func(p *v1alpha1.WorkloadIdentityPoolProvider) { p.Spec.ForProvider.AttributeCondition = &c }
+}
+
+func wippWithAWS(accountID string) workloadIdentityPoolProviderModifier {
+	return func(p *v1alpha1.WorkloadIdentityPoolProvider) {
+		p.Spec.ForProvider.OIDC = nil
+		p.Spec.ForProvider.AWS = &v1alpha1.WorkloadIdentityPoolProviderAWS{AccountID: accountID}
+	}
+}
+
+func wippWithAtProvider(o v1alpha1.WorkloadIdentityPoolProviderObservation) workloadIdentityPoolProviderModifier {
+	return func(p *v1alpha1.WorkloadIdentityPoolProvider) { p.Status.AtProvider = o }
+}
+
+func wippWithCondition(c xpv1.Condition) workloadIdentityPoolProviderModifier {
+	return func(p *v1alpha1.WorkloadIdentityPoolProvider) { p.SetConditions(c) }
+}
+
+func newWorkloadIdentityPoolProvider(m ...workloadIdentityPoolProviderModifier) *v1alpha1.WorkloadIdentityPoolProvider {
+	pool := poolName
+	p := &v1alpha1.WorkloadIdentityPoolProvider{
+		ObjectMeta: metav1.ObjectMeta{Name: poolProviderName},
+		Spec: v1alpha1.WorkloadIdentityPoolProviderSpec{
+			ForProvider: v1alpha1.WorkloadIdentityPoolProviderParameters{
+				WorkloadIdentityPool: &pool,
+				OIDC:                 &v1alpha1.WorkloadIdentityPoolProviderOIDC{IssuerURI: testIssuerURI},
+			},
+		},
+	}
+	meta.SetExternalName(p, poolProviderName)
+	for _, f := range m {
+		f(p)
+	}
+	return p
+}
+
+func newWorkloadIdentityPoolProviderExternal(t *testing.T, url string) *workloadIdentityPoolProviderExternal {
+	t.Helper()
+	s, err := iamv1.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("cannot create IAM service: %v", err)
+	}
+	return &workloadIdentityPoolProviderExternal{providers: iamv1.NewProjectsLocationsWorkloadIdentityPoolsProvidersService(s), projectID: project}
+}
+
+func TestWorkloadIdentityPoolProviderObserve(t *testing.T) {
+	active := v1alpha1.WorkloadIdentityPoolProviderObservation{Name: poolProviderRRN, State: v1alpha1.WorkloadIdentityStateActive}
+
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+	cases := map[string]struct {
+		reason   string
+		observed *iamv1.WorkloadIdentityPoolProvider
+		mg       resource.Managed
+		want     want
+	}{
+		"NotWorkloadIdentityPoolProvider": {
+			reason: "Should return an error if the managed resource is not a WorkloadIdentityPoolProvider",
+			mg:     &strange{},
+			want: want{
+				mg:  &strange{},
+				err: errors.New(errNotWorkloadIdentityPoolProvider),
+			},
+		},
+		"NotFound": {
+			reason: "Should report that the WorkloadIdentityPoolProvider does not exist",
+			mg:     newWorkloadIdentityPoolProvider(),
+			want: want{
+				mg: newWorkloadIdentityPoolProvider(),
+			},
+		},
+		"DeletedOutOfBand": {
+			reason:   "Should report a soft deleted WorkloadIdentityPoolProvider that is not being deleted as existing but outdated",
+			observed: &iamv1.WorkloadIdentityPoolProvider{Name: poolProviderRRN, State: v1alpha1.WorkloadIdentityStateDeleted},
+			mg:       newWorkloadIdentityPoolProvider(),
+			want: want{
+				mg: newWorkloadIdentityPoolProvider(
+					wippWithAtProvider(v1alpha1.WorkloadIdentityPoolProviderObservation{Name: poolProviderRRN, State: v1alpha1.WorkloadIdentityStateDeleted}),
+					wippWithCondition(xpv1.Unavailable())),
+				obs: managed.ExternalObservation{ResourceExists: true},
+			},
+		},
+		"LateInitializedDefaultMapping": {
+			reason: "Should late initialize the default attribute mapping of an AWS WorkloadIdentityPoolProvider",
+			observed: &iamv1.WorkloadIdentityPoolProvider{
+				Name: poolProviderRRN, State: v1alpha1.WorkloadIdentityStateActive,
+				AttributeMapping: testMapping,
+				Aws:              &iamv1.Aws{AccountId: "123456789012"},
+			},
+			mg: newWorkloadIdentityPoolProvider(wippWithAWS("123456789012")),
+			want: want{
+				mg: newWorkloadIdentityPoolProvider(
+					wippWithAWS("123456789012"),
+					wippWithAttributeMapping(testMapping),
+					wippWithAtProvider(active),
+					wippWithCondition(xpv1.Available())),
+				obs: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+			},
+		},
+		"AttributeConditionDiffers": {
+			reason: "Should report the WorkloadIdentityPoolProvider as not up to date if its attribute condition differs",
+			observed: &iamv1.WorkloadIdentityPoolProvider{
+				Name: poolProviderRRN, State: v1alpha1.WorkloadIdentityStateActive,
+				AttributeMapping:   testMapping,
+				AttributeCondition: "true",
+				Oidc:               &iamv1.Oidc{IssuerUri: testIssuerURI},
+			},
+			mg: newWorkloadIdentityPoolProvider(wippWithAttributeMapping(testMapping), wippWithAttributeCondition("false")),
+			want: want{
+				mg: newWorkloadIdentityPoolProvider(
+					wippWithAttributeMapping(testMapping),
+					wippWithAttributeCondition("false"),
+					wippWithAtProvider(active),
+					wippWithCondition(xpv1.Available())),
+				obs: managed.ExternalObservation{ResourceExists: true},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if tc.observed == nil {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				if !strings.HasSuffix(r.URL.Path, poolProviderRRN) {
+					t.Errorf("requested URL.Path should end with %s, got %s instead", poolProviderRRN, r.URL.Path)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(tc.observed)
+			}))
+			defer server.Close()
+			e := newWorkloadIdentityPoolProviderExternal(t, server.URL)
+			obs, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestWorkloadIdentityPoolProviderCreate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := &iamv1.WorkloadIdentityPoolProvider{}
+		_ = json.NewDecoder(r.Body).Decode(got)
+		_ = r.Body.Close()
+		if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+			t.Errorf("r: -want, +got:\n%s", diff)
+		}
+		if !strings.HasSuffix(r.URL.Path, poolRRN+"/providers") {
+			t.Errorf("requested URL.Path should end with %s, got %s instead", poolRRN+"/providers", r.URL.Path)
+		}
+		if diff := cmp.Diff(poolProviderName, r.URL.Query().Get("workloadIdentityPoolProviderId")); diff != "" {
+			t.Errorf("workloadIdentityPoolProviderId: -want, +got:\n%s", diff)
+		}
+		want := &iamv1.WorkloadIdentityPoolProvider{AttributeMapping: testMapping, Oidc: &iamv1.Oidc{IssuerUri: testIssuerURI}}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("body: -want, +got:\n%s", diff)
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(&iamv1.Operation{})
+	}))
+	defer server.Close()
+	e := newWorkloadIdentityPoolProviderExternal(t, server.URL)
+	if _, err := e.Create(context.Background(), newWorkloadIdentityPoolProvider(wippWithAttributeMapping(testMapping))); err != nil {
+		t.Errorf("Create(...): unexpected error: %v", err)
+	}
+}
+
+func TestWorkloadIdentityPoolProviderUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		observed *iamv1.WorkloadIdentityPoolProvider
+		path     string
+		mask     string
+		mg       resource.Managed
+	}{
+		"AttributeMapping": {
+			reason: "Should patch only the attribute mapping of the WorkloadIdentityPoolProvider",
+			observed: &iamv1.WorkloadIdentityPoolProvider{
+				Name: poolProviderRRN, State: v1alpha1.WorkloadIdentityStateActive,
+				AttributeMapping: testMapping,
+				Oidc:             &iamv1.Oidc{IssuerUri: testIssuerURI},
+			},
+			path: poolProviderRRN,
+			mask: "attributeMapping",
+			mg:   newWorkloadIdentityPoolProvider(wippWithAttributeMapping(map[string]string{"google.subject": "assertion.actor"})),
+		},
+		"Undeleted": {
+			reason:   "Should undelete a soft deleted WorkloadIdentityPoolProvider instead of patching it",
+			observed: &iamv1.WorkloadIdentityPoolProvider{Name: poolProviderRRN, State: v1alpha1.WorkloadIdentityStateDeleted},
+			path:     poolProviderRRN + ":undelete",
+			mg:       newWorkloadIdentityPoolProvider(wippWithAttributeMapping(testMapping)),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(tc.observed)
+					return
+				}
+				if !strings.HasSuffix(r.URL.Path, tc.path) {
+					t.Errorf("\n%s\nrequested URL.Path should end with %s, got %s instead", tc.reason, tc.path, r.URL.Path)
+				}
+				if diff := cmp.Diff(tc.mask, r.URL.Query().Get("updateMask")); diff != "" {
+					t.Errorf("updateMask: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&iamv1.Operation{})
+			}))
+			defer server.Close()
+			e := newWorkloadIdentityPoolProviderExternal(t, server.URL)
+			if _, err := e.Update(context.Background(), tc.mg); err != nil {
+				t.Errorf("\n%s\nUpdate(...): unexpected error: %v", tc.reason, err)
+			}
+		})
+	}
+}
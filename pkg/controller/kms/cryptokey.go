@@ -48,7 +48,7 @@ const (
 )
 
 // SetupCryptoKey adds a controller that reconciles CryptoKeys.
-func SetupCryptoKey(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupCryptoKey(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
 	name := managed.ControllerName(v1alpha1.CryptoKeyGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -61,7 +61,9 @@ func SetupCryptoKey(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter
 			resource.ManagedKind(v1alpha1.CryptoKeyGroupVersionKind),
 			managed.WithExternalConnecter(&cryptoKeyConnecter{client: mgr.GetClient()}),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
 			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
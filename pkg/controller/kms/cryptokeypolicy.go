@@ -47,7 +47,7 @@ const (
 )
 
 // SetupCryptoKeyPolicy adds a controller that reconciles CryptoKeyPolicys.
-func SetupCryptoKeyPolicy(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupCryptoKeyPolicy(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
 	name := managed.ControllerName(v1alpha1.CryptoKeyPolicyGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -61,6 +61,7 @@ func SetupCryptoKeyPolicy(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateL
 			managed.WithExternalConnecter(&cryptoKeyPolicyConnecter{client: mgr.GetClient()}),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
@@ -0,0 +1,197 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kms
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kmsv1 "google.golang.org/api/cloudkms/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/kms/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/cryptokeyversion"
+)
+
+const (
+	errNotCryptoKeyVersion = "managed resource is not a GCP CryptoKeyVersion"
+	errDestroy             = "cannot schedule destruction of GCP CryptoKeyVersion"
+	errRestore             = "cannot restore GCP CryptoKeyVersion"
+)
+
+// SetupCryptoKeyVersion adds a controller that reconciles CryptoKeyVersions.
+func SetupCryptoKeyVersion(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.CryptoKeyVersionGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.CryptoKeyVersion{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.CryptoKeyVersionGroupVersionKind),
+			managed.WithExternalConnecter(&cryptoKeyVersionConnecter{client: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			// The version ID is assigned by GCP at creation time.
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type cryptoKeyVersionConnecter struct {
+	client client.Client
+}
+
+// Connect sets up kms client using credentials from the provider
+func (c *cryptoKeyVersionConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, opts, err := gcp.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := kmsv1.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &cryptoKeyVersionExternal{versions: kmsv1.NewProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsService(s)}, nil
+}
+
+type cryptoKeyVersionExternal struct {
+	versions cryptokeyversion.Client
+}
+
+func (e *cryptoKeyVersionExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.CryptoKeyVersion)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotCryptoKeyVersion)
+	}
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{}, nil
+	}
+
+	instance, err := e.versions.Get(cryptoKeyVersionRRN(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGet)
+	}
+	cr.Status.AtProvider = cryptokeyversion.GenerateObservation(*instance)
+
+	// NOTE: A version that is scheduled for destruction can still be
+	// restored until its destroy time, but it is gone as far as the deletion
+	// of this resource is concerned.
+	if meta.WasDeleted(cr) && (instance.State == cryptokeyversion.StateDestroyScheduled || instance.State == cryptokeyversion.StateDestroyed) {
+		return managed.ExternalObservation{}, nil
+	}
+
+	switch instance.State {
+	case cryptokeyversion.StateEnabled:
+		cr.SetConditions(xpv1.Available())
+	case cryptokeyversion.StatePendingGeneration, cryptokeyversion.StatePendingImport:
+		cr.SetConditions(xpv1.Creating())
+	default:
+		cr.SetConditions(xpv1.Unavailable())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: cryptokeyversion.IsUpToDate(cr.Spec.ForProvider, instance),
+	}, nil
+}
+
+func (e *cryptoKeyVersionExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.CryptoKeyVersion)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotCryptoKeyVersion)
+	}
+	cr.SetConditions(xpv1.Creating())
+
+	// New versions are ENABLED. Any other desired state is reconciled once
+	// the version has been generated.
+	instance, err := e.versions.Create(gcp.StringValue(cr.Spec.ForProvider.CryptoKey), &kmsv1.CryptoKeyVersion{}).
+		Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreate)
+	}
+
+	meta.SetExternalName(cr, cryptokeyversion.ID(instance.Name))
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+func (e *cryptoKeyVersionExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.CryptoKeyVersion)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotCryptoKeyVersion)
+	}
+
+	desired := cryptokeyversion.DesiredState(cr.Spec.ForProvider)
+	if desired == cryptokeyversion.StateDestroyScheduled {
+		_, err := e.versions.Destroy(cryptoKeyVersionRRN(cr), &kmsv1.DestroyCryptoKeyVersionRequest{}).Context(ctx).Do()
+		return managed.ExternalUpdate{}, errors.Wrap(err, errDestroy)
+	}
+
+	// A version that is scheduled for destruction can only be restored to
+	// the DISABLED state.
+	if cr.Status.AtProvider.State == cryptokeyversion.StateDestroyScheduled {
+		if _, err := e.versions.Restore(cryptoKeyVersionRRN(cr), &kmsv1.RestoreCryptoKeyVersionRequest{}).Context(ctx).Do(); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errRestore)
+		}
+		if desired == cryptokeyversion.StateDisabled {
+			return managed.ExternalUpdate{}, nil
+		}
+	}
+
+	_, err := e.versions.Patch(cryptoKeyVersionRRN(cr), &kmsv1.CryptoKeyVersion{State: desired}).
+		UpdateMask("state").Context(ctx).Do()
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+}
+
+func (e *cryptoKeyVersionExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.CryptoKeyVersion)
+	if !ok {
+		return errors.New(errNotCryptoKeyVersion)
+	}
+	cr.SetConditions(xpv1.Deleting())
+
+	// CryptoKeyVersions cannot be deleted. They are scheduled for
+	// destruction instead, and destroyed by GCP once their destroy time is
+	// reached.
+	switch cr.Status.AtProvider.State {
+	case cryptokeyversion.StateDestroyScheduled, cryptokeyversion.StateDestroyed:
+		return nil
+	}
+	_, err := e.versions.Destroy(cryptoKeyVersionRRN(cr), &kmsv1.DestroyCryptoKeyVersionRequest{}).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDestroy)
+}
+
+func cryptoKeyVersionRRN(cr *v1alpha1.CryptoKeyVersion) string {
+	return fmt.Sprintf("%s/cryptoKeyVersions/%s", gcp.StringValue(cr.Spec.ForProvider.CryptoKey), meta.GetExternalName(cr))
+}
@@ -0,0 +1,367 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	kmsv1 "google.golang.org/api/cloudkms/v1"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/kms/v1alpha1"
+	"github.com/crossplane/provider-gcp/pkg/clients/cryptokeyversion"
+)
+
+const (
+	ckvVersionID   = "2"
+	ckvDestroyTime = "2021-09-01T00:00:00Z"
+)
+
+var (
+	ckvCryptoKey = fmt.Sprintf("%s/cryptoKeys/%s", parentKeyRing, ckMetadataName)
+	ckvRRN       = fmt.Sprintf("%s/cryptoKeyVersions/%s", ckvCryptoKey, ckvVersionID)
+)
+
+type ckvValueModifier func(v *v1alpha1.CryptoKeyVersion)
+
+func ckvWithExternalName(s string) ckvValueModifier {
+	return func(v *v1alpha1.CryptoKeyVersion) { meta.SetExternalName(v, s) }
+}
+
+func ckvWithState(s string) ckvValueModifier {
+	return func(v *v1alpha1.CryptoKeyVersion) { v.Spec.ForProvider.State = &s }
+}
+
+func ckvWithObservation(o v1alpha1.CryptoKeyVersionObservation) ckvValueModifier {
+	return func(v *v1alpha1.CryptoKeyVersion) { v.Status.AtProvider = o }
+}
+
+func ckvWithCondition(c xpv1.Condition) ckvValueModifier {
+	return func(v *v1alpha1.CryptoKeyVersion) { v.SetConditions(c) }
+}
+
+func ckvWithDeletionTimestamp(ts metav1.Time) ckvValueModifier {
+	return func(v *v1alpha1.CryptoKeyVersion) { v.SetDeletionTimestamp(&ts) }
+}
+
+func cryptoKeyVersion(m ...ckvValueModifier) *v1alpha1.CryptoKeyVersion {
+	v := &v1alpha1.CryptoKeyVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: ckMetadataName},
+		Spec: v1alpha1.CryptoKeyVersionSpec{
+			ForProvider: v1alpha1.CryptoKeyVersionParameters{CryptoKey: &ckvCryptoKey},
+		},
+	}
+	for _, f := range m {
+		f(v)
+	}
+	return v
+}
+
+// versionServer serves the supplied CryptoKeyVersion for all requests and
+// records the method and path of each request.
+func versionServer(t *testing.T, ckv *kmsv1.CryptoKeyVersion, requests *[]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.Body.Close()
+		req := r.Method + " " + r.URL.Path
+		if um := r.URL.Query().Get("updateMask"); um != "" {
+			req += "?updateMask=" + um
+		}
+		*requests = append(*requests, req)
+		if ckv == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(ckv); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestCryptoKeyVersionObserve(t *testing.T) {
+	now := metav1.Now()
+	scheduled := &kmsv1.CryptoKeyVersion{Name: ckvRRN, State: cryptokeyversion.StateDestroyScheduled, DestroyTime: ckvDestroyTime}
+
+	type want struct {
+		mg          resource.Managed
+		observation managed.ExternalObservation
+		err         error
+	}
+	cases := map[string]struct {
+		reason  string
+		version *kmsv1.CryptoKeyVersion
+		mg      resource.Managed
+		want    want
+	}{
+		"NotCryptoKeyVersion": {
+			reason: "An error should be returned if the managed resource is not a CryptoKeyVersion",
+			mg:     &strange{},
+			want: want{
+				mg:  &strange{},
+				err: errors.New(errNotCryptoKeyVersion),
+			},
+		},
+		"NoExternalName": {
+			reason: "A CryptoKeyVersion without an external name has not been created yet",
+			mg:     cryptoKeyVersion(),
+			want: want{
+				mg: cryptoKeyVersion(),
+			},
+		},
+		"NotFound": {
+			reason: "A CryptoKeyVersion that cannot be found does not exist",
+			mg:     cryptoKeyVersion(ckvWithExternalName(ckvVersionID)),
+			want: want{
+				mg: cryptoKeyVersion(ckvWithExternalName(ckvVersionID)),
+			},
+		},
+		"Enabled": {
+			reason:  "An ENABLED CryptoKeyVersion should be available and up to date by default",
+			version: &kmsv1.CryptoKeyVersion{Name: ckvRRN, State: cryptokeyversion.StateEnabled},
+			mg:      cryptoKeyVersion(ckvWithExternalName(ckvVersionID)),
+			want: want{
+				mg: cryptoKeyVersion(
+					ckvWithExternalName(ckvVersionID),
+					ckvWithObservation(v1alpha1.CryptoKeyVersionObservation{Name: ckvRRN, State: cryptokeyversion.StateEnabled}),
+					ckvWithCondition(xpv1.Available())),
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"PendingGeneration": {
+			reason:  "A CryptoKeyVersion that is still being generated should be creating and cannot be updated yet",
+			version: &kmsv1.CryptoKeyVersion{Name: ckvRRN, State: cryptokeyversion.StatePendingGeneration},
+			mg:      cryptoKeyVersion(ckvWithExternalName(ckvVersionID), ckvWithState(cryptokeyversion.StateDisabled)),
+			want: want{
+				mg: cryptoKeyVersion(
+					ckvWithExternalName(ckvVersionID),
+					ckvWithState(cryptokeyversion.StateDisabled),
+					ckvWithObservation(v1alpha1.CryptoKeyVersionObservation{Name: ckvRRN, State: cryptokeyversion.StatePendingGeneration}),
+					ckvWithCondition(xpv1.Creating())),
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"DisabledOutOfBand": {
+			reason:  "A DISABLED CryptoKeyVersion that should be ENABLED is unavailable and not up to date",
+			version: &kmsv1.CryptoKeyVersion{Name: ckvRRN, State: cryptokeyversion.StateDisabled},
+			mg:      cryptoKeyVersion(ckvWithExternalName(ckvVersionID), ckvWithState(cryptokeyversion.StateEnabled)),
+			want: want{
+				mg: cryptoKeyVersion(
+					ckvWithExternalName(ckvVersionID),
+					ckvWithState(cryptokeyversion.StateEnabled),
+					ckvWithObservation(v1alpha1.CryptoKeyVersionObservation{Name: ckvRRN, State: cryptokeyversion.StateDisabled}),
+					ckvWithCondition(xpv1.Unavailable())),
+				observation: managed.ExternalObservation{ResourceExists: true},
+			},
+		},
+		"DestroyScheduled": {
+			reason:  "A CryptoKeyVersion that is scheduled for destruction should surface its destroy time and still exist",
+			version: scheduled,
+			mg:      cryptoKeyVersion(ckvWithExternalName(ckvVersionID), ckvWithState(cryptokeyversion.StateDestroyScheduled)),
+			want: want{
+				mg: cryptoKeyVersion(
+					ckvWithExternalName(ckvVersionID),
+					ckvWithState(cryptokeyversion.StateDestroyScheduled),
+					ckvWithObservation(v1alpha1.CryptoKeyVersionObservation{Name: ckvRRN, State: cryptokeyversion.StateDestroyScheduled, DestroyTime: ckvDestroyTime}),
+					ckvWithCondition(xpv1.Unavailable())),
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"DestroyScheduledWhileDeleted": {
+			reason:  "A deleted CryptoKeyVersion that is scheduled for destruction no longer exists",
+			version: scheduled,
+			mg:      cryptoKeyVersion(ckvWithExternalName(ckvVersionID), ckvWithDeletionTimestamp(now)),
+			want: want{
+				mg: cryptoKeyVersion(
+					ckvWithExternalName(ckvVersionID),
+					ckvWithDeletionTimestamp(now),
+					ckvWithObservation(v1alpha1.CryptoKeyVersionObservation{Name: ckvRRN, State: cryptokeyversion.StateDestroyScheduled, DestroyTime: ckvDestroyTime})),
+			},
+		},
+		"Destroyed": {
+			reason:  "A destroyed CryptoKeyVersion cannot leave its state and is considered up to date",
+			version: &kmsv1.CryptoKeyVersion{Name: ckvRRN, State: cryptokeyversion.StateDestroyed, DestroyEventTime: ckvDestroyTime},
+			mg:      cryptoKeyVersion(ckvWithExternalName(ckvVersionID)),
+			want: want{
+				mg: cryptoKeyVersion(
+					ckvWithExternalName(ckvVersionID),
+					ckvWithObservation(v1alpha1.CryptoKeyVersionObservation{Name: ckvRRN, State: cryptokeyversion.StateDestroyed, DestroyEventTime: ckvDestroyTime}),
+					ckvWithCondition(xpv1.Unavailable())),
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			server := httptest.NewServer(versionServer(t, tc.version, &requests))
+			defer server.Close()
+			s, _ := kmsv1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &cryptoKeyVersionExternal{versions: kmsv1.NewProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsService(s)}
+			obs, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.observation, obs); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCryptoKeyVersionCreate(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(versionServer(t, &kmsv1.CryptoKeyVersion{Name: ckvRRN, State: cryptokeyversion.StatePendingGeneration}, &requests))
+	defer server.Close()
+	s, _ := kmsv1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	e := &cryptoKeyVersionExternal{versions: kmsv1.NewProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsService(s)}
+
+	cr := cryptoKeyVersion()
+	got, err := e.Create(context.Background(), cr)
+	if err != nil {
+		t.Errorf("Create(...): %s", err)
+	}
+	if diff := cmp.Diff(managed.ExternalCreation{ExternalNameAssigned: true}, got); diff != "" {
+		t.Errorf("Create(...): -want, +got:\n%s", diff)
+	}
+	if diff := cmp.Diff(ckvVersionID, meta.GetExternalName(cr)); diff != "" {
+		t.Errorf("Create(...): -want external name, +got external name:\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"POST /v1/" + ckvCryptoKey + "/cryptoKeyVersions"}, requests); diff != "" {
+		t.Errorf("Create(...): -want requests, +got requests:\n%s", diff)
+	}
+}
+
+func TestCryptoKeyVersionUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		want   []string
+	}{
+		"Disable": {
+			reason: "An ENABLED CryptoKeyVersion should be patched to be DISABLED",
+			mg: cryptoKeyVersion(
+				ckvWithExternalName(ckvVersionID),
+				ckvWithState(cryptokeyversion.StateDisabled),
+				ckvWithObservation(v1alpha1.CryptoKeyVersionObservation{State: cryptokeyversion.StateEnabled})),
+			want: []string{"PATCH /v1/" + ckvRRN + "?updateMask=state"},
+		},
+		"ScheduleDestruction": {
+			reason: "A CryptoKeyVersion that should be DESTROY_SCHEDULED should be destroyed",
+			mg: cryptoKeyVersion(
+				ckvWithExternalName(ckvVersionID),
+				ckvWithState(cryptokeyversion.StateDestroyScheduled),
+				ckvWithObservation(v1alpha1.CryptoKeyVersionObservation{State: cryptokeyversion.StateEnabled})),
+			want: []string{"POST /v1/" + ckvRRN + ":destroy"},
+		},
+		"RestoreToDisabled": {
+			reason: "Restoring a CryptoKeyVersion that is scheduled for destruction leaves it DISABLED",
+			mg: cryptoKeyVersion(
+				ckvWithExternalName(ckvVersionID),
+				ckvWithState(cryptokeyversion.StateDisabled),
+				ckvWithObservation(v1alpha1.CryptoKeyVersionObservation{State: cryptokeyversion.StateDestroyScheduled})),
+			want: []string{"POST /v1/" + ckvRRN + ":restore"},
+		},
+		"RestoreToEnabled": {
+			reason: "A restored CryptoKeyVersion should be patched to be ENABLED",
+			mg: cryptoKeyVersion(
+				ckvWithExternalName(ckvVersionID),
+				ckvWithObservation(v1alpha1.CryptoKeyVersionObservation{State: cryptokeyversion.StateDestroyScheduled})),
+			want: []string{"POST /v1/" + ckvRRN + ":restore", "PATCH /v1/" + ckvRRN + "?updateMask=state"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			server := httptest.NewServer(versionServer(t, &kmsv1.CryptoKeyVersion{Name: ckvRRN}, &requests))
+			defer server.Close()
+			s, _ := kmsv1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &cryptoKeyVersionExternal{versions: kmsv1.NewProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsService(s)}
+			if _, err := e.Update(context.Background(), tc.mg); err != nil {
+				t.Errorf("\n%s\nUpdate(...): %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, requests); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want requests, +got requests:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCryptoKeyVersionDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		version *kmsv1.CryptoKeyVersion
+		mg      resource.Managed
+		want    []string
+	}{
+		"ScheduleDestruction": {
+			reason:  "Deleting a CryptoKeyVersion should schedule its destruction",
+			version: &kmsv1.CryptoKeyVersion{Name: ckvRRN},
+			mg: cryptoKeyVersion(
+				ckvWithExternalName(ckvVersionID),
+				ckvWithObservation(v1alpha1.CryptoKeyVersionObservation{State: cryptokeyversion.StateEnabled})),
+			want: []string{"POST /v1/" + ckvRRN + ":destroy"},
+		},
+		"AlreadyScheduled": {
+			reason: "A CryptoKeyVersion that is already scheduled for destruction should not be destroyed again",
+			mg: cryptoKeyVersion(
+				ckvWithExternalName(ckvVersionID),
+				ckvWithObservation(v1alpha1.CryptoKeyVersionObservation{State: cryptokeyversion.StateDestroyScheduled})),
+		},
+		"NotFound": {
+			reason: "A CryptoKeyVersion that cannot be found should be considered deleted",
+			mg: cryptoKeyVersion(
+				ckvWithExternalName(ckvVersionID),
+				ckvWithObservation(v1alpha1.CryptoKeyVersionObservation{State: cryptokeyversion.StateEnabled})),
+			want: []string{"POST /v1/" + ckvRRN + ":destroy"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			server := httptest.NewServer(versionServer(t, tc.version, &requests))
+			defer server.Close()
+			s, _ := kmsv1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &cryptoKeyVersionExternal{versions: kmsv1.NewProjectsLocationsKeyRingsCryptoKeysCryptoKeyVersionsService(s)}
+			if err := e.Delete(context.Background(), tc.mg); err != nil {
+				t.Errorf("\n%s\nDelete(...): %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, requests); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want requests, +got requests:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -51,7 +51,7 @@ const (
 )
 
 // SetupKeyRing adds a controller that reconciles KeyRings.
-func SetupKeyRing(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupKeyRing(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
 	name := managed.ControllerName(v1alpha1.KeyRingGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -63,7 +63,9 @@ func SetupKeyRing(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter,
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.KeyRingGroupVersionKind),
 			managed.WithExternalConnecter(&keyRingConnecter{client: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
 			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
@@ -0,0 +1,191 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	loggingv2 "google.golang.org/api/logging/v2"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/logging/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/logbucket"
+)
+
+// Error strings.
+const (
+	errNewClient    = "cannot create new GCP Cloud Logging API client"
+	errNotLogBucket = "managed resource is not a GCP Cloud Logging LogBucket"
+	errGet          = "cannot get GCP object via Cloud Logging API"
+	errCreate       = "cannot create GCP object via Cloud Logging API"
+	errUpdate       = "cannot update GCP object via Cloud Logging API"
+	errDelete       = "cannot delete GCP object via Cloud Logging API"
+)
+
+// SetupLogBucket adds a controller that reconciles Cloud Logging buckets.
+func SetupLogBucket(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.LogBucketGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.LogBucket{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.LogBucketGroupVersionKind),
+			managed.WithExternalConnecter(&logBucketConnecter{client: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type logBucketConnecter struct {
+	client client.Client
+}
+
+// Connect sets up Cloud Logging client using credentials from the provider
+func (c *logBucketConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := loggingv2.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &logBucketExternal{buckets: loggingv2.NewProjectsLocationsBucketsService(s), projectID: projectID}, nil
+}
+
+type logBucketExternal struct {
+	buckets   logbucket.Client
+	projectID string
+}
+
+func (e *logBucketExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.LogBucket)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotLogBucket)
+	}
+
+	instance, err := e.buckets.Get(logBucketRRN(e.projectID, cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGet)
+	}
+
+	cr.Status.AtProvider = logbucket.GenerateLogBucketObservation(*instance)
+
+	// NOTE: Deleted buckets are kept in the DELETE_REQUESTED state for a
+	// grace period before they are purged.
+	if instance.LifecycleState == v1alpha1.LogBucketStateDeleteRequested {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	logbucket.LateInitializeLogBucket(&cr.Spec.ForProvider, *instance)
+
+	cr.Status.SetConditions(xpv1.Available())
+
+	upToDate, _ := logbucket.IsLogBucketUpToDate(cr.Spec.ForProvider, instance)
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: !cmp.Equal(currentSpec, &cr.Spec.ForProvider),
+		ResourceUpToDate:        upToDate,
+	}, nil
+}
+
+func (e *logBucketExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.LogBucket)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotLogBucket)
+	}
+	cr.SetConditions(xpv1.Creating())
+	instance := &loggingv2.LogBucket{}
+	logbucket.GenerateLogBucket(cr.Spec.ForProvider, instance)
+
+	if _, err := e.buckets.Create(locationRRN(e.projectID, cr.Spec.ForProvider.Location), instance).
+		BucketId(meta.GetExternalName(cr)).Context(ctx).Do(); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreate)
+	}
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *logBucketExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.LogBucket)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotLogBucket)
+	}
+	// We have to get the bucket again here to calculate update mask (what to
+	// patch) and to check it against the constraints of locked buckets.
+	instance, err := e.buckets.Get(logBucketRRN(e.projectID, cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGet)
+	}
+
+	u, um := logbucket.IsLogBucketUpToDate(cr.Spec.ForProvider, instance)
+	if u {
+		return managed.ExternalUpdate{}, nil
+	}
+	if err := logbucket.ValidateLogBucketUpdate(cr.Spec.ForProvider, instance); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+	}
+
+	logbucket.GenerateLogBucket(cr.Spec.ForProvider, instance)
+	if _, err := e.buckets.Patch(logBucketRRN(e.projectID, cr), instance).UpdateMask(um).
+		Context(ctx).Do(); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+	}
+
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *logBucketExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.LogBucket)
+	if !ok {
+		return errors.New(errNotLogBucket)
+	}
+	_, err := e.buckets.Delete(logBucketRRN(e.projectID, cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDelete)
+}
+
+func locationRRN(projectID, location string) string {
+	return fmt.Sprintf("projects/%s/locations/%s", projectID, location)
+}
+
+func logBucketRRN(projectID string, cr *v1alpha1.LogBucket) string {
+	return fmt.Sprintf("%s/buckets/%s", locationRRN(projectID, cr.Spec.ForProvider.Location), meta.GetExternalName(cr))
+}
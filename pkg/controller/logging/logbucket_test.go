@@ -0,0 +1,380 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/googleapi"
+	loggingv2 "google.golang.org/api/logging/v2"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/logging/v1alpha1"
+)
+
+const (
+	projectID  = "test-project"
+	location   = "global"
+	bucketName = "test-bucket"
+)
+
+var bucketRRN = "projects/" + projectID + "/locations/" + location + "/buckets/" + bucketName
+
+type strange struct {
+	resource.Managed
+}
+
+func gError(code int, message string) *googleapi.Error {
+	return &googleapi.Error{
+		Code:    code,
+		Body:    "",
+		Message: message,
+	}
+}
+
+type logBucketModifier func(*v1alpha1.LogBucket)
+
+func lbWithRetentionDays(d int64) logBucketModifier {
+	return func(lb *v1alpha1.LogBucket) { lb.Spec.ForProvider.RetentionDays = &d }
+}
+
+func lbWithLocked(l bool) logBucketModifier {
+	return func(lb *v1alpha1.LogBucket) { lb.Spec.ForProvider.Locked = &l }
+}
+
+func lbWithAtProvider(o v1alpha1.LogBucketObservation) logBucketModifier {
+	return func(lb *v1alpha1.LogBucket) { lb.Status.AtProvider = o }
+}
+
+func lbWithCondition(c xpv1.Condition) logBucketModifier {
+	return func(lb *v1alpha1.LogBucket) { lb.SetConditions(c) }
+}
+
+func newLogBucket(m ...logBucketModifier) *v1alpha1.LogBucket {
+	lb := &v1alpha1.LogBucket{
+		ObjectMeta: metav1.ObjectMeta{Name: bucketName},
+		Spec: v1alpha1.LogBucketSpec{
+			ForProvider: v1alpha1.LogBucketParameters{Location: location},
+		},
+	}
+	meta.SetExternalName(lb, bucketName)
+	for _, f := range m {
+		f(lb)
+	}
+	return lb
+}
+
+func TestLogBucketObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"NotLogBucket": {
+			reason: "Should return an error if the managed resource is not a LogBucket",
+			mg:     &strange{},
+			want: want{
+				mg:  &strange{},
+				err: errors.New(errNotLogBucket),
+			},
+		},
+		"NotFound": {
+			reason: "Should report that the LogBucket does not exist",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+			}),
+			mg: newLogBucket(),
+			want: want{
+				mg: newLogBucket(),
+			},
+		},
+		"GetFailed": {
+			reason: "Should return an error if the LogBucket cannot be fetched",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			mg: newLogBucket(),
+			want: want{
+				mg:  newLogBucket(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGet),
+			},
+		},
+		"DeleteRequested": {
+			reason: "Should report that a LogBucket pending deletion does not exist",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&loggingv2.LogBucket{Name: bucketRRN, LifecycleState: v1alpha1.LogBucketStateDeleteRequested})
+			}),
+			mg: newLogBucket(),
+			want: want{
+				mg: newLogBucket(lbWithAtProvider(v1alpha1.LogBucketObservation{
+					Name:           bucketRRN,
+					LifecycleState: v1alpha1.LogBucketStateDeleteRequested,
+				})),
+			},
+		},
+		"LateInitializedAndUpToDate": {
+			reason: "Should late initialize the retention and report the LogBucket as up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if !strings.HasSuffix(r.URL.Path, bucketRRN) {
+					t.Errorf("requested URL.Path should end with %s, got %s instead", bucketRRN, r.URL.Path)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&loggingv2.LogBucket{Name: bucketRRN, LifecycleState: v1alpha1.LogBucketStateActive, RetentionDays: 30})
+			}),
+			mg: newLogBucket(),
+			want: want{
+				mg: newLogBucket(
+					lbWithRetentionDays(30),
+					lbWithAtProvider(v1alpha1.LogBucketObservation{Name: bucketRRN, LifecycleState: v1alpha1.LogBucketStateActive}),
+					lbWithCondition(xpv1.Available())),
+				obs: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+			},
+		},
+		"RetentionDiffers": {
+			reason: "Should report the LogBucket as not up to date if the retention differs",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&loggingv2.LogBucket{Name: bucketRRN, LifecycleState: v1alpha1.LogBucketStateActive, RetentionDays: 30})
+			}),
+			mg: newLogBucket(lbWithRetentionDays(90)),
+			want: want{
+				mg: newLogBucket(
+					lbWithRetentionDays(90),
+					lbWithAtProvider(v1alpha1.LogBucketObservation{Name: bucketRRN, LifecycleState: v1alpha1.LogBucketStateActive}),
+					lbWithCondition(xpv1.Available())),
+				obs: managed.ExternalObservation{
+					ResourceExists: true,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := loggingv2.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &logBucketExternal{buckets: loggingv2.NewProjectsLocationsBucketsService(s), projectID: projectID}
+			obs, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLogBucketCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"Created": {
+			reason: "Should create the LogBucket in the configured location",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff(bucketName, r.URL.Query().Get("bucketId")); diff != "" {
+					t.Errorf("bucketId: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&loggingv2.LogBucket{Name: bucketRRN})
+			}),
+			mg: newLogBucket(lbWithRetentionDays(30)),
+		},
+		"CreateFailed": {
+			reason: "Should return an error if the LogBucket cannot be created",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			mg:  newLogBucket(),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreate),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := loggingv2.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &logBucketExternal{buckets: loggingv2.NewProjectsLocationsBucketsService(s), projectID: projectID}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLogBucketUpdate(t *testing.T) {
+	locked := &loggingv2.LogBucket{Name: bucketRRN, RetentionDays: 30, Locked: true}
+
+	cases := map[string]struct {
+		reason   string
+		observed *loggingv2.LogBucket
+		mask     string
+		mg       resource.Managed
+		err      error
+	}{
+		"PatchedRetention": {
+			reason:   "Should patch only the retention of the LogBucket",
+			observed: &loggingv2.LogBucket{Name: bucketRRN, RetentionDays: 30},
+			mask:     "retentionDays",
+			mg:       newLogBucket(lbWithRetentionDays(7)),
+		},
+		"IncreasedLockedRetention": {
+			reason:   "Should increase the retention of a locked LogBucket",
+			observed: locked,
+			mask:     "retentionDays",
+			mg:       newLogBucket(lbWithRetentionDays(90), lbWithLocked(true)),
+		},
+		"DecreasedLockedRetention": {
+			reason:   "Should return an error if the retention of a locked LogBucket would be decreased",
+			observed: locked,
+			mg:       newLogBucket(lbWithRetentionDays(7), lbWithLocked(true)),
+			err:      errors.Wrap(errors.New("the retention period of a locked log bucket cannot be decreased"), errUpdate),
+		},
+		"Unlocked": {
+			reason:   "Should return an error if a locked LogBucket would be unlocked",
+			observed: locked,
+			mg:       newLogBucket(lbWithRetentionDays(30), lbWithLocked(false)),
+			err:      errors.Wrap(errors.New("a locked log bucket cannot be unlocked"), errUpdate),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				switch r.Method {
+				case http.MethodGet:
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(tc.observed)
+				case http.MethodPatch:
+					if tc.err != nil {
+						t.Errorf("\n%s\nunexpected patch of the LogBucket", tc.reason)
+					}
+					if diff := cmp.Diff(tc.mask, r.URL.Query().Get("updateMask")); diff != "" {
+						t.Errorf("updateMask: -want, +got:\n%s", diff)
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(tc.observed)
+				default:
+					t.Errorf("unexpected method %s", r.Method)
+				}
+			}))
+			defer server.Close()
+			s, _ := loggingv2.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &logBucketExternal{buckets: loggingv2.NewProjectsLocationsBucketsService(s), projectID: projectID}
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLogBucketDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"Deleted": {
+			reason: "Should delete the LogBucket",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&loggingv2.Empty{})
+			}),
+			mg: newLogBucket(),
+		},
+		"AlreadyGone": {
+			reason: "Should not return an error if the LogBucket is already gone",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+			}),
+			mg: newLogBucket(),
+		},
+		"DeleteFailed": {
+			reason: "Should return an error if the LogBucket cannot be deleted",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			mg:  newLogBucket(),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errDelete),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := loggingv2.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &logBucketExternal{buckets: loggingv2.NewProjectsLocationsBucketsService(s), projectID: projectID}
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
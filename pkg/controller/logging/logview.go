@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	loggingv2 "google.golang.org/api/logging/v2"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/logging/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/logview"
+)
+
+const errNotLogView = "managed resource is not a GCP Cloud Logging LogView"
+
+// SetupLogView adds a controller that reconciles Cloud Logging views.
+func SetupLogView(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.LogViewGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.LogView{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.LogViewGroupVersionKind),
+			managed.WithExternalConnecter(&logViewConnecter{client: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type logViewConnecter struct {
+	client client.Client
+}
+
+// Connect sets up Cloud Logging client using credentials from the provider
+func (c *logViewConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, opts, err := gcp.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := loggingv2.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &logViewExternal{views: loggingv2.NewProjectsLocationsBucketsViewsService(s)}, nil
+}
+
+type logViewExternal struct {
+	views logview.Client
+}
+
+func (e *logViewExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.LogView)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotLogView)
+	}
+
+	instance, err := e.views.Get(logViewRRN(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGet)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	logview.LateInitializeLogView(&cr.Spec.ForProvider, *instance)
+
+	cr.Status.AtProvider = logview.GenerateLogViewObservation(*instance)
+	cr.Status.SetConditions(xpv1.Available())
+
+	upToDate, _ := logview.IsLogViewUpToDate(cr.Spec.ForProvider, instance)
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: !cmp.Equal(currentSpec, &cr.Spec.ForProvider),
+		ResourceUpToDate:        upToDate,
+	}, nil
+}
+
+func (e *logViewExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.LogView)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotLogView)
+	}
+	cr.SetConditions(xpv1.Creating())
+	instance := &loggingv2.LogView{}
+	logview.GenerateLogView(cr.Spec.ForProvider, instance)
+
+	if _, err := e.views.Create(gcp.StringValue(cr.Spec.ForProvider.Bucket), instance).
+		ViewId(meta.GetExternalName(cr)).Context(ctx).Do(); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreate)
+	}
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *logViewExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.LogView)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotLogView)
+	}
+	// We have to get the view again here to calculate update mask (what to patch).
+	instance, err := e.views.Get(logViewRRN(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGet)
+	}
+
+	u, um := logview.IsLogViewUpToDate(cr.Spec.ForProvider, instance)
+	if u {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	logview.GenerateLogView(cr.Spec.ForProvider, instance)
+	if _, err := e.views.Patch(logViewRRN(cr), instance).UpdateMask(um).
+		Context(ctx).Do(); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+	}
+
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *logViewExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.LogView)
+	if !ok {
+		return errors.New(errNotLogView)
+	}
+	_, err := e.views.Delete(logViewRRN(cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDelete)
+}
+
+func logViewRRN(cr *v1alpha1.LogView) string {
+	return fmt.Sprintf("%s/views/%s", gcp.StringValue(cr.Spec.ForProvider.Bucket), meta.GetExternalName(cr))
+}
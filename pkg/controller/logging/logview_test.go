@@ -0,0 +1,212 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	loggingv2 "google.golang.org/api/logging/v2"
+	"google.golang.org/api/option"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/logging/v1alpha1"
+)
+
+const viewName = "test-view"
+
+var viewRRN = bucketRRN + "/views/" + viewName
+
+type logViewModifier func(*v1alpha1.LogView)
+
+func lvWithFilter(f string) logViewModifier {
+	return func(lv *v1alpha1.LogView) { lv.Spec.ForProvider.Filter = &f }
+}
+
+func lvWithAtProviderName(n string) logViewModifier {
+	return func(lv *v1alpha1.LogView) { lv.Status.AtProvider.Name = n }
+}
+
+func lvWithCondition(c xpv1.Condition) logViewModifier {
+	return func(lv *v1alpha1.LogView) { lv.SetConditions(c) }
+}
+
+func newLogView(m ...logViewModifier) *v1alpha1.LogView {
+	b := bucketRRN
+	lv := &v1alpha1.LogView{
+		ObjectMeta: metav1.ObjectMeta{Name: viewName},
+		Spec: v1alpha1.LogViewSpec{
+			ForProvider: v1alpha1.LogViewParameters{Bucket: &b},
+		},
+	}
+	meta.SetExternalName(lv, viewName)
+	for _, f := range m {
+		f(lv)
+	}
+	return lv
+}
+
+func TestLogViewObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"NotLogView": {
+			reason: "Should return an error if the managed resource is not a LogView",
+			mg:     &strange{},
+			want: want{
+				mg:  &strange{},
+				err: errors.New(errNotLogView),
+			},
+		},
+		"NotFound": {
+			reason: "Should report that the LogView does not exist",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+			}),
+			mg: newLogView(),
+			want: want{
+				mg: newLogView(),
+			},
+		},
+		"LateInitializedAndUpToDate": {
+			reason: "Should late initialize the filter and report the LogView as up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if !strings.HasSuffix(r.URL.Path, viewRRN) {
+					t.Errorf("requested URL.Path should end with %s, got %s instead", viewRRN, r.URL.Path)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&loggingv2.LogView{Name: viewRRN, Filter: `LOG_ID("stdout")`})
+			}),
+			mg: newLogView(),
+			want: want{
+				mg: newLogView(
+					lvWithFilter(`LOG_ID("stdout")`),
+					lvWithAtProviderName(viewRRN),
+					lvWithCondition(xpv1.Available())),
+				obs: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+			},
+		},
+		"FilterDiffers": {
+			reason: "Should report the LogView as not up to date if the filter differs",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&loggingv2.LogView{Name: viewRRN, Filter: `LOG_ID("stdout")`})
+			}),
+			mg: newLogView(lvWithFilter(`LOG_ID("stderr")`)),
+			want: want{
+				mg: newLogView(
+					lvWithFilter(`LOG_ID("stderr")`),
+					lvWithAtProviderName(viewRRN),
+					lvWithCondition(xpv1.Available())),
+				obs: managed.ExternalObservation{
+					ResourceExists: true,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := loggingv2.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &logViewExternal{views: loggingv2.NewProjectsLocationsBucketsViewsService(s)}
+			obs, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestLogViewCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"Created": {
+			reason: "Should create the LogView in the referenced bucket",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if !strings.HasSuffix(r.URL.Path, bucketRRN+"/views") {
+					t.Errorf("requested URL.Path should end with %s, got %s instead", bucketRRN+"/views", r.URL.Path)
+				}
+				if diff := cmp.Diff(viewName, r.URL.Query().Get("viewId")); diff != "" {
+					t.Errorf("viewId: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&loggingv2.LogView{Name: viewRRN})
+			}),
+			mg: newLogView(),
+		},
+		"CreateFailed": {
+			reason: "Should return an error if the LogView cannot be created",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			mg:  newLogView(),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreate),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := loggingv2.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &logViewExternal{views: loggingv2.NewProjectsLocationsBucketsViewsService(s)}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,377 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package pubsub
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/option"
+	pubsub "google.golang.org/api/pubsub/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/pubsub/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testSubscriptionName = "cool-subscription"
+	testSubscriptionFQN  = "projects/" + projectID + "/subscriptions/" + testSubscriptionName
+	testTopicFQN         = "projects/" + projectID + "/topics/cool-topic"
+)
+
+type SubscriptionOption func(*v1alpha1.Subscription)
+
+func withFilter(f string) SubscriptionOption {
+	return func(s *v1alpha1.Subscription) { s.Spec.ForProvider.Filter = &f }
+}
+
+func withAckDeadlineSeconds(d int64) SubscriptionOption {
+	return func(s *v1alpha1.Subscription) { s.Spec.ForProvider.AckDeadlineSeconds = &d }
+}
+
+func withRecreateOnImmutable() SubscriptionOption {
+	return func(s *v1alpha1.Subscription) {
+		meta.AddAnnotations(s, map[string]string{gcp.AnnotationKeyRecreateOnImmutable: "true"})
+	}
+}
+
+func newSubscription(opts ...SubscriptionOption) *v1alpha1.Subscription {
+	s := &v1alpha1.Subscription{}
+	meta.SetExternalName(s, testSubscriptionName)
+	s.Spec.ForProvider = v1alpha1.SubscriptionParameters{
+		Topic:                 gcp.StringPtr("cool-topic"),
+		Filter:                gcp.StringPtr(`attributes.region = "eu"`),
+		AckDeadlineSeconds:    gcp.Int64Ptr(10),
+		RetainAckedMessages:   gcp.BoolPtr(false),
+		EnableMessageOrdering: gcp.BoolPtr(false),
+	}
+	for _, f := range opts {
+		f(s)
+	}
+	return s
+}
+
+func observedSubscription() *pubsub.Subscription {
+	return &pubsub.Subscription{
+		Name:               testSubscriptionFQN,
+		Topic:              testTopicFQN,
+		Filter:             `attributes.region = "eu"`,
+		AckDeadlineSeconds: 10,
+	}
+}
+
+// subscriptionHandler serves the supplied subscription and records the
+// methods of the requests it receives. It fails requests with the supplied
+// method.
+func subscriptionHandler(sub *pubsub.Subscription, fail string, methods *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_ = r.Body.Close()
+		*methods = append(*methods, r.Method)
+		if r.Method == fail {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if sub == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(sub)
+	}
+}
+
+func TestSubscriptionObserve(t *testing.T) {
+	type args struct {
+		sub  *pubsub.Subscription
+		kube client.Client
+		mg   resource.Managed
+	}
+
+	type want struct {
+		eo  managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NotSubscription": {
+			reason: "Should return error if the managed resource is not a Subscription",
+			args: args{
+				mg: newTopic(),
+			},
+			want: want{
+				err: errors.New(errNotSubscription),
+			},
+		},
+		"NotFound": {
+			reason: "Should not return error if Subscription is not found",
+			args: args{
+				mg: newSubscription(),
+			},
+		},
+		"UpToDate": {
+			reason: "Should report a Subscription that matches its spec as up to date",
+			args: args{
+				sub: observedSubscription(),
+				mg:  newSubscription(),
+			},
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"AckDeadlineChanged": {
+			reason: "Should report a Subscription whose mutable fields differ as not up to date",
+			args: args{
+				sub: observedSubscription(),
+				mg:  newSubscription(withAckDeadlineSeconds(30)),
+			},
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"FilterDrifted": {
+			reason: "Should report a Subscription whose immutable filter differs as not up to date",
+			args: args{
+				sub: observedSubscription(),
+				mg:  newSubscription(withFilter(`attributes.region = "us"`)),
+			},
+			want: want{
+				eo: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"SpecUpdateFailed": {
+			reason: "Should fail if spec Update failed",
+			args: args{
+				sub: observedSubscription(),
+				kube: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(errBoom),
+				},
+				mg: newSubscription(func(s *v1alpha1.Subscription) { s.Spec.ForProvider.Filter = nil }),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errKubeUpdateSubscription),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var methods []string
+			server := httptest.NewServer(subscriptionHandler(tc.args.sub, "", &methods))
+			defer server.Close()
+			s, _ := pubsub.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := subscriptionExternal{
+				client:    tc.args.kube,
+				projectID: projectID,
+				ps:        s,
+			}
+			got, err := e.Observe(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.eo, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSubscriptionCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		fail   string
+		want   error
+	}{
+		"CreateFailed": {
+			reason: "Should return error if CreateSubscription fails",
+			fail:   http.MethodPut,
+			want:   errors.Wrap(gError(http.StatusBadRequest, ""), errCreateSubscription),
+		},
+		"Success": {
+			reason: "Should not fail if all calls succeed",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var methods []string
+			server := httptest.NewServer(subscriptionHandler(observedSubscription(), tc.fail, &methods))
+			defer server.Close()
+			s, _ := pubsub.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := subscriptionExternal{
+				projectID: projectID,
+				ps:        s,
+			}
+			_, err := e.Create(context.Background(), newSubscription())
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSubscriptionUpdate(t *testing.T) {
+	type args struct {
+		fail string
+		mg   resource.Managed
+	}
+
+	type want struct {
+		methods []string
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"GetFailed": {
+			reason: "Should return error if GetSubscription fails",
+			args: args{
+				fail: http.MethodGet,
+				mg:   newSubscription(),
+			},
+			want: want{
+				methods: []string{http.MethodGet},
+				err:     errors.Wrap(gError(http.StatusBadRequest, ""), errGetSubscription),
+			},
+		},
+		"AckDeadlineChanged": {
+			reason: "Should patch the mutable fields of a Subscription",
+			args: args{
+				mg: newSubscription(withAckDeadlineSeconds(30)),
+			},
+			want: want{
+				methods: []string{http.MethodGet, http.MethodPatch},
+			},
+		},
+		"FilterImmutable": {
+			reason: "Should report a changed filter as an immutable field error without the recreate policy",
+			args: args{
+				mg: newSubscription(withFilter(`attributes.region = "us"`)),
+			},
+			want: want{
+				methods: []string{http.MethodGet},
+				err:     errors.Errorf(errFmtImmutableSubscription, `filter: "attributes.region = \"us\"" != "attributes.region = \"eu\""`, gcp.AnnotationKeyRecreateOnImmutable),
+			},
+		},
+		"FilterRecreated": {
+			reason: "Should delete a Subscription whose filter changed under the recreate policy so that it is recreated",
+			args: args{
+				mg: newSubscription(withFilter(`attributes.region = "us"`), withRecreateOnImmutable()),
+			},
+			want: want{
+				methods: []string{http.MethodGet, http.MethodDelete},
+			},
+		},
+		"RecreateFailed": {
+			reason: "Should return error if deleting a Subscription to recreate it fails",
+			args: args{
+				fail: http.MethodDelete,
+				mg:   newSubscription(withFilter(`attributes.region = "us"`), withRecreateOnImmutable()),
+			},
+			want: want{
+				methods: []string{http.MethodGet, http.MethodDelete},
+				err:     errors.Wrap(gError(http.StatusBadRequest, ""), errRecreateSubscription),
+			},
+		},
+		"UpdateFailed": {
+			reason: "Should return error if UpdateSubscription fails",
+			args: args{
+				fail: http.MethodPatch,
+				mg:   newSubscription(withAckDeadlineSeconds(30)),
+			},
+			want: want{
+				methods: []string{http.MethodGet, http.MethodPatch},
+				err:     errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateSubscription),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var methods []string
+			server := httptest.NewServer(subscriptionHandler(observedSubscription(), tc.args.fail, &methods))
+			defer server.Close()
+			s, _ := pubsub.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := subscriptionExternal{
+				projectID: projectID,
+				ps:        s,
+			}
+			_, err := e.Update(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.methods, methods); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want methods, +got methods:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSubscriptionDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		sub    *pubsub.Subscription
+		fail   string
+		want   error
+	}{
+		"DeleteFailed": {
+			reason: "Should return error if DeleteSubscription fails",
+			sub:    observedSubscription(),
+			fail:   http.MethodDelete,
+			want:   errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteSubscription),
+		},
+		"NotFound": {
+			reason: "Should not return error if resource is already gone",
+		},
+		"Success": {
+			reason: "Should not fail if all calls succeed",
+			sub:    &pubsub.Subscription{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var methods []string
+			server := httptest.NewServer(subscriptionHandler(tc.sub, tc.fail, &methods))
+			defer server.Close()
+			s, _ := pubsub.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := subscriptionExternal{
+				projectID: projectID,
+				ps:        s,
+			}
+			err := e.Delete(context.Background(), newSubscription())
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
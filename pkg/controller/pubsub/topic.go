@@ -51,8 +51,12 @@ const (
 	errDeleteTopic     = "cannot delete Topic"
 )
 
+// topicExternalNameTemplate is used to generate the external name of Topics
+// that don't have one. It defaults to the name of the managed resource.
+const topicExternalNameTemplate = "{{ .Name }}"
+
 // SetupTopic adds a controller that reconciles Topics.
-func SetupTopic(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupTopic(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
 	name := managed.ControllerName(v1alpha1.TopicGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -64,7 +68,9 @@ func SetupTopic(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, po
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.TopicGroupVersionKind),
 			managed.WithExternalConnecter(&connector{client: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewTemplatedExternalName(mgr.GetClient(), topicExternalNameTemplate)),
 			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
@@ -0,0 +1,193 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/option"
+	run "google.golang.org/api/run/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/run/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/runservice"
+)
+
+// Error strings.
+const (
+	errNotService         = "managed resource is not a Service"
+	errNewClient          = "cannot create new Cloud Run client"
+	errGetService         = "cannot get external Service resource"
+	errCreateService      = "cannot create external Service resource"
+	errUpdateService      = "cannot update external Service resource"
+	errDeleteService      = "cannot delete external Service resource"
+	errManagedService     = "cannot update managed Service resource"
+	errInvalidService     = "invalid Service"
+	errFmtServiceNotReady = "service is not ready: %s"
+)
+
+// SetupService adds a controller that reconciles Service managed resources.
+func SetupService(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.ServiceGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.Service{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.ServiceGroupVersionKind),
+			managed.WithExternalConnecter(&serviceConnector{kube: mgr.GetClient()}),
+			managed.WithConnectionPublishers(),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type serviceConnector struct {
+	kube client.Client
+}
+
+func (c *serviceConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Service)
+	if !ok {
+		return nil, errors.New(errNotService)
+	}
+	projectID, opts, err := gcp.GetRegionalAuthInfo(ctx, c.kube, mg, gcp.ServiceRun, cr.Spec.ForProvider.Location)
+	if err != nil {
+		return nil, err
+	}
+	// Services are managed through the endpoint of their region unless the
+	// ProviderConfig configures another one.
+	opts = append([]option.ClientOption{option.WithEndpoint(runservice.GetEndpoint(cr.Spec.ForProvider.Location))}, opts...)
+	s, err := run.NewService(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &serviceExternal{kube: c.kube, run: s, projectID: projectID}, nil
+}
+
+type serviceExternal struct {
+	kube      client.Client
+	projectID string
+	run       *run.APIService
+}
+
+func (e *serviceExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Service)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotService)
+	}
+
+	name := meta.GetExternalName(cr)
+	observed, err := e.run.Namespaces.Services.Get(runservice.GetFullyQualifiedName(e.projectID, name)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetService)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	runservice.LateInitializeSpec(&cr.Spec.ForProvider, *observed)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := e.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errManagedService)
+		}
+	}
+
+	cr.Status.AtProvider = runservice.GenerateServiceObservation(*observed)
+	c := runservice.GetCondition(*observed, runservice.ConditionReady)
+	switch {
+	case c == nil || c.Status == "Unknown":
+		// The latest revision is still rolled out.
+		cr.SetConditions(xpv1.Creating())
+	case c.Status == "True":
+		cr.SetConditions(xpv1.Available())
+	default:
+		cr.SetConditions(xpv1.Unavailable().WithMessage(errors.Errorf(errFmtServiceNotReady, c.Message).Error()))
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: runservice.IsUpToDate(e.projectID, name, cr.Spec.ForProvider, *observed),
+	}, nil
+}
+
+func (e *serviceExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Service)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotService)
+	}
+	if err := runservice.Validate(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errInvalidService)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+	s := &run.Service{}
+	runservice.GenerateService(e.projectID, meta.GetExternalName(cr), cr.Spec.ForProvider, s)
+	_, err := e.run.Namespaces.Services.Create(runservice.GetParent(e.projectID), s).Context(ctx).Do()
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateService)
+}
+
+func (e *serviceExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Service)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotService)
+	}
+	if err := runservice.Validate(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errInvalidService)
+	}
+
+	// A service is replaced as a whole, so the observed one is updated to
+	// keep the fields GCP defaulted.
+	name := runservice.GetFullyQualifiedName(e.projectID, meta.GetExternalName(cr))
+	s, err := e.run.Namespaces.Services.Get(name).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetService)
+	}
+	s.Status = nil
+	runservice.GenerateService(e.projectID, meta.GetExternalName(cr), cr.Spec.ForProvider, s)
+	_, err = e.run.Namespaces.Services.ReplaceService(name, s).Context(ctx).Do()
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateService)
+}
+
+func (e *serviceExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Service)
+	if !ok {
+		return errors.New(errNotService)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+	_, err := e.run.Namespaces.Services.Delete(runservice.GetFullyQualifiedName(e.projectID, meta.GetExternalName(cr))).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteService)
+}
@@ -0,0 +1,535 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	run "google.golang.org/api/run/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	pubsubv1alpha1 "github.com/crossplane/provider-gcp/apis/pubsub/v1alpha1"
+	"github.com/crossplane/provider-gcp/apis/run/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	projectID        = "cool-project"
+	testServiceName  = "hello"
+	testServiceImage = "us-docker.pkg.dev/cloudrun/container/hello"
+	testServiceURL   = "https://hello-abc123-uc.a.run.app"
+)
+
+var _ managed.ExternalConnecter = &serviceConnector{}
+var _ managed.ExternalClient = &serviceExternal{}
+
+func gError(code int, message string) *googleapi.Error {
+	return &googleapi.Error{
+		Code:    code,
+		Body:    "{}\n",
+		Message: message,
+	}
+}
+
+type serviceModifier func(*v1alpha1.Service)
+
+func serviceWithConditions(c ...xpv1.Condition) serviceModifier {
+	return func(s *v1alpha1.Service) { s.Status.SetConditions(c...) }
+}
+
+func serviceWithTraffic(stable, canary int64) serviceModifier {
+	return func(s *v1alpha1.Service) {
+		s.Spec.ForProvider.Traffic = []v1alpha1.TrafficTarget{
+			{RevisionName: gcp.StringPtr("hello-v1"), Percent: gcp.Int64Ptr(stable), Tag: gcp.StringPtr("stable")},
+			{RevisionName: gcp.StringPtr("hello-v2"), Percent: gcp.Int64Ptr(canary), Tag: gcp.StringPtr("canary")},
+		}
+	}
+}
+
+func serviceWithObservation(o v1alpha1.ServiceObservation) serviceModifier {
+	return func(s *v1alpha1.Service) { s.Status.AtProvider = o }
+}
+
+func serviceObj(m ...serviceModifier) *v1alpha1.Service {
+	s := &v1alpha1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        testServiceName,
+			Annotations: map[string]string{meta.AnnotationKeyExternalName: testServiceName},
+		},
+		Spec: v1alpha1.ServiceSpec{
+			ForProvider: v1alpha1.ServiceParameters{
+				Location: "us-central1",
+				Template: v1alpha1.RevisionTemplate{
+					Name:       gcp.StringPtr("hello-v2"),
+					Containers: []v1alpha1.Container{{Image: testServiceImage}},
+				},
+			},
+		},
+	}
+	for _, f := range m {
+		f(s)
+	}
+	return s
+}
+
+func serviceObserved(ready string, m ...func(*run.Service)) *run.Service {
+	s := &run.Service{
+		ApiVersion: "serving.knative.dev/v1",
+		Kind:       "Service",
+		Metadata:   &run.ObjectMeta{Name: testServiceName, Namespace: projectID, ResourceVersion: "AAA"},
+		Spec: &run.ServiceSpec{
+			Template: &run.RevisionTemplate{
+				Metadata: &run.ObjectMeta{Name: "hello-v2"},
+				Spec: &run.RevisionSpec{
+					ServiceAccountName: "sa@cool-project.iam.gserviceaccount.com",
+					TimeoutSeconds:     300,
+					Containers:         []*run.Container{{Image: testServiceImage}},
+				},
+			},
+			Traffic: []*run.TrafficTarget{
+				{RevisionName: "hello-v1", Percent: 90, Tag: "stable"},
+				{RevisionName: "hello-v2", Percent: 10, Tag: "canary"},
+			},
+		},
+		Status: &run.ServiceStatus{
+			Url:                     testServiceURL,
+			LatestReadyRevisionName: "hello-v2",
+			Conditions:              []*run.GoogleCloudRunV1Condition{{Type: "Ready", Status: ready, Message: "revision failed"}},
+			Traffic: []*run.TrafficTarget{
+				{RevisionName: "hello-v1", Percent: 90, Tag: "stable", Url: "https://stable---hello-abc123-uc.a.run.app"},
+				{RevisionName: "hello-v2", Percent: 10, Tag: "canary", Url: "https://canary---hello-abc123-uc.a.run.app"},
+			},
+		},
+	}
+	for _, f := range m {
+		f(s)
+	}
+	return s
+}
+
+// serviceHandler serves the supplied service and records the method and body
+// of the last request that changes it.
+func serviceHandler(s *run.Service, method *string, got *map[string]interface{}, fail bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		_ = r.Body.Close()
+		if r.Method != http.MethodGet {
+			*method = r.Method
+			_ = json.Unmarshal(b, got)
+			if fail {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&run.Service{})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(&run.Service{})
+			return
+		}
+		if s == nil {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(&run.Service{})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(s)
+	}
+}
+
+func TestServiceObserve(t *testing.T) {
+	available := v1alpha1.ServiceObservation{
+		URL:                     testServiceURL,
+		LatestReadyRevisionName: "hello-v2",
+		Traffic: []v1alpha1.TrafficTargetStatus{
+			{RevisionName: "hello-v1", Percent: 90, Tag: "stable", URL: "https://stable---hello-abc123-uc.a.run.app"},
+			{RevisionName: "hello-v2", Percent: 10, Tag: "canary", URL: "https://canary---hello-abc123-uc.a.run.app"},
+		},
+	}
+	withServiceAccount := func(s *v1alpha1.Service) {
+		s.Spec.ForProvider.Template.ServiceAccountName = gcp.StringPtr("sa@cool-project.iam.gserviceaccount.com")
+	}
+
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		s    *run.Service
+		mg   resource.Managed
+		want want
+	}{
+		"NotService": {
+			mg: &pubsubv1alpha1.Topic{},
+			want: want{
+				mg:  &pubsubv1alpha1.Topic{},
+				err: errors.New(errNotService),
+			},
+		},
+		"NotFound": {
+			mg: serviceObj(),
+			want: want{
+				mg:  serviceObj(),
+				obs: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Available": {
+			s:  serviceObserved("True"),
+			mg: serviceObj(serviceWithTraffic(90, 10)),
+			want: want{
+				mg: serviceObj(
+					serviceWithTraffic(90, 10),
+					withServiceAccount,
+					serviceWithObservation(available),
+					serviceWithConditions(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"SplitChanged": {
+			s:  serviceObserved("True"),
+			mg: serviceObj(serviceWithTraffic(50, 50)),
+			want: want{
+				mg: serviceObj(
+					serviceWithTraffic(50, 50),
+					withServiceAccount,
+					serviceWithObservation(available),
+					serviceWithConditions(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"RollingOut": {
+			s:  serviceObserved("Unknown"),
+			mg: serviceObj(serviceWithTraffic(90, 10)),
+			want: want{
+				mg: serviceObj(
+					serviceWithTraffic(90, 10),
+					withServiceAccount,
+					serviceWithObservation(available),
+					serviceWithConditions(xpv1.Creating()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"NotReady": {
+			s:  serviceObserved("False"),
+			mg: serviceObj(serviceWithTraffic(90, 10)),
+			want: want{
+				mg: serviceObj(
+					serviceWithTraffic(90, 10),
+					withServiceAccount,
+					serviceWithObservation(available),
+					serviceWithConditions(xpv1.Unavailable().WithMessage("service is not ready: revision failed")),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var method string
+			var body map[string]interface{}
+			server := httptest.NewServer(serviceHandler(tc.s, &method, &body, false))
+			defer server.Close()
+			s, _ := run.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := serviceExternal{
+				kube:      &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				projectID: projectID,
+				run:       s,
+			}
+			obs, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestServiceCreate(t *testing.T) {
+	type want struct {
+		method string
+		body   map[string]interface{}
+		err    error
+	}
+
+	cases := map[string]struct {
+		fail bool
+		mg   resource.Managed
+		want want
+	}{
+		"NotService": {
+			mg: &pubsubv1alpha1.Topic{},
+			want: want{
+				err: errors.New(errNotService),
+			},
+		},
+		"InvalidSplit": {
+			mg: serviceObj(serviceWithTraffic(90, 20)),
+			want: want{
+				err: errors.Wrap(errors.New("the traffic percentages must sum to 100, not 110"), errInvalidService),
+			},
+		},
+		"Created": {
+			mg: serviceObj(serviceWithTraffic(90, 10)),
+			want: want{
+				method: http.MethodPost,
+				body: map[string]interface{}{
+					"apiVersion": "serving.knative.dev/v1",
+					"kind":       "Service",
+					"metadata":   map[string]interface{}{"name": testServiceName, "namespace": projectID},
+					"spec": map[string]interface{}{
+						"template": map[string]interface{}{
+							"metadata": map[string]interface{}{"name": "hello-v2"},
+							"spec": map[string]interface{}{
+								"containers": []interface{}{map[string]interface{}{"image": testServiceImage}},
+							},
+						},
+						"traffic": []interface{}{
+							map[string]interface{}{"revisionName": "hello-v1", "percent": float64(90), "tag": "stable"},
+							map[string]interface{}{"revisionName": "hello-v2", "percent": float64(10), "tag": "canary"},
+						},
+					},
+				},
+			},
+		},
+		"CreateFailed": {
+			fail: true,
+			mg:   serviceObj(),
+			want: want{
+				method: http.MethodPost,
+				body: map[string]interface{}{
+					"apiVersion": "serving.knative.dev/v1",
+					"kind":       "Service",
+					"metadata":   map[string]interface{}{"name": testServiceName, "namespace": projectID},
+					"spec": map[string]interface{}{
+						"template": map[string]interface{}{
+							"metadata": map[string]interface{}{"name": "hello-v2"},
+							"spec": map[string]interface{}{
+								"containers": []interface{}{map[string]interface{}{"image": testServiceImage}},
+							},
+						},
+					},
+				},
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreateService),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var method string
+			var body map[string]interface{}
+			server := httptest.NewServer(serviceHandler(nil, &method, &body, tc.fail))
+			defer server.Close()
+			s, _ := run.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := serviceExternal{
+				projectID: projectID,
+				run:       s,
+			}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.method, method); diff != "" {
+				t.Errorf("Create(...): -want method, +got method:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.body, body); diff != "" {
+				t.Errorf("Create(...): -want body, +got body:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestServiceUpdate(t *testing.T) {
+	type want struct {
+		method  string
+		traffic interface{}
+		err     error
+	}
+
+	cases := map[string]struct {
+		s    *run.Service
+		fail bool
+		mg   resource.Managed
+		want want
+	}{
+		"NotService": {
+			mg: &pubsubv1alpha1.Topic{},
+			want: want{
+				err: errors.New(errNotService),
+			},
+		},
+		"InvalidSplit": {
+			s:  serviceObserved("True"),
+			mg: serviceObj(serviceWithTraffic(50, 40)),
+			want: want{
+				err: errors.Wrap(errors.New("the traffic percentages must sum to 100, not 90"), errInvalidService),
+			},
+		},
+		"NotFound": {
+			mg: serviceObj(serviceWithTraffic(50, 50)),
+			want: want{
+				err: errors.Wrap(gError(http.StatusNotFound, ""), errGetService),
+			},
+		},
+		"SplitChanged": {
+			s:  serviceObserved("True"),
+			mg: serviceObj(serviceWithTraffic(50, 50)),
+			want: want{
+				method: http.MethodPut,
+				traffic: []interface{}{
+					map[string]interface{}{"revisionName": "hello-v1", "percent": float64(50), "tag": "stable"},
+					map[string]interface{}{"revisionName": "hello-v2", "percent": float64(50), "tag": "canary"},
+				},
+			},
+		},
+		"CanaryPromoted": {
+			s: serviceObserved("True"),
+			mg: serviceObj(func(s *v1alpha1.Service) {
+				s.Spec.ForProvider.Traffic = []v1alpha1.TrafficTarget{
+					{LatestRevision: gcp.BoolPtr(true), Percent: gcp.Int64Ptr(100)},
+					{RevisionName: gcp.StringPtr("hello-v1"), Percent: gcp.Int64Ptr(0), Tag: gcp.StringPtr("previous")},
+				}
+			}),
+			want: want{
+				method: http.MethodPut,
+				traffic: []interface{}{
+					map[string]interface{}{"latestRevision": true, "percent": float64(100)},
+					map[string]interface{}{"revisionName": "hello-v1", "tag": "previous"},
+				},
+			},
+		},
+		"ReplaceFailed": {
+			s:    serviceObserved("True"),
+			fail: true,
+			mg:   serviceObj(serviceWithTraffic(50, 50)),
+			want: want{
+				method: http.MethodPut,
+				traffic: []interface{}{
+					map[string]interface{}{"revisionName": "hello-v1", "percent": float64(50), "tag": "stable"},
+					map[string]interface{}{"revisionName": "hello-v2", "percent": float64(50), "tag": "canary"},
+				},
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errUpdateService),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var method string
+			var body map[string]interface{}
+			server := httptest.NewServer(serviceHandler(tc.s, &method, &body, tc.fail))
+			defer server.Close()
+			s, _ := run.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := serviceExternal{
+				projectID: projectID,
+				run:       s,
+			}
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.method, method); diff != "" {
+				t.Errorf("Update(...): -want method, +got method:\n%s", diff)
+			}
+			if body == nil {
+				return
+			}
+			// The observed service is replaced, keeping the fields GCP
+			// defaulted and dropping its status.
+			if diff := cmp.Diff(tc.want.traffic, body["spec"].(map[string]interface{})["traffic"]); diff != "" {
+				t.Errorf("Update(...): -want traffic, +got traffic:\n%s", diff)
+			}
+			if diff := cmp.Diff("AAA", body["metadata"].(map[string]interface{})["resourceVersion"]); diff != "" {
+				t.Errorf("Update(...): -want resourceVersion, +got resourceVersion:\n%s", diff)
+			}
+			if _, ok := body["status"]; ok {
+				t.Errorf("Update(...): replaced service has a status")
+			}
+		})
+	}
+}
+
+func TestServiceDelete(t *testing.T) {
+	cases := map[string]struct {
+		status int
+		mg     resource.Managed
+		want   error
+	}{
+		"NotService": {
+			mg:   &pubsubv1alpha1.Topic{},
+			want: errors.New(errNotService),
+		},
+		"Deleted": {
+			status: http.StatusOK,
+			mg:     serviceObj(),
+		},
+		"AlreadyGone": {
+			status: http.StatusNotFound,
+			mg:     serviceObj(),
+		},
+		"DeleteFailed": {
+			status: http.StatusBadRequest,
+			mg:     serviceObj(),
+			want:   errors.Wrap(gError(http.StatusBadRequest, ""), errDeleteService),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(tc.status)
+				_ = json.NewEncoder(w).Encode(&run.Status{})
+			}))
+			defer server.Close()
+			s, _ := run.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := serviceExternal{
+				projectID: projectID,
+				run:       s,
+			}
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
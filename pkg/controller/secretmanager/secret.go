@@ -0,0 +1,172 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretmanager
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	secretmanager "google.golang.org/api/secretmanager/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/secretmanager/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/secret"
+)
+
+// Error strings.
+const (
+	errNotSecret        = "managed resource is not a GCP Secret"
+	errGetSecret        = "cannot get GCP Secret"
+	errCreateSecret     = "cannot create GCP Secret"
+	errUpdateSecret     = "cannot update GCP Secret"
+	errDeleteSecret     = "cannot delete GCP Secret"
+	errKubeUpdateSecret = "cannot update Secret custom resource"
+	errInvalidSecret    = "invalid Secret"
+)
+
+// secretExternalNameTemplate is used to generate the external name of Secrets
+// that don't have one. It defaults to the name of the managed resource.
+const secretExternalNameTemplate = "{{ .Name }}"
+
+// SetupSecret adds a controller that reconciles Secrets.
+func SetupSecret(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.SecretGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.Secret{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.SecretGroupVersionKind),
+			managed.WithExternalConnecter(&secretConnecter{client: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewTemplatedExternalName(mgr.GetClient(), secretExternalNameTemplate)),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type secretConnecter struct {
+	client client.Client
+}
+
+// Connect sets up Secret Manager client using credentials from the provider
+func (c *secretConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := secretmanager.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &secretExternal{projectID: projectID, kube: c.client, secrets: secretmanager.NewProjectsSecretsService(s)}, nil
+}
+
+type secretExternal struct {
+	projectID string
+	kube      client.Client
+	secrets   *secretmanager.ProjectsSecretsService
+}
+
+func (e *secretExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Secret)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSecret)
+	}
+	s, err := e.secrets.Get(secret.GetFullyQualifiedName(e.projectID, meta.GetExternalName(cr))).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetSecret)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	secret.LateInitialize(&cr.Spec.ForProvider, *s)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := e.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errKubeUpdateSecret)
+		}
+	}
+
+	cr.Status.AtProvider = secret.GenerateObservation(*s)
+	cr.SetConditions(xpv1.Available())
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: secret.IsUpToDate(e.projectID, cr.Spec.ForProvider, *s, time.Now()),
+	}, nil
+}
+
+func (e *secretExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Secret)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotSecret)
+	}
+	if err := secret.Validate(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errInvalidSecret)
+	}
+	cr.SetConditions(xpv1.Creating())
+	_, err := e.secrets.Create(secret.GetProjectName(e.projectID), secret.GenerateSecret(e.projectID, cr.Spec.ForProvider)).
+		SecretId(meta.GetExternalName(cr)).Context(ctx).Do()
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateSecret)
+}
+
+func (e *secretExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Secret)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotSecret)
+	}
+	if err := secret.Validate(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errInvalidSecret)
+	}
+	name := secret.GetFullyQualifiedName(e.projectID, meta.GetExternalName(cr))
+	s, err := e.secrets.Get(name).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetSecret)
+	}
+	patch, mask := secret.GenerateUpdate(e.projectID, cr.Spec.ForProvider, *s, time.Now())
+	if mask == "" {
+		return managed.ExternalUpdate{}, nil
+	}
+	_, err = e.secrets.Patch(name, patch).UpdateMask(mask).Context(ctx).Do()
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateSecret)
+}
+
+func (e *secretExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Secret)
+	if !ok {
+		return errors.New(errNotSecret)
+	}
+	cr.SetConditions(xpv1.Deleting())
+	_, err := e.secrets.Delete(secret.GetFullyQualifiedName(e.projectID, meta.GetExternalName(cr))).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteSecret)
+}
@@ -0,0 +1,407 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretmanager
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/api/option"
+	secretmanager "google.golang.org/api/secretmanager/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/secretmanager/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	testProject      = "test-project"
+	testSecretName   = "test-secret"
+	testTopic        = "projects/test-project/topics/rotations"
+	testAuditTopic   = "projects/test-project/topics/audit"
+	testRotationTime = "2099-01-01T00:00:00Z"
+	testPeriod       = "2592000s"
+)
+
+type secretModifier func(*v1alpha1.Secret)
+
+func secretWithTopics(t ...string) secretModifier {
+	return func(s *v1alpha1.Secret) { s.Spec.ForProvider.Topics = t }
+}
+
+func secretWithRotation() secretModifier {
+	return func(s *v1alpha1.Secret) {
+		s.Spec.ForProvider.Rotation = &v1alpha1.Rotation{
+			NextRotationTime: gcp.StringPtr(testRotationTime),
+			RotationPeriod:   gcp.StringPtr(testPeriod),
+		}
+	}
+}
+
+func secretWithAtProvider(o v1alpha1.SecretObservation) secretModifier {
+	return func(s *v1alpha1.Secret) { s.Status.AtProvider = o }
+}
+
+func secretWithCondition(c xpv1.Condition) secretModifier {
+	return func(s *v1alpha1.Secret) { s.SetConditions(c) }
+}
+
+func newSecret(m ...secretModifier) *v1alpha1.Secret {
+	s := &v1alpha1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        testSecretName,
+			Annotations: map[string]string{meta.AnnotationKeyExternalName: testSecretName},
+		},
+		Spec: v1alpha1.SecretSpec{
+			ForProvider: v1alpha1.SecretParameters{Topics: []string{"rotations"}},
+		},
+	}
+	for _, f := range m {
+		f(s)
+	}
+	return s
+}
+
+func observedSecret(m ...func(*secretmanager.Secret)) *secretmanager.Secret {
+	s := &secretmanager.Secret{
+		Name:        "projects/test-project/secrets/" + testSecretName,
+		CreateTime:  "2021-06-01T00:00:00Z",
+		Replication: &secretmanager.Replication{Automatic: &secretmanager.Automatic{}},
+		Topics:      []*secretmanager.Topic{{Name: testTopic}},
+	}
+	for _, f := range m {
+		f(s)
+	}
+	return s
+}
+
+// secretRequest is a request that changes a secret.
+type secretRequest struct {
+	Method     string
+	SecretID   string
+	UpdateMask string
+	Body       map[string]interface{}
+}
+
+// secretServer serves the supplied secret, or a 404 if it is nil, and records
+// the requests that change it.
+func secretServer(t *testing.T, s *secretmanager.Secret, got *[]secretRequest) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() { _ = r.Body.Close() }()
+		if r.Method == http.MethodGet {
+			if s == nil {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(&secretmanager.Secret{})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(s)
+			return
+		}
+		req := secretRequest{
+			Method:     r.Method,
+			SecretID:   r.URL.Query().Get("secretId"),
+			UpdateMask: r.URL.Query().Get("updateMask"),
+		}
+		if r.Method != http.MethodDelete {
+			if err := json.NewDecoder(r.Body).Decode(&req.Body); err != nil {
+				t.Errorf("cannot decode request: %s", err)
+			}
+		}
+		*got = append(*got, req)
+		if r.Method == http.MethodDelete && s == nil {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		_ = json.NewEncoder(w).Encode(&secretmanager.Secret{})
+	}))
+}
+
+func newSecretExternal(url string) *secretExternal {
+	s, _ := secretmanager.NewService(context.Background(), option.WithEndpoint(url), option.WithoutAuthentication())
+	return &secretExternal{
+		projectID: testProject,
+		kube:      &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+		secrets:   secretmanager.NewProjectsSecretsService(s),
+	}
+}
+
+func TestSecretObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+	observation := v1alpha1.SecretObservation{
+		Name:       "projects/test-project/secrets/" + testSecretName,
+		CreateTime: "2021-06-01T00:00:00Z",
+	}
+	rotating := func(s *secretmanager.Secret) {
+		s.Rotation = &secretmanager.Rotation{NextRotationTime: testRotationTime, RotationPeriod: testPeriod}
+	}
+	cases := map[string]struct {
+		reason string
+		secret *secretmanager.Secret
+		mg     resource.Managed
+		want   want
+	}{
+		"NotSecret": {
+			reason: "Should return an error if the managed resource is not a Secret",
+			mg:     &strange{},
+			want: want{
+				mg:  &strange{},
+				err: errors.New(errNotSecret),
+			},
+		},
+		"NotFound": {
+			reason: "Should report that the Secret does not exist if GCP does not know it",
+			mg:     newSecret(),
+			want: want{
+				mg: newSecret(),
+			},
+		},
+		"UpToDate": {
+			reason: "Should report that the Secret is up to date if its topics and rotation match",
+			secret: observedSecret(rotating),
+			mg:     newSecret(secretWithRotation()),
+			want: want{
+				mg: newSecret(
+					secretWithRotation(),
+					secretWithAtProvider(v1alpha1.SecretObservation{
+						Name:             observation.Name,
+						CreateTime:       observation.CreateTime,
+						NextRotationTime: testRotationTime,
+					}),
+					secretWithCondition(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"TopicAdded": {
+			reason: "Should report that the Secret is not up to date if a topic is to be notified",
+			secret: observedSecret(),
+			mg:     newSecret(secretWithTopics("rotations", "audit")),
+			want: want{
+				mg: newSecret(
+					secretWithTopics("rotations", "audit"),
+					secretWithAtProvider(observation),
+					secretWithCondition(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"RotationScheduled": {
+			reason: "Should report that the Secret is not up to date if it is to be rotated",
+			secret: observedSecret(),
+			mg:     newSecret(secretWithRotation()),
+			want: want{
+				mg: newSecret(
+					secretWithRotation(),
+					secretWithAtProvider(observation),
+					secretWithCondition(xpv1.Available()),
+				),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []secretRequest
+			server := secretServer(t, tc.secret, &got)
+			defer server.Close()
+			obs, err := newSecretExternal(server.URL).Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSecretCreate(t *testing.T) {
+	type want struct {
+		requests []secretRequest
+		err      error
+	}
+	cases := map[string]struct {
+		reason string
+		mg     resource.Managed
+		want   want
+	}{
+		"Created": {
+			reason: "Should create a rotated Secret that notifies its topics",
+			mg:     newSecret(secretWithRotation()),
+			want: want{
+				requests: []secretRequest{{
+					Method:   http.MethodPost,
+					SecretID: testSecretName,
+					Body: map[string]interface{}{
+						"replication": map[string]interface{}{"automatic": map[string]interface{}{}},
+						"topics":      []interface{}{map[string]interface{}{"name": testTopic}},
+						"rotation": map[string]interface{}{
+							"nextRotationTime": testRotationTime,
+							"rotationPeriod":   testPeriod,
+						},
+					},
+				}},
+			},
+		},
+		"RotationWithoutTopics": {
+			reason: "Should not create a rotated Secret that notifies no topics",
+			mg:     newSecret(secretWithRotation(), secretWithTopics()),
+			want: want{
+				err: errors.Wrap(errors.New("rotation requires at least one topic to notify"), errInvalidSecret),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []secretRequest
+			server := secretServer(t, nil, &got)
+			defer server.Close()
+			_, err := newSecretExternal(server.URL).Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.requests, got); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want requests, +got requests:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSecretUpdate(t *testing.T) {
+	type want struct {
+		requests []secretRequest
+		err      error
+	}
+	cases := map[string]struct {
+		reason string
+		secret *secretmanager.Secret
+		mg     resource.Managed
+		want   want
+	}{
+		"RotationSet": {
+			reason: "Should patch only the rotation of a Secret that is to be rotated",
+			secret: observedSecret(),
+			mg:     newSecret(secretWithRotation()),
+			want: want{
+				requests: []secretRequest{{
+					Method:     http.MethodPatch,
+					UpdateMask: "rotation",
+					Body: map[string]interface{}{
+						"rotation": map[string]interface{}{
+							"nextRotationTime": testRotationTime,
+							"rotationPeriod":   testPeriod,
+						},
+					},
+				}},
+			},
+		},
+		"TopicAdded": {
+			reason: "Should patch only the topics of a Secret that is to notify another topic",
+			secret: observedSecret(),
+			mg:     newSecret(secretWithTopics("rotations", testAuditTopic)),
+			want: want{
+				requests: []secretRequest{{
+					Method:     http.MethodPatch,
+					UpdateMask: "topics",
+					Body: map[string]interface{}{
+						"topics": []interface{}{
+							map[string]interface{}{"name": testTopic},
+							map[string]interface{}{"name": testAuditTopic},
+						},
+					},
+				}},
+			},
+		},
+		"UpToDate": {
+			reason: "Should not patch a Secret that is up to date",
+			secret: observedSecret(),
+			mg:     newSecret(),
+		},
+		"LastTopicRemoved": {
+			reason: "Should not remove the last topic of a rotated Secret",
+			secret: observedSecret(),
+			mg:     newSecret(secretWithRotation(), secretWithTopics()),
+			want: want{
+				err: errors.Wrap(errors.New("rotation requires at least one topic to notify"), errInvalidSecret),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []secretRequest
+			server := secretServer(t, tc.secret, &got)
+			defer server.Close()
+			_, err := newSecretExternal(server.URL).Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.requests, got); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want requests, +got requests:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSecretDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		secret *secretmanager.Secret
+		want   []secretRequest
+	}{
+		"Deleted": {
+			reason: "Should delete the Secret",
+			secret: observedSecret(),
+			want:   []secretRequest{{Method: http.MethodDelete}},
+		},
+		"AlreadyGone": {
+			reason: "Should not return an error if the Secret is already gone",
+			want:   []secretRequest{{Method: http.MethodDelete}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []secretRequest
+			server := secretServer(t, tc.secret, &got)
+			defer server.Close()
+			err := newSecretExternal(server.URL).Delete(context.Background(), newSecret())
+			if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want requests, +got requests:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
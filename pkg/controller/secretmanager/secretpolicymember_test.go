@@ -0,0 +1,255 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secretmanager
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/option"
+	secretmanager "google.golang.org/api/secretmanager/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	iamv1alpha1 "github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
+	"github.com/crossplane/provider-gcp/apis/secretmanager/v1alpha1"
+)
+
+const (
+	testSecret = "projects/test-project/secrets/test-secret"
+	testRole   = "roles/secretmanager.secretAccessor"
+	testMember = "serviceAccount:app@test-project.iam.gserviceaccount.com"
+	testOther  = "user:alice@example.com"
+)
+
+type strange struct {
+	resource.Managed
+}
+
+type spmModifier func(*v1alpha1.SecretPolicyMember)
+
+func spmWithMember(m string) spmModifier {
+	return func(spm *v1alpha1.SecretPolicyMember) { spm.Spec.ForProvider.Member = &m }
+}
+
+func spmWithCondition(c xpv1.Condition) spmModifier {
+	return func(spm *v1alpha1.SecretPolicyMember) { spm.SetConditions(c) }
+}
+
+func newSecretPolicyMember(m ...spmModifier) *v1alpha1.SecretPolicyMember {
+	member := testMember
+	spm := &v1alpha1.SecretPolicyMember{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-secret-policy-member"},
+		Spec: v1alpha1.SecretPolicyMemberSpec{
+			ForProvider: v1alpha1.SecretPolicyMemberParameters{
+				Secret: testSecret,
+				Role:   testRole,
+				Member: &member,
+			},
+		},
+	}
+	for _, f := range m {
+		f(spm)
+	}
+	return spm
+}
+
+// policyServer serves the supplied policy and records the policy it is asked
+// to set.
+func policyServer(t *testing.T, p *secretmanager.Policy, set **secretmanager.Policy) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() { _ = r.Body.Close() }()
+		switch r.Method {
+		case http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(p)
+		case http.MethodPost:
+			req := &secretmanager.SetIamPolicyRequest{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				t.Errorf("cannot decode request: %s", err)
+			}
+			*set = req.Policy
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(req.Policy)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	}))
+}
+
+func TestSecretPolicyMemberObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		policy *secretmanager.Policy
+		mg     resource.Managed
+		want   want
+	}{
+		"NotSecretPolicyMember": {
+			reason: "Should return an error if the managed resource is not a SecretPolicyMember",
+			mg:     &strange{},
+			want: want{
+				mg:  &strange{},
+				err: errors.New(errNotSecretPolicyMember),
+			},
+		},
+		"NotBound": {
+			reason: "Should report that the SecretPolicyMember does not exist if the member is not bound",
+			policy: &secretmanager.Policy{Bindings: []*secretmanager.Binding{{Role: testRole, Members: []string{testOther}}}},
+			mg:     newSecretPolicyMember(),
+			want: want{
+				mg: newSecretPolicyMember(),
+			},
+		},
+		"Bound": {
+			reason: "Should report that the SecretPolicyMember exists and is up to date if the member is bound",
+			policy: &secretmanager.Policy{Bindings: []*secretmanager.Binding{{Role: testRole, Members: []string{testOther, testMember}}}},
+			mg:     newSecretPolicyMember(),
+			want: want{
+				mg:  newSecretPolicyMember(spmWithCondition(xpv1.Available())),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var set *secretmanager.Policy
+			server := policyServer(t, tc.policy, &set)
+			defer server.Close()
+			s, _ := secretmanager.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &secretPolicyMemberExternal{secretpolicy: secretmanager.NewProjectsSecretsService(s)}
+			obs, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSecretPolicyMemberCreate(t *testing.T) {
+	type want struct {
+		set *secretmanager.Policy
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		policy *secretmanager.Policy
+		mg     resource.Managed
+		want   want
+	}{
+		"AddedAccessor": {
+			reason: "Should add the member to the accessor binding of the Secret",
+			policy: &secretmanager.Policy{Etag: "BwWWja0YfJA=", Bindings: []*secretmanager.Binding{{Role: testRole, Members: []string{testOther}}}},
+			mg:     newSecretPolicyMember(),
+			want: want{
+				set: &secretmanager.Policy{Etag: "BwWWja0YfJA=", Version: iamv1alpha1.PolicyVersion, Bindings: []*secretmanager.Binding{
+					{Role: testRole, Members: []string{testOther, testMember}},
+				}},
+			},
+		},
+		"AlreadyBound": {
+			reason: "Should not set the policy if the member is already bound",
+			policy: &secretmanager.Policy{Bindings: []*secretmanager.Binding{{Role: testRole, Members: []string{testMember}}}},
+			mg:     newSecretPolicyMember(),
+		},
+		"InvalidMember": {
+			reason: "Should return an error if the member is not a well formed identity",
+			mg:     newSecretPolicyMember(spmWithMember("alice@example.com")),
+			want: want{
+				err: errors.Errorf("invalid member %q: must be allUsers, allAuthenticatedUsers, a principal:// or principalSet:// identifier, or an identity prefixed with its type, e.g. user:, serviceAccount:, group: or domain:", "alice@example.com"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var set *secretmanager.Policy
+			server := policyServer(t, tc.policy, &set)
+			defer server.Close()
+			s, _ := secretmanager.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &secretPolicyMemberExternal{secretpolicy: secretmanager.NewProjectsSecretsService(s)}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.set, set); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want policy, +got policy:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSecretPolicyMemberDelete(t *testing.T) {
+	type want struct {
+		set *secretmanager.Policy
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		policy *secretmanager.Policy
+		mg     resource.Managed
+		want   want
+	}{
+		"RemovedAccessor": {
+			reason: "Should remove the member from the accessor binding of the Secret",
+			policy: &secretmanager.Policy{Bindings: []*secretmanager.Binding{{Role: testRole, Members: []string{testOther, testMember}}}},
+			mg:     newSecretPolicyMember(),
+			want: want{
+				set: &secretmanager.Policy{Bindings: []*secretmanager.Binding{{Role: testRole, Members: []string{testOther}}}},
+			},
+		},
+		"NotBound": {
+			reason: "Should not set the policy if the member is not bound",
+			policy: &secretmanager.Policy{Bindings: []*secretmanager.Binding{{Role: testRole, Members: []string{testOther}}}},
+			mg:     newSecretPolicyMember(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var set *secretmanager.Policy
+			server := policyServer(t, tc.policy, &set)
+			defer server.Close()
+			s, _ := secretmanager.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &secretPolicyMemberExternal{secretpolicy: secretmanager.NewProjectsSecretsService(s)}
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.set, set); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want policy, +got policy:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicedirectory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	sd "google.golang.org/api/servicedirectory/v1beta1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/servicedirectory/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/servicedirectory"
+)
+
+const errNotEndpoint = "managed resource is not a GCP Service Directory Endpoint"
+
+// SetupEndpoint adds a controller that reconciles Service Directory Endpoints.
+func SetupEndpoint(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.EndpointGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.Endpoint{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.EndpointGroupVersionKind),
+			managed.WithExternalConnecter(&endpointConnecter{client: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type endpointConnecter struct {
+	client client.Client
+}
+
+// Connect sets up Service Directory client using credentials from the provider
+func (c *endpointConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, opts, err := gcp.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := sd.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &endpointExternal{endpoints: sd.NewProjectsLocationsNamespacesServicesEndpointsService(s)}, nil
+}
+
+type endpointExternal struct {
+	endpoints servicedirectory.EndpointClient
+}
+
+func (e *endpointExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Endpoint)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotEndpoint)
+	}
+
+	instance, err := e.endpoints.Get(endpointRRN(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGet)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	servicedirectory.LateInitializeEndpoint(&cr.Spec.ForProvider, *instance)
+
+	cr.Status.AtProvider = servicedirectory.GenerateEndpointObservation(*instance)
+	cr.Status.SetConditions(xpv1.Available())
+
+	upToDate, _ := servicedirectory.IsEndpointUpToDate(cr.Spec.ForProvider, instance)
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: !cmp.Equal(currentSpec, &cr.Spec.ForProvider),
+		ResourceUpToDate:        upToDate,
+	}, nil
+}
+
+func (e *endpointExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Endpoint)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotEndpoint)
+	}
+	cr.SetConditions(xpv1.Creating())
+	instance := &sd.Endpoint{}
+	servicedirectory.GenerateEndpoint(cr.Spec.ForProvider, instance)
+
+	if _, err := e.endpoints.Create(gcp.StringValue(cr.Spec.ForProvider.Service), instance).
+		EndpointId(meta.GetExternalName(cr)).Context(ctx).Do(); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreate)
+	}
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *endpointExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Endpoint)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotEndpoint)
+	}
+	// We have to get the endpoint again here to calculate update mask (what to patch).
+	instance, err := e.endpoints.Get(endpointRRN(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGet)
+	}
+
+	u, um := servicedirectory.IsEndpointUpToDate(cr.Spec.ForProvider, instance)
+	if u {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	servicedirectory.GenerateEndpoint(cr.Spec.ForProvider, instance)
+	if _, err := e.endpoints.Patch(endpointRRN(cr), instance).UpdateMask(um).
+		Context(ctx).Do(); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+	}
+
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *endpointExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Endpoint)
+	if !ok {
+		return errors.New(errNotEndpoint)
+	}
+	_, err := e.endpoints.Delete(endpointRRN(cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDelete)
+}
+
+func endpointRRN(cr *v1alpha1.Endpoint) string {
+	return fmt.Sprintf("%s/endpoints/%s", gcp.StringValue(cr.Spec.ForProvider.Service), meta.GetExternalName(cr))
+}
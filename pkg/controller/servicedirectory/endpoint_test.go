@@ -0,0 +1,275 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicedirectory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/option"
+	sd "google.golang.org/api/servicedirectory/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/servicedirectory/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const (
+	endpointName = "test-endpoint"
+	address      = "10.0.0.1"
+	network      = "projects/123456/locations/global/networks/default"
+)
+
+var epRRN = svcRRN + "/endpoints/" + endpointName
+
+type endpointModifier func(*v1alpha1.Endpoint)
+
+func epWithPort(p int64) endpointModifier {
+	return func(e *v1alpha1.Endpoint) { e.Spec.ForProvider.Port = &p }
+}
+
+func epWithNetwork(n string) endpointModifier {
+	return func(e *v1alpha1.Endpoint) { e.Spec.ForProvider.Network = &n }
+}
+
+func epWithAtProviderName(n string) endpointModifier {
+	return func(e *v1alpha1.Endpoint) { e.Status.AtProvider.Name = n }
+}
+
+func epWithCondition(c xpv1.Condition) endpointModifier {
+	return func(e *v1alpha1.Endpoint) { e.SetConditions(c) }
+}
+
+func newEndpoint(m ...endpointModifier) *v1alpha1.Endpoint {
+	e := &v1alpha1.Endpoint{
+		ObjectMeta: metav1.ObjectMeta{Name: endpointName},
+		Spec: v1alpha1.EndpointSpec{
+			ForProvider: v1alpha1.EndpointParameters{
+				Service: gcp.StringPtr(svcRRN),
+				Address: gcp.StringPtr(address),
+			},
+		},
+	}
+	meta.SetExternalName(e, endpointName)
+	for _, f := range m {
+		f(e)
+	}
+	return e
+}
+
+func TestEndpointObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"NotEndpoint": {
+			reason: "Should return an error if the managed resource is not an Endpoint",
+			mg:     &strange{},
+			want: want{
+				mg:  &strange{},
+				err: errors.New(errNotEndpoint),
+			},
+		},
+		"LateInitialized": {
+			reason: "Should late initialize the network of the Endpoint",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sd.Endpoint{Name: epRRN, Address: address, Port: 8080, Network: network})
+			}),
+			mg: newEndpoint(epWithPort(8080)),
+			want: want{
+				mg: newEndpoint(
+					epWithPort(8080),
+					epWithNetwork(network),
+					epWithAtProviderName(epRRN),
+					epWithCondition(xpv1.Available())),
+				obs: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+			},
+		},
+		"MetadataRemoved": {
+			reason: "Should report the Endpoint as not up to date rather than late initialize metadata removed from its spec",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sd.Endpoint{Name: epRRN, Address: address, Port: 8080, Metadata: map[string]string{"zone": "us-east1-b"}})
+			}),
+			mg: newEndpoint(epWithPort(8080)),
+			want: want{
+				mg: newEndpoint(
+					epWithPort(8080),
+					epWithAtProviderName(epRRN),
+					epWithCondition(xpv1.Available())),
+				obs: managed.ExternalObservation{
+					ResourceExists: true,
+				},
+			},
+		},
+		"PortDiffers": {
+			reason: "Should report the Endpoint as not up to date if the port differs",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sd.Endpoint{Name: epRRN, Address: address, Port: 8080})
+			}),
+			mg: newEndpoint(epWithPort(443)),
+			want: want{
+				mg: newEndpoint(
+					epWithPort(443),
+					epWithAtProviderName(epRRN),
+					epWithCondition(xpv1.Available())),
+				obs: managed.ExternalObservation{
+					ResourceExists: true,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := sd.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &endpointExternal{endpoints: sd.NewProjectsLocationsNamespacesServicesEndpointsService(s)}
+			obs, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestEndpointUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"PatchedPort": {
+			reason: "Should patch only the port of the Endpoint",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				switch r.Method {
+				case http.MethodGet:
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&sd.Endpoint{Name: epRRN, Address: address, Port: 8080})
+				case http.MethodPatch:
+					if diff := cmp.Diff("port", r.URL.Query().Get("updateMask")); diff != "" {
+						t.Errorf("updateMask: -want, +got:\n%s", diff)
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&sd.Endpoint{Name: epRRN})
+				default:
+					t.Errorf("unexpected method %s", r.Method)
+				}
+			}),
+			mg: newEndpoint(epWithPort(443)),
+		},
+		"GetFailed": {
+			reason: "Should return an error if the Endpoint cannot be fetched",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			mg:  newEndpoint(),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errGet),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := sd.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &endpointExternal{endpoints: sd.NewProjectsLocationsNamespacesServicesEndpointsService(s)}
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestEndpointDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"Deleted": {
+			reason: "Should delete the Endpoint",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sd.Empty{})
+			}),
+			mg: newEndpoint(),
+		},
+		"DeleteFailed": {
+			reason: "Should return an error if the Endpoint cannot be deleted",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			mg:  newEndpoint(),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errDelete),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := sd.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &endpointExternal{endpoints: sd.NewProjectsLocationsNamespacesServicesEndpointsService(s)}
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicedirectory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	sd "google.golang.org/api/servicedirectory/v1beta1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/servicedirectory/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/servicedirectory"
+)
+
+// Error strings.
+const (
+	errNewClient    = "cannot create new GCP Service Directory API client"
+	errNotNamespace = "managed resource is not a GCP Service Directory Namespace"
+	errGet          = "cannot get GCP object via Service Directory API"
+	errCreate       = "cannot create GCP object via Service Directory API"
+	errUpdate       = "cannot update GCP object via Service Directory API"
+	errDelete       = "cannot delete GCP object via Service Directory API"
+)
+
+// SetupServiceDirectoryNamespace adds a controller that reconciles Service
+// Directory Namespaces.
+func SetupServiceDirectoryNamespace(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.NamespaceGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.Namespace{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.NamespaceGroupVersionKind),
+			managed.WithExternalConnecter(&namespaceConnecter{client: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type namespaceConnecter struct {
+	client client.Client
+}
+
+// Connect sets up Service Directory client using credentials from the provider
+func (c *namespaceConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	projectID, opts, err := gcp.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := sd.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &namespaceExternal{namespaces: sd.NewProjectsLocationsNamespacesService(s), projectID: projectID}, nil
+}
+
+type namespaceExternal struct {
+	namespaces servicedirectory.NamespaceClient
+	projectID  string
+}
+
+func (e *namespaceExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Namespace)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotNamespace)
+	}
+
+	instance, err := e.namespaces.Get(namespaceRRN(e.projectID, cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGet)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	servicedirectory.LateInitializeNamespace(&cr.Spec.ForProvider, *instance)
+
+	cr.Status.AtProvider = servicedirectory.GenerateNamespaceObservation(*instance)
+	cr.Status.SetConditions(xpv1.Available())
+
+	upToDate, _ := servicedirectory.IsNamespaceUpToDate(cr.Spec.ForProvider, instance)
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: !cmp.Equal(currentSpec, &cr.Spec.ForProvider),
+		ResourceUpToDate:        upToDate,
+	}, nil
+}
+
+func (e *namespaceExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Namespace)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotNamespace)
+	}
+	cr.SetConditions(xpv1.Creating())
+	instance := &sd.Namespace{}
+	servicedirectory.GenerateNamespace(cr.Spec.ForProvider, instance)
+
+	if _, err := e.namespaces.Create(locationRRN(e.projectID, cr.Spec.ForProvider.Location), instance).
+		NamespaceId(meta.GetExternalName(cr)).Context(ctx).Do(); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreate)
+	}
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *namespaceExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Namespace)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotNamespace)
+	}
+	// We have to get the namespace again here to calculate update mask (what to patch).
+	instance, err := e.namespaces.Get(namespaceRRN(e.projectID, cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGet)
+	}
+
+	u, um := servicedirectory.IsNamespaceUpToDate(cr.Spec.ForProvider, instance)
+	if u {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	servicedirectory.GenerateNamespace(cr.Spec.ForProvider, instance)
+	if _, err := e.namespaces.Patch(namespaceRRN(e.projectID, cr), instance).UpdateMask(um).
+		Context(ctx).Do(); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+	}
+
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *namespaceExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Namespace)
+	if !ok {
+		return errors.New(errNotNamespace)
+	}
+	_, err := e.namespaces.Delete(namespaceRRN(e.projectID, cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDelete)
+}
+
+func locationRRN(projectID, location string) string {
+	return fmt.Sprintf("projects/%s/locations/%s", projectID, location)
+}
+
+func namespaceRRN(projectID string, cr *v1alpha1.Namespace) string {
+	return fmt.Sprintf("%s/namespaces/%s", locationRRN(projectID, cr.Spec.ForProvider.Location), meta.GetExternalName(cr))
+}
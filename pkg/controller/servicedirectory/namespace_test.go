@@ -0,0 +1,350 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicedirectory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	sd "google.golang.org/api/servicedirectory/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/servicedirectory/v1alpha1"
+)
+
+const (
+	projectID     = "test-project"
+	location      = "us-east1"
+	namespaceName = "test-namespace"
+)
+
+var nsRRN = "projects/" + projectID + "/locations/" + location + "/namespaces/" + namespaceName
+
+type strange struct {
+	resource.Managed
+}
+
+func gError(code int, message string) *googleapi.Error {
+	return &googleapi.Error{
+		Code:    code,
+		Body:    "",
+		Message: message,
+	}
+}
+
+type namespaceModifier func(*v1alpha1.Namespace)
+
+func nsWithLabels(l map[string]string) namespaceModifier {
+	return func(ns *v1alpha1.Namespace) { ns.Spec.ForProvider.Labels = l }
+}
+
+func nsWithAtProviderName(n string) namespaceModifier {
+	return func(ns *v1alpha1.Namespace) { ns.Status.AtProvider.Name = n }
+}
+
+func nsWithCondition(c xpv1.Condition) namespaceModifier {
+	return func(ns *v1alpha1.Namespace) { ns.SetConditions(c) }
+}
+
+func newNamespace(m ...namespaceModifier) *v1alpha1.Namespace {
+	ns := &v1alpha1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: namespaceName},
+		Spec: v1alpha1.NamespaceSpec{
+			ForProvider: v1alpha1.NamespaceParameters{Location: location},
+		},
+	}
+	meta.SetExternalName(ns, namespaceName)
+	for _, f := range m {
+		f(ns)
+	}
+	return ns
+}
+
+func TestNamespaceObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"NotNamespace": {
+			reason: "Should return an error if the managed resource is not a Namespace",
+			mg:     &strange{},
+			want: want{
+				mg:  &strange{},
+				err: errors.New(errNotNamespace),
+			},
+		},
+		"NotFound": {
+			reason: "Should report that the Namespace does not exist",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+			}),
+			mg: newNamespace(),
+			want: want{
+				mg: newNamespace(),
+			},
+		},
+		"GetFailed": {
+			reason: "Should return an error if the Namespace cannot be fetched",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			mg: newNamespace(),
+			want: want{
+				mg:  newNamespace(),
+				err: errors.Wrap(gError(http.StatusBadRequest, ""), errGet),
+			},
+		},
+		"LateInitializedAndUpToDate": {
+			reason: "Should late initialize labels and report the Namespace as up to date",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if !strings.HasSuffix(r.URL.Path, nsRRN) {
+					t.Errorf("requested URL.Path should end with %s, got %s instead", nsRRN, r.URL.Path)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sd.Namespace{Name: nsRRN, Labels: map[string]string{"team": "payments"}})
+			}),
+			mg: newNamespace(),
+			want: want{
+				mg: newNamespace(
+					nsWithLabels(map[string]string{"team": "payments"}),
+					nsWithAtProviderName(nsRRN),
+					nsWithCondition(xpv1.Available())),
+				obs: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+			},
+		},
+		"LabelsDiffer": {
+			reason: "Should report the Namespace as not up to date if labels differ",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sd.Namespace{Name: nsRRN, Labels: map[string]string{"team": "payments"}})
+			}),
+			mg: newNamespace(nsWithLabels(map[string]string{"team": "core"})),
+			want: want{
+				mg: newNamespace(
+					nsWithLabels(map[string]string{"team": "core"}),
+					nsWithAtProviderName(nsRRN),
+					nsWithCondition(xpv1.Available())),
+				obs: managed.ExternalObservation{
+					ResourceExists: true,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := sd.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &namespaceExternal{namespaces: sd.NewProjectsLocationsNamespacesService(s), projectID: projectID}
+			obs, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNamespaceCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"Created": {
+			reason: "Should create the Namespace in the configured location",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if diff := cmp.Diff(namespaceName, r.URL.Query().Get("namespaceId")); diff != "" {
+					t.Errorf("namespaceId: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sd.Namespace{Name: nsRRN})
+			}),
+			mg: newNamespace(),
+		},
+		"CreateFailed": {
+			reason: "Should return an error if the Namespace cannot be created",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			mg:  newNamespace(),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreate),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := sd.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &namespaceExternal{namespaces: sd.NewProjectsLocationsNamespacesService(s), projectID: projectID}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNamespaceUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"PatchedLabels": {
+			reason: "Should patch only the labels of the Namespace",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				switch r.Method {
+				case http.MethodGet:
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&sd.Namespace{Name: nsRRN})
+				case http.MethodPatch:
+					if diff := cmp.Diff("labels", r.URL.Query().Get("updateMask")); diff != "" {
+						t.Errorf("updateMask: -want, +got:\n%s", diff)
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&sd.Namespace{Name: nsRRN})
+				default:
+					t.Errorf("unexpected method %s", r.Method)
+				}
+			}),
+			mg: newNamespace(nsWithLabels(map[string]string{"team": "core"})),
+		},
+		"PatchFailed": {
+			reason: "Should return an error if the Namespace cannot be patched",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&sd.Namespace{Name: nsRRN})
+					return
+				}
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			mg:  newNamespace(nsWithLabels(map[string]string{"team": "core"})),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errUpdate),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := sd.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &namespaceExternal{namespaces: sd.NewProjectsLocationsNamespacesService(s), projectID: projectID}
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNamespaceDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"Deleted": {
+			reason: "Should delete the Namespace",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodDelete, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sd.Empty{})
+			}),
+			mg: newNamespace(),
+		},
+		"AlreadyGone": {
+			reason: "Should not return an error if the Namespace is already gone",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+			}),
+			mg: newNamespace(),
+		},
+		"DeleteFailed": {
+			reason: "Should return an error if the Namespace cannot be deleted",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			mg:  newNamespace(),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errDelete),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := sd.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &namespaceExternal{namespaces: sd.NewProjectsLocationsNamespacesService(s), projectID: projectID}
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicedirectory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	sd "google.golang.org/api/servicedirectory/v1beta1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/servicedirectory/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/servicedirectory"
+)
+
+const errNotService = "managed resource is not a GCP Service Directory Service"
+
+// SetupService adds a controller that reconciles Service Directory Services.
+func SetupService(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.ServiceGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.Service{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.ServiceGroupVersionKind),
+			managed.WithExternalConnecter(&serviceConnecter{client: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithInitializers(gcp.NewNameAsConventionalExternalName(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type serviceConnecter struct {
+	client client.Client
+}
+
+// Connect sets up Service Directory client using credentials from the provider
+func (c *serviceConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, opts, err := gcp.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := sd.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &serviceExternal{services: sd.NewProjectsLocationsNamespacesServicesService(s)}, nil
+}
+
+type serviceExternal struct {
+	services servicedirectory.ServiceClient
+}
+
+func (e *serviceExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Service)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotService)
+	}
+
+	instance, err := e.services.Get(serviceRRN(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGet)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	servicedirectory.LateInitializeService(&cr.Spec.ForProvider, *instance)
+
+	cr.Status.AtProvider = servicedirectory.GenerateServiceObservation(*instance)
+	cr.Status.SetConditions(xpv1.Available())
+
+	upToDate, _ := servicedirectory.IsServiceUpToDate(cr.Spec.ForProvider, instance)
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceLateInitialized: !cmp.Equal(currentSpec, &cr.Spec.ForProvider),
+		ResourceUpToDate:        upToDate,
+	}, nil
+}
+
+func (e *serviceExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Service)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotService)
+	}
+	cr.SetConditions(xpv1.Creating())
+	instance := &sd.Service{}
+	servicedirectory.GenerateService(cr.Spec.ForProvider, instance)
+
+	if _, err := e.services.Create(gcp.StringValue(cr.Spec.ForProvider.Namespace), instance).
+		ServiceId(meta.GetExternalName(cr)).Context(ctx).Do(); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreate)
+	}
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *serviceExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Service)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotService)
+	}
+	// We have to get the service again here to calculate update mask (what to patch).
+	instance, err := e.services.Get(serviceRRN(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGet)
+	}
+
+	u, um := servicedirectory.IsServiceUpToDate(cr.Spec.ForProvider, instance)
+	if u {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	servicedirectory.GenerateService(cr.Spec.ForProvider, instance)
+	if _, err := e.services.Patch(serviceRRN(cr), instance).UpdateMask(um).
+		Context(ctx).Do(); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+	}
+
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *serviceExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Service)
+	if !ok {
+		return errors.New(errNotService)
+	}
+	_, err := e.services.Delete(serviceRRN(cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDelete)
+}
+
+func serviceRRN(cr *v1alpha1.Service) string {
+	return fmt.Sprintf("%s/services/%s", gcp.StringValue(cr.Spec.ForProvider.Namespace), meta.GetExternalName(cr))
+}
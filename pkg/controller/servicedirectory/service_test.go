@@ -0,0 +1,214 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicedirectory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/option"
+	sd "google.golang.org/api/servicedirectory/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/servicedirectory/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+const serviceName = "test-service"
+
+var svcRRN = nsRRN + "/services/" + serviceName
+
+type serviceModifier func(*v1alpha1.Service)
+
+func svcWithMetadata(m map[string]string) serviceModifier {
+	return func(s *v1alpha1.Service) { s.Spec.ForProvider.Metadata = m }
+}
+
+func svcWithAtProviderName(n string) serviceModifier {
+	return func(s *v1alpha1.Service) { s.Status.AtProvider.Name = n }
+}
+
+func svcWithCondition(c xpv1.Condition) serviceModifier {
+	return func(s *v1alpha1.Service) { s.SetConditions(c) }
+}
+
+func newService(m ...serviceModifier) *v1alpha1.Service {
+	s := &v1alpha1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: serviceName},
+		Spec: v1alpha1.ServiceSpec{
+			ForProvider: v1alpha1.ServiceParameters{Namespace: gcp.StringPtr(nsRRN)},
+		},
+	}
+	meta.SetExternalName(s, serviceName)
+	for _, f := range m {
+		f(s)
+	}
+	return s
+}
+
+func TestServiceObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		want    want
+	}{
+		"NotService": {
+			reason: "Should return an error if the managed resource is not a Service",
+			mg:     &strange{},
+			want: want{
+				mg:  &strange{},
+				err: errors.New(errNotService),
+			},
+		},
+		"NotFound": {
+			reason: "Should report that the Service does not exist",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusNotFound)
+			}),
+			mg: newService(),
+			want: want{
+				mg: newService(),
+			},
+		},
+		"UpToDate": {
+			reason: "Should report the Service as up to date if the metadata matches",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if !strings.HasSuffix(r.URL.Path, svcRRN) {
+					t.Errorf("requested URL.Path should end with %s, got %s instead", svcRRN, r.URL.Path)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sd.Service{Name: svcRRN, Metadata: map[string]string{"owner": "payments"}})
+			}),
+			mg: newService(svcWithMetadata(map[string]string{"owner": "payments"})),
+			want: want{
+				mg: newService(
+					svcWithMetadata(map[string]string{"owner": "payments"}),
+					svcWithAtProviderName(svcRRN),
+					svcWithCondition(xpv1.Available())),
+				obs: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"MetadataDiffers": {
+			reason: "Should report the Service as not up to date if the metadata differs",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sd.Service{Name: svcRRN, Metadata: map[string]string{"owner": "payments"}})
+			}),
+			mg: newService(svcWithMetadata(map[string]string{"owner": "core"})),
+			want: want{
+				mg: newService(
+					svcWithMetadata(map[string]string{"owner": "core"}),
+					svcWithAtProviderName(svcRRN),
+					svcWithCondition(xpv1.Available())),
+				obs: managed.ExternalObservation{
+					ResourceExists: true,
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := sd.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &serviceExternal{services: sd.NewProjectsLocationsNamespacesServicesService(s)}
+			obs, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestServiceCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		handler http.Handler
+		mg      resource.Managed
+		err     error
+	}{
+		"Created": {
+			reason: "Should create the Service in the referenced Namespace",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				if diff := cmp.Diff(http.MethodPost, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				if !strings.HasSuffix(r.URL.Path, nsRRN+"/services") {
+					t.Errorf("requested URL.Path should end with %s/services, got %s instead", nsRRN, r.URL.Path)
+				}
+				if diff := cmp.Diff(serviceName, r.URL.Query().Get("serviceId")); diff != "" {
+					t.Errorf("serviceId: -want, +got:\n%s", diff)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&sd.Service{Name: svcRRN})
+			}),
+			mg: newService(),
+		},
+		"CreateFailed": {
+			reason: "Should return an error if the Service cannot be created",
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.Body.Close()
+				w.WriteHeader(http.StatusBadRequest)
+			}),
+			mg:  newService(),
+			err: errors.Wrap(gError(http.StatusBadRequest, ""), errCreate),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := sd.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &serviceExternal{services: sd.NewProjectsLocationsNamespacesServicesService(s)}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -75,7 +75,7 @@ const (
 
 // SetupConnection adds a controller that reconciles Connection
 // managed resources.
-func SetupConnection(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupConnection(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
 	name := managed.ControllerName(v1beta1.ConnectionGroupKind)
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
@@ -89,6 +89,7 @@ func SetupConnection(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimite
 			managed.WithConnectionPublishers(),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
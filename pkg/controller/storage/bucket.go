@@ -22,7 +22,11 @@ import (
 
 	"cloud.google.com/go/storage"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/imdario/mergo"
+	crmv3 "google.golang.org/api/cloudresourcemanager/v3"
+	"google.golang.org/api/option"
+	storagev1 "google.golang.org/api/storage/v1"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -37,8 +41,13 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
+	iamv1alpha1 "github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
+	"github.com/crossplane/provider-gcp/apis/storage/v1alpha1"
 	"github.com/crossplane/provider-gcp/apis/storage/v1alpha3"
 	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/bucketpolicy"
+	"github.com/crossplane/provider-gcp/pkg/clients/objecthold"
+	"github.com/crossplane/provider-gcp/pkg/clients/tagbinding"
 )
 
 // Error strings.
@@ -50,10 +59,35 @@ const (
 	errCreate    = "cannot create GCP bucket"
 	errUpdate    = "cannot update GCP bucket"
 	errDelete    = "cannot delete GCP bucket"
+
+	errPredefinedACL = "predefined ACLs cannot be applied to a GCP bucket with bucketPolicyOnly (uniform bucket-level access) enabled"
+
+	errFmtLifecycleActionType      = "lifecycle rule %d: action type must be one of Delete or SetStorageClass, not %q"
+	errFmtLifecycleStorageClass    = "lifecycle rule %d: %q is not a valid storage class"
+	errFmtLifecycleDeleteClass     = "lifecycle rule %d: a Delete action must not specify a storage class"
+	errFmtLifecycleTransition      = "lifecycle rule %d: objects of storage class %s cannot be transitioned to storage class %s"
+	errFmtLifecycleNoCondition     = "lifecycle rule %d: at least one condition must be specified"
+	errFmtLifecycleNegativeCounter = "lifecycle rule %d: ageInDays and numNewerVersions must not be negative"
+
+	errListIAMResources = "cannot list managed resources that bind members to roles of GCP bucket"
+
+	errNewTagBindingClient = "cannot create new GCP Resource Manager client"
+	errListTagBindings     = "cannot list tag bindings of GCP bucket"
+	errFmtBindTagValue     = "cannot bind tag value %s to GCP bucket"
+	errFmtUnbindTagValue   = "cannot unbind tag value %s from GCP bucket"
+
+	errReleaseEventBasedHolds = "cannot release event-based holds of objects of GCP bucket"
 )
 
+// defaultBucketLocation is the location of buckets that don't specify one.
+const defaultBucketLocation = "US"
+
+// bucketExternalNameTemplate is used to generate the external name of Buckets
+// that don't have one. It defaults to the name of the managed resource.
+const bucketExternalNameTemplate = "{{ .Name }}"
+
 // SetupBucket adds a controller that reconciles Buckets.
-func SetupBucket(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupBucket(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
 	name := managed.ControllerName(v1alpha3.BucketGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -65,7 +99,9 @@ func SetupBucket(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, p
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha3.BucketGroupVersionKind),
 			managed.WithExternalConnecter(&connecter{client: mgr.GetClient()}),
+			managed.WithInitializers(gcp.NewTemplatedExternalName(mgr.GetClient(), bucketExternalNameTemplate)),
 			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
@@ -78,10 +114,16 @@ type BucketClient interface {
 // A GCSBucketClient wraps the GCS storage.Client as a BucketClient.
 type GCSBucketClient struct {
 	c *storage.Client
+
+	// userProject is billed for requests if not empty.
+	userProject string
 }
 
 // Bucket produces a BucketHandler for the named bucket.
 func (sbc *GCSBucketClient) Bucket(name string) BucketHandler {
+	if sbc.userProject != "" {
+		return sbc.c.Bucket(name).UserProject(sbc.userProject)
+	}
 	return sbc.c.Bucket(name)
 }
 
@@ -99,23 +141,75 @@ type connecter struct {
 
 // Connect sets up iam client using credentials from the provider
 func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	projectID, opts, err := gcp.GetAuthInfo(ctx, c.client, mg)
+	cr, ok := mg.(*v1alpha3.Bucket)
+	if !ok {
+		return nil, errors.New(errNotBucket)
+	}
+	projectID, opts, err := gcp.GetRegionalAuthInfo(ctx, c.client, mg, gcp.ServiceStorage, cr.Spec.Location)
 	if err != nil {
 		return nil, err
 	}
 
-	s, err := storage.NewClient(ctx, opts)
+	s, err := storage.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	ps, err := storagev1.NewService(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	tb, err := c.connectTagBindings(ctx, cr)
 	if err != nil {
 		return nil, err
 	}
 
-	return &external{handle: &GCSBucketClient{c: s}, projectID: projectID, client: c.client}, errors.Wrap(err, errNewClient)
+	up := userProject(cr, projectID)
+	return &external{handle: &GCSBucketClient{c: s, userProject: up}, bucketpolicy: storagev1.NewBucketsService(ps), objects: storagev1.NewObjectsService(ps), tagbindings: tb, projectID: projectID, userProject: up, client: c.client}, nil
+}
+
+// connectTagBindings returns a client for the tag bindings of the supplied
+// bucket, or nil if it does not desire any tag values. Tag values are bound
+// to a bucket via the Resource Manager endpoint of its location unless its
+// ProviderConfig configures one.
+func (c *connecter) connectTagBindings(ctx context.Context, cr *v1alpha3.Bucket) (tagbinding.Client, error) {
+	if cr.Spec.TagValues == nil {
+		return nil, nil
+	}
+	location := cr.Spec.Location
+	if location == "" {
+		location = defaultBucketLocation
+	}
+	_, opts, err := gcp.GetRegionalAuthInfo(ctx, c.client, cr, gcp.ServiceResourceManager, location)
+	if err != nil {
+		return nil, err
+	}
+	crm, err := crmv3.NewService(ctx, append([]option.ClientOption{option.WithEndpoint(tagbinding.LocationEndpoint(location))}, opts...)...)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewTagBindingClient)
+	}
+	return crmv3.NewTagBindingsService(crm), nil
+}
+
+// userProject returns the project that is billed for requests for the
+// supplied bucket, or an empty string if the bucket is not a Requester Pays
+// bucket. A bucket is treated as one while Requester Pays is desired or was
+// last observed, so that it can still be disabled.
+func userProject(cr *v1alpha3.Bucket, projectID string) string {
+	if cr.Spec.RequesterPays || cr.Status.RequesterPays {
+		return projectID
+	}
+	return ""
 }
 
 type external struct {
-	handle    BucketClient
-	projectID string
-	client    client.Client
+	handle       BucketClient
+	bucketpolicy bucketpolicy.Client
+	objects      objecthold.Client
+	tagbindings  tagbinding.Client
+	projectID    string
+	userProject  string
+	client       client.Client
 }
 
 func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -139,6 +233,9 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	if err := mergo.Merge(proposed, v1alpha3.NewBucketSpecAttrs(a)); err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errLateInit)
 	}
+	// NOTE: The default KMS key is never late initialized. Removing it from
+	// the spec reverts the bucket to Google-managed encryption.
+	proposed.Encryption = cr.Spec.Encryption.DeepCopy()
 	if !cmp.Equal(*proposed, cr.Spec.BucketSpecAttrs) {
 		cr.Spec.BucketSpecAttrs = *proposed
 		if err := e.client.Update(ctx, cr); err != nil {
@@ -147,19 +244,143 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}
 
 	cr.Status.BucketOutputAttrs = v1alpha3.NewBucketOutputAttrs(a)
+	members, err := e.observeIAMMembers(ctx, meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	cr.Status.IAMMembers = members
+	bindings, err := e.observeTagBindings(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	cr.Status.TagValues = tagbinding.TagValues(bindings)
+	held, err := e.observeEventBasedHolds(ctx, cr, a)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
 	cr.SetConditions(xpv1.Available())
 
+	// NOTE: Predefined ACLs are never returned by GCP, so they can't be
+	// compared. They are applied whenever the bucket is updated.
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: cmp.Equal(v1alpha3.NewBucketUpdatableAttrs(a), &cr.Spec.BucketUpdatableAttrs),
+		ResourceExists: true,
+		ResourceUpToDate: cmp.Equal(v1alpha3.NewBucketUpdatableAttrs(a), &cr.Spec.BucketUpdatableAttrs,
+			cmpopts.IgnoreFields(v1alpha3.BucketUpdatableAttrs{}, "PredefinedACL", "PredefinedDefaultObjectACL", "Encryption")) &&
+			defaultKMSKeyName(cr.Spec.Encryption) == defaultKMSKeyName(v1alpha3.NewBucketEncryption(a.Encryption)) &&
+			tagValuesUpToDate(cr.Spec.TagValues, bindings) && !held,
 	}, nil
 }
 
+// releasesEventBasedHolds returns true if the event-based holds of the objects
+// of the supplied bucket should be released. Holds are not released while the
+// bucket places them on new objects, because those would be held again.
+func releasesEventBasedHolds(cr *v1alpha3.Bucket) bool {
+	return cr.Spec.ReleaseEventBasedHolds != nil && !cr.Spec.DefaultEventBasedHold
+}
+
+// observeEventBasedHolds returns true if any object of the supplied bucket
+// whose event-based hold should be released is still held.
+func (e *external) observeEventBasedHolds(ctx context.Context, cr *v1alpha3.Bucket, a *storage.BucketAttrs) (bool, error) {
+	if !releasesEventBasedHolds(cr) || a.DefaultEventBasedHold || e.objects == nil {
+		return false, nil
+	}
+	held, err := objecthold.Held(ctx, e.objects, meta.GetExternalName(cr), cr.Spec.ReleaseEventBasedHolds.Prefix, e.userProject)
+	return held, errors.Wrap(err, errReleaseEventBasedHolds)
+}
+
+// tagValuesUpToDate returns true if the supplied tag bindings bind exactly the
+// desired tag values.
+func tagValuesUpToDate(desired []string, observed []*crmv3.TagBinding) bool {
+	bind, unbind := tagbinding.Diff(desired, observed)
+	return len(bind) == 0 && len(unbind) == 0
+}
+
+// defaultKMSKeyName returns the default KMS key of the supplied encryption
+// configuration. An absent configuration and one without a key are the same.
+func defaultKMSKeyName(e *v1alpha3.BucketEncryption) string {
+	if e == nil {
+		return ""
+	}
+	return e.DefaultKMSKeyName
+}
+
+// observeIAMMembers returns the members bound to roles by the IAM policy of
+// the named bucket. Members bound by a BucketPolicy, BucketPolicyBinding or
+// BucketPolicyMember are attributed to that managed resource.
+func (e *external) observeIAMMembers(ctx context.Context, bucket string) ([]v1alpha3.BucketIAMMember, error) {
+	call := e.bucketpolicy.GetIamPolicy(bucket).OptionsRequestedPolicyVersion(iamv1alpha1.PolicyVersion)
+	if e.userProject != "" {
+		call = call.UserProject(e.userProject)
+	}
+	sp, err := call.Context(ctx).Do()
+	if err != nil {
+		return nil, errors.Wrap(err, errGetPolicy)
+	}
+
+	policies := &v1alpha1.BucketPolicyList{}
+	if err := e.client.List(ctx, policies); err != nil {
+		return nil, errors.Wrap(err, errListIAMResources)
+	}
+	bindings := &v1alpha1.BucketPolicyBindingList{}
+	if err := e.client.List(ctx, bindings); err != nil {
+		return nil, errors.Wrap(err, errListIAMResources)
+	}
+	members := &v1alpha1.BucketPolicyMemberList{}
+	if err := e.client.List(ctx, members); err != nil {
+		return nil, errors.Wrap(err, errListIAMResources)
+	}
+
+	o := bucketpolicy.NewMemberOwners(bucket, policies.Items, bindings.Items, members.Items)
+	return bucketpolicy.GenerateIAMMembers(sp, o), nil
+}
+
+// observeTagBindings returns the tag bindings of the supplied bucket, or nil if
+// it does not desire any tag values.
+func (e *external) observeTagBindings(ctx context.Context, cr *v1alpha3.Bucket) ([]*crmv3.TagBinding, error) {
+	if cr.Spec.TagValues == nil || e.tagbindings == nil {
+		return nil, nil
+	}
+	var bindings []*crmv3.TagBinding
+	err := e.tagbindings.List().Parent(tagbinding.BucketResourceName(meta.GetExternalName(cr))).Pages(ctx, func(r *crmv3.ListTagBindingsResponse) error {
+		bindings = append(bindings, r.TagBindings...)
+		return nil
+	})
+	return bindings, errors.Wrap(err, errListTagBindings)
+}
+
+// updateTagBindings binds the desired tag values of the supplied bucket that
+// are not yet bound to it, and unbinds those that are no longer desired.
+func (e *external) updateTagBindings(ctx context.Context, cr *v1alpha3.Bucket) error {
+	observed, err := e.observeTagBindings(ctx, cr)
+	if err != nil || cr.Spec.TagValues == nil || e.tagbindings == nil {
+		return err
+	}
+	bind, unbind := tagbinding.Diff(cr.Spec.TagValues, observed)
+	for _, v := range bind {
+		tb := &crmv3.TagBinding{Parent: tagbinding.BucketResourceName(meta.GetExternalName(cr)), TagValue: v}
+		if _, err := e.tagbindings.Create(tb).Context(ctx).Do(); err != nil {
+			return errors.Wrapf(err, errFmtBindTagValue, v)
+		}
+	}
+	for _, b := range unbind {
+		if _, err := e.tagbindings.Delete(b.Name).Context(ctx).Do(); resource.Ignore(gcp.IsErrorNotFound, err) != nil {
+			return errors.Wrapf(err, errFmtUnbindTagValue, b.TagValue)
+		}
+	}
+	return nil
+}
+
 func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
 	cr, ok := mg.(*v1alpha3.Bucket)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotBucket)
 	}
+	if err := validatePredefinedACLs(cr.Spec.BucketUpdatableAttrs); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if err := validateLifecycle(cr.Spec.Lifecycle); err != nil {
+		return managed.ExternalCreation{}, err
+	}
 
 	err := e.handle.Bucket(meta.GetExternalName(cr)).Create(ctx, e.projectID, v1alpha3.CopyBucketSpecAttrs(&cr.Spec.BucketSpecAttrs))
 	return managed.ExternalCreation{}, errors.Wrap(err, errCreate)
@@ -170,15 +391,40 @@ func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotBucket)
 	}
+	if err := validatePredefinedACLs(cr.Spec.BucketUpdatableAttrs); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := validateLifecycle(cr.Spec.Lifecycle); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
 
 	current, err := e.handle.Bucket(meta.GetExternalName(cr)).Attrs(ctx)
 	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, errAttrs)
 	}
 	ua := v1alpha3.CopyToBucketUpdateAttrs(cr.Spec.BucketUpdatableAttrs, current.Labels)
-	_, err = e.handle.Bucket(meta.GetExternalName(cr)).Update(ctx, ua)
+	// An encryption configuration without a key deletes the default KMS key,
+	// while an absent one would leave it unchanged.
+	if cr.Spec.Encryption == nil && current.Encryption != nil {
+		ua.Encryption = &storage.BucketEncryption{}
+	}
+	if _, err := e.handle.Bucket(meta.GetExternalName(cr)).Update(ctx, ua); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+	}
+	if err := e.updateTagBindings(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
 
-	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+	// Holds are released once the bucket no longer places them on new
+	// objects, which the update above takes care of.
+	if releasesEventBasedHolds(cr) && e.objects != nil {
+		released, err := objecthold.Release(ctx, e.objects, meta.GetExternalName(cr), cr.Spec.ReleaseEventBasedHolds.Prefix, e.userProject)
+		cr.Status.ReleasedEventBasedHolds = released
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errReleaseEventBasedHolds)
+		}
+	}
+	return managed.ExternalUpdate{}, nil
 }
 
 func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
@@ -190,3 +436,70 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
 	err := e.handle.Bucket(meta.GetExternalName(cr)).Delete(ctx)
 	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDelete)
 }
+
+// validatePredefinedACLs returns an error if predefined ACLs are combined with
+// uniform bucket-level access, which GCP rejects.
+func validatePredefinedACLs(a v1alpha3.BucketUpdatableAttrs) error {
+	if a.BucketPolicyOnly == nil || !a.BucketPolicyOnly.Enabled {
+		return nil
+	}
+	if a.PredefinedACL != "" || a.PredefinedDefaultObjectACL != "" {
+		return errors.New(errPredefinedACL)
+	}
+	return nil
+}
+
+// storageClassTiers ranks the storage classes from the most to the least
+// frequently accessed. A lifecycle rule may only transition objects to a
+// class of a higher tier than the classes they are matched by.
+var storageClassTiers = map[string]int{
+	"STANDARD":                     0,
+	"MULTI_REGIONAL":               0,
+	"REGIONAL":                     0,
+	"DURABLE_REDUCED_AVAILABILITY": 0,
+	"NEARLINE":                     1,
+	"COLDLINE":                     2,
+	"ARCHIVE":                      3,
+}
+
+// validateLifecycle returns an error if any of the supplied lifecycle rules
+// would be rejected by GCP, i.e. if its action is unknown, its conditions are
+// malformed, or it transitions objects to a storage class that is not colder
+// than the classes it matches.
+func validateLifecycle(l v1alpha3.Lifecycle) error { // nolint:gocyclo
+	for i, r := range l.Rules {
+		c := r.Condition
+		if c.AgeInDays < 0 || c.NumNewerVersions < 0 {
+			return errors.Errorf(errFmtLifecycleNegativeCounter, i)
+		}
+		if c.AgeInDays == 0 && c.NumNewerVersions == 0 && c.CreatedBefore.IsZero() &&
+			c.Liveness == storage.LiveAndArchived && len(c.MatchesStorageClasses) == 0 {
+			return errors.Errorf(errFmtLifecycleNoCondition, i)
+		}
+		for _, sc := range c.MatchesStorageClasses {
+			if _, ok := storageClassTiers[sc]; !ok {
+				return errors.Errorf(errFmtLifecycleStorageClass, i, sc)
+			}
+		}
+
+		switch r.Action.Type {
+		case storage.DeleteAction:
+			if r.Action.StorageClass != "" {
+				return errors.Errorf(errFmtLifecycleDeleteClass, i)
+			}
+		case storage.SetStorageClassAction:
+			to, ok := storageClassTiers[r.Action.StorageClass]
+			if !ok {
+				return errors.Errorf(errFmtLifecycleStorageClass, i, r.Action.StorageClass)
+			}
+			for _, sc := range c.MatchesStorageClasses {
+				if storageClassTiers[sc] >= to {
+					return errors.Errorf(errFmtLifecycleTransition, i, sc, r.Action.StorageClass)
+				}
+			}
+		default:
+			return errors.Errorf(errFmtLifecycleActionType, i, r.Action.Type)
+		}
+	}
+	return nil
+}
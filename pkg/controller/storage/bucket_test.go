@@ -18,18 +18,31 @@ package storage
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"cloud.google.com/go/storage"
 	"github.com/google/go-cmp/cmp"
+	crmv3 "google.golang.org/api/cloudresourcemanager/v3"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	storagev1 "google.golang.org/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
+	"github.com/crossplane/provider-gcp/apis/storage/v1alpha1"
 	"github.com/crossplane/provider-gcp/apis/storage/v1alpha3"
+	"github.com/crossplane/provider-gcp/pkg/clients/objecthold"
+	"github.com/crossplane/provider-gcp/pkg/clients/tagbinding"
 )
 
 type MockBucketClient struct {
@@ -63,11 +76,66 @@ func (m *MockBucketHandler) Delete(ctx context.Context) error {
 	return m.MockDelete(ctx)
 }
 
+// newTagBindings returns a client for tag bindings served by the supplied
+// handler, or nil if there is none.
+func newTagBindings(t *testing.T, h http.Handler) tagbinding.Client {
+	t.Helper()
+	if h == nil {
+		return nil
+	}
+	server := httptest.NewServer(h)
+	t.Cleanup(server.Close)
+	s, _ := crmv3.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	return crmv3.NewTagBindingsService(s)
+}
+
+// tagBindings returns a handler that lists the supplied tag bindings.
+func tagBindings(bindings ...*crmv3.TagBinding) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.Body.Close()
+		_ = json.NewEncoder(w).Encode(&crmv3.ListTagBindingsResponse{TagBindings: bindings})
+	})
+}
+
+// newObjects returns a client for objects served by the supplied handler, or
+// nil if there is none.
+func newObjects(t *testing.T, h http.Handler) objecthold.Client {
+	t.Helper()
+	if h == nil {
+		return nil
+	}
+	server := httptest.NewServer(h)
+	t.Cleanup(server.Close)
+	s, _ := storagev1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	return storagev1.NewObjectsService(s)
+}
+
+// heldObjects returns a handler that lists the supplied objects, and releases
+// their event-based holds.
+func heldObjects(objects ...*storagev1.Object) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.Body.Close()
+		if r.Method == http.MethodPatch {
+			_ = json.NewEncoder(w).Encode(&storagev1.Object{})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&storagev1.Objects{Items: objects})
+	})
+}
+
 func TestObserve(t *testing.T) {
 	errBoom := errors.New("boom")
 
+	emptyPolicy := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.Body.Close()
+		_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+	})
+
 	type fields struct {
 		handle    BucketClient
+		policy    http.Handler
+		tags      http.Handler
+		objects   http.Handler
 		projectID string
 		client    client.Client
 	}
@@ -78,8 +146,10 @@ func TestObserve(t *testing.T) {
 	}
 
 	type want struct {
-		o   managed.ExternalObservation
-		err error
+		o         managed.ExternalObservation
+		members   []v1alpha3.BucketIAMMember
+		tagValues []string
+		err       error
 	}
 
 	cases := map[string]struct {
@@ -154,6 +224,10 @@ func TestObserve(t *testing.T) {
 				handle: &MockBucketClient{&MockBucketHandler{
 					MockAttrs: func(context.Context) (*storage.BucketAttrs, error) { return &storage.BucketAttrs{}, nil },
 				}},
+				policy: emptyPolicy,
+				client: &test.MockClient{
+					MockList: test.NewMockListFn(nil),
+				},
 			},
 			args: args{
 				mg: &v1alpha3.Bucket{},
@@ -163,18 +237,301 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
+		"EventBasedHoldsHeld": {
+			reason: "A bucket should be out of date while objects whose event-based holds should be released are held",
+			fields: fields{
+				handle: &MockBucketClient{&MockBucketHandler{
+					MockAttrs: func(context.Context) (*storage.BucketAttrs, error) { return &storage.BucketAttrs{}, nil },
+				}},
+				policy:  emptyPolicy,
+				objects: heldObjects(&storagev1.Object{Name: "released"}, &storagev1.Object{Name: "held", EventBasedHold: true}),
+				client: &test.MockClient{
+					MockList: test.NewMockListFn(nil),
+				},
+			},
+			args: args{
+				mg: &v1alpha3.Bucket{Spec: v1alpha3.BucketSpec{BucketParameters: v1alpha3.BucketParameters{
+					ReleaseEventBasedHolds: &v1alpha3.EventBasedHoldRelease{},
+				}}},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"EventBasedHoldsPlacedByDefault": {
+			reason: "Event-based holds should not be released while the bucket places them on new objects",
+			fields: fields{
+				handle: &MockBucketClient{&MockBucketHandler{
+					MockAttrs: func(context.Context) (*storage.BucketAttrs, error) {
+						return &storage.BucketAttrs{DefaultEventBasedHold: true}, nil
+					},
+				}},
+				policy: emptyPolicy,
+				objects: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					t.Errorf("objects of a bucket that places event-based holds by default should not be listed")
+				}),
+				client: &test.MockClient{
+					MockList: test.NewMockListFn(nil),
+				},
+			},
+			args: args{
+				mg: &v1alpha3.Bucket{Spec: v1alpha3.BucketSpec{BucketParameters: v1alpha3.BucketParameters{
+					BucketSpecAttrs:        v1alpha3.BucketSpecAttrs{BucketUpdatableAttrs: v1alpha3.BucketUpdatableAttrs{DefaultEventBasedHold: true}},
+					ReleaseEventBasedHolds: &v1alpha3.EventBasedHoldRelease{},
+				}}},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"PredefinedACL": {
+			reason: "Predefined ACLs are never returned by GCP and should not cause a bucket to be out of date",
+			fields: fields{
+				handle: &MockBucketClient{&MockBucketHandler{
+					MockAttrs: func(context.Context) (*storage.BucketAttrs, error) { return &storage.BucketAttrs{}, nil },
+				}},
+				policy: emptyPolicy,
+				client: &test.MockClient{
+					MockList: test.NewMockListFn(nil),
+				},
+			},
+			args: args{
+				mg: &v1alpha3.Bucket{Spec: v1alpha3.BucketSpec{BucketParameters: v1alpha3.BucketParameters{BucketSpecAttrs: v1alpha3.BucketSpecAttrs{
+					BucketUpdatableAttrs: v1alpha3.BucketUpdatableAttrs{PredefinedACL: "publicRead", PredefinedDefaultObjectACL: "publicRead"},
+				}}}},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"DefaultKMSKeySet": {
+			reason: "A bucket should be out of date if a default KMS key is desired but not set",
+			fields: fields{
+				handle: &MockBucketClient{&MockBucketHandler{
+					MockAttrs: func(context.Context) (*storage.BucketAttrs, error) { return &storage.BucketAttrs{}, nil },
+				}},
+				policy: emptyPolicy,
+				client: &test.MockClient{
+					MockList: test.NewMockListFn(nil),
+				},
+			},
+			args: args{
+				mg: &v1alpha3.Bucket{Spec: v1alpha3.BucketSpec{BucketParameters: v1alpha3.BucketParameters{BucketSpecAttrs: v1alpha3.BucketSpecAttrs{
+					BucketUpdatableAttrs: v1alpha3.BucketUpdatableAttrs{Encryption: &v1alpha3.BucketEncryption{DefaultKMSKeyName: "test-kms"}},
+				}}}},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true},
+			},
+		},
+		"DefaultKMSKeyCleared": {
+			reason: "A bucket should be out of date rather than late initialized if its default KMS key was removed from the spec",
+			fields: fields{
+				handle: &MockBucketClient{&MockBucketHandler{
+					MockAttrs: func(context.Context) (*storage.BucketAttrs, error) {
+						return &storage.BucketAttrs{Encryption: &storage.BucketEncryption{DefaultKMSKeyName: "test-kms"}}, nil
+					},
+				}},
+				policy: emptyPolicy,
+				client: &test.MockClient{
+					MockUpdate: test.NewMockUpdateFn(errBoom),
+					MockList:   test.NewMockListFn(nil),
+				},
+			},
+			args: args{
+				mg: &v1alpha3.Bucket{},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true},
+			},
+		},
+		"GetPolicyError": {
+			reason: "Errors getting the IAM policy of a bucket should be returned",
+			fields: fields{
+				handle: &MockBucketClient{&MockBucketHandler{
+					MockAttrs: func(context.Context) (*storage.BucketAttrs, error) { return &storage.BucketAttrs{}, nil },
+				}},
+				policy: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_ = r.Body.Close()
+					w.WriteHeader(http.StatusForbidden)
+				}),
+			},
+			args: args{
+				mg: &v1alpha3.Bucket{},
+			},
+			want: want{
+				err: errors.Wrap(&googleapi.Error{Code: http.StatusForbidden}, errGetPolicy),
+			},
+		},
+		"ListError": {
+			reason: "Errors listing the managed resources that bind members to roles of a bucket should be returned",
+			fields: fields{
+				handle: &MockBucketClient{&MockBucketHandler{
+					MockAttrs: func(context.Context) (*storage.BucketAttrs, error) { return &storage.BucketAttrs{}, nil },
+				}},
+				policy: emptyPolicy,
+				client: &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				},
+			},
+			args: args{
+				mg: &v1alpha3.Bucket{},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errListIAMResources),
+			},
+		},
+		"TagValueAttached": {
+			reason: "A bucket should be out of date if a desired tag value is not bound to it",
+			fields: fields{
+				handle: &MockBucketClient{&MockBucketHandler{
+					MockAttrs: func(context.Context) (*storage.BucketAttrs, error) { return &storage.BucketAttrs{}, nil },
+				}},
+				policy: emptyPolicy,
+				tags:   tagBindings(&crmv3.TagBinding{Name: "tagBindings/env", TagValue: "tagValues/111"}),
+				client: &test.MockClient{
+					MockList: test.NewMockListFn(nil),
+				},
+			},
+			args: args{
+				mg: &v1alpha3.Bucket{Spec: v1alpha3.BucketSpec{BucketParameters: v1alpha3.BucketParameters{
+					TagValues: []string{"tagValues/111", "tagValues/222"},
+				}}},
+			},
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true},
+				tagValues: []string{"tagValues/111"},
+			},
+		},
+		"TagValuesUpToDate": {
+			reason: "A bucket should be up to date if exactly its desired tag values are bound to it",
+			fields: fields{
+				handle: &MockBucketClient{&MockBucketHandler{
+					MockAttrs: func(context.Context) (*storage.BucketAttrs, error) { return &storage.BucketAttrs{}, nil },
+				}},
+				policy: emptyPolicy,
+				tags: tagBindings(
+					&crmv3.TagBinding{Name: "tagBindings/team", TagValue: "tagValues/222"},
+					&crmv3.TagBinding{Name: "tagBindings/env", TagValue: "tagValues/111"},
+				),
+				client: &test.MockClient{
+					MockList: test.NewMockListFn(nil),
+				},
+			},
+			args: args{
+				mg: &v1alpha3.Bucket{Spec: v1alpha3.BucketSpec{BucketParameters: v1alpha3.BucketParameters{
+					TagValues: []string{"tagValues/111", "tagValues/222"},
+				}}},
+			},
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				tagValues: []string{"tagValues/111", "tagValues/222"},
+			},
+		},
+		"ListTagBindingsError": {
+			reason: "Errors listing the tag bindings of a bucket should be returned",
+			fields: fields{
+				handle: &MockBucketClient{&MockBucketHandler{
+					MockAttrs: func(context.Context) (*storage.BucketAttrs, error) { return &storage.BucketAttrs{}, nil },
+				}},
+				policy: emptyPolicy,
+				tags: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_ = r.Body.Close()
+					w.WriteHeader(http.StatusForbidden)
+				}),
+				client: &test.MockClient{
+					MockList: test.NewMockListFn(nil),
+				},
+			},
+			args: args{
+				mg: &v1alpha3.Bucket{Spec: v1alpha3.BucketSpec{BucketParameters: v1alpha3.BucketParameters{
+					TagValues: []string{"tagValues/111"},
+				}}},
+			},
+			want: want{
+				err: errors.Wrap(&googleapi.Error{Code: http.StatusForbidden}, errListTagBindings),
+			},
+		},
+		"IAMMembers": {
+			reason: "Members bound by managed resources should be distinguished from those bound outside of the provider",
+			fields: fields{
+				handle: &MockBucketClient{&MockBucketHandler{
+					MockAttrs: func(context.Context) (*storage.BucketAttrs, error) { return &storage.BucketAttrs{}, nil },
+				}},
+				policy: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					_ = r.Body.Close()
+					_ = json.NewEncoder(w).Encode(&storagev1.Policy{
+						Bindings: []*storagev1.PolicyBindings{
+							{
+								Role:    "roles/storage.objectViewer",
+								Members: []string{"group:owned@example.com", "user:external@example.com"},
+							},
+						},
+					})
+				}),
+				client: &test.MockClient{
+					MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+						if l, ok := obj.(*v1alpha1.BucketPolicyMemberList); ok {
+							member := "group:owned@example.com"
+							bucket := "cool-bucket"
+							l.Items = []v1alpha1.BucketPolicyMember{{
+								ObjectMeta: metav1.ObjectMeta{Name: "viewer"},
+								Spec: v1alpha1.BucketPolicyMemberSpec{
+									ForProvider: v1alpha1.BucketPolicyMemberParameters{
+										Bucket: &bucket,
+										Role:   "roles/storage.objectViewer",
+										Member: &member,
+									},
+								},
+							}}
+						}
+						return nil
+					},
+				},
+			},
+			args: args{
+				mg: &v1alpha3.Bucket{ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool-bucket"},
+				}},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				members: []v1alpha3.BucketIAMMember{
+					{
+						Role:      "roles/storage.objectViewer",
+						Member:    "group:owned@example.com",
+						ManagedBy: "BucketPolicyMember/viewer",
+					},
+					{
+						Role:   "roles/storage.objectViewer",
+						Member: "user:external@example.com",
+					},
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &external{handle: tc.fields.handle, projectID: tc.fields.projectID, client: tc.fields.client}
-			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			server := httptest.NewServer(tc.fields.policy)
+			defer server.Close()
+			s, _ := storagev1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &external{handle: tc.fields.handle, bucketpolicy: storagev1.NewBucketsService(s), objects: newObjects(t, tc.fields.objects), tagbindings: newTagBindings(t, tc.fields.tags), projectID: tc.fields.projectID, client: tc.fields.client}
+			got, err := e.Observe(context.Background(), tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
 			if diff := cmp.Diff(tc.want.o, got); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
+			if cr, ok := tc.args.mg.(*v1alpha3.Bucket); ok {
+				if diff := cmp.Diff(tc.want.members, cr.Status.IAMMembers); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want IAM members, +got IAM members:\n%s\n", tc.reason, diff)
+				}
+				if diff := cmp.Diff(tc.want.tagValues, cr.Status.TagValues); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want tag values, +got tag values:\n%s\n", tc.reason, diff)
+				}
+			}
 		})
 	}
 }
@@ -213,6 +570,36 @@ func TestCreate(t *testing.T) {
 				err: errors.New(errNotBucket),
 			},
 		},
+		"PredefinedACLWithBucketPolicyOnly": {
+			reason: "Predefined ACLs should be rejected when uniform bucket-level access is enabled",
+			args: args{
+				mg: &v1alpha3.Bucket{Spec: v1alpha3.BucketSpec{BucketParameters: v1alpha3.BucketParameters{BucketSpecAttrs: v1alpha3.BucketSpecAttrs{
+					BucketUpdatableAttrs: v1alpha3.BucketUpdatableAttrs{
+						BucketPolicyOnly: &v1alpha3.BucketPolicyOnly{Enabled: true},
+						PredefinedACL:    "publicRead",
+					},
+				}}}},
+			},
+			want: want{
+				err: errors.New(errPredefinedACL),
+			},
+		},
+		"InvalidLifecycle": {
+			reason: "Lifecycle rules that GCP would reject should be rejected before the bucket is created",
+			args: args{
+				mg: &v1alpha3.Bucket{Spec: v1alpha3.BucketSpec{BucketParameters: v1alpha3.BucketParameters{BucketSpecAttrs: v1alpha3.BucketSpecAttrs{
+					BucketUpdatableAttrs: v1alpha3.BucketUpdatableAttrs{
+						Lifecycle: v1alpha3.Lifecycle{Rules: []v1alpha3.LifecycleRule{{
+							Action:    v1alpha3.LifecycleAction{Type: storage.SetStorageClassAction, StorageClass: "NEARLINE"},
+							Condition: v1alpha3.LifecycleCondition{MatchesStorageClasses: []string{"COLDLINE"}},
+						}}},
+					},
+				}}}},
+			},
+			want: want{
+				err: errors.Errorf(errFmtLifecycleTransition, 0, "COLDLINE", "NEARLINE"),
+			},
+		},
 		"CreateError": {
 			reason: "Errors creating a bucket should be returned",
 			fields: fields{
@@ -260,6 +647,8 @@ func TestUpdate(t *testing.T) {
 
 	type fields struct {
 		handle    BucketClient
+		tags      http.Handler
+		objects   http.Handler
 		projectID string
 		client    client.Client
 	}
@@ -270,8 +659,9 @@ func TestUpdate(t *testing.T) {
 	}
 
 	type want struct {
-		u   managed.ExternalUpdate
-		err error
+		u        managed.ExternalUpdate
+		released int64
+		err      error
 	}
 
 	cases := map[string]struct {
@@ -318,6 +708,166 @@ func TestUpdate(t *testing.T) {
 				err: errors.Wrap(errBoom, errUpdate),
 			},
 		},
+		"RequesterPays": {
+			reason: "Requester Pays should be disabled when it is no longer desired",
+			fields: fields{
+				handle: &MockBucketClient{&MockBucketHandler{
+					MockAttrs: func(context.Context) (*storage.BucketAttrs, error) {
+						return &storage.BucketAttrs{RequesterPays: true}, nil
+					},
+					MockUpdate: func(_ context.Context, ua storage.BucketAttrsToUpdate) (*storage.BucketAttrs, error) {
+						if diff := cmp.Diff(false, ua.RequesterPays); diff != "" {
+							t.Errorf("Update(...): -want requesterPays, +got requesterPays:\n%s", diff)
+						}
+						return nil, nil
+					},
+				}},
+			},
+			args: args{
+				mg: &v1alpha3.Bucket{},
+			},
+			want: want{},
+		},
+		"DefaultKMSKeySet": {
+			reason: "The default KMS key should be set when it is desired",
+			fields: fields{
+				handle: &MockBucketClient{&MockBucketHandler{
+					MockAttrs: func(context.Context) (*storage.BucketAttrs, error) { return &storage.BucketAttrs{}, nil },
+					MockUpdate: func(_ context.Context, ua storage.BucketAttrsToUpdate) (*storage.BucketAttrs, error) {
+						if diff := cmp.Diff(&storage.BucketEncryption{DefaultKMSKeyName: "test-kms"}, ua.Encryption); diff != "" {
+							t.Errorf("Update(...): -want encryption, +got encryption:\n%s", diff)
+						}
+						return nil, nil
+					},
+				}},
+			},
+			args: args{
+				mg: &v1alpha3.Bucket{Spec: v1alpha3.BucketSpec{BucketParameters: v1alpha3.BucketParameters{BucketSpecAttrs: v1alpha3.BucketSpecAttrs{
+					BucketUpdatableAttrs: v1alpha3.BucketUpdatableAttrs{Encryption: &v1alpha3.BucketEncryption{DefaultKMSKeyName: "test-kms"}},
+				}}}},
+			},
+			want: want{},
+		},
+		"DefaultKMSKeyCleared": {
+			reason: "The default KMS key should be deleted when it is no longer desired",
+			fields: fields{
+				handle: &MockBucketClient{&MockBucketHandler{
+					MockAttrs: func(context.Context) (*storage.BucketAttrs, error) {
+						return &storage.BucketAttrs{Encryption: &storage.BucketEncryption{DefaultKMSKeyName: "test-kms"}}, nil
+					},
+					MockUpdate: func(_ context.Context, ua storage.BucketAttrsToUpdate) (*storage.BucketAttrs, error) {
+						if diff := cmp.Diff(&storage.BucketEncryption{}, ua.Encryption); diff != "" {
+							t.Errorf("Update(...): -want encryption, +got encryption:\n%s", diff)
+						}
+						return nil, nil
+					},
+				}},
+			},
+			args: args{
+				mg: &v1alpha3.Bucket{},
+			},
+			want: want{},
+		},
+		"TagValuesReconciled": {
+			reason: "Desired tag values that are not bound to a bucket should be bound, and bound ones that are not desired unbound",
+			fields: fields{
+				handle: &MockBucketClient{&MockBucketHandler{
+					MockAttrs:  func(context.Context) (*storage.BucketAttrs, error) { return &storage.BucketAttrs{}, nil },
+					MockUpdate: func(context.Context, storage.BucketAttrsToUpdate) (*storage.BucketAttrs, error) { return nil, nil },
+				}},
+				tags: func() http.Handler {
+					bound, unbound := 0, 0
+					t.Cleanup(func() {
+						if bound != 1 || unbound != 1 {
+							t.Errorf("Update(...): want one tag value bound and one unbound, got %d bound and %d unbound", bound, unbound)
+						}
+					})
+					return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						defer r.Body.Close()
+						switch r.Method {
+						case http.MethodGet:
+							_ = json.NewEncoder(w).Encode(&crmv3.ListTagBindingsResponse{TagBindings: []*crmv3.TagBinding{
+								{Name: "tagBindings/env", TagValue: "tagValues/111"},
+								{Name: "tagBindings/old", TagValue: "tagValues/999"},
+							}})
+						case http.MethodPost:
+							bound++
+							tb := &crmv3.TagBinding{}
+							_ = json.NewDecoder(r.Body).Decode(tb)
+							want := &crmv3.TagBinding{Parent: "//storage.googleapis.com/projects/_/buckets/cool-bucket", TagValue: "tagValues/222"}
+							if diff := cmp.Diff(want, tb); diff != "" {
+								t.Errorf("Create(...): -want tag binding, +got tag binding:\n%s", diff)
+							}
+							_ = json.NewEncoder(w).Encode(&crmv3.Operation{})
+						case http.MethodDelete:
+							unbound++
+							if !strings.HasSuffix(r.URL.Path, "/tagBindings/old") {
+								t.Errorf("Delete(...): unexpected tag binding %s unbound", r.URL.Path)
+							}
+							_ = json.NewEncoder(w).Encode(&crmv3.Operation{})
+						default:
+							w.WriteHeader(http.StatusBadRequest)
+						}
+					})
+				}(),
+			},
+			args: args{
+				mg: &v1alpha3.Bucket{
+					ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{meta.AnnotationKeyExternalName: "cool-bucket"}},
+					Spec: v1alpha3.BucketSpec{BucketParameters: v1alpha3.BucketParameters{
+						TagValues: []string{"tagValues/111", "tagValues/222"},
+					}},
+				},
+			},
+			want: want{},
+		},
+		"BindTagValueError": {
+			reason: "Errors binding a tag value to a bucket should be returned",
+			fields: fields{
+				handle: &MockBucketClient{&MockBucketHandler{
+					MockAttrs:  func(context.Context) (*storage.BucketAttrs, error) { return &storage.BucketAttrs{}, nil },
+					MockUpdate: func(context.Context, storage.BucketAttrsToUpdate) (*storage.BucketAttrs, error) { return nil, nil },
+				}},
+				tags: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					defer r.Body.Close()
+					if r.Method == http.MethodGet {
+						_ = json.NewEncoder(w).Encode(&crmv3.ListTagBindingsResponse{})
+						return
+					}
+					w.WriteHeader(http.StatusForbidden)
+				}),
+			},
+			args: args{
+				mg: &v1alpha3.Bucket{Spec: v1alpha3.BucketSpec{BucketParameters: v1alpha3.BucketParameters{
+					TagValues: []string{"tagValues/222"},
+				}}},
+			},
+			want: want{
+				err: errors.Wrapf(&googleapi.Error{Code: http.StatusForbidden}, errFmtBindTagValue, "tagValues/222"),
+			},
+		},
+		"ReleasesEventBasedHolds": {
+			reason: "The event-based holds of held objects should be released and counted",
+			fields: fields{
+				handle: &MockBucketClient{&MockBucketHandler{
+					MockAttrs:  func(context.Context) (*storage.BucketAttrs, error) { return &storage.BucketAttrs{}, nil },
+					MockUpdate: func(context.Context, storage.BucketAttrsToUpdate) (*storage.BucketAttrs, error) { return nil, nil },
+				}},
+				objects: heldObjects(
+					&storagev1.Object{Name: "a", EventBasedHold: true},
+					&storagev1.Object{Name: "b"},
+					&storagev1.Object{Name: "c", EventBasedHold: true},
+				),
+			},
+			args: args{
+				mg: &v1alpha3.Bucket{Spec: v1alpha3.BucketSpec{BucketParameters: v1alpha3.BucketParameters{
+					ReleaseEventBasedHolds: &v1alpha3.EventBasedHoldRelease{Prefix: "logs/"},
+				}}},
+			},
+			want: want{
+				released: 2,
+			},
+		},
 		"Success": {
 			reason: "Updating a bucket successfully should return an empty ExternalUpdate and nil error",
 			fields: fields{
@@ -335,14 +885,19 @@ func TestUpdate(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &external{handle: tc.fields.handle, projectID: tc.fields.projectID, client: tc.fields.client}
-			got, err := e.Update(tc.args.ctx, tc.args.mg)
+			e := &external{handle: tc.fields.handle, objects: newObjects(t, tc.fields.objects), tagbindings: newTagBindings(t, tc.fields.tags), projectID: tc.fields.projectID, client: tc.fields.client}
+			got, err := e.Update(context.Background(), tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
 			if diff := cmp.Diff(tc.want.u, got); diff != "" {
 				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
+			if cr, ok := tc.args.mg.(*v1alpha3.Bucket); ok {
+				if diff := cmp.Diff(tc.want.released, cr.Status.ReleasedEventBasedHolds); diff != "" {
+					t.Errorf("\n%s\ne.Update(...): -want released holds, +got released holds:\n%s\n", tc.reason, diff)
+				}
+			}
 		})
 	}
 }
@@ -410,3 +965,116 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestUserProject(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha3.Bucket
+		want   string
+	}{
+		"NotRequesterPays": {
+			reason: "Requests for a bucket that is not a Requester Pays bucket should not be billed to a user project",
+			cr:     &v1alpha3.Bucket{},
+		},
+		"DesiredRequesterPays": {
+			reason: "Requests for a bucket that should be a Requester Pays bucket should be billed to the project",
+			cr: &v1alpha3.Bucket{Spec: v1alpha3.BucketSpec{BucketParameters: v1alpha3.BucketParameters{BucketSpecAttrs: v1alpha3.BucketSpecAttrs{
+				BucketUpdatableAttrs: v1alpha3.BucketUpdatableAttrs{RequesterPays: true},
+			}}}},
+			want: "cool-project",
+		},
+		"ObservedRequesterPays": {
+			reason: "Requests for a bucket that was a Requester Pays bucket should be billed to the project until it is disabled",
+			cr:     &v1alpha3.Bucket{Status: v1alpha3.BucketStatus{BucketOutputAttrs: v1alpha3.BucketOutputAttrs{RequesterPays: true}}},
+			want:   "cool-project",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, userProject(tc.cr, "cool-project")); diff != "" {
+				t.Errorf("\n%s\nuserProject(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidateLifecycle(t *testing.T) {
+	rule := func(a v1alpha3.LifecycleAction, c v1alpha3.LifecycleCondition) v1alpha3.Lifecycle {
+		return v1alpha3.Lifecycle{Rules: []v1alpha3.LifecycleRule{{Action: a, Condition: c}}}
+	}
+	setStorageClass := func(sc string) v1alpha3.LifecycleAction {
+		return v1alpha3.LifecycleAction{Type: storage.SetStorageClassAction, StorageClass: sc}
+	}
+
+	cases := map[string]struct {
+		reason string
+		l      v1alpha3.Lifecycle
+		want   error
+	}{
+		"NoRules": {
+			reason: "A bucket without lifecycle rules is valid",
+		},
+		"Delete": {
+			reason: "A Delete action with a condition is valid",
+			l:      rule(v1alpha3.LifecycleAction{Type: storage.DeleteAction}, v1alpha3.LifecycleCondition{AgeInDays: 30}),
+		},
+		"ValidTransitions": {
+			reason: "Objects may be transitioned to colder storage classes",
+			l: v1alpha3.Lifecycle{Rules: []v1alpha3.LifecycleRule{
+				{Action: setStorageClass("NEARLINE"), Condition: v1alpha3.LifecycleCondition{MatchesStorageClasses: []string{"STANDARD", "MULTI_REGIONAL", "REGIONAL"}}},
+				{Action: setStorageClass("COLDLINE"), Condition: v1alpha3.LifecycleCondition{MatchesStorageClasses: []string{"NEARLINE"}}},
+				{Action: setStorageClass("ARCHIVE"), Condition: v1alpha3.LifecycleCondition{AgeInDays: 365}},
+			}},
+		},
+		"TransitionToWarmerClass": {
+			reason: "Objects may not be transitioned to a warmer storage class",
+			l:      rule(setStorageClass("NEARLINE"), v1alpha3.LifecycleCondition{MatchesStorageClasses: []string{"ARCHIVE"}}),
+			want:   errors.Errorf(errFmtLifecycleTransition, 0, "ARCHIVE", "NEARLINE"),
+		},
+		"TransitionToSameClass": {
+			reason: "Objects may not be transitioned to the storage class they already have",
+			l:      rule(setStorageClass("COLDLINE"), v1alpha3.LifecycleCondition{MatchesStorageClasses: []string{"COLDLINE"}}),
+			want:   errors.Errorf(errFmtLifecycleTransition, 0, "COLDLINE", "COLDLINE"),
+		},
+		"UnknownTargetClass": {
+			reason: "Objects may only be transitioned to a known storage class",
+			l:      rule(setStorageClass("FROZEN"), v1alpha3.LifecycleCondition{AgeInDays: 30}),
+			want:   errors.Errorf(errFmtLifecycleStorageClass, 0, "FROZEN"),
+		},
+		"UnknownMatchedClass": {
+			reason: "Conditions may only match known storage classes",
+			l:      rule(setStorageClass("ARCHIVE"), v1alpha3.LifecycleCondition{MatchesStorageClasses: []string{"standard"}}),
+			want:   errors.Errorf(errFmtLifecycleStorageClass, 0, "standard"),
+		},
+		"DeleteWithStorageClass": {
+			reason: "A Delete action may not specify a storage class",
+			l:      rule(v1alpha3.LifecycleAction{Type: storage.DeleteAction, StorageClass: "ARCHIVE"}, v1alpha3.LifecycleCondition{AgeInDays: 30}),
+			want:   errors.Errorf(errFmtLifecycleDeleteClass, 0),
+		},
+		"UnknownAction": {
+			reason: "Only Delete and SetStorageClass actions are valid",
+			l:      rule(v1alpha3.LifecycleAction{Type: "Archive"}, v1alpha3.LifecycleCondition{AgeInDays: 30}),
+			want:   errors.Errorf(errFmtLifecycleActionType, 0, "Archive"),
+		},
+		"NoCondition": {
+			reason: "A rule must have at least one condition",
+			l:      rule(setStorageClass("ARCHIVE"), v1alpha3.LifecycleCondition{}),
+			want:   errors.Errorf(errFmtLifecycleNoCondition, 0),
+		},
+		"NegativeAge": {
+			reason: "A rule may not match objects of a negative age",
+			l:      rule(v1alpha3.LifecycleAction{Type: storage.DeleteAction}, v1alpha3.LifecycleCondition{AgeInDays: -1}),
+			want:   errors.Errorf(errFmtLifecycleNegativeCounter, 0),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := validateLifecycle(tc.l)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nvalidateLifecycle(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
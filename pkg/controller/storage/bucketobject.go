@@ -0,0 +1,189 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/storage/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/storage/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/bucketobject"
+)
+
+const (
+	errNotBucketObject     = "managed resource is not a GCP BucketObject"
+	errGetBucketObject     = "cannot get GCP bucket object"
+	errUploadBucketObject  = "cannot upload GCP bucket object"
+	errUpdateBucketObject  = "cannot update GCP bucket object"
+	errDeleteBucketObject  = "cannot delete GCP bucket object"
+	errManagedBucketObject = "cannot update managed BucketObject resource"
+)
+
+// bucketObjectExternalNameTemplate is used to generate the external name of
+// BucketObjects that don't have one. It defaults to the name of the managed
+// resource.
+const bucketObjectExternalNameTemplate = "{{ .Name }}"
+
+// SetupBucketObject adds a controller that reconciles BucketObjects.
+func SetupBucketObject(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration) error {
+	name := managed.ControllerName(v1alpha1.BucketObjectGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.BucketObject{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.BucketObjectGroupVersionKind),
+			managed.WithExternalConnecter(&bucketObjectConnecter{client: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithInitializers(gcp.NewTemplatedExternalName(mgr.GetClient(), bucketObjectExternalNameTemplate)),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type bucketObjectConnecter struct {
+	client client.Client
+}
+
+// Connect sets up storage client using credentials from the provider
+func (c *bucketObjectConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, opts, err := gcp.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := storage.NewService(ctx, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &bucketObjectExternal{kube: c.client, objects: storage.NewObjectsService(s)}, nil
+}
+
+type bucketObjectExternal struct {
+	kube    client.Client
+	objects bucketobject.Client
+}
+
+func (e *bucketObjectExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.BucketObject)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotBucketObject)
+	}
+	// References are not resolved once a BucketObject is deleted, so one
+	// whose bucket was never resolved has no object to observe.
+	if cr.Spec.ForProvider.Bucket == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	// GCP reports a missing object and a missing bucket alike.
+	o, err := e.objects.Get(*cr.Spec.ForProvider.Bucket, meta.GetExternalName(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetBucketObject)
+	}
+
+	currentSpec := cr.Spec.ForProvider.DeepCopy()
+	bucketobject.LateInitializeSpec(&cr.Spec.ForProvider, *o)
+	if !cmp.Equal(currentSpec, &cr.Spec.ForProvider) {
+		if err := e.kube.Update(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errManagedBucketObject)
+		}
+	}
+
+	cr.Status.AtProvider = bucketobject.GenerateObservation(*o)
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: bucketobject.IsUpToDate(cr.Spec.ForProvider, *o),
+	}, nil
+}
+
+func (e *bucketObjectExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.BucketObject)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotBucketObject)
+	}
+	cr.SetConditions(xpv1.Creating())
+	return managed.ExternalCreation{}, errors.Wrap(e.upload(ctx, cr), errUploadBucketObject)
+}
+
+// Update uploads the object again if its content changed, which also sets its
+// metadata, and otherwise only updates its metadata.
+func (e *bucketObjectExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.BucketObject)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotBucketObject)
+	}
+
+	bucket := gcp.StringValue(cr.Spec.ForProvider.Bucket)
+	o, err := e.objects.Get(bucket, meta.GetExternalName(cr)).Context(ctx).Do()
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetBucketObject)
+	}
+	if !bucketobject.IsContentUpToDate(cr.Spec.ForProvider, *o) {
+		return managed.ExternalUpdate{}, errors.Wrap(e.upload(ctx, cr), errUploadBucketObject)
+	}
+	_, err = e.objects.Patch(bucket, meta.GetExternalName(cr), bucketobject.GeneratePatch(cr.Spec.ForProvider, *o)).Context(ctx).Do()
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateBucketObject)
+}
+
+func (e *bucketObjectExternal) upload(ctx context.Context, cr *v1alpha1.BucketObject) error {
+	o := bucketobject.GenerateObject(meta.GetExternalName(cr), cr.Spec.ForProvider)
+	var opts []googleapi.MediaOption
+	if o.ContentType != "" {
+		opts = append(opts, googleapi.ContentType(o.ContentType))
+	}
+	_, err := e.objects.Insert(gcp.StringValue(cr.Spec.ForProvider.Bucket), o).Media(strings.NewReader(cr.Spec.ForProvider.Content), opts...).Context(ctx).Do()
+	return err
+}
+
+// Delete deletes the object. An object whose bucket is gone, e.g. because the
+// bucket was deleted along with its objects, is considered deleted.
+func (e *bucketObjectExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.BucketObject)
+	if !ok {
+		return errors.New(errNotBucketObject)
+	}
+	if cr.Spec.ForProvider.Bucket == nil {
+		return nil
+	}
+	cr.SetConditions(xpv1.Deleting())
+	err := e.objects.Delete(*cr.Spec.ForProvider.Bucket, meta.GetExternalName(cr)).Context(ctx).Do()
+	return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errDeleteBucketObject)
+}
@@ -0,0 +1,400 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/option"
+	storagev1 "google.golang.org/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/storage/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/bucketobject"
+)
+
+const (
+	testObjectBucket  = "my-bucket"
+	testObjectName    = "config/app.json"
+	testObjectContent = `{"greeting": "hello"}`
+
+	// bucketNotFound is how GCP responds to requests for the objects of a
+	// bucket that does not exist.
+	bucketNotFound = `{"error":{"code":404,"message":"The specified bucket does not exist.","errors":[{"message":"The specified bucket does not exist.","domain":"global","reason":"notFound"}]}}`
+)
+
+var _ managed.ExternalConnecter = &bucketObjectConnecter{}
+var _ managed.ExternalClient = &bucketObjectExternal{}
+
+type boModifier func(*v1alpha1.BucketObject)
+
+func boWithConditions(c ...xpv1.Condition) boModifier {
+	return func(o *v1alpha1.BucketObject) { o.Status.SetConditions(c...) }
+}
+
+func boWithContent(c string) boModifier {
+	return func(o *v1alpha1.BucketObject) { o.Spec.ForProvider.Content = c }
+}
+
+func boWithCacheControl(c string) boModifier {
+	return func(o *v1alpha1.BucketObject) { o.Spec.ForProvider.CacheControl = &c }
+}
+
+func boWithObservation(o v1alpha1.BucketObjectObservation) boModifier {
+	return func(b *v1alpha1.BucketObject) { b.Status.AtProvider = o }
+}
+
+func boObj(m ...boModifier) *v1alpha1.BucketObject {
+	o := &v1alpha1.BucketObject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "app-config",
+			Annotations: map[string]string{meta.AnnotationKeyExternalName: testObjectName},
+		},
+		Spec: v1alpha1.BucketObjectSpec{
+			ForProvider: v1alpha1.BucketObjectParameters{
+				Bucket:      gcp.StringPtr(testObjectBucket),
+				Content:     testObjectContent,
+				ContentType: gcp.StringPtr("application/json"),
+			},
+		},
+	}
+	for _, f := range m {
+		f(o)
+	}
+	return o
+}
+
+func boObserved() *storagev1.Object {
+	return &storagev1.Object{
+		Name:        testObjectName,
+		Bucket:      testObjectBucket,
+		ContentType: "application/json",
+		Md5Hash:     bucketobject.MD5Hash(testObjectContent),
+		Generation:  1,
+		Size:        uint64(len(testObjectContent)),
+	}
+}
+
+// bucketObjectHandler serves the supplied object, or responds as if its
+// bucket was gone, and records the method and path of requests that change
+// it. Those fail with the supplied status, if any.
+func bucketObjectHandler(o *storagev1.Object, bucketGone bool, calls *[]string, status int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, _ = ioutil.ReadAll(r.Body)
+		_ = r.Body.Close()
+		if r.Method != http.MethodGet {
+			*calls = append(*calls, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/upload/storage/v1"))
+		}
+		switch {
+		case bucketGone:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(bucketNotFound))
+		case status != 0 && r.Method != http.MethodGet:
+			w.WriteHeader(status)
+			_ = json.NewEncoder(w).Encode(&storagev1.Object{})
+		case o == nil && r.Method != http.MethodPost:
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(&storagev1.Object{})
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			_ = json.NewEncoder(w).Encode(o)
+		}
+	}
+}
+
+func newBucketObjects(t *testing.T, h http.Handler) bucketobject.Client {
+	t.Helper()
+	server := httptest.NewServer(h)
+	t.Cleanup(server.Close)
+	s, _ := storagev1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	return storagev1.NewObjectsService(s)
+}
+
+func TestBucketObjectObserve(t *testing.T) {
+	type want struct {
+		mg  resource.Managed
+		obs managed.ExternalObservation
+		err error
+	}
+
+	observation := v1alpha1.BucketObjectObservation{
+		Generation: 1,
+		MD5Hash:    bucketobject.MD5Hash(testObjectContent),
+		Size:       uint64(len(testObjectContent)),
+	}
+
+	cases := map[string]struct {
+		o          *storagev1.Object
+		bucketGone bool
+		mg         resource.Managed
+		want       want
+	}{
+		"NotBucketObject": {
+			mg: &strange{},
+			want: want{
+				mg:  &strange{},
+				err: errors.New(errNotBucketObject),
+			},
+		},
+		"NotFound": {
+			mg: boObj(),
+			want: want{
+				mg:  boObj(),
+				obs: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"BucketGone": {
+			bucketGone: true,
+			mg:         boObj(),
+			want: want{
+				mg:  boObj(),
+				obs: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"BucketNotResolved": {
+			mg: boObj(func(o *v1alpha1.BucketObject) { o.Spec.ForProvider.Bucket = nil }),
+			want: want{
+				mg:  boObj(func(o *v1alpha1.BucketObject) { o.Spec.ForProvider.Bucket = nil }),
+				obs: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			o:  boObserved(),
+			mg: boObj(),
+			want: want{
+				mg:  boObj(boWithObservation(observation), boWithConditions(xpv1.Available())),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"ContentChanged": {
+			o:  boObserved(),
+			mg: boObj(boWithContent(`{"greeting": "hi"}`)),
+			want: want{
+				mg:  boObj(boWithContent(`{"greeting": "hi"}`), boWithObservation(observation), boWithConditions(xpv1.Available())),
+				obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var calls []string
+			e := bucketObjectExternal{
+				kube:    &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				objects: newBucketObjects(t, bucketObjectHandler(tc.o, tc.bucketGone, &calls, 0)),
+			}
+			obs, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, obs); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBucketObjectCreate(t *testing.T) {
+	type want struct {
+		calls []string
+		err   error
+	}
+
+	cases := map[string]struct {
+		status int
+		mg     resource.Managed
+		want   want
+	}{
+		"NotBucketObject": {
+			mg: &strange{},
+			want: want{
+				err: errors.New(errNotBucketObject),
+			},
+		},
+		"Uploaded": {
+			mg: boObj(),
+			want: want{
+				calls: []string{"POST /b/my-bucket/o"},
+			},
+		},
+		"UploadFailed": {
+			status: http.StatusForbidden,
+			mg:     boObj(),
+			want: want{
+				calls: []string{"POST /b/my-bucket/o"},
+				err:   errors.Wrap(gError(http.StatusForbidden, "{}\n"), errUploadBucketObject),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var calls []string
+			e := bucketObjectExternal{objects: newBucketObjects(t, bucketObjectHandler(nil, false, &calls, tc.status))}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("Create(...): -want calls, +got calls:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBucketObjectUpdate(t *testing.T) {
+	type want struct {
+		calls []string
+		err   error
+	}
+
+	cases := map[string]struct {
+		o    *storagev1.Object
+		mg   resource.Managed
+		want want
+	}{
+		"NotBucketObject": {
+			mg: &strange{},
+			want: want{
+				err: errors.New(errNotBucketObject),
+			},
+		},
+		"ContentChanged": {
+			o:  boObserved(),
+			mg: boObj(boWithContent(`{"greeting": "hi"}`)),
+			want: want{
+				calls: []string{"POST /b/my-bucket/o"},
+			},
+		},
+		"MetadataChanged": {
+			o:  boObserved(),
+			mg: boObj(boWithCacheControl("no-store")),
+			want: want{
+				calls: []string{"PATCH /b/my-bucket/o/config/app.json"},
+			},
+		},
+		"NotFound": {
+			mg: boObj(boWithCacheControl("no-store")),
+			want: want{
+				err: errors.Wrap(gError(http.StatusNotFound, "{}\n"), errGetBucketObject),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var calls []string
+			e := bucketObjectExternal{objects: newBucketObjects(t, bucketObjectHandler(tc.o, false, &calls, 0))}
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("Update(...): -want calls, +got calls:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBucketObjectDelete(t *testing.T) {
+	type want struct {
+		calls []string
+		err   error
+	}
+
+	cases := map[string]struct {
+		o          *storagev1.Object
+		bucketGone bool
+		status     int
+		mg         resource.Managed
+		want       want
+	}{
+		"NotBucketObject": {
+			mg: &strange{},
+			want: want{
+				err: errors.New(errNotBucketObject),
+			},
+		},
+		"Deleted": {
+			o:  boObserved(),
+			mg: boObj(),
+			want: want{
+				calls: []string{"DELETE /b/my-bucket/o/config/app.json"},
+			},
+		},
+		"AlreadyGone": {
+			mg: boObj(),
+			want: want{
+				calls: []string{"DELETE /b/my-bucket/o/config/app.json"},
+			},
+		},
+		"BucketGone": {
+			// The bucket was deleted along with its objects while the
+			// BucketObject was being deleted.
+			bucketGone: true,
+			mg:         boObj(),
+			want: want{
+				calls: []string{"DELETE /b/my-bucket/o/config/app.json"},
+			},
+		},
+		"BucketNotResolved": {
+			mg: boObj(func(o *v1alpha1.BucketObject) { o.Spec.ForProvider.Bucket = nil }),
+		},
+		"DeleteFailed": {
+			o:      boObserved(),
+			status: http.StatusForbidden,
+			mg:     boObj(),
+			want: want{
+				calls: []string{"DELETE /b/my-bucket/o/config/app.json"},
+				err:   errors.Wrap(gError(http.StatusForbidden, "{}\n"), errDeleteBucketObject),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var calls []string
+			e := bucketObjectExternal{objects: newBucketObjects(t, bucketObjectHandler(tc.o, tc.bucketGone, &calls, tc.status))}
+			err := e.Delete(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("Delete(...): -want calls, +got calls:\n%s", diff)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package storage
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"google.golang.org/api/storage/v1"
@@ -30,6 +31,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
@@ -47,8 +49,10 @@ const (
 	errSetPolicy       = "cannot set GCP BucketPolicy object via Storage API"
 )
 
-// SetupBucketPolicy adds a controller that reconciles BucketPolicys.
-func SetupBucketPolicy(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+// SetupBucketPolicy adds a controller that reconciles BucketPolicys. In
+// read-only mode the IAM policies of buckets are never changed; changes to them
+// are planned instead.
+func SetupBucketPolicy(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration, readOnly bool) error {
 	name := managed.ControllerName(v1alpha1.BucketPolicyGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -59,33 +63,46 @@ func SetupBucketPolicy(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimi
 		For(&v1alpha1.BucketPolicy{}).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.BucketPolicyGroupVersionKind),
-			managed.WithExternalConnecter(&bucketPolicyConnecter{client: mgr.GetClient()}),
+			managed.WithExternalConnecter(&bucketPolicyConnecter{client: mgr.GetClient(), readOnly: readOnly}),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
 			managed.WithLogger(l.WithValues("controller", name)),
 			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
 }
 
 type bucketPolicyConnecter struct {
-	client client.Client
+	client   client.Client
+	readOnly bool
 }
 
 // Connect sets up iam client using credentials from the provider
 func (c *bucketPolicyConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	_, opts, err := gcp.GetAuthInfo(ctx, c.client, mg)
+	_, opts, err := gcp.GetAuthInfoWithQuotaProject(ctx, c.client, mg)
 	if err != nil {
 		return nil, err
 	}
-	s, err := storage.NewService(ctx, opts)
+	caller, err := gcp.GetCredentialsMember(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := storage.NewService(ctx, opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
-	return &bucketPolicyExternal{kube: c.client, bucketpolicy: storage.NewBucketsService(s)}, nil
+	return &bucketPolicyExternal{kube: c.client, bucketpolicy: storage.NewBucketsService(s), caller: caller, readOnly: c.readOnly}, nil
 }
 
+// A bucketPolicyExternal manages the IAM policy of a bucket authoritatively,
+// i.e. it removes the bindings of the policy that its BucketPolicy does not
+// contain. It never removes the bindings of the caller, i.e. the member its
+// credentials authenticate as, if known. A read-only bucketPolicyExternal never
+// sets the policy, but plans the changes it would make to it instead.
 type bucketPolicyExternal struct {
 	kube         client.Client
 	bucketpolicy bucketpolicy.Client
+	caller       string
+	readOnly     bool
 }
 
 func (e *bucketPolicyExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -94,21 +111,39 @@ func (e *bucketPolicyExternal) Observe(ctx context.Context, mg resource.Managed)
 		return managed.ExternalObservation{}, errors.New(errNotBucketPolicy)
 	}
 
-	instance, err := e.bucketpolicy.GetIamPolicy(gcp.StringValue(cr.Spec.ForProvider.Bucket)).OptionsRequestedPolicyVersion(iamv1alpha1.PolicyVersion).Context(ctx).Do()
+	// NOTE: The policy is never removed in read-only mode, so it is reported
+	// as deleted once its removal was planned in order for the resource to be
+	// finalized.
+	if meta.WasDeleted(cr) && e.readOnly && cr.GetCondition(gcp.TypeDryRun).Reason == gcp.ReasonDeletionPlanned {
+		return managed.ExternalObservation{}, nil
+	}
+
+	instance, err := e.getPolicy(ctx, cr)
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetPolicy)
 	}
+	upToDate := false
+	if !bucketpolicy.IsEmpty(instance) {
+		if upToDate, err = bucketpolicy.IsUpToDate(&cr.Spec.ForProvider, instance); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errCheckUpToDate)
+		}
+	}
+	// A change that is planned rather than applied is planned here, and
+	// reported as up to date so that Create, whose conditions the managed
+	// reconciler discards, is not called.
+	if !upToDate && e.readOnly && !meta.WasDeleted(cr) {
+		gcp.SetDryRun(cr, gcp.ReasonChangePlanned, "would "+describePolicyDiff(cr.Spec.ForProvider, instance))
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
 	// Empty policy
 	if bucketpolicy.IsEmpty(instance) {
 		return managed.ExternalObservation{}, nil
 	}
-
-	if upToDate, err := bucketpolicy.IsUpToDate(&cr.Spec.ForProvider, instance); err != nil {
-		return managed.ExternalObservation{}, errors.Wrap(err, errCheckUpToDate)
-	} else if !upToDate {
-		return managed.ExternalObservation{ResourceExists: true}, nil
+	if !upToDate {
+		return managed.ExternalObservation{ResourceExists: true, Diff: describePolicyDiff(cr.Spec.ForProvider, instance)}, nil
 	}
 
+	gcp.ClearIAMPolicyError(cr)
 	cr.Status.SetConditions(xpv1.Available())
 	return managed.ExternalObservation{
 		ResourceExists:   true,
@@ -121,16 +156,15 @@ func (e *bucketPolicyExternal) Create(ctx context.Context, mg resource.Managed)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotBucketPolicy)
 	}
+	if err := validatePolicyBindings(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
 	cr.SetConditions(xpv1.Creating())
-	instance := &storage.Policy{}
-	bucketpolicy.GenerateBucketPolicyInstance(cr.Spec.ForProvider, instance)
-
-	if _, err := e.bucketpolicy.SetIamPolicy(gcp.StringValue(cr.Spec.ForProvider.Bucket), instance).
-		Context(ctx).Do(); err != nil {
-		return managed.ExternalCreation{}, errors.Wrap(err, errSetPolicy)
+	instance, err := e.getPolicy(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errGetPolicy)
 	}
-
-	return managed.ExternalCreation{}, nil
+	return managed.ExternalCreation{}, e.setPolicy(ctx, cr, instance)
 }
 
 func (e *bucketPolicyExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
@@ -138,7 +172,10 @@ func (e *bucketPolicyExternal) Update(ctx context.Context, mg resource.Managed)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotBucketPolicy)
 	}
-	instance, err := e.bucketpolicy.GetIamPolicy(gcp.StringValue(cr.Spec.ForProvider.Bucket)).OptionsRequestedPolicyVersion(iamv1alpha1.PolicyVersion).Context(ctx).Do()
+	if err := validatePolicyBindings(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	instance, err := e.getPolicy(ctx, cr)
 	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, errGetPolicy)
 	}
@@ -150,24 +187,92 @@ func (e *bucketPolicyExternal) Update(ctx context.Context, mg resource.Managed)
 	if u {
 		return managed.ExternalUpdate{}, nil
 	}
+	return managed.ExternalUpdate{}, e.setPolicy(ctx, cr, instance)
+}
 
-	bucketpolicy.GenerateBucketPolicyInstance(cr.Spec.ForProvider, instance)
+func (e *bucketPolicyExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.BucketPolicy)
+	if !ok {
+		return errors.New(errNotBucketPolicy)
+	}
+	if e.readOnly {
+		plan := "would unbind all members"
+		if e.caller != "" {
+			plan += " but " + e.caller
+		}
+		gcp.SetDryRun(cr, gcp.ReasonDeletionPlanned, plan)
+		return nil
+	}
+	instance, err := e.getPolicy(ctx, cr)
+	if err != nil {
+		return errors.Wrap(resource.Ignore(gcp.IsErrorNotFound, err), errGetPolicy)
+	}
+	// The bindings of the caller are kept so that it can still manage the
+	// policy of the bucket afterwards.
+	bucketpolicy.RetainMember(instance, e.caller)
 	if _, err := e.bucketpolicy.SetIamPolicy(gcp.StringValue(cr.Spec.ForProvider.Bucket), instance).
 		Context(ctx).Do(); err != nil {
-		return managed.ExternalUpdate{}, errors.Wrap(err, errSetPolicy)
+		gcp.SetIAMPolicyError(cr, "bucket", err)
+		return errors.Wrap(err, setPolicyError(err))
 	}
+	return nil
+}
 
-	return managed.ExternalUpdate{}, nil
+func (e *bucketPolicyExternal) getPolicy(ctx context.Context, cr *v1alpha1.BucketPolicy) (*storage.Policy, error) {
+	return e.bucketpolicy.GetIamPolicy(gcp.StringValue(cr.Spec.ForProvider.Bucket)).OptionsRequestedPolicyVersion(iamv1alpha1.PolicyVersion).Context(ctx).Do()
 }
 
-func (e *bucketPolicyExternal) Delete(ctx context.Context, mg resource.Managed) error {
-	cr, ok := mg.(*v1alpha1.BucketPolicy)
-	if !ok {
-		return errors.New(errNotBucketPolicy)
+// setPolicy replaces the bindings of the supplied observed policy with those
+// of the supplied BucketPolicy and sets it. The policy is set along with the
+// etag it was read with, so setting it fails rather than overwriting a
+// concurrent change to it.
+func (e *bucketPolicyExternal) setPolicy(ctx context.Context, cr *v1alpha1.BucketPolicy, observed *storage.Policy) error {
+	desired := &storage.Policy{Etag: observed.Etag}
+	bucketpolicy.GenerateBucketPolicyInstance(cr.Spec.ForProvider, desired)
+	if err := bucketpolicy.ValidatePolicySize(desired); err != nil {
+		return err
 	}
-	if _, err := e.bucketpolicy.SetIamPolicy(gcp.StringValue(cr.Spec.ForProvider.Bucket), &storage.Policy{}).
+	if err := bucketpolicy.ValidateRetainsMember(desired, observed, e.caller); err != nil {
+		return err
+	}
+	if e.readOnly {
+		gcp.SetDryRun(cr, gcp.ReasonChangePlanned, "would "+describePolicyDiff(cr.Spec.ForProvider, observed))
+		return nil
+	}
+	if _, err := e.bucketpolicy.SetIamPolicy(gcp.StringValue(cr.Spec.ForProvider.Bucket), desired).
 		Context(ctx).Do(); err != nil {
-		return errors.Wrap(err, errSetPolicy)
+		gcp.SetIAMPolicyError(cr, "bucket", err)
+		return errors.Wrap(err, setPolicyError(err))
+	}
+	gcp.ClearIAMPolicyError(cr)
+	return nil
+}
+
+// describePolicyDiff returns a human readable description of the members the
+// supplied parameters would bind to and unbind from roles of the supplied
+// observed policy.
+func describePolicyDiff(in v1alpha1.BucketPolicyParameters, observed *storage.Policy) string {
+	desired := &storage.Policy{}
+	bucketpolicy.GenerateBucketPolicyInstance(in, desired)
+	bound, unbound := bucketpolicy.DiffMembers(desired, observed)
+	var d []string
+	if len(bound) > 0 {
+		d = append(d, "bind "+strings.Join(bound, ", "))
+	}
+	if len(unbound) > 0 {
+		d = append(d, "unbind "+strings.Join(unbound, ", "))
+	}
+	return strings.Join(d, "; ")
+}
+
+func validatePolicyBindings(in v1alpha1.BucketPolicyParameters) error {
+	for _, b := range in.Policy.Bindings {
+		if err := bucketpolicy.ValidateMembers(b.Members...); err != nil {
+			return err
+		}
+		if err := bucketpolicy.ValidateRole(b.Role); err != nil {
+			return err
+		}
 	}
 	return nil
 }
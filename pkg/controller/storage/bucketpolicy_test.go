@@ -15,16 +15,20 @@ import (
 	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 	storagev1 "google.golang.org/api/storage/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	iamv1alpha1 "github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
 	"github.com/crossplane/provider-gcp/apis/storage/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
 	"github.com/crossplane/provider-gcp/pkg/clients/bucketpolicy"
+	"github.com/crossplane/provider-gcp/pkg/clients/bucketpolicy/fake"
 )
 
 const (
@@ -50,6 +54,17 @@ func gError(code int, message string) error {
 	})
 }
 
+// iamPolicyFailed returns the condition set once setting the IAM policy of a
+// bucket failed with the supplied error, which is not a well known one.
+func iamPolicyFailed(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:    gcp.TypeIAMPolicyError,
+		Status:  corev1.ConditionTrue,
+		Reason:  gcp.ReasonSetIAMPolicyError,
+		Message: "cannot set the IAM policy of the bucket: " + err.Error(),
+	}
+}
+
 type bpValueModifier func(ring *v1alpha1.BucketPolicy)
 
 func bpWithName(s string) bpValueModifier {
@@ -188,6 +203,7 @@ func TestBucketPolicyObserve(t *testing.T) {
 					bpWithName(bpMetadataName)),
 				observation: managed.ExternalObservation{
 					ResourceExists: true,
+					Diff:           "bind role " + testRole + " of member " + testMember + "; unbind role " + testRole + " of member some-other-member",
 				},
 			},
 		},
@@ -293,6 +309,11 @@ func TestBucketPolicyCreate(t *testing.T) {
 		"CreateSucceeded": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				defer r.Body.Close()
+				if r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+					return
+				}
 				if diff := cmp.Diff(http.MethodPut, r.Method); diff != "" {
 					t.Errorf("r: -want, +got:\n%s", diff)
 				}
@@ -340,6 +361,11 @@ func TestBucketPolicyCreate(t *testing.T) {
 		},
 		"CreateFailed": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+					return
+				}
 				w.WriteHeader(http.StatusInternalServerError)
 				_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
 			}),
@@ -353,7 +379,8 @@ func TestBucketPolicyCreate(t *testing.T) {
 				mg: BucketPolicy(
 					bpWithName(bpMetadataName),
 					bpWithExternalNameAnnotation(bpMetadataName),
-					bpWithCondition(xpv1.Creating())),
+					bpWithCondition(xpv1.Creating()),
+					bpWithCondition(iamPolicyFailed(gError(http.StatusInternalServerError, "{}\n")))),
 				err: errors.Wrap(gError(http.StatusInternalServerError, "{}\n"), errSetPolicy),
 			},
 		},
@@ -448,8 +475,8 @@ func TestBucketPolicyUpdate(t *testing.T) {
 								Role:    testRole,
 							},
 							{
-								Members: []string{"another-member"},
-								Role:    "another-role",
+								Members: []string{"group:another-member@example.com"},
+								Role:    "roles/crossplane.anotherTester",
 							},
 						},
 					}
@@ -470,8 +497,8 @@ func TestBucketPolicyUpdate(t *testing.T) {
 					bpWithExternalNameAnnotation(bpMetadataName),
 					bpWithCondition(xpv1.Available()),
 					bpWithBinding(&iamv1alpha1.Binding{
-						Members: []string{"another-member"},
-						Role:    "another-role",
+						Members: []string{"group:another-member@example.com"},
+						Role:    "roles/crossplane.anotherTester",
 					})),
 			},
 			want: want{
@@ -480,8 +507,8 @@ func TestBucketPolicyUpdate(t *testing.T) {
 					bpWithExternalNameAnnotation(bpMetadataName),
 					bpWithCondition(xpv1.Available()),
 					bpWithBinding(&iamv1alpha1.Binding{
-						Members: []string{"another-member"},
-						Role:    "another-role",
+						Members: []string{"group:another-member@example.com"},
+						Role:    "roles/crossplane.anotherTester",
 					})),
 			},
 		},
@@ -561,8 +588,8 @@ func TestBucketPolicyUpdate(t *testing.T) {
 					bpWithExternalNameAnnotation(bpMetadataName),
 					bpWithCondition(xpv1.Available()),
 					bpWithBinding(&iamv1alpha1.Binding{
-						Members: []string{"another-member"},
-						Role:    "another-role",
+						Members: []string{"group:another-member@example.com"},
+						Role:    "roles/crossplane.anotherTester",
 					})),
 			},
 			want: want{
@@ -571,9 +598,10 @@ func TestBucketPolicyUpdate(t *testing.T) {
 					bpWithExternalNameAnnotation(bpMetadataName),
 					bpWithCondition(xpv1.Available()),
 					bpWithBinding(&iamv1alpha1.Binding{
-						Members: []string{"another-member"},
-						Role:    "another-role",
-					})),
+						Members: []string{"group:another-member@example.com"},
+						Role:    "roles/crossplane.anotherTester",
+					}),
+					bpWithCondition(iamPolicyFailed(gError(http.StatusInternalServerError, "{}\n")))),
 				err: errors.Wrap(gError(http.StatusInternalServerError, "{}\n"), errSetPolicy),
 			},
 		},
@@ -609,6 +637,94 @@ func TestBucketPolicyUpdate(t *testing.T) {
 	}
 }
 
+func TestBucketPolicyAuthoritative(t *testing.T) {
+	etag := "BwWKmjvelug="
+	caller := "serviceAccount:crossplane@my-project.iam.gserviceaccount.com"
+	bound := &storagev1.PolicyBindings{Role: testRole, Members: []string{testMember}}
+	other := &storagev1.PolicyBindings{Role: "roles/storage.objectViewer", Members: []string{"user:jane@example.com"}}
+	admin := &storagev1.PolicyBindings{Role: "roles/storage.admin", Members: []string{caller}}
+
+	type want struct {
+		set *storagev1.Policy
+		err error
+	}
+	cases := map[string]struct {
+		reason   string
+		observed *storagev1.Policy
+		mg       *v1alpha1.BucketPolicy
+		caller   string
+		want     want
+	}{
+		"AddsBinding": {
+			reason:   "A binding of the BucketPolicy that the bucket policy lacks should be added.",
+			observed: &storagev1.Policy{Etag: etag, Bindings: []*storagev1.PolicyBindings{bound}},
+			mg:       BucketPolicy(bpWithBinding(&iamv1alpha1.Binding{Role: other.Role, Members: other.Members})),
+			want: want{
+				set: &storagev1.Policy{Etag: etag, Bindings: []*storagev1.PolicyBindings{bound, other}},
+			},
+		},
+		"RemovesBinding": {
+			reason:   "A binding of the bucket policy that the BucketPolicy lacks should be removed.",
+			observed: &storagev1.Policy{Etag: etag, Bindings: []*storagev1.PolicyBindings{bound, other}},
+			mg:       BucketPolicy(),
+			want: want{
+				set: &storagev1.Policy{Etag: etag, Bindings: []*storagev1.PolicyBindings{bound}},
+			},
+		},
+		"NoOp": {
+			reason:   "The bucket policy should not be set if it contains exactly the bindings of the BucketPolicy.",
+			observed: &storagev1.Policy{Etag: etag, Bindings: []*storagev1.PolicyBindings{bound}},
+			mg:       BucketPolicy(),
+		},
+		"RetainsCaller": {
+			reason:   "The bindings of the member the provider authenticates as should never be removed.",
+			observed: &storagev1.Policy{Etag: etag, Bindings: []*storagev1.PolicyBindings{bound, admin}},
+			mg:       BucketPolicy(),
+			caller:   caller,
+			want: want{
+				err: bucketpolicy.ValidateRetainsMember(
+					&storagev1.Policy{Bindings: []*storagev1.PolicyBindings{bound}},
+					&storagev1.Policy{Bindings: []*storagev1.PolicyBindings{bound, admin}}, caller),
+			},
+		},
+		"CallerInPolicy": {
+			reason:   "The bindings of the member the provider authenticates as may be managed by the BucketPolicy.",
+			observed: &storagev1.Policy{Etag: etag, Bindings: []*storagev1.PolicyBindings{bound, admin, other}},
+			mg:       BucketPolicy(bpWithBinding(&iamv1alpha1.Binding{Role: admin.Role, Members: admin.Members})),
+			caller:   caller,
+			want: want{
+				set: &storagev1.Policy{Etag: etag, Bindings: []*storagev1.PolicyBindings{bound, admin}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var set *storagev1.Policy
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if r.Method == http.MethodPut {
+					set = &storagev1.Policy{}
+					_ = json.NewDecoder(r.Body).Decode(set)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(tc.observed)
+			}))
+			defer server.Close()
+			s, _ := storagev1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &bucketPolicyExternal{bucketpolicy: storagev1.NewBucketsService(s), caller: tc.caller}
+
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.set, set, cmpopts.IgnoreFields(storagev1.Policy{}, "Version")); diff != "" {
+				t.Errorf("\n%s\nSetIamPolicy(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestBucketPolicyDelete(t *testing.T) {
 	type args struct {
 		ctx context.Context
@@ -637,6 +753,11 @@ func TestBucketPolicyDelete(t *testing.T) {
 		"DeleteSucceeded": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				defer r.Body.Close()
+				if r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+					return
+				}
 				if diff := cmp.Diff(http.MethodPut, r.Method); diff != "" {
 					t.Errorf("r: -want, +got:\n%s", diff)
 				}
@@ -676,6 +797,11 @@ func TestBucketPolicyDelete(t *testing.T) {
 		},
 		"CreateFailed": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+					return
+				}
 				w.WriteHeader(http.StatusInternalServerError)
 				_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
 			}),
@@ -688,7 +814,8 @@ func TestBucketPolicyDelete(t *testing.T) {
 			want: want{
 				mg: BucketPolicy(
 					bpWithName(bpMetadataName),
-					bpWithExternalNameAnnotation(bpMetadataName)),
+					bpWithExternalNameAnnotation(bpMetadataName),
+					bpWithCondition(iamPolicyFailed(gError(http.StatusInternalServerError, "{}\n")))),
 				err: errors.Wrap(gError(http.StatusInternalServerError, "{}\n"), errSetPolicy),
 			},
 		},
@@ -722,3 +849,127 @@ func TestBucketPolicyDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestBucketPolicyDeleteRetainsCaller(t *testing.T) {
+	caller := "serviceAccount:crossplane@my-project.iam.gserviceaccount.com"
+	observed := &storagev1.Policy{Etag: "BwWKmjvelug=", Bindings: []*storagev1.PolicyBindings{
+		{Role: testRole, Members: []string{testMember}},
+		{Role: "roles/storage.admin", Members: []string{testMember, caller}},
+	}}
+	want := &storagev1.Policy{Etag: "BwWKmjvelug=", Bindings: []*storagev1.PolicyBindings{
+		{Role: "roles/storage.admin", Members: []string{caller}},
+	}}
+
+	var set *storagev1.Policy
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		if r.Method == http.MethodPut {
+			set = &storagev1.Policy{}
+			_ = json.NewDecoder(r.Body).Decode(set)
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(observed)
+	}))
+	defer server.Close()
+	s, _ := storagev1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	e := &bucketPolicyExternal{bucketpolicy: storagev1.NewBucketsService(s), caller: caller}
+
+	if err := e.Delete(context.Background(), BucketPolicy()); err != nil {
+		t.Fatalf("Delete(...): unexpected error %s", err)
+	}
+	if diff := cmp.Diff(want, set); diff != "" {
+		t.Errorf("SetIamPolicy(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestBucketPolicyReadOnly(t *testing.T) {
+	bound := &storagev1.Policy{
+		Bindings: []*storagev1.PolicyBindings{{Role: testRole, Members: []string{testMember}}},
+	}
+	type want struct {
+		calls  map[string]int
+		reason xpv1.ConditionReason
+	}
+	cases := map[string]struct {
+		reason string
+		policy *storagev1.Policy
+		apply  func(e *bucketPolicyExternal, mg resource.Managed) error
+		want   want
+	}{
+		"ObservePlanned": {
+			reason: "Observe should plan rather than make the policy, and report it as up to date, in read-only mode.",
+			policy: &storagev1.Policy{},
+			apply: func(e *bucketPolicyExternal, mg resource.Managed) error {
+				o, err := e.Observe(context.Background(), mg)
+				if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, o); diff != "" {
+					t.Errorf("Observe(...): -want, +got:\n%s", diff)
+				}
+				return err
+			},
+			want: want{calls: map[string]int{http.MethodGet: 1}, reason: gcp.ReasonChangePlanned},
+		},
+		"CreatePlanned": {
+			reason: "Create should plan rather than set the policy in read-only mode.",
+			policy: &storagev1.Policy{},
+			apply: func(e *bucketPolicyExternal, mg resource.Managed) error {
+				_, err := e.Create(context.Background(), mg)
+				return err
+			},
+			want: want{calls: map[string]int{http.MethodGet: 1}, reason: gcp.ReasonChangePlanned},
+		},
+		"UpdatePlanned": {
+			reason: "Update should plan rather than set the policy in read-only mode.",
+			policy: &storagev1.Policy{
+				Bindings: []*storagev1.PolicyBindings{{Role: testRole, Members: []string{"user:jane@example.com"}}},
+			},
+			apply: func(e *bucketPolicyExternal, mg resource.Managed) error {
+				_, err := e.Update(context.Background(), mg)
+				return err
+			},
+			want: want{calls: map[string]int{http.MethodGet: 1}, reason: gcp.ReasonChangePlanned},
+		},
+		"DeletePlanned": {
+			reason: "Delete should plan rather than remove the policy in read-only mode.",
+			policy: bound,
+			apply: func(e *bucketPolicyExternal, mg resource.Managed) error {
+				return e.Delete(context.Background(), mg)
+			},
+			want: want{calls: map[string]int{}, reason: gcp.ReasonDeletionPlanned},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			store := fake.NewPolicyStore(map[string]*storagev1.Policy{testBucketName: tc.policy})
+			defer store.Close()
+			buckets, err := store.Client(context.Background())
+			if err != nil {
+				t.Fatalf("Client(...): %s", err)
+			}
+			e := &bucketPolicyExternal{bucketpolicy: buckets, readOnly: true}
+			mg := BucketPolicy()
+			if err := tc.apply(e, mg); err != nil {
+				t.Errorf("\n%s\nunexpected error %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.calls, store.Calls()); diff != "" {
+				t.Errorf("\n%s\n-want calls to the GCP API, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.reason, mg.GetCondition(gcp.TypeDryRun).Reason); diff != "" {
+				t.Errorf("\n%s\n-want DryRun reason, +got:\n%s", tc.reason, diff)
+			}
+
+			// A deleted policy whose removal was planned is finalized.
+			if tc.want.reason != gcp.ReasonDeletionPlanned {
+				return
+			}
+			now := metav1.Now()
+			mg.SetDeletionTimestamp(&now)
+			o, err := e.Observe(context.Background(), mg)
+			if err != nil {
+				t.Fatalf("Observe(...): %s", err)
+			}
+			if o.ResourceExists {
+				t.Errorf("Observe(...): want a policy whose removal was planned in read-only mode to be reported as deleted")
+			}
+		})
+	}
+}
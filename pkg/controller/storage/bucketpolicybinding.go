@@ -0,0 +1,231 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/api/storage/v1"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	iamv1alpha1 "github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
+	"github.com/crossplane/provider-gcp/apis/storage/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/bucketpolicy"
+)
+
+const (
+	errNotBucketPolicyBinding = "managed resource is not a GCP BucketPolicyBinding"
+)
+
+// SetupBucketPolicyBinding adds a controller that reconciles BucketPolicyBindings.
+// In read-only mode the IAM policies of buckets are never changed; changes to
+// them are planned instead.
+func SetupBucketPolicyBinding(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration, readOnly bool) error {
+	name := managed.ControllerName(v1alpha1.BucketPolicyBindingGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+		}).
+		For(&v1alpha1.BucketPolicyBinding{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.BucketPolicyBindingGroupVersionKind),
+			managed.WithExternalConnecter(&bucketPolicyBindingConnecter{client: mgr.GetClient(), readOnly: readOnly}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithTimeout(timeout),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type bucketPolicyBindingConnecter struct {
+	client   client.Client
+	readOnly bool
+}
+
+// Connect sets up iam client using credentials from the provider
+func (c *bucketPolicyBindingConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	_, opts, err := gcp.GetAuthInfoWithQuotaProject(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := storage.NewService(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return &bucketPolicyBindingExternal{kube: c.client, bucketpolicy: storage.NewBucketsService(s), readOnly: c.readOnly, metrics: defaultIAMMetrics}, nil
+}
+
+// A bucketPolicyBindingExternal binds a role to members in the IAM policy of a
+// bucket. A read-only bucketPolicyBindingExternal never sets the policy, but
+// plans the changes it would make to it instead.
+type bucketPolicyBindingExternal struct {
+	kube         client.Client
+	bucketpolicy bucketpolicy.Client
+	readOnly     bool
+	metrics      *iamMetrics
+}
+
+func (e *bucketPolicyBindingExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.BucketPolicyBinding)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotBucketPolicyBinding)
+	}
+
+	// NOTE: The binding is never removed in read-only mode, so it is reported
+	// as deleted once its removal was planned in order for the resource to be
+	// finalized.
+	if meta.WasDeleted(cr) && e.readOnly && cr.GetCondition(gcp.TypeDryRun).Reason == gcp.ReasonDeletionPlanned {
+		return managed.ExternalObservation{}, nil
+	}
+
+	instance, err := e.getPolicy(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetPolicy)
+	}
+
+	changed := bucketpolicy.BindRoleToMembers(cr.Spec.ForProvider, instance)
+	if !changed {
+		gcp.ClearIAMPolicyError(cr)
+		cr.Status.SetConditions(xpv1.Available())
+		e.metrics.noop(v1alpha1.BucketPolicyBindingKind)
+		return managed.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: true,
+		}, nil
+	}
+	// A binding that is planned rather than applied is planned here, and
+	// reported as up to date so that Create, whose conditions the managed
+	// reconciler discards, is not called.
+	if e.readOnly && !meta.WasDeleted(cr) {
+		gcp.SetDryRun(cr, gcp.ReasonChangePlanned, "would bind "+describeBinding(cr.Spec.ForProvider))
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+
+	return managed.ExternalObservation{}, nil
+}
+
+func (e *bucketPolicyBindingExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.BucketPolicyBinding)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotBucketPolicyBinding)
+	}
+	if err := bucketpolicy.ValidateMembers(cr.Spec.ForProvider.Members...); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if err := bucketpolicy.ValidateRole(cr.Spec.ForProvider.Role); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	instance, err := e.getPolicy(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errGetPolicy)
+	}
+
+	changed := bucketpolicy.BindRoleToMembers(cr.Spec.ForProvider, instance)
+	if !changed {
+		return managed.ExternalCreation{}, nil
+	}
+	if err := bucketpolicy.ValidatePolicySize(instance); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if e.readOnly {
+		gcp.SetDryRun(cr, gcp.ReasonChangePlanned, "would bind "+describeBinding(cr.Spec.ForProvider))
+		return managed.ExternalCreation{}, nil
+	}
+
+	if err := e.setPolicy(ctx, cr, instance); err != nil {
+		gcp.SetIAMPolicyError(cr, "bucket", err)
+		return managed.ExternalCreation{}, errors.Wrap(err, errSetPolicy)
+	}
+	gcp.ClearIAMPolicyError(cr)
+	e.metrics.added(v1alpha1.BucketPolicyBindingKind)
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *bucketPolicyBindingExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	_, err := e.Create(ctx, mg)
+	return managed.ExternalUpdate{}, err
+}
+
+func (e *bucketPolicyBindingExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.BucketPolicyBinding)
+	if !ok {
+		return errors.New(errNotBucketPolicyBinding)
+	}
+	instance, err := e.getPolicy(ctx, cr)
+	if err != nil {
+		return errors.Wrap(err, errGetPolicy)
+	}
+
+	changed := bucketpolicy.UnbindRoleFromMembers(cr.Spec.ForProvider, instance)
+	if !changed {
+		return nil
+	}
+	if e.readOnly {
+		gcp.SetDryRun(cr, gcp.ReasonDeletionPlanned, "would unbind "+describeBinding(cr.Spec.ForProvider))
+		return nil
+	}
+	if err := e.setPolicy(ctx, cr, instance); err != nil {
+		gcp.SetIAMPolicyError(cr, "bucket", err)
+		return errors.Wrap(err, errSetPolicy)
+	}
+	e.metrics.removed(v1alpha1.BucketPolicyBindingKind)
+
+	return nil
+}
+
+// describeBinding returns a human readable description of the binding of the
+// supplied parameters.
+func describeBinding(in v1alpha1.BucketPolicyBindingParameters) string {
+	return "role " + in.Role + " of members " + strings.Join(in.Members, ", ")
+}
+
+// getPolicy returns the IAM policy of the bucket of the supplied
+// BucketPolicyBinding.
+func (e *bucketPolicyBindingExternal) getPolicy(ctx context.Context, cr *v1alpha1.BucketPolicyBinding) (p *storage.Policy, err error) {
+	err = e.metrics.call(v1alpha1.BucketPolicyBindingKind, methodGetIAMPolicy, func() error {
+		p, err = e.bucketpolicy.GetIamPolicy(gcp.StringValue(cr.Spec.ForProvider.Bucket)).OptionsRequestedPolicyVersion(iamv1alpha1.PolicyVersion).Context(ctx).Do()
+		return err
+	})
+	return p, err
+}
+
+// setPolicy sets the IAM policy of the bucket of the supplied
+// BucketPolicyBinding.
+func (e *bucketPolicyBindingExternal) setPolicy(ctx context.Context, cr *v1alpha1.BucketPolicyBinding, p *storage.Policy) error {
+	return e.metrics.call(v1alpha1.BucketPolicyBindingKind, methodSetIAMPolicy, func() error {
+		_, err := e.bucketpolicy.SetIamPolicy(gcp.StringValue(cr.Spec.ForProvider.Bucket), p).Context(ctx).Do()
+		return err
+	})
+}
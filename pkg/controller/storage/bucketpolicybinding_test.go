@@ -0,0 +1,576 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/api/option"
+	storagev1 "google.golang.org/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-gcp/apis/storage/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+	"github.com/crossplane/provider-gcp/pkg/clients/bucketpolicy"
+	"github.com/crossplane/provider-gcp/pkg/clients/bucketpolicy/fake"
+)
+
+const (
+	bpbMetadataName = "test-bucket-policy-binding"
+)
+
+type bpbValueModifier func(ring *v1alpha1.BucketPolicyBinding)
+
+func bpbWithName(s string) bpbValueModifier {
+	return func(i *v1alpha1.BucketPolicyBinding) { i.Name = s }
+}
+
+func bpbWithCondition(condition xpv1.Condition) bpbValueModifier {
+	return func(i *v1alpha1.BucketPolicyBinding) { i.SetConditions(condition) }
+}
+
+func BucketPolicyBinding(im ...bpbValueModifier) *v1alpha1.BucketPolicyBinding {
+	bpb := &v1alpha1.BucketPolicyBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       bpbMetadataName,
+			Finalizers: []string{},
+		},
+		Spec: v1alpha1.BucketPolicyBindingSpec{
+			ForProvider: v1alpha1.BucketPolicyBindingParameters{
+				Bucket:  &testBucketName,
+				Role:    testRole,
+				Members: []string{testMember, "group:another-member@example.com"},
+			},
+		},
+	}
+
+	for _, m := range im {
+		m(bpb)
+	}
+
+	return bpb
+}
+
+func TestBucketPolicyBindingObserve(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+	type want struct {
+		mg          resource.Managed
+		observation managed.ExternalObservation
+		err         error
+	}
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"NotBucketPolicyBinding": {
+			args: args{
+				ctx: context.Background(),
+				mg:  &strange{},
+			},
+			want: want{
+				mg:  &strange{},
+				err: errors.New(errNotBucketPolicyBinding),
+			},
+		},
+		"FailedToObserve": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+				_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyBinding(),
+			},
+			want: want{
+				mg:  BucketPolicyBinding(),
+				err: errors.Wrap(gError(http.StatusInternalServerError, "{}\n"), errGetPolicy),
+			},
+		},
+		"ObservedPolicyNeedsUpdate": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				p := &storagev1.Policy{
+					Bindings: []*storagev1.PolicyBindings{
+						{
+							Members: []string{testMember},
+							Role:    testRole,
+						},
+					},
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(p)
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyBinding(),
+			},
+			want: want{
+				mg:          BucketPolicyBinding(),
+				observation: managed.ExternalObservation{},
+			},
+		},
+		"ObservedPolicyUpToDate": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if diff := cmp.Diff(http.MethodGet, r.Method); diff != "" {
+					t.Errorf("r: -want, +got:\n%s", diff)
+				}
+				// https://cloud.google.com/storage/docs/json_api/v1/buckets/getIamPolicy
+				expectedEp := fmt.Sprintf("/b/%s/iam", testBucketName)
+				if !strings.EqualFold(r.URL.Path, expectedEp) {
+					t.Errorf("requested URL.Path to get policy should end with: %s, got %s instead",
+						expectedEp, r.URL.Path)
+				}
+				p := &storagev1.Policy{
+					Bindings: []*storagev1.PolicyBindings{
+						{
+							Members: []string{"group:another-member@example.com", testMember},
+							Role:    testRole,
+						},
+						{
+							Members: []string{"yet-another-member"},
+							Role:    "another-role",
+						},
+					},
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(p)
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyBinding(),
+			},
+			want: want{
+				mg: BucketPolicyBinding(
+					bpbWithCondition(xpv1.Available())),
+				observation: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := storagev1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			buckets := storagev1.NewBucketsService(s)
+			e := &bucketPolicyBindingExternal{bucketpolicy: buckets}
+			obs, err := e.Observe(context.Background(), tc.args.mg)
+
+			if err != nil {
+				if tc.want.err != nil {
+					// we expected a different error than we got
+					if diff := cmp.Diff(tc.want.err.Error(), err.Error()); diff != "" {
+						t.Errorf("Observe(...): want error string != got error string:\n%s", diff)
+					}
+				} else {
+					t.Errorf("Observe(...): unexpected error %s", err)
+				}
+			} else {
+				if tc.want.err != nil {
+					t.Errorf("Observe(...) want error %s got nil", tc.want.err)
+				}
+			}
+
+			if diff := cmp.Diff(tc.want.observation, obs); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBucketPolicyBindingUpdate(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"NotBucketPolicyBinding": {
+			args: args{
+				ctx: context.Background(),
+				mg:  &strange{},
+			},
+			want: want{
+				mg:  &strange{},
+				err: errors.New(errNotBucketPolicyBinding),
+			},
+		},
+		"UpdateSucceeded": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var p *storagev1.Policy
+				defer r.Body.Close()
+				switch r.Method {
+				case http.MethodGet:
+					p = &storagev1.Policy{
+						Bindings: []*storagev1.PolicyBindings{
+							{
+								Members: []string{"stale-member"},
+								Role:    testRole,
+							},
+							{
+								Members: []string{"stale-member"},
+								Role:    "another-role",
+							},
+						},
+					}
+					w.WriteHeader(http.StatusOK)
+				case http.MethodPut:
+					i := &storagev1.Policy{}
+					b, err := ioutil.ReadAll(r.Body)
+					if diff := cmp.Diff(err, nil); diff != "" {
+						t.Errorf("r: -want, +got:\n%s", diff)
+					}
+					err = json.Unmarshal(b, i)
+					if diff := cmp.Diff(err, nil); diff != "" {
+						t.Errorf("r: -want, +got:\n%s", diff)
+					}
+					p = &storagev1.Policy{
+						Bindings: []*storagev1.PolicyBindings{
+							{
+								Members: []string{testMember, "group:another-member@example.com"},
+								Role:    testRole,
+							},
+							{
+								Members: []string{"stale-member"},
+								Role:    "another-role",
+							},
+						},
+					}
+					if !bucketpolicy.ArePoliciesSame(p, i) {
+						t.Errorf("policy in setIamPolicyRequest not equal to expected, diff: %s", cmp.Diff(p, i, cmpopts.IgnoreFields(storagev1.Policy{}, "Version")))
+					}
+					w.WriteHeader(http.StatusOK)
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+				}
+
+				_ = json.NewEncoder(w).Encode(p)
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyBinding(bpbWithName(bpbMetadataName)),
+			},
+			want: want{
+				mg: BucketPolicyBinding(bpbWithName(bpbMetadataName)),
+			},
+		},
+		"FailedToUpdate": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var p *storagev1.Policy
+				defer r.Body.Close()
+				switch r.Method {
+				case http.MethodGet:
+					p = &storagev1.Policy{}
+					w.WriteHeader(http.StatusOK)
+				case http.MethodPut:
+					p = &storagev1.Policy{}
+					w.WriteHeader(http.StatusInternalServerError)
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+				}
+
+				_ = json.NewEncoder(w).Encode(p)
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyBinding(bpbWithName(bpbMetadataName)),
+			},
+			want: want{
+				mg: BucketPolicyBinding(
+					bpbWithName(bpbMetadataName),
+					bpbWithCondition(iamPolicyFailed(gError(http.StatusInternalServerError, "{}\n")))),
+				err: errors.Wrap(gError(http.StatusInternalServerError, "{}\n"), errSetPolicy),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := storagev1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			buckets := storagev1.NewBucketsService(s)
+			e := &bucketPolicyBindingExternal{bucketpolicy: buckets}
+			_, err := e.Update(context.Background(), tc.args.mg)
+			if err != nil {
+				if tc.want.err != nil {
+					// we expected a different error than we got
+					if diff := cmp.Diff(tc.want.err.Error(), err.Error()); diff != "" {
+						t.Errorf("Update(...): want error string != got error string:\n%s", diff)
+					}
+				} else {
+					t.Errorf("Update(...): unexpected error %s", err)
+				}
+			} else {
+				if tc.want.err != nil {
+					t.Errorf("Update(...) want error %s got nil", tc.want.err)
+				}
+			}
+
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg); diff != "" {
+				t.Errorf("Update(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBucketPolicyBindingDelete(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+	type want struct {
+		mg  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		handler http.Handler
+		args    args
+		want    want
+	}{
+		"NotBucketPolicyBinding": {
+			args: args{
+				ctx: context.Background(),
+				mg:  &strange{},
+			},
+			want: want{
+				mg:  &strange{},
+				err: errors.New(errNotBucketPolicyBinding),
+			},
+		},
+		"DeleteSucceeded": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				switch r.Method {
+				case http.MethodGet:
+					i := &storagev1.Policy{
+						Bindings: []*storagev1.PolicyBindings{
+							{
+								Members: []string{testMember, "group:another-member@example.com"},
+								Role:    testRole,
+							},
+							{
+								Members: []string{testMember},
+								Role:    "another-role",
+							},
+						},
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(i)
+				case http.MethodPut:
+					i := &storagev1.Policy{}
+					b, err := ioutil.ReadAll(r.Body)
+					if diff := cmp.Diff(err, nil); diff != "" {
+						t.Errorf("r: -want, +got:\n%s", diff)
+					}
+					err = json.Unmarshal(b, i)
+					if diff := cmp.Diff(err, nil); diff != "" {
+						t.Errorf("r: -want, +got:\n%s", diff)
+					}
+					exp := &storagev1.Policy{
+						Bindings: []*storagev1.PolicyBindings{
+							{
+								Members: []string{testMember},
+								Role:    "another-role",
+							},
+						},
+					}
+					if !bucketpolicy.ArePoliciesSame(exp, i) {
+						t.Errorf("policy in setIamPolicyRequest not equal to expected, diff: %s", cmp.Diff(exp, i, cmpopts.IgnoreFields(storagev1.Policy{}, "Version")))
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(exp)
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+					_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+				}
+
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyBinding(bpbWithName(bpbMetadataName)),
+			},
+			want: want{
+				mg: BucketPolicyBinding(bpbWithName(bpbMetadataName)),
+			},
+		},
+		"AlreadyDeleted": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					p := &storagev1.Policy{
+						Bindings: []*storagev1.PolicyBindings{
+							{
+								Members: []string{"yet-another-member"},
+								Role:    testRole,
+							},
+						},
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(p)
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+					_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+				}
+
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyBinding(bpbWithName(bpbMetadataName)),
+			},
+			want: want{
+				mg: BucketPolicyBinding(bpbWithName(bpbMetadataName)),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := storagev1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			buckets := storagev1.NewBucketsService(s)
+			e := &bucketPolicyBindingExternal{bucketpolicy: buckets}
+			err := e.Delete(context.Background(), tc.args.mg)
+			if err != nil {
+				if tc.want.err != nil {
+					// we expected a different error than we got
+					if diff := cmp.Diff(tc.want.err.Error(), err.Error()); diff != "" {
+						t.Errorf("Delete(...): want error string != got error string:\n%s", diff)
+					}
+				} else {
+					t.Errorf("Delete(...): unexpected error %s", err)
+				}
+			} else {
+				if tc.want.err != nil {
+					t.Errorf("Delete(...) want error %s got nil", tc.want.err)
+				}
+			}
+
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg); diff != "" {
+				t.Errorf("Delete(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBucketPolicyBindingReadOnly(t *testing.T) {
+	other := "group:another-member@example.com"
+	type want struct {
+		calls  map[string]int
+		reason xpv1.ConditionReason
+	}
+	cases := map[string]struct {
+		reason string
+		policy *storagev1.Policy
+		apply  func(e *bucketPolicyBindingExternal, mg resource.Managed) error
+		want   want
+	}{
+		"ObservePlanned": {
+			reason: "Observe should plan rather than make the binding, and report it as up to date, in read-only mode.",
+			policy: &storagev1.Policy{},
+			apply: func(e *bucketPolicyBindingExternal, mg resource.Managed) error {
+				o, err := e.Observe(context.Background(), mg)
+				if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, o); diff != "" {
+					t.Errorf("Observe(...): -want, +got:\n%s", diff)
+				}
+				return err
+			},
+			want: want{calls: map[string]int{http.MethodGet: 1}, reason: gcp.ReasonChangePlanned},
+		},
+		"CreatePlanned": {
+			reason: "Create should plan rather than set the policy in read-only mode.",
+			policy: &storagev1.Policy{},
+			apply: func(e *bucketPolicyBindingExternal, mg resource.Managed) error {
+				_, err := e.Create(context.Background(), mg)
+				return err
+			},
+			want: want{calls: map[string]int{http.MethodGet: 1}, reason: gcp.ReasonChangePlanned},
+		},
+		"UpdatePlanned": {
+			reason: "Update should plan rather than set the policy in read-only mode.",
+			policy: &storagev1.Policy{
+				Bindings: []*storagev1.PolicyBindings{{Role: testRole, Members: []string{testMember}}},
+			},
+			apply: func(e *bucketPolicyBindingExternal, mg resource.Managed) error {
+				_, err := e.Update(context.Background(), mg)
+				return err
+			},
+			want: want{calls: map[string]int{http.MethodGet: 1}, reason: gcp.ReasonChangePlanned},
+		},
+		"DeletePlanned": {
+			reason: "Delete should plan rather than unbind the members in read-only mode.",
+			policy: &storagev1.Policy{
+				Bindings: []*storagev1.PolicyBindings{{Role: testRole, Members: []string{testMember, other}}},
+			},
+			apply: func(e *bucketPolicyBindingExternal, mg resource.Managed) error {
+				return e.Delete(context.Background(), mg)
+			},
+			want: want{calls: map[string]int{http.MethodGet: 1}, reason: gcp.ReasonDeletionPlanned},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			store := fake.NewPolicyStore(map[string]*storagev1.Policy{testBucketName: tc.policy})
+			defer store.Close()
+			buckets, err := store.Client(context.Background())
+			if err != nil {
+				t.Fatalf("Client(...): %s", err)
+			}
+			e := &bucketPolicyBindingExternal{bucketpolicy: buckets, readOnly: true}
+			mg := BucketPolicyBinding()
+			if err := tc.apply(e, mg); err != nil {
+				t.Errorf("\n%s\nunexpected error %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.calls, store.Calls()); diff != "" {
+				t.Errorf("\n%s\n-want calls to the GCP API, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.reason, mg.GetCondition(gcp.TypeDryRun).Reason); diff != "" {
+				t.Errorf("\n%s\n-want DryRun reason, +got:\n%s", tc.reason, diff)
+			}
+
+			// A deleted binding whose removal was planned is finalized.
+			if tc.want.reason != gcp.ReasonDeletionPlanned {
+				return
+			}
+			now := metav1.Now()
+			mg.SetDeletionTimestamp(&now)
+			o, err := e.Observe(context.Background(), mg)
+			if err != nil {
+				t.Fatalf("Observe(...): %s", err)
+			}
+			if o.ResourceExists {
+				t.Errorf("Observe(...): want a binding whose removal was planned in read-only mode to be reported as deleted")
+			}
+		})
+	}
+}
@@ -18,9 +18,14 @@ package storage
 
 import (
 	"context"
+	"strconv"
+	"strings"
 	"time"
 
+	"golang.org/x/time/rate"
+	"google.golang.org/api/iam/v1"
 	"google.golang.org/api/storage/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -30,6 +35,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
@@ -42,11 +48,65 @@ import (
 
 const (
 	errNotBucketPolicyMember = "managed resource is not a GCP BucketPolicyMember"
+	errPolicyChanged         = "GCP BucketPolicy object was changed concurrently and will be read again"
+	errAPIRateLimit          = "cannot wait for GCP API rate limit"
+	errLockPolicy            = "cannot wait for other changes to the GCP BucketPolicy object"
+	errNoMembers             = "neither a member nor members to bind the role to are set"
+	errManagedMember         = "cannot update managed BucketPolicyMember resource"
+	errFmtPublicAccess       = "refusing to bind %s, which would make the bucket public, without the %s annotation"
+	errFmtDomainNotAllowed   = "refusing to bind %s, whose domain is not allowed"
+	errNewIAMClient          = "cannot create new GCP IAM client"
+	errGetRole               = "cannot get GCP IAM custom role"
+	errFmtRoleNotFound       = "refusing to bind custom role %s, which does not exist"
 )
 
-// SetupBucketPolicyMember adds a controller that reconciles BucketPolicyMembers.
-func SetupBucketPolicyMember(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+const (
+	reasonDryRun          event.Reason = "DryRun"
+	reasonBound           event.Reason = "BoundRole"
+	reasonUnbound         event.Reason = "UnboundRole"
+	reasonCannotSetPolicy event.Reason = "CannotSetPolicy"
+	reasonRemovedExpired  event.Reason = "RemovedExpiredRole"
+	reasonNormalized      event.Reason = "NormalizedRole"
+)
+
+// BucketPolicyMemberOptions configure the controller that reconciles
+// BucketPolicyMembers.
+type BucketPolicyMemberOptions struct {
+	// APIRateLimiter limits the calls to the GCP API. Calls are not limited
+	// if it is nil.
+	APIRateLimiter *rate.Limiter
+
+	// PolicyTTL is how long the IAM policy of a bucket is shared by its
+	// BucketPolicyMembers once read.
+	PolicyTTL time.Duration
+
+	// BatchWindow is how long the changes of the BucketPolicyMembers of a
+	// bucket to its IAM policy are collected to be applied together.
+	BatchWindow time.Duration
+
+	// CallTimeout is how long a call to the GCP API may take before it is
+	// abandoned and retried.
+	CallTimeout time.Duration
+
+	// ErrorBackoffCap is the longest that failing observations are backed
+	// off. They are not backed off if it is not positive.
+	ErrorBackoffCap time.Duration
+
+	// ReadOnly plans changes to the IAM policies of buckets as if each
+	// BucketPolicyMember was in dry-run mode, rather than making them.
+	ReadOnly bool
+
+	// AllowedDomains are the domains whose members may be bound. Members of
+	// any domain may be bound if none are allowed.
+	AllowedDomains []string
+}
+
+// SetupBucketPolicyMember adds a controller that reconciles BucketPolicyMembers
+// with the supplied options.
+func SetupBucketPolicyMember(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll, timeout time.Duration, o BucketPolicyMemberOptions) error {
 	name := managed.ControllerName(v1alpha1.BucketPolicyMemberGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	log := l.WithValues("controller", name)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
@@ -56,56 +116,251 @@ func SetupBucketPolicyMember(mgr ctrl.Manager, l logging.Logger, rl workqueue.Ra
 		For(&v1alpha1.BucketPolicyMember{}).
 		Complete(managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.BucketPolicyMemberGroupVersionKind),
-			managed.WithExternalConnecter(&bucketPolicyMemberConnecter{client: mgr.GetClient()}),
+			managed.WithExternalConnecter(&bucketPolicyMemberConnecter{
+				client:         mgr.GetClient(),
+				record:         record,
+				log:            log,
+				api:            o.APIRateLimiter,
+				policies:       bucketpolicy.NewPolicyCache(o.PolicyTTL),
+				locks:          bucketpolicy.NewBucketLocks(),
+				batches:        newBatcher(o.BatchWindow),
+				callTimeout:    o.CallTimeout,
+				observeErrors:  newErrorBackoff(poll, o.ErrorBackoffCap),
+				readOnly:       o.ReadOnly,
+				allowedDomains: o.AllowedDomains,
+			}),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithInitializers(),
 			managed.WithPollInterval(poll),
-			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithTimeout(timeout),
+			managed.WithLogger(log),
+			managed.WithRecorder(record)))
 }
 
 type bucketPolicyMemberConnecter struct {
-	client client.Client
+	client         client.Client
+	record         event.Recorder
+	log            logging.Logger
+	api            *rate.Limiter
+	policies       *bucketpolicy.PolicyCache
+	locks          *bucketpolicy.BucketLocks
+	batches        *bucketpolicy.Batcher
+	callTimeout    time.Duration
+	observeErrors  *bucketpolicy.ErrorBackoff
+	readOnly       bool
+	allowedDomains []string
 }
 
 // Connect sets up iam client using credentials from the provider
 func (c *bucketPolicyMemberConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	_, opts, err := gcp.GetAuthInfo(ctx, c.client, mg)
+	cr, ok := mg.(*v1alpha1.BucketPolicyMember)
+	if !ok {
+		return nil, errors.New(errNotBucketPolicyMember)
+	}
+	_, opts, err := gcp.GetAuthInfoForProject(ctx, c.client, mg, gcp.StringValue(cr.Spec.ForProvider.Project))
 	if err != nil {
 		return nil, err
 	}
-	s, err := storage.NewService(ctx, opts)
+	s, err := storage.NewService(ctx, opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
-	return &bucketPolicyMemberExternal{kube: c.client, bucketpolicy: storage.NewBucketsService(s)}, nil
+	r, err := iam.NewService(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewIAMClient)
+	}
+	return &bucketPolicyMemberExternal{
+		kube:           c.client,
+		bucketpolicy:   storage.NewBucketsService(s),
+		roles:          r,
+		record:         c.record,
+		log:            c.log,
+		api:            c.api,
+		policies:       c.policies,
+		locks:          c.locks,
+		batches:        c.batches,
+		callTimeout:    c.callTimeout,
+		observeErrors:  c.observeErrors,
+		readOnly:       c.readOnly,
+		allowedDomains: c.allowedDomains,
+		backoff:        gcp.DefaultRetryBackoff,
+		conflicts:      gcp.DefaultConflictBackoff,
+		metrics:        defaultIAMMetrics,
+	}, nil
 }
 
 type bucketPolicyMemberExternal struct {
-	kube         client.Client
-	bucketpolicy bucketpolicy.Client
+	kube           client.Client
+	bucketpolicy   bucketpolicy.Client
+	roles          *iam.Service
+	record         event.Recorder
+	log            logging.Logger
+	api            *rate.Limiter
+	policies       *bucketpolicy.PolicyCache
+	locks          *bucketpolicy.BucketLocks
+	batches        *bucketpolicy.Batcher
+	callTimeout    time.Duration
+	observeErrors  *bucketpolicy.ErrorBackoff
+	readOnly       bool
+	allowedDomains []string
+	backoff        wait.Backoff
+	conflicts      wait.Backoff
+	metrics        *iamMetrics
 }
 
+// Observe the binding of the supplied BucketPolicyMember. Observations that
+// keep failing with the same error are backed off.
 func (e *bucketPolicyMemberExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
 	cr, ok := mg.(*v1alpha1.BucketPolicyMember)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotBucketPolicyMember)
 	}
+	var o managed.ExternalObservation
+	var refused error
+	err := e.observeErrors.Do(cr.GetName(), strconv.FormatInt(cr.GetGeneration(), 10), func() error {
+		var err error
+		o, err = e.observe(ctx, cr)
+		// A refused binding was observed, so its refusal is not backed off.
+		var gErr *guardErr
+		if errors.As(err, &gErr) {
+			refused, err = gErr.error, nil
+		}
+		return err
+	})
+	if err != nil {
+		return o, err
+	}
+	return o, refused
+}
+
+func (e *bucketPolicyMemberExternal) observe(ctx context.Context, cr *v1alpha1.BucketPolicyMember) (managed.ExternalObservation, error) {
+	log := e.logger(cr)
+
+	// The binding is reported as deleted once its removal was planned.
+	if meta.WasDeleted(cr) && e.planOnly(cr) && cr.GetCondition(gcp.TypeDryRun).Reason == gcp.ReasonDeletionPlanned {
+		log.Debug("Observed binding", "decision", "deletion planned")
+		return managed.ExternalObservation{}, nil
+	}
 
-	instance, err := e.bucketpolicy.GetIamPolicy(gcp.StringValue(cr.Spec.ForProvider.Bucket)).OptionsRequestedPolicyVersion(iamv1alpha1.PolicyVersion).Context(ctx).Do()
+	params := memberParameters(cr)
+	instance, err := e.getPolicy(ctx, cr, gcp.StringValue(params.Bucket), bucketpolicy.MemberPolicyVersion(params))
+	// A binding of a bucket that does not exist does not exist either.
+	if gcp.IsErrorNotFound(err) {
+		gcp.SetResourceNotFound(cr, "bucket", gcp.StringValue(params.Bucket))
+		cr.Status.SetConditions(xpv1.Unavailable())
+		cr.Status.AtProvider = v1alpha1.BucketPolicyMemberObservation{}
+		log.Debug("Observed binding", "decision", "bucket not found")
+		return managed.ExternalObservation{}, nil
+	}
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errGetPolicy)
 	}
+	if !e.planOnly(cr) {
+		gcp.ClearDryRun(cr)
+	}
+	lateInit := lateInitializeMember(cr, params, instance)
+	if lateInit {
+		params = memberParameters(cr)
+	}
+	cr.Status.AtProvider = v1alpha1.BucketPolicyMemberObservation{
+		BindingMembers: bucketpolicy.ObservedMembers(params, instance),
+		Etag:           instance.Etag,
+	}
+	if at, ok := bucketpolicy.ConditionExpiry(cr.Spec.ForProvider.Condition); ok && gcp.RemovesExpired(cr) && !meta.WasDeleted(cr) && time.Now().After(at) {
+		log.Debug("Observed binding", "decision", "remove expired")
+		if err := e.removeExpired(ctx, cr, at); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
 
-	changed := bucketpolicy.BindRoleToMember(cr.Spec.ForProvider, instance)
-	if !changed {
-		cr.Status.SetConditions(xpv1.Available())
-		return managed.ExternalObservation{
-			ResourceExists:   true,
-			ResourceUpToDate: true,
-		}, nil
+	// A binding renamed by a change of its spec is rebound by Update.
+	if _, ok := renamedBinding(cr); ok && !meta.WasDeleted(cr) {
+		log.Debug("Observed binding", "decision", "rename")
+		return managed.ExternalObservation{ResourceExists: true}, nil
 	}
 
-	return managed.ExternalObservation{}, nil
+	if !bucketpolicy.HasBinding(params, instance) {
+		// A deleted binding exists while any of its members is still bound,
+		// so that Delete unbinds them.
+		if meta.WasDeleted(cr) {
+			if bucketpolicy.HasAnyMember(params, instance) {
+				log.Debug("Observed binding", "decision", "unbind")
+				return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+			}
+			log.Debug("Observed binding", "decision", "unbound")
+			return managed.ExternalObservation{}, nil
+		}
+		// NOTE: The managed reconciler discards the conditions Create sets,
+		// so bindings are refused and planned here rather than by Create.
+		if err := e.guardBinding(ctx, cr); err != nil {
+			log.Debug("Observed binding", "decision", "refuse")
+			return managed.ExternalObservation{}, &guardErr{err}
+		}
+		if e.planOnly(cr) {
+			e.recordDryRun(cr, gcp.ReasonChangePlanned, "would bind "+bucketpolicy.DescribeMemberBinding(params))
+			log.Debug("Observed binding", "decision", "bind planned")
+			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+		}
+		log.Debug("Observed binding", "decision", "bind")
+		return managed.ExternalObservation{}, nil
+	}
+	if bucketpolicy.HasAnyMember(removedMembers(cr, params), instance) {
+		log.Debug("Observed binding", "decision", "unbind removed members")
+		return managed.ExternalObservation{ResourceExists: true, ResourceLateInitialized: lateInit}, nil
+	}
+	if bucketpolicy.HasDeletedMembers(params, instance) {
+		log.Debug("Observed binding", "decision", "prune deleted members")
+		return managed.ExternalObservation{ResourceExists: true, ResourceLateInitialized: lateInit}, nil
+	}
+	if bucketpolicy.HasDuplicateBindings(params, instance) {
+		log.Debug("Observed binding", "decision", "normalize duplicates")
+		if err := e.normalizeBindings(ctx, cr); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
+
+	// BucketPolicyMembers bound before they were named are named once observed.
+	_, _, _, named := bucketpolicy.ParseMemberExternalName(meta.GetExternalName(cr))
+	name := memberExternalName(params)
+	rename := !named && name != ""
+	if rename {
+		meta.SetExternalName(cr, name)
+	}
+	gcp.ClearIAMPolicyError(cr)
+	cr.Status.SetConditions(xpv1.Available())
+	e.metrics.noop(v1alpha1.BucketPolicyMemberKind)
+	log.Debug("Observed binding", "decision", "up to date")
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        true,
+		ResourceLateInitialized: rename || lateInit,
+	}, nil
+}
+
+// lateInitializeMember initializes the unset parameters of the supplied
+// BucketPolicyMember, e.g. of an imported binding, from its binding and the
+// policy of its bucket. It returns true if any parameter was initialized.
+func lateInitializeMember(cr *v1alpha1.BucketPolicyMember, params v1alpha1.BucketPolicyMemberParameters, instance *storage.Policy) bool {
+	spec := &cr.Spec.ForProvider
+	changed := false
+	if spec.Bucket == nil && params.Bucket != nil {
+		spec.Bucket = params.Bucket
+		changed = true
+	}
+	if spec.Member == nil && len(spec.Members) == 0 && params.Member != nil {
+		spec.Member = params.Member
+		changed = true
+	}
+	// Only a binding the provider did not bind itself is imported.
+	_, _, _, named := bucketpolicy.ParseMemberExternalName(meta.GetExternalName(cr))
+	if _, applied := cr.GetAnnotations()[gcp.AnnotationKeyLastAppliedBinding]; named && !applied && spec.Condition == nil {
+		if c := bucketpolicy.ImportedCondition(params, instance); c != nil {
+			spec.Condition = c
+			changed = true
+		}
+	}
+	return changed
 }
 
 func (e *bucketPolicyMemberExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
@@ -113,27 +368,94 @@ func (e *bucketPolicyMemberExternal) Create(ctx context.Context, mg resource.Man
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotBucketPolicyMember)
 	}
-	instance, err := e.bucketpolicy.GetIamPolicy(gcp.StringValue(cr.Spec.ForProvider.Bucket)).OptionsRequestedPolicyVersion(iamv1alpha1.PolicyVersion).Context(ctx).Do()
-	if err != nil {
-		return managed.ExternalCreation{}, errors.Wrap(err, errGetPolicy)
+	if err := validateMemberParameters(memberParameters(cr)); err != nil {
+		return managed.ExternalCreation{}, err
 	}
-
-	changed := bucketpolicy.BindRoleToMember(cr.Spec.ForProvider, instance)
-	if !changed {
-		return managed.ExternalCreation{}, nil
+	if err := guardPublicAccess(cr); err != nil {
+		return managed.ExternalCreation{}, err
 	}
-
-	if _, err := e.bucketpolicy.SetIamPolicy(gcp.StringValue(cr.Spec.ForProvider.Bucket), instance).
-		Context(ctx).Do(); err != nil {
-		return managed.ExternalCreation{}, errors.Wrap(err, errSetPolicy)
+	if err := e.guardMemberDomains(cr); err != nil {
+		return managed.ExternalCreation{}, err
 	}
-
-	return managed.ExternalCreation{}, nil
+	if err := e.guardCustomRole(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if _, _, _, ok := bucketpolicy.ParseMemberExternalName(meta.GetExternalName(cr)); !ok {
+		if name := memberExternalName(cr.Spec.ForProvider); name != "" {
+			meta.SetExternalName(cr, name)
+		}
+	}
+	log := e.logger(cr)
+	log.Debug("Creating binding")
+	return managed.ExternalCreation{}, e.bind(ctx, cr)
 }
 
 func (e *bucketPolicyMemberExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	_, err := e.Create(ctx, mg)
-	return managed.ExternalUpdate{}, err
+	cr, ok := mg.(*v1alpha1.BucketPolicyMember)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotBucketPolicyMember)
+	}
+	if err := validateMemberParameters(memberParameters(cr)); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := guardPublicAccess(cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := e.guardMemberDomains(cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := e.guardCustomRole(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	// A binding that is unchanged since it was last applied is not bound
+	// again, unless its deleted members are pruned or it was renamed.
+	params := memberParameters(cr)
+	renamed, isRenamed := renamedBinding(cr)
+	if !isRenamed && !gcp.BoolValue(params.PruneDeletedMembers) && cr.GetAnnotations()[gcp.AnnotationKeyLastAppliedBinding] == bucketpolicy.MemberBinding(params) {
+		e.logger(cr).Debug("Updating binding", "decision", "unchanged since last applied")
+		return managed.ExternalUpdate{}, nil
+	}
+	e.logger(cr).Debug("Updating binding", "decision", "bind")
+	if err := e.bind(ctx, cr); err != nil || e.planOnly(cr) {
+		return managed.ExternalUpdate{}, err
+	}
+	if isRenamed {
+		if err := e.unbindRenamed(ctx, cr, renamed); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+	// The managed reconciler does not persist annotations set by Update.
+	return managed.ExternalUpdate{}, errors.Wrap(e.kube.Update(ctx, cr), errManagedMember)
+}
+
+// bind binds the role of the supplied BucketPolicyMember to its members, unbinds
+// the members removed since it was last applied, and records it as applied.
+func (e *bucketPolicyMemberExternal) bind(ctx context.Context, cr *v1alpha1.BucketPolicyMember) error {
+	params := memberParameters(cr)
+	removed := removedMembers(cr, params)
+	set, err := e.changePolicy(ctx, cr, params, func(p *storage.Policy) (bool, error) {
+		bound := bucketpolicy.BindRoleToMember(params, p)
+		unbound := len(removed.Members) > 0 && bucketpolicy.UnbindRoleFromMember(removed, p)
+		pruned := bucketpolicy.PruneDeletedMembers(params, p)
+		if !bound && !unbound && !pruned {
+			return false, nil
+		}
+		return true, bucketpolicy.ValidatePolicySize(p)
+	}, gcp.ReasonChangePlanned, "would bind "+bucketpolicy.DescribeMemberBinding(params))
+	if err != nil {
+		return err
+	}
+	if !e.planOnly(cr) {
+		meta.AddAnnotations(cr, map[string]string{gcp.AnnotationKeyLastAppliedBinding: bucketpolicy.MemberBinding(params)})
+	}
+	if !set {
+		return nil
+	}
+	gcp.ClearIAMPolicyError(cr)
+	e.metrics.added(v1alpha1.BucketPolicyMemberKind)
+	e.record.Event(cr, event.Normal(reasonBound, "bound "+describeMemberBinding(cr)))
+
+	return nil
 }
 
 func (e *bucketPolicyMemberExternal) Delete(ctx context.Context, mg resource.Managed) error {
@@ -141,19 +463,397 @@ func (e *bucketPolicyMemberExternal) Delete(ctx context.Context, mg resource.Man
 	if !ok {
 		return errors.New(errNotBucketPolicyMember)
 	}
-	instance, err := e.bucketpolicy.GetIamPolicy(gcp.StringValue(cr.Spec.ForProvider.Bucket)).OptionsRequestedPolicyVersion(iamv1alpha1.PolicyVersion).Context(ctx).Do()
+	e.observeErrors.Forget(cr.GetName())
+	// The bucket policy is shared, so it is never changed for an orphan.
+	if cr.GetDeletionPolicy() == xpv1.DeletionOrphan {
+		e.logger(cr).Debug("Deleting binding", "decision", "orphan")
+		return nil
+	}
+	e.logger(cr).Debug("Deleting binding", "decision", "unbind")
+	params := memberParameters(cr)
+	set, err := e.changePolicy(ctx, cr, params, unbindRoleFromMember(params), gcp.ReasonDeletionPlanned, "would unbind "+bucketpolicy.DescribeMemberBinding(params))
+	if err != nil || !set {
+		return err
+	}
+	e.metrics.removed(v1alpha1.BucketPolicyMemberKind)
+	e.record.Event(cr, event.Normal(reasonUnbound, "unbound "+describeMemberBinding(cr)))
+
+	return nil
+}
+
+// removeExpired removes the binding of the supplied BucketPolicyMember, whose
+// condition expired at the supplied time, and reports it as unavailable.
+func (e *bucketPolicyMemberExternal) removeExpired(ctx context.Context, cr *v1alpha1.BucketPolicyMember, at time.Time) error {
+	cr.Status.SetConditions(gcp.Expired(at))
+	params := memberParameters(cr)
+	set, err := e.changePolicy(ctx, cr, params, unbindRoleFromMember(params), gcp.ReasonChangePlanned, "would remove expired "+bucketpolicy.DescribeMemberBinding(params))
+	if err != nil || !set {
+		return err
+	}
+	e.metrics.removed(v1alpha1.BucketPolicyMemberKind)
+	e.record.Event(cr, event.Normal(reasonRemovedExpired, "removed expired "+describeMemberBinding(cr)))
+	return nil
+}
+
+// normalizeBindings merges the duplicate bindings of the binding of the
+// supplied BucketPolicyMember in the policy of its bucket.
+func (e *bucketPolicyMemberExternal) normalizeBindings(ctx context.Context, cr *v1alpha1.BucketPolicyMember) error {
+	params := memberParameters(cr)
+	set, err := e.changePolicy(ctx, cr, params, func(p *storage.Policy) (bool, error) {
+		return bucketpolicy.NormalizeBindings(params, p), nil
+	}, gcp.ReasonChangePlanned, "would normalize "+bucketpolicy.DescribeMemberBinding(params))
+	if err != nil || !set {
+		return err
+	}
+	e.record.Event(cr, event.Normal(reasonNormalized, "normalized "+describeMemberBinding(cr)))
+	return nil
+}
+
+// unbindRenamed unbinds the supplied binding, which the external name of the
+// supplied BucketPolicyMember named before its spec was changed, and names the
+// BucketPolicyMember after the binding of its spec instead.
+func (e *bucketPolicyMemberExternal) unbindRenamed(ctx context.Context, cr *v1alpha1.BucketPolicyMember, renamed v1alpha1.BucketPolicyMemberParameters) error {
+	name := memberExternalName(memberParameters(cr))
+	if name == "" {
+		name = cr.GetName()
+	}
+	set, err := e.changePolicy(ctx, cr, renamed, unbindRoleFromMember(renamed), gcp.ReasonChangePlanned, "would unbind "+bucketpolicy.DescribeMemberBinding(renamed))
+	if err != nil {
+		return err
+	}
+	meta.SetExternalName(cr, name)
+	if !set {
+		return nil
+	}
+	e.metrics.removed(v1alpha1.BucketPolicyMemberKind)
+	e.record.Event(cr, event.Normal(reasonUnbound, "unbound "+bucketpolicy.DescribeMemberBinding(renamed)+" on bucket "+gcp.StringValue(renamed.Bucket)))
+	return nil
+}
+
+// removedMembers returns the supplied parameters of the binding of the supplied
+// BucketPolicyMember with only the members that were removed from it since it
+// was last applied, if any, as their members.
+func removedMembers(cr *v1alpha1.BucketPolicyMember, params v1alpha1.BucketPolicyMemberParameters) v1alpha1.BucketPolicyMemberParameters {
+	removed := bucketpolicy.RemovedMembers(cr.GetAnnotations()[gcp.AnnotationKeyLastAppliedBinding], params)
+	params.Member, params.Members = nil, removed
+	return params
+}
+
+// unbindRoleFromMember returns a change to a bucket policy that unbinds the
+// role of the supplied parameters from their members.
+func unbindRoleFromMember(params v1alpha1.BucketPolicyMemberParameters) bucketpolicy.Change {
+	return func(p *storage.Policy) (bool, error) {
+		return bucketpolicy.UnbindRoleFromMember(params, p), nil
+	}
+}
+
+// changePolicy applies the supplied change to the IAM policy of the bucket of
+// the supplied parameters of a binding of the BucketPolicyMember, batched with others unless it is planned
+// with the supplied reason and description. It returns true if it was set.
+func (e *bucketPolicyMemberExternal) changePolicy(ctx context.Context, cr *v1alpha1.BucketPolicyMember, params v1alpha1.BucketPolicyMemberParameters, change bucketpolicy.Change, r xpv1.ConditionReason, plan string) (bool, error) {
+	bucket, version := gcp.StringValue(params.Bucket), bucketpolicy.MemberPolicyVersion(params)
+	batches := e.batches
+	if e.planOnly(cr) {
+		batches = nil
+	}
+	set, err := batches.Apply(ctx, batchKey(cr, bucket, version), change, func(ctx context.Context, change bucketpolicy.Change) (bool, error) {
+		return e.applyChange(ctx, cr, bucket, version, change, r, plan)
+	})
+	log := e.logger(cr)
+	var sErr *setPolicyErr
+	if errors.As(err, &sErr) {
+		gcp.SetIAMPolicyError(cr, "bucket", sErr.error)
+		err = errors.Wrap(sErr.error, setPolicyError(sErr.error))
+		e.record.Event(cr, event.Warning(reasonCannotSetPolicy, err))
+		log.Info("Cannot set bucket policy", "code", gcp.ErrorCode(sErr.error), "error", sErr.error)
+		return set, err
+	}
+	if err == nil {
+		log.Debug("Changed bucket policy", "set", set, "dryRun", e.planOnly(cr))
+	}
+	return set, err
+}
+
+// logger returns the logger of the external client with the bucket, role and
+// members of the binding of the supplied BucketPolicyMember.
+func (e *bucketPolicyMemberExternal) logger(cr *v1alpha1.BucketPolicyMember) logging.Logger {
+	log := e.log
+	if log == nil {
+		log = logging.NewNopLogger()
+	}
+	params := memberParameters(cr)
+	return log.WithValues("bucket", gcp.StringValue(params.Bucket), "role", params.Role, "member", strings.Join(bucketpolicy.BoundMembers(params), ","))
+}
+
+// applyChange applies the supplied change to the supplied version of the IAM
+// policy of the supplied bucket under its lock, reading and changing it again
+// while it was changed concurrently. It returns true if the policy was set.
+func (e *bucketPolicyMemberExternal) applyChange(ctx context.Context, cr *v1alpha1.BucketPolicyMember, bucket string, version int64, change bucketpolicy.Change, r xpv1.ConditionReason, plan string) (bool, error) {
+	unlock, err := e.locks.Lock(ctx, bucket)
 	if err != nil {
-		return errors.Wrap(err, errGetPolicy)
+		return false, errors.Wrap(err, errLockPolicy)
 	}
+	defer unlock()
+	set := false
+	var setErr error
+	err = gcp.RetryOnConflict(ctx, e.conflicts, func() error {
+		instance, err := e.getPolicy(ctx, cr, bucket, version)
+		if err != nil {
+			return errors.Wrap(err, errGetPolicy)
+		}
+		changed, err := change(instance)
+		if err != nil || !changed {
+			return err
+		}
+		if e.planOnly(cr) {
+			e.recordDryRun(cr, r, plan)
+			return nil
+		}
+		setErr = e.setPolicy(ctx, cr, bucket, instance)
+		set = setErr == nil
+		return setErr
+	})
+	if err != nil && err == setErr {
+		return set, &setPolicyErr{err}
+	}
+	return set, err
+}
 
-	changed := bucketpolicy.UnbindRoleFromMember(cr.Spec.ForProvider, instance)
-	if !changed {
+// A setPolicyErr is an error setting, rather than reading or changing, the IAM
+// policy of a bucket.
+type setPolicyErr struct {
+	error
+}
+
+// batchKey returns the key of the batch of changes to the supplied version of
+// the IAM policy of the supplied bucket.
+func batchKey(cr *v1alpha1.BucketPolicyMember, bucket string, version int64) string {
+	return policyKey(cr, bucket) + "/" + strconv.FormatInt(version, 10)
+}
+
+// policyKey returns the key of the IAM policy of the supplied bucket, which is
+// distinct per ProviderConfig and project.
+func policyKey(cr *v1alpha1.BucketPolicyMember, bucket string) string {
+	pc := ""
+	if ref := cr.GetProviderConfigReference(); ref != nil {
+		pc = ref.Name
+	}
+	return pc + "/" + gcp.StringValue(cr.Spec.ForProvider.Project) + "/" + bucket
+}
+
+// getPolicy returns the supplied version of the IAM policy of the supplied
+// bucket. Only the default version is cached.
+func (e *bucketPolicyMemberExternal) getPolicy(ctx context.Context, cr *v1alpha1.BucketPolicyMember, bucket string, version int64) (*storage.Policy, error) {
+	policies := e.policies
+	if version != iamv1alpha1.PolicyVersion {
+		policies = nil
+	}
+	return policies.Get(ctx, policyKey(cr, bucket), func() (p *storage.Policy, err error) {
+		err = e.call(ctx, methodGetIAMPolicy, func(ctx context.Context) error {
+			p, err = e.bucketpolicy.GetIamPolicy(bucket).OptionsRequestedPolicyVersion(version).Context(ctx).Do()
+			return err
+		})
+		return p, err
+	})
+}
+
+// setPolicy sets the IAM policy of the supplied bucket and invalidates its
+// cached policy.
+func (e *bucketPolicyMemberExternal) setPolicy(ctx context.Context, cr *v1alpha1.BucketPolicyMember, bucket string, p *storage.Policy) error {
+	defer e.policies.Invalidate(policyKey(cr, bucket))
+	return e.call(ctx, methodSetIAMPolicy, func(ctx context.Context) error {
+		_, err := e.bucketpolicy.SetIamPolicy(bucket, p).Context(ctx).Do()
+		return err
+	})
+}
+
+// call calls the supplied method of the GCP API, rate limited and with a
+// timeout, retrying it while it fails transiently.
+func (e *bucketPolicyMemberExternal) call(ctx context.Context, method string, fn func(ctx context.Context) error) error {
+	return gcp.Retry(ctx, e.backoff, func() error {
+		if e.api != nil {
+			if err := e.api.Wait(ctx); err != nil {
+				return errors.Wrap(err, errAPIRateLimit)
+			}
+		}
+		return e.metrics.call(v1alpha1.BucketPolicyMemberKind, method, func() error {
+			return gcp.CallWithTimeout(ctx, e.callTimeout, fn)
+		})
+	})
+}
+
+// newBatcher returns a batcher that applies the changes made within the
+// supplied window together, or none if the window is not positive.
+func newBatcher(window time.Duration) *bucketpolicy.Batcher {
+	if window <= 0 {
 		return nil
 	}
-	if _, err := e.bucketpolicy.SetIamPolicy(gcp.StringValue(cr.Spec.ForProvider.Bucket), instance).
-		Context(ctx).Do(); err != nil {
-		return errors.Wrap(err, errSetPolicy)
+	return bucketpolicy.NewBatcher(window)
+}
+
+// newErrorBackoff returns an error backoff from the supplied duration up to the
+// supplied cap, or none if the cap is not positive.
+func newErrorBackoff(base, cap time.Duration) *bucketpolicy.ErrorBackoff {
+	if cap <= 0 {
+		return nil
 	}
+	return bucketpolicy.NewErrorBackoff(base, cap)
+}
 
-	return nil
+// describeMemberBinding returns a human readable description of the binding of
+// the supplied BucketPolicyMember, including the bucket whose policy it is in.
+func describeMemberBinding(cr *v1alpha1.BucketPolicyMember) string {
+	params := memberParameters(cr)
+	return bucketpolicy.DescribeMemberBinding(params) + " on bucket " + gcp.StringValue(params.Bucket)
+}
+
+// memberParameters returns the parameters of the binding of the supplied
+// BucketPolicyMember, whose unset bucket, role and member are those named by
+// its external name, if any.
+func memberParameters(cr *v1alpha1.BucketPolicyMember) v1alpha1.BucketPolicyMemberParameters {
+	params := *cr.Spec.ForProvider.DeepCopy()
+	bucket, role, member, ok := bucketpolicy.ParseMemberExternalName(meta.GetExternalName(cr))
+	if !ok {
+		return params
+	}
+	if params.Bucket == nil {
+		params.Bucket = &bucket
+	}
+	if params.Role == "" {
+		params.Role = role
+	}
+	if params.Member == nil && len(params.Members) == 0 {
+		params.Member = &member
+	}
+	return params
+}
+
+// renamedBinding returns the parameters of the binding named by the external
+// name of the supplied BucketPolicyMember, and whether it is another binding
+// than that of its spec, e.g. once the spec was changed after it was named.
+func renamedBinding(cr *v1alpha1.BucketPolicyMember) (v1alpha1.BucketPolicyMemberParameters, bool) {
+	bucket, role, member, ok := bucketpolicy.ParseMemberExternalName(meta.GetExternalName(cr))
+	if !ok {
+		return v1alpha1.BucketPolicyMemberParameters{}, false
+	}
+	params := memberParameters(cr)
+	renamed := v1alpha1.BucketPolicyMemberParameters{Bucket: &bucket, Role: role, Member: &member, Condition: params.Condition}
+	if gcp.StringValue(params.Bucket) != bucket || params.Role != role {
+		return renamed, true
+	}
+	for _, m := range bucketpolicy.BoundMembers(params) {
+		if m == member {
+			return v1alpha1.BucketPolicyMemberParameters{}, false
+		}
+	}
+	return renamed, true
+}
+
+// memberExternalName returns the external name of the binding of the supplied
+// parameters, or an empty string if they bind more than one member.
+func memberExternalName(in v1alpha1.BucketPolicyMemberParameters) string {
+	members := bucketpolicy.BoundMembers(in)
+	if len(members) != 1 {
+		return ""
+	}
+	return bucketpolicy.MemberExternalName(gcp.StringValue(in.Bucket), in.Role, members[0])
+}
+
+// validateMemberParameters returns an error if the supplied parameters are
+// malformed.
+func validateMemberParameters(in v1alpha1.BucketPolicyMemberParameters) error {
+	members := bucketpolicy.BoundMembers(in)
+	if len(members) == 0 {
+		return errors.New(errNoMembers)
+	}
+	if err := bucketpolicy.ValidateMembers(members...); err != nil {
+		return err
+	}
+	if err := bucketpolicy.ValidateRole(in.Role); err != nil {
+		return err
+	}
+	return bucketpolicy.ValidatePolicyVersion(in)
+}
+
+// guardBinding returns an error if the binding of the supplied
+// BucketPolicyMember is refused by any guard.
+func (e *bucketPolicyMemberExternal) guardBinding(ctx context.Context, cr *v1alpha1.BucketPolicyMember) error {
+	if err := guardPublicAccess(cr); err != nil {
+		return err
+	}
+	if err := e.guardMemberDomains(cr); err != nil {
+		return err
+	}
+	return e.guardCustomRole(ctx, cr)
+}
+
+// A guardErr is an error refusing, rather than observing, a binding.
+type guardErr struct {
+	error
+}
+
+// guardPublicAccess returns an error if the supplied BucketPolicyMember makes
+// its bucket public without being annotated to allow public access.
+func guardPublicAccess(cr *v1alpha1.BucketPolicyMember) error {
+	public := bucketpolicy.PublicMembers(bucketpolicy.BoundMembers(memberParameters(cr))...)
+	if len(public) == 0 || gcp.AllowsPublicAccess(cr) {
+		return nil
+	}
+	gcp.SetPublicAccessBlocked(cr, "bucket", public)
+	return errors.Errorf(errFmtPublicAccess, strings.Join(public, ", "), gcp.AnnotationKeyAllowPublicAccess)
+}
+
+// guardMemberDomains returns an error if the supplied BucketPolicyMember binds
+// a member of a domain that is not allowed.
+func (e *bucketPolicyMemberExternal) guardMemberDomains(cr *v1alpha1.BucketPolicyMember) error {
+	disallowed := bucketpolicy.DisallowedMembers(e.allowedDomains, bucketpolicy.BoundMembers(memberParameters(cr))...)
+	if len(disallowed) == 0 {
+		return nil
+	}
+	gcp.SetDomainNotAllowed(cr, disallowed, e.allowedDomains)
+	return errors.Errorf(errFmtDomainNotAllowed, strings.Join(disallowed, ", "))
+}
+
+// guardCustomRole returns an error if the supplied BucketPolicyMember checks
+// its custom role and the role does not exist.
+func (e *bucketPolicyMemberExternal) guardCustomRole(ctx context.Context, cr *v1alpha1.BucketPolicyMember) error {
+	role := memberParameters(cr).Role
+	if !gcp.ChecksCustomRole(cr) || !bucketpolicy.IsCustomRole(role) {
+		return nil
+	}
+	exists := false
+	err := e.call(ctx, methodGetRole, func(ctx context.Context) (err error) {
+		exists, err = bucketpolicy.CustomRoleExists(ctx, e.roles, role)
+		return err
+	})
+	if err != nil {
+		return errors.Wrap(err, errGetRole)
+	}
+	if exists {
+		return nil
+	}
+	gcp.SetRoleNotFound(cr, role)
+	return errors.Errorf(errFmtRoleNotFound, role)
+}
+
+// planOnly returns true if changes of the supplied BucketPolicyMember are
+// planned rather than applied.
+func (e *bucketPolicyMemberExternal) planOnly(cr *v1alpha1.BucketPolicyMember) bool {
+	return e.readOnly || gcp.IsDryRun(cr)
+}
+
+// recordDryRun surfaces a change to the bucket policy that was planned rather
+// than applied as both an event and a condition of the supplied resource.
+func (e *bucketPolicyMemberExternal) recordDryRun(cr *v1alpha1.BucketPolicyMember, r xpv1.ConditionReason, change string) {
+	e.record.Event(cr, event.Normal(reasonDryRun, change))
+	gcp.SetDryRun(cr, r, change)
+}
+
+// setPolicyError returns the message an error setting a bucket policy should
+// be wrapped with.
+func setPolicyError(err error) string {
+	if gcp.IsErrorConflict(err) {
+		return errPolicyChanged
+	}
+	return errSetPolicy
 }
@@ -8,27 +8,52 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"golang.org/x/time/rate"
+	iamv1 "google.golang.org/api/iam/v1"
 	"google.golang.org/api/option"
 	storagev1 "google.golang.org/api/storage/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	kubefake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	xpfake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
 
+	iamv1alpha1 "github.com/crossplane/provider-gcp/apis/iam/v1alpha1"
 	"github.com/crossplane/provider-gcp/apis/storage/v1alpha1"
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
 	"github.com/crossplane/provider-gcp/pkg/clients/bucketpolicy"
+	"github.com/crossplane/provider-gcp/pkg/clients/bucketpolicy/fake"
 )
 
 const (
 	bpmMetadataName = "test-bucket-policy-member"
+
+	// bpmEtag is the etag of a policy of the fake store that was not set.
+	bpmEtag = "MQ=="
 )
 
+// bpmRejected is the error the fake store refuses to set a policy with.
+var bpmRejected = gError(http.StatusForbidden, `{"error":{"code":403,"message":"Forbidden"}}`+"\n")
+
 type bpmValueModifier func(ring *v1alpha1.BucketPolicyMember)
 
 func bpmWithName(s string) bpmValueModifier {
@@ -44,15 +69,58 @@ func bpmWithExternalNameAnnotation(externalName string) bpmValueModifier {
 	}
 }
 
+func bpmWithMember(m string) bpmValueModifier {
+	return func(i *v1alpha1.BucketPolicyMember) { i.Spec.ForProvider.Member = &m }
+}
+
+func bpmWithRole(r string) bpmValueModifier {
+	return func(i *v1alpha1.BucketPolicyMember) { i.Spec.ForProvider.Role = r }
+}
+
+func bpmWithMembers(m ...string) bpmValueModifier {
+	return func(i *v1alpha1.BucketPolicyMember) { i.Spec.ForProvider.Members = m }
+}
+
+// bpmWithImportedSpec leaves the bucket and member of the binding to the
+// external name, as an imported BucketPolicyMember does.
+func bpmWithImportedSpec() bpmValueModifier {
+	return func(i *v1alpha1.BucketPolicyMember) {
+		i.Spec.ForProvider.Bucket = nil
+		i.Spec.ForProvider.Member = nil
+		i.Spec.ForProvider.Role = "roles/storage.objectViewer"
+	}
+}
+
+func bpmWithDeletionPolicy(p xpv1.DeletionPolicy) bpmValueModifier {
+	return func(i *v1alpha1.BucketPolicyMember) { i.Spec.DeletionPolicy = p }
+}
+
+// bpmWithLastAppliedBinding records the binding of the BucketPolicyMember as
+// last applied. It must follow the modifiers that change the binding.
+func bpmWithLastAppliedBinding() bpmValueModifier {
+	return func(i *v1alpha1.BucketPolicyMember) {
+		meta.AddAnnotations(i, map[string]string{gcp.AnnotationKeyLastAppliedBinding: bucketpolicy.MemberBinding(memberParameters(i))})
+	}
+}
+
+func bpmWithDeletionTimestamp(t metav1.Time) bpmValueModifier {
+	return func(i *v1alpha1.BucketPolicyMember) { i.SetDeletionTimestamp(&t) }
+}
+
 func bpmWithCondition(condition xpv1.Condition) bpmValueModifier {
 	return func(i *v1alpha1.BucketPolicyMember) { i.SetConditions(condition) }
 }
 
+func bpmWithBindingMembers(m ...string) bpmValueModifier {
+	return func(i *v1alpha1.BucketPolicyMember) { i.Status.AtProvider.BindingMembers = m }
+}
+
 func BucketPolicyMember(im ...bpmValueModifier) *v1alpha1.BucketPolicyMember {
 	bpm := &v1alpha1.BucketPolicyMember{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:       bpmMetadataName,
-			Finalizers: []string{},
+			Name:        bpmMetadataName,
+			Annotations: map[string]string{keyExternalName: bucketpolicy.MemberExternalName(testBucketName, testRole, testMember)},
+			Finalizers:  []string{},
 		},
 		Spec: v1alpha1.BucketPolicyMemberSpec{
 			ForProvider: v1alpha1.BucketPolicyMemberParameters{
@@ -70,6 +138,45 @@ func BucketPolicyMember(im ...bpmValueModifier) *v1alpha1.BucketPolicyMember {
 	return bpm
 }
 
+func bpmWithEtag(etag string) bpmValueModifier {
+	return func(i *v1alpha1.BucketPolicyMember) { i.Status.AtProvider.Etag = etag }
+}
+
+// bpmPolicies returns the policies of a fake store in which the test bucket
+// has a policy of the supplied bindings.
+func bpmPolicies(bindings ...*storagev1.PolicyBindings) map[string]*storagev1.Policy {
+	return map[string]*storagev1.Policy{testBucketName: {Bindings: bindings}}
+}
+
+// bpmBuckets returns a client of a fake store of the supplied policies, or of a
+// server of the supplied handler if there are no policies.
+func bpmBuckets(t *testing.T, handler http.Handler, policies map[string]*storagev1.Policy) (bucketpolicy.Client, *fake.PolicyStore) {
+	t.Helper()
+	if policies == nil {
+		server := httptest.NewServer(handler)
+		t.Cleanup(server.Close)
+		s, _ := storagev1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+		return storagev1.NewBucketsService(s), nil
+	}
+	store := fake.NewPolicyStore(policies)
+	t.Cleanup(store.Close)
+	buckets, err := store.Client(context.Background())
+	if err != nil {
+		t.Fatalf("Client(...): %s", err)
+	}
+	return buckets, store
+}
+
+// bpmBindings returns the bindings of the policy of the test bucket in the
+// supplied fake store, if the bucket exists.
+func bpmBindings(store *fake.PolicyStore) []*storagev1.PolicyBindings {
+	p := store.Policy(testBucketName)
+	if p == nil {
+		return nil
+	}
+	return p.Bindings
+}
+
 func TestBucketPolicyMemberObserve(t *testing.T) {
 	type args struct {
 		ctx context.Context
@@ -79,11 +186,46 @@ func TestBucketPolicyMemberObserve(t *testing.T) {
 		mg          resource.Managed
 		observation managed.ExternalObservation
 		err         error
+		bindings    []*storagev1.PolicyBindings
+		events      []event.Event
+	}
+
+	other := "group:team@example.com"
+	jane := "user:jane@example.com"
+	expired := "2020-10-01T00:00:00Z"
+	expiredAt, _ := time.Parse(time.RFC3339, expired)
+	pending := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	expirable := func(at string, members ...string) *storagev1.PolicyBindings {
+		return &storagev1.PolicyBindings{
+			Role:      testRole,
+			Members:   members,
+			Condition: &storagev1.Expr{Title: "expirable-access", Expression: "request.time < timestamp(\"" + at + "\")"},
+		}
 	}
+	expiring := "role " + testRole + " of member " + testMember + " with condition \"expirable-access\""
+	imported := &iamv1alpha1.Expr{
+		Title:       gcp.StringPtr("business-hours"),
+		Description: gcp.StringPtr("Access during business hours"),
+		Expression:  "request.time.getHours(\"Europe/Berlin\") < 18",
+	}
+	ambiguous := &iamv1alpha1.Expr{
+		Title:      gcp.StringPtr("expirable-access"),
+		Expression: "request.time < timestamp(\"2099-01-01T00:00:00Z\")",
+	}
+	conditional := &storagev1.PolicyBindings{
+		Role:      testRole,
+		Members:   []string{testMember},
+		Condition: &storagev1.Expr{Title: "business-hours", Description: "Access during business hours", Expression: imported.Expression},
+	}
+	unconditional := &storagev1.PolicyBindings{Role: testRole, Members: []string{testMember}}
+	deleted := metav1.Now()
+
 	cases := map[string]struct {
-		handler http.Handler
-		args    args
-		want    want
+		reason   string
+		handler  http.Handler
+		policies map[string]*storagev1.Policy
+		args     args
+		want     want
 	}{
 		"NotBucketPolicyMember": {
 			args: args{
@@ -152,7 +294,8 @@ func TestBucketPolicyMemberObserve(t *testing.T) {
 			},
 			want: want{
 				mg: BucketPolicyMember(
-					bpmWithName(bpmMetadataName)),
+					bpmWithName(bpmMetadataName),
+					bpmWithBindingMembers("some-other-member")),
 				observation: managed.ExternalObservation{},
 			},
 		},
@@ -187,160 +330,76 @@ func TestBucketPolicyMemberObserve(t *testing.T) {
 			want: want{
 				mg: BucketPolicyMember(
 					bpmWithCondition(xpv1.Available()),
-					bpmWithName(bpmMetadataName)),
+					bpmWithName(bpmMetadataName),
+					bpmWithBindingMembers(testMember)),
 				observation: managed.ExternalObservation{
 					ResourceExists:   true,
 					ResourceUpToDate: true,
 				},
 			},
 		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			server := httptest.NewServer(tc.handler)
-			defer server.Close()
-			s, _ := storagev1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
-			buckets := storagev1.NewBucketsService(s)
-			e := &bucketPolicyMemberExternal{bucketpolicy: buckets}
-			obs, err := e.Observe(context.Background(), tc.args.mg)
-
-			if err != nil {
-				if tc.want.err != nil {
-					// we expected a different error than we got
-					if diff := cmp.Diff(tc.want.err.Error(), err.Error()); diff != "" {
-						t.Errorf("Observe(...): want error string != got error string:\n%s", diff)
-					}
-				} else {
-					t.Errorf("Observe(...): unexpected error %s", err)
-				}
-			} else {
-				if tc.want.err != nil {
-					t.Errorf("Observe(...) want error %s got nil", tc.want.err)
-				}
-			}
-
-			if diff := cmp.Diff(tc.want.observation, obs); diff != "" {
-				t.Errorf("Observe(...): -want, +got:\n%s", diff)
-			}
-			if diff := cmp.Diff(tc.want.mg, tc.args.mg); diff != "" {
-				t.Errorf("Observe(...): -want, +got:\n%s", diff)
-			}
-		})
-	}
-}
-
-func TestBucketPolicyMemberUpdate(t *testing.T) {
-	type args struct {
-		ctx context.Context
-		mg  resource.Managed
-	}
-	type want struct {
-		mg  resource.Managed
-		err error
-	}
-
-	cases := map[string]struct {
-		handler http.Handler
-		args    args
-		want    want
-	}{
-		"NotBucketPolicyMember": {
-			args: args{
-				ctx: context.Background(),
-				mg:  &strange{},
-			},
-			want: want{
-				mg:  &strange{},
-				err: errors.New(errNotBucketPolicyMember),
-			},
-		},
-		"UpdateSucceeded": {
+		"ObservedUnnamedBinding": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				var bpm *storagev1.Policy
-				defer r.Body.Close()
-				switch r.Method {
-				case http.MethodGet:
-					bpm = &storagev1.Policy{
-						Bindings: []*storagev1.PolicyBindings{
-							{
-								Members: []string{"another-member"},
-								Role:    "another-role",
-							},
-						},
-					}
-					w.WriteHeader(http.StatusOK)
-				case http.MethodPut:
-					i := &storagev1.Policy{}
-					b, err := ioutil.ReadAll(r.Body)
-					if diff := cmp.Diff(err, nil); diff != "" {
-						t.Errorf("r: -want, +got:\n%s", diff)
-					}
-					err = json.Unmarshal(b, i)
-					if diff := cmp.Diff(err, nil); diff != "" {
-						t.Errorf("r: -want, +got:\n%s", diff)
-					}
-					bpm = &storagev1.Policy{
-						Bindings: []*storagev1.PolicyBindings{
-							{
-								Members: []string{"another-member"},
-								Role:    "another-role",
-							},
-							{
-								Members: []string{testMember},
-								Role:    testRole,
-							},
+				bpm := &storagev1.Policy{
+					Bindings: []*storagev1.PolicyBindings{
+						{
+							Members: []string{testMember},
+							Role:    testRole,
 						},
-					}
-					if !bucketpolicy.ArePoliciesSame(bpm, i) {
-						t.Errorf("policy in setIamPolicyRequest not equal to expected, diff: %s", cmp.Diff(bpm, i, cmpopts.IgnoreFields(storagev1.Policy{}, "Version")))
-					}
-					w.WriteHeader(http.StatusOK)
-				default:
-					w.WriteHeader(http.StatusBadRequest)
+					},
 				}
-
+				w.WriteHeader(http.StatusOK)
 				_ = json.NewEncoder(w).Encode(bpm)
 			}),
 			args: args{
 				ctx: context.Background(),
 				mg: BucketPolicyMember(
-					bpmWithName(bpmMetadataName),
 					bpmWithExternalNameAnnotation(bpmMetadataName),
-					bpmWithCondition(xpv1.Available())),
+				),
 			},
 			want: want{
 				mg: BucketPolicyMember(
-					bpmWithName(bpmMetadataName),
-					bpmWithExternalNameAnnotation(bpmMetadataName),
-					bpmWithCondition(xpv1.Available())),
+					bpmWithCondition(xpv1.Available()),
+					bpmWithBindingMembers(testMember)),
+				observation: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
 			},
 		},
-		"FailedToGet": {
-			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				w.WriteHeader(http.StatusInternalServerError)
-				_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
-			}),
+		"ObservedMultipleMembers": {
+			handler: setMembers(t, []string{"user:external@example.com", testMember, "group:team@example.com"}, nil),
 			args: args{
 				ctx: context.Background(),
 				mg: BucketPolicyMember(
-					bpmWithName(bpmMetadataName),
-					bpmWithExternalNameAnnotation(bpmMetadataName)),
+					bpmWithExternalNameAnnotation(bpmMetadataName),
+					bpmWithMembers("group:team@example.com")),
 			},
 			want: want{
 				mg: BucketPolicyMember(
-					bpmWithName(bpmMetadataName),
-					bpmWithExternalNameAnnotation(bpmMetadataName)),
-				err: errors.Wrap(gError(http.StatusInternalServerError, "{}\n"), errGetPolicy),
+					bpmWithExternalNameAnnotation(bpmMetadataName),
+					bpmWithMembers("group:team@example.com"),
+					bpmWithCondition(xpv1.Available()),
+					bpmWithBindingMembers("group:team@example.com", testMember, "user:external@example.com")),
+				observation: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
 			},
 		},
-		"AlreadyUpToDate": {
+		"ObservedImportedBinding": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				expectedEp := "/b/imported-bucket/iam"
+				if !strings.EqualFold(r.URL.Path, expectedEp) {
+					t.Errorf("requested URL.Path to get policy should end with: %s, got %s instead",
+						expectedEp, r.URL.Path)
+				}
 				bpm := &storagev1.Policy{
 					Bindings: []*storagev1.PolicyBindings{
 						{
-							Members: []string{testMember},
-							Role:    testRole,
+							Members: []string{"user:jane@example.com"},
+							Role:    "roles/storage.objectViewer",
 						},
 					},
 				}
@@ -350,102 +409,555 @@ func TestBucketPolicyMemberUpdate(t *testing.T) {
 			args: args{
 				ctx: context.Background(),
 				mg: BucketPolicyMember(
-					bpmWithName(bpmMetadataName),
-					bpmWithExternalNameAnnotation(bpmMetadataName),
-					bpmWithCondition(xpv1.Available())),
+					bpmWithExternalNameAnnotation("imported-bucket/roles/storage.objectViewer/user:jane@example.com"),
+					bpmWithImportedSpec(),
+				),
 			},
 			want: want{
 				mg: BucketPolicyMember(
-					bpmWithName(bpmMetadataName),
-					bpmWithExternalNameAnnotation(bpmMetadataName),
-					bpmWithCondition(xpv1.Available())),
+					bpmWithExternalNameAnnotation("imported-bucket/roles/storage.objectViewer/user:jane@example.com"),
+					bpmWithImportedSpec(),
+					func(i *v1alpha1.BucketPolicyMember) {
+						i.Spec.ForProvider.Bucket = gcp.StringPtr("imported-bucket")
+						i.Spec.ForProvider.Member = gcp.StringPtr("user:jane@example.com")
+					},
+					bpmWithCondition(xpv1.Available()),
+					bpmWithBindingMembers("user:jane@example.com")),
+				observation: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
 			},
 		},
-		"FailedToUpdate": {
-			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				var bpm *storagev1.Policy
-				defer r.Body.Close()
-				switch r.Method {
-				case http.MethodGet:
-					bpm = &storagev1.Policy{
-						Bindings: []*storagev1.PolicyBindings{
-							{
-								Members: []string{"another-member"},
-								Role:    testRole,
-							},
-						},
-					}
-					w.WriteHeader(http.StatusOK)
-				case http.MethodPut:
-					bpm = &storagev1.Policy{}
-					w.WriteHeader(http.StatusInternalServerError)
-				default:
-					w.WriteHeader(http.StatusBadRequest)
-				}
-
-				_ = json.NewEncoder(w).Encode(bpm)
-			}),
+		"Renamed": {
+			reason:   "A binding whose spec no longer matches the binding named by its external name should be rebound by Update.",
+			policies: bpmPolicies(&storagev1.PolicyBindings{Role: "roles/storage.objectViewer", Members: []string{testMember}}),
 			args: args{
 				ctx: context.Background(),
 				mg: BucketPolicyMember(
-					bpmWithName(bpmMetadataName),
-					bpmWithExternalNameAnnotation(bpmMetadataName),
-					bpmWithCondition(xpv1.Available())),
+					bpmWithExternalNameAnnotation(testBucketName + "/roles/storage.objectViewer/" + testMember),
+				),
 			},
 			want: want{
 				mg: BucketPolicyMember(
-					bpmWithName(bpmMetadataName),
-					bpmWithExternalNameAnnotation(bpmMetadataName),
-					bpmWithCondition(xpv1.Available())),
-				err: errors.Wrap(gError(http.StatusInternalServerError, "{}\n"), errSetPolicy),
+					bpmWithExternalNameAnnotation(testBucketName+"/roles/storage.objectViewer/"+testMember),
+					bpmWithEtag(bpmEtag),
+				),
+				observation: managed.ExternalObservation{ResourceExists: true},
+				bindings:    []*storagev1.PolicyBindings{{Role: "roles/storage.objectViewer", Members: []string{testMember}}},
 			},
 		},
+		"BindingRemovedOutOfBand": {
+			reason:   "A member removed from its binding out of band should be observed as unbound, while the other members are kept.",
+			policies: bpmPolicies(&storagev1.PolicyBindings{Role: testRole, Members: []string{other}}),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyMember(),
+			},
+			want: want{
+				mg:       BucketPolicyMember(bpmWithBindingMembers(other), bpmWithEtag(bpmEtag)),
+				bindings: []*storagev1.PolicyBindings{{Role: testRole, Members: []string{other}}},
+			},
+		},
+		"DeletedPartiallyBound": {
+			reason:   "A deleted binding should exist while any of its members is still bound, e.g. once others were unbound out of band, so that they are unbound.",
+			policies: bpmPolicies(&storagev1.PolicyBindings{Role: testRole, Members: []string{jane, testMember}}),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyMember(bpmWithMembers(other), bpmWithDeletionTimestamp(deleted)),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithMembers(other),
+					bpmWithDeletionTimestamp(deleted),
+					bpmWithBindingMembers(testMember, jane),
+					bpmWithEtag(bpmEtag)),
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				bindings:    []*storagev1.PolicyBindings{{Role: testRole, Members: []string{jane, testMember}}},
+			},
+		},
+		"DeletedUnbound": {
+			reason:   "A deleted binding none of whose members is bound should not exist.",
+			policies: bpmPolicies(&storagev1.PolicyBindings{Role: testRole, Members: []string{jane}}),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyMember(bpmWithMembers(other), bpmWithDeletionTimestamp(deleted)),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithMembers(other),
+					bpmWithDeletionTimestamp(deleted),
+					bpmWithBindingMembers(jane),
+					bpmWithEtag(bpmEtag)),
+				bindings: []*storagev1.PolicyBindings{{Role: testRole, Members: []string{jane}}},
+			},
+		},
+		"ObservedOtherRoles": {
+			reason: "The members bound to the role by others should be observed, but not those bound to other roles.",
+			policies: bpmPolicies(
+				&storagev1.PolicyBindings{Role: testRole, Members: []string{testMember, other}},
+				&storagev1.PolicyBindings{Role: "roles/storage.objectViewer", Members: []string{jane}}),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyMember(),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithCondition(xpv1.Available()),
+					bpmWithBindingMembers(other, testMember),
+					bpmWithEtag(bpmEtag)),
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				bindings: []*storagev1.PolicyBindings{
+					{Role: testRole, Members: []string{testMember, other}},
+					{Role: "roles/storage.objectViewer", Members: []string{jane}},
+				},
+			},
+		},
+		"MembersRemoved": {
+			reason:   "A binding should not be up to date while members removed from it since it was last applied are still bound.",
+			policies: bpmPolicies(&storagev1.PolicyBindings{Role: testRole, Members: []string{other, testMember, jane}}),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyMember(bpmWithMembers(jane), bpmWithLastAppliedBinding(), bpmWithMembers()),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithMembers(jane),
+					bpmWithLastAppliedBinding(),
+					bpmWithMembers(),
+					bpmWithBindingMembers(other, testMember, jane),
+					bpmWithEtag(bpmEtag)),
+				observation: managed.ExternalObservation{ResourceExists: true},
+				bindings:    []*storagev1.PolicyBindings{{Role: testRole, Members: []string{other, testMember, jane}}},
+			},
+		},
+		"DuplicateMembers": {
+			reason:   "A member listed twice in its binding should be listed once after it is observed.",
+			policies: bpmPolicies(&storagev1.PolicyBindings{Role: testRole, Members: []string{testMember, other, testMember}}),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyMember(bpmWithLastAppliedBinding()),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithLastAppliedBinding(),
+					bpmWithCondition(xpv1.Available()),
+					bpmWithBindingMembers(other, testMember),
+					bpmWithEtag(bpmEtag)),
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				bindings:    []*storagev1.PolicyBindings{{Role: testRole, Members: []string{testMember, other}}},
+				events:      []event.Event{event.Normal(reasonNormalized, "normalized role "+testRole+" of member "+testMember+" on bucket "+testBucketName)},
+			},
+		},
+		"DuplicateBindings": {
+			reason: "Duplicate bindings of the role should be merged, and empty bindings removed, after they are observed.",
+			policies: bpmPolicies(
+				&storagev1.PolicyBindings{Role: testRole, Members: []string{testMember}},
+				&storagev1.PolicyBindings{Role: "roles/storage.objectViewer", Members: []string{}},
+				&storagev1.PolicyBindings{Role: testRole, Members: []string{testMember, other}}),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyMember(bpmWithLastAppliedBinding()),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithLastAppliedBinding(),
+					bpmWithCondition(xpv1.Available()),
+					bpmWithBindingMembers(other, testMember),
+					bpmWithEtag(bpmEtag)),
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				bindings:    []*storagev1.PolicyBindings{{Role: testRole, Members: []string{testMember, other}}},
+				events:      []event.Event{event.Normal(reasonNormalized, "normalized role "+testRole+" of member "+testMember+" on bucket "+testBucketName)},
+			},
+		},
+		"BucketNotFound": {
+			reason:   "The binding of a bucket that does not exist should not exist either, rather than failing to be observed.",
+			policies: map[string]*storagev1.Policy{},
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyMember(),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithCondition(xpv1.Condition{
+						Type:    gcp.TypeIAMPolicyError,
+						Status:  corev1.ConditionTrue,
+						Reason:  gcp.ReasonResourceNotFound,
+						Message: "the bucket " + testBucketName + " does not exist: create it, or reference an existing bucket",
+					}),
+					bpmWithCondition(xpv1.Unavailable())),
+			},
+		},
+		"ImportedConditionalBinding": {
+			reason:   "The condition of an imported conditional binding should be late initialized.",
+			policies: bpmPolicies(conditional),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyMember(),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithPolicyCondition(imported),
+					bpmWithCondition(xpv1.Available()),
+					bpmWithBindingMembers(testMember),
+					bpmWithEtag(bpmEtag)),
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+				bindings:    []*storagev1.PolicyBindings{conditional},
+			},
+		},
+		"ImportedBucket": {
+			reason:   "The bucket of an imported binding should be late initialized from its external name.",
+			policies: bpmPolicies(unconditional),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyMember(func(i *v1alpha1.BucketPolicyMember) { i.Spec.ForProvider.Bucket = nil }),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithCondition(xpv1.Available()),
+					bpmWithBindingMembers(testMember),
+					bpmWithEtag(bpmEtag)),
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+				bindings:    []*storagev1.PolicyBindings{unconditional},
+			},
+		},
+		"ConditionSet": {
+			reason:   "A condition that is set should not be overwritten by the condition of the observed binding.",
+			policies: bpmPolicies(conditional),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyMember(bpmWithPolicyCondition(ambiguous)),
+			},
+			want: want{
+				mg:       BucketPolicyMember(bpmWithPolicyCondition(ambiguous), bpmWithEtag(bpmEtag)),
+				bindings: []*storagev1.PolicyBindings{conditional},
+			},
+		},
+		"UnconditionalBinding": {
+			reason:   "A BucketPolicyMember without a condition whose member is bound unconditionally should not adopt the condition of another binding.",
+			policies: bpmPolicies(conditional, unconditional),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyMember(),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithCondition(xpv1.Available()),
+					bpmWithBindingMembers(testMember),
+					bpmWithEtag(bpmEtag)),
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				bindings:    []*storagev1.PolicyBindings{conditional, unconditional},
+			},
+		},
+		"AppliedBinding": {
+			reason:   "A binding the provider bound itself is not imported, so its condition should not be late initialized.",
+			policies: bpmPolicies(conditional),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyMember(bpmWithLastAppliedBinding()),
+			},
+			want: want{
+				mg:       BucketPolicyMember(bpmWithLastAppliedBinding(), bpmWithEtag(bpmEtag)),
+				bindings: []*storagev1.PolicyBindings{conditional},
+			},
+		},
+		"AmbiguousConditions": {
+			reason: "The condition should not be late initialized if the member is bound by several conditional bindings.",
+			policies: bpmPolicies(conditional, &storagev1.PolicyBindings{
+				Role:      testRole,
+				Members:   []string{testMember},
+				Condition: &storagev1.Expr{Title: gcp.StringValue(ambiguous.Title), Expression: ambiguous.Expression},
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyMember(),
+			},
+			want: want{
+				mg: BucketPolicyMember(bpmWithEtag(bpmEtag)),
+				bindings: []*storagev1.PolicyBindings{conditional, {
+					Role:      testRole,
+					Members:   []string{testMember},
+					Condition: &storagev1.Expr{Title: gcp.StringValue(ambiguous.Title), Expression: ambiguous.Expression},
+				}},
+			},
+		},
+		"RemovedExpired": {
+			reason:   "An expired binding should be removed and reported as unavailable.",
+			policies: bpmPolicies(expirable(expired, testMember, jane)),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyMember(bpmWithExpiry(expired), bpmWithRemoveExpired()),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithExpiry(expired),
+					bpmWithRemoveExpired(),
+					bpmWithCondition(gcp.Expired(expiredAt)),
+					bpmWithBindingMembers(testMember, jane),
+					bpmWithEtag(bpmEtag)),
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				bindings:    []*storagev1.PolicyBindings{expirable(expired, jane)},
+				events:      []event.Event{event.Normal(reasonRemovedExpired, "removed expired "+expiring+" on bucket "+testBucketName)},
+			},
+		},
+		"ExpiredAlreadyRemoved": {
+			reason:   "An expired binding that was removed should not be bound again.",
+			policies: bpmPolicies(),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyMember(bpmWithExpiry(expired), bpmWithRemoveExpired()),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithExpiry(expired),
+					bpmWithRemoveExpired(),
+					bpmWithCondition(gcp.Expired(expiredAt)),
+					bpmWithEtag(bpmEtag)),
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"ExpiredDryRun": {
+			reason:   "The removal of an expired binding should only be planned in dry-run mode.",
+			policies: bpmPolicies(expirable(expired, testMember)),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyMember(bpmWithExpiry(expired), bpmWithRemoveExpired(), bpmWithDryRun()),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithExpiry(expired),
+					bpmWithRemoveExpired(),
+					bpmWithDryRun(),
+					bpmWithCondition(gcp.Expired(expiredAt)),
+					bpmWithCondition(xpv1.Condition{Type: gcp.TypeDryRun, Status: corev1.ConditionTrue, Reason: gcp.ReasonChangePlanned, Message: "would remove expired " + expiring}),
+					bpmWithBindingMembers(testMember),
+					bpmWithEtag(bpmEtag)),
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				bindings:    []*storagev1.PolicyBindings{expirable(expired, testMember)},
+				events:      []event.Event{event.Normal(reasonDryRun, "would remove expired "+expiring)},
+			},
+		},
+		"ExpiredNotAnnotated": {
+			reason:   "An expired binding should be kept unless its removal was opted into.",
+			policies: bpmPolicies(expirable(expired, testMember)),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyMember(bpmWithExpiry(expired)),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithExpiry(expired),
+					bpmWithCondition(xpv1.Available()),
+					bpmWithBindingMembers(testMember),
+					bpmWithEtag(bpmEtag)),
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				bindings:    []*storagev1.PolicyBindings{expirable(expired, testMember)},
+			},
+		},
+		"NotYetExpired": {
+			reason:   "A binding that has not yet expired should be kept.",
+			policies: bpmPolicies(expirable(pending, testMember)),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyMember(bpmWithExpiry(pending), bpmWithRemoveExpired()),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithExpiry(pending),
+					bpmWithRemoveExpired(),
+					bpmWithCondition(xpv1.Available()),
+					bpmWithBindingMembers(testMember),
+					bpmWithEtag(bpmEtag)),
+				observation: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				bindings:    []*storagev1.PolicyBindings{expirable(pending, testMember)},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			buckets, store := bpmBuckets(t, tc.handler, tc.policies)
+			record := &bpmRecorder{}
+			e := &bucketPolicyMemberExternal{bucketpolicy: buckets, record: record}
+			obs, err := e.Observe(context.Background(), tc.args.mg)
+
+			if err != nil {
+				if tc.want.err != nil {
+					// we expected a different error than we got
+					if diff := cmp.Diff(tc.want.err.Error(), err.Error()); diff != "" {
+						t.Errorf("Observe(...): want error string != got error string:\n%s", diff)
+					}
+				} else {
+					t.Errorf("Observe(...): unexpected error %s", err)
+				}
+			} else {
+				if tc.want.err != nil {
+					t.Errorf("Observe(...) want error %s got nil", tc.want.err)
+				}
+			}
+
+			if diff := cmp.Diff(tc.want.observation, obs); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg, cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if store != nil {
+				if diff := cmp.Diff(tc.want.bindings, bpmBindings(store)); diff != "" {
+					t.Errorf("\n%s\nObserve(...): -want bindings, +got:\n%s", tc.reason, diff)
+				}
+			}
+			if diff := cmp.Diff(tc.want.events, record.events); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want events, +got:\n%s", tc.reason, diff)
+			}
+		})
 	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			server := httptest.NewServer(tc.handler)
-			defer server.Close()
-			s, _ := storagev1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
-			buckets := storagev1.NewBucketsService(s)
-			e := &bucketPolicyMemberExternal{bucketpolicy: buckets}
-			_, err := e.Update(context.Background(), tc.args.mg)
-			if err != nil {
-				if tc.want.err != nil {
-					// we expected a different error than we got
-					if diff := cmp.Diff(tc.want.err.Error(), err.Error()); diff != "" {
-						t.Errorf("Update(...): want error string != got error string:\n%s", diff)
-					}
-				} else {
-					t.Errorf("Update(...): unexpected error %s", err)
-				}
-			} else {
-				if tc.want.err != nil {
-					t.Errorf("Update(...) want error %s got nil", tc.want.err)
-				}
-			}
-
-			if diff := cmp.Diff(tc.want.mg, tc.args.mg); diff != "" {
-				t.Errorf("Update(...): -want, +got:\n%s", diff)
-			}
-		})
-	}
-}
-
-func TestBucketPolicyMemberDelete(t *testing.T) {
-	type args struct {
-		ctx context.Context
-		mg  resource.Managed
-	}
+}
+
+func TestBucketPolicyMemberCreate(t *testing.T) {
+	imported := testBucketName + "/roles/storage.objectViewer/user:jane@example.com"
+	other := "group:team@example.com"
+
 	type want struct {
+		mg       resource.Managed
+		bindings []*storagev1.PolicyBindings
+	}
+	cases := map[string]struct {
+		reason   string
+		policies map[string]*storagev1.Policy
+		mg       *v1alpha1.BucketPolicyMember
+		want     want
+	}{
+		"Unnamed": {
+			reason:   "A BucketPolicyMember should be named after the binding it creates.",
+			policies: bpmPolicies(),
+			mg:       BucketPolicyMember(bpmWithExternalNameAnnotation("")),
+			want: want{
+				mg:       BucketPolicyMember(bpmWithLastAppliedBinding()),
+				bindings: []*storagev1.PolicyBindings{{Role: testRole, Members: []string{testMember}}},
+			},
+		},
+		"Imported": {
+			reason:   "The binding named by the external name of a BucketPolicyMember should be created.",
+			policies: bpmPolicies(),
+			mg:       BucketPolicyMember(bpmWithExternalNameAnnotation(imported), bpmWithImportedSpec()),
+			want: want{
+				mg:       BucketPolicyMember(bpmWithExternalNameAnnotation(imported), bpmWithImportedSpec(), bpmWithLastAppliedBinding()),
+				bindings: []*storagev1.PolicyBindings{{Role: "roles/storage.objectViewer", Members: []string{"user:jane@example.com"}}},
+			},
+		},
+		"MultipleMembers": {
+			reason:   "A BucketPolicyMember that binds several members should keep its name and bind all of them in one policy.",
+			policies: bpmPolicies(),
+			mg: BucketPolicyMember(
+				bpmWithExternalNameAnnotation(bpmMetadataName),
+				bpmWithMembers(other, "user:jane@example.com")),
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithExternalNameAnnotation(bpmMetadataName),
+					bpmWithMembers(other, "user:jane@example.com"),
+					bpmWithLastAppliedBinding()),
+				bindings: []*storagev1.PolicyBindings{{Role: testRole, Members: []string{testMember, other, "user:jane@example.com"}}},
+			},
+		},
+		"BoundAlongsideOthers": {
+			reason:   "A member removed from its binding out of band should be bound again alongside the other members of the binding.",
+			policies: bpmPolicies(&storagev1.PolicyBindings{Role: testRole, Members: []string{other}}),
+			mg:       BucketPolicyMember(),
+			want: want{
+				mg:       BucketPolicyMember(bpmWithLastAppliedBinding()),
+				bindings: []*storagev1.PolicyBindings{{Role: testRole, Members: []string{other, testMember}}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			buckets, store := bpmBuckets(t, nil, tc.policies)
+			e := &bucketPolicyMemberExternal{bucketpolicy: buckets, record: &bpmRecorder{}}
+			if _, err := e.Create(context.Background(), tc.mg); err != nil {
+				t.Errorf("\n%s\nCreate(...): unexpected error %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.mg, tc.mg); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.bindings, bpmBindings(store)); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want bindings, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// setMembers returns a handler that serves a bucket policy binding the test
+// role to the supplied observed members, and expects it to be set with the
+// supplied desired members bound to the role.
+func setMembers(t *testing.T, observed, desired []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		if r.Method == http.MethodPut {
+			i := &storagev1.Policy{}
+			_ = json.NewDecoder(r.Body).Decode(i)
+			want := []*storagev1.PolicyBindings{{Role: testRole, Members: desired}}
+			if diff := cmp.Diff(want, i.Bindings); diff != "" {
+				t.Errorf("SetIamPolicy(...): -want bindings, +got bindings:\n%s", diff)
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(&storagev1.Policy{
+			Bindings: []*storagev1.PolicyBindings{{Role: testRole, Members: observed}},
+		})
+	})
+}
+
+// setPolicyRejected returns a handler that serves an empty bucket policy and
+// rejects setting it with the supplied status code and error body.
+func setPolicyRejected(code int, body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+			return
+		}
+		w.WriteHeader(code)
+		_, _ = w.Write([]byte(body))
+	})
+}
+
+func TestBucketPolicyMemberUpdate(t *testing.T) {
+	large := func() *storagev1.Policy {
+		members := make([]string, 5000)
+		for i := range members {
+			members[i] = fmt.Sprintf("serviceAccount:sa-%d@wesaas-playground.iam.gserviceaccount.com", i)
+		}
+		return &storagev1.Policy{Bindings: []*storagev1.PolicyBindings{{Role: "another-role", Members: members}}}
+	}
+	tooLarge := large()
+	bucketpolicy.BindRoleToMember(BucketPolicyMember().Spec.ForProvider, tooLarge)
+
+	type args struct {
+		ctx context.Context
 		mg  resource.Managed
-		err error
+	}
+	type want struct {
+		mg       resource.Managed
+		err      error
+		bindings []*storagev1.PolicyBindings
+		calls    map[string]int
 	}
 
+	other := "group:team@example.com"
+	jane := "user:jane@example.com"
+	deleted := "deleted:serviceAccount:sa@example.com?uid=123"
+	bound := &storagev1.PolicyBindings{Role: testRole, Members: []string{testMember}}
+
 	cases := map[string]struct {
-		handler http.Handler
-		args    args
-		want    want
+		reason   string
+		handler  http.Handler
+		policies map[string]*storagev1.Policy
+		args     args
+		want     want
 	}{
 		"NotBucketPolicyMember": {
 			args: args{
@@ -457,21 +969,21 @@ func TestBucketPolicyMemberDelete(t *testing.T) {
 				err: errors.New(errNotBucketPolicyMember),
 			},
 		},
-		"DeleteSucceeded": {
+		"UpdateSucceeded": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var bpm *storagev1.Policy
 				defer r.Body.Close()
 				switch r.Method {
 				case http.MethodGet:
-					i := &storagev1.Policy{
+					bpm = &storagev1.Policy{
 						Bindings: []*storagev1.PolicyBindings{
 							{
-								Members: []string{testMember, "another-member"},
-								Role:    testRole,
+								Members: []string{"another-member"},
+								Role:    "another-role",
 							},
 						},
 					}
 					w.WriteHeader(http.StatusOK)
-					_ = json.NewEncoder(w).Encode(i)
 				case http.MethodPut:
 					i := &storagev1.Policy{}
 					b, err := ioutil.ReadAll(r.Body)
@@ -482,77 +994,202 @@ func TestBucketPolicyMemberDelete(t *testing.T) {
 					if diff := cmp.Diff(err, nil); diff != "" {
 						t.Errorf("r: -want, +got:\n%s", diff)
 					}
-					exp := &storagev1.Policy{
+					bpm = &storagev1.Policy{
 						Bindings: []*storagev1.PolicyBindings{
 							{
 								Members: []string{"another-member"},
+								Role:    "another-role",
+							},
+							{
+								Members: []string{testMember},
 								Role:    testRole,
 							},
 						},
 					}
-					if !bucketpolicy.ArePoliciesSame(exp, i) {
-						t.Errorf("policy in setIamPolicyRequest not equal to expected, diff: %s", cmp.Diff(exp, i, cmpopts.IgnoreFields(storagev1.Policy{}, "Version")))
+					if !bucketpolicy.ArePoliciesSame(bpm, i) {
+						t.Errorf("policy in setIamPolicyRequest not equal to expected, diff: %s", cmp.Diff(bpm, i, cmpopts.IgnoreFields(storagev1.Policy{}, "Version")))
 					}
 					w.WriteHeader(http.StatusOK)
-					_ = json.NewEncoder(w).Encode(exp)
 				default:
 					w.WriteHeader(http.StatusBadRequest)
-					_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
 				}
 
+				_ = json.NewEncoder(w).Encode(bpm)
 			}),
 			args: args{
 				ctx: context.Background(),
 				mg: BucketPolicyMember(
 					bpmWithName(bpmMetadataName),
-					bpmWithExternalNameAnnotation(bpmMetadataName)),
+					bpmWithExternalNameAnnotation(bpmMetadataName),
+					bpmWithCondition(xpv1.Available())),
 			},
 			want: want{
 				mg: BucketPolicyMember(
 					bpmWithName(bpmMetadataName),
-					bpmWithExternalNameAnnotation(bpmMetadataName)),
+					bpmWithExternalNameAnnotation(bpmMetadataName),
+					bpmWithCondition(xpv1.Available()),
+					bpmWithLastAppliedBinding()),
 			},
 		},
-		"DeleteFailedWhileGetting": {
-			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				w.WriteHeader(http.StatusInternalServerError)
-				_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
-			}),
+		"MembersPartiallyBound": {
+			handler: setMembers(t,
+				[]string{"user:external@example.com", "group:team@example.com"},
+				[]string{"user:external@example.com", "group:team@example.com", testMember, "user:jane@example.com"}),
 			args: args{
 				ctx: context.Background(),
 				mg: BucketPolicyMember(
 					bpmWithName(bpmMetadataName),
-					bpmWithExternalNameAnnotation(bpmMetadataName)),
+					bpmWithMembers("group:team@example.com", "user:jane@example.com")),
 			},
 			want: want{
 				mg: BucketPolicyMember(
 					bpmWithName(bpmMetadataName),
-					bpmWithExternalNameAnnotation(bpmMetadataName)),
-				err: errors.Wrap(gError(http.StatusInternalServerError, "{}\n"), errGetPolicy),
+					bpmWithMembers("group:team@example.com", "user:jane@example.com"),
+					bpmWithLastAppliedBinding()),
 			},
 		},
-		"DeleteFailedWhileSetting": {
-			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				switch r.Method {
-				case http.MethodGet:
-					p := &storagev1.Policy{
-						Bindings: []*storagev1.PolicyBindings{
-							{
-								Members: []string{testMember},
-								Role:    testRole,
-							},
+		"NoMembers": {
+			args: args{
+				ctx: context.Background(),
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName),
+					func(i *v1alpha1.BucketPolicyMember) { i.Spec.ForProvider.Member = nil }),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName),
+					func(i *v1alpha1.BucketPolicyMember) { i.Spec.ForProvider.Member = nil }),
+				err: errors.New(errNoMembers),
+			},
+		},
+		"InvalidMember": {
+			args: args{
+				ctx: context.Background(),
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithMember("perfect-test-sa@my-project.iam.gserviceaccount.com")),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithMember("perfect-test-sa@my-project.iam.gserviceaccount.com")),
+				err: errors.Errorf(`invalid member %q: must be allUsers, allAuthenticatedUsers, a principal:// or principalSet:// identifier, or an email address or domain prefixed with its identity type, e.g. user:jane@example.com, serviceAccount:, group: or domain:example.com`, "perfect-test-sa@my-project.iam.gserviceaccount.com"),
+			},
+		},
+		"InvalidRole": {
+			args: args{
+				ctx: context.Background(),
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithRole("storage.objectAdmin")),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithRole("storage.objectAdmin")),
+				err: errors.Errorf(`invalid role %q: must be a predefined role, e.g. roles/storage.objectViewer, or a custom role, e.g. projects/my-project/roles/myRole or organizations/123/roles/myRole`, "storage.objectAdmin"),
+			},
+		},
+		"FailedToGet": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+				_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName)),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName)),
+				err: errors.Wrap(gError(http.StatusInternalServerError, "{}\n"), errGetPolicy),
+			},
+		},
+		"AlreadyUpToDate": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				bpm := &storagev1.Policy{
+					Bindings: []*storagev1.PolicyBindings{
+						{
+							Members: []string{testMember},
+							Role:    testRole,
 						},
+					},
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(bpm)
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName),
+					bpmWithCondition(xpv1.Available())),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName),
+					bpmWithCondition(xpv1.Available()),
+					bpmWithLastAppliedBinding()),
+			},
+		},
+		"ChangedConcurrently": {
+			handler: func() http.Handler {
+				sets := 0
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					defer r.Body.Close()
+					switch r.Method {
+					case http.MethodGet:
+						w.WriteHeader(http.StatusOK)
+						_ = json.NewEncoder(w).Encode(&storagev1.Policy{Etag: "BwWKmjvelug="})
+					case http.MethodPut:
+						// The policy was changed by another actor since it
+						// was read, so its etag no longer matches.
+						sets++
+						if sets > 1 {
+							t.Errorf("SetIamPolicy(...): policy was set again after a concurrent change")
+						}
+						i := &storagev1.Policy{}
+						_ = json.NewDecoder(r.Body).Decode(i)
+						if diff := cmp.Diff("BwWKmjvelug=", i.Etag); diff != "" {
+							t.Errorf("SetIamPolicy(...): -want etag, +got etag:\n%s", diff)
+						}
+						w.WriteHeader(http.StatusPreconditionFailed)
+						_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+					default:
+						w.WriteHeader(http.StatusBadRequest)
 					}
-					w.WriteHeader(http.StatusOK)
-					_ = json.NewEncoder(w).Encode(p)
-				case http.MethodPut:
-					w.WriteHeader(http.StatusInternalServerError)
-					_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
-				default:
+				})
+			}(),
+			args: args{
+				ctx: context.Background(),
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName)),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName),
+					bpmWithCondition(iamPolicyFailed(gError(http.StatusPreconditionFailed, "{}\n")))),
+				err: errors.Wrap(gError(http.StatusPreconditionFailed, "{}\n"), errPolicyChanged),
+			},
+		},
+		"PolicyTooLarge": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				if r.Method != http.MethodGet {
+					t.Errorf("SetIamPolicy(...): policy was set although it is too large")
 					w.WriteHeader(http.StatusBadRequest)
-					_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+					return
 				}
-
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(large())
 			}),
 			args: args{
 				ctx: context.Background(),
@@ -564,14 +1201,16 @@ func TestBucketPolicyMemberDelete(t *testing.T) {
 				mg: BucketPolicyMember(
 					bpmWithName(bpmMetadataName),
 					bpmWithExternalNameAnnotation(bpmMetadataName)),
-				err: errors.Wrap(gError(http.StatusInternalServerError, "{}\n"), errSetPolicy),
+				err: bucketpolicy.ValidatePolicySize(tooLarge),
 			},
 		},
-		"AlreadyDeletedMemberNotThere": {
+		"FailedToUpdate": {
 			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var bpm *storagev1.Policy
+				defer r.Body.Close()
 				switch r.Method {
 				case http.MethodGet:
-					p := &storagev1.Policy{
+					bpm = &storagev1.Policy{
 						Bindings: []*storagev1.PolicyBindings{
 							{
 								Members: []string{"another-member"},
@@ -580,38 +1219,54 @@ func TestBucketPolicyMemberDelete(t *testing.T) {
 						},
 					}
 					w.WriteHeader(http.StatusOK)
-					_ = json.NewEncoder(w).Encode(p)
+				case http.MethodPut:
+					bpm = &storagev1.Policy{}
+					w.WriteHeader(http.StatusInternalServerError)
 				default:
 					w.WriteHeader(http.StatusBadRequest)
-					_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
 				}
 
+				_ = json.NewEncoder(w).Encode(bpm)
 			}),
 			args: args{
 				ctx: context.Background(),
 				mg: BucketPolicyMember(
 					bpmWithName(bpmMetadataName),
-					bpmWithExternalNameAnnotation(bpmMetadataName)),
+					bpmWithExternalNameAnnotation(bpmMetadataName),
+					bpmWithCondition(xpv1.Available())),
 			},
 			want: want{
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName),
+					bpmWithCondition(xpv1.Available()),
+					bpmWithCondition(iamPolicyFailed(gError(http.StatusInternalServerError, "{}\n")))),
+				err: errors.Wrap(gError(http.StatusInternalServerError, "{}\n"), errSetPolicy),
+			},
+		},
+		"PermissionDenied": {
+			handler: setPolicyRejected(http.StatusForbidden, `{"error":{"code":403,"message":"caller does not have storage.buckets.setIamPolicy access"}}`),
+			args: args{
+				ctx: context.Background(),
 				mg: BucketPolicyMember(
 					bpmWithName(bpmMetadataName),
 					bpmWithExternalNameAnnotation(bpmMetadataName)),
 			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName),
+					bpmWithCondition(xpv1.Condition{
+						Type:    gcp.TypeIAMPolicyError,
+						Status:  corev1.ConditionTrue,
+						Reason:  gcp.ReasonPermissionDenied,
+						Message: "permission denied: the provider's credentials are not allowed to set the IAM policy of the bucket: caller does not have storage.buckets.setIamPolicy access",
+					})),
+				err: errors.Wrap(gError(http.StatusForbidden, `{"error":{"code":403,"message":"caller does not have storage.buckets.setIamPolicy access"}}`), errSetPolicy),
+			},
 		},
-		"AlreadyDeletedEmptyPolicy": {
-			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-				switch r.Method {
-				case http.MethodGet:
-					p := &storagev1.Policy{}
-					w.WriteHeader(http.StatusOK)
-					_ = json.NewEncoder(w).Encode(p)
-				default:
-					w.WriteHeader(http.StatusBadRequest)
-					_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
-				}
-
-			}),
+		"BucketNotFound": {
+			handler: setPolicyRejected(http.StatusNotFound, `{"error":{"code":404,"message":"The specified bucket does not exist."}}`),
 			args: args{
 				ctx: context.Background(),
 				mg: BucketPolicyMember(
@@ -619,20 +1274,138 @@ func TestBucketPolicyMemberDelete(t *testing.T) {
 					bpmWithExternalNameAnnotation(bpmMetadataName)),
 			},
 			want: want{
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName),
+					bpmWithCondition(xpv1.Condition{
+						Type:    gcp.TypeIAMPolicyError,
+						Status:  corev1.ConditionTrue,
+						Reason:  gcp.ReasonResourceNotFound,
+						Message: "the bucket does not exist: The specified bucket does not exist.",
+					})),
+				err: errors.Wrap(gError(http.StatusNotFound, `{"error":{"code":404,"message":"The specified bucket does not exist."}}`), errSetPolicy),
+			},
+		},
+		"MalformedBinding": {
+			handler: setPolicyRejected(http.StatusBadRequest, `{"error":{"code":400,"message":"Invalid argument"}}`),
+			args: args{
+				ctx: context.Background(),
 				mg: BucketPolicyMember(
 					bpmWithName(bpmMetadataName),
 					bpmWithExternalNameAnnotation(bpmMetadataName)),
 			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName),
+					bpmWithCondition(xpv1.Condition{
+						Type:    gcp.TypeIAMPolicyError,
+						Status:  corev1.ConditionTrue,
+						Reason:  gcp.ReasonMalformedPolicy,
+						Message: "the IAM policy is malformed, e.g. a role, member or condition of one of its bindings is invalid: Invalid argument",
+					})),
+				err: errors.Wrap(gError(http.StatusBadRequest, `{"error":{"code":400,"message":"Invalid argument"}}`), errSetPolicy),
+			},
+		},
+		"LabelsChanged": {
+			reason:   "A change that leaves the last applied binding unchanged should not call the GCP API.",
+			policies: bpmPolicies(bound),
+			args: args{
+				ctx: context.Background(),
+				mg: BucketPolicyMember(bpmWithLastAppliedBinding(), func(i *v1alpha1.BucketPolicyMember) {
+					i.SetLabels(map[string]string{"team": "core"})
+				}),
+			},
+			want: want{
+				mg: BucketPolicyMember(bpmWithLastAppliedBinding(), func(i *v1alpha1.BucketPolicyMember) {
+					i.SetLabels(map[string]string{"team": "core"})
+				}),
+				bindings: []*storagev1.PolicyBindings{bound},
+				calls:    map[string]int{},
+			},
+		},
+		"RoleChanged": {
+			reason:   "A change of the role of the last applied binding should bind it in the policy of the bucket.",
+			policies: bpmPolicies(bound),
+			args: args{
+				ctx: context.Background(),
+				mg: BucketPolicyMember(
+					bpmWithLastAppliedBinding(),
+					bpmWithExternalNameAnnotation(bpmMetadataName),
+					bpmWithRole("roles/storage.objectViewer")),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithExternalNameAnnotation(bpmMetadataName),
+					bpmWithRole("roles/storage.objectViewer"),
+					bpmWithLastAppliedBinding()),
+				bindings: []*storagev1.PolicyBindings{bound, {Role: "roles/storage.objectViewer", Members: []string{testMember}}},
+				calls:    map[string]int{http.MethodGet: 1, http.MethodPut: 1},
+			},
+		},
+		"Renamed": {
+			reason:   "A binding renamed by a change of its role should be bound, the binding named by its external name unbound, and it should be named after its spec.",
+			policies: bpmPolicies(&storagev1.PolicyBindings{Role: "roles/storage.objectViewer", Members: []string{other, testMember}}),
+			args: args{
+				ctx: context.Background(),
+				mg: BucketPolicyMember(
+					bpmWithLastAppliedBinding(),
+					bpmWithExternalNameAnnotation(testBucketName+"/roles/storage.objectViewer/"+testMember)),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithExternalNameAnnotation(testBucketName+"/"+testRole+"/"+testMember),
+					bpmWithLastAppliedBinding()),
+				bindings: []*storagev1.PolicyBindings{{Role: "roles/storage.objectViewer", Members: []string{other}}, bound},
+			},
+		},
+		"MembersRemoved": {
+			reason:   "Only the members removed from the BucketPolicyMember since it was last applied should be unbound, keeping those bound by others.",
+			policies: bpmPolicies(&storagev1.PolicyBindings{Role: testRole, Members: []string{other, testMember, jane}}),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyMember(bpmWithMembers(jane), bpmWithLastAppliedBinding(), bpmWithMembers()),
+			},
+			want: want{
+				mg:       BucketPolicyMember(bpmWithMembers(), bpmWithLastAppliedBinding()),
+				bindings: []*storagev1.PolicyBindings{{Role: testRole, Members: []string{other, testMember}}},
+			},
+		},
+		"DeletedMembersKept": {
+			reason:   "Deleted members should be left in the binding unless they are pruned.",
+			policies: bpmPolicies(&storagev1.PolicyBindings{Role: testRole, Members: []string{deleted, testMember}}),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyMember(bpmWithLastAppliedBinding()),
+			},
+			want: want{
+				mg:       BucketPolicyMember(bpmWithLastAppliedBinding()),
+				bindings: []*storagev1.PolicyBindings{{Role: testRole, Members: []string{deleted, testMember}}},
+			},
+		},
+		"DeletedMembersPruned": {
+			reason:   "Deleted members should be removed from the binding once they are pruned.",
+			policies: bpmPolicies(&storagev1.PolicyBindings{Role: testRole, Members: []string{deleted, testMember}}),
+			args: args{
+				ctx: context.Background(),
+				mg: BucketPolicyMember(func(i *v1alpha1.BucketPolicyMember) {
+					i.Spec.ForProvider.PruneDeletedMembers = gcp.BoolPtr(true)
+				}, bpmWithLastAppliedBinding()),
+			},
+			want: want{
+				mg: BucketPolicyMember(func(i *v1alpha1.BucketPolicyMember) {
+					i.Spec.ForProvider.PruneDeletedMembers = gcp.BoolPtr(true)
+				}, bpmWithLastAppliedBinding()),
+				bindings: []*storagev1.PolicyBindings{bound},
+			},
 		},
 	}
+
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			server := httptest.NewServer(tc.handler)
-			defer server.Close()
-			s, _ := storagev1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
-			buckets := storagev1.NewBucketsService(s)
-			e := &bucketPolicyMemberExternal{bucketpolicy: buckets}
-			err := e.Delete(context.Background(), tc.args.mg)
+			buckets, store := bpmBuckets(t, tc.handler, tc.policies)
+			e := &bucketPolicyMemberExternal{kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)}, bucketpolicy: buckets, record: &bpmRecorder{}}
+			_, err := e.Update(context.Background(), tc.args.mg)
 			if err != nil {
 				if tc.want.err != nil {
 					// we expected a different error than we got
@@ -649,7 +1422,1780 @@ func TestBucketPolicyMemberDelete(t *testing.T) {
 			}
 
 			if diff := cmp.Diff(tc.want.mg, tc.args.mg); diff != "" {
-				t.Errorf("Update(...): -want, +got:\n%s", diff)
+				t.Errorf("\n%s\nUpdate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if store == nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.bindings, bpmBindings(store)); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want bindings, +got:\n%s", tc.reason, diff)
+			}
+			if tc.want.calls == nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.calls, store.Calls()); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want calls to the GCP API, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestBucketPolicyMemberDelete(t *testing.T) {
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+	type want struct {
+		mg       resource.Managed
+		err      error
+		bindings []*storagev1.PolicyBindings
+	}
+
+	other := "group:team@example.com"
+
+	cases := map[string]struct {
+		reason   string
+		handler  http.Handler
+		policies map[string]*storagev1.Policy
+		args     args
+		want     want
+	}{
+		"NotBucketPolicyMember": {
+			args: args{
+				ctx: context.Background(),
+				mg:  &strange{},
+			},
+			want: want{
+				mg:  &strange{},
+				err: errors.New(errNotBucketPolicyMember),
+			},
+		},
+		"DeleteSucceeded": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				switch r.Method {
+				case http.MethodGet:
+					i := &storagev1.Policy{
+						Bindings: []*storagev1.PolicyBindings{
+							{
+								Members: []string{testMember, "another-member"},
+								Role:    testRole,
+							},
+						},
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(i)
+				case http.MethodPut:
+					i := &storagev1.Policy{}
+					b, err := ioutil.ReadAll(r.Body)
+					if diff := cmp.Diff(err, nil); diff != "" {
+						t.Errorf("r: -want, +got:\n%s", diff)
+					}
+					err = json.Unmarshal(b, i)
+					if diff := cmp.Diff(err, nil); diff != "" {
+						t.Errorf("r: -want, +got:\n%s", diff)
+					}
+					exp := &storagev1.Policy{
+						Bindings: []*storagev1.PolicyBindings{
+							{
+								Members: []string{"another-member"},
+								Role:    testRole,
+							},
+						},
+					}
+					if !bucketpolicy.ArePoliciesSame(exp, i) {
+						t.Errorf("policy in setIamPolicyRequest not equal to expected, diff: %s", cmp.Diff(exp, i, cmpopts.IgnoreFields(storagev1.Policy{}, "Version")))
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(exp)
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+					_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+				}
+
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName)),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName)),
+			},
+		},
+		"DeleteOwnMembersOnly": {
+			handler: setMembers(t,
+				[]string{"user:external@example.com", testMember, "group:team@example.com", "user:jane@example.com"},
+				[]string{"user:external@example.com", "user:jane@example.com"}),
+			args: args{
+				ctx: context.Background(),
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName),
+					bpmWithMembers("group:team@example.com")),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName),
+					bpmWithMembers("group:team@example.com")),
+			},
+		},
+		"OrphanLeavesBinding": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("r: unexpected %s request to %s", r.Method, r.URL.Path)
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName),
+					bpmWithDeletionPolicy(xpv1.DeletionOrphan)),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName),
+					bpmWithDeletionPolicy(xpv1.DeletionOrphan)),
+			},
+		},
+		"DeleteFailedWhileGetting": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+				_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName)),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName)),
+				err: errors.Wrap(gError(http.StatusInternalServerError, "{}\n"), errGetPolicy),
+			},
+		},
+		"DeleteFailedWhileSetting": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					p := &storagev1.Policy{
+						Bindings: []*storagev1.PolicyBindings{
+							{
+								Members: []string{testMember},
+								Role:    testRole,
+							},
+						},
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(p)
+				case http.MethodPut:
+					w.WriteHeader(http.StatusInternalServerError)
+					_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+					_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+				}
+
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName)),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName),
+					bpmWithCondition(iamPolicyFailed(gError(http.StatusInternalServerError, "{}\n")))),
+				err: errors.Wrap(gError(http.StatusInternalServerError, "{}\n"), errSetPolicy),
+			},
+		},
+		"DeleteChangedConcurrently": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				switch r.Method {
+				case http.MethodGet:
+					p := &storagev1.Policy{
+						Bindings: []*storagev1.PolicyBindings{
+							{
+								Members: []string{testMember},
+								Role:    testRole,
+							},
+						},
+						Etag: "BwWKmjvelug=",
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(p)
+				case http.MethodPut:
+					i := &storagev1.Policy{}
+					_ = json.NewDecoder(r.Body).Decode(i)
+					if diff := cmp.Diff("BwWKmjvelug=", i.Etag); diff != "" {
+						t.Errorf("SetIamPolicy(...): -want etag, +got etag:\n%s", diff)
+					}
+					w.WriteHeader(http.StatusConflict)
+					_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+					_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+				}
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName)),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName),
+					bpmWithCondition(iamPolicyFailed(gError(http.StatusConflict, "{}\n")))),
+				err: errors.Wrap(gError(http.StatusConflict, "{}\n"), errPolicyChanged),
+			},
+		},
+		"AlreadyDeletedMemberNotThere": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					p := &storagev1.Policy{
+						Bindings: []*storagev1.PolicyBindings{
+							{
+								Members: []string{"another-member"},
+								Role:    testRole,
+							},
+						},
+					}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(p)
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+					_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+				}
+
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName)),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName)),
+			},
+		},
+		"AlreadyDeletedEmptyPolicy": {
+			handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method {
+				case http.MethodGet:
+					p := &storagev1.Policy{}
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(p)
+				default:
+					w.WriteHeader(http.StatusBadRequest)
+					_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+				}
+
+			}),
+			args: args{
+				ctx: context.Background(),
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName)),
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithName(bpmMetadataName),
+					bpmWithExternalNameAnnotation(bpmMetadataName)),
+			},
+		},
+		"DeletePartiallyBound": {
+			reason:   "The members of a partially bound binding that are still bound should be unbound, keeping the members of others.",
+			policies: bpmPolicies(&storagev1.PolicyBindings{Role: testRole, Members: []string{"user:jane@example.com", testMember}}),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyMember(bpmWithMembers(other)),
+			},
+			want: want{
+				mg:       BucketPolicyMember(bpmWithMembers(other)),
+				bindings: []*storagev1.PolicyBindings{{Role: testRole, Members: []string{"user:jane@example.com"}}},
+			},
+		},
+		"DeleteDuplicateBindings": {
+			reason: "The member should be removed from every binding of the role, and bindings left without members removed.",
+			policies: bpmPolicies(
+				&storagev1.PolicyBindings{Role: testRole, Members: []string{testMember}},
+				&storagev1.PolicyBindings{Role: "roles/storage.objectViewer", Members: []string{other}},
+				&storagev1.PolicyBindings{Role: testRole, Members: []string{other, testMember}},
+				&storagev1.PolicyBindings{Role: testRole, Members: []string{testMember}}),
+			args: args{
+				ctx: context.Background(),
+				mg:  BucketPolicyMember(),
+			},
+			want: want{
+				mg: BucketPolicyMember(),
+				bindings: []*storagev1.PolicyBindings{
+					{Role: testRole, Members: []string{other}},
+					{Role: "roles/storage.objectViewer", Members: []string{other}},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			buckets, store := bpmBuckets(t, tc.handler, tc.policies)
+			e := &bucketPolicyMemberExternal{bucketpolicy: buckets, record: &bpmRecorder{}}
+			err := e.Delete(context.Background(), tc.args.mg)
+			if err != nil {
+				if tc.want.err != nil {
+					// we expected a different error than we got
+					if diff := cmp.Diff(tc.want.err.Error(), err.Error()); diff != "" {
+						t.Errorf("Delete(...): want error string != got error string:\n%s", diff)
+					}
+				} else {
+					t.Errorf("Delete(...): unexpected error %s", err)
+				}
+			} else {
+				if tc.want.err != nil {
+					t.Errorf("Delete(...) want error %s got nil", tc.want.err)
+				}
+			}
+
+			if diff := cmp.Diff(tc.want.mg, tc.args.mg); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if store == nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.bindings, bpmBindings(store)); diff != "" {
+				t.Errorf("\n%s\nDelete(...): -want bindings, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+type bpmRecorder struct {
+	events []event.Event
+}
+
+func (r *bpmRecorder) Event(_ runtime.Object, e event.Event) { r.events = append(r.events, e) }
+
+func (r *bpmRecorder) WithAnnotations(_ ...string) event.Recorder { return r }
+
+func bpmWithDryRun() bpmValueModifier {
+	return func(i *v1alpha1.BucketPolicyMember) {
+		if i.ObjectMeta.Annotations == nil {
+			i.ObjectMeta.Annotations = make(map[string]string)
+		}
+		i.ObjectMeta.Annotations[gcp.AnnotationKeyDryRun] = "true"
+	}
+}
+
+func TestBucketPolicyMemberDryRun(t *testing.T) {
+	type want struct {
+		mg     resource.Managed
+		events []event.Event
+	}
+
+	bind := "would bind role " + testRole + " of member " + testMember
+	unbind := "would unbind role " + testRole + " of member " + testMember
+
+	cases := map[string]struct {
+		policy *storagev1.Policy
+		apply  func(e *bucketPolicyMemberExternal, mg resource.Managed) error
+		want   want
+	}{
+		"CreatePlanned": {
+			policy: &storagev1.Policy{},
+			apply: func(e *bucketPolicyMemberExternal, mg resource.Managed) error {
+				_, err := e.Create(context.Background(), mg)
+				return err
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithDryRun(),
+					bpmWithCondition(xpv1.Condition{Type: gcp.TypeDryRun, Status: corev1.ConditionTrue, Reason: gcp.ReasonChangePlanned, Message: bind})),
+				events: []event.Event{event.Normal(reasonDryRun, bind)},
+			},
+		},
+		"ObservePlanned": {
+			policy: &storagev1.Policy{},
+			apply: func(e *bucketPolicyMemberExternal, mg resource.Managed) error {
+				o, err := e.Observe(context.Background(), mg)
+				if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, o); diff != "" {
+					t.Errorf("Observe(...): -want, +got:\n%s", diff)
+				}
+				return err
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithDryRun(),
+					bpmWithCondition(xpv1.Condition{Type: gcp.TypeDryRun, Status: corev1.ConditionTrue, Reason: gcp.ReasonChangePlanned, Message: bind}),
+					bpmWithEtag(bpmEtag)),
+				events: []event.Event{event.Normal(reasonDryRun, bind)},
+			},
+		},
+		"UpdatePlanned": {
+			policy: &storagev1.Policy{
+				Bindings: []*storagev1.PolicyBindings{
+					{
+						Members: []string{"another-member"},
+						Role:    testRole,
+					},
+				},
+			},
+			apply: func(e *bucketPolicyMemberExternal, mg resource.Managed) error {
+				_, err := e.Update(context.Background(), mg)
+				return err
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithDryRun(),
+					bpmWithCondition(xpv1.Condition{Type: gcp.TypeDryRun, Status: corev1.ConditionTrue, Reason: gcp.ReasonChangePlanned, Message: bind})),
+				events: []event.Event{event.Normal(reasonDryRun, bind)},
+			},
+		},
+		"DeletePlanned": {
+			policy: &storagev1.Policy{
+				Bindings: []*storagev1.PolicyBindings{
+					{
+						Members: []string{testMember},
+						Role:    testRole,
+					},
+				},
+			},
+			apply: func(e *bucketPolicyMemberExternal, mg resource.Managed) error {
+				return e.Delete(context.Background(), mg)
+			},
+			want: want{
+				mg: BucketPolicyMember(
+					bpmWithDryRun(),
+					bpmWithCondition(xpv1.Condition{Type: gcp.TypeDryRun, Status: corev1.ConditionTrue, Reason: gcp.ReasonDeletionPlanned, Message: unbind})),
+				events: []event.Event{event.Normal(reasonDryRun, unbind)},
+			},
+		},
+		"NothingToPlan": {
+			policy: &storagev1.Policy{
+				Bindings: []*storagev1.PolicyBindings{
+					{
+						Members: []string{testMember},
+						Role:    testRole,
+					},
+				},
+			},
+			apply: func(e *bucketPolicyMemberExternal, mg resource.Managed) error {
+				_, err := e.Create(context.Background(), mg)
+				return err
+			},
+			want: want{
+				mg: BucketPolicyMember(bpmWithDryRun()),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			buckets, store := bpmBuckets(t, nil, map[string]*storagev1.Policy{testBucketName: tc.policy})
+			defer func() {
+				if store.Calls()[http.MethodPut] != 0 {
+					t.Errorf("SetIamPolicy(...): policy was set in dry-run mode")
+				}
+			}()
+			record := &bpmRecorder{}
+			e := &bucketPolicyMemberExternal{bucketpolicy: buckets, record: record}
+			mg := BucketPolicyMember(bpmWithDryRun())
+			if err := tc.apply(e, mg); err != nil {
+				t.Errorf("unexpected error %s", err)
+			}
+			if diff := cmp.Diff(tc.want.mg, mg, cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("-want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.events, record.events); diff != "" {
+				t.Errorf("events: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBucketPolicyMemberReadOnly(t *testing.T) {
+	type want struct {
+		mg     resource.Managed
+		events []event.Event
+	}
+
+	bound := &storagev1.Policy{
+		Bindings: []*storagev1.PolicyBindings{{Role: testRole, Members: []string{testMember}}},
+	}
+	bind := "would bind role " + testRole + " of member " + testMember
+	unbind := "would unbind role " + testRole + " of member " + testMember
+
+	cases := map[string]struct {
+		reason string
+		policy *storagev1.Policy
+		apply  func(e *bucketPolicyMemberExternal, mg resource.Managed) error
+		want   want
+	}{
+		"CreatePlanned": {
+			reason: "Create should plan rather than make the binding in read-only mode.",
+			policy: &storagev1.Policy{},
+			apply: func(e *bucketPolicyMemberExternal, mg resource.Managed) error {
+				_, err := e.Create(context.Background(), mg)
+				return err
+			},
+			want: want{
+				mg:     BucketPolicyMember(bpmWithCondition(xpv1.Condition{Type: gcp.TypeDryRun, Status: corev1.ConditionTrue, Reason: gcp.ReasonChangePlanned, Message: bind})),
+				events: []event.Event{event.Normal(reasonDryRun, bind)},
+			},
+		},
+		"UpdatePlanned": {
+			reason: "Update should plan rather than make the binding, nor record it as last applied, in read-only mode.",
+			policy: &storagev1.Policy{
+				Bindings: []*storagev1.PolicyBindings{{Role: testRole, Members: []string{"another-member"}}},
+			},
+			apply: func(e *bucketPolicyMemberExternal, mg resource.Managed) error {
+				_, err := e.Update(context.Background(), mg)
+				return err
+			},
+			want: want{
+				mg:     BucketPolicyMember(bpmWithCondition(xpv1.Condition{Type: gcp.TypeDryRun, Status: corev1.ConditionTrue, Reason: gcp.ReasonChangePlanned, Message: bind})),
+				events: []event.Event{event.Normal(reasonDryRun, bind)},
+			},
+		},
+		"DeletePlanned": {
+			reason: "Delete should plan rather than remove the binding in read-only mode.",
+			policy: bound,
+			apply: func(e *bucketPolicyMemberExternal, mg resource.Managed) error {
+				return e.Delete(context.Background(), mg)
+			},
+			want: want{
+				mg:     BucketPolicyMember(bpmWithCondition(xpv1.Condition{Type: gcp.TypeDryRun, Status: corev1.ConditionTrue, Reason: gcp.ReasonDeletionPlanned, Message: unbind})),
+				events: []event.Event{event.Normal(reasonDryRun, unbind)},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			buckets, store := bpmBuckets(t, nil, map[string]*storagev1.Policy{testBucketName: tc.policy})
+			defer func() {
+				if store.Calls()[http.MethodPut] != 0 {
+					t.Errorf("SetIamPolicy(...): policy was set in read-only mode")
+				}
+			}()
+			record := &bpmRecorder{}
+			kube := &test.MockClient{MockUpdate: func(_ context.Context, _ client.Object, _ ...client.UpdateOption) error {
+				t.Errorf("Update(...): managed resource was updated in read-only mode")
+				return nil
+			}}
+			e := &bucketPolicyMemberExternal{kube: kube, bucketpolicy: buckets, record: record, readOnly: true}
+			mg := BucketPolicyMember()
+			if err := tc.apply(e, mg); err != nil {
+				t.Errorf("\n%s\nunexpected error %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.mg, mg, cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("\n%s\n-want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.events, record.events); diff != "" {
+				t.Errorf("\n%s\nevents: -want, +got:\n%s", tc.reason, diff)
+			}
+
+			// A deleted binding whose removal was planned is finalized.
+			if tc.want.mg.GetCondition(gcp.TypeDryRun).Reason != gcp.ReasonDeletionPlanned {
+				return
+			}
+			now := metav1.Now()
+			mg.SetDeletionTimestamp(&now)
+			o, err := e.Observe(context.Background(), mg)
+			if err != nil {
+				t.Fatalf("Observe(...): %s", err)
+			}
+			if o.ResourceExists {
+				t.Errorf("Observe(...): want a binding whose removal was planned in read-only mode to be reported as deleted")
+			}
+		})
+	}
+}
+
+func TestBucketPolicyMemberAPIRateLimit(t *testing.T) {
+	const (
+		calls    = 5
+		interval = 50 * time.Millisecond
+	)
+	buckets, store := bpmBuckets(t, nil, bpmPolicies())
+	e := &bucketPolicyMemberExternal{bucketpolicy: buckets, record: &bpmRecorder{}, api: rate.NewLimiter(rate.Every(interval), 1)}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < calls; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := e.Observe(context.Background(), BucketPolicyMember()); err != nil {
+				t.Errorf("Observe(...): unexpected error %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if diff := cmp.Diff(map[string]int{http.MethodGet: calls}, store.Calls()); diff != "" {
+		t.Errorf("Observe(...): -want calls to the GCP API, +got:\n%s", diff)
+	}
+	// Allow for some jitter in when the limiter releases each call.
+	if got, want := time.Since(start), (calls-1)*interval*9/10; got < want {
+		t.Errorf("Observe(...): want %d calls to the GCP API to take at least %s, took %s", calls, want, got)
+	}
+}
+
+func TestBucketPolicyMemberRetry(t *testing.T) {
+	type want struct {
+		calls int
+		err   error
+	}
+	cases := map[string]struct {
+		codes []int
+		want  want
+	}{
+		"RetriedUnavailable": {
+			codes: []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK},
+			want:  want{calls: 3},
+		},
+		"NotRetriedForbidden": {
+			codes: []int{http.StatusForbidden, http.StatusOK},
+			want: want{
+				calls: 1,
+				err:   errors.Wrap(gError(http.StatusForbidden, "{}\n"), errGetPolicy),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				w.WriteHeader(tc.codes[calls])
+				calls++
+				_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+			}))
+			defer server.Close()
+			s, _ := storagev1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &bucketPolicyMemberExternal{
+				bucketpolicy: storagev1.NewBucketsService(s),
+				record:       &bpmRecorder{},
+				backoff:      wait.Backoff{Duration: time.Millisecond, Factor: 2, Steps: 4},
+			}
+			_, err := e.Observe(context.Background(), BucketPolicyMember())
+			if tc.want.err == nil && err != nil {
+				t.Errorf("Observe(...): unexpected error %s", err)
+			}
+			if tc.want.err != nil {
+				if err == nil {
+					t.Errorf("Observe(...): want error %s got nil", tc.want.err)
+				} else if diff := cmp.Diff(tc.want.err.Error(), err.Error()); diff != "" {
+					t.Errorf("Observe(...): want error string != got error string:\n%s", diff)
+				}
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("Observe(...): -want calls to the GCP API, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBucketPolicyMemberCallTimeout(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		calls int
+	)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		// Block until the call is abandoned, like a GCP API that never
+		// responds.
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+	s, _ := storagev1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	e := &bucketPolicyMemberExternal{
+		bucketpolicy: storagev1.NewBucketsService(s),
+		record:       &bpmRecorder{},
+		callTimeout:  10 * time.Millisecond,
+		backoff:      wait.Backoff{Duration: time.Millisecond, Factor: 2, Steps: 3},
+	}
+
+	_, err := e.Observe(context.Background(), BucketPolicyMember())
+	if err == nil {
+		t.Fatal("Observe(...): want error, got nil")
+	}
+	if !gcp.IsErrorRetryable(errors.Cause(err)) {
+		t.Errorf("Observe(...): want retryable error, got %s", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if diff := cmp.Diff(3, calls); diff != "" {
+		t.Errorf("Observe(...): -want calls to the GCP API, +got:\n%s", diff)
+	}
+}
+
+func TestBucketPolicyMemberObserveErrorBackoff(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		calls     int
+		forbidden = true
+		now       = time.Now()
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if forbidden {
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(&storagev1.Policy{
+			Bindings: []*storagev1.PolicyBindings{{Role: testRole, Members: []string{testMember}}},
+		})
+	}))
+	defer server.Close()
+	s, _ := storagev1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+	e := &bucketPolicyMemberExternal{
+		bucketpolicy:  storagev1.NewBucketsService(s),
+		record:        &bpmRecorder{},
+		observeErrors: bucketpolicy.NewErrorBackoff(200*time.Millisecond, time.Second, bucketpolicy.WithClock(func() time.Time { return now })),
+	}
+	cr := BucketPolicyMember()
+
+	// observe observes the binding once the clock advanced by the supplied
+	// duration, and reports whether the GCP API was called.
+	observe := func(after time.Duration, wantErr bool) bool {
+		t.Helper()
+		now = now.Add(after)
+		mu.Lock()
+		before := calls
+		mu.Unlock()
+		_, err := e.Observe(context.Background(), cr)
+		if (err != nil) != wantErr {
+			t.Fatalf("Observe(...): want error %t, got %v", wantErr, err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		return calls > before
+	}
+
+	if !observe(0, true) {
+		t.Errorf("Observe(...): want the first observation to call the GCP API")
+	}
+	if observe(0, true) {
+		t.Errorf("Observe(...): want an observation within the backoff to fail without calling the GCP API")
+	}
+	if !observe(250*time.Millisecond, true) {
+		t.Errorf("Observe(...): want an observation after the backoff to call the GCP API")
+	}
+	// The backoff doubled to 400ms after the second 403.
+	if observe(250*time.Millisecond, true) {
+		t.Errorf("Observe(...): want the backoff to grow while observations keep failing")
+	}
+
+	mu.Lock()
+	forbidden = false
+	mu.Unlock()
+	if !observe(200*time.Millisecond, false) {
+		t.Errorf("Observe(...): want an observation after the grown backoff to call the GCP API")
+	}
+
+	// The backoff is reset to 200ms once an observation succeeded.
+	mu.Lock()
+	forbidden = true
+	mu.Unlock()
+	if !observe(0, true) {
+		t.Errorf("Observe(...): want an observation after a success to call the GCP API")
+	}
+	if !observe(250*time.Millisecond, true) {
+		t.Errorf("Observe(...): want the backoff to be reset once an observation succeeded")
+	}
+
+	// The backoff is reset once the BucketPolicyMember changed.
+	if observe(0, true) {
+		t.Errorf("Observe(...): want an observation within the backoff to fail without calling the GCP API")
+	}
+	cr.SetGeneration(cr.GetGeneration() + 1)
+	if !observe(0, true) {
+		t.Errorf("Observe(...): want an observation of another generation to call the GCP API")
+	}
+
+	// The backoff is forgotten once the BucketPolicyMember is deleted.
+	if err := e.Delete(context.Background(), cr); err == nil {
+		t.Errorf("Delete(...): want an error reading the policy")
+	}
+	if !observe(0, true) {
+		t.Errorf("Observe(...): want an observation after a deletion to call the GCP API")
+	}
+}
+
+func TestBucketPolicyMemberConcurrentChanges(t *testing.T) {
+	type want struct {
+		sets    int
+		members []string
+		err     error
+	}
+	cases := map[string]struct {
+		reason string
+		stale  int
+		want   want
+	}{
+		"ConvergedAfterStaleEtags": {
+			reason: "A binding whose policy is repeatedly changed concurrently should be bound along with the concurrent changes once it is set with the current etag.",
+			stale:  3,
+			want: want{
+				sets:    4,
+				members: []string{"user:replica-1@example.com", "user:replica-2@example.com", "user:replica-3@example.com", testMember},
+			},
+		},
+		"ConflictBackoffExhausted": {
+			reason: "A binding whose policy keeps being changed concurrently should fail once the conflict backoff is exhausted.",
+			stale:  10,
+			want: want{
+				sets: 4,
+				err:  errors.Wrap(gError(http.StatusPreconditionFailed, "{}\n"), errPolicyChanged),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			// Each stale attempt to set the policy is preceded by a change
+			// of another replica of the provider, which binds another
+			// member and thus changes the etag of the policy.
+			var (
+				mu      sync.Mutex
+				version int
+				sets    int
+				members []string
+			)
+			policy := func() *storagev1.Policy {
+				p := &storagev1.Policy{Etag: fmt.Sprintf("etag-%d", version)}
+				if version > 0 {
+					b := &storagev1.PolicyBindings{Role: testRole}
+					for i := 1; i <= version; i++ {
+						b.Members = append(b.Members, fmt.Sprintf("user:replica-%d@example.com", i))
+					}
+					p.Bindings = []*storagev1.PolicyBindings{b}
+				}
+				return p
+			}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				mu.Lock()
+				defer mu.Unlock()
+				if r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusOK)
+					_ = json.NewEncoder(w).Encode(policy())
+					return
+				}
+				sets++
+				i := &storagev1.Policy{}
+				_ = json.NewDecoder(r.Body).Decode(i)
+				if diff := cmp.Diff(policy().Etag, i.Etag); diff != "" {
+					t.Errorf("SetIamPolicy(...): policy was not read again after a concurrent change: -want etag, +got etag:\n%s", diff)
+				}
+				if version < tc.stale {
+					version++
+					w.WriteHeader(http.StatusPreconditionFailed)
+					_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+					return
+				}
+				members = i.Bindings[0].Members
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(i)
+			}))
+			defer server.Close()
+			s, _ := storagev1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &bucketPolicyMemberExternal{
+				kube:         &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				bucketpolicy: storagev1.NewBucketsService(s),
+				record:       &bpmRecorder{},
+				conflicts:    wait.Backoff{Duration: time.Millisecond, Factor: 2, Jitter: 0.5, Steps: 4},
+			}
+			_, err := e.Update(context.Background(), BucketPolicyMember())
+			if tc.want.err == nil && err != nil {
+				t.Errorf("\n%s\nUpdate(...): unexpected error %s", tc.reason, err)
+			}
+			if tc.want.err != nil {
+				if err == nil {
+					t.Errorf("\n%s\nUpdate(...): want error %s got nil", tc.reason, tc.want.err)
+				} else if diff := cmp.Diff(tc.want.err.Error(), err.Error()); diff != "" {
+					t.Errorf("\n%s\nUpdate(...): want error string != got error string:\n%s", tc.reason, diff)
+				}
+			}
+			if diff := cmp.Diff(tc.want.sets, sets); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want calls to SetIamPolicy, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.members, members); diff != "" {
+				t.Errorf("\n%s\nUpdate(...): -want bound members, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestBucketPolicyMemberConcurrentBindings(t *testing.T) {
+	buckets, store := bpmBuckets(t, nil, bpmPolicies())
+
+	members := []string{"user:jane@example.com", "user:joe@example.com", "group:team@example.com", "domain:example.com"}
+	var wg sync.WaitGroup
+	for _, m := range members {
+		wg.Add(1)
+		go func(m string) {
+			defer wg.Done()
+			e := &bucketPolicyMemberExternal{
+				bucketpolicy: buckets,
+				record:       &bpmRecorder{},
+				conflicts:    wait.Backoff{Duration: time.Millisecond, Factor: 2, Jitter: 1, Steps: 10},
+			}
+			if _, err := e.Create(context.Background(), BucketPolicyMember(bpmWithExternalNameAnnotation(""), bpmWithMember(m))); err != nil {
+				t.Errorf("Create(...): %s", err)
+			}
+		}(m)
+	}
+	wg.Wait()
+
+	// Each BucketPolicyMember read the policy again until it set it along
+	// with its current etag, so none overwrote the binding of another.
+	want := []*storagev1.PolicyBindings{{Role: testRole, Members: members}}
+	if diff := cmp.Diff(want, store.Policy(testBucketName).Bindings, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("Create(...): -want bindings, +got:\n%s", diff)
+	}
+}
+
+func TestBucketPolicyMemberBucketLocks(t *testing.T) {
+	buckets, store := bpmBuckets(t, nil, bpmPolicies())
+	locks := bucketpolicy.NewBucketLocks()
+
+	const n = 20
+	members := make([]string, n)
+	var wg sync.WaitGroup
+	for i := range members {
+		members[i] = fmt.Sprintf("user:member-%d@example.com", i)
+		wg.Add(1)
+		go func(m string) {
+			defer wg.Done()
+			// Conflicting changes are not retried, so any conflict fails.
+			e := &bucketPolicyMemberExternal{bucketpolicy: buckets, record: &bpmRecorder{}, locks: locks}
+			if _, err := e.Create(context.Background(), BucketPolicyMember(bpmWithExternalNameAnnotation(""), bpmWithMember(m))); err != nil {
+				t.Errorf("Create(...): %s", err)
+			}
+		}(members[i])
+	}
+	wg.Wait()
+
+	// Each BucketPolicyMember read the policy once and set it once.
+	if diff := cmp.Diff(map[string]int{http.MethodGet: n, http.MethodPut: n}, store.Calls()); diff != "" {
+		t.Errorf("Create(...): -want calls to the GCP API, +got:\n%s", diff)
+	}
+	want := []*storagev1.PolicyBindings{{Role: testRole, Members: members}}
+	if diff := cmp.Diff(want, store.Policy(testBucketName).Bindings, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("Create(...): -want bindings, +got:\n%s", diff)
+	}
+}
+
+func TestBucketPolicyMemberBatches(t *testing.T) {
+	buckets, store := bpmBuckets(t, nil, bpmPolicies())
+	batches := bucketpolicy.NewBatcher(100 * time.Millisecond)
+
+	members := []string{"user:jane@example.com", "user:joe@example.com", "group:team@example.com"}
+	records := make([]*bpmRecorder, len(members))
+	var wg sync.WaitGroup
+	for i, m := range members {
+		records[i] = &bpmRecorder{}
+		wg.Add(1)
+		go func(m string, record *bpmRecorder) {
+			defer wg.Done()
+			// Conflicting changes are not retried, so any conflict fails.
+			e := &bucketPolicyMemberExternal{bucketpolicy: buckets, record: record, batches: batches}
+			if _, err := e.Create(context.Background(), BucketPolicyMember(bpmWithExternalNameAnnotation(""), bpmWithMember(m))); err != nil {
+				t.Errorf("Create(...): %s", err)
+			}
+		}(m, records[i])
+	}
+	wg.Wait()
+
+	// The policy was read and set once for all BucketPolicyMembers.
+	if diff := cmp.Diff(map[string]int{http.MethodGet: 1, http.MethodPut: 1}, store.Calls()); diff != "" {
+		t.Errorf("Create(...): -want calls to the GCP API, +got:\n%s", diff)
+	}
+	want := []*storagev1.PolicyBindings{{Role: testRole, Members: members}}
+	if diff := cmp.Diff(want, store.Policy(testBucketName).Bindings, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("Create(...): -want bindings, +got:\n%s", diff)
+	}
+	// Each BucketPolicyMember reported its own binding.
+	for i, r := range records {
+		if len(r.events) != 1 || r.events[0].Reason != reasonBound {
+			t.Errorf("Create(...): want BucketPolicyMember %d to record that it bound its role, got %v", i, r.events)
+		}
+	}
+}
+
+func TestBucketPolicyMemberEvents(t *testing.T) {
+	bound := &storagev1.Policy{
+		Bindings: []*storagev1.PolicyBindings{
+			{
+				Members: []string{testMember},
+				Role:    testRole,
+			},
+		},
+	}
+	binding := "role " + testRole + " of member " + testMember + " on bucket " + testBucketName
+
+	cases := map[string]struct {
+		policy *storagev1.Policy
+		code   int
+		apply  func(e *bucketPolicyMemberExternal, mg resource.Managed) error
+		want   []event.Event
+	}{
+		"Bound": {
+			policy: &storagev1.Policy{},
+			code:   http.StatusOK,
+			apply: func(e *bucketPolicyMemberExternal, mg resource.Managed) error {
+				_, err := e.Create(context.Background(), mg)
+				return err
+			},
+			want: []event.Event{event.Normal(reasonBound, "bound "+binding)},
+		},
+		"Unbound": {
+			policy: bound,
+			code:   http.StatusOK,
+			apply: func(e *bucketPolicyMemberExternal, mg resource.Managed) error {
+				return e.Delete(context.Background(), mg)
+			},
+			want: []event.Event{event.Normal(reasonUnbound, "unbound "+binding)},
+		},
+		"AlreadyBound": {
+			policy: bound,
+			code:   http.StatusOK,
+			apply: func(e *bucketPolicyMemberExternal, mg resource.Managed) error {
+				_, err := e.Create(context.Background(), mg)
+				return err
+			},
+		},
+		"CannotBind": {
+			policy: &storagev1.Policy{},
+			code:   http.StatusForbidden,
+			apply: func(e *bucketPolicyMemberExternal, mg resource.Managed) error {
+				_, err := e.Create(context.Background(), mg)
+				return err
+			},
+			want: []event.Event{event.Warning(reasonCannotSetPolicy, errors.Wrap(bpmRejected, errSetPolicy))},
+		},
+		"CannotUnbind": {
+			policy: bound,
+			code:   http.StatusForbidden,
+			apply: func(e *bucketPolicyMemberExternal, mg resource.Managed) error {
+				return e.Delete(context.Background(), mg)
+			},
+			want: []event.Event{event.Warning(reasonCannotSetPolicy, errors.Wrap(bpmRejected, errSetPolicy))},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			buckets, store := bpmBuckets(t, nil, map[string]*storagev1.Policy{testBucketName: tc.policy})
+			if tc.code != http.StatusOK {
+				store.RejectSet(testBucketName, tc.code)
+			}
+			record := &bpmRecorder{}
+			e := &bucketPolicyMemberExternal{bucketpolicy: buckets, record: record}
+			_ = tc.apply(e, BucketPolicyMember())
+			if diff := cmp.Diff(tc.want, record.events); diff != "" {
+				t.Errorf("events: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+// A bpmLogEntry is a message logged by a bpmLogger, along with its level and
+// structured fields.
+type bpmLogEntry struct {
+	level  string
+	msg    string
+	fields map[string]interface{}
+}
+
+// A bpmLogger records the messages logged to it and the loggers derived from
+// it.
+type bpmLogger struct {
+	entries *[]bpmLogEntry
+	values  []interface{}
+}
+
+func (l bpmLogger) log(level, msg string, keysAndValues []interface{}) {
+	fields := map[string]interface{}{}
+	kv := append(append([]interface{}{}, l.values...), keysAndValues...)
+	for i := 0; i+1 < len(kv); i += 2 {
+		fields[fmt.Sprint(kv[i])] = kv[i+1]
+	}
+	*l.entries = append(*l.entries, bpmLogEntry{level: level, msg: msg, fields: fields})
+}
+
+func (l bpmLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.log("info", msg, keysAndValues)
+}
+
+func (l bpmLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.log("debug", msg, keysAndValues)
+}
+
+func (l bpmLogger) WithValues(keysAndValues ...interface{}) logging.Logger {
+	return bpmLogger{entries: l.entries, values: append(append([]interface{}{}, l.values...), keysAndValues...)}
+}
+
+func TestBucketPolicyMemberLogs(t *testing.T) {
+	bound := &storagev1.Policy{
+		Bindings: []*storagev1.PolicyBindings{{Role: testRole, Members: []string{testMember}}},
+	}
+	binding := map[string]interface{}{"bucket": testBucketName, "role": testRole, "member": testMember}
+	with := func(kv ...interface{}) map[string]interface{} {
+		fields := map[string]interface{}{}
+		for k, v := range binding {
+			fields[k] = v
+		}
+		for i := 0; i+1 < len(kv); i += 2 {
+			fields[kv[i].(string)] = kv[i+1]
+		}
+		return fields
+	}
+
+	cases := map[string]struct {
+		reason string
+		policy *storagev1.Policy
+		code   int
+		apply  func(e *bucketPolicyMemberExternal, mg resource.Managed) error
+		want   []bpmLogEntry
+	}{
+		"ObserveMissing": {
+			reason: "Observing a missing binding should log that it is to be bound.",
+			policy: &storagev1.Policy{},
+			code:   http.StatusOK,
+			apply: func(e *bucketPolicyMemberExternal, mg resource.Managed) error {
+				_, err := e.Observe(context.Background(), mg)
+				return err
+			},
+			want: []bpmLogEntry{{level: "debug", msg: "Observed binding", fields: with("decision", "bind")}},
+		},
+		"ObserveUpToDate": {
+			reason: "Observing a bound binding should log that it is up to date.",
+			policy: bound,
+			code:   http.StatusOK,
+			apply: func(e *bucketPolicyMemberExternal, mg resource.Managed) error {
+				_, err := e.Observe(context.Background(), mg)
+				return err
+			},
+			want: []bpmLogEntry{{level: "debug", msg: "Observed binding", fields: with("decision", "up to date")}},
+		},
+		"Create": {
+			reason: "Creating a binding should log that it is created and that the policy was set.",
+			policy: &storagev1.Policy{},
+			code:   http.StatusOK,
+			apply: func(e *bucketPolicyMemberExternal, mg resource.Managed) error {
+				_, err := e.Create(context.Background(), mg)
+				return err
+			},
+			want: []bpmLogEntry{
+				{level: "debug", msg: "Creating binding", fields: with()},
+				{level: "debug", msg: "Changed bucket policy", fields: with("set", true, "dryRun", false)},
+			},
+		},
+		"Update": {
+			reason: "Updating a binding that is bound already should log that the policy was not set.",
+			policy: bound,
+			code:   http.StatusOK,
+			apply: func(e *bucketPolicyMemberExternal, mg resource.Managed) error {
+				_, err := e.Update(context.Background(), mg)
+				return err
+			},
+			want: []bpmLogEntry{
+				{level: "debug", msg: "Updating binding", fields: with("decision", "bind")},
+				{level: "debug", msg: "Changed bucket policy", fields: with("set", false, "dryRun", false)},
+			},
+		},
+		"Delete": {
+			reason: "Deleting a binding should log that it is unbound and that the policy was set.",
+			policy: bound,
+			code:   http.StatusOK,
+			apply: func(e *bucketPolicyMemberExternal, mg resource.Managed) error {
+				return e.Delete(context.Background(), mg)
+			},
+			want: []bpmLogEntry{
+				{level: "debug", msg: "Deleting binding", fields: with("decision", "unbind")},
+				{level: "debug", msg: "Changed bucket policy", fields: with("set", true, "dryRun", false)},
+			},
+		},
+		"CannotSetPolicy": {
+			reason: "Failing to set the policy should be logged along with the status code of the error.",
+			policy: &storagev1.Policy{},
+			code:   http.StatusForbidden,
+			apply: func(e *bucketPolicyMemberExternal, mg resource.Managed) error {
+				_, err := e.Create(context.Background(), mg)
+				return err
+			},
+			want: []bpmLogEntry{
+				{level: "debug", msg: "Creating binding", fields: with()},
+				{level: "info", msg: "Cannot set bucket policy", fields: with("code", http.StatusForbidden, "error", bpmRejected)},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			buckets, store := bpmBuckets(t, nil, map[string]*storagev1.Policy{testBucketName: tc.policy})
+			if tc.code != http.StatusOK {
+				store.RejectSet(testBucketName, tc.code)
+			}
+			entries := []bpmLogEntry{}
+			e := &bucketPolicyMemberExternal{kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)}, bucketpolicy: buckets, record: &bpmRecorder{}, log: bpmLogger{entries: &entries}}
+			_ = tc.apply(e, BucketPolicyMember())
+			if diff := cmp.Diff(tc.want, entries, cmp.AllowUnexported(bpmLogEntry{}), test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nlogs: -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestBucketPolicyMemberPolicyCache(t *testing.T) {
+	buckets, store := bpmBuckets(t, nil, bpmPolicies())
+	policies := bucketpolicy.NewPolicyCache(time.Minute)
+	newExternal := func() *bucketPolicyMemberExternal {
+		return &bucketPolicyMemberExternal{bucketpolicy: buckets, record: &bpmRecorder{}, policies: policies}
+	}
+
+	const n = 10
+	wg := &sync.WaitGroup{}
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := newExternal().Observe(context.Background(), BucketPolicyMember()); err != nil {
+				t.Errorf("Observe(...): %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if diff := cmp.Diff(map[string]int{http.MethodGet: 1}, store.Calls()); diff != "" {
+		t.Errorf("Observe(...): -want calls to the GCP API, +got:\n%s", diff)
+	}
+
+	// Setting the policy must bust the cache so that it is read again.
+	if _, err := newExternal().Create(context.Background(), BucketPolicyMember()); err != nil {
+		t.Errorf("Create(...): %s", err)
+	}
+	if _, err := newExternal().Observe(context.Background(), BucketPolicyMember()); err != nil {
+		t.Errorf("Observe(...): %s", err)
+	}
+	if diff := cmp.Diff(map[string]int{http.MethodGet: 2, http.MethodPut: 1}, store.Calls()); diff != "" {
+		t.Errorf("Create(...): -want calls to the GCP API, +got:\n%s", diff)
+	}
+
+	// The policy of the bucket read with another ProviderConfig, or in
+	// another project, is not shared.
+	other := BucketPolicyMember()
+	other.SetProviderConfigReference(&xpv1.Reference{Name: "other-provider-config"})
+	if _, err := newExternal().Observe(context.Background(), other); err != nil {
+		t.Errorf("Observe(...): %s", err)
+	}
+	other = BucketPolicyMember()
+	other.Spec.ForProvider.Project = gcp.StringPtr("other-project")
+	if _, err := newExternal().Observe(context.Background(), other); err != nil {
+		t.Errorf("Observe(...): %s", err)
+	}
+	if diff := cmp.Diff(map[string]int{http.MethodGet: 4, http.MethodPut: 1}, store.Calls()); diff != "" {
+		t.Errorf("Observe(...): -want calls to the GCP API, +got:\n%s", diff)
+	}
+}
+
+func bpmWithAllowPublicAccess() bpmValueModifier {
+	return func(i *v1alpha1.BucketPolicyMember) {
+		meta.AddAnnotations(i, map[string]string{gcp.AnnotationKeyAllowPublicAccess: "true"})
+	}
+}
+
+func TestBucketPolicyMemberPublicAccess(t *testing.T) {
+	type want struct {
+		calls     map[string]int
+		err       error
+		condition xpv1.ConditionReason
+	}
+	cases := map[string]struct {
+		reason string
+		mg     *v1alpha1.BucketPolicyMember
+		want   want
+	}{
+		"BlockedByDefault": {
+			reason: "Binding allUsers should be refused without calling the GCP API unless public access is allowed.",
+			mg:     BucketPolicyMember(bpmWithExternalNameAnnotation(""), bpmWithMember("allUsers")),
+			want: want{
+				calls:     map[string]int{},
+				err:       errors.Errorf(errFmtPublicAccess, "allUsers", gcp.AnnotationKeyAllowPublicAccess),
+				condition: gcp.ReasonPublicAccessBlocked,
+			},
+		},
+		"BlockedAmongMembers": {
+			reason: "Binding allAuthenticatedUsers along with other members should be refused unless public access is allowed.",
+			mg:     BucketPolicyMember(bpmWithExternalNameAnnotation(""), bpmWithMembers("group:team@example.com", "allAuthenticatedUsers")),
+			want: want{
+				calls:     map[string]int{},
+				err:       errors.Errorf(errFmtPublicAccess, "allAuthenticatedUsers", gcp.AnnotationKeyAllowPublicAccess),
+				condition: gcp.ReasonPublicAccessBlocked,
+			},
+		},
+		"AllowedWithAnnotation": {
+			reason: "Binding allUsers should set the policy once public access is allowed.",
+			mg:     BucketPolicyMember(bpmWithExternalNameAnnotation(""), bpmWithMember("allUsers"), bpmWithAllowPublicAccess()),
+			want:   want{calls: map[string]int{http.MethodGet: 1, http.MethodPut: 1}},
+		},
+		"NonPublicMember": {
+			reason: "Binding a member that does not make the bucket public should never be refused.",
+			mg:     BucketPolicyMember(bpmWithExternalNameAnnotation("")),
+			want:   want{calls: map[string]int{http.MethodGet: 1, http.MethodPut: 1}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			buckets, store := bpmBuckets(t, nil, bpmPolicies())
+			e := &bucketPolicyMemberExternal{bucketpolicy: buckets, record: &bpmRecorder{}}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, store.Calls()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want calls to the GCP API, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.condition, tc.mg.GetCondition(gcp.TypeIAMPolicyError).Reason); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want IAMPolicyError reason, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestBucketPolicyMemberAllowedDomains(t *testing.T) {
+	allowed := []string{"example.com", "my-project.iam.gserviceaccount.com"}
+	type want struct {
+		calls     map[string]int
+		err       error
+		condition xpv1.ConditionReason
+	}
+	cases := map[string]struct {
+		reason  string
+		allowed []string
+		mg      *v1alpha1.BucketPolicyMember
+		want    want
+	}{
+		"AllowedDomain": {
+			reason:  "Binding a member of an allowed domain should set the policy.",
+			allowed: allowed,
+			mg:      BucketPolicyMember(bpmWithExternalNameAnnotation(""), bpmWithMember("user:jane@example.com")),
+			want:    want{calls: map[string]int{http.MethodGet: 1, http.MethodPut: 1}},
+		},
+		"AllowedSubdomain": {
+			reason:  "Binding members of subdomains of allowed domains should set the policy.",
+			allowed: allowed,
+			mg:      BucketPolicyMember(bpmWithExternalNameAnnotation(""), bpmWithMembers("group:eng@corp.example.com", "domain:Corp.Example.com")),
+			want:    want{calls: map[string]int{http.MethodGet: 1, http.MethodPut: 1}},
+		},
+		"DisallowedDomain": {
+			reason:  "Binding a member of a domain that is not allowed should be refused without calling the GCP API.",
+			allowed: allowed,
+			mg:      BucketPolicyMember(bpmWithExternalNameAnnotation(""), bpmWithMember("user:attacker@evil.com")),
+			want: want{
+				calls:     map[string]int{},
+				err:       errors.Errorf(errFmtDomainNotAllowed, "user:attacker@evil.com"),
+				condition: gcp.ReasonDomainNotAllowed,
+			},
+		},
+		"DisallowedAmongMembers": {
+			reason:  "Binding members of domains that are not allowed along with allowed ones should be refused, naming only the former.",
+			allowed: allowed,
+			mg:      BucketPolicyMember(bpmWithExternalNameAnnotation(""), bpmWithMembers("group:team@example.com", "serviceAccount:sa@other-project.iam.gserviceaccount.com", "domain:notexample.com")),
+			want: want{
+				calls:     map[string]int{},
+				err:       errors.Errorf(errFmtDomainNotAllowed, "serviceAccount:sa@other-project.iam.gserviceaccount.com, domain:notexample.com"),
+				condition: gcp.ReasonDomainNotAllowed,
+			},
+		},
+		"PublicMemberAllowed": {
+			reason:  "Binding allUsers should be governed by the public access guard only, not by the allowed domains.",
+			allowed: allowed,
+			mg:      BucketPolicyMember(bpmWithExternalNameAnnotation(""), bpmWithMember("allUsers"), bpmWithAllowPublicAccess()),
+			want:    want{calls: map[string]int{http.MethodGet: 1, http.MethodPut: 1}},
+		},
+		"PublicMemberBlocked": {
+			reason:  "Binding allAuthenticatedUsers without allowing public access should be refused by the public access guard.",
+			allowed: allowed,
+			mg:      BucketPolicyMember(bpmWithExternalNameAnnotation(""), bpmWithMember("allAuthenticatedUsers")),
+			want: want{
+				calls:     map[string]int{},
+				err:       errors.Errorf(errFmtPublicAccess, "allAuthenticatedUsers", gcp.AnnotationKeyAllowPublicAccess),
+				condition: gcp.ReasonPublicAccessBlocked,
+			},
+		},
+		"NoAllowedDomains": {
+			reason: "Binding a member of any domain should set the policy unless some domains are allowed.",
+			mg:     BucketPolicyMember(bpmWithExternalNameAnnotation(""), bpmWithMember("user:attacker@evil.com")),
+			want:   want{calls: map[string]int{http.MethodGet: 1, http.MethodPut: 1}},
+		},
+	}
+	for name, tc := range cases {
+		for method, call := range map[string]func(*bucketPolicyMemberExternal, *v1alpha1.BucketPolicyMember) error{
+			"Create": func(e *bucketPolicyMemberExternal, cr *v1alpha1.BucketPolicyMember) error {
+				_, err := e.Create(context.Background(), cr)
+				return err
+			},
+			"Update": func(e *bucketPolicyMemberExternal, cr *v1alpha1.BucketPolicyMember) error {
+				_, err := e.Update(context.Background(), cr)
+				return err
+			},
+		} {
+			t.Run(name+"/"+method, func(t *testing.T) {
+				buckets, store := bpmBuckets(t, nil, bpmPolicies())
+				e := &bucketPolicyMemberExternal{kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)}, bucketpolicy: buckets, record: &bpmRecorder{}, allowedDomains: tc.allowed}
+				cr := tc.mg.DeepCopy()
+				err := call(e, cr)
+				if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+					t.Errorf("\n%s\n%s(...): -want error, +got error:\n%s", tc.reason, method, diff)
+				}
+				if diff := cmp.Diff(tc.want.calls, store.Calls()); diff != "" {
+					t.Errorf("\n%s\n%s(...): -want calls to the GCP API, +got:\n%s", tc.reason, method, diff)
+				}
+				if diff := cmp.Diff(tc.want.condition, cr.GetCondition(gcp.TypeIAMPolicyError).Reason); diff != "" {
+					t.Errorf("\n%s\n%s(...): -want IAMPolicyError reason, +got:\n%s", tc.reason, method, diff)
+				}
+			})
+		}
+	}
+}
+
+func bpmWithCheckCustomRole() bpmValueModifier {
+	return func(i *v1alpha1.BucketPolicyMember) {
+		meta.AddAnnotations(i, map[string]string{gcp.AnnotationKeyCheckCustomRole: "true"})
+	}
+}
+
+func TestBucketPolicyMemberCustomRole(t *testing.T) {
+	customRole := "projects/my-project/roles/myRole"
+	type want struct {
+		calls     map[string]int
+		roleCalls int
+		err       error
+		condition xpv1.ConditionReason
+	}
+	cases := map[string]struct {
+		reason string
+		mg     *v1alpha1.BucketPolicyMember
+		roles  map[string]bool
+		want   want
+	}{
+		"PredefinedRoleSkipped": {
+			reason: "Predefined roles should never be checked.",
+			mg:     BucketPolicyMember(bpmWithExternalNameAnnotation(""), bpmWithCheckCustomRole()),
+			want:   want{calls: map[string]int{http.MethodGet: 1, http.MethodPut: 1}},
+		},
+		"ExistingCustomRole": {
+			reason: "An existing custom role should be checked and then bound.",
+			mg:     BucketPolicyMember(bpmWithExternalNameAnnotation(""), bpmWithRole(customRole), bpmWithCheckCustomRole()),
+			roles:  map[string]bool{customRole: true},
+			want:   want{calls: map[string]int{http.MethodGet: 1, http.MethodPut: 1}, roleCalls: 1},
+		},
+		"MissingCustomRole": {
+			reason: "A missing custom role should be refused without setting the policy.",
+			mg:     BucketPolicyMember(bpmWithExternalNameAnnotation(""), bpmWithRole(customRole), bpmWithCheckCustomRole()),
+			want: want{
+				calls:     map[string]int{},
+				roleCalls: 1,
+				err:       errors.Errorf(errFmtRoleNotFound, customRole),
+				condition: gcp.ReasonRoleNotFound,
+			},
+		},
+		"UncheckedCustomRole": {
+			reason: "A custom role should not be checked unless the resource is annotated to check it.",
+			mg:     BucketPolicyMember(bpmWithExternalNameAnnotation(""), bpmWithRole(customRole)),
+			want:   want{calls: map[string]int{http.MethodGet: 1, http.MethodPut: 1}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			buckets, store := bpmBuckets(t, nil, bpmPolicies())
+			roleCalls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				roleCalls++
+				name := strings.TrimPrefix(r.URL.Path, "/v1/")
+				if !tc.roles[name] {
+					w.WriteHeader(http.StatusNotFound)
+					_ = json.NewEncoder(w).Encode(map[string]interface{}{"error": map[string]interface{}{"code": http.StatusNotFound, "message": "role not found"}})
+					return
+				}
+				_ = json.NewEncoder(w).Encode(&iamv1.Role{Name: name})
+			}))
+			defer server.Close()
+			roles, err := iamv1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			if err != nil {
+				t.Fatalf("NewService(...): %s", err)
+			}
+			e := &bucketPolicyMemberExternal{bucketpolicy: buckets, roles: roles, record: &bpmRecorder{}}
+			_, err = e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, store.Calls()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want calls to the GCP API, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.roleCalls, roleCalls); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want calls to the GCP IAM API, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.condition, tc.mg.GetCondition(gcp.TypeIAMPolicyError).Reason); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want IAMPolicyError reason, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func bpmWithPolicyVersion(v int64) bpmValueModifier {
+	return func(i *v1alpha1.BucketPolicyMember) {
+		i.Spec.ForProvider.PolicyVersion = &v
+	}
+}
+
+func TestBucketPolicyMemberPolicyVersion(t *testing.T) {
+	condition := &iamv1alpha1.Expr{Title: gcp.StringPtr("expirable access"), Expression: "request.time < timestamp(\"2020-10-01T00:00:00.000Z\")"}
+	withCondition := func(i *v1alpha1.BucketPolicyMember) { i.Spec.ForProvider.Condition = condition }
+
+	type want struct {
+		requested string
+		set       int64
+		err       error
+	}
+	cases := map[string]struct {
+		reason string
+		mg     *v1alpha1.BucketPolicyMember
+		want   want
+	}{
+		"Default": {
+			reason: "The policy of a BucketPolicyMember without a policy version should be requested and set in version 3.",
+			mg:     BucketPolicyMember(),
+			want:   want{requested: "3", set: iamv1alpha1.PolicyVersion},
+		},
+		"ExplicitV1": {
+			reason: "The policy of a BucketPolicyMember should be requested and set in its policy version.",
+			mg:     BucketPolicyMember(bpmWithPolicyVersion(1)),
+			want:   want{requested: "1", set: 1},
+		},
+		"ConditionRequiresV3": {
+			reason: "A conditional BucketPolicyMember whose policy version does not support conditions should be rejected without calling the GCP API.",
+			mg:     BucketPolicyMember(bpmWithPolicyVersion(1), withCondition),
+			want:   want{err: errors.Errorf("policy version %d does not support conditional bindings: set the policy version to 3, or remove the condition", 1)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer r.Body.Close()
+				switch r.Method {
+				case http.MethodGet:
+					if diff := cmp.Diff(tc.want.requested, r.URL.Query().Get("optionsRequestedPolicyVersion")); diff != "" {
+						t.Errorf("\n%s\nGetIamPolicy(...): -want requested policy version, +got:\n%s", tc.reason, diff)
+					}
+				case http.MethodPut:
+					i := &storagev1.Policy{}
+					_ = json.NewDecoder(r.Body).Decode(i)
+					if diff := cmp.Diff(tc.want.set, i.Version); diff != "" {
+						t.Errorf("\n%s\nSetIamPolicy(...): -want policy version, +got:\n%s", tc.reason, diff)
+					}
+				}
+				if tc.want.err != nil {
+					t.Errorf("\n%s\nCreate(...): unexpected call to the GCP API", tc.reason)
+				}
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(&storagev1.Policy{})
+			}))
+			defer server.Close()
+			s, _ := storagev1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			e := &bucketPolicyMemberExternal{bucketpolicy: storagev1.NewBucketsService(s), record: &bpmRecorder{}}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCreate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func bpmWithRemoveExpired() bpmValueModifier {
+	return func(i *v1alpha1.BucketPolicyMember) {
+		if i.ObjectMeta.Annotations == nil {
+			i.ObjectMeta.Annotations = make(map[string]string)
+		}
+		i.ObjectMeta.Annotations[gcp.AnnotationKeyRemoveExpired] = "true"
+	}
+}
+
+func bpmWithExpiry(at string) bpmValueModifier {
+	return func(i *v1alpha1.BucketPolicyMember) {
+		i.Spec.ForProvider.Condition = &iamv1alpha1.Expr{
+			Title:      gcp.StringPtr("expirable-access"),
+			Expression: "request.time < timestamp(\"" + at + "\")",
+		}
+	}
+}
+
+func bpmWithPolicyCondition(c *iamv1alpha1.Expr) bpmValueModifier {
+	return func(i *v1alpha1.BucketPolicyMember) { i.Spec.ForProvider.Condition = c }
+}
+
+func TestBucketPolicyMemberReconcile(t *testing.T) {
+	type want struct {
+		calls        map[string]int
+		condition    xpv1.ConditionType
+		reason       xpv1.ConditionReason
+		externalName string
+		bindings     []*storagev1.PolicyBindings
+	}
+	cases := map[string]struct {
+		reason    string
+		mg        *v1alpha1.BucketPolicyMember
+		policies  map[string]*storagev1.Policy
+		rejectSet int
+		want      want
+	}{
+		"PublicAccessBlocked": {
+			reason: "A binding that is refused because it would make the bucket public should be reported by the conditions the resource is stored with.",
+			mg:     BucketPolicyMember(bpmWithExternalNameAnnotation(""), bpmWithMember("allUsers")),
+			want: want{
+				calls:     map[string]int{http.MethodGet: 1},
+				condition: gcp.TypeIAMPolicyError,
+				reason:    gcp.ReasonPublicAccessBlocked,
+			},
+		},
+		"PermissionDenied": {
+			reason:    "A binding that cannot be created because setting the policy is forbidden should be reported by the ReconcileError condition the resource is stored with.",
+			mg:        BucketPolicyMember(bpmWithExternalNameAnnotation("")),
+			rejectSet: http.StatusForbidden,
+			want: want{
+				calls:     map[string]int{http.MethodGet: 2, http.MethodPut: 1},
+				condition: xpv1.TypeSynced,
+				reason:    xpv1.ReasonReconcileError,
+			},
+		},
+		"DryRun": {
+			reason: "A binding that is planned rather than applied should be reported by the conditions the resource is stored with.",
+			mg:     BucketPolicyMember(bpmWithExternalNameAnnotation(""), bpmWithDryRun()),
+			want: want{
+				calls:     map[string]int{http.MethodGet: 1},
+				condition: gcp.TypeDryRun,
+				reason:    gcp.ReasonChangePlanned,
+			},
+		},
+		"DomainNotAllowed": {
+			reason: "A binding that is refused because the domain of its member is not allowed should be reported by the conditions the resource is stored with.",
+			mg:     BucketPolicyMember(bpmWithExternalNameAnnotation(""), bpmWithMember("user:attacker@evil.com")),
+			want: want{
+				calls:     map[string]int{http.MethodGet: 1},
+				condition: gcp.TypeIAMPolicyError,
+				reason:    gcp.ReasonDomainNotAllowed,
+			},
+		},
+		"RoleChanged": {
+			reason:   "A binding whose role was changed after it was named should be rebound and renamed rather than fail to be observed.",
+			mg:       BucketPolicyMember(bpmWithExternalNameAnnotation(testBucketName + "/roles/storage.objectViewer/" + testMember)),
+			policies: bpmPolicies(&storagev1.PolicyBindings{Role: "roles/storage.objectViewer", Members: []string{testMember}}),
+			want: want{
+				calls:        map[string]int{http.MethodGet: 3, http.MethodPut: 2},
+				condition:    xpv1.TypeSynced,
+				reason:       xpv1.ReasonReconcileSuccess,
+				externalName: testBucketName + "/" + testRole + "/" + testMember,
+				bindings:     []*storagev1.PolicyBindings{{Role: testRole, Members: []string{testMember}}},
+			},
+		},
+		"RoleNotFound": {
+			reason: "A binding that is refused because its custom role does not exist should be reported by the conditions the resource is stored with.",
+			mg:     BucketPolicyMember(bpmWithExternalNameAnnotation(""), bpmWithRole("projects/my-project/roles/myRole"), bpmWithCheckCustomRole()),
+			want: want{
+				calls:     map[string]int{http.MethodGet: 1},
+				condition: gcp.TypeIAMPolicyError,
+				reason:    gcp.ReasonRoleNotFound,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := runtime.NewScheme()
+			if err := v1alpha1.SchemeBuilder.AddToScheme(s); err != nil {
+				t.Fatalf("AddToScheme(...): %s", err)
+			}
+			// The resource is stored with the conditions of a previous
+			// reconcile, which the managed reconciler reads again after
+			// Create.
+			tc.mg.SetConditions(xpv1.Creating())
+			kube := kubefake.NewClientBuilder().WithScheme(s).WithObjects(tc.mg).Build()
+			policies := tc.policies
+			if policies == nil {
+				policies = bpmPolicies()
+			}
+			buckets, store := bpmBuckets(t, nil, policies)
+			if tc.rejectSet != 0 {
+				store.RejectSet(testBucketName, tc.rejectSet)
+			}
+			// No custom role exists.
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{"error": map[string]interface{}{"code": http.StatusNotFound, "message": "role not found"}})
+			}))
+			defer server.Close()
+			roles, err := iamv1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			if err != nil {
+				t.Fatalf("NewService(...): %s", err)
+			}
+			e := &bucketPolicyMemberExternal{kube: kube, bucketpolicy: buckets, roles: roles, allowedDomains: []string{"example.com", "my-project.iam.gserviceaccount.com"}, record: &bpmRecorder{}}
+			r := managed.NewReconciler(&xpfake.Manager{Client: kube, Scheme: s},
+				resource.ManagedKind(v1alpha1.BucketPolicyMemberGroupVersionKind),
+				managed.WithExternalConnecter(managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+					return e, nil
+				})),
+				managed.WithInitializers())
+			nn := types.NamespacedName{Name: tc.mg.GetName()}
+			_, _ = r.Reconcile(context.Background(), reconcile.Request{NamespacedName: nn})
+
+			got := &v1alpha1.BucketPolicyMember{}
+			if err := kube.Get(context.Background(), nn, got); err != nil {
+				t.Fatalf("Get(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want.reason, got.GetCondition(tc.want.condition).Reason); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want %s reason, +got:\n%s", tc.reason, tc.want.condition, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, store.Calls()); diff != "" {
+				t.Errorf("\n%s\nReconcile(...): -want calls to the GCP API, +got:\n%s", tc.reason, diff)
+			}
+			if tc.want.externalName != "" {
+				if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(got)); diff != "" {
+					t.Errorf("\n%s\nReconcile(...): -want external name, +got:\n%s", tc.reason, diff)
+				}
+			}
+			if tc.want.bindings != nil {
+				if diff := cmp.Diff(tc.want.bindings, bpmBindings(store)); diff != "" {
+					t.Errorf("\n%s\nReconcile(...): -want bindings, +got:\n%s", tc.reason, diff)
+				}
 			}
 		})
 	}
@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	gcp "github.com/crossplane/provider-gcp/pkg/clients"
+)
+
+// Methods of the GCP API whose latency is observed.
+const (
+	methodGetIAMPolicy = "GetIamPolicy"
+	methodSetIAMPolicy = "SetIamPolicy"
+	methodGetRole      = "GetRole"
+)
+
+// Classes of errors setting an IAM policy.
+const (
+	errorClassConflict         = "Conflict"
+	errorClassPermissionDenied = "PermissionDenied"
+	errorClassNotFound         = "NotFound"
+	errorClassMalformed        = "Malformed"
+	errorClassRetryable        = "Retryable"
+	errorClassOther            = "Other"
+)
+
+// defaultIAMMetrics are the IAM metrics of the controllers of this package.
+// They are registered with the controller-runtime registry, which is served
+// by the metrics endpoint of the provider.
+var defaultIAMMetrics = newIAMMetrics(metrics.Registry)
+
+// iamMetrics count the outcomes of reconciling the bindings of bucket IAM
+// policies, labelled by the kind of managed resource reconciled. A nil
+// *iamMetrics counts nothing.
+type iamMetrics struct {
+	bindingsAdded   *prometheus.CounterVec
+	bindingsRemoved *prometheus.CounterVec
+	noopObserves    *prometheus.CounterVec
+	setPolicyErrors *prometheus.CounterVec
+	apiLatency      *prometheus.HistogramVec
+}
+
+// newIAMMetrics returns IAM metrics registered with the supplied registerer.
+func newIAMMetrics(r prometheus.Registerer) *iamMetrics {
+	m := &iamMetrics{
+		bindingsAdded: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gcp_storage_iam_bindings_added_total",
+			Help: "Number of bucket IAM policy changes that bound a role to members.",
+		}, []string{"kind"}),
+		bindingsRemoved: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gcp_storage_iam_bindings_removed_total",
+			Help: "Number of bucket IAM policy changes that unbound a role from members.",
+		}, []string{"kind"}),
+		noopObserves: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gcp_storage_iam_noop_observes_total",
+			Help: "Number of observations that found a binding to be up to date.",
+		}, []string{"kind"}),
+		setPolicyErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "gcp_storage_iam_set_policy_errors_total",
+			Help: "Number of failures to set a bucket IAM policy, by class of error.",
+		}, []string{"kind", "class"}),
+		apiLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "gcp_storage_iam_api_call_duration_seconds",
+			Help:    "Latency of calls to the bucket IAM policy methods of the GCP API.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"kind", "method"}),
+	}
+	r.MustRegister(m.bindingsAdded, m.bindingsRemoved, m.noopObserves, m.setPolicyErrors, m.apiLatency)
+	return m
+}
+
+// added counts a change that bound a role to members.
+func (m *iamMetrics) added(kind string) {
+	if m == nil {
+		return
+	}
+	m.bindingsAdded.WithLabelValues(kind).Inc()
+}
+
+// removed counts a change that unbound a role from members.
+func (m *iamMetrics) removed(kind string) {
+	if m == nil {
+		return
+	}
+	m.bindingsRemoved.WithLabelValues(kind).Inc()
+}
+
+// noop counts an observation that found a binding to be up to date.
+func (m *iamMetrics) noop(kind string) {
+	if m == nil {
+		return
+	}
+	m.noopObserves.WithLabelValues(kind).Inc()
+}
+
+// call makes the supplied call to the supplied method of the GCP API and
+// observes its latency. An error setting a policy is counted by its class.
+func (m *iamMetrics) call(kind, method string, fn func() error) error {
+	if m == nil {
+		return fn()
+	}
+	start := time.Now()
+	err := fn()
+	m.apiLatency.WithLabelValues(kind, method).Observe(time.Since(start).Seconds())
+	if err != nil && method == methodSetIAMPolicy {
+		m.setPolicyErrors.WithLabelValues(kind, errorClass(err)).Inc()
+	}
+	return err
+}
+
+// errorClass returns the class of the supplied error of the GCP API.
+func errorClass(err error) string {
+	err = errors.Cause(err)
+	switch {
+	case gcp.IsErrorConflict(err):
+		return errorClassConflict
+	case gcp.IsErrorForbidden(err):
+		return errorClassPermissionDenied
+	case gcp.IsErrorNotFound(err):
+		return errorClassNotFound
+	case gcp.IsErrorBadRequest(err):
+		return errorClassMalformed
+	case gcp.IsErrorRetryable(err):
+		return errorClassRetryable
+	default:
+		return errorClassOther
+	}
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/api/option"
+	storagev1 "google.golang.org/api/storage/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-gcp/apis/storage/v1alpha1"
+)
+
+// iamCounts are the values of the IAM metrics of a kind of managed resource.
+type iamCounts struct {
+	added   float64
+	removed float64
+	noop    float64
+	errors  map[string]float64
+	calls   map[string]uint64
+}
+
+// countsOf returns the values of the supplied IAM metrics, which must be
+// registered with the supplied registry, for the supplied kind.
+func countsOf(t *testing.T, reg *prometheus.Registry, m *iamMetrics, kind string) iamCounts {
+	t.Helper()
+	c := iamCounts{
+		added:   testutil.ToFloat64(m.bindingsAdded.WithLabelValues(kind)),
+		removed: testutil.ToFloat64(m.bindingsRemoved.WithLabelValues(kind)),
+		noop:    testutil.ToFloat64(m.noopObserves.WithLabelValues(kind)),
+		errors:  map[string]float64{},
+		calls:   map[string]uint64{},
+	}
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather(): %s", err)
+	}
+	for _, f := range families {
+		for _, s := range f.GetMetric() {
+			labels := map[string]string{}
+			for _, l := range s.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			if labels["kind"] != kind {
+				continue
+			}
+			switch f.GetName() {
+			case "gcp_storage_iam_set_policy_errors_total":
+				c.errors[labels["class"]] = s.GetCounter().GetValue()
+			case "gcp_storage_iam_api_call_duration_seconds":
+				c.calls[labels["method"]] = s.GetHistogram().GetSampleCount()
+			}
+		}
+	}
+	return c
+}
+
+func TestErrorClass(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want string
+	}{
+		"Conflict":         {err: gError(http.StatusPreconditionFailed, ""), want: errorClassConflict},
+		"PermissionDenied": {err: gError(http.StatusForbidden, ""), want: errorClassPermissionDenied},
+		"NotFound":         {err: gError(http.StatusNotFound, ""), want: errorClassNotFound},
+		"Malformed":        {err: gError(http.StatusBadRequest, ""), want: errorClassMalformed},
+		"Retryable":        {err: gError(http.StatusServiceUnavailable, ""), want: errorClassRetryable},
+		"Wrapped":          {err: errors.Wrap(gError(http.StatusForbidden, ""), errSetPolicy), want: errorClassPermissionDenied},
+		"Other":            {err: errors.New("boom"), want: errorClassOther},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, errorClass(tc.err)); diff != "" {
+				t.Errorf("errorClass(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBucketPolicyMemberMetrics(t *testing.T) {
+	other := "group:another-member@example.com"
+
+	cases := map[string]struct {
+		handler   http.Handler
+		conflicts wait.Backoff
+		reconcile func(ctx context.Context, e *bucketPolicyMemberExternal) error
+		want      iamCounts
+	}{
+		"NoopObserve": {
+			handler: setMembers(t, []string{testMember}, nil),
+			reconcile: func(ctx context.Context, e *bucketPolicyMemberExternal) error {
+				_, err := e.Observe(ctx, BucketPolicyMember())
+				return err
+			},
+			want: iamCounts{
+				noop:   1,
+				errors: map[string]float64{},
+				calls:  map[string]uint64{methodGetIAMPolicy: 1},
+			},
+		},
+		"BindingAdded": {
+			handler: setMembers(t, []string{other}, []string{other, testMember}),
+			reconcile: func(ctx context.Context, e *bucketPolicyMemberExternal) error {
+				_, err := e.Create(ctx, BucketPolicyMember())
+				return err
+			},
+			want: iamCounts{
+				added:  1,
+				errors: map[string]float64{},
+				calls:  map[string]uint64{methodGetIAMPolicy: 1, methodSetIAMPolicy: 1},
+			},
+		},
+		"BindingRemoved": {
+			handler: setMembers(t, []string{testMember, other}, []string{other}),
+			reconcile: func(ctx context.Context, e *bucketPolicyMemberExternal) error {
+				return e.Delete(ctx, BucketPolicyMember())
+			},
+			want: iamCounts{
+				removed: 1,
+				errors:  map[string]float64{},
+				calls:   map[string]uint64{methodGetIAMPolicy: 1, methodSetIAMPolicy: 1},
+			},
+		},
+		"PermissionDenied": {
+			handler: setPolicyRejected(http.StatusForbidden, "{}\n"),
+			reconcile: func(ctx context.Context, e *bucketPolicyMemberExternal) error {
+				_, err := e.Create(ctx, BucketPolicyMember())
+				return err
+			},
+			want: iamCounts{
+				errors: map[string]float64{errorClassPermissionDenied: 1},
+				calls:  map[string]uint64{methodGetIAMPolicy: 1, methodSetIAMPolicy: 1},
+			},
+		},
+		"ConflictsRetried": {
+			handler:   setPolicyRejected(http.StatusPreconditionFailed, "{}\n"),
+			conflicts: wait.Backoff{Duration: time.Millisecond, Steps: 3},
+			reconcile: func(ctx context.Context, e *bucketPolicyMemberExternal) error {
+				_, err := e.Create(ctx, BucketPolicyMember())
+				return err
+			},
+			want: iamCounts{
+				errors: map[string]float64{errorClassConflict: 3},
+				calls:  map[string]uint64{methodGetIAMPolicy: 3, methodSetIAMPolicy: 3},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := storagev1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			reg := prometheus.NewRegistry()
+			m := newIAMMetrics(reg)
+			e := &bucketPolicyMemberExternal{
+				kube:         &test.MockClient{MockStatusUpdate: test.NewMockStatusUpdateFn(nil)},
+				bucketpolicy: storagev1.NewBucketsService(s),
+				record:       &bpmRecorder{},
+				conflicts:    tc.conflicts,
+				metrics:      m,
+			}
+			_ = tc.reconcile(context.Background(), e)
+			got := countsOf(t, reg, m, v1alpha1.BucketPolicyMemberKind)
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(iamCounts{})); diff != "" {
+				t.Errorf("metrics: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBucketPolicyBindingMetrics(t *testing.T) {
+	members := []string{testMember, "group:another-member@example.com"}
+
+	cases := map[string]struct {
+		handler   http.Handler
+		reconcile func(ctx context.Context, e *bucketPolicyBindingExternal) error
+		want      iamCounts
+	}{
+		"NoopObserve": {
+			handler: setMembers(t, members, nil),
+			reconcile: func(ctx context.Context, e *bucketPolicyBindingExternal) error {
+				_, err := e.Observe(ctx, BucketPolicyBinding())
+				return err
+			},
+			want: iamCounts{
+				noop:   1,
+				errors: map[string]float64{},
+				calls:  map[string]uint64{methodGetIAMPolicy: 1},
+			},
+		},
+		"BindingAdded": {
+			handler: setMembers(t, nil, members),
+			reconcile: func(ctx context.Context, e *bucketPolicyBindingExternal) error {
+				_, err := e.Create(ctx, BucketPolicyBinding())
+				return err
+			},
+			want: iamCounts{
+				added:  1,
+				errors: map[string]float64{},
+				calls:  map[string]uint64{methodGetIAMPolicy: 1, methodSetIAMPolicy: 1},
+			},
+		},
+		"BindingRemoved": {
+			handler: setMembers(t, append(members, "user:jane@example.com"), []string{"user:jane@example.com"}),
+			reconcile: func(ctx context.Context, e *bucketPolicyBindingExternal) error {
+				return e.Delete(ctx, BucketPolicyBinding())
+			},
+			want: iamCounts{
+				removed: 1,
+				errors:  map[string]float64{},
+				calls:   map[string]uint64{methodGetIAMPolicy: 1, methodSetIAMPolicy: 1},
+			},
+		},
+		"MalformedPolicy": {
+			handler: setPolicyRejected(http.StatusBadRequest, "{}\n"),
+			reconcile: func(ctx context.Context, e *bucketPolicyBindingExternal) error {
+				_, err := e.Create(ctx, BucketPolicyBinding())
+				return err
+			},
+			want: iamCounts{
+				errors: map[string]float64{errorClassMalformed: 1},
+				calls:  map[string]uint64{methodGetIAMPolicy: 1, methodSetIAMPolicy: 1},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			s, _ := storagev1.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithoutAuthentication())
+			reg := prometheus.NewRegistry()
+			m := newIAMMetrics(reg)
+			e := &bucketPolicyBindingExternal{bucketpolicy: storagev1.NewBucketsService(s), metrics: m}
+			_ = tc.reconcile(context.Background(), e)
+			got := countsOf(t, reg, m, v1alpha1.BucketPolicyBindingKind)
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(iamCounts{})); diff != "" {
+				t.Errorf("metrics: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}